@@ -0,0 +1,260 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+// Package breaking compares two versions of an OpenAPI specification and reports the changes that break clients
+// (removed paths and operations, narrowed enums, new required fields, changed types) as results of rules, so they
+// can be rendered by any report vacuum has, and used to gate releases on API compatibility.
+package breaking
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/daveshanley/vacuum/locator"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pb33f/libopenapi/index"
+	whatChanged "github.com/pb33f/libopenapi/what-changed/model"
+	"gopkg.in/yaml.v3"
+)
+
+// CategoryBreaking is the category of every breaking change rule.
+const CategoryBreaking = "breaking"
+
+// The rules changes are reported against.
+const (
+	RuleRemoved        = "breaking-removed"
+	RuleEnumNarrowed   = "breaking-enum-narrowed"
+	RuleRequiredAdded  = "breaking-required-added"
+	RuleTypeChanged    = "breaking-type-changed"
+	RuleBreakingChange = "breaking-change"
+	RuleChange         = "api-change"
+)
+
+const documentationURL = "https://pb33f.io/libopenapi/what-changed/"
+
+// CompareRequest is the two versions of a specification to compare, the new version is the one results are
+// reported against. The configs (like where references are resolved from) are optional.
+type CompareRequest struct {
+	OldSpec            []byte
+	NewSpec            []byte
+	OldFile            string
+	NewFile            string
+	IncludeNonBreaking bool // report changes that don't break clients too, as information.
+	OldConfig          *datamodel.DocumentConfiguration
+	NewConfig          *datamodel.DocumentConfiguration
+}
+
+// Report is the changes found between two versions of a specification.
+type Report struct {
+	ResultSet     *model.RuleResultSet
+	TotalChanges  int
+	BreakingCount int
+	SpecInfo      *datamodel.SpecInfo // of the new version, to prepare the result set for serialization.
+}
+
+var (
+	buildRules sync.Once
+	category   *model.RuleCategory
+	rules      map[string]*model.Rule
+)
+
+// Rules returns the rules breaking changes are reported against, by id.
+func Rules() map[string]*model.Rule {
+	buildRules.Do(func() {
+		// reports find the category through the results, it is not one of the built-in categories.
+		category = &model.RuleCategory{
+			Id:   CategoryBreaking,
+			Name: "Breaking Changes",
+			Description: "Breaking changes are changes between two versions of a specification that stop existing " +
+				"clients from working, like removing an operation, or requiring something that was optional.",
+			Order: 100,
+		}
+		rule := func(id, description, severity, howToFix string) *model.Rule {
+			return &model.Rule{
+				Id:               id,
+				Description:      description,
+				Severity:         severity,
+				Type:             model.CategoryValidation,
+				RuleCategory:     category,
+				Recommended:      true,
+				HowToFix:         howToFix,
+				DocumentationURL: documentationURL,
+				Tags:             []string{CategoryBreaking},
+			}
+		}
+		rules = map[string]*model.Rule{
+			RuleRemoved: rule(RuleRemoved, "Paths, operations, parameters, properties and responses must not be removed",
+				model.SeverityError, "Deprecate what is no longer needed, and remove it in a new major version."),
+			RuleEnumNarrowed: rule(RuleEnumNarrowed, "Values must not be removed from an enum", model.SeverityError,
+				"Keep accepting the values clients already send, or release a new major version."),
+			RuleRequiredAdded: rule(RuleRequiredAdded, "Parameters and properties must not become required",
+				model.SeverityError, "Make new parameters and properties optional, with a default."),
+			RuleTypeChanged: rule(RuleTypeChanged, "The type of a schema must not change", model.SeverityError,
+				"Add a new property with the new type, and deprecate the old one."),
+			RuleBreakingChange: rule(RuleBreakingChange, "Changes must not break existing clients", model.SeverityError,
+				"Revert the change, or release a new major version."),
+			RuleChange: rule(RuleChange, "A change that doesn't break existing clients", model.SeverityInfo, ""),
+		}
+	})
+	return rules
+}
+
+// Compare compares two versions of a specification, every breaking change is an error. Both versions must be
+// the same kind of specification (OpenAPI 3, or Swagger).
+func Compare(req *CompareRequest) (*Report, error) {
+	if req == nil {
+		return nil, errors.New("there is nothing to compare")
+	}
+	oldDoc, err := newDocument(req.OldSpec, req.OldConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read '%s': %w", req.OldFile, err)
+	}
+	newDoc, err := newDocument(req.NewSpec, req.NewConfig)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read '%s': %w", req.NewFile, err)
+	}
+	changes, errs := libopenapi.CompareDocuments(oldDoc, newDoc)
+	if changes == nil && len(errs) > 0 {
+		return nil, fmt.Errorf("unable to compare '%s' and '%s': %w", req.OldFile, req.NewFile, errors.Join(errs...))
+	}
+
+	oldLocator := locator.NewDocument(oldDoc.GetSpecInfo().RootNode, req.OldFile)
+	newLocator := locator.NewDocument(newDoc.GetSpecInfo().RootNode, req.NewFile)
+	r := Rules()
+
+	report := &Report{SpecInfo: newDoc.GetSpecInfo()}
+	var results []model.RuleFunctionResult
+	if changes != nil {
+		for _, change := range changes.GetAllChanges() {
+			report.TotalChanges++
+			if change.Breaking {
+				report.BreakingCount++
+			} else if !req.IncludeNonBreaking {
+				continue
+			}
+			result := model.RuleFunctionResult{Rule: r[ruleFor(change)], Message: message(change)}
+			result.RuleId = result.Rule.Id
+			result.RuleSeverity = result.Rule.Severity
+			locate(&result, change, oldLocator, newLocator)
+			results = append(results, result)
+		}
+	}
+	report.ResultSet = model.NewRuleResultSet(results)
+	report.ResultSet.SortResultsByLineNumber()
+	return report, nil
+}
+
+func newDocument(spec []byte, config *datamodel.DocumentConfiguration) (libopenapi.Document, error) {
+	if config == nil {
+		return libopenapi.NewDocument(spec)
+	}
+	return libopenapi.NewDocumentWithConfiguration(spec, config)
+}
+
+// ruleFor returns the id of the rule a change breaks.
+func ruleFor(change *whatChanged.Change) string {
+	if !change.Breaking {
+		return RuleChange
+	}
+	switch {
+	case change.Property == "enum":
+		return RuleEnumNarrowed
+	case change.Property == "required":
+		return RuleRequiredAdded
+	case change.Property == "type":
+		return RuleTypeChanged
+	case change.ChangeType == whatChanged.ObjectRemoved || change.ChangeType == whatChanged.PropertyRemoved:
+		return RuleRemoved
+	}
+	return RuleBreakingChange
+}
+
+func message(change *whatChanged.Change) string {
+	name := change.Property
+	switch change.ChangeType {
+	case whatChanged.ObjectRemoved, whatChanged.PropertyRemoved:
+		if change.Property == "enum" {
+			return fmt.Sprintf("enum value '%s' was removed", change.Original)
+		}
+		if change.Original != "" && change.Original != change.Property {
+			return fmt.Sprintf("%s '%s' was removed", name, change.Original)
+		}
+		return fmt.Sprintf("'%s' was removed", name)
+	case whatChanged.ObjectAdded, whatChanged.PropertyAdded:
+		if change.Property == "required" {
+			if change.New == "true" {
+				return "it is now required"
+			}
+			return fmt.Sprintf("'%s' is now required", change.New)
+		}
+		if change.New != "" && change.New != change.Property {
+			return fmt.Sprintf("%s '%s' was added", name, change.New)
+		}
+		return fmt.Sprintf("'%s' was added", name)
+	}
+	return fmt.Sprintf("%s changed from '%s' to '%s'", name, change.Original, change.New)
+}
+
+// locate points a result at where a change is in the new version. Something that was removed isn't there anymore,
+// so the result points at the closest thing that contained it, and the message says where it was.
+func locate(result *model.RuleFunctionResult, change *whatChanged.Change, oldDoc, newDoc *locator.Document) {
+	ctx := change.Context
+	if ctx == nil {
+		return
+	}
+	if ctx.DocumentLocation != "" {
+		// the change is in another file, reached through a reference.
+		origin := &index.NodeOrigin{AbsoluteLocation: ctx.DocumentLocation}
+		if ctx.NewLine != nil {
+			origin.Line, origin.Column = *ctx.NewLine, *ctx.NewColumn
+		} else if ctx.OriginalLine != nil {
+			origin.Line, origin.Column = *ctx.OriginalLine, *ctx.OriginalColumn
+		}
+		result.Origin = origin
+		return
+	}
+	if ctx.NewLine != nil && ctx.NewColumn != nil {
+		if path, node, err := newDoc.PathAt(*ctx.NewLine, *ctx.NewColumn); err == nil {
+			result.Path, result.StartNode, result.EndNode = path, node, node
+			return
+		}
+	}
+	if ctx.OriginalLine == nil || ctx.OriginalColumn == nil {
+		return
+	}
+	result.Message = fmt.Sprintf("%s (it was at %s:%d:%d)", result.Message, oldDoc.File,
+		*ctx.OriginalLine, *ctx.OriginalColumn)
+	path, _, err := oldDoc.PathAt(*ctx.OriginalLine, *ctx.OriginalColumn)
+	if err != nil {
+		return
+	}
+	result.Path = path
+	result.StartNode = closest(newDoc, path)
+	result.EndNode = result.StartNode
+}
+
+// closest returns the node of the deepest part of a path that is in a document.
+func closest(doc *locator.Document, path string) *yaml.Node {
+	segments := locator.Segments(path)
+	for i := len(segments) - 1; i > 0; i-- {
+		parent := "$"
+		for _, s := range segments[:i] {
+			if strings.Contains(s, "'") {
+				parent += `["` + s + `"]`
+			} else {
+				parent += "['" + s + "']"
+			}
+		}
+		if key, value, err := doc.Lookup(parent); err == nil {
+			if key != nil {
+				return key
+			}
+			return value
+		}
+	}
+	return nil
+}
@@ -0,0 +1,148 @@
+package breaking
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+var oldSpec = `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          schema:
+            type: integer
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /owners:
+    get:
+      responses:
+        "200":
+          description: ok
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name]
+      properties:
+        name:
+          type: string
+        kind:
+          type: string
+          enum: [cat, dog, fish]
+        age:
+          type: integer
+`
+
+var newSpec = `openapi: 3.1.0
+info:
+  title: pets
+  version: 2.0.0
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      parameters:
+        - name: limit
+          in: query
+          required: true
+          schema:
+            type: string
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      required: [name, kind]
+      properties:
+        name:
+          type: string
+        kind:
+          type: string
+          enum: [cat, dog]
+        age:
+          type: string
+`
+
+func compare(t *testing.T, includeNonBreaking bool) *Report {
+	report, err := Compare(&CompareRequest{OldSpec: []byte(oldSpec), NewSpec: []byte(newSpec),
+		OldFile: "old.yaml", NewFile: "new.yaml", IncludeNonBreaking: includeNonBreaking})
+	assert.NoError(t, err)
+	return report
+}
+
+func TestCompare(t *testing.T) {
+	report := compare(t, false)
+	assert.Equal(t, 7, report.TotalChanges)
+	assert.Equal(t, 6, report.BreakingCount)
+	assert.Len(t, report.ResultSet.Results, 6)
+	assert.Equal(t, 6, report.ResultSet.ErrorCount)
+
+	byRule := make(map[string][]*model.RuleFunctionResult)
+	for _, r := range report.ResultSet.Results {
+		assert.Equal(t, CategoryBreaking, r.Rule.RuleCategory.Id)
+		byRule[r.Rule.Id] = append(byRule[r.Rule.Id], r)
+	}
+	assert.Len(t, byRule[RuleRemoved], 1)
+	assert.Len(t, byRule[RuleEnumNarrowed], 1)
+	assert.Len(t, byRule[RuleRequiredAdded], 2)
+	assert.Len(t, byRule[RuleTypeChanged], 2)
+
+	enum := byRule[RuleEnumNarrowed][0]
+	assert.Equal(t, "enum value 'fish' was removed (it was at old.yaml:36:28)", enum.Message)
+	assert.Equal(t, "$.components.schemas.Pet.properties.kind.enum[2]", enum.Path)
+	assert.Equal(t, 32, enum.StartNode.Line) // the enum, in the new version.
+
+	removed := byRule[RuleRemoved][0]
+	assert.Equal(t, "$.paths['/owners']", removed.Path)
+	assert.Equal(t, 5, removed.StartNode.Line) // paths, in the new version.
+
+	for _, r := range byRule[RuleTypeChanged] {
+		assert.Equal(t, "type changed from 'integer' to 'string'", r.Message)
+	}
+	assert.Equal(t, "$.components.schemas.Pet.properties.age.type", byRule[RuleTypeChanged][1].Path)
+	assert.Equal(t, 34, byRule[RuleTypeChanged][1].StartNode.Line)
+}
+
+func TestCompare_IncludeNonBreaking(t *testing.T) {
+	report := compare(t, true)
+	assert.Len(t, report.ResultSet.Results, 7)
+	assert.Equal(t, 1, report.ResultSet.InfoCount)
+	assert.Equal(t, "version changed from '1.0.0' to '2.0.0'", report.ResultSet.Results[0].Message)
+	assert.Equal(t, "$.info.version", report.ResultSet.Results[0].Path)
+}
+
+func TestCompare_NoChanges(t *testing.T) {
+	report, err := Compare(&CompareRequest{OldSpec: []byte(oldSpec), NewSpec: []byte(oldSpec)})
+	assert.NoError(t, err)
+	assert.Zero(t, report.TotalChanges)
+	assert.Empty(t, report.ResultSet.Results)
+}
+
+func TestCompare_DifferentVersions(t *testing.T) {
+	_, err := Compare(&CompareRequest{OldSpec: []byte("swagger: '2.0'\ninfo:\n  title: a\n  version: 1\npaths: {}"),
+		NewSpec: []byte(newSpec), OldFile: "old.yaml", NewFile: "new.yaml"})
+	assert.Error(t, err)
+
+	_, err = Compare(nil)
+	assert.Error(t, err)
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+func GetBaselineCommand() *cobra.Command {
+
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "baseline",
+		Short:        "Generate a baseline of existing findings, to be ignored by future linting runs",
+		Long: "Generate a baseline file from the current linting results of a specification. When the baseline is " +
+			"passed to the lint command using '--baseline', only findings that are not in the baseline are reported. " +
+			"The default filename is 'vacuum-baseline.json' located in the working directory.",
+		Example: "vacuum baseline my-awesome-spec.yaml <vacuum-baseline.json>",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			switch len(args) {
+			case 0:
+				return []string{"yaml", "yml", "json"}, cobra.ShellCompDirectiveFilterFileExt
+			case 1:
+				return []string{"json"}, cobra.ShellCompDirectiveFilterFileExt
+			default:
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+			baseFlag, _ := cmd.Flags().GetString("base")
+			skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
+			timeoutFlag, _ := cmd.Flags().GetInt("timeout")
+			hardModeFlag, _ := cmd.Flags().GetBool("hard-mode")
+			remoteFlag, _ := cmd.Flags().GetBool("remote")
+			ignoreFile, _ := cmd.Flags().GetString("ignore-file")
+			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+			functionsFlag, _ := cmd.Flags().GetString("functions")
+
+			// Certificate/TLS configuration
+			certFile, _ := cmd.Flags().GetString("cert-file")
+			keyFile, _ := cmd.Flags().GetString("key-file")
+			caFile, _ := cmd.Flags().GetString("ca-file")
+			insecure, _ := cmd.Flags().GetBool("insecure")
+
+			if noStyleFlag {
+				pterm.DisableColor()
+				pterm.DisableStyling()
+			}
+
+			PrintBanner()
+
+			if len(args) == 0 {
+				errText := "please supply an OpenAPI specification to generate a baseline"
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			baselineOutput := "vacuum-baseline.json"
+			if len(args) > 1 {
+				baselineOutput = args[1]
+			}
+
+			specBytes, fileError := os.ReadFile(args[0])
+			if fileError != nil {
+				pterm.Error.Printf("Unable to read file '%s': %s\n", args[0], fileError.Error())
+				pterm.Println()
+				return fileError
+			}
+
+			ignoredItems := model.IgnoredItems{}
+			if ignoreFile != "" {
+				raw, ferr := os.ReadFile(ignoreFile)
+				if ferr != nil {
+					return fmt.Errorf("failed to read ignore file: %w", ferr)
+				}
+				ferr = yaml.Unmarshal(raw, &ignoredItems)
+				if ferr != nil {
+					return fmt.Errorf("failed to parse ignore file: %w", ferr)
+				}
+			}
+
+			customFunctions, _ := LoadCustomFunctions(functionsFlag, false)
+
+			resultSet, _, err := BuildResultsWithDocCheckSkip(false, hardModeFlag, rulesetFlag, specBytes, customFunctions,
+				baseFlag, remoteFlag, skipCheckFlag, time.Duration(timeoutFlag)*time.Second, utils.HTTPClientConfig{
+					CertFile: certFile,
+					KeyFile:  keyFile,
+					CAFile:   caFile,
+					Insecure: insecure,
+				}, ignoredItems)
+			if err != nil {
+				pterm.Error.Printf("Failed to generate baseline: %v\n\n", err)
+				return err
+			}
+
+			data, _ := json.MarshalIndent(utils.BuildBaseline(resultSet.Results), "", "  ")
+			err = os.WriteFile(baselineOutput, data, 0664)
+			if err != nil {
+				pterm.Error.Printf("Unable to write baseline file: '%s': %s\n", baselineOutput, err.Error())
+				pterm.Println()
+				return err
+			}
+
+			pterm.Success.Printf("Baseline of %d results generated for '%s', written to '%s'\n",
+				len(resultSet.Results), args[0], baselineOutput)
+			pterm.Println()
+			return nil
+		},
+	}
+	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	cmd.Flags().String("ignore-file", "", "Path to ignore file")
+	return cmd
+}
@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetBaselineCommand(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "baseline.json")
+	cmd := GetBaselineCommand()
+	cmd.SetArgs([]string{"../model/test_files/burgershop.openapi.yaml", out})
+	assert.NoError(t, cmd.Execute())
+
+	baseline, err := utils.ReadBaselineFile(out)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, baseline.Results)
+
+	// linting against the baseline must not report, or fail on, anything that already exists.
+	lint := GetLintCommand()
+	lint.SetArgs([]string{"-x", "--fail-severity", "info", "--baseline", out, "../model/test_files/burgershop.openapi.yaml"})
+	assert.NoError(t, lint.Execute())
+}
+
+func TestGetBaselineCommand_NoSpec(t *testing.T) {
+	cmd := GetBaselineCommand()
+	cmd.SetArgs([]string{})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestGetLintCommand_BaselineMissing(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{"--baseline", "nope.json", "../model/test_files/burgershop.openapi.yaml"})
+	assert.Error(t, cmd.Execute())
+}
@@ -0,0 +1,177 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/daveshanley/vacuum/breaking"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/utils"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// BreakingFormats are the formats breaking changes can be rendered in, instead of the console output.
+var BreakingFormats = []string{FormatSARIF, FormatGitLab, FormatCheckstyle, FormatGitHub, FormatMarkdown,
+	FormatJUnit, FormatJSON, FormatHTML, FormatTAP}
+
+// GetBreakingCommand returns a cobra command that compares two versions of a specification, and reports the
+// changes that break existing clients.
+func GetBreakingCommand() *cobra.Command {
+
+	cmd := &cobra.Command{
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Use:           "breaking",
+		Short:         "Report the breaking changes between two versions of an OpenAPI specification",
+		Long: "Compare an old and a new version of an OpenAPI specification, and report the changes that break existing " +
+			"clients: removed paths, operations, parameters and properties, values removed from enums, new required " +
+			"parameters and properties, and changed types. Breaking changes are errors of rules in the 'breaking' " +
+			"category, so they can be rendered in any report format (like JUnit, SARIF or HTML), and the command " +
+			"fails if there are any, which can be used as an API compatibility gate.",
+		Example: "vacuum breaking old.yaml new.yaml\nvacuum breaking old.yaml new.yaml --format junit > breaking.xml",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) < 2 {
+				return []string{"yaml", "yml", "json"}, cobra.ShellCompDirectiveFilterFileExt
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+			failSeverityFlag, _ := cmd.Flags().GetString("fail-severity")
+			formatFlag, _ := cmd.Flags().GetString("format")
+			allFlag, _ := cmd.Flags().GetBool("all")
+			noClipFlag, _ := cmd.Flags().GetBool("no-clip")
+			remoteFlag, _ := cmd.Flags().GetBool("remote")
+
+			if noStyleFlag {
+				pterm.DisableColor()
+				pterm.DisableStyling()
+			}
+
+			if formatFlag != "" && !slices.Contains(BreakingFormats, formatFlag) {
+				errText := fmt.Sprintf("unknown format '%s', supported formats are %v", formatFlag, BreakingFormats)
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			if formatFlag == "" {
+				PrintBanner()
+			}
+
+			if len(args) != 2 {
+				errText := "please supply an old and a new version of a specification to compare"
+				pterm.Error.Println(errText)
+				pterm.Println()
+				pterm.Println("Usage: vacuum breaking <old-openapi-spec.yaml> <new-openapi-spec.yaml>")
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			logger, logErr := BuildLogger(cmd, slog.LevelError)
+			if logErr != nil {
+				pterm.Error.Println(logErr.Error())
+				pterm.Println()
+				return logErr
+			}
+
+			req := &breaking.CompareRequest{OldFile: args[0], NewFile: args[1], IncludeNonBreaking: allFlag}
+			var err error
+			if req.OldSpec, err = os.ReadFile(args[0]); err != nil {
+				pterm.Error.Printf("Unable to read file '%s': %s\n", args[0], err.Error())
+				pterm.Println()
+				return err
+			}
+			if req.NewSpec, err = os.ReadFile(args[1]); err != nil {
+				pterm.Error.Printf("Unable to read file '%s': %s\n", args[1], err.Error())
+				pterm.Println()
+				return err
+			}
+			req.OldConfig = breakingDocumentConfig(args[0], remoteFlag, logger)
+			req.NewConfig = breakingDocumentConfig(args[1], remoteFlag, logger)
+
+			start := time.Now()
+			report, err := breaking.Compare(req)
+			if err != nil {
+				pterm.Error.Println(err.Error())
+				pterm.Println()
+				return err
+			}
+			resultSet := report.ResultSet
+			errs, warnings, informs := resultSet.GetErrorCount(), resultSet.GetWarnCount(), resultSet.GetInfoCount()
+
+			if formatFlag != "" {
+				resultSet.PrepareForSerialization(report.SpecInfo)
+				lintReq := utils.LintFileRequest{Format: formatFlag, FileName: args[1], FailSeverityFlag: failSeverityFlag}
+				var renderErr error
+				if IsAggregatedFormat(formatFlag) {
+					renderErr = RenderAggregatedReport(formatFlag, []*vacuum_report.FileReport{
+						{FileName: args[1], ResultSet: resultSet, Spec: req.NewSpec},
+					}, start, JUnitConfigForRequest(lintReq), "", "")
+				} else {
+					renderErr = RenderFormattedReport(lintReq, resultSet, nil)
+				}
+				if renderErr != nil {
+					return renderErr
+				}
+				return CheckFailureSeverity(failSeverityFlag, errs, warnings, informs)
+			}
+
+			if len(resultSet.Results) > 0 {
+				specData := strings.Split(string(req.NewSpec), "\n")
+				renderResultsTable(resultSet.Results, specData, false, false, false, false, true, noClipFlag, args[1])
+				pterm.Println()
+			}
+			if report.BreakingCount == 0 {
+				pterm.Success.Printf("No breaking changes, %d changes between '%s' and '%s'\n",
+					report.TotalChanges, args[0], args[1])
+			} else {
+				pterm.Info.Printf("%d changes between '%s' and '%s', %d breaking\n",
+					report.TotalChanges, args[0], args[1], report.BreakingCount)
+			}
+			pterm.Println()
+
+			if err = CheckFailureSeverity(failSeverityFlag, errs, warnings, informs); err != nil {
+				pterm.Error.Printf("Breaking changes detected, %s\n", err.Error())
+				pterm.Println()
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	cmd.Flags().StringP("fail-severity", "n", model.SeverityError, "Fail if changes are at or above this severity (error, warn, info, none)")
+	cmd.Flags().String("format", "", fmt.Sprintf("Render changes in a machine-readable format instead of the console output %v", BreakingFormats))
+	cmd.Flags().Bool("all", false, "Report changes that don't break clients too, as information")
+	cmd.Flags().Bool("no-clip", false, "Do not truncate messages or paths (no '...')")
+
+	if regErr := cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(BreakingFormats,
+		cobra.ShellCompDirectiveNoFileComp)); regErr != nil {
+		panic(regErr)
+	}
+	return cmd
+}
+
+// breakingDocumentConfig resolves the references of a specification from the directory it's in.
+func breakingDocumentConfig(file string, remote bool, logger *slog.Logger) *datamodel.DocumentConfiguration {
+	return &datamodel.DocumentConfiguration{
+		BasePath:                filepath.Dir(file),
+		ExtractRefsSequentially: true,
+		AllowFileReferences:     true,
+		AllowRemoteReferences:   remote,
+		Logger:                  logger,
+	}
+}
@@ -0,0 +1,94 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var breakingOldSpec = `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: kind
+          in: query
+          schema:
+            type: string
+            enum: [cat, dog]
+      responses:
+        "200":
+          description: ok
+  /owners:
+    get:
+      responses:
+        "200":
+          description: ok
+`
+
+var breakingNewSpec = `openapi: 3.1.0
+info:
+  title: pets
+  version: 1.1.0
+paths:
+  /pets:
+    get:
+      parameters:
+        - name: kind
+          in: query
+          schema:
+            type: string
+            enum: [cat]
+      responses:
+        "200":
+          description: ok
+`
+
+func writeBreakingSpecs(t *testing.T) (string, string) {
+	dir := t.TempDir()
+	oldSpec, newSpec := filepath.Join(dir, "old.yaml"), filepath.Join(dir, "new.yaml")
+	assert.NoError(t, os.WriteFile(oldSpec, []byte(breakingOldSpec), 0664))
+	assert.NoError(t, os.WriteFile(newSpec, []byte(breakingNewSpec), 0664))
+	return oldSpec, newSpec
+}
+
+func newTestBreakingCommand(args ...string) error {
+	cmd := GetBreakingCommand()
+	cmd.PersistentFlags().BoolP("remote", "u", true, "")
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+func TestGetBreakingCommand(t *testing.T) {
+	oldSpec, newSpec := writeBreakingSpecs(t)
+	assert.Error(t, newTestBreakingCommand(oldSpec, newSpec))
+	assert.Error(t, newTestBreakingCommand("--all", oldSpec, newSpec))
+
+	// the other way around only adds things.
+	assert.NoError(t, newTestBreakingCommand(newSpec, oldSpec))
+	assert.NoError(t, newTestBreakingCommand(oldSpec, oldSpec))
+
+	// breaking changes can be allowed.
+	assert.NoError(t, newTestBreakingCommand("--fail-severity", "none", oldSpec, newSpec))
+}
+
+func TestGetBreakingCommand_Formats(t *testing.T) {
+	oldSpec, newSpec := writeBreakingSpecs(t)
+	for _, format := range BreakingFormats {
+		assert.Error(t, newTestBreakingCommand("--format", format, oldSpec, newSpec), format)
+		assert.NoError(t, newTestBreakingCommand("--format", format, newSpec, oldSpec), format)
+	}
+	assert.Error(t, newTestBreakingCommand("--format", "nope", oldSpec, newSpec))
+}
+
+func TestGetBreakingCommand_BadArgs(t *testing.T) {
+	oldSpec, _ := writeBreakingSpecs(t)
+	assert.Error(t, newTestBreakingCommand(oldSpec))
+	assert.Error(t, newTestBreakingCommand(oldSpec, "nope.yaml"))
+	assert.Error(t, newTestBreakingCommand("nope.yaml", oldSpec))
+}
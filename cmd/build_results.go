@@ -10,6 +10,7 @@ import (
 	"github.com/pterm/pterm"
 	"net/http"
 	"os"
+	"path/filepath"
 	"strings"
 	"time"
 )
@@ -65,6 +66,10 @@ func BuildResultsWithDocCheckSkip(
 		}
 	}
 
+	if rulesetFlag == "" {
+		selectedRS = SelectRuleSetForSpec(specBytes, selectedRS, defaultRuleSets.GenerateAsyncAPIRecommendedRuleSet())
+	}
+
 	// if ruleset has been supplied, lets make sure it exists, then load it in
 	// and see if it's valid. If so - let's go!
 	if rulesetFlag != "" {
@@ -79,7 +84,9 @@ func BuildResultsWithDocCheckSkip(
 			}
 		}
 
-		if strings.HasPrefix(rulesetFlag, "http") {
+		if builtIn := rulesets.GenerateBuiltInRuleSet(rulesetFlag, defaultRuleSets); builtIn != nil {
+			selectedRS = builtIn
+		} else if strings.HasPrefix(rulesetFlag, "http") {
 			// Handle remote ruleset URL
 			if !remote {
 				return nil, nil, fmt.Errorf("remote ruleset specified but remote flag is disabled (use --remote=true or -u=true)")
@@ -89,7 +96,12 @@ func BuildResultsWithDocCheckSkip(
 			if rsErr != nil {
 				return nil, nil, rsErr
 			}
-			selectedRS = defaultRuleSets.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(downloadedRS, httpClient)
+			selectedRS, rsErr = checkRemoteRuleSets(func() *rulesets.RuleSet {
+				return defaultRuleSets.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(downloadedRS, httpClient)
+			})
+			if rsErr != nil {
+				return nil, nil, rsErr
+			}
 		} else {
 			// Handle local ruleset file
 			rsBytes, rsErr := os.ReadFile(rulesetFlag)
@@ -100,6 +112,11 @@ func BuildResultsWithDocCheckSkip(
 			if rsErr != nil {
 				return nil, nil, rsErr
 			}
+			selectedRS.OverridesDir = filepath.Dir(rulesetFlag)
+			customFunctions, rsErr = LoadRuleSetFunctions(rulesetFlag, selectedRS, customFunctions, silent)
+			if rsErr != nil {
+				return nil, nil, rsErr
+			}
 		}
 
 		// Merge OWASP rules if hard mode is enabled
@@ -127,7 +144,9 @@ func BuildResultsWithDocCheckSkip(
 	})
 
 	resultSet := model.NewRuleResultSet(ruleset.Results)
+	resultSet.RuleSetCategories = selectedRS.Categories
 	resultSet.SortResultsByLineNumber()
 	resultSet.Results = utils.FilterIgnoredResultsPtr(resultSet.Results, ignoredItems)
+	resultSet.SetSuppressedResults(ruleset.Suppressed)
 	return resultSet, ruleset, nil
 }
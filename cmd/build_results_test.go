@@ -5,6 +5,7 @@ import (
 	"github.com/daveshanley/vacuum/utils"
 	"github.com/stretchr/testify/assert"
 	"testing"
+	"time"
 )
 
 func TestBuildResults(t *testing.T) {
@@ -16,3 +17,33 @@ func TestBuildResults_SkipCheck(t *testing.T) {
 	_, _, err := BuildResultsWithDocCheckSkip(false, false, "nuggets", nil, nil, "", true, true, 5, utils.HTTPClientConfig{}, model.IgnoredItems{})
 	assert.Error(t, err)
 }
+
+func TestBuildResults_SpectralFunctions(t *testing.T) {
+	spec := []byte(`openapi: 3.1.0
+tags:
+  - name: pizza
+  - name: cake
+paths:
+  /pizza:
+    get:
+      summary: Get pizza
+    post:
+      description: no summary`)
+
+	results, _, err := BuildResults(true, false, "../rulesets/examples/spectral-functions-ruleset.yaml", spec,
+		nil, "", false, 10*time.Second, utils.HTTPClientConfig{}, model.IgnoredItems{})
+	assert.NoError(t, err)
+	assert.Len(t, results.Results, 2)
+	for _, r := range results.Results {
+		switch r.Rule.Id {
+		case "spectral-operation-summary":
+			assert.Equal(t, "Operation is missing a summary.", r.Message)
+			assert.Equal(t, 10, r.StartNode.Line)
+		case "spectral-tag-names":
+			assert.Equal(t, "Tag name `pizza` is not allowed.", r.Message)
+			assert.Equal(t, "$.tags.name", r.Path)
+		default:
+			t.Errorf("unexpected result: %s", r.Rule.Id)
+		}
+	}
+}
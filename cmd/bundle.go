@@ -100,19 +100,12 @@ func GetBundleCommand() *cobra.Command {
 			}
 
 			// setup logging
-			handler := pterm.NewSlogHandler(&pterm.Logger{
-				Formatter: pterm.LogFormatterColorful,
-				Writer:    os.Stdout,
-				Level:     pterm.LogLevelWarn,
-				ShowTime:  false,
-				MaxWidth:  280,
-				KeyStyles: map[string]pterm.Style{
-					"error":  *pterm.NewStyle(pterm.FgRed, pterm.Bold),
-					"err":    *pterm.NewStyle(pterm.FgRed, pterm.Bold),
-					"caller": *pterm.NewStyle(pterm.FgGray, pterm.Bold),
-				},
-			})
-			logger := slog.New(handler)
+			logger, logErr := BuildLogger(cmd, slog.LevelWarn)
+			if logErr != nil {
+				pterm.Error.Println(logErr.Error())
+				pterm.Println()
+				return logErr
+			}
 			docConfig := &datamodel.DocumentConfiguration{
 				BasePath:                baseFlag,
 				ExtractRefsSequentially: true,
@@ -0,0 +1,479 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/publish"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/statistics"
+	"github.com/daveshanley/vacuum/utils"
+	"github.com/fsnotify/fsnotify"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// DaemonResultPayload is the body pushed to the configured webhook every time a watched specification is linted.
+type DaemonResultPayload struct {
+	Source     string                    `json:"source" yaml:"source"`                             // file path or URL of the spec
+	Generated  time.Time                 `json:"generated" yaml:"generated"`                       // when the lint completed
+	Statistics *reports.ReportStatistics `json:"statistics,omitempty" yaml:"statistics,omitempty"` // score and counts
+	ResultSet  *model.RuleResultSet      `json:"resultSet" yaml:"resultSet"`                       // all the results
+}
+
+// daemonState holds everything the daemon needs to lint a spec and push the results.
+type daemonState struct {
+	ruleSet         *rulesets.RuleSet
+	customFunctions map[string]model.RuleFunction
+	publisher       *publish.WebhookPublisher
+	client          *http.Client
+	base            string
+	remote          bool
+	skipCheck       bool
+	timeout         time.Duration
+	httpConfig      utils.HTTPClientConfig
+	ignoredItems    model.IgnoredItems
+	lock            sync.Mutex
+}
+
+func GetDaemonCommand() *cobra.Command {
+
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "daemon",
+		Short:        "Run vacuum as a long-running API quality monitor",
+		Long: "Watch directories and/or poll registry URLs for OpenAPI specification changes. Every time a " +
+			"specification changes it is linted, and the results are pushed to a webhook as JSON.",
+		Example: "vacuum daemon --watch ./specs --poll https://registry.example.com/api.yaml --webhook https://hooks.example.com/vacuum",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			watchDirs, _ := cmd.Flags().GetStringArray("watch")
+			pollURLs, _ := cmd.Flags().GetStringArray("poll")
+			interval, _ := cmd.Flags().GetInt("interval")
+			webhook, _ := cmd.Flags().GetString("webhook")
+			webhookHeaders, _ := cmd.Flags().GetStringArray("webhook-header")
+			debounce, _ := cmd.Flags().GetInt("debounce")
+			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+			ignoreFile, _ := cmd.Flags().GetString("ignore-file")
+
+			baseFlag, _ := cmd.Flags().GetString("base")
+			skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
+			timeoutFlag, _ := cmd.Flags().GetInt("timeout")
+			hardModeFlag, _ := cmd.Flags().GetBool("hard-mode")
+			remoteFlag, _ := cmd.Flags().GetBool("remote")
+			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+			functionsFlag, _ := cmd.Flags().GetString("functions")
+
+			// Certificate/TLS configuration
+			certFile, _ := cmd.Flags().GetString("cert-file")
+			keyFile, _ := cmd.Flags().GetString("key-file")
+			caFile, _ := cmd.Flags().GetString("ca-file")
+			insecure, _ := cmd.Flags().GetBool("insecure")
+
+			if noStyleFlag {
+				pterm.DisableColor()
+				pterm.DisableStyling()
+			}
+
+			if len(watchDirs) == 0 && len(pollURLs) == 0 {
+				errText := "please supply at least one directory to watch (--watch) or a URL to poll (--poll)"
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+			if webhook == "" {
+				errText := "please supply a webhook URL to push results to (--webhook)"
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			httpClientConfig := utils.HTTPClientConfig{
+				CertFile: certFile,
+				KeyFile:  keyFile,
+				CAFile:   caFile,
+				Insecure: insecure,
+			}
+			httpClient := &http.Client{Timeout: 30 * time.Second}
+			if utils.ShouldUseCustomHTTPClient(httpClientConfig) {
+				var clientErr error
+				httpClient, clientErr = utils.CreateCustomHTTPClient(httpClientConfig)
+				if clientErr != nil {
+					pterm.Error.Printf("Failed to create custom HTTP client: %s\n", clientErr.Error())
+					return clientErr
+				}
+			}
+
+			publisher, pErr := newReportPublisher(webhook, webhookHeaders, "", publish.DefaultWebhookRetries,
+				httpClientConfig)
+			if pErr != nil {
+				return pErr
+			}
+
+			ignoredItems := model.IgnoredItems{}
+			if ignoreFile != "" {
+				raw, ferr := os.ReadFile(ignoreFile)
+				if ferr != nil {
+					return fmt.Errorf("failed to read ignore file: %w", ferr)
+				}
+				ferr = yaml.Unmarshal(raw, &ignoredItems)
+				if ferr != nil {
+					return fmt.Errorf("failed to read ignore file: %w", ferr)
+				}
+			}
+
+			defaultRuleSets := rulesets.BuildDefaultRuleSets()
+			selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
+			if hardModeFlag {
+				selectedRS = defaultRuleSets.GenerateOpenAPIDefaultRuleSet()
+				for k, v := range rulesets.GetAllOWASPRules() {
+					selectedRS.Rules[k] = v
+				}
+			}
+			if rulesetFlag != "" {
+				var rsErr error
+				selectedRS, rsErr = BuildRuleSetFromUserSuppliedLocation(rulesetFlag, defaultRuleSets, remoteFlag, httpClient)
+				if rsErr != nil {
+					pterm.Error.Printf("Unable to load ruleset '%s': %s\n", rulesetFlag, rsErr.Error())
+					pterm.Println()
+					return rsErr
+				}
+				MergeOWASPRulesToRuleSet(selectedRS, hardModeFlag)
+			}
+			customFunctions, _ := LoadCustomFunctions(functionsFlag, true)
+			customFunctions, rfErr := LoadRuleSetFunctions(rulesetFlag, selectedRS, customFunctions, true)
+			if rfErr != nil {
+				return rfErr
+			}
+
+			state := &daemonState{
+				ruleSet:         selectedRS,
+				customFunctions: customFunctions,
+				publisher:       publisher,
+				client:          httpClient,
+				base:            baseFlag,
+				remote:          remoteFlag,
+				skipCheck:       skipCheckFlag,
+				timeout:         time.Duration(timeoutFlag) * time.Second,
+				httpConfig:      httpClientConfig,
+				ignoredItems:    ignoredItems,
+			}
+
+			pterm.Info.Printf("vacuum daemon started, linting against %d rules and pushing results to '%s'\n",
+				len(selectedRS.Rules), webhook)
+			pterm.Println()
+
+			stop := make(chan struct{})
+			var wg sync.WaitGroup
+
+			if len(watchDirs) > 0 {
+				watcher, wErr := fsnotify.NewWatcher()
+				if wErr != nil {
+					pterm.Error.Printf("Unable to create file watcher: %s\n", wErr.Error())
+					return wErr
+				}
+				defer watcher.Close()
+				for _, dir := range watchDirs {
+					if aErr := addWatchDirectories(watcher, dir); aErr != nil {
+						pterm.Error.Printf("Unable to watch directory '%s': %s\n", dir, aErr.Error())
+						return aErr
+					}
+					pterm.Info.Printf("Watching directory '%s'\n", dir)
+				}
+				wg.Add(2)
+				go func() {
+					defer wg.Done()
+					state.watchFiles(watcher, time.Duration(debounce)*time.Millisecond, stop)
+				}()
+				go func() {
+					defer wg.Done()
+					state.lintDirectories(watchDirs, stop)
+				}()
+			}
+
+			for _, u := range pollURLs {
+				pterm.Info.Printf("Polling '%s' every %d seconds\n", u, interval)
+				wg.Add(1)
+				go func(u string) {
+					defer wg.Done()
+					state.pollURL(u, time.Duration(interval)*time.Second, stop)
+				}(u)
+			}
+
+			signals := make(chan os.Signal, 1)
+			signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+			<-signals
+			pterm.Info.Println("vacuum daemon shutting down")
+			close(stop)
+			wg.Wait()
+			return nil
+		},
+	}
+	cmd.Flags().StringArray("watch", nil, "Directory to watch for specification changes (repeatable)")
+	cmd.Flags().StringArray("poll", nil, "URL of a specification to poll for changes (repeatable)")
+	cmd.Flags().Int("interval", 60, "Polling interval for remote specifications, in seconds")
+	cmd.Flags().String("webhook", "", "Webhook URL to POST lint results to")
+	cmd.Flags().StringArray("webhook-header", nil, "Header to send with each webhook request, e.g. 'Authorization: Bearer xyz' (repeatable)")
+	cmd.Flags().Int("debounce", 500, "Time to wait for file changes to settle before linting, in milliseconds")
+	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	cmd.Flags().String("ignore-file", "", "Path to ignore file")
+	return cmd
+}
+
+// ParseHeaderFlags converts a slice of 'Name: Value' strings into a header map.
+func ParseHeaderFlags(headers []string) (map[string]string, error) {
+	parsed := make(map[string]string)
+	for _, h := range headers {
+		name, value, found := strings.Cut(h, ":")
+		if !found || strings.TrimSpace(name) == "" {
+			return nil, fmt.Errorf("header '%s' is not valid, it must be in the format 'Name: Value'", h)
+		}
+		parsed[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return parsed, nil
+}
+
+// PushResultsToWebhook will POST a DaemonResultPayload as JSON to the webhook of the publisher, failed requests are
+// tried again just like a report posted by 'lint --post-report'.
+func PushResultsToWebhook(publisher *publish.WebhookPublisher, payload *DaemonResultPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	attempts, err := publisher.Publish(body)
+	if err != nil {
+		return fmt.Errorf("webhook '%s' failed (%d attempts): %w", publisher.URL, attempts, err)
+	}
+	return nil
+}
+
+// lintAndPush lints the supplied specification bytes and pushes the results to the webhook.
+func (d *daemonState) lintAndPush(source string, specBytes []byte) {
+	payload := d.lintSpec(source, specBytes)
+	if payload == nil {
+		return
+	}
+	if err := PushResultsToWebhook(d.publisher, payload); err != nil {
+		pterm.Error.Printf("Unable to push results for '%s': %s\n", source, err.Error())
+		return
+	}
+	score := 0
+	if payload.Statistics != nil {
+		score = payload.Statistics.OverallScore
+	}
+	pterm.Success.Printf("Linted '%s' (score: %d, errors: %d, warnings: %d), results pushed\n", source, score,
+		payload.ResultSet.GetErrorCount(), payload.ResultSet.GetWarnCount())
+}
+
+// lintSpec runs the configured ruleset against a specification and builds a payload ready to push.
+func (d *daemonState) lintSpec(source string, specBytes []byte) *DaemonResultPayload {
+	// rule-sets are shared, so only lint one spec at a time.
+	d.lock.Lock()
+	defer d.lock.Unlock()
+
+	specFileName := ""
+	if !strings.HasPrefix(source, "http") {
+		specFileName = source
+	}
+	result := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet:           d.ruleSet,
+		Spec:              specBytes,
+		SpecFileName:      specFileName,
+		SpecDisplayName:   source,
+		CustomFunctions:   d.customFunctions,
+		Base:              d.base,
+		AllowLookup:       d.remote,
+		SkipDocumentCheck: d.skipCheck,
+		SilenceLogs:       true,
+		Timeout:           d.timeout,
+		HTTPClientConfig:  d.httpConfig,
+	})
+	if len(result.Errors) > 0 {
+		for _, err := range result.Errors {
+			pterm.Error.Printf("Unable to process spec '%s', error: %s\n", source, err.Error())
+		}
+		return nil
+	}
+
+	resultSet := model.NewRuleResultSet(result.Results)
+	resultSet.RuleSetCategories = d.ruleSet.Categories
+	resultSet.Results = utils.FilterIgnoredResultsPtr(resultSet.Results, d.ignoredItems)
+	resultSet.SortResultsByLineNumber()
+	resultSet.PrepareForSerialization(result.SpecInfo)
+
+	return &DaemonResultPayload{
+		Source:     source,
+		Generated:  time.Now(),
+		Statistics: statistics.CreateReportStatistics(result.Index, result.SpecInfo, resultSet),
+		ResultSet:  resultSet,
+	}
+}
+
+// lintDirectories lints every specification in the watched directories once, so results are pushed for every spec
+// when the daemon starts, not only after a spec changes.
+func (d *daemonState) lintDirectories(dirs []string, stop chan struct{}) {
+	for _, dir := range dirs {
+		_ = filepath.WalkDir(dir, func(path string, entry os.DirEntry, err error) error {
+			select {
+			case <-stop:
+				return filepath.SkipAll
+			default:
+			}
+			if err != nil || entry.IsDir() || !isSpecFile(path) {
+				return nil
+			}
+			specBytes, rErr := os.ReadFile(path)
+			if rErr != nil {
+				pterm.Error.Printf("Unable to read file '%s': %s\n", path, rErr.Error())
+				return nil
+			}
+			d.lintAndPush(path, specBytes)
+			return nil
+		})
+	}
+}
+
+// watchFiles listens for file system events and lints any specification that changes, once things settle down.
+func (d *daemonState) watchFiles(watcher *fsnotify.Watcher, debounce time.Duration, stop chan struct{}) {
+	timers := make(map[string]*time.Timer)
+	for {
+		select {
+		case <-stop:
+			for _, t := range timers {
+				t.Stop()
+			}
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Has(fsnotify.Create) {
+				if fi, err := os.Stat(event.Name); err == nil && fi.IsDir() {
+					_ = addWatchDirectories(watcher, event.Name)
+					continue
+				}
+			}
+			if !event.Has(fsnotify.Write) && !event.Has(fsnotify.Create) {
+				continue
+			}
+			if !isSpecFile(event.Name) {
+				continue
+			}
+			name := event.Name
+			if t, ok := timers[name]; ok {
+				t.Stop()
+			}
+			timers[name] = time.AfterFunc(debounce, func() {
+				specBytes, err := os.ReadFile(name)
+				if err != nil {
+					pterm.Error.Printf("Unable to read file '%s': %s\n", name, err.Error())
+					return
+				}
+				d.lintAndPush(name, specBytes)
+			})
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			pterm.Error.Printf("File watcher error: %s\n", err.Error())
+		}
+	}
+}
+
+// pollURL fetches a remote specification on an interval, and lints it every time the content changes.
+func (d *daemonState) pollURL(u string, interval time.Duration, stop chan struct{}) {
+	var lastDigest, etag string
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		specBytes, newEtag, err := fetchRemoteSpec(d.client, u, etag, nil)
+		if err != nil {
+			pterm.Error.Printf("Unable to fetch '%s': %s\n", u, err.Error())
+		} else if specBytes != nil {
+			etag = newEtag
+			sum := sha256.Sum256(specBytes)
+			digest := hex.EncodeToString(sum[:])
+			if digest != lastDigest {
+				lastDigest = digest
+				d.lintAndPush(u, specBytes)
+			}
+		}
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// fetchRemoteSpec downloads a specification, using an ETag to avoid downloading it again if nothing has changed.
+// if the remote spec has not been modified, nil bytes are returned.
+func fetchRemoteSpec(client *http.Client, u, etag string, headers map[string]string) ([]byte, string, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, "", err
+	}
+	req.Header.Set("User-Agent", fmt.Sprintf("vacuum/%s", Version))
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, etag, nil
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return nil, "", fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", err
+	}
+	return b, resp.Header.Get("ETag"), nil
+}
+
+// addWatchDirectories adds a directory and all the directories below it to the watcher.
+func addWatchDirectories(watcher *fsnotify.Watcher, root string) error {
+	return filepath.WalkDir(root, func(path string, d os.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return watcher.Add(path)
+		}
+		return nil
+	})
+}
+
+func isSpecFile(name string) bool {
+	switch strings.ToLower(filepath.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
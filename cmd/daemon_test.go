@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/publish"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseHeaderFlags(t *testing.T) {
+	h, err := ParseHeaderFlags([]string{"Authorization: Bearer 123", "X-Team:platform"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer 123", h["Authorization"])
+	assert.Equal(t, "platform", h["X-Team"])
+}
+
+func TestParseHeaderFlags_Invalid(t *testing.T) {
+	_, err := ParseHeaderFlags([]string{"nope"})
+	assert.Error(t, err)
+}
+
+func TestPushResultsToWebhook(t *testing.T) {
+	var received DaemonResultPayload
+	var auth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth = r.Header.Get("Authorization")
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &received)
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	d := &daemonState{
+		ruleSet: rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet(),
+		timeout: 5 * time.Second,
+	}
+	spec, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+	payload := d.lintSpec("burgershop.openapi.yaml", spec)
+	assert.NotNil(t, payload)
+
+	publisher := &publish.WebhookPublisher{Client: server.Client(), URL: server.URL, Token: "123"}
+	err := PushResultsToWebhook(publisher, payload)
+	assert.NoError(t, err)
+	assert.Equal(t, "Bearer 123", auth)
+	assert.Equal(t, "burgershop.openapi.yaml", received.Source)
+	assert.NotNil(t, received.Statistics)
+	assert.Len(t, received.ResultSet.Results, len(payload.ResultSet.Results))
+}
+
+func TestPushResultsToWebhook_BadStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	err := PushResultsToWebhook(&publish.WebhookPublisher{Client: server.Client(), URL: server.URL},
+		&DaemonResultPayload{Source: "test"})
+	assert.Error(t, err)
+}
+
+func TestDaemonState_LintDirectories(t *testing.T) {
+	var sources []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var received DaemonResultPayload
+		b, _ := io.ReadAll(r.Body)
+		_ = json.Unmarshal(b, &received)
+		sources = append(sources, received.Source)
+	}))
+	defer server.Close()
+
+	dir := t.TempDir()
+	spec, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "burgershop.yaml"), spec, 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("not a spec"), 0o644))
+
+	d := &daemonState{
+		ruleSet:   rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet(),
+		publisher: &publish.WebhookPublisher{Client: server.Client(), URL: server.URL},
+		timeout:   5 * time.Second,
+	}
+	d.lintDirectories([]string{dir}, make(chan struct{}))
+	assert.Equal(t, []string{filepath.Join(dir, "nested", "burgershop.yaml")}, sources)
+}
+
+func TestFetchRemoteSpec_ETag(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") == `"abc"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"abc"`)
+		_, _ = w.Write([]byte("openapi: 3.1.0"))
+	}))
+	defer server.Close()
+
+	b, etag, err := fetchRemoteSpec(server.Client(), server.URL, "", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, "openapi: 3.1.0", string(b))
+	assert.Equal(t, `"abc"`, etag)
+
+	b, etag, err = fetchRemoteSpec(server.Client(), server.URL, etag, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, b)
+	assert.Equal(t, `"abc"`, etag)
+}
+
+func TestGetDaemonCommand_NoWebhook(t *testing.T) {
+	cmd := GetDaemonCommand()
+	cmd.SetArgs([]string{"--watch", "."})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestGetDaemonCommand_NoSources(t *testing.T) {
+	cmd := GetDaemonCommand()
+	cmd.SetArgs([]string{"--webhook", "http://localhost"})
+	assert.Error(t, cmd.Execute())
+}
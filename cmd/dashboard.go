@@ -51,6 +51,13 @@ func GetDashboardCommand() *cobra.Command {
 			extensionRefsFlag, _ := cmd.Flags().GetBool("ext-refs")
 			remoteFlag, _ := cmd.Flags().GetBool("remote")
 			ignoreFile, _ := cmd.Flags().GetString("ignore-file")
+			sortFlag, _ := cmd.Flags().GetString("sort")
+			sortOrder, sortErr := model.ParseResultOrder(sortFlag)
+			if sortErr != nil {
+				pterm.Error.Println(sortErr.Error())
+				pterm.Println()
+				return sortErr
+			}
 
 			var err error
 			vacuumReport, specBytes, _ := vacuum_report.BuildVacuumReportFromFile(args[0])
@@ -149,11 +156,13 @@ func GetDashboardCommand() *cobra.Command {
 				return nil
 			}
 
+			resultSet.SortResults(sortOrder)
 			dash := cui.CreateDashboard(resultSet, specIndex, specInfo)
 			dash.Version = Version
 			return dash.Render()
 		},
 	}
 	cmd.Flags().String("ignore-file", "", "Path to ignore file")
+	cmd.Flags().String("sort", string(model.OrderByLine), fmt.Sprintf("Sort the violations of a rule by %v", model.ResultOrders))
 	return cmd
 }
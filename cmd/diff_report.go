@@ -0,0 +1,135 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func GetDiffReportCommand() *cobra.Command {
+
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "diff-report",
+		Short:        "Compare two vacuum reports and show what has changed",
+		Long: "Compare an old and a new vacuum report (generated by the 'report' command), listing findings that have " +
+			"been added and removed, and the change in score. If any added findings are at or above the fail severity, " +
+			"the command fails, which can be used as a 'no new lint errors' gate.",
+		Example: "vacuum diff-report old-report.json new-report.json",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			if len(args) < 2 {
+				return []string{"json", "gz", "zst", "br"}, cobra.ShellCompDirectiveFilterFileExt
+			}
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+			failSeverityFlag, _ := cmd.Flags().GetString("fail-severity")
+			jsonFlag, _ := cmd.Flags().GetBool("json")
+
+			if noStyleFlag {
+				pterm.DisableColor()
+				pterm.DisableStyling()
+			}
+
+			if !jsonFlag {
+				PrintBanner()
+			}
+
+			if len(args) != 2 {
+				errText := "please supply an old and a new vacuum report to compare"
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			oldReport, err := readVacuumReport(args[0])
+			if err != nil {
+				pterm.Error.Println(err.Error())
+				pterm.Println()
+				return err
+			}
+			newReport, err := readVacuumReport(args[1])
+			if err != nil {
+				pterm.Error.Println(err.Error())
+				pterm.Println()
+				return err
+			}
+
+			diff := vacuum_report.DiffReports(oldReport, newReport)
+			errs, warnings, informs := diff.CountBySeverity()
+
+			if jsonFlag {
+				data, _ := json.MarshalIndent(diff, "", "  ")
+				fmt.Println(string(data))
+				return CheckFailureSeverity(failSeverityFlag, errs, warnings, informs)
+			}
+
+			renderDiffResults("Added", diff.Added)
+			renderDiffResults("Removed", diff.Removed)
+
+			pterm.Info.Printf("%d added, %d removed, %d unchanged\n", len(diff.Added), len(diff.Removed), len(diff.Unchanged))
+			switch {
+			case diff.ScoreDelta > 0:
+				pterm.Success.Printf("Score improved by %d, from %d to %d\n", diff.ScoreDelta, diff.OldScore, diff.NewScore)
+			case diff.ScoreDelta < 0:
+				pterm.Warning.Printf("Score dropped by %d, from %d to %d\n", -diff.ScoreDelta, diff.OldScore, diff.NewScore)
+			default:
+				pterm.Info.Printf("Score unchanged at %d\n", diff.NewScore)
+			}
+			pterm.Println()
+
+			if err = CheckFailureSeverity(failSeverityFlag, errs, warnings, informs); err != nil {
+				pterm.Error.Printf("Regression detected, new findings %s\n", err.Error())
+				pterm.Println()
+				return err
+			}
+			return nil
+		},
+	}
+	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	cmd.Flags().StringP("fail-severity", "n", model.SeverityError, "Fail if added findings are at or above this severity (error, warn, info, none)")
+	cmd.Flags().Bool("json", false, "Render the diff as JSON to stdout")
+	return cmd
+}
+
+func readVacuumReport(path string) (*vacuum_report.VacuumReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read report '%s': %w", path, err)
+	}
+	vr, err := vacuum_report.CheckFileForVacuumReport(data)
+	if err != nil || vr == nil {
+		return nil, fmt.Errorf("'%s' is not a vacuum report", path)
+	}
+	return vr, nil
+}
+
+func renderDiffResults(title string, results []*model.RuleFunctionResult) {
+	if len(results) == 0 {
+		return
+	}
+	pterm.DefaultSection.Printf("%s (%d)", title, len(results))
+	tableData := pterm.TableData{{"Line", "Severity", "Rule", "Message", "Path"}}
+	for _, r := range results {
+		sev := r.RuleSeverity
+		if r.Rule != nil {
+			sev = r.Rule.Severity
+		}
+		tableData = append(tableData, []string{fmt.Sprint(r.Range.Start.Line), sev, r.RuleId, r.Message, r.Path})
+	}
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		pterm.Error.Printf("error rendering table '%v'", err.Error())
+	}
+	pterm.Println()
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeDiffTestReport(t *testing.T, name string, results ...*model.RuleFunctionResult) string {
+	vr := vacuum_report.VacuumReport{
+		Statistics: &reports.ReportStatistics{OverallScore: 50},
+		ResultSet:  &model.RuleResultSet{Results: results},
+	}
+	data, _ := json.Marshal(vr)
+	f := filepath.Join(t.TempDir(), name)
+	assert.NoError(t, os.WriteFile(f, data, 0664))
+	return f
+}
+
+func TestGetDiffReportCommand(t *testing.T) {
+	cmd := GetDiffReportCommand()
+	cmd.SetArgs([]string{"test_data/vacuum-report.json", "test_data/vacuum-report.json"})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetDiffReportCommand_Regression(t *testing.T) {
+	old := writeDiffTestReport(t, "old.json",
+		&model.RuleFunctionResult{RuleId: "a", Message: "one", RuleSeverity: model.SeverityWarn})
+	newer := writeDiffTestReport(t, "new.json",
+		&model.RuleFunctionResult{RuleId: "a", Message: "one", RuleSeverity: model.SeverityWarn},
+		&model.RuleFunctionResult{RuleId: "b", Message: "two", RuleSeverity: model.SeverityError})
+
+	cmd := GetDiffReportCommand()
+	cmd.SetArgs([]string{old, newer})
+	assert.Error(t, cmd.Execute())
+
+	// the other way around is an improvement.
+	cmd = GetDiffReportCommand()
+	cmd.SetArgs([]string{"--json", newer, old})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetDiffReportCommand_BadArgs(t *testing.T) {
+	cmd := GetDiffReportCommand()
+	cmd.SetArgs([]string{"test_data/vacuum-report.json"})
+	assert.Error(t, cmd.Execute())
+
+	cmd = GetDiffReportCommand()
+	cmd.SetArgs([]string{"test_data/vacuum-report.json", "../model/test_files/burgershop.openapi.yaml"})
+	assert.Error(t, cmd.Execute())
+}
@@ -7,11 +7,15 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"gopkg.in/yaml.v3"
 	"os"
+	"slices"
+	"sort"
+	"strings"
 )
 
 func GetGenerateRulesetCommand() *cobra.Command {
@@ -21,8 +25,12 @@ func GetGenerateRulesetCommand() *cobra.Command {
 		SilenceErrors: true,
 		Use:           "generate-ruleset",
 		Short:         "Generate a vacuum RuleSet",
-		Long:          "Generate a YAML ruleset containing 'all', or 'recommended' rules",
-		Example:       "vacuum generate-ruleset recommended | all <ruleset-output-name>",
+		Long: "Generate a YAML ruleset containing 'all', or 'recommended' rules. Use --profile (strict, recommended, " +
+			"security or minimal) to generate a ruleset to start from, or --interactive to pick the categories, tags and " +
+			"severities of the rules",
+		Example: "vacuum generate-ruleset recommended | all <ruleset-output-name>\n" +
+			"vacuum generate-ruleset --profile security <ruleset-output-name>\n" +
+			"vacuum generate-ruleset --interactive <ruleset-output-name>",
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			switch len(args) {
 			case 0:
@@ -37,6 +45,16 @@ func GetGenerateRulesetCommand() *cobra.Command {
 
 			PrintBanner()
 
+			profileFlag, _ := cmd.Flags().GetString("profile")
+			interactiveFlag, _ := cmd.Flags().GetBool("interactive")
+			if profileFlag != "" || interactiveFlag {
+				reportOutput := "ruleset"
+				if len(args) > 0 {
+					reportOutput = args[0]
+				}
+				return generateProfileRuleSet(profileFlag, interactiveFlag, reportOutput)
+			}
+
 			// check for file args
 			if len(args) < 1 {
 				errText := "please supply 'recommended', 'owasp' or 'all' and a file path to output the ruleset"
@@ -108,5 +126,136 @@ func GetGenerateRulesetCommand() *cobra.Command {
 			return nil
 		},
 	}
+	cmd.Flags().String("profile", "", "Generate a ruleset from a profile: strict, recommended, security or minimal")
+	cmd.Flags().BoolP("interactive", "i", false, "Pick the categories, tags and severities of the rules to enforce")
 	return cmd
 }
+
+// generateProfileRuleSet writes a ruleset generated from a profile, optionally changed interactively, to
+// '<output>-<profile>.yaml' ('<output>-custom.yaml' if changed interactively).
+func generateProfileRuleSet(profileName string, interactive bool, output string) error {
+	var profile *rulesets.RuleSetProfile
+	if profileName != "" {
+		if profile = rulesets.GetRuleSetProfile(profileName); profile == nil {
+			errText := fmt.Sprintf("unknown profile '%s', please use one of %s", profileName,
+				strings.Join(rulesets.RuleSetProfiles, ", "))
+			pterm.Error.Println(errText)
+			pterm.Println()
+			return errors.New(errText)
+		}
+	}
+	if interactive {
+		var err error
+		if profile, err = promptRuleSetProfile(profile); err != nil {
+			pterm.Error.Printf("Unable to generate RuleSet: %s\n", err.Error())
+			pterm.Println()
+			return err
+		}
+	}
+
+	selectedRuleSet := profile.GenerateRuleSet()
+	pterm.Info.Printf("Generating RuleSet: %s", selectedRuleSet.Description)
+	pterm.Println()
+
+	yamlBytes, _ := yaml.Marshal(selectedRuleSet)
+	reportOutputName := fmt.Sprintf("%s-%s.yaml", output, profile.Name)
+	if err := os.WriteFile(reportOutputName, yamlBytes, 0664); err != nil {
+		pterm.Error.Printf("Unable to write RuleSet file: '%s': %s\n", reportOutputName, err.Error())
+		pterm.Println()
+		return err
+	}
+
+	pterm.Success.Printf("RuleSet generated for '%s', written to '%s'\n", profile.Name, reportOutputName)
+	pterm.Println()
+	return nil
+}
+
+// the interactive prompts, tests replace these to answer them.
+var (
+	promptSelect = func(title string, options []string, defaultOption string) (string, error) {
+		return pterm.DefaultInteractiveSelect.WithOptions(options).WithDefaultOption(defaultOption).Show(title)
+	}
+	promptMultiselect = func(title string, options, defaults []string) ([]string, error) {
+		return pterm.DefaultInteractiveMultiselect.WithOptions(options).WithDefaultOptions(defaults).
+			WithFilter(false).Show(title + " (enter to select, tab to confirm)")
+	}
+)
+
+const keepRuleSeverity = "keep the rule severity"
+
+// promptRuleSetProfile asks which profile to start from (unless one has been picked already), which categories
+// and tags to enforce, and at which severity each category runs.
+func promptRuleSetProfile(profile *rulesets.RuleSetProfile) (*rulesets.RuleSetProfile, error) {
+	if profile == nil {
+		name, err := promptSelect("Start from a profile", rulesets.RuleSetProfiles, rulesets.ProfileRecommended)
+		if err != nil {
+			return nil, err
+		}
+		profile = rulesets.GetRuleSetProfile(name)
+	}
+
+	// only offer the categories and tags of rules the profile can pick.
+	rules := rulesets.ProfileRules()
+	var categories, pickedCategories, tags []string
+	for _, cat := range model.RuleCategoriesOrdered {
+		present, picked := false, false
+		for _, rule := range rules {
+			if rule.RuleCategory != nil && rule.RuleCategory.Id == cat.Id {
+				present = true
+				picked = picked || profile.Includes(rule)
+			}
+		}
+		if present {
+			categories = append(categories, cat.Id)
+		}
+		if picked {
+			pickedCategories = append(pickedCategories, cat.Id)
+		}
+	}
+	for _, rule := range rules {
+		for _, tag := range rule.Tags {
+			if !slices.Contains(tags, tag) {
+				tags = append(tags, tag)
+			}
+		}
+	}
+	sort.Strings(tags)
+
+	chosenCategories, err := promptMultiselect("Categories of rules to enforce", categories, pickedCategories)
+	if err != nil {
+		return nil, err
+	}
+	chosenTags, err := promptMultiselect("Only enforce rules with these tags (none for every rule)", tags, profile.Tags)
+	if err != nil {
+		return nil, err
+	}
+
+	custom := &rulesets.RuleSetProfile{
+		Name:        "custom",
+		Description: fmt.Sprintf("Started from the '%s' profile", profile.Name),
+		Recommended: profile.Recommended,
+		Categories:  chosenCategories,
+		Tags:        chosenTags,
+		MinSeverity: profile.MinSeverity,
+		Severities:  make(map[string]string),
+	}
+	severities := []string{keepRuleSeverity, model.SeverityError, model.SeverityWarn, model.SeverityInfo,
+		model.SeverityHint}
+	for _, cat := range chosenCategories {
+		current := profile.Severities[cat]
+		if current == "" {
+			current = profile.Severities[model.CategoryAll]
+		}
+		if current == "" {
+			current = keepRuleSeverity
+		}
+		severity, sErr := promptSelect(fmt.Sprintf("Severity of the '%s' rules", cat), severities, current)
+		if sErr != nil {
+			return nil, sErr
+		}
+		if severity != keepRuleSeverity {
+			custom.Severities[cat] = severity
+		}
+	}
+	return custom, nil
+}
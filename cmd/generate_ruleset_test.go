@@ -2,9 +2,13 @@ package cmd
 
 import (
 	"bytes"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -74,3 +78,64 @@ func TestGenerateRulesetCommand_BadWrite(t *testing.T) {
 	cmdErr := cmd.Execute()
 	assert.Error(t, cmdErr)
 }
+
+func TestGenerateRulesetCommand_Profile(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "test-output")
+	cmd := GetGenerateRulesetCommand()
+	cmd.SetArgs([]string{"--profile", "security", output})
+	assert.NoError(t, cmd.Execute())
+
+	rsBytes, err := os.ReadFile(output + "-security.yaml")
+	assert.NoError(t, err)
+	rs, err := rulesets.CreateRuleSetFromData(rsBytes)
+	assert.NoError(t, err)
+
+	generated := rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.NotEmpty(t, generated.Rules)
+	for _, rule := range generated.Rules {
+		assert.True(t, rule.HasTag(rulesets.TagSecurity), rule.Id)
+	}
+}
+
+func TestGenerateRulesetCommand_UnknownProfile(t *testing.T) {
+	cmd := GetGenerateRulesetCommand()
+	cmd.SetArgs([]string{"--profile", "fish-cakes", "test-output"})
+	assert.ErrorContains(t, cmd.Execute(), "unknown profile 'fish-cakes'")
+}
+
+func TestGenerateRulesetCommand_Interactive(t *testing.T) {
+	selectFn, multiselectFn := promptSelect, promptMultiselect
+	t.Cleanup(func() { promptSelect, promptMultiselect = selectFn, multiselectFn })
+
+	promptSelect = func(title string, options []string, defaultOption string) (string, error) {
+		if title == "Start from a profile" {
+			return rulesets.ProfileRecommended, nil
+		}
+		return model.SeverityError, nil
+	}
+	promptMultiselect = func(title string, options, defaults []string) ([]string, error) {
+		if strings.HasPrefix(title, "Categories") {
+			assert.Contains(t, defaults, model.CategoryOperations)
+			return []string{model.CategoryOperations}, nil
+		}
+		return nil, nil
+	}
+
+	output := filepath.Join(t.TempDir(), "test-output")
+	cmd := GetGenerateRulesetCommand()
+	cmd.SetArgs([]string{"-i", output})
+	assert.NoError(t, cmd.Execute())
+
+	rsBytes, err := os.ReadFile(output + "-custom.yaml")
+	assert.NoError(t, err)
+	rs, err := rulesets.CreateRuleSetFromData(rsBytes)
+	assert.NoError(t, err)
+
+	generated := rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.NotEmpty(t, generated.Rules)
+	for _, rule := range generated.Rules {
+		assert.Equal(t, model.CategoryOperations, rule.RuleCategory.Id)
+		assert.Equal(t, model.SeverityError, rule.Severity)
+		assert.True(t, rule.Recommended)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"errors"
 	html_report "github.com/daveshanley/vacuum/html-report"
 	"fmt"
+	"github.com/daveshanley/vacuum/i18n"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/daveshanley/vacuum/motor"
@@ -19,6 +20,8 @@ import (
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"os"
+	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -31,7 +34,8 @@ func GetHTMLReportCommand() *cobra.Command {
 		Short:        "Generate an HTML report of a linting run",
 		Long: "Generate an interactive and useful HTML report. Default output " +
 			"filename is 'report.html' located in the working directory.",
-		Example: "vacuum html-report <my-awesome-spec.yaml> <report.html>",
+		Example: "vacuum html-report <my-awesome-spec.yaml> <report.html>\n" +
+			"vacuum html-report --bundle reports/ <first-spec.yaml> <second-spec.yaml>",
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			switch len(args) {
 			case 0:
@@ -73,25 +77,17 @@ func GetHTMLReportCommand() *cobra.Command {
 			timeFlag, _ := cmd.Flags().GetBool("time")
 			disableTimestamp, _ := cmd.Flags().GetBool("disableTimestamp")
 
-			reportOutput := "report.html"
-
-			if len(args) > 1 {
-				reportOutput = args[1]
-			}
-
-			start := time.Now()
-			var err error
-			vacuumReport, specBytes, _ := vacuum_report.BuildVacuumReportFromFile(args[0])
-			if len(specBytes) <= 0 {
-				pterm.Error.Printf("Failed to read specification: %v\n\n", args[0])
-				return err
+			theme, themeErr := htmlReportTheme(cmd)
+			if themeErr != nil {
+				pterm.Error.Println(themeErr.Error())
+				pterm.Println()
+				return themeErr
 			}
 
-			var resultSet *model.RuleResultSet
-			var ruleset *motor.RuleSetExecutionResult
-			var specIndex *index.SpecIndex
-			var specInfo *datamodel.SpecInfo
-			var stats *reports.ReportStatistics
+			bundleFlag, _ := cmd.Flags().GetString("bundle")
+			contextLinesFlag, _ := cmd.Flags().GetInt("context-lines")
+			maxLineLengthFlag, _ := cmd.Flags().GetInt("max-line-length")
+			codeFrames := model.CodeFrameOptions{ContextLines: contextLinesFlag, MaxLineLength: maxLineLengthFlag}
 
 			ignoredItems := model.IgnoredItems{}
 			if ignoreFile != "" {
@@ -105,54 +101,87 @@ func GetHTMLReportCommand() *cobra.Command {
 				}
 			}
 
-			// if we have a pre-compiled report, jump straight to the end and collect $500
-			if vacuumReport == nil {
-
-				functionsFlag, _ := cmd.Flags().GetString("functions")
-				customFunctions, _ := LoadCustomFunctions(functionsFlag, silent)
-
-				rulesetFlag, _ := cmd.Flags().GetString("ruleset")
-				
-				// Certificate/TLS configuration
-				certFile, _ := cmd.Flags().GetString("cert-file")
-				keyFile, _ := cmd.Flags().GetString("key-file")
-				caFile, _ := cmd.Flags().GetString("ca-file")
-				insecure, _ := cmd.Flags().GetBool("insecure")
-				
-				resultSet, ruleset, err = BuildResultsWithDocCheckSkip(false, hardModeFlag, rulesetFlag, specBytes, customFunctions,
-					baseFlag, remoteFlag, skipCheckFlag, time.Duration(timeoutFlag)*time.Second, utils.HTTPClientConfig{
-						CertFile: certFile,
-						KeyFile:  keyFile,
-						CAFile:   caFile,
-						Insecure: insecure,
-					}, ignoredItems)
-				if err != nil {
-					pterm.Error.Printf("Failed to generate report: %v\n\n", err)
-					return err
+			// generate lints a specification (or replays a vacuum report) and renders the html report.
+			generate := func(specPath string) ([]byte, *reports.ReportStatistics, *model.RuleResultSet, error) {
+				var err error
+				vacuumReport, specBytes, _ := vacuum_report.BuildVacuumReportFromFile(specPath)
+				if len(specBytes) <= 0 {
+					pterm.Error.Printf("Failed to read specification: %v\n\n", specPath)
+					return nil, nil, nil, fmt.Errorf("failed to read specification '%s'", specPath)
 				}
-				specIndex = ruleset.Index
-				specInfo = ruleset.SpecInfo
 
-				specInfo.Generated = time.Now()
-				stats = statistics.CreateReportStatistics(specIndex, specInfo, resultSet)
+				var resultSet *model.RuleResultSet
+				var ruleset *motor.RuleSetExecutionResult
+				var specIndex *index.SpecIndex
+				var specInfo *datamodel.SpecInfo
+				var stats *reports.ReportStatistics
+
+				// if we have a pre-compiled report, jump straight to the end and collect $500
+				if vacuumReport == nil {
+
+					functionsFlag, _ := cmd.Flags().GetString("functions")
+					customFunctions, _ := LoadCustomFunctions(functionsFlag, silent)
+
+					rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+
+					// Certificate/TLS configuration
+					certFile, _ := cmd.Flags().GetString("cert-file")
+					keyFile, _ := cmd.Flags().GetString("key-file")
+					caFile, _ := cmd.Flags().GetString("ca-file")
+					insecure, _ := cmd.Flags().GetBool("insecure")
 
-			} else {
+					resultSet, ruleset, err = BuildResultsWithDocCheckSkip(false, hardModeFlag, rulesetFlag, specBytes, customFunctions,
+						baseFlag, remoteFlag, skipCheckFlag, time.Duration(timeoutFlag)*time.Second, utils.HTTPClientConfig{
+							CertFile: certFile,
+							KeyFile:  keyFile,
+							CAFile:   caFile,
+							Insecure: insecure,
+						}, ignoredItems)
+					if err != nil {
+						pterm.Error.Printf("Failed to generate report: %v\n\n", err)
+						return nil, nil, nil, err
+					}
+					specIndex = ruleset.Index
+					specInfo = ruleset.SpecInfo
 
-				resultSet = model.NewRuleResultSetPointer(vacuumReport.ResultSet.Results)
-				// Apply ignore filter to pre-compiled report results
-				resultSet.Results = utils.FilterIgnoredResultsPtr(resultSet.Results, ignoredItems)
-				specInfo = vacuumReport.SpecInfo
-				stats = vacuumReport.Statistics
-				specInfo.Generated = vacuumReport.Generated
+					specInfo.Generated = time.Now()
+					stats = statistics.CreateReportStatistics(specIndex, specInfo, resultSet)
+					resultSet.CaptureCodeFrames(specPath, specBytes, codeFrames)
+
+				} else {
+
+					resultSet = model.NewRuleResultSetPointer(vacuumReport.ResultSet.Results)
+					// Apply ignore filter to pre-compiled report results
+					resultSet.Results = utils.FilterIgnoredResultsPtr(resultSet.Results, ignoredItems)
+					specInfo = vacuumReport.SpecInfo
+					stats = vacuumReport.Statistics
+					specInfo.Generated = vacuumReport.Generated
+				}
+
+				i18n.LocalizeResults(resultSet.Results)
+
+				// generate html report
+				report := html_report.NewThemedHTMLReport(specIndex, specInfo, resultSet, stats, disableTimestamp, theme)
+				return report.GenerateReport(false, Version), stats, resultSet, nil
 			}
 
-			duration := time.Since(start)
+			start := time.Now()
 
-			// generate html report
-			report := html_report.NewHTMLReport(specIndex, specInfo, resultSet, stats, disableTimestamp)
+			if bundleFlag != "" {
+				return generateHTMLBundle(bundleFlag, args, generate, theme, disableTimestamp, timeFlag, start)
+			}
 
-			generatedBytes := report.GenerateReport(false, Version)
-			//generatedBytes := report.GenerateReport(true) // test mode
+			reportOutput := "report.html"
+
+			if len(args) > 1 {
+				reportOutput = args[1]
+			}
+
+			generatedBytes, _, _, err := generate(args[0])
+			if err != nil {
+				return err
+			}
+			duration := time.Since(start)
 
 			err = os.WriteFile(reportOutput, generatedBytes, 0664)
 
@@ -172,8 +201,109 @@ func GetHTMLReportCommand() *cobra.Command {
 		},
 	}
 	cmd.Flags().BoolP("disableTimestamp", "d", false, "Disable timestamp in report")
+	cmd.Flags().String("bundle", "", "Generate a report for every specification into this directory, with an index page listing them all")
 	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
 	cmd.Flags().String("ignore-file", "", "Path to ignore file")
+	cmd.Flags().Int("context-lines", model.DefaultCodeFrameContextLines,
+		"Lines of source shown before and after a result in code snippets")
+	cmd.Flags().Int("max-line-length", model.DefaultCodeFrameMaxLineLength,
+		"Cut lines of code snippets off after this many characters, 0 keeps lines whole")
+	cmd.Flags().String("theme", "", "Path to a theme file (YAML or JSON) to brand the report, flags override the file")
+	cmd.Flags().String("title", "", "Title of the report, replaces 'vacuum report'")
+	cmd.Flags().String("logo", "", "Logo for the report, a URL, a path to an image or a base64 encoded image")
+	cmd.Flags().String("primary-color", "", "Primary color of the report, e.g. '#62c4ff'")
+	cmd.Flags().String("secondary-color", "", "Secondary color of the report, e.g. '#f83aff'")
+	cmd.Flags().String("footer-text", "", "Text rendered at the bottom of the report")
+	cmd.Flags().String("theme-mode", "", "Render the report 'dark' (default), 'light', or 'auto' to follow the browser")
 
 	return cmd
 }
+
+// htmlReportTheme builds the theme for an HTML report, from the theme file (if there is one) and any theme flags.
+// nil is returned if the report has not been themed.
+func htmlReportTheme(cmd *cobra.Command) (*html_report.Theme, error) {
+	themeFlag, _ := cmd.Flags().GetString("theme")
+	theme := &html_report.Theme{}
+	if themeFlag != "" {
+		var err error
+		if theme, err = html_report.LoadTheme(themeFlag); err != nil {
+			return nil, err
+		}
+	}
+	for flag, value := range map[string]*string{
+		"title":           &theme.Title,
+		"logo":            &theme.Logo,
+		"primary-color":   &theme.PrimaryColor,
+		"secondary-color": &theme.SecondaryColor,
+		"footer-text":     &theme.FooterText,
+		"theme-mode":      &theme.Mode,
+	} {
+		if v, _ := cmd.Flags().GetString(flag); v != "" {
+			*value = v
+		}
+	}
+	if *theme == (html_report.Theme{}) {
+		return nil, nil
+	}
+	return theme, theme.Validate()
+}
+
+// generateHTMLBundle generates a report for every specification into a directory, along with an index page that
+// lists every specification with its score and issues, linking to each report.
+func generateHTMLBundle(dir string, specs []string,
+	generate func(string) ([]byte, *reports.ReportStatistics, *model.RuleResultSet, error),
+	theme *html_report.Theme, disableTimestamp, timeFlag bool, start time.Time) error {
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		pterm.Error.Printf("Unable to create report bundle directory '%s': %s\n", dir, err.Error())
+		pterm.Println()
+		return err
+	}
+
+	var documents []*html_report.IndexDocument
+	var size int64
+	for i, spec := range specs {
+		generatedBytes, stats, resultSet, err := generate(spec)
+		if err != nil {
+			return err
+		}
+		name := strings.TrimSuffix(filepath.Base(spec), filepath.Ext(spec))
+		link := fmt.Sprintf("%d-%s.html", i+1, name)
+		if err = os.WriteFile(filepath.Join(dir, link), generatedBytes, 0664); err != nil {
+			pterm.Error.Printf("Unable to write HTML report file: '%s': %s\n", link, err.Error())
+			pterm.Println()
+			return err
+		}
+
+		doc := &html_report.IndexDocument{Name: spec, Link: link}
+		if stats != nil {
+			doc.Score, doc.Errors, doc.Warnings, doc.Info = stats.OverallScore, stats.TotalErrors,
+				stats.TotalWarnings, stats.TotalInfo
+		} else if resultSet != nil {
+			doc.Score = statistics.CalculateQualityScore(resultSet)
+			doc.Errors, doc.Warnings, doc.Info = resultSet.GetErrorCount(), resultSet.GetWarnCount(),
+				resultSet.GetInfoCount()
+		}
+		documents = append(documents, doc)
+		if fi, statErr := os.Stat(spec); statErr == nil {
+			size += fi.Size()
+		}
+	}
+
+	indexBytes, err := html_report.BuildIndexPage(documents, theme, time.Now(), disableTimestamp, Version)
+	if err == nil {
+		err = os.WriteFile(filepath.Join(dir, "index.html"), indexBytes, 0664)
+	}
+	if err != nil {
+		pterm.Error.Printf("Unable to write HTML report index: %s\n", err.Error())
+		pterm.Println()
+		return err
+	}
+
+	pterm.Success.Printf("HTML Report bundle generated for %d specifications, written to '%s'\n", len(specs),
+		filepath.Join(dir, "index.html"))
+	pterm.Println()
+
+	RenderTime(timeFlag, time.Since(start), size)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -85,3 +86,50 @@ func TestGetHTMLReportCommand_BadWrite(t *testing.T) {
 	cmdErr := cmd.Execute()
 	assert.Error(t, cmdErr)
 }
+
+func TestGetHTMLReportCommand_Theme(t *testing.T) {
+	dir := t.TempDir()
+	theme := filepath.Join(dir, "theme.yaml")
+	assert.NoError(t, os.WriteFile(theme, []byte("title: Acme APIs\nfooterText: Acme platform team\n"), 0664))
+	report := filepath.Join(dir, "report.html")
+
+	cmd := GetHTMLReportCommand()
+	cmd.SetArgs([]string{
+		"--theme", theme,
+		"--primary-color", "#ff0000",
+		"../model/test_files/burgershop.openapi.yaml",
+		report,
+	})
+	assert.NoError(t, cmd.Execute())
+
+	data, err := os.ReadFile(report)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "Acme platform team")
+	assert.Contains(t, string(data), "--primary-color: #ff0000;")
+
+	cmd = GetHTMLReportCommand()
+	cmd.SetArgs([]string{"--theme-mode", "neon", "../model/test_files/burgershop.openapi.yaml", report})
+	assert.ErrorContains(t, cmd.Execute(), "theme mode 'neon' is not valid")
+}
+
+func TestGetHTMLReportCommand_Bundle(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "reports")
+
+	cmd := GetHTMLReportCommand()
+	cmd.SetArgs([]string{
+		"--bundle", dir,
+		"--title", "Acme APIs",
+		"../model/test_files/burgershop.openapi.yaml",
+		"../model/test_files/burgershop-report.json.gz",
+	})
+	assert.NoError(t, cmd.Execute())
+
+	assert.FileExists(t, filepath.Join(dir, "1-burgershop.openapi.html"))
+	assert.FileExists(t, filepath.Join(dir, "2-burgershop-report.json.html"))
+
+	data, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "Acme APIs")
+	assert.Contains(t, string(data), `href="1-burgershop.openapi.html"`)
+	assert.Contains(t, string(data), `href="2-burgershop-report.json.html"`)
+}
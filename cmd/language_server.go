@@ -94,6 +94,10 @@ IDE and start linting your OpenAPI documents in real-time.`,
 				if rsErr != nil {
 					return rsErr
 				}
+				customFunctions, rsErr = LoadRuleSetFunctions(rulesetFlag, selectedRS, customFunctions, true)
+				if rsErr != nil {
+					return rsErr
+				}
 			}
 
 			ignoredItems := model.IgnoredItems{}
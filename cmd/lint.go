@@ -6,11 +6,13 @@ package cmd
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log/slog"
 	"net/http"
 	"os"
 	"path/filepath"
-	"strconv"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -19,10 +21,13 @@ import (
 	"github.com/daveshanley/vacuum/statistics"
 	"gopkg.in/yaml.v3"
 
+	"github.com/daveshanley/vacuum/i18n"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/publish"
 	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/daveshanley/vacuum/utils"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
 	"github.com/dustin/go-humanize"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
@@ -35,7 +40,10 @@ func GetLintCommand() *cobra.Command {
 		SilenceUsage: true,
 		Use:          "lint <your-openapi-file.yaml>",
 		Short:        "Lint an OpenAPI specification",
-		Long:         `Lint an OpenAPI specification, the output of the response will be in the terminal`,
+		Long: "Lint an OpenAPI specification, the output of the response will be in the terminal. " +
+			"Use '-' as the file (or pipe a specification in) to read the specification from stdin. " +
+			"Many specifications can be linted at once using a glob, e.g. \"specs/**/*.yaml\", formats such as " +
+			"junit, json, html and sarif render a single combined report for every file.",
 		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
 			if len(args) != 0 {
 				return nil, cobra.ShellCompDirectiveNoFileComp
@@ -48,29 +56,65 @@ func GetLintCommand() *cobra.Command {
 			detailsFlag, _ := cmd.Flags().GetBool("details")
 			timeFlag, _ := cmd.Flags().GetBool("time")
 			snippetsFlag, _ := cmd.Flags().GetBool("snippets")
+			contextLinesFlag, _ := cmd.Flags().GetInt("context-lines")
+			maxLineLengthFlag, _ := cmd.Flags().GetInt("max-line-length")
 			errorsFlag, _ := cmd.Flags().GetBool("errors")
 			categoryFlag, _ := cmd.Flags().GetString("category")
 			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
 			silent, _ := cmd.Flags().GetBool("silent")
 			functionsFlag, _ := cmd.Flags().GetString("functions")
 			failSeverityFlag, _ := cmd.Flags().GetString("fail-severity")
+			maxWarningsFlag, _ := cmd.Flags().GetInt("max-warnings")
 			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
 			baseFlag, _ := cmd.Flags().GetString("base")
 			skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
 			remoteFlag, _ := cmd.Flags().GetBool("remote")
-			debugFlag, _ := cmd.Flags().GetBool("debug")
 			noBanner, _ := cmd.Flags().GetBool("no-banner")
 			noMessage, _ := cmd.Flags().GetBool("no-message")
 			allResults, _ := cmd.Flags().GetBool("all-results")
 			timeoutFlag, _ := cmd.Flags().GetInt("timeout")
 			hardModeFlag, _ := cmd.Flags().GetBool("hard-mode")
 			noClipFlag, _ := cmd.Flags().GetBool("no-clip")
+			groupByFlag, _ := cmd.Flags().GetString("group-by")
+			sortFlag, _ := cmd.Flags().GetString("sort")
+			maxResultsFlag, _ := cmd.Flags().GetInt("max-results")
 			extensionRefsFlag, _ := cmd.Flags().GetBool("ext-refs")
 			ignoreArrayCircleRef, _ := cmd.Flags().GetBool("ignore-array-circle-ref")
 			ignorePolymorphCircleRef, _ := cmd.Flags().GetBool("ignore-polymorph-circle-ref")
 			ignoreFile, _ := cmd.Flags().GetString("ignore-file")
 			minScore, _ := cmd.Flags().GetInt("min-score")
 			pipelineOutput, _ := cmd.Flags().GetBool("pipeline-output")
+			formatFlag, _ := cmd.Flags().GetString("format")
+			maxAnnotations, _ := cmd.Flags().GetInt("max-annotations")
+			baselineFile, _ := cmd.Flags().GetString("baseline")
+			watchFlag, _ := cmd.Flags().GetBool("watch")
+			headerFlags, _ := cmd.Flags().GetStringArray("header")
+			fetchTimeoutFlag, _ := cmd.Flags().GetInt("fetch-timeout")
+			debounceFlag, _ := cmd.Flags().GetInt("debounce")
+			fixFlag, _ := cmd.Flags().GetBool("fix")
+			excludeFlags, _ := cmd.Flags().GetStringArray("exclude")
+			dryRunFlag, _ := cmd.Flags().GetBool("dry-run")
+			schemaFlag, _ := cmd.Flags().GetBool("schema")
+			ruleSeverityFlags, _ := cmd.Flags().GetStringArray("rule-severity")
+			disableRuleFlags, _ := cmd.Flags().GetStringArray("disable-rule")
+			lowMemoryFlag, _ := cmd.Flags().GetBool("low-memory")
+			collapseRefsFlag, _ := cmd.Flags().GetBool("collapse-refs")
+			showRuleTimingsFlag, _ := cmd.Flags().GetBool("show-rule-timings")
+			workersFlag, _ := cmd.Flags().GetInt("workers")
+			tagsFlag, _ := cmd.Flags().GetStringSlice("tags")
+			excludeTagsFlag, _ := cmd.Flags().GetStringSlice("exclude-tags")
+			changedSinceFlag, _ := cmd.Flags().GetString("changed-since")
+			showUnchangedFlag, _ := cmd.Flags().GetBool("show-unchanged")
+			changedLinesOnlyFlag, _ := cmd.Flags().GetBool("changed-lines-only")
+			fullReportFlag, _ := cmd.Flags().GetString("full-report")
+			postReportFlag, _ := cmd.Flags().GetString("post-report")
+			postReportHeadersFlag, _ := cmd.Flags().GetStringArray("post-report-header")
+			postReportTokenFlag, _ := cmd.Flags().GetString("post-report-token")
+			postReportRetriesFlag, _ := cmd.Flags().GetInt("post-report-retries")
+			badgeFlag, _ := cmd.Flags().GetString("badge")
+			stagedFlag, _ := cmd.Flags().GetBool("staged")
+			gatesFileFlag, _ := cmd.Flags().GetString("gates-file")
+			vacuumIgnoreFlag, _ := cmd.Flags().GetString("vacuumignore")
 
 			// https://github.com/daveshanley/vacuum/issues/636
 			showRules, _ := cmd.Flags().GetBool("show-rules")
@@ -81,6 +125,87 @@ func GetLintCommand() *cobra.Command {
 			caFile, _ := cmd.Flags().GetString("ca-file")
 			insecure, _ := cmd.Flags().GetBool("insecure")
 
+			defer SetLowMemoryMode(lowMemoryFlag)()
+
+			// code frames are only captured for the views and formats that render them.
+			var codeFrames *model.CodeFrameOptions
+			if snippetsFlag || RendersCodeFrames(formatFlag) {
+				codeFrames = &model.CodeFrameOptions{ContextLines: contextLinesFlag, MaxLineLength: maxLineLengthFlag}
+			}
+
+			// JUnit options are shared by every file, and by the combined report.
+			junitReq := utils.LintFileRequest{FailSeverityFlag: failSeverityFlag, MaxWarnings: maxWarningsFlag}
+			if formatFlag != "" {
+				if !IsValidLintFormat(formatFlag) {
+					pterm.Error.Printf("Unknown format '%s', supported formats are %v\n", formatFlag, LintFormats)
+					pterm.Println()
+					return fmt.Errorf("unknown format '%s'", formatFlag)
+				}
+				if jErr := readJUnitFlags(cmd, &junitReq); jErr != nil {
+					return jErr
+				}
+				// machine-readable output must not be polluted by anything else.
+				silent = true
+				noStyleFlag = true
+			}
+			if tErr := readReportTemplateFlag(cmd, formatFlag, &junitReq); tErr != nil {
+				return tErr
+			}
+			junitReq.HTMLBundle, _ = cmd.Flags().GetString("html-bundle")
+			if junitReq.HTMLBundle != "" && formatFlag != FormatHTML {
+				errText := "'--html-bundle' writes html reports, use it with '--format html'"
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			// annotations are limited to the diff of the pull request, every result is still reported (and counted).
+			var changedLinesOnly string
+			if changedLinesOnlyFlag {
+				if formatFlag != FormatGitHub && formatFlag != FormatGitLab {
+					errText := "'--changed-lines-only' limits annotations, use it with '--format github' or '--format gitlab'"
+					pterm.Error.Println(errText)
+					pterm.Println()
+					return errors.New(errText)
+				}
+				changedLinesOnly = changedSinceFlag
+				if changedLinesOnly == "" {
+					changedLinesOnly = pullRequestBase()
+				}
+				if changedLinesOnly == "" {
+					errText := "'--changed-lines-only' needs the base of the pull request to diff against, use " +
+						"'--changed-since' (GITHUB_BASE_REF and CI_MERGE_REQUEST_DIFF_BASE_SHA are used when set)"
+					pterm.Error.Println(errText)
+					pterm.Println()
+					return errors.New(errText)
+				}
+				changedSinceFlag = ""
+			}
+
+			if groupByFlag != "" && !slices.Contains(GroupByOptions, groupByFlag) {
+				errText := fmt.Sprintf("unknown grouping '%s', results can be grouped by %v", groupByFlag, GroupByOptions)
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+			sortOrder, sortErr := model.ParseResultOrder(sortFlag)
+			if sortErr != nil {
+				pterm.Error.Println(sortErr.Error())
+				pterm.Println()
+				return sortErr
+			}
+
+			// the report is posted once every file is linted, anything wrong with the endpoint is found first.
+			var reportPublisher *publish.WebhookPublisher
+			if postReportFlag != "" {
+				var pErr error
+				if reportPublisher, pErr = newReportPublisher(postReportFlag, postReportHeadersFlag, postReportTokenFlag,
+					postReportRetriesFlag, utils.HTTPClientConfig{CertFile: certFile, KeyFile: keyFile, CAFile: caFile,
+						Insecure: insecure}); pErr != nil {
+					return pErr
+				}
+			}
+
 			// disable color and styling, for CI/CD use.
 			// https://github.com/daveshanley/vacuum/issues/234
 			if noStyleFlag || pipelineOutput {
@@ -92,6 +217,27 @@ func GetLintCommand() *cobra.Command {
 				PrintBanner()
 			}
 
+			// specifications that are not read from the local filesystem (stdin, or remote URLs).
+			specSources := make(map[string][]byte)
+
+			// read the specification from stdin, if asked for with '-', or if something is being piped in.
+			var stdinBytes []byte
+			if (len(args) == 1 && args[0] == "-") || (len(args) == 0 && globPattern == "" && stdinIsPiped(cmd)) {
+				var sErr error
+				stdinBytes, sErr = io.ReadAll(cmd.InOrStdin())
+				if sErr != nil {
+					pterm.Error.Printf("Unable to read specification from stdin: %s\n", sErr.Error())
+					pterm.Println()
+					return sErr
+				}
+				if len(stdinBytes) > 0 {
+					specSources[StdinFileName] = stdinBytes
+					args = []string{StdinFileName}
+				} else {
+					args = nil
+				}
+			}
+
 			filesToLint, err := getFilesToLint(globPattern, args, validFileExtensions)
 			// If the user has specifically asked for --globbed-files and it throws an error, they should know about it.
 			// However if they have not, then they should expect the default behavior.
@@ -101,6 +247,53 @@ func GetLintCommand() *cobra.Command {
 				return err
 			}
 
+			// only the specifications staged for the next commit are linted, for pre-commit hooks.
+			if stagedFlag {
+				if len(args) > 0 || globPattern != "" {
+					errText := "--staged lints the staged specifications, files can't be supplied as well"
+					pterm.Error.Println(errText)
+					pterm.Println()
+					return errors.New(errText)
+				}
+				filesToLint, err = stagedSpecifications()
+				if err != nil {
+					pterm.Error.Println(err.Error())
+					pterm.Println()
+					return err
+				}
+				if len(filesToLint) == 0 {
+					if !silent {
+						pterm.Info.Println("No OpenAPI specifications are staged, nothing to lint")
+						pterm.Println()
+					}
+					return nil
+				}
+			}
+
+			filesToLint = excludeFiles(filesToLint, excludeFlags)
+
+			// an ignore file only applies when linting many files, a file named on its own is always linted.
+			var vacuumIgnore *utils.VacuumIgnore
+			if globPattern != "" || stagedFlag || len(filesToLint) > 1 || slices.ContainsFunc(args, utils.IsGlobPattern) {
+				vi, viErr := FindVacuumIgnoreFile(vacuumIgnoreFlag)
+				if viErr != nil {
+					pterm.Error.Println(viErr.Error())
+					pterm.Println()
+					return viErr
+				}
+				vacuumIgnore = vi
+				if kept := vacuumIgnore.FilterFiles(filesToLint); len(kept) < len(filesToLint) {
+					if len(kept) == 0 {
+						if !silent {
+							pterm.Info.Printf("Every specification is ignored by '%s', nothing to lint\n", vacuumIgnore.Path)
+							pterm.Println()
+						}
+						return nil
+					}
+					filesToLint = kept
+				}
+			}
+
 			// verify that there is at least one file to lint
 			if len(filesToLint) < 1 {
 				pterm.Error.Println("Please supply an OpenAPI specification to lint")
@@ -108,6 +301,28 @@ func GetLintCommand() *cobra.Command {
 				return fmt.Errorf("no file supplied")
 			}
 
+			headers, hErr := ParseHeaderFlags(headerFlags)
+			if hErr != nil {
+				pterm.Error.Println(hErr.Error())
+				pterm.Println()
+				return hErr
+			}
+			remoteSpecs, fErr := fetchRemoteLintSpecs(filesToLint, headers, time.Duration(fetchTimeoutFlag)*time.Second,
+				utils.HTTPClientConfig{
+					CertFile: certFile,
+					KeyFile:  keyFile,
+					CAFile:   caFile,
+					Insecure: insecure,
+				})
+			if fErr != nil {
+				pterm.Error.Println(fErr.Error())
+				pterm.Println()
+				return fErr
+			}
+			for k, v := range remoteSpecs {
+				specSources[k] = v
+			}
+
 			var errs []error
 
 			mf := false
@@ -115,25 +330,13 @@ func GetLintCommand() *cobra.Command {
 				mf = true
 			}
 
-			logLevel := pterm.LogLevelError
-			if debugFlag {
-				logLevel = pterm.LogLevelDebug
-			}
-
 			// setup logging
-			handler := pterm.NewSlogHandler(&pterm.Logger{
-				Formatter: pterm.LogFormatterColorful,
-				Writer:    os.Stdout,
-				Level:     logLevel,
-				ShowTime:  false,
-				MaxWidth:  280,
-				KeyStyles: map[string]pterm.Style{
-					"error":  *pterm.NewStyle(pterm.FgRed, pterm.Bold),
-					"err":    *pterm.NewStyle(pterm.FgRed, pterm.Bold),
-					"caller": *pterm.NewStyle(pterm.FgGray, pterm.Bold),
-				},
-			})
-			logger := slog.New(handler)
+			logger, logErr := BuildLogger(cmd, slog.LevelError)
+			if logErr != nil {
+				pterm.Error.Println(logErr.Error())
+				pterm.Println()
+				return logErr
+			}
 
 			defaultRuleSets := rulesets.BuildDefaultRuleSetsWithLogger(logger)
 			selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
@@ -186,6 +389,13 @@ func GetLintCommand() *cobra.Command {
 					return rsErr
 				}
 
+				// load any Spectral functions the ruleset declares
+				customFunctions, rsErr = LoadRuleSetFunctions(rulesetFlag, selectedRS, customFunctions, silent)
+				if rsErr != nil {
+					return rsErr
+				}
+				RenderRuleSetDowngrades(selectedRS, silent)
+
 				// Merge OWASP rules if hard mode is enabled
 				if MergeOWASPRulesToRuleSet(selectedRS, hardModeFlag) {
 					if !silent && !pipelineOutput {
@@ -197,6 +407,26 @@ func GetLintCommand() *cobra.Command {
 				}
 			}
 
+			// AsyncAPI documents are linted with the AsyncAPI rules, unless a ruleset has been supplied.
+			// Standalone JSON Schema documents can't be detected, so they are only linted as such when asked to.
+			var asyncAPIRS *rulesets.RuleSet
+			var documentKind model.DocumentKind
+			if schemaFlag {
+				documentKind = model.DocumentKindJSONSchema
+				if rulesetFlag == "" {
+					selectedRS = defaultRuleSets.GenerateJSONSchemaRuleSet()
+				}
+			} else if rulesetFlag == "" {
+				asyncAPIRS = defaultRuleSets.GenerateAsyncAPIRecommendedRuleSet()
+			}
+
+			if rfErr := ApplyRuleFlags(ruleSeverityFlags, disableRuleFlags, selectedRS, asyncAPIRS); rfErr != nil {
+				pterm.Error.Printf("Unable to adjust rules: %s\n", rfErr.Error())
+				pterm.Println()
+				return rfErr
+			}
+			FilterRulesByTags(tagsFlag, excludeTagsFlag, selectedRS, asyncAPIRS)
+
 			// Show which rules are being used (after ruleset is fully loaded)
 			if showRules && !pipelineOutput {
 				pterm.Println("The following rules are being used:")
@@ -264,24 +494,104 @@ func GetLintCommand() *cobra.Command {
 				}
 			}
 
-			start := time.Now()
+			gatePolicy, gErr := loadQualityGatePolicy(gatesFileFlag, selectedRS.Categories)
+			if gErr != nil {
+				pterm.Error.Println(gErr.Error())
+				pterm.Println()
+				return gErr
+			}
+
+			// the scores of every file are calculated by the scoring of the policy, and recorded in the statistics.
+			var scoring *reports.Scoring
+			if gatePolicy != nil && gatePolicy.Scoring != nil {
+				scoring = gatePolicy.Scoring.Scoring()
+			}
+
+			annotationCount := make(map[string]int)
+			var baseline *model.Baseline
+			if baselineFile != "" {
+				var bErr error
+				baseline, bErr = utils.ReadBaselineFile(baselineFile)
+				if bErr != nil {
+					pterm.Error.Println(bErr.Error())
+					pterm.Println()
+					return bErr
+				}
+			}
+
+			// a single linting run, watch mode will run this every time a file changes.
+			var totalWarnings int
+			runLint := func() error {
+				errs = nil
+				clear(annotationCount)
+				totalWarnings = 0
+				start := time.Now()
+
+				var filesProcessedSize int64
+				var filesProcessed int
+				var size int64
+				var stats *reports.ReportStatistics
+
+				// formats that render a single document collect the results of every file, and render once done.
+				var fileReports []*vacuum_report.FileReport
+				var onResults func(string, []byte, *model.RuleResultSet, *reports.ReportStatistics)
+				if IsAggregatedFormat(formatFlag) {
+					fileReports = make([]*vacuum_report.FileReport, len(filesToLint))
+					index := make(map[string]int, len(filesToLint))
+					for i, f := range filesToLint {
+						index[f] = i
+					}
+					onResults = func(fileName string, spec []byte, rs *model.RuleResultSet, st *reports.ReportStatistics) {
+						fr := &vacuum_report.FileReport{
+							FileName:   fileName,
+							Statistics: st,
+							ResultSet:  rs,
+						}
+						// only a custom template or the html report can render snippets, so don't hold on to every
+						// spec unless needed.
+						if junitReq.JUnitTemplate != "" || junitReq.ReportTemplate != "" || formatFlag == FormatHTML {
+							fr.Spec = spec
+						}
+						fileReports[index[fileName]] = fr
+					}
+				}
 
-			var filesProcessedSize int64
-			var filesProcessed int
-			var size int64
-			var stats *reports.ReportStatistics
-			for i, fileName := range filesToLint {
+				// the full report has every result of every file, even when annotations are limited to changed lines.
+				var fullReports []*vacuum_report.FileReport
+				var onFullResults func(string, *model.RuleResultSet, *reports.ReportStatistics)
+				if fullReportFlag != "" || postReportFlag != "" {
+					fullReports = make([]*vacuum_report.FileReport, len(filesToLint))
+					index := make(map[string]int, len(filesToLint))
+					for i, f := range filesToLint {
+						index[f] = i
+					}
+					onFullResults = func(fileName string, rs *model.RuleResultSet, st *reports.ReportStatistics) {
+						fullReports[index[fileName]] = &vacuum_report.FileReport{
+							FileName:   fileName,
+							Statistics: st,
+							ResultSet:  rs,
+						}
+					}
+				}
 
-				go func(c chan bool, i int, fileName string) {
+				// files are linted by a pool of workers, and print their results in the order they were given.
+				var aggregateLock sync.Mutex
+				fileErrs := make([]error, len(filesToLint))
+				fileStats := make([]*reports.ReportStatistics, len(filesToLint))
+				turns := newOutputTurns()
+				lintOne := func(i int, fileName string) {
 					// get size
 					s, _ := os.Stat(fileName)
 					if s != nil {
+						aggregateLock.Lock()
 						size = size + s.Size()
+						aggregateLock.Unlock()
 					}
 
 					lfr := utils.LintFileRequest{
 						FileName:                 fileName,
-						BaseFlag:                 baseFlag,
+						SpecBytes:                specSources[fileName],
+						BaseFlag:                 lintBase(baseFlag, fileName),
 						Remote:                   remoteFlag,
 						MultiFile:                mf,
 						SkipCheckFlag:            skipCheckFlag,
@@ -289,8 +599,10 @@ func GetLintCommand() *cobra.Command {
 						DetailsFlag:              detailsFlag,
 						TimeFlag:                 timeFlag,
 						FailSeverityFlag:         failSeverityFlag,
+						MaxWarnings:              maxWarningsFlag,
 						CategoryFlag:             categoryFlag,
 						SnippetsFlag:             snippetsFlag,
+						CodeFrames:               codeFrames,
 						ErrorsFlag:               errorsFlag,
 						NoMessageFlag:            noMessage,
 						AllResultsFlag:           allResults,
@@ -303,9 +615,21 @@ func GetLintCommand() *cobra.Command {
 						Logger:                   logger,
 						TimeoutFlag:              timeoutFlag,
 						NoClip:                   noClipFlag,
+						GroupBy:                  groupByFlag,
+						SortOrder:                sortOrder,
+						MaxResults:               maxResultsFlag,
+						Scoring:                  scoring,
 						IgnoreArrayCircleRef:     ignoreArrayCircleRef,
 						IgnorePolymorphCircleRef: ignorePolymorphCircleRef,
 						IgnoredResults:           ignoredItems,
+						Baseline:                 baseline,
+						ChangedSince:             changedSinceFlag,
+						ShowUnchanged:            showUnchangedFlag,
+						ChangedLinesOnly:         changedLinesOnly,
+						LowMemory:                lowMemoryFlag,
+						CollapseRefs:             collapseRefsFlag,
+						ShowRuleTimings:          showRuleTimingsFlag,
+						VacuumIgnore:             vacuumIgnore,
 						ExtensionRefs:            extensionRefsFlag,
 						PipelineOutput:           pipelineOutput,
 						ShowRules:                showRules,
@@ -315,65 +639,172 @@ func GetLintCommand() *cobra.Command {
 							CAFile:   caFile,
 							Insecure: insecure,
 						},
+						AsyncAPIRuleSet:       asyncAPIRS,
+						DocumentKind:          documentKind,
+						Format:                formatFlag,
+						MaxAnnotations:        maxAnnotations,
+						JUnitGroupBy:          junitReq.JUnitGroupBy,
+						JUnitTemplate:         junitReq.JUnitTemplate,
+						JUnitFailOn:           junitReq.JUnitFailOn,
+						JUnitSeverityOutcomes: junitReq.JUnitSeverityOutcomes,
+						ReportTemplate:        junitReq.ReportTemplate,
+						HTMLBundle:            junitReq.HTMLBundle,
+						AnnotationCount:       annotationCount,
+						OnResults:             onResults,
+						OnFullResults:         onFullResults,
+						Fix:                   fixFlag || dryRunFlag,
+						DryRun:                dryRunFlag,
+						WaitTurn: func() {
+							turns.wait(i)
+						},
 					}
 					st, fs, fp, err := lintFile(lfr)
 
-					if st != nil {
+					aggregateLock.Lock()
+					defer aggregateLock.Unlock()
+
+					// the lowest score across all files is the one checked against the threshold.
+					if st != nil && (stats == nil || st.OverallScore < stats.OverallScore) {
 						stats = st
 					}
+					if st != nil {
+						totalWarnings += st.TotalWarnings
+					}
+					fileStats[i] = st
 					filesProcessedSize = filesProcessedSize + fs + size
 					filesProcessed = filesProcessed + fp + 1
 
-					errs = append(errs, err)
-					doneChan <- true
-				}(doneChan, i, fileName)
-			}
+					fileErrs[i] = err
+				}
 
-			completed := 0
-			for completed < len(filesToLint) {
-				<-doneChan
-				completed++
-			}
+				files := make(chan int)
+				go func() {
+					for i := range filesToLint {
+						files <- i
+					}
+					close(files)
+				}()
+				for w := 0; w < lintWorkers(workersFlag, len(filesToLint)); w++ {
+					go func() {
+						for i := range files {
+							lintOne(i, filesToLint[i])
+							turns.done(i)
+							doneChan <- true
+						}
+					}()
+				}
 
-			if !detailsFlag && !silent && !pipelineOutput {
-				pterm.Println()
-				pterm.Info.Println("To see full details of linting report, use the '-d' flag.")
-				pterm.Println()
-			}
+				completed := 0
+				for completed < len(filesToLint) {
+					<-doneChan
+					completed++
+				}
+				errs = append(errs, fileErrs...)
 
-			duration := time.Since(start)
+				if fileReports != nil {
+					if rErr := RenderAggregatedReport(formatFlag, fileReports, start,
+						JUnitConfigForRequest(junitReq), junitReq.ReportTemplate, junitReq.HTMLBundle); rErr != nil {
+						errs = append(errs, rErr)
+					}
+				}
 
-			RenderTimeAndFiles(timeFlag, duration, filesProcessedSize, filesProcessed)
+				if fullReports != nil {
+					if fullReportFlag != "" {
+						if fErr := writeFullReport(fullReportFlag, fullReports); fErr != nil {
+							errs = append(errs, fErr)
+						}
+					}
+					if reportPublisher != nil {
+						if pErr := postReport(reportPublisher, fullReports, silent); pErr != nil {
+							errs = append(errs, pErr)
+						}
+					}
+				}
 
-			if minScore > 10 {
-				// check overall-score is above the threshold
-				if stats != nil {
-					if stats.OverallScore < minScore {
+				if !detailsFlag && !silent && !pipelineOutput {
+					pterm.Println()
+					pterm.Info.Println("To see full details of linting report, use the '-d' flag.")
+					pterm.Println()
+				}
 
-						if !pipelineOutput {
-							box := pterm.DefaultBox.WithLeftPadding(5).WithRightPadding(5)
-							box.BoxStyle = pterm.NewStyle(pterm.FgLightRed)
-							box.Println(pterm.LightRed("🚨 SCORE THRESHOLD FAILED 🚨"))
-							pterm.Println()
-						} else {
-							pterm.Println(pterm.LightRed("\n> 🚨 SCORE THRESHOLD FAILED, PIPELINE WILL FAIL 🚨\n"))
+				duration := time.Since(start)
+
+				RenderTimeAndFiles(timeFlag, duration, filesProcessedSize, filesProcessed)
+
+				// the badge shows the lowest score across all files, the same score checked against the threshold.
+				if badgeFlag != "" && stats != nil {
+					if bErr := vacuum_report.WriteBadge(badgeFlag, stats.OverallScore); bErr != nil {
+						pterm.Error.Println(bErr.Error())
+						errs = append(errs, bErr)
+					}
+				}
+
+				// quality gates are checked against every file, the same as the fail severity.
+				if gatePolicy != nil && len(gatePolicy.Gates) > 0 {
+					if gErr := renderQualityGates(evaluateQualityGates(gatePolicy, filesToLint, fileStats),
+						silent, pipelineOutput); gErr != nil {
+						errs = append(errs, gErr)
+					}
+				}
 
+				if minScore > 10 {
+					// check overall-score is above the threshold
+					if stats != nil {
+						if stats.OverallScore < minScore {
+
+							if !pipelineOutput {
+								box := pterm.DefaultBox.WithLeftPadding(5).WithRightPadding(5)
+								box.BoxStyle = pterm.NewStyle(pterm.FgLightRed)
+								box.Println(pterm.LightRed("🚨 SCORE THRESHOLD FAILED 🚨"))
+								pterm.Println()
+							} else {
+								pterm.Println(pterm.LightRed("\n> 🚨 SCORE THRESHOLD FAILED, PIPELINE WILL FAIL 🚨\n"))
+
+							}
+							return fmt.Errorf("score threshold failed, overall score is %d, and the threshold is %d", stats.OverallScore, minScore)
 						}
-						return fmt.Errorf("score threshold failed, overall score is %d, and the threshold is %d", stats.OverallScore, minScore)
 					}
 				}
-			}
 
-			if len(errs) > 0 {
-				return errors.Join(errs...)
+				// warnings are capped across every file linted, not per file.
+				if wErr := model.NewFailureThreshold(failSeverityFlag, maxWarningsFlag).CheckWarnings(totalWarnings); wErr != nil {
+					if !silent {
+						pterm.Error.Println(wErr.Error())
+						pterm.Println()
+					}
+					errs = append(errs, wErr)
+				}
+
+				if len(errs) > 0 {
+					return errors.Join(errs...)
+				}
+
+				return nil
 			}
 
-			return nil
+			if watchFlag {
+				if fixFlag {
+					pterm.Error.Println("Watch mode cannot be used with --fix, fixing a file would trigger another run")
+					pterm.Println()
+					return errors.New("watch mode cannot be used with --fix")
+				}
+				if len(specSources) > 0 {
+					pterm.Error.Println("Watch mode can only be used with local files, not stdin or remote specifications")
+					pterm.Println()
+					return errors.New("watch mode can only be used with local files")
+				}
+				return watchAndLint(cmd.Context(), filesToLint, baseFlag, time.Duration(debounceFlag)*time.Millisecond, runLint)
+			}
+			return runLint()
 		},
 	}
 
 	cmd.Flags().BoolP("details", "d", false, "Show full details of linting report")
 	cmd.Flags().BoolP("snippets", "s", false, "Show code snippets where issues are found")
+	cmd.Flags().Int("context-lines", model.DefaultCodeFrameContextLines,
+		"Lines of source shown before and after a result in code snippets (-s, and the html, junit and template formats)")
+	cmd.Flags().Int("max-line-length", model.DefaultCodeFrameMaxLineLength,
+		"Cut lines of code snippets off after this many characters, 0 keeps lines whole")
 	cmd.Flags().BoolP("errors", "e", false, "Show errors only")
 	cmd.Flags().StringP("category", "c", "", "Show a single category of results")
 	cmd.Flags().BoolP("silent", "x", false, "Show nothing except the result.")
@@ -382,16 +813,55 @@ func GetLintCommand() *cobra.Command {
 	cmd.Flags().BoolP("no-message", "m", false, "Hide the message output when using -d to show details")
 	cmd.Flags().BoolP("all-results", "a", false, "Render out all results, regardless of the number when using -d")
 	cmd.Flags().StringP("fail-severity", "n", model.SeverityError, "Results of this level or above will trigger a failure exit code (e.g. 'info', 'warn', 'error' or 'none')")
+	cmd.Flags().Int("max-warnings", -1, "Trigger a failure exit code when there are more warnings than this (across all files), -1 disables")
 	cmd.Flags().Bool("ignore-array-circle-ref", false, "Ignore circular array references")
 	cmd.Flags().Bool("ignore-polymorph-circle-ref", false, "Ignore circular polymorphic references")
 	cmd.Flags().String("ignore-file", "", "Path to ignore file")
+	cmd.Flags().StringArray("header", nil, "HTTP header to send when fetching a remote specification, 'Name: Value' (repeatable)")
+	cmd.Flags().Int("fetch-timeout", 30, "Timeout in seconds for fetching a remote specification")
+	cmd.Flags().Bool("watch", false, "Watch the specification (and any local references) and re-lint on every change")
+	cmd.Flags().Int("debounce", 300, "Milliseconds to wait for changes to settle before re-linting in watch mode")
+	cmd.Flags().String("baseline", "", "Path to a baseline file, only findings not in the baseline are reported")
+	cmd.Flags().Bool("fix", false, "Apply safe fixes for fixable rules, and write the specification back")
+	cmd.Flags().Bool("dry-run", false, "Used with --fix, print a diff of the fixes instead of writing them")
 	cmd.Flags().Bool("no-clip", false, "Do not truncate messages or paths (no '...')")
+	cmd.Flags().String("group-by", "", fmt.Sprintf("Render results in groups, with a header and counts for each group %v", GroupByOptions))
+	cmd.Flags().String("sort", string(model.OrderByLine), fmt.Sprintf("Sort results by %v", model.ResultOrders))
+	cmd.Flags().Int("max-results", 0, "Render no more than this many results in the details view, 0 renders them all")
 	cmd.Flags().Int("min-score", 10, "Throw an error return code if the score is below this value")
 	cmd.Flags().Bool("show-rules", false, "Show which rules are being used when linting")
+	cmd.Flags().Bool("schema", false, "Lint files as standalone JSON Schema documents, instead of OpenAPI")
 	cmd.Flags().Bool("pipeline-output", false, "Renders CI/CD summary output, suitable for pipelines (e.g. GitHub Actions, GitLab, etc.)")
+	cmd.Flags().Int("max-annotations", vacuum_report.GitHubAnnotationLimit,
+		"Maximum number of annotations of each level rendered by '--format github', 0 renders everything")
+	cmd.Flags().String("format", "", fmt.Sprintf("Render results in a machine-readable format instead of the console output %v", LintFormats))
+	addJUnitFlags(cmd, ", used with '--format junit'")
+	cmd.Flags().String("template", "", "Path to a Go template that renders the report, used with '--format template'")
+	cmd.Flags().String("html-bundle", "", "Write an html report for every specification into this directory, with an index page, used with '--format html'")
 
 	// TODO: Add globbed-files flag to other commands as well
 	cmd.Flags().String("globbed-files", "", "Glob pattern of files to lint")
+	cmd.Flags().StringArray("exclude", nil, "Glob pattern of files to skip when linting, e.g. 'specs/legacy/**' (repeatable)")
+	cmd.Flags().String("vacuumignore", "", "Path to an ignore file of files and rules to skip when linting many files (defaults to .vacuumignore, searched for from the working directory upwards)")
+	cmd.Flags().StringArray("rule-severity", nil, "Change the severity of a rule, e.g. 'operation-tags=warn' (repeatable)")
+	cmd.Flags().StringArray("disable-rule", nil, "Turn off a rule, e.g. 'operation-tags' (repeatable)")
+	cmd.Flags().Int("workers", 0, "Number of files to lint at the same time, defaults to the number of CPUs")
+	cmd.Flags().Bool("low-memory", false, "Use less memory for very large specifications, results no longer hold on to the document")
+	cmd.Flags().Bool("collapse-refs", false, "Report a problem with a component used ($ref) in many places once, with the places it is referenced from")
+	cmd.Flags().Bool("show-rule-timings", false, "Show how long each rule took to run and how many results it found, the slowest first")
+	cmd.Flags().StringSlice("tags", nil, "Only run rules with one of these tags, e.g. 'security,style'")
+	cmd.Flags().StringSlice("exclude-tags", nil, "Do not run rules with any of these tags, e.g. 'style'")
+	cmd.Flags().String("changed-since", "", "Only report results in lines changed since a git ref, e.g. 'origin/main'")
+	cmd.Flags().Bool("show-unchanged", false, "Used with --changed-since, report every result but only fail on results in changed lines")
+	cmd.Flags().Bool("changed-lines-only", false, "Used with '--format github' or '--format gitlab', only annotate results in lines the pull request changed, every result still counts")
+	cmd.Flags().String("full-report", "", "Write every result to a JSON report, to keep as a CI artifact when annotations are limited")
+	cmd.Flags().String("post-report", "", "POST the JSON report to this URL once linting is done, e.g. an API governance platform")
+	cmd.Flags().StringArray("post-report-header", nil, "Header to send with the report, e.g. 'X-Team: payments' (repeatable)")
+	cmd.Flags().String("post-report-token", "", "Bearer token to send with the report (or VACUUM_POST_REPORT_TOKEN)")
+	cmd.Flags().Int("post-report-retries", publish.DefaultWebhookRetries, "How many times to try posting the report again, if the endpoint is unavailable")
+	cmd.Flags().Bool("staged", false, "Lint the OpenAPI specifications staged for the next git commit, for pre-commit hooks")
+	cmd.Flags().String("gates-file", "", "Path to a quality gates file, gates are also read from 'gates' in the config file")
+	cmd.Flags().String("badge", "", "Write a quality badge for the score, shields.io endpoint JSON, or SVG if the file ends in '.svg'")
 
 	if regErr := cmd.RegisterFlagCompletionFunc("category", cobra.FixedCompletions([]string{
 		model.CategoryAll,
@@ -414,6 +884,22 @@ func GetLintCommand() *cobra.Command {
 	}, cobra.ShellCompDirectiveNoFileComp)); regErr != nil {
 		panic(regErr)
 	}
+	if regErr := cmd.RegisterFlagCompletionFunc("format", cobra.FixedCompletions(LintFormats,
+		cobra.ShellCompDirectiveNoFileComp)); regErr != nil {
+		panic(regErr)
+	}
+	if regErr := cmd.RegisterFlagCompletionFunc("group-by", cobra.FixedCompletions(GroupByOptions,
+		cobra.ShellCompDirectiveNoFileComp)); regErr != nil {
+		panic(regErr)
+	}
+	sortOrders := make([]string, len(model.ResultOrders))
+	for i, o := range model.ResultOrders {
+		sortOrders[i] = string(o)
+	}
+	if regErr := cmd.RegisterFlagCompletionFunc("sort", cobra.FixedCompletions(sortOrders,
+		cobra.ShellCompDirectiveNoFileComp)); regErr != nil {
+		panic(regErr)
+	}
 	if regErr := cmd.RegisterFlagCompletionFunc("globbed-files", cobra.NoFileCompletions); regErr != nil {
 		panic(regErr)
 	}
@@ -422,8 +908,18 @@ func GetLintCommand() *cobra.Command {
 }
 
 func lintFile(req utils.LintFileRequest) (*reports.ReportStatistics, int64, int, error) {
-	// read file.
-	specBytes, ferr := os.ReadFile(req.FileName)
+	started := time.Now()
+	// read file, unless it has already been read from stdin.
+	specFileName := req.FileName
+	specBytes := req.SpecBytes
+	var ferr error
+	if specBytes == nil {
+		specBytes, ferr = os.ReadFile(req.FileName)
+	} else {
+		specFileName = ""
+	}
+
+	req.SelectedRS = SelectRuleSetForSpec(specBytes, req.SelectedRS, req.AsyncAPIRuleSet)
 
 	// split up file into an array with lines.
 	specStringData := strings.Split(string(specBytes), "\n")
@@ -436,19 +932,26 @@ func lintFile(req utils.LintFileRequest) (*reports.ReportStatistics, int64, int,
 
 	}
 
+	changes, cErr := changedSince(req, specFileName, specBytes)
+	if cErr != nil {
+		return nil, 0, 0, cErr
+	}
+
 	deepGraph := false
 	if req.IgnoredResults != nil && len(req.IgnoredResults) > 0 {
 		deepGraph = true
 	}
 
-	result := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+	execution := &motor.RuleSetExecution{
 		RuleSet:                         req.SelectedRS,
 		Spec:                            specBytes,
-		SpecFileName:                    req.FileName,
+		SpecFileName:                    specFileName,
+		SpecDisplayName:                 req.FileName, // stdin or a URL, when the spec has no file.
 		CustomFunctions:                 req.Functions,
 		Base:                            req.BaseFlag,
 		AllowLookup:                     req.Remote,
 		SkipDocumentCheck:               req.SkipCheckFlag,
+		DocumentKind:                    req.DocumentKind,
 		Logger:                          req.Logger,
 		BuildDeepGraph:                  deepGraph,
 		Timeout:                         time.Duration(req.TimeoutFlag) * time.Second,
@@ -456,11 +959,48 @@ func lintFile(req utils.LintFileRequest) (*reports.ReportStatistics, int64, int,
 		IgnoreCircularPolymorphicRef:    req.IgnorePolymorphCircleRef,
 		ExtractReferencesFromExtensions: req.ExtensionRefs,
 		HTTPClientConfig:                req.HTTPClientConfig,
-	})
+		LowMemory:                       req.LowMemory,
+		CollapseReferencedResults:       req.CollapseRefs,
+	}
+	result := motor.ApplyRulesToRuleSet(execution)
 
 	result.Results = utils.FilterIgnoredResults(result.Results, req.IgnoredResults)
+	result.Results = req.VacuumIgnore.FilterResults(req.FileName, result.Results)
+	result.Results = utils.FilterBaselineResults(result.Results, req.Baseline)
+	if !req.ShowUnchanged {
+		result.Results = utils.FilterChangedResults(result.Results, changes)
+	}
+
+	if req.Fix && len(result.Errors) == 0 {
+		fixed, fErr := fixFile(req, specBytes, result.Results)
+		if fErr != nil {
+			return nil, result.FileSize, result.FilesProcessed, fErr
+		}
+		if fixed != nil {
+			// lint the fixed specification, so the report reflects what is now on disk.
+			specBytes = fixed
+			specStringData = strings.Split(string(specBytes), "\n")
+			execution.Spec = specBytes
+			result = motor.ApplyRulesToRuleSet(execution)
+			result.Results = utils.FilterIgnoredResults(result.Results, req.IgnoredResults)
+			result.Results = req.VacuumIgnore.FilterResults(req.FileName, result.Results)
+			result.Results = utils.FilterBaselineResults(result.Results, req.Baseline)
+			if changes, cErr = changedSince(req, specFileName, specBytes); cErr != nil {
+				return nil, result.FileSize, result.FilesProcessed, cErr
+			}
+			if !req.ShowUnchanged {
+				result.Results = utils.FilterChangedResults(result.Results, changes)
+			}
+		}
+	}
 
 	if len(result.Errors) > 0 {
+		if req.Logger != nil {
+			for _, err := range result.Errors {
+				req.Logger.Error("unable to process specification", "document", req.FileName, "error", err)
+			}
+		}
+		waitForTurn(req)
 		for _, err := range result.Errors {
 			pterm.Error.Printf("unable to process spec '%s', error: %s", req.FileName, err.Error())
 			pterm.Println()
@@ -469,34 +1009,27 @@ func lintFile(req utils.LintFileRequest) (*reports.ReportStatistics, int64, int,
 	}
 
 	resultSet := model.NewRuleResultSet(result.Results)
+	resultSet.SetSuppressedResults(result.Suppressed)
+
+	if req.SelectedRS != nil {
+		resultSet.RuleSetCategories = req.SelectedRS.Categories
+	}
 
 	var cats []*model.RuleCategory
+	documentCats := resultSet.GetRuleCategories()
+	if result.SpecInfo != nil {
+		documentCats = model.DocumentKindForFormat(result.SpecInfo.SpecFormat).RuleCategoriesWith(documentCats)
+	}
 
 	if req.CategoryFlag != "" {
 		resultSet.ResetCounts()
-		switch req.CategoryFlag {
-		case model.CategoryDescriptions:
-			cats = append(cats, model.RuleCategories[model.CategoryDescriptions])
-		case model.CategoryExamples:
-			cats = append(cats, model.RuleCategories[model.CategoryExamples])
-		case model.CategoryInfo:
-			cats = append(cats, model.RuleCategories[model.CategoryInfo])
-		case model.CategorySchemas:
-			cats = append(cats, model.RuleCategories[model.CategorySchemas])
-		case model.CategorySecurity:
-			cats = append(cats, model.RuleCategories[model.CategorySecurity])
-		case model.CategoryValidation:
-			cats = append(cats, model.RuleCategories[model.CategoryValidation])
-		case model.CategoryOperations:
-			cats = append(cats, model.RuleCategories[model.CategoryOperations])
-		case model.CategoryTags:
-			cats = append(cats, model.RuleCategories[model.CategoryTags])
-		case model.CategoryOWASP:
-			cats = append(cats, model.RuleCategories[model.CategoryOWASP])
-		default:
+		// built-in categories, or categories defined by the ruleset.
+		if cat := resultSet.GetRuleCategory(req.CategoryFlag); cat != nil && req.CategoryFlag != model.CategoryAll {
+			cats = append(cats, cat)
+		} else {
 			pterm.Warning.Printf("Category '%s' is unknown, all categories are being considered.\n", req.CategoryFlag)
 			pterm.Println()
-			cats = model.RuleCategoriesOrdered
+			cats = documentCats
 		}
 		// try a category print out.
 		for _, val := range cats {
@@ -513,17 +1046,73 @@ func lintFile(req utils.LintFileRequest) (*reports.ReportStatistics, int64, int,
 		}
 
 	} else {
-		cats = model.RuleCategoriesOrdered
+		cats = documentCats
 	}
 
-	resultSet.SortResultsByLineNumber()
+	resultSet.SortResults(req.SortOrder)
 	warnings := resultSet.GetWarnCount()
 	errs := resultSet.GetErrorCount()
 	informs := resultSet.GetInfoCount()
-	stats := statistics.CreateReportStatistics(result.Index, result.SpecInfo, resultSet)
+	if req.ShowUnchanged && changes != nil {
+		// every result is reported, but only results in changed lines can fail the run.
+		var changed []model.RuleFunctionResult
+		for _, r := range resultSet.Results {
+			if changes.Contains(r) {
+				changed = append(changed, *r)
+			}
+		}
+		changedSet := model.NewRuleResultSet(changed)
+		warnings, errs, informs = changedSet.GetWarnCount(), changedSet.GetErrorCount(), changedSet.GetInfoCount()
+	}
+	stats := statistics.CreateReportStatisticsWithScoring(result.Index, result.SpecInfo, resultSet, req.Scoring)
+	if req.Logger != nil {
+		req.Logger.Info("linted specification", "document", req.FileName, "duration", time.Since(started),
+			"errors", errs, "warnings", warnings, "info", informs)
+	}
+	// JUnit test cases are timed by the rule that found them.
+	if (req.ShowRuleTimings || req.Format == FormatJUnit) && stats != nil {
+		stats.RuleTimings = result.RuleTimings
+	}
+	// translated last, so baselines and ignores still match the English messages.
+	i18n.LocalizeResults(resultSet.Results)
+	if req.CodeFrames != nil {
+		resultSet.CaptureCodeFrames(req.FileName, specBytes, *req.CodeFrames)
+	}
 
+	waitForTurn(req)
 	req.Lock.Lock()
 	defer req.Lock.Unlock()
+
+	// annotations are limited to changed lines, the full report (and the exit code) still has every result.
+	annotated := resultSet
+	if req.ChangedLinesOnly != "" {
+		annotated, cErr = changedLinesOnly(req, specFileName, specBytes, resultSet)
+		if cErr != nil {
+			return stats, result.FileSize, result.FilesProcessed, cErr
+		}
+	}
+	if req.OnFullResults != nil {
+		resultSet.PrepareForSerialization(result.SpecInfo)
+		req.OnFullResults(req.FileName, resultSet, stats)
+	}
+
+	if req.Format != "" && req.OnResults != nil {
+		annotated.PrepareForSerialization(result.SpecInfo)
+		req.OnResults(req.FileName, specBytes, annotated, stats)
+		return stats, result.FileSize, result.FilesProcessed, CheckFailureSeverity(req.FailSeverityFlag, errs, warnings, informs)
+	}
+
+	if req.Format != "" {
+		if err := RenderFormattedReport(req, annotated, stats); err != nil {
+			return stats, result.FileSize, result.FilesProcessed, err
+		}
+		return stats, result.FileSize, result.FilesProcessed, CheckFailureSeverity(req.FailSeverityFlag, errs, warnings, informs)
+	}
+
+	if req.ShowRuleTimings && !req.Silent {
+		renderRuleTimings(req.FileName, result.RuleTimings)
+	}
+
 	if !req.DetailsFlag {
 
 		rso := RenderSummaryOptions{
@@ -547,7 +1136,7 @@ func lintFile(req utils.LintFileRequest) (*reports.ReportStatistics, int64, int,
 
 	if len(resultSet.Results) > 0 {
 		processResults(
-			resultSet.Results,
+			resultSet.PageResults(0, req.MaxResults),
 			specStringData,
 			req.SnippetsFlag,
 			req.ErrorsFlag,
@@ -557,7 +1146,13 @@ func lintFile(req utils.LintFileRequest) (*reports.ReportStatistics, int64, int,
 			req.NoClip,
 			abs,
 			req.FileName,
-			req.CategoryFlag)
+			req.CategoryFlag,
+			req.GroupBy)
+		if hidden := len(resultSet.Results) - req.MaxResults; req.MaxResults > 0 && hidden > 0 && !req.Silent {
+			pterm.Info.Printf("%s more results not rendered (--max-results %d)\n", humanize.Comma(int64(hidden)),
+				req.MaxResults)
+			pterm.Println()
+		}
 	}
 
 	rso := RenderSummaryOptions{
@@ -577,6 +1172,167 @@ func lintFile(req utils.LintFileRequest) (*reports.ReportStatistics, int64, int,
 	return stats, result.FileSize, result.FilesProcessed, CheckFailureSeverity(req.FailSeverityFlag, errs, warnings, informs)
 }
 
+// changedSince returns the lines of a specification that have changed since the --changed-since git ref, or nil if
+// every result should be reported. Specifications that are not local files (stdin, or URLs) are not diffed.
+func changedSince(req utils.LintFileRequest, specFileName string, specBytes []byte) (*utils.ChangeSet, error) {
+	if req.ChangedSince == "" || specFileName == "" {
+		return nil, nil
+	}
+	lines, err := utils.GitChangedLines(req.ChangedSince, specFileName)
+	if err != nil {
+		pterm.Error.Println(err.Error())
+		pterm.Println()
+		return nil, err
+	}
+	return utils.NewChangeSet(specBytes, lines), nil
+}
+
+// changedLinesOnly returns the results in lines changed since the --changed-lines-only git ref. Specifications that
+// are not local files (stdin, or URLs) are not diffed, every result is returned.
+func changedLinesOnly(req utils.LintFileRequest, specFileName string, specBytes []byte,
+	resultSet *model.RuleResultSet) (*model.RuleResultSet, error) {
+	changes, err := changedSince(utils.LintFileRequest{ChangedSince: req.ChangedLinesOnly}, specFileName, specBytes)
+	if err != nil || changes == nil {
+		return resultSet, err
+	}
+	var changed []*model.RuleFunctionResult
+	for _, r := range resultSet.Results {
+		if changes.Contains(r) {
+			changed = append(changed, r)
+		}
+	}
+	return model.NewRuleResultSetPointer(changed), nil
+}
+
+// pullRequestBase returns the git ref a pull request (or merge request) is diffed against in CI, or an empty string
+// if vacuum is not running for a pull request.
+func pullRequestBase() string {
+	if ref := os.Getenv("GITHUB_BASE_REF"); ref != "" {
+		return "origin/" + ref
+	}
+	if sha := os.Getenv("CI_MERGE_REQUEST_DIFF_BASE_SHA"); sha != "" {
+		return sha
+	}
+	if branch := os.Getenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME"); branch != "" {
+		return "origin/" + branch
+	}
+	return ""
+}
+
+// writeFullReport writes every result of every file linted to a JSON report.
+func writeFullReport(path string, files []*vacuum_report.FileReport) error {
+	var linted []*vacuum_report.FileReport
+	for _, f := range files {
+		if f != nil {
+			linted = append(linted, f)
+		}
+	}
+	if err := os.WriteFile(path, vacuum_report.BuildAggregatedJSONReport(linted, time.Now()), 0664); err != nil {
+		errText := fmt.Sprintf("unable to write the full report '%s': %s", path, err.Error())
+		pterm.Error.Println(errText)
+		pterm.Println()
+		return errors.New(errText)
+	}
+	return nil
+}
+
+// newReportPublisher creates the publisher that posts the JSON report to a URL, any problem is rendered before the
+// error is returned.
+func newReportPublisher(url string, headerFlags []string, token string, retries int,
+	clientConfig utils.HTTPClientConfig) (*publish.WebhookPublisher, error) {
+	if !strings.HasPrefix(url, "http://") && !strings.HasPrefix(url, "https://") {
+		errText := fmt.Sprintf("cannot post the report to '%s', it's not an http(s) URL", url)
+		pterm.Error.Println(errText)
+		pterm.Println()
+		return nil, errors.New(errText)
+	}
+	headers, err := ParseHeaderFlags(headerFlags)
+	if err != nil {
+		pterm.Error.Println(err.Error())
+		pterm.Println()
+		return nil, err
+	}
+	if _, ok := headers["User-Agent"]; !ok {
+		headers["User-Agent"] = fmt.Sprintf("vacuum/%s", Version)
+	}
+	client := &http.Client{Timeout: 30 * time.Second}
+	if utils.ShouldUseCustomHTTPClient(clientConfig) {
+		if client, err = utils.CreateCustomHTTPClient(clientConfig); err != nil {
+			pterm.Error.Printf("Failed to create custom HTTP client: %s\n", err.Error())
+			pterm.Println()
+			return nil, err
+		}
+		client.Timeout = 30 * time.Second
+	}
+	return &publish.WebhookPublisher{Client: client, URL: url, Token: token, Headers: headers,
+		Retries: max(retries, 0)}, nil
+}
+
+// postReport posts the JSON report of every file linted (the same as '--format json'). Files that failed to lint
+// are nil and are skipped.
+func postReport(publisher *publish.WebhookPublisher, files []*vacuum_report.FileReport, silent bool) error {
+	var linted []*vacuum_report.FileReport
+	for _, f := range files {
+		if f != nil {
+			linted = append(linted, f)
+		}
+	}
+	attempts, err := publisher.Publish(vacuum_report.BuildAggregatedJSONReport(linted, time.Now()))
+	if err != nil {
+		errText := fmt.Sprintf("unable to post the report to '%s' (%d attempts): %s", publisher.URL, attempts, err.Error())
+		pterm.Error.Println(errText)
+		pterm.Println()
+		return errors.New(errText)
+	}
+	if !silent {
+		pterm.Success.Printf("Report posted to '%s'\n", publisher.URL)
+	}
+	return nil
+}
+
+// fixFile applies any fixes registered by rules to the specification. With a dry run the diff is printed and nothing
+// is returned, otherwise the file is written back and the fixed specification is returned.
+func fixFile(req utils.LintFileRequest, specBytes []byte, results []model.RuleFunctionResult) ([]byte, error) {
+	fr, err := motor.ApplyFixes(specBytes, req.FileName, results)
+	if err != nil {
+		pterm.Error.Printf("Unable to fix '%s': %s\n", req.FileName, err.Error())
+		pterm.Println()
+		return nil, err
+	}
+	if len(fr.Fixed) == 0 {
+		return nil, nil
+	}
+
+	waitForTurn(req)
+	req.Lock.Lock()
+	defer req.Lock.Unlock()
+
+	if req.DryRun {
+		fmt.Print(utils.UnifiedDiff(req.FileName, req.FileName, fr.Original, fr.Output, 3))
+		if !req.Silent {
+			pterm.Info.Printf("%d results in '%s' can be fixed automatically\n", len(fr.Fixed), req.FileName)
+			pterm.Println()
+		}
+		return nil, nil
+	}
+
+	if req.SpecBytes != nil {
+		err = fmt.Errorf("fixes can only be written to local files, use --dry-run to see the changes")
+		pterm.Error.Println(err.Error())
+		pterm.Println()
+		return nil, err
+	}
+	if err = os.WriteFile(req.FileName, fr.Output, 0664); err != nil {
+		pterm.Error.Printf("Unable to write fixes to '%s': %s\n", req.FileName, err.Error())
+		pterm.Println()
+		return nil, err
+	}
+	if !req.Silent {
+		pterm.Success.Printf("Fixed %d results in '%s'\n", len(fr.Fixed), req.FileName)
+		pterm.Println()
+	}
+	return fr.Output, nil
+}
 
 func processResults(results []*model.RuleFunctionResult,
 	specData []string,
@@ -587,7 +1343,8 @@ func processResults(results []*model.RuleFunctionResult,
 	allResults bool,
 	noClip bool,
 	abs, filename string,
-	categoryFlag string) {
+	categoryFlag string,
+	groupBy string) {
 
 	if allResults && len(results) > 1000 {
 		pterm.Warning.Printf("Formatting %s results - this could take a moment to render out in the terminal",
@@ -611,14 +1368,52 @@ func processResults(results []*model.RuleFunctionResult,
 		pterm.Println(pterm.LightMagenta(strings.Join(underline, "")))
 	}
 
+	if groupBy == "" {
+		renderResultsTable(results, specData, snippets, errors, silent, noMessage, allResults, noClip, filename)
+	} else if !silent {
+		grouped := results
+		if errors {
+			grouped = nil
+			for _, r := range results {
+				if resultSeverity(r) == model.SeverityError {
+					grouped = append(grouped, r)
+				}
+			}
+		}
+		for _, g := range groupResults(grouped, groupBy, filename) {
+			renderGroupHeader(g)
+			renderResultsTable(g.Results, specData, snippets, errors, silent, noMessage, allResults, noClip, filename)
+		}
+	}
+	if !silent {
+		renderRuleDocumentation(results, errors)
+	}
+
+}
+
+// renderResultsTable renders results as a table, or a table and a code snippet for each result.
+func renderResultsTable(results []*model.RuleFunctionResult,
+	specData []string,
+	snippets,
+	errors,
+	silent,
+	noMessage,
+	allResults bool,
+	noClip bool,
+	filename string) {
+
 	// if snippets are being used, we render a single table for a result and then a snippet, if not
 	// we just render the entire table, all rows.
 	var tableData [][]string
+	location, severity, message := i18n.T("table.location", "Location"), i18n.T("table.severity", "Severity"),
+		i18n.T("table.message", "Message")
+	rule, category, path := i18n.T("table.rule", "Rule"), i18n.T("table.category", "Category"),
+		i18n.T("table.path", "Path")
 	if !snippets {
-		tableData = [][]string{{"Location", "Severity", "Message", "Rule", "Category", "Path"}}
+		tableData = [][]string{{location, severity, message, rule, category, path}}
 	}
 	if noMessage {
-		tableData = [][]string{{"Location", "Severity", "Rule", "Category", "Path"}}
+		tableData = [][]string{{location, severity, rule, category, path}}
 	}
 
 	// width, height, err := terminal.GetSize(0)
@@ -677,6 +1472,9 @@ func processResults(results []*model.RuleFunctionResult,
 				m = fmt.Sprintf("%s...", r.Message[:80])
 			}
 		}
+		if len(r.ReferencedFrom) > 0 {
+			p = fmt.Sprintf("%s (referenced from %d places)", p, len(r.ReferencedFrom))
+		}
 		sev := "info"
 		if r.Rule != nil {
 			sev = r.Rule.Severity
@@ -702,37 +1500,57 @@ func processResults(results []*model.RuleFunctionResult,
 		}
 		if snippets && !silent {
 			_ = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
-			renderCodeSnippet(r, specData)
+			renderCodeSnippet(r, specData, filename)
 		}
 	}
 
 	if !snippets && !silent {
 		_ = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
 	}
-
 }
 
-func renderCodeSnippet(r *model.RuleFunctionResult, specData []string) {
-	// render out code snippet
-
-	if r.StartNode.Line-3 >= 0 {
-		pterm.Printf("%s %s %s\n", pterm.Gray(r.StartNode.Line-3), pterm.Gray("|"), specData[r.StartNode.Line-3])
-	} else {
-		pterm.Printf("\n")
+// renderRuleDocumentation lists a link to the documentation of every rule with a result, once per rule.
+func renderRuleDocumentation(results []*model.RuleFunctionResult, errorsOnly bool) {
+	links := make(map[string]string)
+	var ruleIds []string
+	for _, r := range results {
+		if r.Rule == nil || (errorsOnly && r.Rule.Severity != model.SeverityError) {
+			continue
+		}
+		if _, ok := links[r.Rule.Id]; !ok {
+			links[r.Rule.Id] = r.Rule.GetDocumentationURL()
+			ruleIds = append(ruleIds, r.Rule.Id)
+		}
 	}
-
-	if r.StartNode.Line-2 >= 1 {
-		pterm.Printf("%s %s %s\n", pterm.Gray(r.StartNode.Line-2), pterm.Gray("|"), specData[r.StartNode.Line-2])
+	if len(ruleIds) == 0 {
+		return
 	}
-	if r.StartNode.Line-1 >= 2 {
-		pterm.Printf("%s %s %s\n", pterm.LightRed(strconv.Itoa(r.StartNode.Line-1)),
-			pterm.Gray("|"), pterm.LightRed(specData[r.StartNode.Line-1]))
+	sort.Strings(ruleIds)
+	pterm.Println()
+	pterm.Println(pterm.LightMagenta("Rule documentation"))
+	for _, id := range ruleIds {
+		pterm.Printf("%s %s\n", pterm.LightCyan(id), pterm.Gray(links[id]))
 	}
-	pterm.Printf("%s %s %s\n", pterm.Gray(r.StartNode.Line), pterm.Gray("|"), specData[r.StartNode.Line])
+	pterm.Println()
+}
 
-	if r.StartNode.Line+1 <= len(specData) {
-		pterm.Printf("%s %s %s\n\n", pterm.Gray(r.StartNode.Line+1), pterm.Gray("|"), specData[r.StartNode.Line+1])
+// renderCodeSnippet renders the code frame of a result, the lines of the result are highlighted.
+func renderCodeSnippet(r *model.RuleFunctionResult, specData []string, filename string) {
+	frame := model.CodeFrameForResult(r, filename, specData, model.DefaultCodeFrameOptions)
+	if frame == nil {
+		pterm.Println()
+		return
 	}
+	for _, line := range strings.Split(strings.TrimSuffix(frame.String(), "\n"), "\n") {
+		gutter, source, _ := strings.Cut(line, " |") // empty lines have no space after the bar.
+		source = strings.TrimPrefix(source, " ")
+		if strings.HasPrefix(gutter, ">") || strings.TrimSpace(source) == "^" {
+			pterm.Printf("%s %s %s\n", pterm.LightRed(gutter), pterm.Gray("|"), pterm.LightRed(source))
+			continue
+		}
+		pterm.Printf("%s %s %s\n", pterm.Gray(gutter), pterm.Gray("|"), source)
+	}
+	pterm.Println()
 }
 
 type RenderSummaryOptions struct {
@@ -748,11 +1566,80 @@ type RenderSummaryOptions struct {
 	RenderRules    bool
 }
 
+// lintBase returns the base used to resolve references, remote specifications resolve against their own location
+// unless a base has been supplied.
+func lintBase(base, fileName string) string {
+	if base == "" && IsRemoteSpec(fileName) {
+		return remoteSpecBase(fileName)
+	}
+	return base
+}
+
+// StdinFileName is used as the name of a specification read from stdin.
+const StdinFileName = "stdin"
+
+// stdinIsPiped returns true if the command input is a pipe or a redirected file, rather than a terminal.
+func stdinIsPiped(cmd *cobra.Command) bool {
+	f, ok := cmd.InOrStdin().(*os.File)
+	if !ok {
+		// a reader has been supplied, so there is something to read.
+		return true
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return fi.Mode()&os.ModeNamedPipe != 0 || fi.Mode().IsRegular()
+}
+
+// stagedSpecifications returns the OpenAPI specifications staged for the next commit, relative to the working
+// directory. Staged files that are not specifications (like referenced fragments, or other YAML) are left out.
+func stagedSpecifications() ([]string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+	staged, err := utils.GitStagedFiles(cwd)
+	if err != nil {
+		return nil, err
+	}
+	var specs []string
+	for _, f := range staged {
+		if !utils.HasSpecificationExtension(f) {
+			continue
+		}
+		data, rErr := os.ReadFile(f)
+		if rErr != nil || !utils.IsOpenAPIDocument(data) {
+			continue
+		}
+		if rel, relErr := filepath.Rel(cwd, f); relErr == nil {
+			f = rel
+		}
+		specs = append(specs, f)
+	}
+	return specs, nil
+}
+
 // The user may pass in filenames, a glob pattern, or both.
 // We simply concatenate them together, and remove any duplicates we may find.
 func getFilesToLint(globPattern string, filepaths []string, validFileExtensions []string) ([]string, error) {
 	// Note that if some of the paths are absolute and the others are relative,
 	// then we turn all paths into relative ones.
+	// quoted arguments such as "specs/**/*.yaml" are not expanded by the shell, so expand them here.
+	var expanded []string
+	for _, p := range filepaths {
+		if IsRemoteSpec(p) || !utils.IsGlobPattern(p) {
+			expanded = append(expanded, p)
+			continue
+		}
+		matches, err := utils.ExpandGlob(p)
+		if err != nil {
+			return []string{}, err
+		}
+		expanded = append(expanded, matches...)
+	}
+	filepaths = expanded
+
 	if globPattern == "" {
 		return deduplicate(filepaths), nil
 	}
@@ -760,7 +1647,7 @@ func getFilesToLint(globPattern string, filepaths []string, validFileExtensions
 	var filesToLint = filepaths
 
 	// Get all files that match the glob pattern
-	matches, err := filepath.Glob(globPattern)
+	matches, err := utils.ExpandGlob(globPattern)
 	if err != nil {
 		return []string{}, err
 	}
@@ -779,6 +1666,29 @@ func getFilesToLint(globPattern string, filepaths []string, validFileExtensions
 	return filesToLint, nil
 }
 
+// excludeFiles removes any (local) files that match one of the exclude glob patterns.
+func excludeFiles(files []string, patterns []string) []string {
+	if len(patterns) == 0 {
+		return files
+	}
+	var kept []string
+	for _, f := range files {
+		excluded := false
+		if f != StdinFileName && !IsRemoteSpec(f) {
+			for _, p := range patterns {
+				if utils.MatchGlob(p, f) {
+					excluded = true
+					break
+				}
+			}
+		}
+		if !excluded {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
 func deduplicate(input []string) []string {
 	seen := make(map[string]bool)
 	deduplicated := []string{}
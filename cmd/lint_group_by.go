@@ -0,0 +1,153 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/utils"
+	"github.com/pterm/pterm"
+)
+
+// Results in the console output can be grouped by any of these.
+const (
+	GroupByPath     = "path"
+	GroupByRule     = "rule"
+	GroupBySeverity = "severity"
+	GroupByFile     = "file"
+)
+
+// GroupByOptions are all the ways results can be grouped in the console output.
+var GroupByOptions = []string{GroupByPath, GroupByRule, GroupBySeverity, GroupByFile}
+
+// groupOperationMethods are the methods of a path item that are operations, in the order they are listed.
+var groupOperationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace", "query"}
+
+var groupSeverities = []string{model.SeverityError, model.SeverityWarn, model.SeverityInfo, model.SeverityHint}
+
+// resultGroup is the results rendered under a single header, along with how many there are of each severity.
+type resultGroup struct {
+	Name     string
+	Results  []*model.RuleFunctionResult
+	Errors   int
+	Warnings int
+	Info     int
+	rank     groupRank
+}
+
+// groupRank sorts groups, by section first, then by name, then by the method of an operation.
+type groupRank struct {
+	section int
+	name    string
+	method  int
+}
+
+func (r groupRank) less(o groupRank) bool {
+	if r.section != o.section {
+		return r.section < o.section
+	}
+	if r.name != o.name {
+		return r.name < o.name
+	}
+	return r.method < o.method
+}
+
+// groupResults puts results into groups (by path, rule, severity or file). Results for an operation are grouped
+// under the operation (like 'GET /pets'), results elsewhere in a path under the path, and everything else under
+// the top level section of the document it is in (like 'components').
+func groupResults(results []*model.RuleFunctionResult, groupBy, fileName string) []*resultGroup {
+	groups := make(map[string]*resultGroup)
+	var ordered []*resultGroup
+	for _, r := range results {
+		name, rank := resultGroupKey(r, groupBy, fileName)
+		g, ok := groups[name]
+		if !ok {
+			g = &resultGroup{Name: name, rank: rank}
+			groups[name] = g
+			ordered = append(ordered, g)
+		}
+		g.Results = append(g.Results, r)
+		switch resultSeverity(r) {
+		case model.SeverityError:
+			g.Errors++
+		case model.SeverityWarn:
+			g.Warnings++
+		default:
+			g.Info++
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].rank.less(ordered[j].rank)
+	})
+	return ordered
+}
+
+func resultGroupKey(r *model.RuleFunctionResult, groupBy, fileName string) (string, groupRank) {
+	name := ""
+	switch groupBy {
+	case GroupByRule:
+		name = r.RuleId
+		if r.Rule != nil {
+			name = r.Rule.Id
+		}
+		return name, groupRank{name: name}
+	case GroupBySeverity:
+		name = resultSeverity(r)
+		return name, groupRank{section: slices.Index(groupSeverities, name)}
+	case GroupByFile:
+		name = relativePath(fileName)
+		if r.Origin != nil && r.Origin.AbsoluteLocation != "" {
+			name = relativePath(r.Origin.AbsoluteLocation)
+		}
+		return name, groupRank{name: name}
+	}
+	// paths first, then the other sections of the document, then results without a path.
+	segments := utils.JSONPathSegments(r.Path)
+	switch {
+	case len(segments) == 0:
+		return "(document)", groupRank{section: 2}
+	case segments[0] != "paths" || len(segments) == 1:
+		return segments[0], groupRank{section: 1, name: segments[0]}
+	case len(segments) > 2 && slices.Contains(groupOperationMethods, segments[2]):
+		return fmt.Sprintf("%s %s", strings.ToUpper(segments[2]), segments[1]),
+			groupRank{name: segments[1], method: slices.Index(groupOperationMethods, segments[2]) + 1}
+	}
+	return segments[1], groupRank{name: segments[1]}
+}
+
+func resultSeverity(r *model.RuleFunctionResult) string {
+	if r.Rule == nil || r.Rule.Severity == "" {
+		return model.SeverityInfo
+	}
+	return r.Rule.Severity
+}
+
+// relativePath makes a path relative to the working directory, if it can.
+func relativePath(path string) string {
+	if absPath, err := filepath.Abs(path); err == nil {
+		if cwd, err := os.Getwd(); err == nil {
+			if relPath, err := filepath.Rel(cwd, absPath); err == nil {
+				return relPath
+			}
+		}
+	}
+	return path
+}
+
+// renderGroupHeader prints the name of a group, and how many results there are of each severity.
+func renderGroupHeader(g *resultGroup) {
+	counts := []string{
+		pterm.LightRed(fmt.Sprintf("%d errors", g.Errors)),
+		pterm.LightYellow(fmt.Sprintf("%d warnings", g.Warnings)),
+		pterm.LightBlue(fmt.Sprintf("%d info", g.Info)),
+	}
+	pterm.Println()
+	pterm.Println(fmt.Sprintf("%s (%s)", pterm.Bold.Sprint(g.Name), strings.Join(counts, ", ")))
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+)
+
+func groupByTestResults() []*model.RuleFunctionResult {
+	errorRule := &model.Rule{Id: "operation-operationId", Severity: model.SeverityError}
+	warnRule := &model.Rule{Id: "operation-tags", Severity: model.SeverityWarn}
+	infoRule := &model.Rule{Id: "info-contact", Severity: model.SeverityInfo}
+	return []*model.RuleFunctionResult{
+		{Rule: warnRule, Path: "$.paths['/pets'].post.tags"},
+		{Rule: errorRule, Path: "$.paths['/pets'].get"},
+		{Rule: warnRule, Path: "$.paths['/pets'].get.tags"},
+		{Rule: warnRule, Path: "$.paths['/pets'].parameters[0]"},
+		{Rule: infoRule, Path: "$.info"},
+		{Rule: infoRule, Path: "$.components.schemas['Pet']"},
+		{Rule: infoRule, Path: ""},
+	}
+}
+
+func groupNames(groups []*resultGroup) []string {
+	var names []string
+	for _, g := range groups {
+		names = append(names, g.Name)
+	}
+	return names
+}
+
+func TestGroupResults_Path(t *testing.T) {
+	groups := groupResults(groupByTestResults(), GroupByPath, "spec.yaml")
+	assert.Equal(t, []string{"/pets", "GET /pets", "POST /pets", "components", "info", "(document)"}, groupNames(groups))
+
+	get := groups[1]
+	assert.Len(t, get.Results, 2)
+	assert.Equal(t, 1, get.Errors)
+	assert.Equal(t, 1, get.Warnings)
+	assert.Equal(t, 0, get.Info)
+}
+
+func TestGroupResults_Rule(t *testing.T) {
+	groups := groupResults(groupByTestResults(), GroupByRule, "spec.yaml")
+	assert.Equal(t, []string{"info-contact", "operation-operationId", "operation-tags"}, groupNames(groups))
+	assert.Equal(t, 3, groups[2].Warnings)
+}
+
+func TestGroupResults_Severity(t *testing.T) {
+	groups := groupResults(groupByTestResults(), GroupBySeverity, "spec.yaml")
+	assert.Equal(t, []string{model.SeverityError, model.SeverityWarn, model.SeverityInfo}, groupNames(groups))
+	assert.Equal(t, 3, groups[2].Info)
+}
+
+func TestGroupResults_File(t *testing.T) {
+	results := groupByTestResults()
+	results[0].Origin = &index.NodeOrigin{AbsoluteLocation: "components/pets.yaml"}
+	groups := groupResults(results, GroupByFile, "spec.yaml")
+	assert.Equal(t, []string{"components/pets.yaml", "spec.yaml"}, groupNames(groups))
+	assert.Len(t, groups[1].Results, 6)
+}
+
+func TestGetLintCommand_GroupBy(t *testing.T) {
+	for _, groupBy := range GroupByOptions {
+		cmd := GetLintCommand()
+		cmd.SetArgs([]string{"--group-by", groupBy, "-n", "none", "../model/test_files/burgershop.openapi.yaml"})
+		assert.NoError(t, cmd.Execute(), groupBy)
+	}
+
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{"--group-by", "owner", "../model/test_files/burgershop.openapi.yaml"})
+	assert.EqualError(t, cmd.Execute(), "unknown grouping 'owner', results can be grouped by [path rule severity file]")
+}
@@ -0,0 +1,81 @@
+// Copyright 2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/daveshanley/vacuum/utils"
+)
+
+// BearerTokenEnv is an environment variable that, when set, is sent as a bearer token when fetching remote
+// specifications. It will not replace an 'Authorization' header supplied with '--header'.
+const BearerTokenEnv = "VACUUM_BEARER_TOKEN"
+
+// IsRemoteSpec returns true if the specification is an http(s) URL.
+func IsRemoteSpec(spec string) bool {
+	return strings.HasPrefix(spec, "https://") || strings.HasPrefix(spec, "http://")
+}
+
+// remoteSpecBase returns the 'directory' of a remote specification, so relative references resolve against it.
+func remoteSpecBase(spec string) string {
+	u, err := url.Parse(spec)
+	if err != nil {
+		return ""
+	}
+	u.Path = path.Dir(u.Path)
+	u.RawQuery = ""
+	u.Fragment = ""
+	return u.String()
+}
+
+// fetchRemoteLintSpecs downloads every remote specification in the list of files to lint. Redirects are followed
+// by the client (up to ten of them), headers are re-sent to the same host.
+func fetchRemoteLintSpecs(files []string, headers map[string]string, timeout time.Duration,
+	clientConfig utils.HTTPClientConfig) (map[string][]byte, error) {
+
+	fetched := make(map[string][]byte)
+	var remote []string
+	for _, f := range files {
+		if IsRemoteSpec(f) {
+			remote = append(remote, f)
+		}
+	}
+	if len(remote) == 0 {
+		return fetched, nil
+	}
+
+	client := &http.Client{}
+	if utils.ShouldUseCustomHTTPClient(clientConfig) {
+		var err error
+		client, err = utils.CreateCustomHTTPClient(clientConfig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create custom HTTP client: %w", err)
+		}
+	}
+	client.Timeout = timeout
+
+	h := make(map[string]string, len(headers)+1)
+	if token := os.Getenv(BearerTokenEnv); token != "" {
+		h["Authorization"] = "Bearer " + token
+	}
+	for k, v := range headers {
+		h[k] = v
+	}
+
+	for _, f := range remote {
+		b, _, err := fetchRemoteSpec(client, f, "", h)
+		if err != nil {
+			return nil, fmt.Errorf("unable to fetch '%s': %w", f, err)
+		}
+		fetched[f] = b
+	}
+	return fetched, nil
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteSpecBase(t *testing.T) {
+	assert.Equal(t, "https://example.com/specs", remoteSpecBase("https://example.com/specs/api.yaml?v=1"))
+	assert.Equal(t, "https://example.com/specs", lintBase("", "https://example.com/specs/api.yaml"))
+	assert.Equal(t, "/tmp", lintBase("/tmp", "https://example.com/specs/api.yaml"))
+	assert.Equal(t, "", lintBase("", "api.yaml"))
+}
+
+func TestFetchRemoteLintSpecs(t *testing.T) {
+	var auth, team string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/old.yaml" {
+			http.Redirect(w, r, "/new.yaml", http.StatusMovedPermanently)
+			return
+		}
+		auth = r.Header.Get("Authorization")
+		team = r.Header.Get("X-Team")
+		_, _ = w.Write([]byte("openapi: 3.1.0"))
+	}))
+	defer server.Close()
+
+	t.Setenv(BearerTokenEnv, "secret")
+	fetched, err := fetchRemoteLintSpecs([]string{"local.yaml", server.URL + "/old.yaml"},
+		map[string]string{"X-Team": "platform"}, time.Second, utils.HTTPClientConfig{})
+	assert.NoError(t, err)
+	assert.Len(t, fetched, 1)
+	assert.Equal(t, "openapi: 3.1.0", string(fetched[server.URL+"/old.yaml"]))
+	assert.Equal(t, "Bearer secret", auth)
+	assert.Equal(t, "platform", team)
+}
+
+func TestFetchRemoteLintSpecs_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	_, err := fetchRemoteLintSpecs([]string{server.URL + "/api.yaml"}, nil, time.Second, utils.HTTPClientConfig{})
+	assert.Error(t, err)
+}
+
+func TestGetLintCommand_RemoteSpec(t *testing.T) {
+	spec, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer 123" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_, _ = w.Write(spec)
+	}))
+	defer server.Close()
+
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{"-x", "-n", "none", "--header", "Authorization: Bearer 123", server.URL + "/burgershop.yaml"})
+	assert.NoError(t, cmd.Execute())
+
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{"-x", server.URL + "/burgershop.yaml"})
+	assert.Error(t, cmd.Execute())
+}
@@ -0,0 +1,313 @@
+// Copyright 2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	html_report "github.com/daveshanley/vacuum/html-report"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/statistics"
+	"github.com/daveshanley/vacuum/utils"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+const (
+	FormatSARIF       = "sarif"
+	FormatGitLab      = "gitlab"
+	FormatCheckstyle  = "checkstyle"
+	FormatGitHub      = "github"
+	FormatMarkdown    = "markdown"
+	FormatJUnit       = "junit"
+	FormatJSON        = "json"
+	FormatHTML        = "html"
+	FormatOperations  = "operations"
+	FormatTemplate    = "template"
+	FormatOpenMetrics = "openmetrics"
+	FormatTAP         = "tap"
+)
+
+// LintFormats are all the machine-readable formats the lint command can render, instead of the console output.
+var LintFormats = []string{FormatSARIF, FormatGitLab, FormatCheckstyle, FormatGitHub, FormatMarkdown,
+	FormatJUnit, FormatJSON, FormatHTML, FormatOperations, FormatTemplate, FormatOpenMetrics, FormatTAP}
+
+// IsAggregatedFormat returns true if the format renders a single document for every file linted. When linting
+// many files, results for these formats are collected and rendered together once all the files are done.
+func IsAggregatedFormat(format string) bool {
+	switch format {
+	case FormatSARIF, FormatGitLab, FormatCheckstyle, FormatJUnit, FormatJSON, FormatHTML, FormatTemplate,
+		FormatOpenMetrics, FormatTAP:
+		return true
+	}
+	return false
+}
+
+// RendersCodeFrames returns true if the format renders the source around every result.
+func RendersCodeFrames(format string) bool {
+	switch format {
+	case FormatJUnit, FormatHTML, FormatTemplate:
+		return true
+	}
+	return false
+}
+
+// IsValidLintFormat returns true if the format is known to the lint command.
+func IsValidLintFormat(format string) bool {
+	for _, f := range LintFormats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// RenderFormattedReport renders a result set in the format requested by the lint request, straight to stdout.
+func RenderFormattedReport(req utils.LintFileRequest, resultSet *model.RuleResultSet, stats *reports.ReportStatistics) error {
+	switch req.Format {
+	case FormatSARIF:
+		fmt.Println(string(vacuum_report.BuildSARIFReport(resultSet, req.FileName, Version)))
+	case FormatGitLab:
+		fmt.Println(string(vacuum_report.BuildCodeQualityReport(resultSet, req.FileName)))
+	case FormatCheckstyle:
+		fmt.Print(string(vacuum_report.BuildCheckstyleReport(resultSet, req.FileName)))
+	case FormatGitHub:
+		// GitHub caps annotations of each level per step, not per file, so the limit is shared by every file linted.
+		fmt.Print(string(vacuum_report.BuildGitHubAnnotations(resultSet, req.FileName, req.MaxAnnotations,
+			req.AnnotationCount)))
+	case FormatMarkdown:
+		fmt.Print(string(vacuum_report.BuildMarkdownReport(resultSet, stats, req.FileName)))
+	case FormatOperations:
+		var operations []*reports.OperationStatistic
+		if stats != nil {
+			operations = stats.OperationStatistics
+		} else {
+			operations = statistics.CreateOperationStatistics(nil, resultSet)
+		}
+		return renderOperationStatistics(req.FileName, operations)
+	case FormatJUnit, FormatJSON, FormatHTML, FormatTemplate, FormatOpenMetrics, FormatTAP:
+		return RenderAggregatedReport(req.Format, []*vacuum_report.FileReport{
+			{FileName: req.FileName, Statistics: stats, ResultSet: resultSet},
+		}, time.Now(), JUnitConfigForRequest(req), req.ReportTemplate, req.HTMLBundle)
+	default:
+		return fmt.Errorf("unknown format '%s', supported formats are %v", req.Format, LintFormats)
+	}
+	return nil
+}
+
+// renderOperationStatistics renders the score and findings of each operation as a table, the operations with the
+// lowest score first.
+func renderOperationStatistics(fileName string, operations []*reports.OperationStatistic) error {
+	sorted := make([]*reports.OperationStatistic, len(operations))
+	copy(sorted, operations)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Score < sorted[j].Score })
+
+	if fileName != "" {
+		fmt.Printf("Operations in '%s'\n\n", fileName)
+	}
+	if len(sorted) == 0 {
+		fmt.Println("No operations found")
+		return nil
+	}
+	tableData := pterm.TableData{{"Score", "Method", "Path", "Operation ID", "Errors", "Warnings", "Info", "Hints"}}
+	for _, op := range sorted {
+		tableData = append(tableData, []string{fmt.Sprint(op.Score), strings.ToUpper(op.Method), op.Path,
+			op.OperationId, fmt.Sprint(op.Errors), fmt.Sprint(op.Warnings), fmt.Sprint(op.Info), fmt.Sprint(op.Hints)})
+	}
+	return pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+}
+
+// JUnitConfigForRequest returns the JUnit config for a lint request, failures are decided by the fail severity
+// unless the request sets a JUnit specific one.
+func JUnitConfigForRequest(req utils.LintFileRequest) *vacuum_report.JUnitReportConfig {
+	failOn := req.FailSeverityFlag
+	if req.JUnitFailOn != "" {
+		failOn = req.JUnitFailOn
+	}
+	return &vacuum_report.JUnitReportConfig{
+		FailureThreshold: model.NewFailureThreshold(failOn, req.MaxWarnings),
+		GroupBy:          req.JUnitGroupBy,
+		FailureTemplate:  req.JUnitTemplate,
+		SeverityOutcomes: req.JUnitSeverityOutcomes,
+		CodeFrames:       req.CodeFrames,
+	}
+}
+
+// readJUnitFlags reads and checks the JUnit flags of a command into the request, any problem is rendered before
+// the error is returned.
+func readJUnitFlags(cmd *cobra.Command, req *utils.LintFileRequest) error {
+	req.JUnitGroupBy, _ = cmd.Flags().GetString("junit-group-by")
+	req.JUnitFailOn, _ = cmd.Flags().GetString("junit-fail-on")
+	templateFlag, _ := cmd.Flags().GetString("junit-template")
+	severityMapFlag, _ := cmd.Flags().GetStringSlice("junit-severity-map")
+
+	if req.JUnitGroupBy != "" && !vacuum_report.IsValidJUnitGroupBy(req.JUnitGroupBy) {
+		pterm.Error.Printf("Unknown JUnit grouping '%s', supported groupings are %v\n",
+			req.JUnitGroupBy, vacuum_report.JUnitGroupByOptions)
+		pterm.Println()
+		return fmt.Errorf("unknown junit grouping '%s'", req.JUnitGroupBy)
+	}
+	switch req.JUnitFailOn {
+	case "", model.SeverityError, model.SeverityWarn, model.SeverityInfo, model.SeverityHint, model.SeverityNone:
+	default:
+		pterm.Error.Printf("Unknown JUnit failure severity '%s'\n", req.JUnitFailOn)
+		pterm.Println()
+		return fmt.Errorf("unknown junit failure severity '%s'", req.JUnitFailOn)
+	}
+	if templateFlag != "" {
+		tmpl, err := readJUnitTemplate(templateFlag)
+		if err != nil {
+			pterm.Error.Printf("Unable to use JUnit template '%s': %s\n", templateFlag, err.Error())
+			pterm.Println()
+			return err
+		}
+		req.JUnitTemplate = tmpl
+	}
+	if len(severityMapFlag) > 0 {
+		outcomes, err := vacuum_report.ParseJUnitSeverityMap(severityMapFlag)
+		if err != nil {
+			pterm.Error.Printf("Unable to use JUnit severity map: %s\n", err.Error())
+			pterm.Println()
+			return err
+		}
+		req.JUnitSeverityOutcomes = outcomes
+	}
+	return nil
+}
+
+// readReportTemplateFlag reads the report template of the template format into the request, the format and the
+// template are only used together. Any problem is rendered before the error is returned.
+func readReportTemplateFlag(cmd *cobra.Command, format string, req *utils.LintFileRequest) error {
+	templateFlag, _ := cmd.Flags().GetString("template")
+	if format == FormatTemplate && templateFlag == "" {
+		errText := "'--format template' needs a template to render, use '--template <file>'"
+		pterm.Error.Println(errText)
+		pterm.Println()
+		return errors.New(errText)
+	}
+	if templateFlag == "" {
+		return nil
+	}
+	if format != FormatTemplate {
+		errText := "'--template' is rendered by the template format, use it with '--format template'"
+		pterm.Error.Println(errText)
+		pterm.Println()
+		return errors.New(errText)
+	}
+	tmpl, err := readReportTemplate(templateFlag)
+	if err != nil {
+		pterm.Error.Printf("Unable to use report template '%s': %s\n", templateFlag, err.Error())
+		pterm.Println()
+		return err
+	}
+	req.ReportTemplate = tmpl
+	return nil
+}
+
+// addJUnitFlags adds the flags read by readJUnitFlags to a command.
+func addJUnitFlags(cmd *cobra.Command, usedWith string) {
+	cmd.Flags().String("junit-template", "", "Path to a Go template for the contents of JUnit failures"+usedWith)
+	cmd.Flags().String("junit-group-by", "", fmt.Sprintf("Group JUnit test suites by %v", vacuum_report.JUnitGroupByOptions)+usedWith)
+	cmd.Flags().String("junit-fail-on", "", "Results of this level or above are JUnit failures, anything below is skipped"+usedWith)
+	cmd.Flags().StringSlice("junit-severity-map", nil,
+		"Map severities to JUnit outcomes, e.g. 'warn=skipped,info=pass' (failure, skipped or pass)"+usedWith)
+}
+
+// RenderAggregatedReport renders the results of every file linted as a single document, straight to stdout.
+// Files that failed to lint are nil and are skipped. The JUnit config decides what counts as a failure, and how
+// test suites are grouped. The report template is only used by the template format, and the html bundle (a
+// directory to write a report for each file to, with an index page) by the html format.
+func RenderAggregatedReport(format string, files []*vacuum_report.FileReport, start time.Time,
+	junitConfig *vacuum_report.JUnitReportConfig, reportTemplate, htmlBundle string) error {
+	var linted []*vacuum_report.FileReport
+	for _, f := range files {
+		if f != nil {
+			linted = append(linted, f)
+		}
+	}
+	switch format {
+	case FormatSARIF:
+		fmt.Println(string(vacuum_report.BuildSARIFReportForFiles(linted, Version)))
+	case FormatGitLab:
+		fmt.Println(string(vacuum_report.BuildCodeQualityReportForFiles(linted)))
+	case FormatCheckstyle:
+		fmt.Print(string(vacuum_report.BuildCheckstyleReportForFiles(linted)))
+	case FormatJUnit:
+		fmt.Print(string(vacuum_report.BuildJUnitReportForFiles(linted, start, junitConfig)))
+	case FormatJSON:
+		fmt.Println(string(vacuum_report.BuildAggregatedJSONReport(linted, time.Now())))
+	case FormatHTML:
+		return renderHTMLReports(linted, htmlBundle, start)
+	case FormatOpenMetrics:
+		fmt.Print(string(vacuum_report.BuildOpenMetricsReportForFiles(linted, time.Since(start), Version)))
+	case FormatTAP:
+		// failures are decided the same way as JUnit failures, by the fail severity.
+		var threshold *model.FailureThreshold
+		if junitConfig != nil {
+			threshold = junitConfig.FailureThreshold
+		}
+		fmt.Print(string(vacuum_report.BuildTAPReportForFiles(linted, threshold)))
+	case FormatTemplate:
+		out, err := vacuum_report.BuildTemplateReportForFiles(reportTemplate, linted, time.Now(), Version)
+		if err != nil {
+			return fmt.Errorf("unable to render the report template: %w", err)
+		}
+		fmt.Print(string(out))
+	default:
+		return fmt.Errorf("format '%s' cannot be aggregated", format)
+	}
+	return nil
+}
+
+// renderHTMLReports renders the html report of a single file straight to stdout, or writes a report for every file
+// into a bundle directory, along with an index page that links to each one.
+func renderHTMLReports(files []*vacuum_report.FileReport, bundle string, start time.Time) error {
+	if bundle == "" {
+		if len(files) != 1 {
+			return fmt.Errorf("'--format html' renders a single specification, use '--html-bundle <dir>' to "+
+				"write a report for each of the %d specifications, with an index page", len(files))
+		}
+		fmt.Print(string(buildFileHTMLReport(files[0])))
+		return nil
+	}
+	byName := make(map[string]*vacuum_report.FileReport, len(files))
+	names := make([]string, 0, len(files))
+	for _, f := range files {
+		byName[f.FileName] = f
+		names = append(names, f.FileName)
+	}
+	return generateHTMLBundle(bundle, names,
+		func(name string) ([]byte, *reports.ReportStatistics, *model.RuleResultSet, error) {
+			f := byName[name]
+			return buildFileHTMLReport(f), f.Statistics, f.ResultSet, nil
+		}, nil, false, false, start)
+}
+
+// buildFileHTMLReport renders the html report of a file that was linted, any statistics that are missing are
+// worked out from the results.
+func buildFileHTMLReport(f *vacuum_report.FileReport) []byte {
+	resultSet := f.ResultSet
+	if resultSet == nil {
+		resultSet = model.NewRuleResultSetPointer(nil)
+	}
+	stats := f.Statistics
+	if stats == nil {
+		stats = &reports.ReportStatistics{
+			OverallScore:  statistics.CalculateQualityScore(resultSet),
+			TotalErrors:   resultSet.GetErrorCount(),
+			TotalWarnings: resultSet.GetWarnCount(),
+			TotalInfo:     resultSet.GetInfoCount(),
+		}
+	}
+	spec := f.Spec
+	info := &datamodel.SpecInfo{SpecBytes: &spec, Generated: time.Now()}
+	return html_report.NewHTMLReport(nil, info, resultSet, stats, false).GenerateReport(false, Version)
+}
@@ -5,7 +5,9 @@ package cmd
 
 import (
 	"fmt"
+	"github.com/daveshanley/vacuum/i18n"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/utils"
 	"github.com/dustin/go-humanize"
 	"github.com/pterm/pterm"
@@ -23,7 +25,8 @@ func RenderSummary(rso RenderSummaryOptions) {
 	sev := rso.Severity
 
 	// headers: a slice of column names
-	headers := []string{"Category", "Errors", "Warnings", "Info"}
+	headers := []string{i18n.T("table.category", "Category"), i18n.T("summary.errors", "Errors"),
+		i18n.T("summary.warnings", "Warnings"), i18n.T("summary.info", "Info")}
 
 	// rows: each inner slice is one row of table data
 	var rows [][]string
@@ -34,7 +37,7 @@ func RenderSummary(rso RenderSummaryOptions) {
 
 		if len(errors) > 0 || len(warn) > 0 || len(info) > 0 {
 			rows = append(rows, []string{
-				cat.Name,
+				i18n.CategoryName(cat),
 				fmt.Sprintf("%v", humanize.Comma(int64(len(errors)))), // e.g. "1,234"
 				fmt.Sprintf("%v", humanize.Comma(int64(len(warn)))),   // e.g. "56"
 				fmt.Sprintf("%v", humanize.Comma(int64(len(info)))),   // e.g. "7"
@@ -137,6 +140,12 @@ func RenderSummary(rso RenderSummaryOptions) {
 			warnRuleMap := make(map[string]int)
 			infoRuleMap := make(map[string]int)
 
+			// rule ids link to the documentation for the rule.
+			ruleLinks := make(map[string]string)
+			for _, r := range append(append(append([]*model.RuleFunctionResult{}, catErrs...), warn...), info...) {
+				ruleLinks[r.Rule.Id] = fmt.Sprintf("[%s](%s)", r.Rule.Id, r.Rule.GetDocumentationURL())
+			}
+
 			checkMap := func(ruleId string, ruleMap map[string]int) {
 				if _, ok := ruleMap[ruleId]; !ok {
 					ruleMap[ruleId] = 1
@@ -165,7 +174,7 @@ func RenderSummary(rso RenderSummaryOptions) {
 				var errData [][]string
 				for ruleId, count := range errorRuleMap {
 					if count > 0 {
-						buf.WriteString(fmt.Sprintf("%s %s : %d\n\n", errIcon, ruleId, count))
+						buf.WriteString(fmt.Sprintf("%s %s : %d\n\n", errIcon, ruleLinks[ruleId], count))
 					}
 					for _, v := range catErrs {
 						errData = append(errData, []string{fmt.Sprintf("`%d:%d`", v.StartNode.Line, v.StartNode.Column), v.Path})
@@ -179,7 +188,7 @@ func RenderSummary(rso RenderSummaryOptions) {
 				buf.WriteString(fmt.Sprintf("<details><summary>⚠️️ Warnings: %s</summary>\n", humanize.Comma(int64(len(warn)))))
 				for ruleId, count := range warnRuleMap {
 					if count > 0 {
-						buf.WriteString(fmt.Sprintf("⚠️️ %s: %d\n\n", ruleId, count))
+						buf.WriteString(fmt.Sprintf("⚠️️ %s: %d\n\n", ruleLinks[ruleId], count))
 					}
 					for _, v := range warn {
 						warnData = append(warnData, []string{fmt.Sprintf("`%d:%d`", v.StartNode.Line, v.StartNode.Column), v.Path})
@@ -193,7 +202,7 @@ func RenderSummary(rso RenderSummaryOptions) {
 				buf.WriteString(fmt.Sprintf("<details><summary>ℹ️️ Informs: %s</summary>\n\n", humanize.Comma(int64(len(info)))))
 				for ruleId, count := range infoRuleMap {
 					if count > 0 {
-						buf.WriteString(fmt.Sprintf("ℹ️️ %s: %d\n", ruleId, count))
+						buf.WriteString(fmt.Sprintf("ℹ️️ %s: %d\n", ruleLinks[ruleId], count))
 					}
 					for _, v := range info {
 						infoData = append(infoData, []string{fmt.Sprintf("`%d:%d`", v.StartNode.Line, v.StartNode.Column), v.Path})
@@ -212,6 +221,11 @@ func RenderSummary(rso RenderSummaryOptions) {
 			buf.WriteString(fmt.Sprint("✅ You have a perfect score! **Congratulations, you're doing it right.**\n\n"))
 		}
 
+		if rs.GetSuppressedCount() > 0 {
+			buf.WriteString(fmt.Sprintf("> `%d` results were suppressed by `%s` extensions\n\n",
+				rs.GetSuppressedCount(), motor.InlineIgnoreExtension))
+		}
+
 		buf.WriteString(fmt.Sprintf("> learn more about vacuum at [quobix.com/vacuum](https://quobix/vacuum/)\n"))
 		fmt.Print(buf.String())
 		return
@@ -222,6 +236,12 @@ func RenderSummary(rso RenderSummaryOptions) {
 		tableData = append(tableData, row)
 	}
 
+	if rs.GetSuppressedCount() > 0 && !silent {
+		pterm.Info.Printf("%v results were suppressed by '%s' extensions\n",
+			humanize.Comma(int64(rs.GetSuppressedCount())), motor.InlineIgnoreExtension)
+		pterm.Println()
+	}
+
 	if len(rs.Results) > 0 {
 		if !silent {
 			err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
@@ -252,25 +272,26 @@ func RenderSummary(rso RenderSummaryOptions) {
 	if totalFiles <= 1 {
 
 		if errs > 0 {
-			errorHeader.Printf(
-				"Linting file '%s' failed with %v errors, %v warnings and %v informs", filename, errorsHuman, warningsHuman, informsHuman)
+			errorHeader.Println(i18n.Tf("summary.failed",
+				"Linting file '%s' failed with %v errors, %v warnings and %v informs", filename, errorsHuman, warningsHuman, informsHuman))
 			return
 		}
 		if warnings > 0 {
-			msg := "passed, but with"
+			msg := i18n.Tf("summary.passedWithWarnings",
+				"Linting passed, but with %v warnings and %v informs", warningsHuman, informsHuman)
 			switch sev {
 			case model.SeverityWarn:
-				msg = "failed with"
+				msg = i18n.Tf("summary.failedWithWarnings",
+					"Linting failed with %v warnings and %v informs", warningsHuman, informsHuman)
 			}
 
-			warningHeader.Printf(
-				"Linting %s %v warnings and %v informs", msg, warningsHuman, informsHuman)
+			warningHeader.Println(msg)
 			return
 		}
 
 		if informs > 0 {
-			successHeader.Printf(
-				"Linting passed, %v informs reported", informsHuman)
+			successHeader.Println(i18n.Tf("summary.passedWithInforms",
+				"Linting passed, %v informs reported", informsHuman))
 			return
 		}
 
@@ -278,35 +299,35 @@ func RenderSummary(rso RenderSummaryOptions) {
 			return
 		}
 
-		successHeader.Println(
-			"Linting passed, A perfect score! well done!")
+		successHeader.Println(i18n.T("summary.perfect",
+			"Linting passed, A perfect score! well done!"))
 
 	} else {
 
 		if errs > 0 {
-			pterm.Error.Printf("'%s' failed with %v errors, %v warnings and %v informs\n\n",
-				filename, errorsHuman, warningsHuman, informsHuman)
+			pterm.Error.Printf("%s\n\n", i18n.Tf("summary.fileFailed",
+				"'%s' failed with %v errors, %v warnings and %v informs", filename, errorsHuman, warningsHuman, informsHuman))
 			pterm.Println()
 			return
 		}
 		if warnings > 0 {
-			pterm.Warning.Printf(
-				"'%s' passed, but with %v warnings and %v informs\n\n", filename, warningsHuman, informsHuman)
+			pterm.Warning.Printf("%s\n\n", i18n.Tf("summary.filePassedWithWarnings",
+				"'%s' passed, but with %v warnings and %v informs", filename, warningsHuman, informsHuman))
 			pterm.Println()
 			return
 		}
 
 		if informs > 0 {
 
-			successHeader.Printf(
-				"'%s' passed, %v informs reported\n\n", filename, informsHuman)
+			successHeader.Printf("%s\n\n", i18n.Tf("summary.filePassedWithInforms",
+				"'%s' passed, %v informs reported", filename, informsHuman))
 
 			pterm.Println()
 			return
 		}
 
-		successHeader.Printf(
-			"'%s' passed, A perfect score! well done!\n\n", filename)
+		successHeader.Printf("%s\n\n", i18n.Tf("summary.filePerfect",
+			"'%s' passed, A perfect score! well done!", filename))
 		pterm.Println()
 
 	}
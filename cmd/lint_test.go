@@ -2,14 +2,27 @@ package cmd
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/daveshanley/vacuum/utils"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
 	"github.com/pterm/pterm"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 )
 
 func TestGetLintCommand(t *testing.T) {
@@ -580,3 +593,905 @@ rules:
 	assert.NoError(t, cmdErr)
 	assert.Contains(t, b.String(), "Linting passed")
 }
+
+func TestGetLintCommand_FormatSARIF(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"sarif",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetLintCommand_FormatOperations(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"operations",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestRenderFormattedReport_OperationsWithoutStatistics(t *testing.T) {
+	rs := model.NewRuleResultSet([]model.RuleFunctionResult{
+		{Message: "one", Path: "$.paths['/burgers'].get.tags", Rule: &model.Rule{Id: "a", Severity: model.SeverityWarn}},
+	})
+	req := utils.LintFileRequest{FileName: "spec.yaml", Format: FormatOperations}
+	assert.NoError(t, RenderFormattedReport(req, rs, nil))
+	assert.NoError(t, RenderFormattedReport(req, model.NewRuleResultSet(nil), nil))
+}
+
+func TestGetLintCommand_FormatOpenMetrics(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"openmetrics",
+		"-n",
+		"none",
+		"../model/test_files/burgershop.openapi.yaml",
+		"../model/test_files/petstorev3.json",
+	})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetLintCommand_FormatTAP(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"tap",
+		"-n",
+		"none",
+		"../model/test_files/burgershop.openapi.yaml",
+		"../model/test_files/petstorev3.json",
+	})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetLintCommand_FormatUnknown(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"nope",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestGetLintCommand_FormatGitHub(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"github",
+		"--max-annotations",
+		"3",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestRenderFormattedReport_GitHubSharedLimit(t *testing.T) {
+	rs := model.NewRuleResultSet([]model.RuleFunctionResult{
+		{Message: "one", Rule: &model.Rule{Id: "a", Severity: model.SeverityWarn}},
+		{Message: "two", Rule: &model.Rule{Id: "b", Severity: model.SeverityWarn}},
+	})
+	count := make(map[string]int)
+	req := utils.LintFileRequest{FileName: "spec.yaml", Format: FormatGitHub, MaxAnnotations: 3, AnnotationCount: count}
+	assert.NoError(t, RenderFormattedReport(req, rs, nil))
+	assert.Equal(t, 2, count["warning"])
+	assert.NoError(t, RenderFormattedReport(req, rs, nil))
+	assert.Equal(t, 3, count["warning"])
+	assert.NoError(t, RenderFormattedReport(req, rs, nil))
+	assert.Equal(t, 3, count["warning"])
+	assert.Zero(t, count["error"])
+}
+
+func TestLintFile_AsyncAPI(t *testing.T) {
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	var ruleIds []string
+	req := utils.LintFileRequest{
+		FileName:        "../model/test_files/asyncapi-streetlights-v3.yaml",
+		Silent:          true,
+		Format:          FormatJSON,
+		DefaultRuleSets: defaultRuleSets,
+		SelectedRS:      defaultRuleSets.GenerateOpenAPIRecommendedRuleSet(),
+		AsyncAPIRuleSet: defaultRuleSets.GenerateAsyncAPIRecommendedRuleSet(),
+		Lock:            &sync.Mutex{},
+		OnResults: func(_ string, _ []byte, resultSet *model.RuleResultSet, _ *reports.ReportStatistics) {
+			for _, r := range resultSet.Results {
+				ruleIds = append(ruleIds, r.Rule.Id)
+			}
+		},
+	}
+	_, _, _, _ = lintFile(req)
+	assert.Contains(t, ruleIds, rulesets.AsyncAPIOperationAction)
+	assert.Contains(t, ruleIds, rulesets.AsyncAPIChannelAddress)
+	assert.NotContains(t, ruleIds, rulesets.AsyncAPIOperationOperationId)
+}
+
+func TestGetLintCommand_AsyncAPI(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"junit",
+		"-n",
+		"none",
+		"../model/test_files/asyncapi-streetlights-v2.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestLintFile_JSONSchema(t *testing.T) {
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	var ruleIds []string
+	var stats *reports.ReportStatistics
+	req := utils.LintFileRequest{
+		FileName:        "../model/test_files/json-schema-pets.json",
+		Silent:          true,
+		Format:          FormatJSON,
+		DefaultRuleSets: defaultRuleSets,
+		SelectedRS:      defaultRuleSets.GenerateJSONSchemaRuleSet(),
+		DocumentKind:    model.DocumentKindJSONSchema,
+		Lock:            &sync.Mutex{},
+		OnResults: func(_ string, _ []byte, resultSet *model.RuleResultSet, s *reports.ReportStatistics) {
+			stats = s
+			for _, r := range resultSet.Results {
+				ruleIds = append(ruleIds, r.Rule.Id)
+			}
+		},
+	}
+	_, _, _, _ = lintFile(req)
+	assert.Contains(t, ruleIds, rulesets.JSONSchemaUnreachableDefs)
+	assert.Contains(t, ruleIds, rulesets.JSONSchemaEnumExamples)
+	assert.NotNil(t, stats)
+	assert.Equal(t, string(model.DocumentKindJSONSchema), stats.DocumentKind)
+}
+
+func TestLintFile_JUnitRuleTimings(t *testing.T) {
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	var stats *reports.ReportStatistics
+	req := utils.LintFileRequest{
+		FileName:        "../model/test_files/burgershop.openapi.yaml",
+		Silent:          true,
+		Format:          FormatJUnit,
+		DefaultRuleSets: defaultRuleSets,
+		SelectedRS:      defaultRuleSets.GenerateOpenAPIRecommendedRuleSet(),
+		Lock:            &sync.Mutex{},
+		OnResults: func(_ string, _ []byte, _ *model.RuleResultSet, s *reports.ReportStatistics) {
+			stats = s
+		},
+	}
+	_, _, _, _ = lintFile(req)
+	assert.NotNil(t, stats)
+	assert.NotEmpty(t, stats.RuleTimings)
+}
+
+func TestLintFile_CodeFrames(t *testing.T) {
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	var frames []*model.CodeFrame
+	req := utils.LintFileRequest{
+		FileName:        "../model/test_files/burgershop.openapi.yaml",
+		Silent:          true,
+		Format:          FormatJUnit,
+		DefaultRuleSets: defaultRuleSets,
+		SelectedRS:      defaultRuleSets.GenerateOpenAPIRecommendedRuleSet(),
+		CodeFrames:      &model.CodeFrameOptions{ContextLines: 1, MaxLineLength: 20},
+		Lock:            &sync.Mutex{},
+		OnResults: func(_ string, _ []byte, resultSet *model.RuleResultSet, _ *reports.ReportStatistics) {
+			for _, r := range resultSet.Results {
+				frames = append(frames, r.CodeFrame)
+			}
+		},
+	}
+	_, _, _, _ = lintFile(req)
+	assert.NotEmpty(t, frames)
+	for _, frame := range frames {
+		if assert.NotNil(t, frame) {
+			assert.LessOrEqual(t, len(frame.Lines), frame.EndLine-frame.Line+3)
+			for _, line := range frame.Lines {
+				assert.LessOrEqual(t, len([]rune(line)), 20+len("... (9999 more characters)"))
+			}
+		}
+	}
+}
+
+func TestGetLintCommand_Schema(t *testing.T) {
+	cmd := GetLintCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"--schema",
+		"-d",
+		"-n",
+		"none",
+		"../model/test_files/json-schema-pets.json",
+	})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetLintCommand_FormatMarkdown(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"markdown",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetLintCommand_Stdin(t *testing.T) {
+	spec, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+	cmd := GetLintCommand()
+	cmd.SetIn(bytes.NewReader(spec))
+	cmd.SetArgs([]string{"-x", "-"})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetLintCommand_StdinPiped(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetIn(bytes.NewBufferString(`{"openapi": "3.1.0", "info": {"title": "stdin", "version": "1.0"}}`))
+	cmd.SetArgs([]string{"--format", "sarif", "-n", "none"})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestLintFile_StdinReportedFile(t *testing.T) {
+	spec, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	var files []*vacuum_report.FileReport
+	req := utils.LintFileRequest{
+		FileName:        StdinFileName,
+		SpecBytes:       spec,
+		Remote:          true, // local lookups give the root document a name in the rolodex.
+		Silent:          true,
+		Format:          FormatSARIF,
+		DefaultRuleSets: defaultRuleSets,
+		SelectedRS:      defaultRuleSets.GenerateOpenAPIRecommendedRuleSet(),
+		Lock:            &sync.Mutex{},
+		OnResults: func(fileName string, _ []byte, resultSet *model.RuleResultSet, _ *reports.ReportStatistics) {
+			files = append(files, &vacuum_report.FileReport{FileName: fileName, ResultSet: resultSet})
+		},
+	}
+	_, _, _, _ = lintFile(req)
+	assert.Len(t, files, 1)
+
+	// results with an origin in the piped spec are reported against stdin, not the rolodex name for it.
+	var labelled int
+	for _, r := range files[0].ResultSet.Results {
+		if r.Origin != nil && r.Origin.AbsoluteLocation != "" {
+			labelled++
+			assert.Equal(t, StdinFileName, r.Origin.AbsoluteLocation)
+		}
+	}
+	assert.NotZero(t, labelled)
+
+	sarif := string(vacuum_report.BuildSARIFReportForFiles(files, Version))
+	assert.Contains(t, sarif, `"uri": "stdin"`)
+	assert.NotContains(t, sarif, "root.yaml")
+
+	junit := string(vacuum_report.BuildJUnitReportForFiles(files, time.Now(), nil))
+	assert.Contains(t, junit, `file="stdin"`)
+	assert.NotContains(t, junit, "root.yaml")
+}
+
+func TestGetLintCommand_StdinEmpty(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetIn(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{"-"})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestGetLintCommand_MultiFileAggregatedFormat(t *testing.T) {
+	for _, format := range []string{"junit", "json", "sarif"} {
+		cmd := GetLintCommand()
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetArgs([]string{
+			"--format",
+			format,
+			"-n",
+			"none",
+			"../model/test_files/**/petstorev*.json",
+			"../model/test_files/burgershop.openapi.yaml",
+		})
+		assert.NoError(t, cmd.Execute(), format)
+	}
+}
+
+func TestGetLintCommand_MultiFileHTMLBundle(t *testing.T) {
+	files := []string{"../model/test_files/petstorev3.json", "../model/test_files/burgershop.openapi.yaml"}
+
+	// many files can't be rendered as a single html report.
+	cmd := GetLintCommand()
+	cmd.SetArgs(append([]string{"--format", "html", "-n", "none"}, files...))
+	assert.ErrorContains(t, cmd.Execute(), "--html-bundle")
+
+	dir := t.TempDir()
+	cmd = GetLintCommand()
+	cmd.SetArgs(append([]string{"--format", "html", "-n", "none", "--html-bundle", dir}, files...))
+	assert.NoError(t, cmd.Execute())
+
+	index, err := os.ReadFile(filepath.Join(dir, "index.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), "1-petstorev3.html")
+	assert.Contains(t, string(index), "2-burgershop.openapi.html")
+	report, err := os.ReadFile(filepath.Join(dir, "2-burgershop.openapi.html"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(report), "<html")
+}
+
+func TestGetLintCommand_MultiFileWorstResult(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"junit",
+		"../model/test_files/petstorev3.json",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestGetLintCommand_JUnitGroupBy(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"junit",
+		"--junit-group-by",
+		"rule",
+		"-n",
+		"none",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"junit",
+		"--junit-group-by",
+		"owner",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestGetLintCommand_JUnitSeverityMap(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"junit",
+		"--junit-fail-on",
+		"error",
+		"--junit-severity-map",
+		"warn=skipped,info=pass",
+		"-n",
+		"none",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"junit",
+		"--junit-severity-map",
+		"warn=ignored",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.Error(t, cmd.Execute())
+
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"junit",
+		"--junit-fail-on",
+		"fatal",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestGetLintCommand_JUnitTemplate(t *testing.T) {
+	tmpl := filepath.Join(t.TempDir(), "failure.tmpl")
+	assert.NoError(t, os.WriteFile(tmpl, []byte("{{ .RuleId }} - {{ .Message }}\n{{ .Snippet }}"), 0644))
+
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"junit",
+		"--junit-template",
+		tmpl,
+		"-n",
+		"none",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+
+	assert.NoError(t, os.WriteFile(tmpl, []byte("{{ .Ticket }}"), 0644))
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"junit",
+		"--junit-template",
+		tmpl,
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestGetLintCommand_Template(t *testing.T) {
+	tmpl := filepath.Join(t.TempDir(), "report.tmpl")
+	assert.NoError(t, os.WriteFile(tmpl, []byte(`{{ range .Files }}h2. {{ .FileName }}
+{{ range .Results }}* {{ .RuleId }}: {{ .Message }}
+{{ end }}{{ end }}`), 0644))
+
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--format",
+		"template",
+		"--template",
+		tmpl,
+		"-n",
+		"none",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+
+	// the format needs a template, and a template needs the format.
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{"--format", "template", "../model/test_files/burgershop.openapi.yaml"})
+	assert.EqualError(t, cmd.Execute(), "'--format template' needs a template to render, use '--template <file>'")
+
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{"--format", "json", "--template", tmpl, "../model/test_files/burgershop.openapi.yaml"})
+	assert.EqualError(t, cmd.Execute(), "'--template' is rendered by the template format, use it with '--format template'")
+
+	assert.NoError(t, os.WriteFile(tmpl, []byte("{{ .Ticket }}"), 0644))
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{"--format", "template", "--template", tmpl, "../model/test_files/burgershop.openapi.yaml"})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestGetLintCommand_PostReport(t *testing.T) {
+	var report vacuum_report.AggregatedReport
+	var auth, team string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		auth, team = r.Header.Get("Authorization"), r.Header.Get("X-Team")
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&report))
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--post-report",
+		server.URL,
+		"--post-report-header",
+		"X-Team: payments, orders",
+		"--post-report-token",
+		"sekret",
+		"-n",
+		"none",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+	assert.Equal(t, "Bearer sekret", auth)
+	assert.Equal(t, "payments, orders", team)
+	if assert.Len(t, report.Files, 1) {
+		assert.Equal(t, "../model/test_files/burgershop.openapi.yaml", report.Files[0].FileName)
+		assert.NotEmpty(t, report.Files[0].ResultSet.Results)
+	}
+
+	// a report that can't be posted fails the lint, once the retries are used up.
+	missing := httptest.NewServer(http.NotFoundHandler())
+	defer missing.Close()
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{"--post-report", missing.URL, "--post-report-retries", "0", "-n", "none",
+		"../model/test_files/burgershop.openapi.yaml"})
+	assert.Error(t, cmd.Execute())
+
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{"--post-report", "ftp://example.com", "../model/test_files/burgershop.openapi.yaml"})
+	assert.EqualError(t, cmd.Execute(), "cannot post the report to 'ftp://example.com', it's not an http(s) URL")
+}
+
+func TestGetFilesToLint_GlobArgs(t *testing.T) {
+	files, err := getFilesToLint("", []string{"../model/test_files/burger*.yaml"}, []string{".yaml"})
+	assert.NoError(t, err)
+	assert.Contains(t, files, "../model/test_files/burgershop.openapi.yaml")
+}
+
+func TestGetLintCommand_Fix(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: test
+  version: 1.0.0
+  description: a test
+paths:
+  # burgers are tasty
+  /burgers/:
+    get:
+      operationId: listBurgers
+      summary: list burgers
+      description: returns all the burgers
+      tags: [a]
+      responses:
+        "200":
+          description: ok
+`
+	file := filepath.Join(t.TempDir(), "spec.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(spec), 0664))
+
+	// a dry run leaves the file alone.
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{"--dry-run", "-n", "none", file})
+	assert.NoError(t, cmd.Execute())
+	b, _ := os.ReadFile(file)
+	assert.Equal(t, spec, string(b))
+
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{"--fix", "-n", "none", file})
+	assert.NoError(t, cmd.Execute())
+	b, _ = os.ReadFile(file)
+	assert.Contains(t, string(b), "  # burgers are tasty\n  /burgers:\n")
+}
+
+func TestGetLintCommand_FixWatch(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{"--fix", "--watch", "../model/test_files/burgershop.openapi.yaml"})
+	assert.Error(t, cmd.Execute())
+}
+
+func TestGetLintCommand_MaxWarnings(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"-n",
+		"none",
+		"--max-warnings",
+		"1",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	err := cmd.Execute()
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "the maximum allowed is 1")
+
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{
+		"-n",
+		"none",
+		"--max-warnings",
+		"1000",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetLintCommand_ChangedSince(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	spec := `openapi: 3.1.0
+info:
+  title: test
+  version: 1.0.0
+  description: a test
+paths:
+  /burgers:
+    get:
+      operationId: listBurgers
+      summary: list burgers
+      tags: [a]
+      responses:
+        "200":
+          description: ok
+`
+	dir := t.TempDir()
+	file := filepath.Join(dir, "spec.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(spec), 0664))
+	for _, args := range [][]string{{"init", "-q"}, {"add", "."}, {"commit", "-q", "-m", "first"}} {
+		git := exec.Command("git", append([]string{"-c", "user.name=vacuum", "-c", "user.email=vacuum@quobix.com"}, args...)...)
+		git.Dir = dir
+		out, err := git.CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+
+	lint := func(extra ...string) error {
+		cmd := GetLintCommand()
+		cmd.SetArgs(append([]string{"--rule-severity", "operation-tag-defined=error", "-x", file}, extra...))
+		return cmd.Execute()
+	}
+
+	// the tag is not defined, but it has not changed.
+	assert.NoError(t, os.WriteFile(file, []byte(strings.Replace(spec, "title: test", "title: tested", 1)), 0664))
+	assert.Error(t, lint())
+	assert.NoError(t, lint("--changed-since", "HEAD"))
+	assert.NoError(t, lint("--changed-since", "HEAD", "--show-unchanged"))
+
+	assert.NoError(t, os.WriteFile(file, []byte(strings.Replace(spec, "tags: [a]", "tags: [ a ]", 1)), 0664))
+	assert.Error(t, lint("--changed-since", "HEAD"))
+	assert.Error(t, lint("--changed-since", "no-such-ref"))
+}
+
+func TestGetLintCommand_ChangedLinesOnly(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	t.Setenv("GITHUB_BASE_REF", "")
+	t.Setenv("CI_MERGE_REQUEST_DIFF_BASE_SHA", "")
+	t.Setenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME", "")
+	spec := `openapi: 3.1.0
+info:
+  title: test
+  version: 1.0.0
+  description: a test
+paths:
+  /burgers:
+    get:
+      operationId: listBurgers
+      summary: list burgers
+      tags: [a]
+      responses:
+        "200":
+          description: ok
+`
+	dir := t.TempDir()
+	file := filepath.Join(dir, "spec.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte(spec), 0664))
+	for _, args := range [][]string{{"init", "-q"}, {"add", "."}, {"commit", "-q", "-m", "first"}} {
+		git := exec.Command("git", append([]string{"-c", "user.name=vacuum", "-c", "user.email=vacuum@quobix.com"}, args...)...)
+		git.Dir = dir
+		out, err := git.CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+	changedSpec := []byte(strings.Replace(spec, "title: test", "title: tested", 1))
+	assert.NoError(t, os.WriteFile(file, changedSpec, 0664))
+
+	lint := func(extra ...string) error {
+		cmd := GetLintCommand()
+		cmd.SetArgs(append([]string{"--rule-severity", "operation-tag-defined=error", file}, extra...))
+		return cmd.Execute()
+	}
+
+	// the full report has every result, and they all still count.
+	fullReport := filepath.Join(dir, "full-report.json")
+	assert.Error(t, lint("--format", "github", "--changed-lines-only", "--changed-since", "HEAD",
+		"--full-report", fullReport))
+	data, err := os.ReadFile(fullReport)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"operation-tag-defined"`)
+
+	// the base of the pull request is read from CI.
+	t.Setenv("GITHUB_BASE_REF", "no-such-branch")
+	assert.Error(t, lint("--format", "github", "--changed-lines-only", "-n", "none"))
+	t.Setenv("GITHUB_BASE_REF", "")
+
+	assert.EqualError(t, lint("--format", "github", "--changed-lines-only"),
+		"'--changed-lines-only' needs the base of the pull request to diff against, use '--changed-since' "+
+			"(GITHUB_BASE_REF and CI_MERGE_REQUEST_DIFF_BASE_SHA are used when set)")
+	assert.EqualError(t, lint("--changed-lines-only", "--changed-since", "HEAD"),
+		"'--changed-lines-only' limits annotations, use it with '--format github' or '--format gitlab'")
+
+	changed := model.RuleFunctionResult{Message: "changed", StartNode: &yaml.Node{Line: 3}, Rule: &model.Rule{Id: "a"}}
+	unchanged := model.RuleFunctionResult{Message: "unchanged", StartNode: &yaml.Node{Line: 11}, Rule: &model.Rule{Id: "b"}}
+	rs := model.NewRuleResultSet([]model.RuleFunctionResult{changed, unchanged})
+	annotated, err := changedLinesOnly(utils.LintFileRequest{ChangedLinesOnly: "HEAD"}, file, changedSpec, rs)
+	assert.NoError(t, err)
+	assert.Len(t, annotated.Results, 1)
+	assert.Equal(t, "changed", annotated.Results[0].Message)
+	assert.Len(t, rs.Results, 2)
+}
+
+func TestPullRequestBase(t *testing.T) {
+	t.Setenv("GITHUB_BASE_REF", "")
+	t.Setenv("CI_MERGE_REQUEST_DIFF_BASE_SHA", "")
+	t.Setenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME", "")
+	assert.Equal(t, "", pullRequestBase())
+	t.Setenv("CI_MERGE_REQUEST_TARGET_BRANCH_NAME", "main")
+	assert.Equal(t, "origin/main", pullRequestBase())
+	t.Setenv("CI_MERGE_REQUEST_DIFF_BASE_SHA", "abc123")
+	assert.Equal(t, "abc123", pullRequestBase())
+	t.Setenv("GITHUB_BASE_REF", "develop")
+	assert.Equal(t, "origin/develop", pullRequestBase())
+}
+
+func TestGetLintCommand_LowMemory(t *testing.T) {
+	defer debug.SetGCPercent(debug.SetGCPercent(100))
+
+	cmd := GetLintCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--low-memory", "-s", "../model/test_files/burgershop.openapi.yaml"})
+	assert.NoError(t, cmd.Execute())
+
+	// the garbage collection target is put back once the command is done.
+	assert.Equal(t, 100, debug.SetGCPercent(100))
+}
+
+func TestGetLintCommand_CollapseRefs(t *testing.T) {
+	cmd := GetLintCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--collapse-refs", "-d", "../model/test_files/burgershop.openapi.yaml"})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetLintCommand_ShowRuleTimings(t *testing.T) {
+	cmd := GetLintCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--show-rule-timings", "../model/test_files/burgershop.openapi.yaml"})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestLintFile_ShowRuleTimings(t *testing.T) {
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
+	var stats *reports.ReportStatistics
+	req := utils.LintFileRequest{
+		FileName:        "../model/test_files/burgershop.openapi.yaml",
+		Silent:          true,
+		Format:          FormatJSON,
+		DefaultRuleSets: defaultRuleSets,
+		SelectedRS:      selectedRS,
+		Lock:            &sync.Mutex{},
+		OnResults: func(_ string, _ []byte, _ *model.RuleResultSet, s *reports.ReportStatistics) {
+			stats = s
+		},
+	}
+	_, _, _, _ = lintFile(req)
+	assert.NotNil(t, stats)
+	assert.Empty(t, stats.RuleTimings)
+
+	req.ShowRuleTimings = true
+	_, _, _, _ = lintFile(req)
+	assert.Len(t, stats.RuleTimings, len(selectedRS.Rules))
+}
+
+func TestGetLintCommand_LogFormatJSON(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().String("log-level", "", "")
+	cmd.PersistentFlags().String("log-format", "", "")
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--log-format", "json", "--log-level", "info", "../model/test_files/burgershop.openapi.yaml"})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetLintCommand_LogLevelInvalid(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().String("log-level", "", "")
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--log-level", "loud", "../model/test_files/burgershop.openapi.yaml"})
+	assert.ErrorContains(t, cmd.Execute(), "unknown log level")
+}
+
+func TestGetLintCommand_Badge(t *testing.T) {
+	badge := filepath.Join(t.TempDir(), "badge.json")
+
+	cmd := GetLintCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"--badge", badge, "../model/test_files/burgershop.openapi.yaml"})
+	assert.NoError(t, cmd.Execute())
+
+	data, err := os.ReadFile(badge)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"label": "API quality"`)
+}
+
+func TestGetLintCommand_Staged(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	spec := `openapi: 3.1.0
+info:
+  title: test
+  version: 1.0.0
+  description: a test
+paths:
+  /burgers:
+    get:
+      operationId: listBurgers
+      summary: list burgers
+      tags: [a]
+      responses:
+        "200":
+          description: ok
+`
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=vacuum", "-c", "user.email=vacuum@quobix.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	t.Chdir(dir)
+
+	lint := func(extra ...string) error {
+		cmd := GetLintCommand()
+		cmd.SetArgs(append([]string{"--rule-severity", "operation-tag-defined=error", "-x", "--staged"}, extra...))
+		return cmd.Execute()
+	}
+
+	// nothing is staged, or only files that are not specifications.
+	assert.NoError(t, lint())
+	assert.NoError(t, os.WriteFile("config.yaml", []byte("name: ci\n"), 0664))
+	git("add", "config.yaml")
+	assert.NoError(t, lint())
+
+	// the staged specification does not define its tag.
+	assert.NoError(t, os.WriteFile("spec.yaml", []byte(spec), 0664))
+	git("add", "spec.yaml")
+	assert.Error(t, lint())
+	assert.NoError(t, lint("--fail-severity", "none"))
+
+	assert.ErrorContains(t, lint("spec.yaml"), "can't be supplied")
+}
+
+func TestGetLintCommand_VacuumIgnore(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: test
+  version: 1.0.0
+  description: a test
+paths:
+  /burgers:
+    get:
+      operationId: listBurgers
+      summary: list burgers
+      tags: [a]
+      responses:
+        "200":
+          description: ok
+`
+	dir := t.TempDir()
+	t.Chdir(dir)
+	assert.NoError(t, os.MkdirAll("generated", 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join("generated", "spec.yaml"), []byte(spec), 0664))
+	assert.NoError(t, os.WriteFile("legacy.yaml", []byte(spec), 0664))
+
+	lint := func(extra ...string) error {
+		cmd := GetLintCommand()
+		cmd.SetArgs(append([]string{"--rule-severity", "operation-tag-defined=error", "-x"}, extra...))
+		return cmd.Execute()
+	}
+	assert.Error(t, lint("**/*.yaml"))
+
+	assert.NoError(t, os.WriteFile(utils.VacuumIgnoreFileName, []byte("generated/\nlegacy.yaml operation-tag-defined\n"), 0664))
+	assert.NoError(t, lint("**/*.yaml"))
+	assert.NoError(t, lint("--globbed-files", "generated/*.yaml"))
+
+	// a file named on its own is always linted.
+	assert.Error(t, lint("legacy.yaml"))
+
+	assert.NoError(t, os.WriteFile("other-ignore", []byte("generated/\n"), 0664))
+	assert.Error(t, lint("--vacuumignore", "other-ignore", "**/*.yaml"))
+	assert.Error(t, lint("--vacuumignore", "missing", "**/*.yaml"))
+}
+
+func TestGetLintCommand_SortAndMaxResults(t *testing.T) {
+	cmd := GetLintCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{
+		"-d",
+		"--sort",
+		"severity",
+		"--max-results",
+		"3",
+		"-n",
+		"none",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.NoError(t, cmd.Execute())
+
+	cmd = GetLintCommand()
+	cmd.SetArgs([]string{
+		"--sort",
+		"colour",
+		"../model/test_files/burgershop.openapi.yaml",
+	})
+	assert.Error(t, cmd.Execute())
+}
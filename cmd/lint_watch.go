@@ -0,0 +1,176 @@
+// Copyright 2025 Princess Beef Heavy Industries, LLC / Dave Shanley
+// https://pb33f.io
+
+package cmd
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pterm/pterm"
+	"gopkg.in/yaml.v3"
+)
+
+// watchAndLint runs a lint, then watches the specifications and every local file they reference, running the lint
+// again every time one of them changes. Changes are debounced, editors often write a file more than once on save.
+func watchAndLint(ctx context.Context, files []string, base string, debounce time.Duration, run func() error) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		pterm.Error.Printf("Unable to start watching files: %s\n", err.Error())
+		return err
+	}
+	defer watcher.Close()
+
+	watched := make(map[string]bool)
+	dirs := make(map[string]bool)
+
+	// directories are watched rather than files, so files replaced by editors (rename on save) are not lost.
+	refresh := func() {
+		for _, f := range files {
+			for _, w := range append([]string{f}, collectLocalReferences(f, base)...) {
+				abs, aErr := filepath.Abs(w)
+				if aErr != nil {
+					continue
+				}
+				watched[abs] = true
+				dir := filepath.Dir(abs)
+				if !dirs[dir] {
+					if wErr := watcher.Add(dir); wErr != nil {
+						pterm.Warning.Printf("Unable to watch '%s': %s\n", dir, wErr.Error())
+						continue
+					}
+					dirs[dir] = true
+				}
+			}
+		}
+	}
+
+	lint := func() {
+		if lErr := run(); lErr != nil {
+			pterm.Warning.Printf("Linting %s\n", lErr.Error())
+		}
+		pterm.Info.Printf("Watching %d files for changes, press Ctrl+C to stop\n", len(watched))
+		pterm.Println()
+	}
+
+	refresh()
+	lint()
+
+	trigger := make(chan struct{}, 1)
+	var timer *time.Timer
+	for {
+		select {
+		case <-ctx.Done():
+			if timer != nil {
+				timer.Stop()
+			}
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			abs, _ := filepath.Abs(event.Name)
+			if !watched[abs] || event.Op == fsnotify.Chmod {
+				continue
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(debounce, func() {
+				select {
+				case trigger <- struct{}{}:
+				default:
+				}
+			})
+		case <-trigger:
+			pterm.Println()
+			pterm.Info.Printf("Change detected at %s, linting again\n", time.Now().Format(time.TimeOnly))
+			pterm.Println()
+			refresh()
+			lint()
+		case wErr, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			pterm.Warning.Printf("Watch error: %s\n", wErr.Error())
+		}
+	}
+}
+
+// collectLocalReferences finds every local file referenced (directly or through other files) by a specification.
+// References are resolved against the base, if it's a local path, otherwise against the directory of the file
+// that contains them. Remote references are ignored.
+func collectLocalReferences(file, base string) []string {
+	var found []string
+	seen := map[string]bool{}
+	if abs, err := filepath.Abs(file); err == nil {
+		seen[abs] = true
+	}
+	localBase := base
+	if strings.HasPrefix(base, "http://") || strings.HasPrefix(base, "https://") {
+		localBase = ""
+	}
+
+	var collect func(f string)
+	collect = func(f string) {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			return
+		}
+		var root yaml.Node
+		if yaml.Unmarshal(data, &root) != nil {
+			return
+		}
+		dir := localBase
+		if dir == "" {
+			dir = filepath.Dir(f)
+		}
+		for _, ref := range findReferenceValues(&root) {
+			if i := strings.Index(ref, "#"); i >= 0 {
+				ref = ref[:i]
+			}
+			if ref == "" || strings.Contains(ref, "://") {
+				continue
+			}
+			if !filepath.IsAbs(ref) {
+				ref = filepath.Join(dir, ref)
+			}
+			abs, aErr := filepath.Abs(ref)
+			if aErr != nil || seen[abs] {
+				continue
+			}
+			seen[abs] = true
+			found = append(found, ref)
+			collect(ref)
+		}
+	}
+	collect(file)
+	return found
+}
+
+func findReferenceValues(node *yaml.Node) []string {
+	var refs []string
+	if node.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" && node.Content[i+1].Kind == yaml.ScalarNode {
+				refs = append(refs, node.Content[i+1].Value)
+			}
+		}
+	}
+	for _, n := range node.Content {
+		refs = append(refs, findReferenceValues(n)...)
+	}
+	return refs
+}
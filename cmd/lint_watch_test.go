@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCollectLocalReferences(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "spec.yaml"), []byte(`openapi: 3.1.0
+components:
+  schemas:
+    A:
+      $ref: './schemas/a.yaml#/A'
+    B:
+      $ref: 'https://example.com/b.yaml'
+    C:
+      $ref: '#/components/schemas/A'`), 0664))
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "schemas"), 0775))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "a.yaml"), []byte(`A:
+  properties:
+    b:
+      $ref: 'b.yaml'`), 0664))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "b.yaml"), []byte(`type: string`), 0664))
+
+	refs := collectLocalReferences(filepath.Join(dir, "spec.yaml"), "")
+	assert.Equal(t, []string{
+		filepath.Join(dir, "schemas", "a.yaml"),
+		filepath.Join(dir, "schemas", "b.yaml"),
+	}, refs)
+}
+
+func TestCollectLocalReferences_Base(t *testing.T) {
+	refs := collectLocalReferences("../model/test_files/localfile-burgershop.openapi.yaml", "../model")
+	assert.Equal(t, []string{filepath.Join("../model", "test_files/burgershop.openapi.yaml")}, refs)
+}
+
+func TestWatchAndLint(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "spec.yaml")
+	assert.NoError(t, os.WriteFile(spec, []byte("openapi: 3.1.0"), 0664))
+
+	var runs atomic.Int32
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() {
+		done <- watchAndLint(ctx, []string{spec}, "", 10*time.Millisecond, func() error {
+			runs.Add(1)
+			return nil
+		})
+	}()
+
+	assert.Eventually(t, func() bool { return runs.Load() == 1 }, time.Second, 5*time.Millisecond)
+	assert.NoError(t, os.WriteFile(spec, []byte("openapi: 3.1.1"), 0664))
+	assert.Eventually(t, func() bool { return runs.Load() == 2 }, 2*time.Second, 5*time.Millisecond)
+
+	cancel()
+	assert.NoError(t, <-done)
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"runtime"
+	"sync"
+
+	"github.com/daveshanley/vacuum/utils"
+)
+
+// outputTurns makes files print their results in the order they were supplied, no matter which one is done
+// linting first. Files are handed to workers in order, so the earliest file still being linted never waits.
+type outputTurns struct {
+	lock     sync.Mutex
+	cond     *sync.Cond
+	next     int
+	finished map[int]bool
+}
+
+func newOutputTurns() *outputTurns {
+	t := &outputTurns{finished: make(map[int]bool)}
+	t.cond = sync.NewCond(&t.lock)
+	return t
+}
+
+// wait blocks until every file before this one is done.
+func (t *outputTurns) wait(file int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for t.next < file {
+		t.cond.Wait()
+	}
+}
+
+// done lets the next file print, once every file before it is done as well.
+func (t *outputTurns) done(file int) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.finished[file] = true
+	for t.finished[t.next] {
+		delete(t.finished, t.next)
+		t.next++
+	}
+	t.cond.Broadcast()
+}
+
+// lintWorkers returns the number of files to lint at the same time, the number of CPUs unless --workers is set.
+func lintWorkers(workers, files int) int {
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+	if workers > files {
+		workers = files
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	return workers
+}
+
+func waitForTurn(req utils.LintFileRequest) {
+	if req.WaitTurn != nil {
+		req.WaitTurn()
+	}
+}
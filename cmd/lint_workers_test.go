@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"runtime"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputTurns(t *testing.T) {
+	turns := newOutputTurns()
+	var printed []int
+	var lock sync.Mutex
+	var wg sync.WaitGroup
+	for _, file := range []int{3, 1, 0, 2} {
+		wg.Add(1)
+		go func(file int) {
+			defer wg.Done()
+			turns.wait(file)
+			lock.Lock()
+			printed = append(printed, file)
+			lock.Unlock()
+			turns.done(file)
+		}(file)
+	}
+	wg.Wait()
+	assert.Equal(t, []int{0, 1, 2, 3}, printed)
+
+	// a file that finishes early does not let later files skip the queue.
+	turns = newOutputTurns()
+	turns.done(1)
+	turns.done(0)
+	turns.wait(2)
+}
+
+func TestLintWorkers(t *testing.T) {
+	assert.Equal(t, 2, lintWorkers(2, 10))
+	assert.Equal(t, 3, lintWorkers(8, 3))
+	assert.Equal(t, 1, lintWorkers(4, 0))
+	assert.Equal(t, min(runtime.NumCPU(), 100), lintWorkers(0, 100))
+}
+
+func TestGetLintCommand_Workers(t *testing.T) {
+	cmd := GetLintCommand()
+	cmd.SetArgs([]string{
+		"--workers", "2",
+		"../model/test_files/petstorev2.json",
+		"../model/test_files/burgershop.openapi.yaml",
+		"../model/test_files/petstorev3.json",
+	})
+	assert.ErrorContains(t, cmd.Execute(), "failed with 7 errors")
+}
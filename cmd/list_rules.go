@@ -0,0 +1,175 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/utils"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// The rule catalog can be listed as a table, or exported as JSON or YAML.
+const (
+	RuleCatalogFormatTable = "table"
+	RuleCatalogFormatJSON  = "json"
+	RuleCatalogFormatYAML  = "yaml"
+)
+
+// GetListRulesCommand returns a cobra command that lists every rule of the ruleset being used, without linting.
+func GetListRulesCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Use:           "list-rules",
+		Short:         "List every rule of the ruleset, as a table, JSON or YAML",
+		Long: "List every rule that lint would run, the recommended rules unless a ruleset (-r) or hard mode (-z) is " +
+			"used. JSON and YAML have the complete catalog: id, description, severity, category, tags, formats, if the " +
+			"rule can be fixed automatically, documentation and examples, so style guide pages can be generated from it.",
+		Example: "vacuum list-rules\nvacuum list-rules -r my-ruleset.yaml --format json > rules.json",
+		Args:    cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			formatFlag, _ := cmd.Flags().GetString("format")
+			categoryFlag, _ := cmd.Flags().GetString("category")
+			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+			hardModeFlag, _ := cmd.Flags().GetBool("hard-mode")
+			remoteFlag, _ := cmd.Flags().GetBool("remote")
+			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+
+			// Certificate/TLS configuration
+			certFile, _ := cmd.Flags().GetString("cert-file")
+			keyFile, _ := cmd.Flags().GetString("key-file")
+			caFile, _ := cmd.Flags().GetString("ca-file")
+			insecure, _ := cmd.Flags().GetBool("insecure")
+
+			if noStyleFlag {
+				pterm.DisableColor()
+				pterm.DisableStyling()
+			}
+
+			switch formatFlag {
+			case RuleCatalogFormatTable, RuleCatalogFormatJSON, RuleCatalogFormatYAML:
+			default:
+				errText := fmt.Sprintf("unknown format '%s', supported formats are 'table', 'json' and 'yaml'", formatFlag)
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			defaultRuleSets := rulesets.BuildDefaultRuleSets()
+			selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
+			if hardModeFlag {
+				selectedRS = defaultRuleSets.GenerateOpenAPIDefaultRuleSet()
+				for k, v := range rulesets.GetAllOWASPRules() {
+					selectedRS.Rules[k] = v
+				}
+			}
+			if rulesetFlag != "" {
+				var httpClient *http.Client
+				httpClientConfig := utils.HTTPClientConfig{
+					CertFile: certFile,
+					KeyFile:  keyFile,
+					CAFile:   caFile,
+					Insecure: insecure,
+				}
+				if utils.ShouldUseCustomHTTPClient(httpClientConfig) {
+					var clientErr error
+					httpClient, clientErr = utils.CreateCustomHTTPClient(httpClientConfig)
+					if clientErr != nil {
+						pterm.Error.Printf("Failed to create custom HTTP client: %s\n", clientErr.Error())
+						return clientErr
+					}
+				}
+				var rsErr error
+				selectedRS, rsErr = BuildRuleSetFromUserSuppliedLocation(rulesetFlag, defaultRuleSets, remoteFlag, httpClient)
+				if rsErr != nil {
+					pterm.Error.Printf("Unable to load ruleset '%s': %s\n", rulesetFlag, rsErr.Error())
+					pterm.Println()
+					return rsErr
+				}
+				MergeOWASPRulesToRuleSet(selectedRS, hardModeFlag)
+			}
+
+			catalog := rulesets.BuildRuleCatalog(selectedRS)
+			if categoryFlag != "" {
+				filterRuleCatalog(catalog, categoryFlag)
+			}
+			return renderRuleCatalog(catalog, formatFlag)
+		},
+	}
+	cmd.Flags().StringP("format", "o", RuleCatalogFormatTable, "Format of the list: 'table', 'json' or 'yaml'")
+	cmd.Flags().StringP("category", "c", "", "Only list the rules of a category")
+	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	return cmd
+}
+
+// filterRuleCatalog removes every rule (and category) that isn't in the category.
+func filterRuleCatalog(catalog *rulesets.RuleCatalog, category string) {
+	var rules []*rulesets.RuleCatalogEntry
+	for _, r := range catalog.Rules {
+		if r.Category == category {
+			rules = append(rules, r)
+		}
+	}
+	var categories []*model.RuleCategory
+	for _, c := range catalog.Categories {
+		if c.Id == category {
+			categories = append(categories, c)
+		}
+	}
+	catalog.Rules = append([]*rulesets.RuleCatalogEntry{}, rules...)
+	catalog.Categories = append([]*model.RuleCategory{}, categories...)
+}
+
+// renderRuleCatalog prints the catalog to stdout, the table only has the essentials.
+func renderRuleCatalog(catalog *rulesets.RuleCatalog, format string) error {
+	switch format {
+	case RuleCatalogFormatJSON:
+		out, err := json.MarshalIndent(catalog, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	case RuleCatalogFormatYAML:
+		out, err := yaml.Marshal(catalog)
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(out))
+		return nil
+	}
+
+	if len(catalog.Rules) == 0 {
+		pterm.Info.Println("There are no rules to list")
+		return nil
+	}
+	names := make(map[string]string, len(catalog.Categories))
+	for _, c := range catalog.Categories {
+		names[c.Id] = c.Name
+	}
+	tableData := pterm.TableData{{"Rule", "Severity", "Category", "Fixable", "Tags"}}
+	for _, r := range catalog.Rules {
+		fixable := ""
+		if r.Fixable {
+			fixable = "yes"
+		}
+		tableData = append(tableData, []string{r.Id, r.Severity, names[r.Category], fixable, strings.Join(r.Tags, ", ")})
+	}
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		return err
+	}
+	pterm.Println()
+	pterm.Info.Printf("%d rules\n", len(catalog.Rules))
+	return nil
+}
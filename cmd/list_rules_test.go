@@ -0,0 +1,58 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestListRulesCommand(args ...string) error {
+	cmd := GetListRulesCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.PersistentFlags().BoolP("hard-mode", "z", false, "")
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+func TestGetListRulesCommand(t *testing.T) {
+	assert.NoError(t, newTestListRulesCommand())
+	assert.NoError(t, newTestListRulesCommand("-z", "--format", "json"))
+	assert.NoError(t, newTestListRulesCommand("--format", "yaml", "--category", "schemas"))
+}
+
+func TestGetListRulesCommand_Ruleset(t *testing.T) {
+	ruleset := filepath.Join(t.TempDir(), "ruleset.yaml")
+	assert.NoError(t, os.WriteFile(ruleset, []byte(`extends: [[vacuum:oas, off]]
+rules:
+  my-rule:
+    description: check the title
+    given: $.info
+    then:
+      function: truthy
+      field: title
+`), 0664))
+	assert.NoError(t, newTestListRulesCommand("-r", ruleset, "--format", "json"))
+}
+
+func TestGetListRulesCommand_BadFormat(t *testing.T) {
+	assert.Error(t, newTestListRulesCommand("--format", "xml"))
+}
+
+func TestFilterRuleCatalog(t *testing.T) {
+	catalog := rulesets.BuildRuleCatalog(rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet())
+	filterRuleCatalog(catalog, "tags")
+	assert.NotEmpty(t, catalog.Rules)
+	assert.Len(t, catalog.Categories, 1)
+	for _, r := range catalog.Rules {
+		assert.Equal(t, "tags", r.Category)
+	}
+	filterRuleCatalog(catalog, "nope")
+	assert.Empty(t, catalog.Rules)
+	assert.NotNil(t, catalog.Rules)
+}
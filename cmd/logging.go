@@ -0,0 +1,90 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// Log formats for --log-format.
+const (
+	LogFormatText = "text"
+	LogFormatJSON = "json"
+)
+
+// LogFormats are the values --log-format accepts.
+var LogFormats = []string{LogFormatText, LogFormatJSON}
+
+// BuildLogger builds the logger for a command from the --log-level, --log-format and --debug flags. Without a
+// --log-level, records are logged at defaultLevel (or debug, with --debug). Text records are rendered to stdout
+// alongside the rest of the output, JSON records (one per line) go to stderr, so they can be collected by CI log
+// aggregation without picking up anything else.
+func BuildLogger(cmd *cobra.Command, defaultLevel slog.Level) (*slog.Logger, error) {
+	levelFlag, _ := cmd.Flags().GetString("log-level")
+	formatFlag, _ := cmd.Flags().GetString("log-format")
+	debugFlag, _ := cmd.Flags().GetBool("debug")
+
+	level := defaultLevel
+	if debugFlag {
+		level = slog.LevelDebug
+	}
+	if levelFlag != "" {
+		if err := level.UnmarshalText([]byte(levelFlag)); err != nil {
+			return nil, fmt.Errorf("unknown log level '%s', supported levels are debug, info, warn and error", levelFlag)
+		}
+	}
+	switch strings.ToLower(formatFlag) {
+	case "", LogFormatText:
+		return NewTextLogger(os.Stdout, level), nil
+	case LogFormatJSON:
+		return NewJSONLogger(os.Stderr, level), nil
+	}
+	return nil, fmt.Errorf("unknown log format '%s', supported formats are %v", formatFlag, LogFormats)
+}
+
+// LoggingChanged returns true if the log level or format has been chosen, commands that are quiet by default only
+// log when asked to.
+func LoggingChanged(cmd *cobra.Command) bool {
+	return cmd.Flags().Changed("log-level") || cmd.Flags().Changed("log-format") || cmd.Flags().Changed("debug")
+}
+
+// NewJSONLogger returns a logger that writes a JSON object per record.
+func NewJSONLogger(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level}))
+}
+
+// NewTextLogger returns a logger that renders records in color, using pterm.
+func NewTextLogger(w io.Writer, level slog.Level) *slog.Logger {
+	return slog.New(pterm.NewSlogHandler(&pterm.Logger{
+		Formatter: pterm.LogFormatterColorful,
+		Writer:    w,
+		Level:     ptermLogLevel(level),
+		ShowTime:  false,
+		MaxWidth:  280,
+		KeyStyles: map[string]pterm.Style{
+			"error":  *pterm.NewStyle(pterm.FgRed, pterm.Bold),
+			"err":    *pterm.NewStyle(pterm.FgRed, pterm.Bold),
+			"caller": *pterm.NewStyle(pterm.FgGray, pterm.Bold),
+		},
+	}))
+}
+
+func ptermLogLevel(level slog.Level) pterm.LogLevel {
+	switch {
+	case level <= slog.LevelDebug:
+		return pterm.LogLevelDebug
+	case level <= slog.LevelInfo:
+		return pterm.LogLevelInfo
+	case level <= slog.LevelWarn:
+		return pterm.LogLevelWarn
+	}
+	return pterm.LogLevelError
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func loggingTestCommand(t *testing.T, args ...string) *cobra.Command {
+	cmd := GetRootCommand()
+	assert.NoError(t, cmd.ParseFlags(args))
+	return cmd
+}
+
+func TestBuildLogger_Default(t *testing.T) {
+	logger, err := BuildLogger(loggingTestCommand(t), slog.LevelWarn)
+	assert.NoError(t, err)
+	assert.True(t, logger.Enabled(context.Background(), slog.LevelWarn))
+	assert.False(t, logger.Enabled(context.Background(), slog.LevelInfo))
+}
+
+func TestBuildLogger_Debug(t *testing.T) {
+	logger, err := BuildLogger(loggingTestCommand(t, "--debug"), slog.LevelError)
+	assert.NoError(t, err)
+	assert.True(t, logger.Enabled(context.Background(), slog.LevelDebug))
+}
+
+func TestBuildLogger_Level(t *testing.T) {
+	logger, err := BuildLogger(loggingTestCommand(t, "--log-level", "INFO", "--log-format", "json"), slog.LevelError)
+	assert.NoError(t, err)
+	assert.True(t, logger.Enabled(context.Background(), slog.LevelInfo))
+	assert.False(t, logger.Enabled(context.Background(), slog.LevelDebug))
+}
+
+func TestBuildLogger_Invalid(t *testing.T) {
+	_, err := BuildLogger(loggingTestCommand(t, "--log-level", "loud"), slog.LevelError)
+	assert.ErrorContains(t, err, "unknown log level 'loud'")
+
+	_, err = BuildLogger(loggingTestCommand(t, "--log-format", "xml"), slog.LevelError)
+	assert.ErrorContains(t, err, "unknown log format 'xml'")
+}
+
+func TestLoggingChanged(t *testing.T) {
+	assert.False(t, LoggingChanged(loggingTestCommand(t)))
+	assert.True(t, LoggingChanged(loggingTestCommand(t, "--log-format", "json")))
+}
+
+func TestNewJSONLogger(t *testing.T) {
+	var b bytes.Buffer
+	NewJSONLogger(&b, slog.LevelInfo).Info("linted specification", "document", "pets.yaml", "errors", 2)
+
+	var record map[string]any
+	assert.NoError(t, json.Unmarshal(b.Bytes(), &record))
+	assert.Equal(t, "linted specification", record["msg"])
+	assert.Equal(t, "pets.yaml", record["document"])
+	assert.Equal(t, float64(2), record["errors"])
+}
@@ -0,0 +1,105 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func GetMergeReportCommand() *cobra.Command {
+
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "merge-report",
+		Short:        "Merge vacuum reports into a single report",
+		Long: "Merge vacuum reports (generated by the 'report' command) into a single report, for example when linting " +
+			"has been split across CI jobs. Identical findings are only kept once, and the statistics and score are " +
+			"worked out again. Output ending in '.gz', '.zst' or '.br' is compressed.",
+		Example: "vacuum merge-report shard-1.json shard-2.json -o merged.json",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{"json", "gz", "zst", "br"}, cobra.ShellCompDirectiveFilterFileExt
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+			outputFlag, _ := cmd.Flags().GetString("output")
+			noPrettyFlag, _ := cmd.Flags().GetBool("no-pretty")
+
+			if noStyleFlag {
+				pterm.DisableColor()
+				pterm.DisableStyling()
+			}
+
+			if outputFlag != "" {
+				PrintBanner()
+			}
+
+			if len(args) < 2 {
+				errText := "please supply at least two vacuum reports to merge"
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			var vacuumReports []*vacuum_report.VacuumReport
+			for _, arg := range args {
+				vr, _, err := vacuum_report.BuildVacuumReportFromFile(arg)
+				if err == nil && vr == nil {
+					err = fmt.Errorf("'%s' is not a vacuum report", arg)
+				}
+				if err != nil {
+					pterm.Error.Printf("Unable to read report '%s': %s\n", arg, err.Error())
+					pterm.Println()
+					return err
+				}
+				vacuumReports = append(vacuumReports, vr)
+			}
+
+			merged := vacuum_report.MergeReports(vacuumReports)
+
+			var data []byte
+			compression := vacuum_report.CompressionForFileName(outputFlag)
+			if noPrettyFlag || compression != "" {
+				data, _ = json.Marshal(merged)
+			} else {
+				data, _ = json.MarshalIndent(merged, "", "    ")
+			}
+
+			if outputFlag == "" {
+				fmt.Print(string(data))
+				return nil
+			}
+
+			if compression != "" {
+				compressed, err := vacuum_report.CompressReport(data, compression)
+				if err != nil {
+					return err
+				}
+				data = compressed
+			}
+
+			if err := os.WriteFile(outputFlag, data, 0664); err != nil {
+				pterm.Error.Printf("Unable to write merged report: '%s': %s\n", outputFlag, err.Error())
+				pterm.Println()
+				return err
+			}
+
+			pterm.Success.Printf("Merged %d reports (%d findings, score %d), written to '%s'\n", len(vacuumReports),
+				len(merged.ResultSet.Results), merged.Statistics.OverallScore, outputFlag)
+			pterm.Println()
+			return nil
+		},
+	}
+	cmd.Flags().StringP("output", "o", "", "Write the merged report to this file, instead of stdout ('.gz', '.zst' and '.br' are compressed)")
+	cmd.Flags().BoolP("no-pretty", "n", false, "Render JSON with no formatting")
+	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	return cmd
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMergeReportCommand(t *testing.T) {
+	for _, output := range []string{"merged.json", "merged.json.gz"} {
+		out := filepath.Join(t.TempDir(), output)
+		cmd := GetMergeReportCommand()
+		cmd.SetArgs([]string{"test_data/vacuum-report.json", "test_data/vacuum-report.json", "-o", out})
+		assert.NoError(t, cmd.Execute())
+
+		original, _, err := vacuum_report.BuildVacuumReportFromFile("test_data/vacuum-report.json")
+		assert.NoError(t, err)
+		merged, _, err := vacuum_report.BuildVacuumReportFromFile(out)
+		assert.NoError(t, err)
+		assert.NotNil(t, merged)
+
+		// the same report twice is the same report.
+		assert.Len(t, merged.ResultSet.Results, len(original.ResultSet.Results))
+		assert.Equal(t, original.Statistics.Paths, merged.Statistics.Paths)
+	}
+}
+
+func TestGetMergeReportCommand_Shards(t *testing.T) {
+	one := writeDiffTestReport(t, "one.json",
+		&model.RuleFunctionResult{RuleId: "a", Message: "one", RuleSeverity: model.SeverityWarn})
+	two := writeDiffTestReport(t, "two.json",
+		&model.RuleFunctionResult{RuleId: "b", Message: "two", RuleSeverity: model.SeverityError})
+	out := filepath.Join(t.TempDir(), "merged.json")
+
+	cmd := GetMergeReportCommand()
+	cmd.SetArgs([]string{one, two, "-o", out, "--no-pretty"})
+	assert.NoError(t, cmd.Execute())
+
+	data, err := os.ReadFile(out)
+	assert.NoError(t, err)
+	merged, err := vacuum_report.CheckFileForVacuumReport(data)
+	assert.NoError(t, err)
+	assert.Len(t, merged.ResultSet.Results, 2)
+}
+
+func TestGetMergeReportCommand_BadArgs(t *testing.T) {
+	cmd := GetMergeReportCommand()
+	cmd.SetArgs([]string{"test_data/vacuum-report.json"})
+	assert.Error(t, cmd.Execute())
+
+	cmd = GetMergeReportCommand()
+	cmd.SetArgs([]string{"test_data/vacuum-report.json", "../model/test_files/burgershop.openapi.yaml"})
+	assert.Error(t, cmd.Execute())
+}
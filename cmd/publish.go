@@ -0,0 +1,298 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/daveshanley/vacuum/publish"
+	"github.com/daveshanley/vacuum/utils"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+func GetPublishCommand() *cobra.Command {
+
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "publish",
+		Short:        "Publish the findings of a vacuum report as review comments",
+		Long: "Publish the findings of a vacuum report (generated by the 'report' command) as comments on the lines " +
+			"of a pull request (or merge request) that introduced them. Comments from earlier runs are updated or resolved, instead of " +
+			"being posted again. Findings on lines the change did not touch are skipped.",
+		Example: "vacuum publish github vacuum-report.json --repo pb33f/burgers --pr 42",
+	}
+	cmd.PersistentFlags().String("spec-file", "", "Path of the specification in the repository, for findings with no origin")
+	cmd.PersistentFlags().String("root", "", "Root of the repository, finding paths are made relative to it (defaults to the working directory)")
+	cmd.PersistentFlags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	cmd.AddCommand(getPublishGitHubCommand())
+	cmd.AddCommand(getPublishGitLabCommand())
+	cmd.AddCommand(getPublishBitbucketCommand())
+	return cmd
+}
+
+func getPublishGitHubCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "github",
+		Short:        "Post findings as review comments on a GitHub pull request",
+		Long: "Post findings as review comments on a GitHub pull request. In GitHub Actions the repository, pull " +
+			"request, token and API URL are read from GITHUB_REPOSITORY, GITHUB_REF, GITHUB_TOKEN and GITHUB_API_URL. " +
+			"The token needs 'pull-requests: write' permission.",
+		Example: "vacuum publish github vacuum-report.json --repo pb33f/burgers --pr 42 --token $GITHUB_TOKEN",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{"json", "gz", "zst", "br"}, cobra.ShellCompDirectiveFilterFileExt
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			repoFlag, _ := cmd.Flags().GetString("repo")
+			prFlag, _ := cmd.Flags().GetInt("pr")
+			tokenFlag, _ := cmd.Flags().GetString("token")
+			apiURLFlag, _ := cmd.Flags().GetString("api-url")
+			commitFlag, _ := cmd.Flags().GetString("commit")
+
+			_, findings, err := readPublishReport(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			if repoFlag == "" {
+				repoFlag = os.Getenv("GITHUB_REPOSITORY")
+			}
+			if prFlag == 0 {
+				prFlag = publish.ParseGitHubPullRequestRef(os.Getenv("GITHUB_REF"))
+			}
+			if tokenFlag == "" {
+				tokenFlag = os.Getenv("GITHUB_TOKEN")
+			}
+			if apiURLFlag == "" {
+				apiURLFlag = os.Getenv("GITHUB_API_URL")
+			}
+
+			owner, repo, err := publish.ParseGitHubRepository(repoFlag)
+			if err == nil && prFlag < 1 {
+				err = errors.New("please supply the pull request number to comment on, using '--pr'")
+			}
+			if err != nil {
+				pterm.Error.Println(err.Error())
+				pterm.Println()
+				return err
+			}
+
+			publisher := &publish.GitHubPublisher{
+				APIURL:      apiURLFlag,
+				Token:       tokenFlag,
+				Owner:       owner,
+				Repo:        repo,
+				PullRequest: prFlag,
+				CommitSHA:   commitFlag,
+			}
+			summary, err := publisher.Publish(findings)
+			return renderPublishSummary(fmt.Sprintf("pull request #%d", prFlag), summary, err)
+		},
+	}
+	cmd.Flags().String("repo", "", "GitHub repository, like 'owner/name' (defaults to GITHUB_REPOSITORY)")
+	cmd.Flags().Int("pr", 0, "Pull request number (defaults to the pull request in GITHUB_REF)")
+	cmd.Flags().String("token", "", "GitHub token (defaults to GITHUB_TOKEN)")
+	cmd.Flags().String("api-url", "", "GitHub API URL, for GitHub Enterprise (defaults to GITHUB_API_URL, or "+publish.GitHubAPIURL+")")
+	cmd.Flags().String("commit", "", "Commit to comment on (defaults to the head of the pull request)")
+	return cmd
+}
+
+func getPublishGitLabCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "gitlab",
+		Short:        "Start discussions on the lines of a GitLab merge request",
+		Long: "Start discussions on the lines of a GitLab merge request, and add a summary note with the score. " +
+			"Discussions for findings that have gone are resolved. In GitLab CI merge request pipelines the project, " +
+			"merge request and API URL are read from CI_PROJECT_ID, CI_MERGE_REQUEST_IID and CI_API_V4_URL. The token " +
+			"is read from GITLAB_TOKEN, and needs the 'api' scope (the CI job token cannot start discussions).",
+		Example: "vacuum publish gitlab vacuum-report.json --project pb33f/burgers --mr 7 --token $GITLAB_TOKEN",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{"json", "gz", "zst", "br"}, cobra.ShellCompDirectiveFilterFileExt
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			projectFlag, _ := cmd.Flags().GetString("project")
+			mrFlag, _ := cmd.Flags().GetInt("mr")
+			tokenFlag, _ := cmd.Flags().GetString("token")
+			apiURLFlag, _ := cmd.Flags().GetString("api-url")
+
+			vr, findings, err := readPublishReport(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			if projectFlag == "" {
+				projectFlag = os.Getenv("CI_PROJECT_ID")
+			}
+			if mrFlag == 0 {
+				mrFlag, _ = strconv.Atoi(os.Getenv("CI_MERGE_REQUEST_IID"))
+			}
+			if tokenFlag == "" {
+				tokenFlag = os.Getenv("GITLAB_TOKEN")
+			}
+			if apiURLFlag == "" {
+				apiURLFlag = os.Getenv("CI_API_V4_URL")
+			}
+
+			if projectFlag == "" || mrFlag < 1 {
+				errText := "please supply the project and merge request to comment on, using '--project' and '--mr'"
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			publisher := &publish.GitLabPublisher{
+				APIURL:       apiURLFlag,
+				Token:        tokenFlag,
+				Project:      projectFlag,
+				MergeRequest: mrFlag,
+				Statistics:   vr.Statistics,
+			}
+			summary, err := publisher.Publish(findings)
+			return renderPublishSummary(fmt.Sprintf("merge request !%d", mrFlag), summary, err)
+		},
+	}
+	cmd.Flags().String("project", "", "GitLab project id or path, like 'group/name' (defaults to CI_PROJECT_ID)")
+	cmd.Flags().Int("mr", 0, "Merge request iid (defaults to CI_MERGE_REQUEST_IID)")
+	cmd.Flags().String("token", "", "GitLab access token with the 'api' scope (defaults to GITLAB_TOKEN)")
+	cmd.Flags().String("api-url", "", "GitLab API URL, for self-managed instances (defaults to CI_API_V4_URL, or "+publish.GitLabAPIURL+")")
+	return cmd
+}
+
+func getPublishBitbucketCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "bitbucket",
+		Short:        "Publish a Bitbucket Code Insights report, with an annotation for each finding",
+		Long: "Publish a Code Insights report on a commit, with an annotation for each finding, so pull requests with " +
+			"the commit are decorated. The report of an earlier run on the commit is replaced. Code Insights accepts " +
+			fmt.Sprint(publish.BitbucketMaxAnnotations) + " annotations for a report, the most severe findings are " +
+			"kept. In Bitbucket Pipelines the workspace, repository and commit are read from BITBUCKET_WORKSPACE, " +
+			"BITBUCKET_REPO_SLUG and BITBUCKET_COMMIT, the token is read from BITBUCKET_TOKEN. Use '--server-url' " +
+			"for Bitbucket Server or Data Center.",
+		Example: "vacuum publish bitbucket vacuum-report.json --workspace pb33f --repo burgers --token $BITBUCKET_TOKEN\n" +
+			"vacuum publish bitbucket vacuum-report.json --server-url https://bitbucket.example.com --workspace PB --repo burgers",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{"json", "gz", "zst", "br"}, cobra.ShellCompDirectiveFilterFileExt
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			workspaceFlag, _ := cmd.Flags().GetString("workspace")
+			repoFlag, _ := cmd.Flags().GetString("repo")
+			commitFlag, _ := cmd.Flags().GetString("commit")
+			tokenFlag, _ := cmd.Flags().GetString("token")
+			usernameFlag, _ := cmd.Flags().GetString("username")
+			serverURLFlag, _ := cmd.Flags().GetString("server-url")
+			apiURLFlag, _ := cmd.Flags().GetString("api-url")
+			reportIDFlag, _ := cmd.Flags().GetString("report-id")
+
+			vr, findings, err := readPublishReport(cmd, args)
+			if err != nil {
+				return err
+			}
+
+			if workspaceFlag == "" {
+				workspaceFlag = os.Getenv("BITBUCKET_WORKSPACE")
+			}
+			if repoFlag == "" {
+				repoFlag = os.Getenv("BITBUCKET_REPO_SLUG")
+			}
+			if commitFlag == "" {
+				commitFlag = os.Getenv("BITBUCKET_COMMIT")
+			}
+			if commitFlag == "" {
+				commitFlag = utils.GitHeadSHA(".")
+			}
+			if tokenFlag == "" {
+				tokenFlag = os.Getenv("BITBUCKET_TOKEN")
+			}
+
+			if workspaceFlag == "" || repoFlag == "" || commitFlag == "" {
+				errText := "please supply the workspace (or project), repository and commit to report on, using " +
+					"'--workspace', '--repo' and '--commit'"
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			publisher := &publish.BitbucketPublisher{
+				APIURL:     apiURLFlag,
+				ServerURL:  serverURLFlag,
+				Token:      tokenFlag,
+				Username:   usernameFlag,
+				Workspace:  workspaceFlag,
+				Repo:       repoFlag,
+				Commit:     commitFlag,
+				ReportID:   reportIDFlag,
+				Statistics: vr.Statistics,
+			}
+			summary, err := publisher.Publish(findings)
+			if err != nil {
+				return renderPublishSummary(fmt.Sprintf("commit %s", commitFlag), summary, err)
+			}
+			pterm.Success.Printf("Published a Code Insights report to commit %s: %d annotations, %d left out\n",
+				commitFlag, summary.Created, summary.Skipped)
+			pterm.Println()
+			return nil
+		},
+	}
+	cmd.Flags().String("workspace", "", "Bitbucket Cloud workspace, or Bitbucket Server project key (defaults to BITBUCKET_WORKSPACE)")
+	cmd.Flags().String("repo", "", "Repository slug (defaults to BITBUCKET_REPO_SLUG)")
+	cmd.Flags().String("commit", "", "Commit to report on (defaults to BITBUCKET_COMMIT, or the commit checked out)")
+	cmd.Flags().String("token", "", "Access token, or app password when '--username' is set (defaults to BITBUCKET_TOKEN)")
+	cmd.Flags().String("username", "", "Username for an app password")
+	cmd.Flags().String("server-url", "", "Bitbucket Server or Data Center URL, like 'https://bitbucket.example.com'")
+	cmd.Flags().String("api-url", "", "Bitbucket Cloud API URL (defaults to "+publish.BitbucketAPIURL+")")
+	cmd.Flags().String("report-id", publish.DefaultBitbucketReportID, "Code Insights report id, a commit has one report for each id")
+	return cmd
+}
+
+// readPublishReport reads the vacuum report to publish, and locates its findings in the repository.
+func readPublishReport(cmd *cobra.Command, args []string) (*vacuum_report.VacuumReport, []*publish.Finding, error) {
+	noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+	specFileFlag, _ := cmd.Flags().GetString("spec-file")
+	rootFlag, _ := cmd.Flags().GetString("root")
+
+	if noStyleFlag {
+		pterm.DisableColor()
+		pterm.DisableStyling()
+	}
+
+	if len(args) != 1 {
+		errText := "please supply a vacuum report to publish"
+		pterm.Error.Println(errText)
+		pterm.Println()
+		return nil, nil, errors.New(errText)
+	}
+
+	vr, _, err := vacuum_report.BuildVacuumReportFromFile(args[0])
+	if err == nil && vr == nil {
+		err = fmt.Errorf("'%s' is not a vacuum report", args[0])
+	}
+	if err != nil {
+		pterm.Error.Printf("Unable to read report '%s': %s\n", args[0], err.Error())
+		pterm.Println()
+		return nil, nil, err
+	}
+	return vr, publish.BuildFindings(vr.ResultSet.Results, specFileFlag, rootFlag), nil
+}
+
+func renderPublishSummary(target string, summary *publish.Summary, err error) error {
+	if err != nil {
+		pterm.Error.Printf("Unable to publish to %s: %s\n", target, err.Error())
+		pterm.Println()
+		return err
+	}
+	pterm.Success.Printf("Published to %s: %d created, %d updated, %d unchanged, %d resolved, %d skipped (not changed)\n",
+		target, summary.Created, summary.Updated, summary.Unchanged, summary.Resolved, summary.Skipped)
+	pterm.Println()
+	return nil
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPublishCommand_GitHub(t *testing.T) {
+	var posted atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/repos/pb33f/burgers/pulls/42/files":
+			// every line of the specification has changed.
+			fmt.Fprint(w, `[{"filename":"burgershop.openapi.yaml","patch":"@@ -0,0 +1,5000 @@"}]`)
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `[]`)
+		case r.Method == http.MethodPost:
+			posted.Add(1)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	cmd := GetPublishCommand()
+	cmd.SetArgs([]string{"github", "test_data/vacuum-report.json", "--repo", "pb33f/burgers", "--pr", "42",
+		"--commit", "cafe", "--api-url", server.URL, "--spec-file", "burgershop.openapi.yaml", "--no-style"})
+	assert.NoError(t, cmd.Execute())
+	assert.Greater(t, posted.Load(), int32(0))
+}
+
+func TestGetPublishCommand_GitHub_BadArgs(t *testing.T) {
+	t.Setenv("GITHUB_REPOSITORY", "")
+	t.Setenv("GITHUB_REF", "")
+
+	cmd := GetPublishCommand()
+	cmd.SetArgs([]string{"github", "--repo", "pb33f/burgers", "--pr", "42"})
+	assert.ErrorContains(t, cmd.Execute(), "please supply a vacuum report")
+
+	cmd = GetPublishCommand()
+	cmd.SetArgs([]string{"github", "../model/test_files/burgershop.openapi.yaml", "--repo", "pb33f/burgers", "--pr", "42"})
+	assert.Error(t, cmd.Execute())
+
+	cmd = GetPublishCommand()
+	cmd.SetArgs([]string{"github", "test_data/vacuum-report.json", "--repo", "pb33f/burgers"})
+	assert.ErrorContains(t, cmd.Execute(), "--pr")
+
+	cmd = GetPublishCommand()
+	cmd.SetArgs([]string{"github", "test_data/vacuum-report.json", "--pr", "42"})
+	assert.ErrorContains(t, cmd.Execute(), "owner/name")
+}
+
+func TestGetPublishCommand_GitLab(t *testing.T) {
+	var discussions, notes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/7/merge_requests/3":
+			fmt.Fprint(w, `{"diff_refs":{"base_sha":"a","head_sha":"b","start_sha":"c"}}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/projects/7/merge_requests/3/diffs":
+			fmt.Fprint(w, `[{"new_path":"burgershop.openapi.yaml","diff":"@@ -0,0 +1,5000 @@"}]`)
+		case r.Method == http.MethodGet:
+			fmt.Fprint(w, `[]`)
+		case r.URL.Path == "/projects/7/merge_requests/3/notes":
+			notes.Add(1)
+			fmt.Fprint(w, `{}`)
+		default:
+			discussions.Add(1)
+			fmt.Fprint(w, `{}`)
+		}
+	}))
+	defer server.Close()
+
+	cmd := GetPublishCommand()
+	cmd.SetArgs([]string{"gitlab", "test_data/vacuum-report.json", "--project", "7", "--mr", "3",
+		"--api-url", server.URL, "--spec-file", "burgershop.openapi.yaml", "--no-style"})
+	assert.NoError(t, cmd.Execute())
+	assert.Greater(t, discussions.Load(), int32(0))
+	assert.Equal(t, int32(1), notes.Load())
+}
+
+func TestGetPublishCommand_GitLab_BadArgs(t *testing.T) {
+	t.Setenv("CI_PROJECT_ID", "")
+	t.Setenv("CI_MERGE_REQUEST_IID", "")
+
+	cmd := GetPublishCommand()
+	cmd.SetArgs([]string{"gitlab", "test_data/vacuum-report.json", "--project", "7"})
+	assert.ErrorContains(t, cmd.Execute(), "--mr")
+}
+
+func TestGetPublishCommand_Bitbucket(t *testing.T) {
+	var annotations atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost {
+			var batch []map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			annotations.Add(int32(len(batch)))
+		}
+		fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	cmd := GetPublishCommand()
+	cmd.SetArgs([]string{"bitbucket", "test_data/vacuum-report.json", "--workspace", "pb33f", "--repo", "burgers",
+		"--commit", "cafe", "--api-url", server.URL, "--no-style"})
+	assert.NoError(t, cmd.Execute())
+	assert.Greater(t, annotations.Load(), int32(0))
+}
+
+func TestGetPublishCommand_Bitbucket_BadArgs(t *testing.T) {
+	t.Setenv("BITBUCKET_WORKSPACE", "")
+	t.Setenv("BITBUCKET_REPO_SLUG", "")
+
+	cmd := GetPublishCommand()
+	cmd.SetArgs([]string{"bitbucket", "test_data/vacuum-report.json", "--commit", "cafe"})
+	assert.ErrorContains(t, cmd.Execute(), "--workspace")
+}
@@ -0,0 +1,118 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/statistics"
+	"github.com/pterm/pterm"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// QualityGatesConfigKey is the key of the quality gates in a config file.
+const QualityGatesConfigKey = "gates"
+
+// ScoringConfigKey is the key of the scoring (the weights of the score) in a config file.
+const ScoringConfigKey = "scoring"
+
+// fileQualityGates are the results of checking the quality gates against a single linted file.
+type fileQualityGates struct {
+	fileName string
+	results  []*statistics.QualityGateResult
+}
+
+// loadQualityGatePolicy reads the quality gates (and the scoring) from a gates file if one is supplied, otherwise
+// from the 'gates' and 'scoring' of the config file in use. A nil policy is returned if there are no gates to check,
+// and no scoring. Gates can check the categories of the ruleset, as well as the built-in ones.
+func loadQualityGatePolicy(gatesFile string, categories []*model.RuleCategory) (*statistics.QualityGatePolicy, error) {
+	if gatesFile != "" {
+		return statistics.ReadQualityGatePolicy(gatesFile, categories...)
+	}
+	if !viper.InConfig(QualityGatesConfigKey) && !viper.InConfig(ScoringConfigKey) {
+		return nil, nil
+	}
+	// the config is decoded by viper, round trip it through YAML to get to the gates.
+	raw, err := yaml.Marshal(map[string]any{QualityGatesConfigKey: viper.Get(QualityGatesConfigKey),
+		ScoringConfigKey: viper.Get(ScoringConfigKey)})
+	if err != nil {
+		return nil, fmt.Errorf("unable to read quality gates from config: %w", err)
+	}
+	var policy statistics.QualityGatePolicy
+	if err = yaml.Unmarshal(raw, &policy); err != nil {
+		return nil, fmt.Errorf("unable to read quality gates from config: %w", err)
+	}
+	if err = policy.Validate(categories...); err != nil {
+		return nil, fmt.Errorf("invalid quality gates in config: %w", err)
+	}
+	if len(policy.Gates) == 0 && policy.Scoring == nil {
+		return nil, nil
+	}
+	return &policy, nil
+}
+
+// evaluateQualityGates checks the policy against the statistics of every linted file, in the order the files were
+// linted. A file without statistics (it failed to lint) is checked as if it had none.
+func evaluateQualityGates(policy *statistics.QualityGatePolicy, files []string,
+	stats []*reports.ReportStatistics) []*fileQualityGates {
+	evaluated := make([]*fileQualityGates, 0, len(files))
+	for i, f := range files {
+		evaluated = append(evaluated, &fileQualityGates{fileName: f, results: policy.Evaluate(stats[i])})
+	}
+	return evaluated
+}
+
+// renderQualityGates renders the pass / fail result of every quality gate, for every file, and returns an error
+// listing the gates that failed.
+func renderQualityGates(evaluated []*fileQualityGates, silent, pipelineOutput bool) error {
+	var failed []string
+	for _, fg := range evaluated {
+		var buf strings.Builder
+		tableData := pterm.TableData{{"Gate", "Value", "Result"}}
+		buf.WriteString(fmt.Sprintf("### Quality gates for `%s`\n\n", fg.fileName))
+		buf.WriteString("| Gate | Value | Result |\n| ---- | ----- | ------ |\n")
+		for _, r := range fg.results {
+			gate := r.Gate.Condition()
+			if r.Gate.Name != "" {
+				gate = fmt.Sprintf("%s (%s)", r.Gate.Name, gate)
+			}
+			outcome, plain := pterm.LightGreen("PASSED"), "✅ passed"
+			if !r.Passed {
+				outcome, plain = pterm.LightRed("FAILED"), "🚨 failed"
+				failed = append(failed, fmt.Sprintf("'%s' in '%s' (%d)", gate, fg.fileName, r.Value))
+			}
+			tableData = append(tableData, []string{gate, fmt.Sprint(r.Value), outcome})
+			buf.WriteString(fmt.Sprintf("| %s | %d | %s |\n", gate, r.Value, plain))
+		}
+		if silent {
+			continue
+		}
+		if pipelineOutput {
+			pterm.Println(buf.String())
+			continue
+		}
+		pterm.Println()
+		pterm.DefaultSection.Printf("Quality gates for '%s'", fg.fileName)
+		_ = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+	}
+	if len(failed) == 0 {
+		return nil
+	}
+	if !silent {
+		if !pipelineOutput {
+			pterm.Println()
+			box := pterm.DefaultBox.WithLeftPadding(5).WithRightPadding(5)
+			box.BoxStyle = pterm.NewStyle(pterm.FgLightRed)
+			box.Println(pterm.LightRed("🚨 QUALITY GATES FAILED 🚨"))
+			pterm.Println()
+		} else {
+			pterm.Println(pterm.LightRed("\n> 🚨 QUALITY GATES FAILED, PIPELINE WILL FAIL 🚨\n"))
+		}
+	}
+	return fmt.Errorf("%d quality gates failed: %s", len(failed), strings.Join(failed, ", "))
+}
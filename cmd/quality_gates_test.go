@@ -0,0 +1,112 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetLintCommand_GatesFile(t *testing.T) {
+	dir := t.TempDir()
+	gates := filepath.Join(dir, "gates.yaml")
+	assert.NoError(t, os.WriteFile(gates, []byte(`gates:
+  - name: Overall quality
+    metric: score
+    min: 85
+  - metric: errors
+    category: security
+    max: 0
+`), 0664))
+
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.SetArgs([]string{"--gates-file", gates, "-n", "none", "../model/test_files/burgershop.openapi.yaml"})
+	assert.NoError(t, cmd.Execute())
+
+	// burgershop has three warnings.
+	assert.NoError(t, os.WriteFile(gates, []byte("gates:\n  - metric: warnings\n    max: 1\n"), 0664))
+	cmd = GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.SetArgs([]string{"--gates-file", gates, "-n", "none", "../model/test_files/burgershop.openapi.yaml"})
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "1 quality gates failed: 'warnings <= 1'")
+	assert.ErrorContains(t, err, "(3)")
+}
+
+func TestGetLintCommand_GatesFileScoring(t *testing.T) {
+	gates := filepath.Join(t.TempDir(), "gates.yaml")
+	// burgershop has three warnings, each takes ten points off the score.
+	assert.NoError(t, os.WriteFile(gates, []byte(`scoring:
+  weights:
+    warning: 10
+gates:
+  - metric: score
+    min: 85
+  - metric: errors
+    rule: operation-operationId
+    max: 0
+`), 0664))
+
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.SetArgs([]string{"--gates-file", gates, "-n", "none", "../model/test_files/burgershop.openapi.yaml"})
+	err := cmd.Execute()
+	assert.ErrorContains(t, err, "1 quality gates failed: 'score >= 85'")
+	assert.ErrorContains(t, err, "(70)")
+}
+
+func TestGetLintCommand_GatesFileInvalid(t *testing.T) {
+	gates := filepath.Join(t.TempDir(), "gates.yaml")
+	assert.NoError(t, os.WriteFile(gates, []byte("gates:\n  - metric: errors\n"), 0664))
+
+	cmd := GetLintCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.SetArgs([]string{"--gates-file", gates, "../model/test_files/burgershop.openapi.yaml"})
+	assert.ErrorContains(t, cmd.Execute(), "needs a min or a max")
+}
+
+func TestGetLintCommand_GatesConfig(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	dir := t.TempDir()
+	config := filepath.Join(dir, "vacuum.yaml")
+	assert.NoError(t, os.WriteFile(config, []byte(`gates:
+  - metric: warnings
+    category: examples
+    max: 2
+lint:
+  fail-severity: none
+`), 0664))
+
+	rootCmd := GetRootCommand()
+	rootCmd.SetArgs([]string{"lint", "--config", config, "../model/test_files/burgershop.openapi.yaml"})
+	assert.ErrorContains(t, rootCmd.Execute(), "'warnings in examples <= 2' in '../model/test_files/burgershop.openapi.yaml' (3)")
+}
+
+func TestRenderQualityGates(t *testing.T) {
+	policy, err := loadQualityGatePolicy("", nil)
+	assert.NoError(t, err)
+	assert.Nil(t, policy)
+
+	gates := filepath.Join(t.TempDir(), "gates.yaml")
+	assert.NoError(t, os.WriteFile(gates, []byte("gates:\n  - metric: score\n    min: 50\n"), 0664))
+	policy, err = loadQualityGatePolicy(gates, nil)
+	assert.NoError(t, err)
+
+	// a file that failed to lint has no statistics, so fails a minimum score.
+	evaluated := evaluateQualityGates(policy, []string{"a.yaml", "b.yaml"},
+		[]*reports.ReportStatistics{{OverallScore: 90}, nil})
+	assert.Len(t, evaluated, 2)
+	assert.True(t, evaluated[0].results[0].Passed)
+	assert.False(t, evaluated[1].results[0].Passed)
+
+	assert.EqualError(t, renderQualityGates(evaluated, false, true),
+		"1 quality gates failed: 'score >= 50' in 'b.yaml' (0)")
+	assert.NoError(t, renderQualityGates(evaluated[:1], true, false))
+}
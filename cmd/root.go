@@ -6,14 +6,41 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/daveshanley/vacuum/i18n"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/utils"
 	"github.com/pterm/pterm"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
 	"github.com/spf13/viper"
 )
 
+// ProjectConfigFileNames are the names of a project config file, discovered by searching from the working directory
+// upwards, until the root of a git repository (or the filesystem) is reached.
+var ProjectConfigFileNames = []string{".vacuum.yaml", ".vacuum.yml"}
+
+// configPathFlags are flags that hold a path, relative paths set by a project config file are relative to it.
+var configPathFlags = map[string]bool{
+	"ruleset":        true,
+	"functions":      true,
+	"base":           true,
+	"ignore-file":    true,
+	"baseline":       true,
+	"exclude":        true,
+	"cert-file":      true,
+	"key-file":       true,
+	"ca-file":        true,
+	"junit-template": true,
+	"lock-file":      true,
+	"gates-file":     true,
+}
+
+// projectConfigDir is the directory of the project config file in use, if one was discovered.
+var projectConfigDir string
+
 // TODO: This is a temporary UI, it's to help figure out the best experience, and it is not intended as a final face
 // of vacuum. It's going to change around a good bit, so don't get too comfy with it :)
 var (
@@ -42,6 +69,9 @@ func GetRootCommand() *cobra.Command {
 			if err != nil {
 				pterm.Error.Printf("%s", err)
 			}
+			configureRemoteCache(cmd)
+			configureRefCache(cmd)
+			configureLocale(cmd)
 			return err
 		},
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -54,14 +84,16 @@ func GetRootCommand() *cobra.Command {
 			return nil
 		},
 	}
-	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file (defaults to ./vacuum.conf.yaml) ")
+	rootCmd.PersistentFlags().StringVar(&configFile, "config", "", "config file (defaults to .vacuum.yaml, searched for from the working directory upwards, then ./vacuum.conf.yaml)")
 	rootCmd.PersistentFlags().BoolP("time", "t", false, "Show how long vacuum took to run")
-	rootCmd.PersistentFlags().StringP("ruleset", "r", "", "Location of a vacuum (or Spectral) ruleset")
-	rootCmd.PersistentFlags().StringP("functions", "f", "", "Path to custom functions")
+	rootCmd.PersistentFlags().StringP("ruleset", "r", "", "Location of a vacuum (or Spectral) ruleset, or 'owasp' for the built-in OWASP ruleset")
+	rootCmd.PersistentFlags().StringP("functions", "f", "", "Path to custom functions (Go plugins, JavaScript or WASM)")
 	rootCmd.PersistentFlags().StringP("base", "p", "", "Override Base URL or path to use for resolving local file based or remote references")
 	rootCmd.PersistentFlags().BoolP("remote", "u", true, "Allow local files and remote (http) references to be looked up")
 	rootCmd.PersistentFlags().BoolP("skip-check", "k", false, "Skip checking for a valid OpenAPI document, useful for linting fragments or non-OpenAPI documents")
 	rootCmd.PersistentFlags().BoolP("debug", "w", false, "Turn on debug logging")
+	rootCmd.PersistentFlags().String("log-level", "", "Log records at this level and above: debug, info, warn or error (default is error, or debug with --debug)")
+	rootCmd.PersistentFlags().String("log-format", LogFormatText, "Format of log records: 'text', or 'json' (one object per line, written to stderr)")
 	rootCmd.PersistentFlags().IntP("timeout", "g", 5, "Rule timeout in seconds, default is 5 seconds")
 	rootCmd.PersistentFlags().BoolP("hard-mode", "z", false, "Enable all the built-in rules, even the OWASP ones. This is the level to beat!")
 	rootCmd.PersistentFlags().BoolP("ext-refs", "", false, "Turn on $ref lookups and resolving for extensions (x-) objects")
@@ -69,6 +101,11 @@ func GetRootCommand() *cobra.Command {
 	rootCmd.PersistentFlags().String("key-file", "", "Path to client private key file for HTTPS requests")
 	rootCmd.PersistentFlags().String("ca-file", "", "Path to CA certificate file for HTTPS requests")
 	rootCmd.PersistentFlags().Bool("insecure", false, "Skip TLS certificate verification (insecure)")
+	rootCmd.PersistentFlags().Bool("offline", false, "Only use cached remote rulesets and references, fail if one is not cached")
+	rootCmd.PersistentFlags().String("lock-file", "", "Path to a lockfile pinning the digests of remote rulesets (defaults to ./vacuum.lock, if it exists)")
+	rootCmd.PersistentFlags().Duration("ref-cache-ttl", utils.DefaultRefCacheTTL, "How long remote references ($ref) are cached for before checking if they changed, 0 turns the cache off")
+	rootCmd.PersistentFlags().Bool("refresh-refs", false, "Download every remote reference ($ref) again, ignoring the cache")
+	rootCmd.PersistentFlags().String("locale", "", "Translate rule messages and report titles into a locale (like 'es'), English is the default")
 
 	if regErr := rootCmd.RegisterFlagCompletionFunc("functions", cobra.FixedCompletions(
 		[]string{"so"}, cobra.ShellCompDirectiveFilterFileExt,
@@ -80,9 +117,24 @@ func GetRootCommand() *cobra.Command {
 	)); regErr != nil {
 		panic(regErr)
 	}
+	if regErr := rootCmd.RegisterFlagCompletionFunc("locale", cobra.FixedCompletions(
+		i18n.Locales(), cobra.ShellCompDirectiveNoFileComp,
+	)); regErr != nil {
+		panic(regErr)
+	}
 	if regErr := rootCmd.RegisterFlagCompletionFunc("timeout", cobra.NoFileCompletions); regErr != nil {
 		panic(regErr)
 	}
+	if regErr := rootCmd.RegisterFlagCompletionFunc("log-level", cobra.FixedCompletions(
+		[]string{"debug", "info", "warn", "error"}, cobra.ShellCompDirectiveNoFileComp,
+	)); regErr != nil {
+		panic(regErr)
+	}
+	if regErr := rootCmd.RegisterFlagCompletionFunc("log-format", cobra.FixedCompletions(
+		LogFormats, cobra.ShellCompDirectiveNoFileComp,
+	)); regErr != nil {
+		panic(regErr)
+	}
 	if regErr := rootCmd.RegisterFlagCompletionFunc("cert-file", cobra.FixedCompletions(
 		[]string{"crt", "pem", "cert"}, cobra.ShellCompDirectiveFilterFileExt,
 	)); regErr != nil {
@@ -108,16 +160,70 @@ func GetRootCommand() *cobra.Command {
 	rootCmd.AddCommand(GetHTMLReportCommand())
 	rootCmd.AddCommand(GetDashboardCommand())
 	rootCmd.AddCommand(GetGenerateRulesetCommand())
+	rootCmd.AddCommand(GetValidateRuleSetCommand())
+	rootCmd.AddCommand(GetListRulesCommand())
 	rootCmd.AddCommand(GetGenerateIgnoreFileCommand())
 	rootCmd.AddCommand(GetGenerateVersionCommand())
 	rootCmd.AddCommand(GetLanguageServerCommand())
 	rootCmd.AddCommand(GetBundleCommand())
+	rootCmd.AddCommand(GetDaemonCommand())
+	rootCmd.AddCommand(GetServeCommand())
+	rootCmd.AddCommand(GetBaselineCommand())
+	rootCmd.AddCommand(GetDiffReportCommand())
+	rootCmd.AddCommand(GetBreakingCommand())
+	rootCmd.AddCommand(GetMergeReportCommand())
+	rootCmd.AddCommand(GetTrendCommand())
+	rootCmd.AddCommand(GetPublishCommand())
 
 	return rootCmd
 }
 
+// configureRemoteCache caches remote rulesets under the user cache directory, pinned by a lockfile if there is one.
+func configureRemoteCache(cmd *cobra.Command) {
+	offline, _ := cmd.Flags().GetBool("offline")
+	lockFile, _ := cmd.Flags().GetString("lock-file")
+	if lockFile == "" {
+		if _, err := os.Stat(rulesets.DefaultLockFile); err == nil {
+			lockFile = rulesets.DefaultLockFile
+		}
+	}
+	rulesets.SetRemoteCache(&rulesets.RemoteCache{
+		Dir:      rulesets.DefaultRemoteCacheDir(),
+		Offline:  offline,
+		LockFile: lockFile,
+	})
+}
+
+// configureLocale translates rule messages and report titles, English is used if there is no catalog for the locale.
+func configureLocale(cmd *cobra.Command) {
+	locale, _ := cmd.Flags().GetString("locale")
+	if err := i18n.SetLocale(locale); err != nil {
+		pterm.Warning.Printf("%s, using English\n", err.Error())
+		_ = i18n.SetLocale(i18n.DefaultLocale)
+	}
+}
+
+// configureRefCache caches remote references under the user cache directory, unless the TTL is zero (and vacuum
+// is not offline).
+func configureRefCache(cmd *cobra.Command) {
+	offline, _ := cmd.Flags().GetBool("offline")
+	ttl, _ := cmd.Flags().GetDuration("ref-cache-ttl")
+	refresh, _ := cmd.Flags().GetBool("refresh-refs")
+	if ttl <= 0 && !offline {
+		utils.SetRefCache(nil)
+		return
+	}
+	utils.SetRefCache(&utils.RefCache{
+		Dir:     utils.DefaultRefCacheDir(),
+		TTL:     ttl,
+		Refresh: refresh,
+		Offline: offline,
+	})
+}
+
 func useConfigFile(cmd *cobra.Command) error {
 	useEnvironmentConfiguration()
+	projectConfigDir = ""
 	var err error
 	if len(configFile) != 0 {
 		err = useUserSuppliedConfigFile(configFile)
@@ -140,6 +246,12 @@ func useConfigFile(cmd *cobra.Command) error {
 }
 
 func useDefaultConfigFile() error {
+	if cwd, err := os.Getwd(); err == nil {
+		if projectConfig := findProjectConfigFile(cwd); projectConfig != "" {
+			projectConfigDir = filepath.Dir(projectConfig)
+			return useUserSuppliedConfigFile(projectConfig)
+		}
+	}
 	viper.SetConfigName("vacuum.conf")
 	viper.SetConfigType("yaml")
 	viper.AddConfigPath(".")
@@ -163,6 +275,27 @@ func useEnvironmentConfiguration() {
 	viper.SetEnvKeyReplacer(strings.NewReplacer("-", "_"))
 }
 
+// findProjectConfigFile searches for a project config file in dir and every parent of dir, stopping at the root of
+// a git repository. An empty string is returned if there isn't one.
+func findProjectConfigFile(dir string) string {
+	for {
+		for _, name := range ProjectConfigFileNames {
+			candidate := filepath.Join(dir, name)
+			if fi, err := os.Stat(candidate); err == nil && !fi.IsDir() {
+				return candidate
+			}
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return ""
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
 func useUserSuppliedConfigFile(configFilePath string) error {
 	viper.SetConfigFile(os.ExpandEnv(configFilePath))
 	return viper.ReadInConfig()
@@ -181,10 +314,38 @@ func getXdgConfigHome() string {
 func bindFlags(flags *pflag.FlagSet, viperTree *viper.Viper) error {
 	var err error
 	flags.VisitAll(func(f *pflag.Flag) {
-		if !f.Changed && viperTree.IsSet(f.Name) {
-			val := viperTree.Get(f.Name)
-			err = flags.Set(f.Name, fmt.Sprintf("%v", val))
+		if err != nil || f.Changed || !viperTree.IsSet(f.Name) {
+			return
+		}
+		// lists (like repeatable flags) are set one value at a time.
+		var values []string
+		switch val := viperTree.Get(f.Name).(type) {
+		case []interface{}:
+			for _, v := range val {
+				values = append(values, fmt.Sprintf("%v", v))
+			}
+		case []string:
+			values = val
+		default:
+			values = []string{fmt.Sprintf("%v", val)}
+		}
+		for _, v := range values {
+			if configPathFlags[f.Name] && projectConfigDir != "" && viperTree.InConfig(f.Name) &&
+				!(f.Name == "ruleset" && rulesets.IsBuiltInRuleSetName(v)) {
+				v = resolveConfigPath(projectConfigDir, v)
+			}
+			if err = flags.Set(f.Name, v); err != nil {
+				return
+			}
 		}
 	})
 	return err
 }
+
+// resolveConfigPath makes a relative path from a config file relative to the directory of the config file.
+func resolveConfigPath(configDir, path string) string {
+	if path == "" || filepath.IsAbs(path) || strings.Contains(path, "://") {
+		return path
+	}
+	return filepath.Join(configDir, path)
+}
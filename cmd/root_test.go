@@ -3,8 +3,14 @@ package cmd
 import (
 	"bytes"
 	"io"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/daveshanley/vacuum/i18n"
+	"github.com/daveshanley/vacuum/utils"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,3 +57,98 @@ func TestLocalFlagConfigFile(t *testing.T) {
 	assert.NotNil(t, outBytes)
 	//TODO test local flag override
 }
+
+func TestFindProjectConfigFile(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "one", "two")
+	assert.NoError(t, os.MkdirAll(nested, 0775))
+	assert.Empty(t, findProjectConfigFile(nested))
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".vacuum.yaml"), []byte("time: true"), 0664))
+	assert.Equal(t, filepath.Join(dir, ".vacuum.yaml"), findProjectConfigFile(nested))
+
+	// the search stops at the root of a git repository.
+	assert.NoError(t, os.Mkdir(filepath.Join(dir, "one", ".git"), 0775))
+	assert.Empty(t, findProjectConfigFile(nested))
+}
+
+func TestResolveConfigPath(t *testing.T) {
+	assert.Equal(t, filepath.Join("config", "rules.yaml"), resolveConfigPath("config", "rules.yaml"))
+	assert.Equal(t, "/abs/rules.yaml", resolveConfigPath("config", "/abs/rules.yaml"))
+	assert.Equal(t, "https://example.com/rules.yaml", resolveConfigPath("config", "https://example.com/rules.yaml"))
+}
+
+func TestProjectConfigFile(t *testing.T) {
+	t.Cleanup(viper.Reset)
+	spec, err := os.ReadFile("../model/test_files/petstorev3.json")
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "specs", "legacy"), 0775))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "specs", "petstore.json"), spec, 0664))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "specs", "legacy", "broken.yaml"), []byte("nope"), 0664))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, ".vacuum.yaml"), []byte(`lint:
+  fail-severity: none
+  exclude:
+    - specs/legacy/**
+`), 0664))
+	t.Chdir(filepath.Join(dir, "specs"))
+
+	rootCmd := GetRootCommand()
+	rootCmd.SetArgs([]string{"lint", "petstore.json", "legacy/broken.yaml"})
+	assert.NoError(t, rootCmd.Execute())
+
+	lint, _, _ := rootCmd.Find([]string{"lint"})
+	exclude, _ := lint.Flags().GetStringArray("exclude")
+	assert.Equal(t, []string{filepath.Join(dir, "specs", "legacy", "**")}, exclude)
+
+	// flags always win over the config file.
+	rootCmd = GetRootCommand()
+	rootCmd.SetArgs([]string{"lint", "-n", "error", "petstore.json"})
+	assert.Error(t, rootCmd.Execute())
+}
+
+func TestConfigureRefCache(t *testing.T) {
+	t.Cleanup(func() { utils.SetRefCache(nil) })
+
+	configureRefCache(loggingTestCommand(t))
+	cache := utils.GetRefCache()
+	assert.NotNil(t, cache)
+	assert.Equal(t, utils.DefaultRefCacheTTL, cache.TTL)
+	assert.False(t, cache.Refresh)
+
+	configureRefCache(loggingTestCommand(t, "--ref-cache-ttl", "10m", "--refresh-refs"))
+	cache = utils.GetRefCache()
+	assert.Equal(t, 10*time.Minute, cache.TTL)
+	assert.True(t, cache.Refresh)
+}
+
+func TestConfigureRefCache_Off(t *testing.T) {
+	t.Cleanup(func() { utils.SetRefCache(nil) })
+
+	configureRefCache(loggingTestCommand(t, "--ref-cache-ttl", "0"))
+	assert.Nil(t, utils.GetRefCache())
+
+	// offline still uses what is cached.
+	configureRefCache(loggingTestCommand(t, "--ref-cache-ttl", "0", "--offline"))
+	assert.True(t, utils.GetRefCache().Offline)
+}
+
+func TestConfigureLocale(t *testing.T) {
+	t.Cleanup(func() { i18n.SetCatalog(nil) })
+
+	configureLocale(loggingTestCommand(t, "--locale", "es"))
+	assert.Equal(t, "es", i18n.GetCatalog().Locale)
+	assert.Equal(t, "Errores", i18n.T("summary.errors", "Errors"))
+
+	configureLocale(loggingTestCommand(t))
+	assert.Nil(t, i18n.GetCatalog())
+	assert.Equal(t, "Errors", i18n.T("summary.errors", "Errors"))
+}
+
+func TestConfigureLocale_Unknown(t *testing.T) {
+	t.Cleanup(func() { i18n.SetCatalog(nil) })
+
+	configureLocale(loggingTestCommand(t, "--locale", "xx"))
+	assert.Nil(t, i18n.GetCatalog())
+}
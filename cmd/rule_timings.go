@@ -0,0 +1,34 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/pterm/pterm"
+)
+
+// renderRuleTimings renders how long each rule took to run, and how many results it found, the slowest rule first.
+func renderRuleTimings(fileName string, timings []*reports.RuleTiming) {
+	if len(timings) == 0 {
+		return
+	}
+	var total float64
+	tableData := pterm.TableData{{"Rule", "Time", "Results"}}
+	for _, t := range timings {
+		duration := fmt.Sprintf("%.2fms", t.DurationMs)
+		if t.TimedOut {
+			duration += " (timed out)"
+		}
+		tableData = append(tableData, []string{t.RuleId, duration, fmt.Sprint(t.Results)})
+		total += t.DurationMs
+	}
+	if fileName != "" {
+		pterm.Info.Printf("Rule timings for '%s', %d rules took %.2fms in total (rules run concurrently)\n",
+			fileName, len(timings), total)
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+	pterm.Println()
+}
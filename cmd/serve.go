@@ -0,0 +1,449 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"syscall"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/statistics"
+	"github.com/daveshanley/vacuum/utils"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// ServeFormats are the reports the lint endpoint of the server can respond with.
+var ServeFormats = []string{FormatJSON, FormatSARIF, FormatJUnit}
+
+// serveContentTypes are the content types of the reports the server responds with.
+var serveContentTypes = map[string]string{
+	FormatJSON:  "application/json",
+	FormatSARIF: "application/sarif+json",
+	FormatJUnit: "application/xml",
+}
+
+// Defaults for the server.
+const (
+	DefaultServePort           = 8080
+	DefaultServeMaxRequestSize = 10 // megabytes
+	DefaultServeQueueTimeout   = 30 // seconds
+)
+
+// lintServer lints specifications posted to it, and responds with a report.
+type lintServer struct {
+	ruleSet         *rulesets.RuleSet
+	customFunctions map[string]model.RuleFunction
+	remote          bool
+	skipCheck       bool
+	timeout         time.Duration
+	httpConfig      utils.HTTPClientConfig
+	maxRequestSize  int64
+	queueTimeout    time.Duration
+	slots           chan struct{} // one for every spec that can be linted at the same time.
+	inFlight        atomic.Int32
+	draining        atomic.Bool
+}
+
+// serveError is the body of every response that isn't a report.
+type serveError struct {
+	Error  string   `json:"error"`
+	Errors []string `json:"errors,omitempty"`
+}
+
+// serveStatus is the body of the health and readiness endpoints.
+type serveStatus struct {
+	Status        string `json:"status"`
+	Rules         int    `json:"rules,omitempty"`
+	InFlight      int    `json:"inFlight"`
+	MaxConcurrent int    `json:"maxConcurrent,omitempty"`
+}
+
+func GetServeCommand() *cobra.Command {
+
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "serve",
+		Short:        "Run vacuum as an HTTP service, lint specifications posted to it",
+		Long: "Run an HTTP server that lints OpenAPI specifications posted to it, and responds with a JSON, SARIF " +
+			"or JUnit report. Specifications are linted against the ruleset of the server, unless a ruleset is " +
+			"posted with the specification. GET /health and /ready are there for load balancers and orchestrators.",
+		Example: "vacuum serve --port 8080 --max-concurrent 4\n" +
+			"curl --data-binary @openapi.yaml 'http://localhost:8080/lint?format=sarif'\n" +
+			"curl -F spec=@openapi.yaml -F ruleset=@ruleset.yaml http://localhost:8080/lint",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			hostFlag, _ := cmd.Flags().GetString("host")
+			portFlag, _ := cmd.Flags().GetInt("port")
+			maxConcurrentFlag, _ := cmd.Flags().GetInt("max-concurrent")
+			maxRequestSizeFlag, _ := cmd.Flags().GetInt("max-request-size")
+			queueTimeoutFlag, _ := cmd.Flags().GetInt("queue-timeout")
+			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+
+			skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
+			timeoutFlag, _ := cmd.Flags().GetInt("timeout")
+			hardModeFlag, _ := cmd.Flags().GetBool("hard-mode")
+			remoteFlag, _ := cmd.Flags().GetBool("remote")
+			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+			functionsFlag, _ := cmd.Flags().GetString("functions")
+
+			// Certificate/TLS configuration
+			certFile, _ := cmd.Flags().GetString("cert-file")
+			keyFile, _ := cmd.Flags().GetString("key-file")
+			caFile, _ := cmd.Flags().GetString("ca-file")
+			insecure, _ := cmd.Flags().GetBool("insecure")
+
+			if noStyleFlag {
+				pterm.DisableColor()
+				pterm.DisableStyling()
+			}
+
+			if portFlag < 0 || portFlag > 65535 {
+				errText := fmt.Sprintf("port '%d' is not valid, it must be between 0 and 65535", portFlag)
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+			if maxRequestSizeFlag <= 0 {
+				errText := "'--max-request-size' must be at least 1 megabyte"
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			httpClientConfig := utils.HTTPClientConfig{
+				CertFile: certFile,
+				KeyFile:  keyFile,
+				CAFile:   caFile,
+				Insecure: insecure,
+			}
+			httpClient := &http.Client{Timeout: 30 * time.Second}
+			if utils.ShouldUseCustomHTTPClient(httpClientConfig) {
+				var clientErr error
+				httpClient, clientErr = utils.CreateCustomHTTPClient(httpClientConfig)
+				if clientErr != nil {
+					pterm.Error.Printf("Failed to create custom HTTP client: %s\n", clientErr.Error())
+					return clientErr
+				}
+			}
+
+			defaultRuleSets := rulesets.BuildDefaultRuleSets()
+			selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
+			if hardModeFlag {
+				selectedRS = defaultRuleSets.GenerateOpenAPIDefaultRuleSet()
+				for k, v := range rulesets.GetAllOWASPRules() {
+					selectedRS.Rules[k] = v
+				}
+			}
+			if rulesetFlag != "" {
+				var rsErr error
+				selectedRS, rsErr = BuildRuleSetFromUserSuppliedLocation(rulesetFlag, defaultRuleSets, remoteFlag, httpClient)
+				if rsErr != nil {
+					pterm.Error.Printf("Unable to load ruleset '%s': %s\n", rulesetFlag, rsErr.Error())
+					pterm.Println()
+					return rsErr
+				}
+				MergeOWASPRulesToRuleSet(selectedRS, hardModeFlag)
+			}
+			customFunctions, _ := LoadCustomFunctions(functionsFlag, true)
+			customFunctions, rfErr := LoadRuleSetFunctions(rulesetFlag, selectedRS, customFunctions, true)
+			if rfErr != nil {
+				return rfErr
+			}
+
+			server := newLintServer(selectedRS, maxConcurrentFlag)
+			server.customFunctions = customFunctions
+			// specifications come from anyone who can reach the server, references are only looked up if asked.
+			server.remote = remoteFlag && cmd.Flags().Changed("remote")
+			server.skipCheck = skipCheckFlag
+			server.timeout = time.Duration(timeoutFlag) * time.Second
+			server.httpConfig = httpClientConfig
+			server.maxRequestSize = int64(maxRequestSizeFlag) << 20
+			server.queueTimeout = time.Duration(queueTimeoutFlag) * time.Second
+
+			listener, lErr := net.Listen("tcp", net.JoinHostPort(hostFlag, strconv.Itoa(portFlag)))
+			if lErr != nil {
+				pterm.Error.Printf("Unable to listen on port %d: %s\n", portFlag, lErr.Error())
+				pterm.Println()
+				return lErr
+			}
+			httpServer := &http.Server{
+				Handler:           server.handler(),
+				ReadHeaderTimeout: 10 * time.Second,
+			}
+
+			pterm.Info.Printf("vacuum server listening on '%s', linting against %d rules, %d at a time\n",
+				listener.Addr().String(), len(selectedRS.Rules), cap(server.slots))
+			pterm.Println()
+
+			serveErr := make(chan error, 1)
+			go func() {
+				serveErr <- httpServer.Serve(listener)
+			}()
+
+			signals := make(chan os.Signal, 1)
+			signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+			select {
+			case err := <-serveErr:
+				pterm.Error.Printf("vacuum server stopped: %s\n", err.Error())
+				return err
+			case <-signals:
+			}
+
+			// stop being ready first, then let the specs being linted finish.
+			pterm.Info.Println("vacuum server shutting down")
+			server.draining.Store(true)
+			ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+			defer cancel()
+			return httpServer.Shutdown(ctx)
+		},
+	}
+	cmd.Flags().String("host", "", "Address to listen on, all of them by default")
+	cmd.Flags().Int("port", DefaultServePort, "Port to listen on")
+	cmd.Flags().Int("max-concurrent", 0, "How many specifications can be linted at the same time, the number of CPUs by default")
+	cmd.Flags().Int("max-request-size", DefaultServeMaxRequestSize, "The largest request accepted, in megabytes")
+	cmd.Flags().Int("queue-timeout", DefaultServeQueueTimeout, "How long a request waits for a turn to be linted, in seconds, before a 429 response")
+	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	return cmd
+}
+
+// newLintServer creates a server that lints against a ruleset, no more than maxConcurrent specs at a time.
+func newLintServer(ruleSet *rulesets.RuleSet, maxConcurrent int) *lintServer {
+	if maxConcurrent <= 0 {
+		maxConcurrent = runtime.NumCPU()
+	}
+	return &lintServer{
+		ruleSet:        ruleSet,
+		timeout:        5 * time.Second,
+		maxRequestSize: DefaultServeMaxRequestSize << 20,
+		queueTimeout:   DefaultServeQueueTimeout * time.Second,
+		slots:          make(chan struct{}, maxConcurrent),
+	}
+}
+
+func (s *lintServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/lint", s.handleLint)
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/ready", s.handleReady)
+	return mux
+}
+
+// handleHealth responds as long as the server is running.
+func (s *lintServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	writeServeJSON(w, http.StatusOK, &serveStatus{Status: "ok", InFlight: int(s.inFlight.Load())})
+}
+
+// handleReady responds with a 503 once the server is shutting down, so no new specs are sent its way.
+func (s *lintServer) handleReady(w http.ResponseWriter, r *http.Request) {
+	status := &serveStatus{Status: "ready", Rules: len(s.ruleSet.Rules), InFlight: int(s.inFlight.Load()),
+		MaxConcurrent: cap(s.slots)}
+	if s.draining.Load() {
+		status.Status = "draining"
+		writeServeJSON(w, http.StatusServiceUnavailable, status)
+		return
+	}
+	writeServeJSON(w, http.StatusOK, status)
+}
+
+// handleLint lints the specification in the body of the request, or the 'spec' part of a multipart form (with an
+// optional 'ruleset' part), and responds with a report in the format of the 'format' query parameter.
+func (s *lintServer) handleLint(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		writeServeError(w, http.StatusMethodNotAllowed, "specifications must be posted to be linted")
+		return
+	}
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = FormatJSON
+	}
+	if serveContentTypes[format] == "" {
+		writeServeError(w, http.StatusBadRequest,
+			fmt.Sprintf("unknown format '%s', supported formats are %v", format, ServeFormats))
+		return
+	}
+
+	r.Body = http.MaxBytesReader(w, r.Body, s.maxRequestSize)
+	fileName, spec, ruleSetBytes, err := s.readLintRequest(r)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			writeServeError(w, http.StatusRequestEntityTooLarge,
+				fmt.Sprintf("the request is larger than %d bytes", s.maxRequestSize))
+			return
+		}
+		writeServeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if len(spec) == 0 {
+		writeServeError(w, http.StatusBadRequest, "there is no specification to lint")
+		return
+	}
+
+	ruleSet := s.ruleSet
+	if len(ruleSetBytes) > 0 {
+		if ruleSet, err = buildPostedRuleSet(ruleSetBytes); err != nil {
+			writeServeError(w, http.StatusBadRequest, fmt.Sprintf("unable to use ruleset: %s", err.Error()))
+			return
+		}
+	}
+
+	// wait for a turn, rather than linting more specs at once than the server can handle.
+	wait := time.NewTimer(s.queueTimeout)
+	defer wait.Stop()
+	select {
+	case s.slots <- struct{}{}:
+	case <-wait.C:
+		w.Header().Set("Retry-After", strconv.Itoa(int(max(s.queueTimeout/time.Second, 1))))
+		writeServeError(w, http.StatusTooManyRequests, "the server is linting as many specifications as it can")
+		return
+	case <-r.Context().Done():
+		return
+	}
+	s.inFlight.Add(1)
+	start := time.Now()
+	report, lintErrs := s.lint(fileName, spec, ruleSet)
+	s.inFlight.Add(-1)
+	<-s.slots
+
+	if len(lintErrs) > 0 {
+		resp := &serveError{Error: fmt.Sprintf("unable to lint '%s'", fileName)}
+		for _, e := range lintErrs {
+			resp.Errors = append(resp.Errors, e.Error())
+		}
+		writeServeJSON(w, http.StatusUnprocessableEntity, resp)
+		return
+	}
+
+	var body []byte
+	files := []*vacuum_report.FileReport{report}
+	switch format {
+	case FormatSARIF:
+		body = vacuum_report.BuildSARIFReportForFiles(files, Version)
+	case FormatJUnit:
+		body = vacuum_report.BuildJUnitReportForFiles(files, start, &vacuum_report.JUnitReportConfig{
+			FailureThreshold: model.NewFailureThreshold(model.SeverityError, -1),
+		})
+	default:
+		body = vacuum_report.BuildAggregatedJSONReport(files, time.Now())
+	}
+	w.Header().Set("Content-Type", serveContentTypes[format])
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(body)
+}
+
+// readLintRequest reads the name of the specification, the specification and the ruleset (if there is one) from
+// a request. The name is the 'file' query parameter, or the name of the uploaded file.
+func (s *lintServer) readLintRequest(r *http.Request) (string, []byte, []byte, error) {
+	fileName := r.URL.Query().Get("file")
+	mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type"))
+	if mediaType != "multipart/form-data" {
+		spec, err := io.ReadAll(r.Body)
+		if fileName == "" {
+			fileName = "openapi"
+		}
+		return fileName, spec, nil, err
+	}
+
+	if err := r.ParseMultipartForm(s.maxRequestSize); err != nil {
+		return "", nil, nil, err
+	}
+	defer r.MultipartForm.RemoveAll()
+	specFile, header, err := r.FormFile("spec")
+	if err != nil {
+		return "", nil, nil, errors.New("the form has no 'spec' to lint")
+	}
+	defer specFile.Close()
+	spec, err := io.ReadAll(specFile)
+	if err != nil {
+		return "", nil, nil, err
+	}
+	if fileName == "" {
+		fileName = header.Filename
+	}
+
+	var ruleSet []byte
+	if rsFile, _, rsErr := r.FormFile("ruleset"); rsErr == nil {
+		defer rsFile.Close()
+		if ruleSet, err = io.ReadAll(rsFile); err != nil {
+			return "", nil, nil, err
+		}
+	}
+	return fileName, spec, ruleSet, nil
+}
+
+// buildPostedRuleSet builds a ruleset posted with a specification. Posted rulesets can only extend the built-in
+// rulesets, the server doesn't read files or fetch URLs for whoever posts a ruleset.
+func buildPostedRuleSet(ruleSetBytes []byte) (*rulesets.RuleSet, error) {
+	userRS, err := rulesets.CreateRuleSetFromData(ruleSetBytes)
+	if err != nil {
+		return nil, err
+	}
+	extends := userRS.GetExtendsValue()
+	if rulesets.CheckForRemoteExtends(extends) || rulesets.CheckForLocalExtends(extends) {
+		return nil, errors.New("posted rulesets can only extend the built-in rulesets")
+	}
+	// built-in rules are changed by the rulesets that use them, so every posted ruleset gets its own.
+	return rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(userRS), nil
+}
+
+// lint runs a ruleset against a specification, and returns the results ready to render as a report.
+func (s *lintServer) lint(fileName string, spec []byte, ruleSet *rulesets.RuleSet) (*vacuum_report.FileReport, []error) {
+	result := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet:           ruleSet,
+		Spec:              spec,
+		SpecDisplayName:   fileName,
+		CustomFunctions:   s.customFunctions,
+		AllowLookup:       s.remote,
+		SkipDocumentCheck: s.skipCheck,
+		SilenceLogs:       true,
+		Timeout:           s.timeout,
+		HTTPClientConfig:  s.httpConfig,
+	})
+	if len(result.Errors) > 0 {
+		return nil, result.Errors
+	}
+
+	resultSet := model.NewRuleResultSet(result.Results)
+	resultSet.RuleSetCategories = ruleSet.Categories
+	resultSet.SortResultsByLineNumber()
+	resultSet.PrepareForSerialization(result.SpecInfo)
+	stats := statistics.CreateReportStatistics(result.Index, result.SpecInfo, resultSet)
+	if stats != nil {
+		stats.RuleTimings = result.RuleTimings
+	}
+	return &vacuum_report.FileReport{
+		FileName:   fileName,
+		Statistics: stats,
+		ResultSet:  resultSet,
+		Spec:       spec,
+	}, nil
+}
+
+func writeServeError(w http.ResponseWriter, status int, message string) {
+	writeServeJSON(w, status, &serveError{Error: message})
+}
+
+func writeServeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(body)
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestLintServer(maxConcurrent int) *lintServer {
+	return newLintServer(rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet(), maxConcurrent)
+}
+
+func TestLintServer_HealthAndReady(t *testing.T) {
+	s := newTestLintServer(2)
+	server := httptest.NewServer(s.handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/health")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var status serveStatus
+	resp, err = http.Get(server.URL + "/ready")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&status))
+	assert.Equal(t, "ready", status.Status)
+	assert.Equal(t, 2, status.MaxConcurrent)
+	assert.Equal(t, len(s.ruleSet.Rules), status.Rules)
+
+	s.draining.Store(true)
+	resp, err = http.Get(server.URL + "/ready")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+}
+
+func TestLintServer_Lint(t *testing.T) {
+	server := httptest.NewServer(newTestLintServer(2).handler())
+	defer server.Close()
+	spec, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	resp, err := http.Post(server.URL+"/lint?file=burgershop.yaml", "application/yaml", bytes.NewReader(spec))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/json", resp.Header.Get("Content-Type"))
+
+	var report vacuum_report.AggregatedReport
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	assert.Len(t, report.Files, 1)
+	assert.Equal(t, "burgershop.yaml", report.Files[0].FileName)
+	assert.NotEmpty(t, report.Files[0].ResultSet.Results)
+	assert.NotNil(t, report.Files[0].Statistics)
+}
+
+func TestLintServer_Lint_Formats(t *testing.T) {
+	server := httptest.NewServer(newTestLintServer(2).handler())
+	defer server.Close()
+	spec, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	resp, err := http.Post(server.URL+"/lint?format=sarif", "application/yaml", bytes.NewReader(spec))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/sarif+json", resp.Header.Get("Content-Type"))
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), `"version": "2.1.0"`)
+
+	resp, err = http.Post(server.URL+"/lint?format=junit", "application/yaml", bytes.NewReader(spec))
+	assert.NoError(t, err)
+	assert.Equal(t, "application/xml", resp.Header.Get("Content-Type"))
+	body, _ = io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "<testsuites")
+
+	resp, err = http.Post(server.URL+"/lint?format=pdf", "application/yaml", bytes.NewReader(spec))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestLintServer_Lint_PostedRuleSet(t *testing.T) {
+	server := httptest.NewServer(newTestLintServer(2).handler())
+	defer server.Close()
+	spec, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	post := func(ruleSet string) *http.Response {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("spec", "burgershop.openapi.yaml")
+		_, _ = part.Write(spec)
+		part, _ = form.CreateFormFile("ruleset", "ruleset.yaml")
+		_, _ = part.Write([]byte(ruleSet))
+		_ = form.Close()
+		resp, err := http.Post(server.URL+"/lint", form.FormDataContentType(), &body)
+		assert.NoError(t, err)
+		return resp
+	}
+
+	resp := post(`extends: [[vacuum:oas, off]]
+rules:
+  no-burgers:
+    description: no burgers allowed
+    given: $.info
+    then:
+      field: title
+      function: falsy`)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var report vacuum_report.AggregatedReport
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+	assert.Equal(t, "burgershop.openapi.yaml", report.Files[0].FileName)
+	assert.Len(t, report.Files[0].ResultSet.Results, 1)
+	assert.Equal(t, "no-burgers", report.Files[0].ResultSet.Results[0].RuleId)
+
+	resp = post(`extends: [[https://example.com/ruleset.yaml, all]]`)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Contains(t, string(body), "can only extend the built-in rulesets")
+}
+
+func TestLintServer_Lint_PostedRuleSetCategories(t *testing.T) {
+	server := httptest.NewServer(newTestLintServer(8).handler())
+	defer server.Close()
+	spec, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	post := func(name string) []string {
+		var body bytes.Buffer
+		form := multipart.NewWriter(&body)
+		part, _ := form.CreateFormFile("spec", "burgershop.openapi.yaml")
+		_, _ = part.Write(spec)
+		part, _ = form.CreateFormFile("ruleset", "ruleset.yaml")
+		_, _ = part.Write([]byte(fmt.Sprintf(`extends: [[vacuum:oas, off]]
+categories:
+  - id: tags
+    name: %[1]s tags
+  - id: %[1]s
+    name: %[1]s rules
+rules:
+  %[1]s-title:
+    description: no titles
+    category: %[1]s
+    given: $.info
+    then:
+      field: title
+      function: falsy
+  %[1]s-tags:
+    description: no tags
+    category: tags
+    given: $
+    then:
+      field: tags
+      function: falsy`, name)))
+		_ = form.Close()
+		resp, err := http.Post(server.URL+"/lint", form.FormDataContentType(), &body)
+		if !assert.NoError(t, err) {
+			return nil
+		}
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		var report vacuum_report.AggregatedReport
+		assert.NoError(t, json.NewDecoder(resp.Body).Decode(&report))
+		var categories []string
+		for _, cat := range report.Files[0].Statistics.CategoryStatistics {
+			if cat.NumIssues > 0 {
+				categories = append(categories, cat.CategoryName)
+			}
+		}
+		return categories
+	}
+
+	// every client gets the categories of its own ruleset, whatever the other clients post.
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		name := "pizza"
+		if i%2 == 1 {
+			name = "tacos"
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			assert.ElementsMatch(t, []string{name + " rules", name + " tags"}, post(name))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, "Tags", model.RuleCategories[model.CategoryTags].Name)
+	assert.Nil(t, model.RuleCategories["pizza"])
+}
+
+func TestLintServer_Lint_Rejected(t *testing.T) {
+	s := newTestLintServer(1)
+	s.maxRequestSize = 64
+	server := httptest.NewServer(s.handler())
+	defer server.Close()
+
+	resp, err := http.Get(server.URL + "/lint")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/lint", "application/yaml", strings.NewReader(strings.Repeat("a", 100)))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/lint", "application/yaml", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	resp, err = http.Post(server.URL+"/lint", "application/yaml", strings.NewReader("not: [a spec"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestLintServer_Lint_Busy(t *testing.T) {
+	s := newTestLintServer(1)
+	s.queueTimeout = 10 * time.Millisecond
+	s.slots <- struct{}{} // every slot is taken.
+	server := httptest.NewServer(s.handler())
+	defer server.Close()
+
+	resp, err := http.Post(server.URL+"/lint", "application/yaml", strings.NewReader("openapi: 3.1.0"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get("Retry-After"))
+}
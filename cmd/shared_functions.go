@@ -7,13 +7,18 @@ import (
 	"context"
 	"fmt"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/plugin"
 	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/utils"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
 	"github.com/dustin/go-humanize"
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pterm/pterm"
 	"net/http"
 	"os"
+	"path/filepath"
+	"runtime/debug"
 	"strings"
 	"time"
 )
@@ -42,11 +47,32 @@ func BuildRuleSetFromUserSuppliedSetWithHTTPClient(rsBytes []byte, rs rulesets.R
 		return nil, userErr
 
 	}
-	return rs.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(userRS, httpClient), nil
+	return checkRemoteRuleSets(func() *rulesets.RuleSet {
+		return rs.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(userRS, httpClient)
+	})
+}
+
+// checkRemoteRuleSets generates a ruleset and returns an error if a remote ruleset it extends wasn't cached when
+// running offline, or didn't match the lockfile. Extended rulesets that can't be fetched are otherwise skipped.
+func checkRemoteRuleSets(generate func() *rulesets.RuleSet) (*rulesets.RuleSet, error) {
+	cache := rulesets.GetRemoteCache()
+	if cache != nil {
+		cache.Reset()
+	}
+	selectedRS := generate()
+	if cache != nil && (cache.Offline || cache.LockFile != "") {
+		if err := cache.Err(); err != nil {
+			return nil, err
+		}
+	}
+	return selectedRS, nil
 }
 
 // BuildRuleSetFromUserSuppliedLocation creates a ready to run ruleset from a location (file path or URL)
 func BuildRuleSetFromUserSuppliedLocation(rulesetFlag string, rs rulesets.RuleSets, remote bool, httpClient *http.Client) (*rulesets.RuleSet, error) {
+	if builtIn := rulesets.GenerateBuiltInRuleSet(rulesetFlag, rs); builtIn != nil {
+		return builtIn, nil
+	}
 	if strings.HasPrefix(rulesetFlag, "http") {
 		// Handle remote ruleset URL directly
 		if !remote {
@@ -56,14 +82,20 @@ func BuildRuleSetFromUserSuppliedLocation(rulesetFlag string, rs rulesets.RuleSe
 		if rsErr != nil {
 			return nil, rsErr
 		}
-		return rs.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(downloadedRS, httpClient), nil
+		return checkRemoteRuleSets(func() *rulesets.RuleSet {
+			return rs.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(downloadedRS, httpClient)
+		})
 	} else {
 		// Handle local ruleset file
 		rsBytes, rsErr := os.ReadFile(rulesetFlag)
 		if rsErr != nil {
 			return nil, rsErr
 		}
-		return BuildRuleSetFromUserSuppliedSetWithHTTPClient(rsBytes, rs, httpClient)
+		built, err := BuildRuleSetFromUserSuppliedSetWithHTTPClient(rsBytes, rs, httpClient)
+		if built != nil {
+			built.OverridesDir = filepath.Dir(rulesetFlag)
+		}
+		return built, err
 	}
 }
 
@@ -169,27 +201,186 @@ func LoadCustomFunctions(functionsFlag string, silence bool) (map[string]model.R
 	return nil, nil
 }
 
-func CheckFailureSeverity(failSeverityFlag string, errors int, warnings int, informs int) error {
-	if failSeverityFlag == model.SeverityNone {
+// LoadRuleSetFunctions will load the Spectral style JavaScript functions declared by a ruleset (`functions`) from
+// its functions directory (`functionsDir`, defaults to 'functions'), relative to the ruleset file. Loaded functions
+// are added to the custom functions, which are returned. Remote rulesets can't declare functions.
+func LoadRuleSetFunctions(rulesetFlag string, selectedRS *rulesets.RuleSet,
+	customFunctions map[string]model.RuleFunction, silence bool) (map[string]model.RuleFunction, error) {
+
+	if selectedRS == nil || len(selectedRS.Functions) == 0 || strings.HasPrefix(rulesetFlag, "http") {
+		return customFunctions, nil
+	}
+	functionsDir := selectedRS.FunctionsDir
+	if functionsDir == "" {
+		functionsDir = "functions"
+	}
+	if !filepath.IsAbs(functionsDir) {
+		functionsDir = filepath.Join(filepath.Dir(rulesetFlag), functionsDir)
+	}
+
+	pm, err := plugin.LoadSpectralFunctions(functionsDir, selectedRS.Functions, silence)
+	if err != nil {
+		pterm.Error.Printf("Unable to load ruleset functions: %v\n", err)
+		pterm.Println()
+		return customFunctions, err
+	}
+	if customFunctions == nil {
+		customFunctions = make(map[string]model.RuleFunction)
+	}
+	for name, function := range pm.GetCustomFunctions() {
+		customFunctions[name] = function
+	}
+	return customFunctions, nil
+}
+
+// RenderRuleSetDowngrades warns about every part of a Spectral ruleset that vacuum changed or ignored, so the
+// ruleset may not behave exactly as it does with Spectral.
+func RenderRuleSetDowngrades(selectedRS *rulesets.RuleSet, silence bool) {
+	if silence || selectedRS == nil || len(selectedRS.Downgrades) == 0 {
+		return
+	}
+	pterm.Warning.Printf("%d parts of the ruleset are not supported exactly as Spectral supports them\n",
+		len(selectedRS.Downgrades))
+	for _, d := range selectedRS.Downgrades {
+		pterm.Printf("  - %s\n", d.String())
+	}
+	pterm.Println()
+}
+
+// SelectRuleSetForSpec returns the ruleset a specification is linted with. AsyncAPI documents are linted with the
+// AsyncAPI rules, unless a ruleset has been supplied (there is no AsyncAPI ruleset), everything else is linted with
+// the selected ruleset.
+func SelectRuleSetForSpec(spec []byte, selected, asyncAPI *rulesets.RuleSet) *rulesets.RuleSet {
+	if asyncAPI != nil && motor.DetectAsyncAPIFormat(spec) != "" {
+		return asyncAPI
+	}
+	return selected
+}
+
+// FindVacuumIgnoreFile loads the ignore file supplied with --vacuumignore, or the .vacuumignore file found from the
+// working directory upwards. Nil is returned if none is supplied or found.
+func FindVacuumIgnoreFile(path string) (*utils.VacuumIgnore, error) {
+	if path != "" {
+		return utils.LoadVacuumIgnore(path)
+	}
+	return utils.FindVacuumIgnore(".")
+}
+
+// SetLowMemoryMode tunes the runtime for linting very large specifications, when --low-memory is used. The returned
+// function puts the runtime back the way it was, commands defer it so nothing else runs in low memory mode.
+func SetLowMemoryMode(lowMemory bool) (restore func()) {
+	if !lowMemory {
+		return func() {}
+	}
+	old := debug.SetGCPercent(motor.LowMemoryGCPercent)
+	return func() {
+		debug.SetGCPercent(old)
+	}
+}
+
+// ApplyRuleFlags changes the severity of rules (--rule-severity rule-id=severity), or turns them off
+// (--disable-rule rule-id), without editing a ruleset. Every rule has to exist in at least one of the rulesets.
+func ApplyRuleFlags(severityFlags []string, disableFlags []string, ruleSets ...*rulesets.RuleSet) error {
+	if len(severityFlags) == 0 && len(disableFlags) == 0 {
 		return nil
 	}
-	if failSeverityFlag != model.SeverityError {
-		switch failSeverityFlag {
-		case model.SeverityWarn:
-			if warnings > 0 || errors > 0 {
-				return fmt.Errorf("failed with %d errors and %d warnings", errors, warnings)
-			}
-		case model.SeverityInfo:
-			if informs > 0 || warnings > 0 || errors > 0 {
-				return fmt.Errorf("failed with %d errors, %d warnings and %d informs",
-					errors, warnings, informs)
+	severities := make(map[string]string)
+	for _, flag := range severityFlags {
+		ruleId, severity, found := strings.Cut(flag, "=")
+		ruleId, severity = strings.TrimSpace(ruleId), strings.TrimSpace(severity)
+		if !found || ruleId == "" {
+			return fmt.Errorf("invalid rule severity '%s', use 'rule-id=severity'", flag)
+		}
+		switch severity {
+		case model.SeverityError, model.SeverityWarn, model.SeverityInfo, model.SeverityHint, "off":
+		default:
+			return fmt.Errorf("invalid severity '%s' for rule '%s', use 'error', 'warn', 'info', 'hint' or 'off'",
+				severity, ruleId)
+		}
+		severities[ruleId] = severity
+	}
+	for _, ruleId := range disableFlags {
+		severities[strings.TrimSpace(ruleId)] = "off"
+	}
+
+	for ruleId := range severities {
+		exists := false
+		for _, rs := range ruleSets {
+			if rs != nil && rs.Rules[ruleId] != nil {
+				exists = true
 			}
-			return nil
 		}
-	} else {
-		if errors > 0 {
-			return fmt.Errorf("failed with %d errors", errors)
+		if !exists {
+			return fmt.Errorf("rule '%s' is not in the ruleset", ruleId)
+		}
+	}
+
+	for _, rs := range ruleSets {
+		if rs == nil {
+			continue
 		}
+		// rules are shared with the built-in rulesets, so changed rules are copied.
+		rules := make(map[string]*model.Rule, len(rs.Rules))
+		for k, v := range rs.Rules {
+			severity, ok := severities[k]
+			switch {
+			case !ok:
+				rules[k] = v
+			case severity != "off":
+				copied := *v
+				copied.Severity = severity
+				rules[k] = &copied
+			}
+		}
+		rs.Rules = rules
 	}
 	return nil
 }
+
+// FilterRulesByTags removes every rule that does not have one of the include tags (--tags), or that has one of
+// the exclude tags (--exclude-tags). Tags are matched regardless of case.
+func FilterRulesByTags(includeTags []string, excludeTags []string, ruleSets ...*rulesets.RuleSet) {
+	if len(includeTags) == 0 && len(excludeTags) == 0 {
+		return
+	}
+	for _, rs := range ruleSets {
+		if rs == nil {
+			continue
+		}
+		rules := make(map[string]*model.Rule, len(rs.Rules))
+		for k, v := range rs.Rules {
+			if v.MatchesTags(includeTags, excludeTags) {
+				rules[k] = v
+			}
+		}
+		rs.Rules = rules
+	}
+}
+
+func CheckFailureSeverity(failSeverityFlag string, errors int, warnings int, informs int) error {
+	return model.NewFailureThreshold(failSeverityFlag, -1).Check(errors, warnings, informs)
+}
+
+// readReportTemplate reads a user supplied report template, and makes sure it can be rendered.
+func readReportTemplate(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err = vacuum_report.ParseReportTemplate(string(b)); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// readJUnitTemplate reads a user supplied JUnit failure template, and makes sure it can be rendered.
+func readJUnitTemplate(path string) (string, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	if _, err = vacuum_report.ParseJUnitFailureTemplate(string(b)); err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
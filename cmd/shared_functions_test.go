@@ -2,8 +2,14 @@ package cmd
 
 import (
 	"os"
+	"path/filepath"
+	"runtime/debug"
 	"testing"
 	"time"
+
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestRenderTime(t *testing.T) {
@@ -12,3 +18,75 @@ func TestRenderTime(t *testing.T) {
 	fi, _ := os.Stat("shared_functions.go")
 	RenderTime(true, time.Since(start), fi.Size())
 }
+
+func TestBuildRuleSetFromUserSuppliedLocation_BuiltInOWASP(t *testing.T) {
+	rs, err := BuildRuleSetFromUserSuppliedLocation("owasp", rulesets.BuildDefaultRuleSets(), false, nil)
+	assert.NoError(t, err)
+	assert.Len(t, rs.Rules, len(rulesets.GetRecommendedOWASPRules()))
+
+	rs, err = BuildRuleSetFromUserSuppliedLocation(rulesets.VacuumOwasp, rulesets.BuildDefaultRuleSets(), false, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, rs.Rules[rulesets.OwaspJWTBestPractices])
+}
+
+func TestBuildRuleSetFromUserSuppliedLocation_Offline(t *testing.T) {
+	rulesets.SetRemoteCache(&rulesets.RemoteCache{Dir: t.TempDir(), Offline: true})
+	defer rulesets.SetRemoteCache(nil)
+
+	_, err := BuildRuleSetFromUserSuppliedLocation("https://quobix.com/not-cached.yaml",
+		rulesets.BuildDefaultRuleSets(), true, nil)
+	assert.ErrorContains(t, err, "cannot run offline")
+
+	// extended rulesets must be cached too.
+	local := filepath.Join(t.TempDir(), "ruleset.yaml")
+	assert.NoError(t, os.WriteFile(local, []byte("extends: https://quobix.com/also-not-cached.yaml"), 0644))
+	_, err = BuildRuleSetFromUserSuppliedLocation(local, rulesets.BuildDefaultRuleSets(), true, nil)
+	assert.ErrorContains(t, err, "also-not-cached.yaml' is not cached")
+}
+
+func TestApplyRuleFlags(t *testing.T) {
+	defaults := rulesets.BuildDefaultRuleSets()
+	rs := defaults.GenerateOpenAPIRecommendedRuleSet()
+	severity := rs.Rules["operation-tags"].Severity
+
+	err := ApplyRuleFlags([]string{"operation-tags=hint", "info-description=off"}, []string{"operation-description"}, rs)
+	assert.NoError(t, err)
+	assert.Equal(t, "hint", rs.Rules["operation-tags"].Severity)
+	assert.Nil(t, rs.Rules["info-description"])
+	assert.Nil(t, rs.Rules["operation-description"])
+
+	// the built-in rules are not changed.
+	assert.Equal(t, severity, defaults.GenerateOpenAPIRecommendedRuleSet().Rules["operation-tags"].Severity)
+}
+
+func TestApplyRuleFlags_Invalid(t *testing.T) {
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	assert.ErrorContains(t, ApplyRuleFlags([]string{"operation-tags"}, nil, rs), "use 'rule-id=severity'")
+	assert.ErrorContains(t, ApplyRuleFlags([]string{"operation-tags=loud"}, nil, rs), "invalid severity 'loud'")
+	assert.ErrorContains(t, ApplyRuleFlags(nil, []string{"no-such-rule"}, rs), "rule 'no-such-rule' is not in the ruleset")
+	assert.NoError(t, ApplyRuleFlags(nil, nil, rs))
+}
+
+func TestFilterRulesByTags(t *testing.T) {
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIDefaultRuleSet()
+	owasp := rulesets.GenerateOWASPOpenAPIRuleSet()
+	FilterRulesByTags([]string{"security"}, []string{"owasp"}, rs, owasp, nil)
+
+	assert.NotEmpty(t, rs.Rules)
+	for _, rule := range rs.Rules {
+		assert.True(t, rule.HasTag("security"), rule.Id)
+	}
+	assert.Empty(t, owasp.Rules)
+}
+
+func TestSetLowMemoryMode(t *testing.T) {
+	defer debug.SetGCPercent(debug.SetGCPercent(100))
+
+	SetLowMemoryMode(false)()
+	assert.Equal(t, 100, debug.SetGCPercent(100))
+
+	restore := SetLowMemoryMode(true)
+	assert.Equal(t, motor.LowMemoryGCPercent, debug.SetGCPercent(motor.LowMemoryGCPercent))
+	restore()
+	assert.Equal(t, 100, debug.SetGCPercent(100))
+}
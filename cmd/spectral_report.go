@@ -8,6 +8,8 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"github.com/daveshanley/vacuum/i18n"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/rulesets"
@@ -182,6 +184,12 @@ func GetSpectralReportCommand() *cobra.Command {
 					return rsErr
 				}
 
+				// load any Spectral functions the ruleset declares
+				customFunctions, rsErr = LoadRuleSetFunctions(rulesetFlag, selectedRS, customFunctions, true)
+				if rsErr != nil {
+					return rsErr
+				}
+
 				// Merge OWASP rules if hard mode is enabled
 				if MergeOWASPRulesToRuleSet(selectedRS, hardModeFlag) {
 					if !stdIn && !stdOut {
@@ -193,6 +201,35 @@ func GetSpectralReportCommand() *cobra.Command {
 				}
 			}
 
+			ruleSeverityFlags, _ := cmd.Flags().GetStringArray("rule-severity")
+			disableRuleFlags, _ := cmd.Flags().GetStringArray("disable-rule")
+			lowMemoryFlag, _ := cmd.Flags().GetBool("low-memory")
+			collapseRefsFlag, _ := cmd.Flags().GetBool("collapse-refs")
+
+			// reports are quiet, unless logging has been asked for.
+			var logger *slog.Logger
+			if LoggingChanged(cmd) {
+				var logErr error
+				if logger, logErr = BuildLogger(cmd, slog.LevelError); logErr != nil {
+					pterm.Error.Println(logErr.Error())
+					pterm.Println()
+					return logErr
+				}
+				if len(args) > 0 {
+					logger = logger.With("document", args[0])
+				}
+			}
+			tagsFlag, _ := cmd.Flags().GetStringSlice("tags")
+			excludeTagsFlag, _ := cmd.Flags().GetStringSlice("exclude-tags")
+			defer SetLowMemoryMode(lowMemoryFlag)()
+
+			if rfErr := ApplyRuleFlags(ruleSeverityFlags, disableRuleFlags, selectedRS); rfErr != nil {
+				pterm.Error.Printf("Unable to adjust rules: %s\n", rfErr.Error())
+				pterm.Println()
+				return rfErr
+			}
+			FilterRulesByTags(tagsFlag, excludeTagsFlag, selectedRS)
+
 			if !stdIn && !stdOut {
 				pterm.Info.Printf("Linting against %d rules: %s\n", len(selectedRS.Rules), selectedRS.DocumentationURI)
 			}
@@ -207,6 +244,9 @@ func GetSpectralReportCommand() *cobra.Command {
 				SkipDocumentCheck:               skipCheckFlag,
 				Timeout:                         time.Duration(timeoutFlag) * time.Second,
 				ExtractReferencesFromExtensions: extensionRefsFlag,
+				LowMemory:                       lowMemoryFlag,
+				CollapseReferencedResults:       collapseRefsFlag,
+				Logger:                          logger,
 				HTTPClientConfig:                utils.HTTPClientConfig{
 					CertFile: certFile,
 					KeyFile:  keyFile,
@@ -216,9 +256,11 @@ func GetSpectralReportCommand() *cobra.Command {
 			})
 
 			resultSet := model.NewRuleResultSet(ruleset.Results)
+			resultSet.RuleSetCategories = selectedRS.Categories
 			resultSet.SortResultsByLineNumber()
 
 			resultSet.Results = utils.FilterIgnoredResultsPtr(resultSet.Results, ignoredItems)
+			i18n.LocalizeResults(resultSet.Results)
 
 			duration := time.Since(start)
 
@@ -273,6 +315,12 @@ func GetSpectralReportCommand() *cobra.Command {
 	cmd.Flags().BoolP("no-pretty", "n", false, "Render JSON with no formatting")
 	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
 	cmd.Flags().String("ignore-file", "", "Path to ignore file")
+	cmd.Flags().StringArray("rule-severity", nil, "Change the severity of a rule, e.g. 'operation-tags=warn' (repeatable)")
+	cmd.Flags().StringArray("disable-rule", nil, "Turn off a rule, e.g. 'operation-tags' (repeatable)")
+	cmd.Flags().Bool("low-memory", false, "Use less memory for very large specifications, results no longer hold on to the document")
+	cmd.Flags().Bool("collapse-refs", false, "Report a problem with a component used ($ref) in many places once, with the places it is referenced from")
+	cmd.Flags().StringSlice("tags", nil, "Only run rules with one of these tags, e.g. 'security,style'")
+	cmd.Flags().StringSlice("exclude-tags", nil, "Do not run rules with any of these tags, e.g. 'style'")
 	return cmd
 
 }
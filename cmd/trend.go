@@ -0,0 +1,203 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/utils"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// DefaultTrendHistory is the trend history file used when '--history' is not set.
+const DefaultTrendHistory = "vacuum-trend.jsonl"
+
+func GetTrendCommand() *cobra.Command {
+
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "trend",
+		Short:        "Track the quality score of a specification over time",
+		Long: "Record the summary of each linting run (score, counts by severity and category, time and git commit) " +
+			"in a history file, and render the trend over time. Use 'trend record' after generating a report with the " +
+			"'report' command, and 'trend show' to see how quality has changed.",
+		Example: "vacuum trend record vacuum-report.json --label v1.2.0\nvacuum trend show --format markdown",
+	}
+	cmd.PersistentFlags().String("history", DefaultTrendHistory, "Trend history file, runs are appended as JSON lines")
+	cmd.PersistentFlags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
+	cmd.AddCommand(getTrendRecordCommand())
+	cmd.AddCommand(getTrendShowCommand())
+	return cmd
+}
+
+func getTrendRecordCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "record",
+		Short:        "Add the summary of a vacuum report to the trend history",
+		Long: "Add the summary of a vacuum report (generated by the 'report' command) to the trend history. The git " +
+			"commit checked out where the report is, is recorded unless '--sha' is set.",
+		Example: "vacuum trend record vacuum-report.json.gz --label v1.2.0",
+		ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+			return []string{"json", "gz", "zst", "br"}, cobra.ShellCompDirectiveFilterFileExt
+		},
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+			historyFlag, _ := cmd.Flags().GetString("history")
+			shaFlag, _ := cmd.Flags().GetString("sha")
+			labelFlag, _ := cmd.Flags().GetString("label")
+
+			if noStyleFlag {
+				pterm.DisableColor()
+				pterm.DisableStyling()
+			}
+
+			if len(args) != 1 {
+				errText := "please supply a vacuum report to record"
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			vr, _, err := vacuum_report.BuildVacuumReportFromFile(args[0])
+			if err == nil && vr == nil {
+				err = fmt.Errorf("'%s' is not a vacuum report", args[0])
+			}
+			if err != nil {
+				pterm.Error.Printf("Unable to read report '%s': %s\n", args[0], err.Error())
+				pterm.Println()
+				return err
+			}
+
+			if shaFlag == "" {
+				if abs, absErr := filepath.Abs(args[0]); absErr == nil {
+					shaFlag = utils.GitHeadSHA(filepath.Dir(abs))
+				}
+			}
+			entry := vacuum_report.NewTrendEntry(vr, shaFlag)
+			entry.Label = labelFlag
+
+			if err = vacuum_report.AppendTrendHistory(historyFlag, entry); err != nil {
+				pterm.Error.Println(err.Error())
+				pterm.Println()
+				return err
+			}
+			pterm.Success.Printf("Recorded score %d (%d errors, %d warnings) in '%s'\n", entry.Score, entry.Errors,
+				entry.Warnings, historyFlag)
+			pterm.Println()
+			return nil
+		},
+	}
+	cmd.Flags().String("sha", "", "Git commit to record, defaults to the commit checked out")
+	cmd.Flags().String("label", "", "Label for the run, like a release or version")
+	return cmd
+}
+
+func getTrendShowCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage: true,
+		Use:          "show",
+		Short:        "Render the trend history",
+		Long:         "Render the trend history in the terminal (with sparklines), or as JSON or markdown.",
+		Example:      "vacuum trend show --last 20",
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
+			historyFlag, _ := cmd.Flags().GetString("history")
+			formatFlag, _ := cmd.Flags().GetString("format")
+			lastFlag, _ := cmd.Flags().GetInt("last")
+
+			if noStyleFlag {
+				pterm.DisableColor()
+				pterm.DisableStyling()
+			}
+
+			entries, err := vacuum_report.ReadTrendHistory(historyFlag)
+			if err != nil {
+				pterm.Error.Println(err.Error())
+				pterm.Println()
+				return err
+			}
+			if lastFlag > 0 && len(entries) > lastFlag {
+				entries = entries[len(entries)-lastFlag:]
+			}
+
+			switch formatFlag {
+			case "json":
+				data, _ := json.MarshalIndent(entries, "", "  ")
+				fmt.Println(string(data))
+			case "markdown":
+				fmt.Print(string(vacuum_report.BuildTrendMarkdown(entries)))
+			case "terminal", "":
+				PrintBanner()
+				renderTrend(entries)
+			default:
+				errText := fmt.Sprintf("unknown format '%s', use one of: terminal, json, markdown", formatFlag)
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+			return nil
+		},
+	}
+	cmd.Flags().StringP("format", "f", "terminal", "Render the trend as 'terminal', 'json' or 'markdown'")
+	cmd.Flags().Int("last", 0, "Only render the last N runs")
+	return cmd
+}
+
+func renderTrend(entries []*vacuum_report.TrendEntry) {
+	if len(entries) == 0 {
+		pterm.Info.Println("No runs have been recorded yet, use 'vacuum trend record' to add one.")
+		pterm.Println()
+		return
+	}
+	first, last := entries[0], entries[len(entries)-1]
+	pterm.DefaultSection.Printf("Quality score over %d runs", len(entries))
+	pterm.Printf("%s  %d → %d (%s)\n\n", vacuum_report.Sparkline(vacuum_report.TrendScores(entries)), first.Score,
+		last.Score, vacuum_report.TrendDelta(last.Score-first.Score))
+
+	if categories := vacuum_report.TrendCategories(entries); len(categories) > 0 {
+		tableData := pterm.TableData{{"Category", "Issues", "Trend"}}
+		for _, id := range categories {
+			values := vacuum_report.CategoryTrend(entries, id)
+			name := id
+			if cat := model.RuleCategories[id]; cat != nil {
+				name = cat.Name
+			}
+			tableData = append(tableData, []string{name,
+				fmt.Sprint(values[len(values)-1]), vacuum_report.Sparkline(values)})
+		}
+		if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+			pterm.Error.Printf("error rendering table '%v'", err.Error())
+		}
+		pterm.Println()
+	}
+
+	tableData := pterm.TableData{{"Date", "Commit", "Label", "Score", "Change", "Errors", "Warnings", "Info"}}
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		delta := ""
+		if i > 0 {
+			delta = vacuum_report.TrendDelta(e.Score - entries[i-1].Score)
+		}
+		sha := e.GitSHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		tableData = append(tableData, []string{e.Timestamp.Format(time.DateTime), sha, e.Label, fmt.Sprint(e.Score),
+			delta, fmt.Sprint(e.Errors), fmt.Sprint(e.Warnings), fmt.Sprint(e.Info)})
+	}
+	if err := pterm.DefaultTable.WithHasHeader().WithData(tableData).Render(); err != nil {
+		pterm.Error.Printf("error rendering table '%v'", err.Error())
+	}
+	pterm.Println()
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"path/filepath"
+	"testing"
+
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTrendCommand(t *testing.T) {
+	history := filepath.Join(t.TempDir(), "trend.jsonl")
+
+	for _, label := range []string{"v1", "v2"} {
+		cmd := GetTrendCommand()
+		cmd.SetArgs([]string{"record", "test_data/vacuum-report.json", "--history", history, "--label", label, "--sha", "abc"})
+		assert.NoError(t, cmd.Execute())
+	}
+
+	entries, err := vacuum_report.ReadTrendHistory(history)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "v2", entries[1].Label)
+	assert.Equal(t, "abc", entries[1].GitSHA)
+
+	for _, format := range []string{"terminal", "json", "markdown"} {
+		cmd := GetTrendCommand()
+		cmd.SetArgs([]string{"show", "--history", history, "--format", format, "--last", "1"})
+		assert.NoError(t, cmd.Execute())
+	}
+}
+
+func TestGetTrendCommand_BadArgs(t *testing.T) {
+	history := filepath.Join(t.TempDir(), "trend.jsonl")
+
+	cmd := GetTrendCommand()
+	cmd.SetArgs([]string{"record", "--history", history})
+	assert.Error(t, cmd.Execute())
+
+	cmd = GetTrendCommand()
+	cmd.SetArgs([]string{"record", "../model/test_files/burgershop.openapi.yaml", "--history", history})
+	assert.Error(t, cmd.Execute())
+
+	cmd = GetTrendCommand()
+	cmd.SetArgs([]string{"show", "--history", history, "--format", "pdf"})
+	assert.ErrorContains(t, cmd.Execute(), "unknown format")
+}
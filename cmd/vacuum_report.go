@@ -5,10 +5,11 @@ package cmd
 
 import (
 	"bytes"
-	"compress/gzip"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"github.com/daveshanley/vacuum/i18n"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/rulesets"
@@ -46,6 +47,8 @@ func GetVacuumReportCommand() *cobra.Command {
 			noStyleFlag, _ := cmd.Flags().GetBool("no-style")
 			baseFlag, _ := cmd.Flags().GetString("base")
 			junitFlag, _ := cmd.Flags().GetBool("junit")
+			gitlabFlag, _ := cmd.Flags().GetBool("gitlab")
+			checkstyleFlag, _ := cmd.Flags().GetBool("checkstyle")
 			skipCheckFlag, _ := cmd.Flags().GetBool("skip-check")
 			timeoutFlag, _ := cmd.Flags().GetInt("timeout")
 			hardModeFlag, _ := cmd.Flags().GetBool("hard-mode")
@@ -76,6 +79,11 @@ func GetVacuumReportCommand() *cobra.Command {
 			timeFlag, _ := cmd.Flags().GetBool("time")
 			noPretty, _ := cmd.Flags().GetBool("no-pretty")
 			compress, _ := cmd.Flags().GetBool("compress")
+			compressionFlag, _ := cmd.Flags().GetString("compression")
+			badgeFlag, _ := cmd.Flags().GetString("badge")
+			if cmd.Flags().Changed("compression") {
+				compress = true
+			}
 			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
 
 			// Certificate/TLS configuration
@@ -152,6 +160,10 @@ func GetVacuumReportCommand() *cobra.Command {
 
 			}
 
+			if rulesetFlag == "" {
+				selectedRS = SelectRuleSetForSpec(specBytes, selectedRS, defaultRuleSets.GenerateAsyncAPIRecommendedRuleSet())
+			}
+
 			functionsFlag, _ := cmd.Flags().GetString("functions")
 			customFunctions, _ := LoadCustomFunctions(functionsFlag, true)
 
@@ -184,6 +196,12 @@ func GetVacuumReportCommand() *cobra.Command {
 					return rsErr
 				}
 
+				// load any Spectral functions the ruleset declares
+				customFunctions, rsErr = LoadRuleSetFunctions(rulesetFlag, selectedRS, customFunctions, true)
+				if rsErr != nil {
+					return rsErr
+				}
+
 				// Merge OWASP rules if hard mode is enabled
 				if MergeOWASPRulesToRuleSet(selectedRS, hardModeFlag) {
 					if !stdIn && !stdOut {
@@ -195,6 +213,36 @@ func GetVacuumReportCommand() *cobra.Command {
 				}
 			}
 
+			ruleSeverityFlags, _ := cmd.Flags().GetStringArray("rule-severity")
+			disableRuleFlags, _ := cmd.Flags().GetStringArray("disable-rule")
+			lowMemoryFlag, _ := cmd.Flags().GetBool("low-memory")
+			collapseRefsFlag, _ := cmd.Flags().GetBool("collapse-refs")
+
+			// reports are quiet, unless logging has been asked for.
+			var logger *slog.Logger
+			if LoggingChanged(cmd) {
+				var logErr error
+				if logger, logErr = BuildLogger(cmd, slog.LevelError); logErr != nil {
+					pterm.Error.Println(logErr.Error())
+					pterm.Println()
+					return logErr
+				}
+				if len(args) > 0 {
+					logger = logger.With("document", args[0])
+				}
+			}
+			showRuleTimingsFlag, _ := cmd.Flags().GetBool("show-rule-timings")
+			tagsFlag, _ := cmd.Flags().GetStringSlice("tags")
+			excludeTagsFlag, _ := cmd.Flags().GetStringSlice("exclude-tags")
+			defer SetLowMemoryMode(lowMemoryFlag)()
+
+			if rfErr := ApplyRuleFlags(ruleSeverityFlags, disableRuleFlags, selectedRS); rfErr != nil {
+				pterm.Error.Printf("Unable to adjust rules: %s\n", rfErr.Error())
+				pterm.Println()
+				return rfErr
+			}
+			FilterRulesByTags(tagsFlag, excludeTagsFlag, selectedRS)
+
 			if !stdIn && !stdOut {
 				pterm.Info.Printf("Linting against %d rules: %s\n", len(selectedRS.Rules), selectedRS.DocumentationURI)
 			}
@@ -204,9 +252,14 @@ func GetVacuumReportCommand() *cobra.Command {
 				deepGraph = true
 			}
 
+			displayName := StdinFileName
+			if len(args) > 0 {
+				displayName = args[0]
+			}
 			ruleset := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
 				RuleSet:                         selectedRS,
 				Spec:                            specBytes,
+				SpecDisplayName:                 displayName,
 				CustomFunctions:                 customFunctions,
 				SilenceLogs:                     true,
 				Base:                            baseFlag,
@@ -215,6 +268,9 @@ func GetVacuumReportCommand() *cobra.Command {
 				BuildDeepGraph:                  deepGraph,
 				Timeout:                         time.Duration(timeoutFlag) * time.Second,
 				ExtractReferencesFromExtensions: extensionRefsFlag,
+				LowMemory:                       lowMemoryFlag,
+				CollapseReferencedResults:       collapseRefsFlag,
+				Logger:                          logger,
 				HTTPClientConfig:                utils.HTTPClientConfig{
 					CertFile: certFile,
 					KeyFile:  keyFile,
@@ -224,15 +280,35 @@ func GetVacuumReportCommand() *cobra.Command {
 			})
 
 			resultSet := model.NewRuleResultSet(ruleset.Results)
+			resultSet.RuleSetCategories = selectedRS.Categories
 			resultSet.SortResultsByLineNumber()
 
 			resultSet.Results = utils.FilterIgnoredResultsPtr(resultSet.Results, ignoredItems)
+			resultSet.SetSuppressedResults(ruleset.Suppressed)
+			i18n.LocalizeResults(resultSet.Results)
 
 			duration := time.Since(start)
 
 			// if we want jUnit output, then build the report and be done with it.
 			if junitFlag {
-				junitXML := vacuum_report.BuildJUnitReport(resultSet, start, args)
+				var junitReq utils.LintFileRequest
+				if jErr := readJUnitFlags(cmd, &junitReq); jErr != nil {
+					return jErr
+				}
+				junitConfig := &vacuum_report.JUnitReportConfig{
+					GroupBy:          junitReq.JUnitGroupBy,
+					FailureTemplate:  junitReq.JUnitTemplate,
+					SeverityOutcomes: junitReq.JUnitSeverityOutcomes,
+				}
+				// warnings and errors are failures by default, unless told otherwise.
+				if junitReq.JUnitFailOn != "" {
+					junitConfig.FailureThreshold = model.NewFailureThreshold(junitReq.JUnitFailOn, -1)
+				}
+				if junitReq.JUnitTemplate != "" {
+					junitConfig.Spec = specBytes
+				}
+				junitConfig.RuleTimings = ruleset.RuleTimings
+				junitXML := vacuum_report.BuildJUnitReportWithConfig(resultSet, start, args, junitConfig)
 				if stdOut {
 					fmt.Print(string(junitXML))
 					return nil
@@ -254,6 +330,60 @@ func GetVacuumReportCommand() *cobra.Command {
 				}
 			}
 
+			// if we want checkstyle output, then build the report and be done with it.
+			if checkstyleFlag {
+				fileName := ""
+				if len(args) > 0 {
+					fileName = args[0]
+				}
+				checkstyleXML := vacuum_report.BuildCheckstyleReport(resultSet, fileName)
+				if stdOut {
+					fmt.Print(string(checkstyleXML))
+					return nil
+				}
+
+				reportOutputName := fmt.Sprintf("%s-%s%s",
+					reportOutput, time.Now().Format("01-02-06-15_04_05"), ".checkstyle.xml")
+
+				err := os.WriteFile(reportOutputName, checkstyleXML, 0664)
+				if err != nil {
+					pterm.Error.Printf("Unable to write checkstyle report file: '%s': %s\n", reportOutputName, err.Error())
+					pterm.Println()
+					return err
+				}
+
+				pterm.Success.Printf("Checkstyle Report generated for '%s', written to '%s'\n", fileName, reportOutputName)
+				pterm.Println()
+				return nil
+			}
+
+			// if we want a GitLab Code Quality report, then build the report and be done with it.
+			if gitlabFlag {
+				fileName := ""
+				if len(args) > 0 {
+					fileName = args[0]
+				}
+				codeQuality := vacuum_report.BuildCodeQualityReport(resultSet, fileName)
+				if stdOut {
+					fmt.Print(string(codeQuality))
+					return nil
+				}
+
+				reportOutputName := fmt.Sprintf("%s-%s%s",
+					reportOutput, time.Now().Format("01-02-06-15_04_05"), ".codequality.json")
+
+				err := os.WriteFile(reportOutputName, codeQuality, 0664)
+				if err != nil {
+					pterm.Error.Printf("Unable to write code quality report file: '%s': %s\n", reportOutputName, err.Error())
+					pterm.Println()
+					return err
+				}
+
+				pterm.Success.Printf("GitLab Code Quality Report generated for '%s', written to '%s'\n", fileName, reportOutputName)
+				pterm.Println()
+				return nil
+			}
+
 			// pre-render
 			resultSet.PrepareForSerialization(ruleset.SpecInfo)
 
@@ -262,6 +392,16 @@ func GetVacuumReportCommand() *cobra.Command {
 
 			// generate statistics
 			stats := statistics.CreateReportStatistics(ruleset.Index, ruleset.SpecInfo, resultSet)
+			if showRuleTimingsFlag && stats != nil {
+				stats.RuleTimings = ruleset.RuleTimings
+			}
+			if badgeFlag != "" && stats != nil {
+				if bErr := vacuum_report.WriteBadge(badgeFlag, stats.OverallScore); bErr != nil {
+					pterm.Error.Println(bErr.Error())
+					pterm.Println()
+					return bErr
+				}
+			}
 
 			// Extract all unique rules used in the results
 			usedRules := make(map[string]*model.Rule)
@@ -313,19 +453,14 @@ func GetVacuumReportCommand() *cobra.Command {
 			}
 
 			if compress {
-
-				var b bytes.Buffer
-				gz := gzip.NewWriter(&b)
-				_, wErr := gz.Write(data)
-				if wErr != nil {
-					return wErr
-				}
-				wErr = gz.Close()
-				if wErr != nil {
-					return wErr
+				compressed, cErr := vacuum_report.CompressReport(data, compressionFlag)
+				if cErr != nil {
+					pterm.Error.Printf("Unable to compress report: %s\n", cErr.Error())
+					pterm.Println()
+					return cErr
 				}
-				reportData = b.Bytes()
-				extension = ".json.gz"
+				reportData = compressed
+				extension = vacuum_report.CompressionExtension(compressionFlag)
 			}
 
 			reportOutputName := fmt.Sprintf("%s-%s%s",
@@ -359,10 +494,22 @@ func GetVacuumReportCommand() *cobra.Command {
 	cmd.Flags().BoolP("stdin", "i", false, "Use stdin as input, instead of a file")
 	cmd.Flags().BoolP("stdout", "o", false, "Use stdout as output, instead of a file")
 	cmd.Flags().BoolP("junit", "j", false, "Generate report in JUnit format (cannot be compressed)")
+	addJUnitFlags(cmd, ", used with '--junit'")
+	cmd.Flags().BoolP("gitlab", "l", false, "Generate report in GitLab Code Quality (Code Climate) format (cannot be compressed)")
+	cmd.Flags().Bool("checkstyle", false, "Generate report in Checkstyle XML format (cannot be compressed)")
 	cmd.Flags().BoolP("compress", "c", false, "Compress results using gzip")
+	cmd.Flags().String("compression", vacuum_report.CompressionGzip, "Compress results using this codec (gzip, zstd, brotli), implies '--compress'")
 	cmd.Flags().BoolP("no-pretty", "n", false, "Render JSON with no formatting")
 	cmd.Flags().BoolP("no-style", "q", false, "Disable styling and color output, just plain text (useful for CI/CD)")
 	cmd.Flags().String("ignore-file", "", "Path to ignore file")
+	cmd.Flags().StringArray("rule-severity", nil, "Change the severity of a rule, e.g. 'operation-tags=warn' (repeatable)")
+	cmd.Flags().StringArray("disable-rule", nil, "Turn off a rule, e.g. 'operation-tags' (repeatable)")
+	cmd.Flags().Bool("low-memory", false, "Use less memory for very large specifications, results no longer hold on to the document")
+	cmd.Flags().Bool("collapse-refs", false, "Report a problem with a component used ($ref) in many places once, with the places it is referenced from")
+	cmd.Flags().Bool("show-rule-timings", false, "Include how long each rule took to run and how many results it found in the report statistics")
+	cmd.Flags().StringSlice("tags", nil, "Only run rules with one of these tags, e.g. 'security,style'")
+	cmd.Flags().StringSlice("exclude-tags", nil, "Do not run rules with any of these tags, e.g. 'style'")
+	cmd.Flags().String("badge", "", "Write a quality badge for the score, shields.io endpoint JSON, or SVG if the file ends in '.svg'")
 	cmd.Flags().Int("min-score", 10, "Throw an error return code if the score is below this value")
 	return cmd
 }
@@ -2,14 +2,14 @@ package cmd
 
 import (
 	"bytes"
-	"fmt"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
 	"github.com/pterm/pterm"
 	"github.com/stretchr/testify/assert"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
-	"time"
 )
 
 func TestGetVacuumReportCommand(t *testing.T) {
@@ -18,6 +18,7 @@ func TestGetVacuumReportCommand(t *testing.T) {
 	cmd.SetOut(b)
 	cmd.SetArgs([]string{
 		"../model/test_files/petstorev3.json",
+		filepath.Join(t.TempDir(), "vacuum-report"),
 	})
 	cmdErr := cmd.Execute()
 	outBytes, err := io.ReadAll(b)
@@ -25,10 +26,6 @@ func TestGetVacuumReportCommand(t *testing.T) {
 	assert.NoError(t, cmdErr)
 	assert.NoError(t, err)
 	assert.NotNil(t, outBytes)
-
-	time := time.Now()
-	file := fmt.Sprintf("vacuum-report-%s.json", time.Format("01-02-06-15_04_05"))
-	defer os.Remove(file)
 }
 
 func TestGetVacuumReportCommand_StdInOut(t *testing.T) {
@@ -45,6 +42,19 @@ func TestGetVacuumReportCommand_StdInOut(t *testing.T) {
 	assert.NotNil(t, outBytes)
 }
 
+func TestGetVacuumReportCommand_ShowRuleTimings(t *testing.T) {
+	prefix := filepath.Join(t.TempDir(), "report")
+	cmd := GetVacuumReportCommand()
+	cmd.SetArgs([]string{"--show-rule-timings", "../model/test_files/petstorev3.json", prefix})
+	assert.NoError(t, cmd.Execute())
+
+	files, _ := filepath.Glob(prefix + "-*.json")
+	assert.Len(t, files, 1)
+	vr, _, err := vacuum_report.BuildVacuumReportFromFile(files[0])
+	assert.NoError(t, err)
+	assert.NotEmpty(t, vr.Statistics.RuleTimings)
+}
+
 func TestGetVacuumReportCommand_NoPretty(t *testing.T) {
 	cmd := GetVacuumReportCommand()
 	b := bytes.NewBufferString("")
@@ -66,6 +76,7 @@ func TestGetVacuumReportCommand_Compress(t *testing.T) {
 	cmd.SetArgs([]string{
 		"-c",
 		"../model/test_files/petstorev3.json",
+		filepath.Join(t.TempDir(), "vacuum-report"),
 	})
 	cmdErr := cmd.Execute()
 	outBytes, err := io.ReadAll(b)
@@ -73,19 +84,52 @@ func TestGetVacuumReportCommand_Compress(t *testing.T) {
 	assert.NoError(t, cmdErr)
 	assert.NoError(t, err)
 	assert.NotNil(t, outBytes)
+}
+
+func TestGetVacuumReportCommand_Badge(t *testing.T) {
+	dir := t.TempDir()
+	badge := filepath.Join(dir, "badge.svg")
+	cmd := GetVacuumReportCommand()
+	cmd.SetArgs([]string{"--badge", badge, "../model/test_files/petstorev3.json", filepath.Join(dir, "report")})
+	assert.NoError(t, cmd.Execute())
+
+	data, err := os.ReadFile(badge)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "API quality")
+}
 
-	time := time.Now()
-	file := fmt.Sprintf("vacuum-report-%s.json.gz", time.Format("01-02-06-15_04_05"))
-	defer os.Remove(file)
+func TestGetVacuumReportCommand_Compression(t *testing.T) {
+	for _, codec := range []string{"zstd", "brotli"} {
+		prefix := filepath.Join(t.TempDir(), "report")
+		cmd := GetVacuumReportCommand()
+		cmd.SetArgs([]string{
+			"--compression",
+			codec,
+			"../model/test_files/petstorev3.json",
+			prefix,
+		})
+		assert.NoError(t, cmd.Execute())
+
+		files, _ := filepath.Glob(prefix + "-*" + vacuum_report.CompressionExtension(codec))
+		assert.Len(t, files, 1)
+		vr, _, err := vacuum_report.BuildVacuumReportFromFile(files[0])
+		assert.NoError(t, err)
+		assert.NotNil(t, vr)
+	}
+
+	cmd := GetVacuumReportCommand()
+	cmd.SetArgs([]string{"--compression", "lzma", "../model/test_files/petstorev3.json", filepath.Join(t.TempDir(), "report")})
+	assert.ErrorContains(t, cmd.Execute(), "unknown compression")
 }
 
 func TestGetVacuumReportCommand_CustomPrefix(t *testing.T) {
 	cmd := GetVacuumReportCommand()
 	b := bytes.NewBufferString("")
 	cmd.SetOut(b)
+	prefix := filepath.Join(t.TempDir(), "cheesy-shoes")
 	cmd.SetArgs([]string{
 		"../model/test_files/petstorev3.json",
-		"cheesy-shoes",
+		prefix,
 	})
 	cmdErr := cmd.Execute()
 	outBytes, err := io.ReadAll(b)
@@ -94,9 +138,8 @@ func TestGetVacuumReportCommand_CustomPrefix(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, outBytes)
 
-	time := time.Now()
-	file := fmt.Sprintf("cheesy-shoes-%s.json", time.Format("01-02-06-15_04_05"))
-	defer os.Remove(file)
+	files, _ := filepath.Glob(prefix + "-*.json")
+	assert.Len(t, files, 1)
 }
 
 func TestGetVacuumReportCommand_WithRuleSet(t *testing.T) {
@@ -110,6 +153,7 @@ func TestGetVacuumReportCommand_WithRuleSet(t *testing.T) {
 		"-r",
 		"../rulesets/examples/norules-ruleset.yaml",
 		"../model/test_files/petstorev3.json",
+		filepath.Join(t.TempDir(), "vacuum-report"),
 	})
 	cmdErr := cmd.Execute()
 	outBytes, err := io.ReadAll(b)
@@ -117,10 +161,6 @@ func TestGetVacuumReportCommand_WithRuleSet(t *testing.T) {
 	assert.NoError(t, cmdErr)
 	assert.NoError(t, err)
 	assert.NotNil(t, outBytes)
-
-	time := time.Now()
-	file := fmt.Sprintf("vacuum-report-%s.json", time.Format("01-02-06-15_04_05"))
-	defer os.Remove(file)
 }
 
 func TestGetVacuumReportCommand_WithBadRuleset(t *testing.T) {
@@ -224,7 +264,34 @@ rules:
 		"-r",
 		tmp.Name(),
 		"../model/test_files/burgershop.openapi.yaml",
+		filepath.Join(t.TempDir(), "vacuum-report"),
 	})
 	cmdErr := cmd.Execute()
 	assert.NoError(t, cmdErr)
 }
+
+func TestGetVacuumReportCommand_GitLab_StdOut(t *testing.T) {
+	cmd := GetVacuumReportCommand()
+	b := bytes.NewBufferString("")
+	cmd.SetOut(b)
+	cmd.SetArgs([]string{"-o", "--gitlab", "../model/test_files/burgershop.openapi.yaml"})
+	cmdErr := cmd.Execute()
+	assert.NoError(t, cmdErr)
+}
+
+func TestGetVacuumReportCommand_GitLab_File(t *testing.T) {
+	cmd := GetVacuumReportCommand()
+	prefix := filepath.Join(t.TempDir(), "gl-test")
+	cmd.SetArgs([]string{"--gitlab", "../model/test_files/burgershop.openapi.yaml", prefix})
+	cmdErr := cmd.Execute()
+	assert.NoError(t, cmdErr)
+
+	matches, _ := filepath.Glob(prefix + "-*.codequality.json")
+	assert.NotEmpty(t, matches)
+}
+
+func TestGetVacuumReportCommand_Checkstyle_StdOut(t *testing.T) {
+	cmd := GetVacuumReportCommand()
+	cmd.SetArgs([]string{"-o", "--checkstyle", "../model/test_files/burgershop.openapi.yaml"})
+	assert.NoError(t, cmd.Execute())
+}
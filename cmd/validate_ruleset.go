@@ -0,0 +1,122 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/daveshanley/vacuum/functions"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pterm/pterm"
+	"github.com/spf13/cobra"
+)
+
+// GetValidateRuleSetCommand returns a cobra command that checks a ruleset for problems, without linting anything.
+func GetValidateRuleSetCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		Use:           "validate-ruleset",
+		Short:         "Check a ruleset for problems, without linting anything",
+		Long: "Check a ruleset (vacuum or Spectral) against the ruleset schema, report unknown fields, functions that " +
+			"do not exist or are given options they do not accept, and given paths that are not valid JSONPath. " +
+			"Every problem is reported with its line and column.",
+		Example: "vacuum validate-ruleset my-ruleset.yaml",
+		Args:    cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+
+			PrintBanner()
+
+			rulesetFlag, _ := cmd.Flags().GetString("ruleset")
+			functionsFlag, _ := cmd.Flags().GetString("functions")
+			if len(args) > 0 {
+				rulesetFlag = args[0]
+			}
+			if rulesetFlag == "" {
+				errText := "please supply the ruleset to validate"
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			rsBytes, err := os.ReadFile(rulesetFlag)
+			if err != nil {
+				errText := fmt.Sprintf("cannot read ruleset '%s': %s", rulesetFlag, err.Error())
+				pterm.Error.Println(errText)
+				pterm.Println()
+				return errors.New(errText)
+			}
+
+			problems, err := validateRuleSet(rulesetFlag, rsBytes, functionsFlag)
+			if err != nil {
+				return err
+			}
+			return renderRuleSetProblems(rulesetFlag, problems)
+		},
+	}
+	return cmd
+}
+
+// validateRuleSet checks a ruleset using the built-in functions, custom functions, and the functions the ruleset
+// declares itself.
+func validateRuleSet(rulesetPath string, rsBytes []byte, functionsFlag string) ([]*rulesets.RuleSetProblem, error) {
+	available := make(map[string]model.RuleFunction)
+	for name, fn := range functions.MapBuiltinFunctions().GetAllFunctions() {
+		available[name] = fn
+	}
+	customFunctions, err := LoadCustomFunctions(functionsFlag, true)
+	if err != nil {
+		return nil, err
+	}
+	for name, fn := range customFunctions {
+		available[name] = fn
+	}
+
+	var problems []*rulesets.RuleSetProblem
+	// functions declared by the ruleset can only be loaded if the ruleset can be read at all.
+	if rs, rsErr := rulesets.CreateRuleSetFromData(rsBytes); rsErr == nil {
+		declared, fErr := LoadRuleSetFunctions(rulesetPath, rs, nil, true)
+		if fErr != nil {
+			problems = append(problems, &rulesets.RuleSetProblem{
+				Message: fmt.Sprintf("unable to load the functions the ruleset declares: %s", fErr.Error()),
+			})
+		}
+		for name, fn := range declared {
+			available[name] = fn
+		}
+	}
+	return append(problems, rulesets.ValidateRuleSet(rsBytes, available)...), nil
+}
+
+// renderRuleSetProblems prints every problem found with a ruleset, and returns an error if there are any.
+func renderRuleSetProblems(rulesetPath string, problems []*rulesets.RuleSetProblem) error {
+	if len(problems) == 0 {
+		pterm.Success.Printf("Ruleset '%s' is valid\n", rulesetPath)
+		pterm.Println()
+		return nil
+	}
+
+	tableData := pterm.TableData{{"Location", "Rule", "Problem"}}
+	for _, p := range problems {
+		location := "-"
+		if p.Line > 0 {
+			location = fmt.Sprintf("%s:%d:%d", rulesetPath, p.Line, p.Column)
+		}
+		rule := p.Rule
+		if rule == "" {
+			rule = "-"
+		}
+		tableData = append(tableData, []string{location, rule, p.Message})
+	}
+	_ = pterm.DefaultTable.WithHasHeader().WithData(tableData).Render()
+	pterm.Println()
+
+	errText := fmt.Sprintf("ruleset '%s' has %d problems", rulesetPath, len(problems))
+	pterm.Error.Println(errText)
+	pterm.Println()
+	return errors.New(errText)
+}
@@ -0,0 +1,67 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetValidateRuleSetCommand(t *testing.T) {
+	cmd := GetValidateRuleSetCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.PersistentFlags().StringP("functions", "f", "", "")
+	cmd.SetArgs([]string{"../rulesets/examples/custom-ruleset.yaml"})
+	assert.NoError(t, cmd.Execute())
+}
+
+func TestGetValidateRuleSetCommand_Problems(t *testing.T) {
+	ruleset := filepath.Join(t.TempDir(), "ruleset.yaml")
+	rsBytes := []byte(`rules:
+  my-rule:
+    description: check the title
+    givn: $.info
+    given: $.info
+    then:
+      function: pattern
+      field: title
+      functionOptions:
+        matchs: "^[A-Z]"
+  other-rule:
+    given: $.paths[?(@.x ==
+    then:
+      function: notAFunction
+`)
+	assert.NoError(t, os.WriteFile(ruleset, rsBytes, 0664))
+
+	problems, err := validateRuleSet(ruleset, rsBytes, "")
+	assert.NoError(t, err)
+
+	var messages []string
+	for _, p := range problems {
+		messages = append(messages, p.String())
+	}
+	assert.Len(t, messages, 4)
+	assert.Equal(t, "4:5: rule 'my-rule': unknown field 'givn'", messages[0])
+	assert.Contains(t, messages[1], "10:9: rule 'my-rule': function 'pattern'")
+	assert.Contains(t, messages[2], "12:12: rule 'other-rule': given '$.paths[?(@.x ==' is not a valid JSONPath")
+	assert.Equal(t, "14:17: rule 'other-rule': function 'notAFunction' does not exist", messages[3])
+
+	cmd := GetValidateRuleSetCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.PersistentFlags().StringP("functions", "f", "", "")
+	cmd.SetArgs([]string{ruleset})
+	assert.ErrorContains(t, cmd.Execute(), "has 4 problems")
+}
+
+func TestGetValidateRuleSetCommand_Missing(t *testing.T) {
+	cmd := GetValidateRuleSetCommand()
+	cmd.PersistentFlags().StringP("ruleset", "r", "", "")
+	cmd.PersistentFlags().StringP("functions", "f", "", "")
+	cmd.SetArgs([]string{"nope.yaml"})
+	assert.ErrorContains(t, cmd.Execute(), "cannot read ruleset 'nope.yaml'")
+}
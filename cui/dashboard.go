@@ -7,6 +7,8 @@ import (
 	"github.com/gizak/termui/v3/widgets"
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/index"
+	"strings"
+	"unicode/utf8"
 )
 
 // Dashboard represents the dashboard controlling container
@@ -16,10 +18,16 @@ type Dashboard struct {
 	grid                   *ui.Grid
 	helpGrid               *ui.Grid
 	title                  *widgets.Paragraph
+	filterBar              *widgets.Paragraph
 	tabs                   TabbedView
 	healthGaugeItems       []ui.GridItem
 	categoryHealthGauge    []CategoryGauge
-	resultSet              *model.RuleResultSet
+	resultSet              *model.RuleResultSet // the results that match the filter.
+	allResults             *model.RuleResultSet
+	filter                 *ResultFilter
+	filterInput            string
+	filterViewActive       bool
+	exportDir              string // where exports are written, the working directory if empty.
 	index                  *index.SpecIndex
 	info                   *datamodel.SpecInfo
 	selectedTabIndex       int
@@ -38,6 +46,7 @@ type Dashboard struct {
 func CreateDashboard(resultSet *model.RuleResultSet, index *index.SpecIndex, info *datamodel.SpecInfo) *Dashboard {
 	db := new(Dashboard)
 	db.resultSet = resultSet
+	db.allResults = resultSet
 	db.index = index
 	db.info = info
 	return db
@@ -97,6 +106,7 @@ func (dash *Dashboard) Render() error {
 
 	var catsFiltered []*model.RuleCategory
 	if dash.resultSet != nil {
+		cats = dash.resultSet.GetRuleCategories()
 		for i := range cats {
 			res := dash.resultSet.GetResultsByRuleCategory(cats[i].Id)
 			if len(res) >= 1 {
@@ -145,9 +155,21 @@ func (dash *Dashboard) eventLoop(cats []*model.RuleCategory) {
 	// TODO: clean this damn mess up.
 	for {
 		e := <-uiEvents
+		if dash.filterViewActive && e.ID != "<C-c>" {
+			dash.handleFilterEvent(e.ID)
+			ui.Clear()
+			ui.Render(dash.grid, dash.title)
+			continue
+		}
 		switch e.ID {
 		case "q", "<C-c>":
 			return
+		case "/":
+			dash.violationViewActive = false
+			dash.filterViewActive = true
+			dash.updateFilterBar()
+		case "j", "c", "m":
+			dash.export(map[string]string{"j": ExportJSON, "c": ExportCSV, "m": ExportMarkdown}[e.ID])
 		case "h":
 			dash.helpViewActive = true
 		case "<Tab>":
@@ -209,6 +231,78 @@ func (dash *Dashboard) generateViewsAfterEvent() {
 	dash.tabs.generateRuleViolationView()
 }
 
+// handleFilterEvent edits the filter while the filter bar is active, results are filtered as the filter is typed.
+// <Enter> keeps the filter and leaves the filter bar, <Escape> clears it.
+func (dash *Dashboard) handleFilterEvent(id string) {
+	switch id {
+	case "<Enter>":
+		dash.filterViewActive = false
+	case "<Escape>":
+		dash.filterViewActive = false
+		dash.filterInput = ""
+	case "<Backspace>", "<C-<Backspace>>":
+		if dash.filterInput != "" {
+			_, size := utf8.DecodeLastRuneInString(dash.filterInput)
+			dash.filterInput = dash.filterInput[:len(dash.filterInput)-size]
+		}
+	case "<C-u>":
+		dash.filterInput = ""
+	case "<Space>":
+		dash.filterInput += " "
+	default:
+		if utf8.RuneCountInString(id) != 1 {
+			return
+		}
+		dash.filterInput += id
+	}
+	dash.applyFilter()
+}
+
+// applyFilter filters the results by the filter input and regenerates the views of the selected category.
+func (dash *Dashboard) applyFilter() {
+	dash.filter = ParseFilter(dash.filterInput)
+	dash.resultSet = model.NewRuleResultSetPointer(dash.filter.Apply(dash.allResults.Results))
+	dash.resultSet.RuleSetCategories = dash.allResults.RuleSetCategories
+	if dash.tabs.tv != nil {
+		dash.tabs.setActiveCategoryIndex(dash.selectedTabIndex)
+		dash.generateViewsAfterEvent()
+	}
+	dash.updateFilterBar()
+}
+
+// export writes the results in view to a file, and reports where it went (or what went wrong) in the filter bar.
+func (dash *Dashboard) export(format string) {
+	name, err := dash.exportView(format)
+	dash.updateFilterBar()
+	if dash.filterBar == nil {
+		return
+	}
+	if err != nil {
+		dash.filterBar.Text = fmt.Sprintf("[Export failed: %s](fg:red)", err.Error())
+		return
+	}
+	dash.filterBar.Text = fmt.Sprintf("[Exported to '%s'](fg:green) | %s", name, dash.filterBar.Text)
+}
+
+// updateFilterBar renders the filter being typed and how many results match it.
+func (dash *Dashboard) updateFilterBar() {
+	if dash.filterBar == nil {
+		return
+	}
+	input := strings.NewReplacer("[", "{", "]", "}").Replace(dash.filterInput)
+	switch {
+	case dash.filterViewActive:
+		dash.filterBar.Text = fmt.Sprintf("[Filter:](fg:white,md:bold) %s\u2588 | %s", input,
+			filterSummary(dash.resultSet, len(dash.allResults.Results)))
+	case dash.filterInput != "":
+		dash.filterBar.Text = fmt.Sprintf("[Filter:](fg:white,md:bold) %s | %s", input,
+			filterSummary(dash.resultSet, len(dash.allResults.Results)))
+	default:
+		dash.filterBar.Text = "Press / to filter by free text, rule:<id>, severity:<error|warn|info|hint>, " +
+			"category:<name> or path:<$.prefix> | " + filterSummary(dash.resultSet, len(dash.allResults.Results))
+	}
+}
+
 func (dash *Dashboard) setGrid() {
 
 	p := widgets.NewParagraph()
@@ -216,7 +310,9 @@ func (dash *Dashboard) setGrid() {
 	p.Text = fmt.Sprintf("vacuum %v: ", dash.Version) +
 		"[Select Category](fg:white,bg:clear,md:bold) = <Tab>,\u2B05\uFE0F\u27A1\uFE0F/S,X | " +
 		"[Change Rule](fg:white,bg:clear,md:bold) = \u2B06\u2B07/A,Z | " +
-		"[Select / Leave Rule](fg:white,bg:clear,md:bold) = <Enter> / <Esc>"
+		"[Select / Leave Rule](fg:white,bg:clear,md:bold) = <Enter> / <Esc> | " +
+		"[Filter](fg:white,bg:clear,md:bold) = / | " +
+		"[Export JSON / CSV / Markdown](fg:white,bg:clear,md:bold) = j / c / m"
 	p.TextStyle = ui.NewStyle(ui.ColorCyan, ui.ColorClear)
 	p.Border = true
 	p.BorderStyle = ui.NewStyle(ui.ColorCyan)
@@ -228,11 +324,19 @@ func (dash *Dashboard) setGrid() {
 
 	dash.title = p
 
+	f := widgets.NewParagraph()
+	f.TextStyle = ui.NewStyle(ui.ColorCyan, ui.ColorClear)
+	f.Border = false
+	f.PaddingLeft = 1
+	dash.filterBar = f
+	dash.updateFilterBar()
+
 	if !dash.helpViewActive {
 		if dash.tabs.descriptionGridItem != nil {
 			dash.grid.Set(
 				ui.NewRow(0.07, p),
-				ui.NewRow(0.93,
+				ui.NewRow(0.04, f),
+				ui.NewRow(0.89,
 					// TODO: bring statistics back via a shortcut key combo, they take up too much space and don't add
 					// enough value out of the box.
 					//ui.NewCol(0.2,
@@ -0,0 +1,117 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cui
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/utils"
+)
+
+// Formats the dashboard can export results as.
+const (
+	ExportJSON     = "json"
+	ExportCSV      = "csv"
+	ExportMarkdown = "markdown"
+)
+
+// ExportedResult is a single result exported from the dashboard, flattened so it reads the same in every format.
+type ExportedResult struct {
+	RuleId   string `json:"ruleId"`
+	Severity string `json:"severity"`
+	Category string `json:"category,omitempty"`
+	Message  string `json:"message"`
+	Path     string `json:"path"`
+	Line     int    `json:"line"`
+	Column   int    `json:"column"`
+}
+
+func exportResult(r *model.RuleFunctionResult) *ExportedResult {
+	exported := &ExportedResult{RuleId: r.RuleId, Severity: r.RuleSeverity, Message: r.Message, Path: r.Path,
+		Line: r.Range.Start.Line, Column: r.Range.Start.Char}
+	if r.Rule != nil {
+		exported.RuleId = r.Rule.Id
+		if r.Rule.Severity != "" {
+			exported.Severity = r.Rule.Severity
+		}
+		if r.Rule.RuleCategory != nil {
+			exported.Category = r.Rule.RuleCategory.Name
+		}
+	}
+	if r.StartNode != nil {
+		exported.Line, exported.Column = r.StartNode.Line, r.StartNode.Column
+	}
+	return exported
+}
+
+// ExportResults renders results as JSON, CSV or markdown, so a curated set of results can be handed to someone else.
+func ExportResults(results []*model.RuleFunctionResult, format string) ([]byte, error) {
+	exported := make([]*ExportedResult, 0, len(results))
+	for _, r := range results {
+		exported = append(exported, exportResult(r))
+	}
+	switch format {
+	case ExportJSON:
+		return json.MarshalIndent(exported, "", "  ")
+	case ExportCSV:
+		var buf bytes.Buffer
+		w := csv.NewWriter(&buf)
+		_ = w.Write([]string{"rule", "severity", "category", "message", "path", "line", "column"})
+		for _, e := range exported {
+			_ = w.Write([]string{e.RuleId, e.Severity, e.Category, e.Message, e.Path, fmt.Sprint(e.Line),
+				fmt.Sprint(e.Column)})
+		}
+		w.Flush()
+		return buf.Bytes(), w.Error()
+	case ExportMarkdown:
+		cell := strings.NewReplacer("|", "\\|", "\n", " ")
+		var rows [][]string
+		for _, e := range exported {
+			rows = append(rows, []string{fmt.Sprintf("%d:%d", e.Line, e.Column), e.Severity, "`" + e.RuleId + "`",
+				cell.Replace(e.Message), "`" + cell.Replace(e.Path) + "`"})
+		}
+		return []byte(utils.RenderMarkdownTable([]string{"Line", "Severity", "Rule", "Message", "Path"}, rows)), nil
+	}
+	return nil, fmt.Errorf("unknown export format '%s', use one of: json, csv, markdown", format)
+}
+
+// exportExtension returns the file extension used for an export format.
+func exportExtension(format string) string {
+	if format == ExportMarkdown {
+		return "md"
+	}
+	return format
+}
+
+// exportView writes the results currently in view to a file, and returns the name of the file. If a rule is
+// selected, its violations are exported, otherwise every (filtered) result in the selected category is.
+func (dash *Dashboard) exportView(format string) (string, error) {
+	var results []*model.RuleFunctionResult
+	switch {
+	case dash.violationViewActive:
+		results = dash.tabs.currentViolationRules
+	case dash.selectedCategory != nil:
+		results = dash.resultSet.GetResultsByRuleCategory(dash.selectedCategory.Id)
+	default:
+		results = dash.resultSet.Results
+	}
+	data, err := ExportResults(results, format)
+	if err != nil {
+		return "", err
+	}
+	name := filepath.Join(dash.exportDir,
+		fmt.Sprintf("vacuum-export-%s.%s", time.Now().Format("20060102-150405"), exportExtension(format)))
+	if err = os.WriteFile(name, data, 0664); err != nil {
+		return "", err
+	}
+	return name, nil
+}
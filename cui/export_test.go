@@ -0,0 +1,73 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cui
+
+import (
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	ui "github.com/gizak/termui/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExportResults(t *testing.T) {
+	results := []*model.RuleFunctionResult{{
+		Message: "a | pipe, and a comma",
+		Path:    "$.info",
+		Rule: &model.Rule{
+			Id:           "info-contact",
+			Severity:     model.SeverityWarn,
+			RuleCategory: model.RuleCategories[model.CategoryInfo],
+		},
+	}}
+
+	data, err := ExportResults(results, ExportJSON)
+	assert.NoError(t, err)
+	var exported []*ExportedResult
+	assert.NoError(t, json.Unmarshal(data, &exported))
+	assert.Len(t, exported, 1)
+	assert.Equal(t, "info-contact", exported[0].RuleId)
+	assert.Equal(t, model.SeverityWarn, exported[0].Severity)
+
+	data, err = ExportResults(results, ExportCSV)
+	assert.NoError(t, err)
+	assert.Equal(t, "rule,severity,category,message,path,line,column\n"+
+		"info-contact,warn,Contract Information,\"a | pipe, and a comma\",$.info,0,0\n", string(data))
+
+	data, err = ExportResults(results, ExportMarkdown)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `a \| pipe, and a comma`)
+
+	_, err = ExportResults(results, "xml")
+	assert.ErrorContains(t, err, "unknown export format 'xml'")
+}
+
+func TestDashboard_Export(t *testing.T) {
+	resultSet, idx, info := testBootDashboard()
+	dash := CreateDashboard(resultSet, idx, info)
+	dash.ruleCategories = model.RuleCategoriesOrdered
+	dash.exportDir = t.TempDir()
+	dash.GenerateTabbedView()
+	dash.grid = ui.NewGrid()
+	dash.helpGrid = ui.NewGrid()
+	dash.setGrid()
+
+	dash.filterInput = "sev:error"
+	dash.applyFilter()
+	dash.export(ExportCSV)
+	assert.Contains(t, dash.filterBar.Text, "Exported to")
+
+	name, err := dash.exportView(ExportCSV)
+	assert.NoError(t, err)
+	data, err := os.ReadFile(name)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, len(dash.resultSet.GetResultsByRuleCategory(dash.selectedCategory.Id))+1)
+	for _, line := range lines[1:] {
+		assert.Contains(t, line, ",error,")
+	}
+}
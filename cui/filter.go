@@ -0,0 +1,118 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// ResultFilter narrows down the results rendered by the dashboard. Every field that is set must match for a result
+// to be kept, an empty filter keeps everything.
+type ResultFilter struct {
+	Text     string // free text, matched against the message, rule id and path.
+	RuleId   string
+	Severity string
+	Category string // category id or name.
+	Path     string // JSONPath prefix, like '$.paths'
+}
+
+// ParseFilter reads a filter typed into the filter bar. Terms can be prefixed with 'rule:', 'severity:' (or 'sev:'),
+// 'category:' (or 'cat:') and 'path:', anything else is free text. For example:
+//
+//	sev:error path:$.paths['/pets'] missing
+func ParseFilter(query string) *ResultFilter {
+	filter := &ResultFilter{}
+	var text []string
+	for _, term := range strings.Fields(query) {
+		key, value, found := strings.Cut(term, ":")
+		if !found || value == "" {
+			text = append(text, term)
+			continue
+		}
+		switch strings.ToLower(key) {
+		case "rule":
+			filter.RuleId = value
+		case "severity", "sev":
+			filter.Severity = strings.ToLower(value)
+			if filter.Severity == "warning" {
+				filter.Severity = model.SeverityWarn
+			}
+		case "category", "cat":
+			filter.Category = value
+		case "path":
+			filter.Path = value
+		default:
+			text = append(text, term)
+		}
+	}
+	filter.Text = strings.Join(text, " ")
+	return filter
+}
+
+// IsEmpty returns true if nothing is being filtered.
+func (f *ResultFilter) IsEmpty() bool {
+	return f == nil || *f == ResultFilter{}
+}
+
+// Matches returns true if a result passes every part of the filter. Text, rule ids and categories are matched
+// without case, paths must start with the prefix.
+func (f *ResultFilter) Matches(result *model.RuleFunctionResult) bool {
+	if f.IsEmpty() {
+		return true
+	}
+	if f.Path != "" && !strings.HasPrefix(result.Path, f.Path) {
+		return false
+	}
+	ruleId, severity := result.RuleId, result.RuleSeverity
+	var category *model.RuleCategory
+	if result.Rule != nil {
+		ruleId = result.Rule.Id
+		if result.Rule.Severity != "" {
+			severity = result.Rule.Severity
+		}
+		category = result.Rule.RuleCategory
+	}
+	if f.RuleId != "" && !containsFold(ruleId, f.RuleId) {
+		return false
+	}
+	if f.Severity != "" && severity != f.Severity {
+		return false
+	}
+	if f.Category != "" && (category == nil ||
+		!(strings.EqualFold(category.Id, f.Category) || containsFold(category.Name, f.Category))) {
+		return false
+	}
+	if f.Text != "" && !containsFold(result.Message, f.Text) && !containsFold(ruleId, f.Text) &&
+		!containsFold(result.Path, f.Text) {
+		return false
+	}
+	return true
+}
+
+// Apply returns the results that match the filter.
+func (f *ResultFilter) Apply(results []*model.RuleFunctionResult) []*model.RuleFunctionResult {
+	if f.IsEmpty() {
+		return results
+	}
+	var filtered []*model.RuleFunctionResult
+	for _, r := range results {
+		if f.Matches(r) {
+			filtered = append(filtered, r)
+		}
+	}
+	return filtered
+}
+
+// filterSummary renders the number of results that match a filter, by severity.
+func filterSummary(results *model.RuleResultSet, total int) string {
+	return fmt.Sprintf("%d of %d results | [%d errors](fg:red) | [%d warnings](fg:yellow) | [%d info](fg:blue)",
+		len(results.Results), total, results.GetErrorCount(), results.GetWarnCount(), results.GetInfoCount())
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
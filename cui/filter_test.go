@@ -0,0 +1,90 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package cui
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	ui "github.com/gizak/termui/v3"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilter(t *testing.T) {
+	f := ParseFilter("sev:warning rule:oas3 category:schemas path:$.paths missing  thing unknown:key")
+	assert.Equal(t, model.SeverityWarn, f.Severity)
+	assert.Equal(t, "oas3", f.RuleId)
+	assert.Equal(t, "schemas", f.Category)
+	assert.Equal(t, "$.paths", f.Path)
+	assert.Equal(t, "missing thing unknown:key", f.Text)
+
+	assert.True(t, ParseFilter("  ").IsEmpty())
+	assert.Equal(t, "rule:", ParseFilter("rule:").Text)
+}
+
+func TestResultFilter_Matches(t *testing.T) {
+	result := &model.RuleFunctionResult{
+		Message: "Operation is missing a description",
+		Path:    "$.paths['/burgers'].post",
+		Rule: &model.Rule{
+			Id:           "operation-description",
+			Severity:     model.SeverityWarn,
+			RuleCategory: model.RuleCategories[model.CategoryDescriptions],
+		},
+	}
+	assert.True(t, ParseFilter("").Matches(result))
+	assert.True(t, ParseFilter("MISSING").Matches(result))
+	assert.True(t, ParseFilter("burgers").Matches(result))
+	assert.True(t, ParseFilter("rule:operation-desc sev:warn").Matches(result))
+	assert.True(t, ParseFilter("cat:descriptions path:$.paths['/burgers']").Matches(result))
+	assert.False(t, ParseFilter("sev:error").Matches(result))
+	assert.False(t, ParseFilter("rule:oas3").Matches(result))
+	assert.False(t, ParseFilter("cat:schemas").Matches(result))
+	assert.False(t, ParseFilter("path:$.components").Matches(result))
+	assert.False(t, ParseFilter("pizza").Matches(result))
+}
+
+func TestDashboard_Filter(t *testing.T) {
+	resultSet, idx, info := testBootDashboard()
+	total := len(resultSet.Results)
+	dash := CreateDashboard(resultSet, idx, info)
+	dash.ruleCategories = model.RuleCategoriesOrdered
+	dash.GenerateTabbedView()
+	dash.grid = ui.NewGrid()
+	dash.helpGrid = ui.NewGrid()
+	dash.setGrid()
+	assert.Contains(t, dash.filterBar.Text, "Press / to filter")
+
+	dash.filterViewActive = true
+	for _, key := range []string{"s", "e", "v", ":", "e", "r", "r", "o", "r", "x", "<Backspace>", "<Tab>"} {
+		dash.handleFilterEvent(key)
+	}
+	assert.Equal(t, "sev:error", dash.filterInput)
+	assert.Less(t, len(dash.resultSet.Results), total)
+	for _, r := range dash.resultSet.Results {
+		assert.Equal(t, model.SeverityError, r.Rule.Severity)
+	}
+	assert.Contains(t, dash.filterBar.Text, "sev:error")
+
+	dash.handleFilterEvent("<Enter>")
+	assert.False(t, dash.filterViewActive)
+	assert.Equal(t, "sev:error", dash.filterInput)
+
+	dash.filterViewActive = true
+	dash.handleFilterEvent("<Escape>")
+	assert.Empty(t, dash.filterInput)
+	assert.Len(t, dash.resultSet.Results, total)
+}
+
+func TestDashboard_Filter_RuleSetCategories(t *testing.T) {
+	resultSet, idx, info := testBootDashboard()
+	tags := model.NewRuleSetCategory(&model.RuleCategory{Id: model.CategoryTags, Name: "Navigation"})
+	resultSet.RuleSetCategories = []*model.RuleCategory{tags}
+	dash := CreateDashboard(resultSet, idx, info)
+
+	dash.filterInput = "sev:error"
+	dash.applyFilter()
+	assert.Equal(t, resultSet.RuleSetCategories, dash.resultSet.RuleSetCategories)
+	assert.Same(t, tags, dash.resultSet.GetRuleCategory(model.CategoryTags))
+}
@@ -0,0 +1,164 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package asyncapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func buildAsyncAPITestContext(t *testing.T, yml, format string, options interface{}) ([]*yaml.Node, model.RuleFunctionContext) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(yml), &root))
+	rule := model.Rule{Given: "$", Then: &model.RuleAction{Function: "asyncapi"}}
+	return []*yaml.Node{&root}, model.RuleFunctionContext{
+		RuleAction: model.CastToRuleAction(rule.Then),
+		Rule:       &rule,
+		Options:    options,
+		SpecInfo:   &datamodel.SpecInfo{SpecFormat: format},
+	}
+}
+
+func TestChannelAddress_RunRule_V2(t *testing.T) {
+	yml := `asyncapi: 2.6.0
+channels:
+  user/signedup:
+    subscribe: {}
+  user/deleted/:
+    subscribe: {}
+  user/{}?id=1:
+    publish: {}`
+
+	nodes, ctx := buildAsyncAPITestContext(t, yml, model.AsyncAPI2, nil)
+	res := ChannelAddress{}.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "channel address `user/deleted/` must not end with a slash", res[0].Message)
+	assert.Equal(t, "$.channels['user/deleted/']", res[0].Path)
+	assert.Equal(t, "$.channels['user/{}?id=1']", res[1].Path)
+	assert.Contains(t, res[2].Message, "empty parameter")
+}
+
+func TestChannelAddress_RunRule_V3(t *testing.T) {
+	yml := `asyncapi: 3.0.0
+channels:
+  signedUp:
+    address: user/signedup/
+  dynamic:
+    address: null
+  fine:
+    address: user/{userId}`
+
+	nodes, ctx := buildAsyncAPITestContext(t, yml, model.AsyncAPI3, nil)
+	res := ChannelAddress{}.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.channels['signedUp'].address", res[0].Path)
+	assert.Equal(t, 4, res[0].StartNode.Line)
+}
+
+func TestOperationProperties_RunRule_V2(t *testing.T) {
+	yml := `asyncapi: 2.6.0
+channels:
+  user/signedup:
+    subscribe:
+      operationId: onSignUp
+      description: A user signed up
+    publish:
+      summary: nope`
+
+	nodes, ctx := buildAsyncAPITestContext(t, yml, model.AsyncAPI2,
+		map[string]interface{}{"properties": []interface{}{"operationId", "description"}})
+	res := OperationProperties{}.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "operation `publish user/signedup` is missing a `operationId`", res[0].Message)
+	assert.Equal(t, "$.channels['user/signedup'].publish", res[0].Path)
+	assert.Equal(t, "operation `publish user/signedup` is missing a `description`", res[1].Message)
+}
+
+func TestOperationProperties_RunRule_V3(t *testing.T) {
+	yml := `asyncapi: 3.0.0
+operations:
+  onSignUp:
+    action: receive
+    description: A user signed up
+  sendWelcome:
+    action: send`
+
+	nodes, ctx := buildAsyncAPITestContext(t, yml, model.AsyncAPI3,
+		map[string]interface{}{"properties": "description"})
+	res := OperationProperties{}.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.operations['sendWelcome']", res[0].Path)
+}
+
+func TestOperationProperties_RunRule_NoOptions(t *testing.T) {
+	nodes, ctx := buildAsyncAPITestContext(t, "asyncapi: 3.0.0\noperations:\n  a:\n    action: send", model.AsyncAPI3, nil)
+	assert.Empty(t, OperationProperties{}.RunRule(nodes, ctx))
+}
+
+func TestBindingProtocols_RunRule(t *testing.T) {
+	yml := `asyncapi: 3.0.0
+servers:
+  production:
+    bindings:
+      kafka: {}
+      carrier-pigeon: {}
+channels:
+  signedUp:
+    bindings:
+      x-custom: {}
+    messages:
+      userSignedUp:
+        bindings:
+          smoke-signal: {}
+operations:
+  onSignUp:
+    bindings:
+      amqp: {}
+components:
+  messageBindings:
+    shared:
+      telegraph: {}`
+
+	nodes, ctx := buildAsyncAPITestContext(t, yml, model.AsyncAPI3, nil)
+	res := BindingProtocols{}.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "binding protocol `carrier-pigeon` is not a known AsyncAPI protocol", res[0].Message)
+	assert.Equal(t, "$.servers['production'].bindings.carrier-pigeon", res[0].Path)
+	assert.Equal(t, "$.channels['signedUp'].messages['userSignedUp'].bindings.smoke-signal", res[1].Path)
+	assert.Equal(t, "$.components.messageBindings['shared'].telegraph", res[2].Path)
+}
+
+func TestBindingProtocols_RunRule_V2Message(t *testing.T) {
+	yml := `asyncapi: 2.6.0
+channels:
+  user/signedup:
+    subscribe:
+      message:
+        bindings:
+          mqtt: {}
+          fax: {}`
+
+	nodes, ctx := buildAsyncAPITestContext(t, yml, model.AsyncAPI2, nil)
+	res := BindingProtocols{}.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.channels['user/signedup'].subscribe.message.bindings.fax", res[0].Path)
+}
+
+func TestAsyncAPIFunctions_NoDocument(t *testing.T) {
+	_, ctx := buildAsyncAPITestContext(t, "asyncapi: 3.0.0", model.AsyncAPI3, nil)
+	assert.Empty(t, ChannelAddress{}.RunRule(nil, ctx))
+	assert.Empty(t, OperationProperties{}.RunRule(nil, ctx))
+	assert.Empty(t, BindingProtocols{}.RunRule(nil, ctx))
+	assert.Equal(t, model.FunctionCategoryAsyncAPI, BindingProtocols{}.GetCategory())
+}
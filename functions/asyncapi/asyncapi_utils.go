@@ -0,0 +1,116 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package asyncapi
+
+import (
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// asyncOperation is an operation in an AsyncAPI document. In AsyncAPI 2 operations are 'publish' and 'subscribe'
+// objects in a channel, in AsyncAPI 3 they are named objects in 'operations'.
+type asyncOperation struct {
+	name    string
+	keyNode *yaml.Node
+	node    *yaml.Node
+	path    string
+}
+
+// asyncChannelAddress is the address of a channel. In AsyncAPI 2 this is the key of a channel, in AsyncAPI 3 it's
+// the 'address' of a channel.
+type asyncChannelAddress struct {
+	address string
+	node    *yaml.Node
+	path    string
+}
+
+// rootNode returns the root mapping of the document the rule is being run against.
+func rootNode(nodes []*yaml.Node) *yaml.Node {
+	if len(nodes) == 0 || nodes[0] == nil {
+		return nil
+	}
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if !utils.IsNodeMap(root) {
+		return nil
+	}
+	return root
+}
+
+// mapValue returns the mapping value of a key, or nil if it's missing or not a mapping.
+func mapValue(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil {
+		return nil, nil
+	}
+	k, v := utils.FindKeyNodeTop(key, node.Content)
+	if v == nil || !utils.IsNodeMap(v) {
+		return nil, nil
+	}
+	return k, v
+}
+
+// eachPair calls fn for every key and value in a mapping.
+func eachPair(node *yaml.Node, fn func(key, value *yaml.Node)) {
+	if node == nil {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		fn(node.Content[i], node.Content[i+1])
+	}
+}
+
+func quotedPath(base, key string) string {
+	return model.GetStringTemplates().BuildQuotedPath(base, key)
+}
+
+// getChannelAddresses returns the address of every channel in the document.
+func getChannelAddresses(root *yaml.Node, format string) []asyncChannelAddress {
+	var addresses []asyncChannelAddress
+	_, channels := mapValue(root, "channels")
+	eachPair(channels, func(key, value *yaml.Node) {
+		path := quotedPath("$.channels", key.Value)
+		if format != model.AsyncAPI3 {
+			addresses = append(addresses, asyncChannelAddress{address: key.Value, node: key, path: path})
+			return
+		}
+		if !utils.IsNodeMap(value) {
+			return
+		}
+		// a null address is allowed, it means the address is unknown or dynamic.
+		_, address := utils.FindKeyNodeTop("address", value.Content)
+		if address != nil && address.Tag != "!!null" && address.Value != "" {
+			addresses = append(addresses, asyncChannelAddress{address: address.Value, node: address,
+				path: model.GetStringTemplates().BuildJSONPath(path, "address")})
+		}
+	})
+	return addresses
+}
+
+// getOperations returns every operation in the document.
+func getOperations(root *yaml.Node, format string) []asyncOperation {
+	var operations []asyncOperation
+	if format == model.AsyncAPI3 {
+		_, ops := mapValue(root, "operations")
+		eachPair(ops, func(key, value *yaml.Node) {
+			if utils.IsNodeMap(value) {
+				operations = append(operations, asyncOperation{name: key.Value, keyNode: key, node: value,
+					path: quotedPath("$.operations", key.Value)})
+			}
+		})
+		return operations
+	}
+	_, channels := mapValue(root, "channels")
+	eachPair(channels, func(channelKey, channel *yaml.Node) {
+		for _, action := range []string{"publish", "subscribe"} {
+			if k, op := mapValue(channel, action); op != nil {
+				operations = append(operations, asyncOperation{name: action + " " + channelKey.Value, keyNode: k,
+					node: op, path: model.GetStringTemplates().BuildJSONPath(quotedPath("$.channels", channelKey.Value), action)})
+			}
+		}
+	})
+	return operations
+}
@@ -0,0 +1,126 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package asyncapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// knownBindingProtocols are the protocols with a binding defined by https://github.com/asyncapi/bindings
+var knownBindingProtocols = map[string]bool{
+	"http": true, "ws": true, "kafka": true, "anypointmq": true, "amqp": true, "amqp1": true, "mqtt": true,
+	"mqtt5": true, "nats": true, "jms": true, "sns": true, "solace": true, "sqs": true, "stomp": true,
+	"redis": true, "mercure": true, "ibmmq": true, "googlepubsub": true, "pulsar": true,
+}
+
+// BindingProtocols checks every bindings object in an AsyncAPI document only uses known protocols. Extensions
+// (x-) are allowed.
+type BindingProtocols struct{}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the BindingProtocols rule.
+func (bp BindingProtocols) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "asyncapiBindingProtocols"}
+}
+
+// GetCategory returns the category of the BindingProtocols rule.
+func (bp BindingProtocols) GetCategory() string {
+	return model.FunctionCategoryAsyncAPI
+}
+
+// RunRule will execute the BindingProtocols rule, based on supplied context and a supplied []*yaml.Node slice.
+func (bp BindingProtocols) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	var results []model.RuleFunctionResult
+	root := rootNode(nodes)
+	if root == nil || context.SpecInfo == nil {
+		return results
+	}
+
+	for _, b := range collectBindings(root, context.SpecInfo.SpecFormat) {
+		eachPair(b.node, func(key, _ *yaml.Node) {
+			if knownBindingProtocols[key.Value] || strings.HasPrefix(key.Value, "x-") {
+				return
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: vacuumUtils.SuppliedOrDefault(context.Rule.Message,
+					fmt.Sprintf("binding protocol `%s` is not a known AsyncAPI protocol", key.Value)),
+				StartNode: key,
+				EndNode:   vacuumUtils.BuildEndNode(key),
+				Path:      model.GetStringTemplates().BuildJSONPath(b.path, key.Value),
+				Rule:      context.Rule,
+			})
+		})
+	}
+	return results
+}
+
+type bindingsObject struct {
+	path string
+	node *yaml.Node
+}
+
+// collectBindings returns every bindings object in the document, in document order.
+func collectBindings(root *yaml.Node, format string) []bindingsObject {
+	var found []bindingsObject
+	addBindings := func(path string, node *yaml.Node) {
+		if _, bindings := mapValue(node, "bindings"); bindings != nil {
+			found = append(found, bindingsObject{path: path + ".bindings", node: bindings})
+		}
+	}
+	addMessages := func(path string, node *yaml.Node) {
+		if format == model.AsyncAPI3 {
+			_, messages := mapValue(node, "messages")
+			eachPair(messages, func(key, value *yaml.Node) {
+				addBindings(quotedPath(path+".messages", key.Value), value)
+			})
+			return
+		}
+		if _, message := mapValue(node, "message"); message != nil {
+			addBindings(path+".message", message)
+		}
+	}
+
+	_, servers := mapValue(root, "servers")
+	eachPair(servers, func(key, value *yaml.Node) {
+		addBindings(quotedPath("$.servers", key.Value), value)
+	})
+
+	_, channels := mapValue(root, "channels")
+	eachPair(channels, func(key, value *yaml.Node) {
+		path := quotedPath("$.channels", key.Value)
+		addBindings(path, value)
+		if format == model.AsyncAPI3 {
+			addMessages(path, value)
+		}
+	})
+
+	for _, op := range getOperations(root, format) {
+		addBindings(op.path, op.node)
+		if format != model.AsyncAPI3 {
+			addMessages(op.path, op.node)
+		}
+	}
+
+	_, components := mapValue(root, "components")
+	for _, section := range []string{"servers", "channels", "operations", "messages"} {
+		_, items := mapValue(components, section)
+		eachPair(items, func(key, value *yaml.Node) {
+			addBindings(quotedPath("$.components."+section, key.Value), value)
+		})
+	}
+	for _, section := range []string{"serverBindings", "channelBindings", "operationBindings", "messageBindings"} {
+		_, items := mapValue(components, section)
+		eachPair(items, func(key, value *yaml.Node) {
+			if utils.IsNodeMap(value) {
+				found = append(found, bindingsObject{path: quotedPath("$.components."+section, key.Value), node: value})
+			}
+		})
+	}
+	return found
+}
@@ -0,0 +1,60 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package asyncapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ChannelAddress checks channel addresses do not end with a slash, and do not include a query, fragment or an
+// empty parameter.
+type ChannelAddress struct{}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the ChannelAddress rule.
+func (ca ChannelAddress) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "asyncapiChannelAddress"}
+}
+
+// GetCategory returns the category of the ChannelAddress rule.
+func (ca ChannelAddress) GetCategory() string {
+	return model.FunctionCategoryAsyncAPI
+}
+
+// RunRule will execute the ChannelAddress rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ca ChannelAddress) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	var results []model.RuleFunctionResult
+	root := rootNode(nodes)
+	if root == nil || context.SpecInfo == nil {
+		return results
+	}
+
+	for _, ch := range getChannelAddresses(root, context.SpecInfo.SpecFormat) {
+		var problems []string
+		if len(ch.address) > 1 && strings.HasSuffix(ch.address, "/") {
+			problems = append(problems, "must not end with a slash")
+		}
+		if strings.ContainsAny(ch.address, "?#") {
+			problems = append(problems, "must not include a query (`?`) or a fragment (`#`)")
+		}
+		if strings.Contains(ch.address, "{}") {
+			problems = append(problems, "must not include an empty parameter (`{}`)")
+		}
+		for _, problem := range problems {
+			results = append(results, model.RuleFunctionResult{
+				Message: vacuumUtils.SuppliedOrDefault(context.Rule.Message,
+					fmt.Sprintf("channel address `%s` %s", ch.address, problem)),
+				StartNode: ch.node,
+				EndNode:   vacuumUtils.BuildEndNode(ch.node),
+				Path:      ch.path,
+				Rule:      context.Rule,
+			})
+		}
+	}
+	return results
+}
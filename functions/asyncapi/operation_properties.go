@@ -0,0 +1,90 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package asyncapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// OperationProperties checks every operation defines the properties supplied via the 'properties' option, for
+// example 'operationId' or 'description'.
+type OperationProperties struct{}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the OperationProperties rule.
+func (op OperationProperties) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name:     "asyncapiOperationProperties",
+		Required: []string{"properties"},
+		Properties: []model.RuleFunctionProperty{
+			{
+				Name:        "properties",
+				Description: "'asyncapiOperationProperties' needs a list of properties every operation must define",
+			},
+		},
+		MinProperties: 1,
+		ErrorMessage:  "'asyncapiOperationProperties' needs 'properties' to operate, for example: 'operationId, description'",
+	}
+}
+
+// GetCategory returns the category of the OperationProperties rule.
+func (op OperationProperties) GetCategory() string {
+	return model.FunctionCategoryAsyncAPI
+}
+
+// RunRule will execute the OperationProperties rule, based on supplied context and a supplied []*yaml.Node slice.
+func (op OperationProperties) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	var results []model.RuleFunctionResult
+	root := rootNode(nodes)
+	if root == nil || context.SpecInfo == nil {
+		return results
+	}
+
+	properties := operationPropertiesOption(context.Options)
+	for _, operation := range getOperations(root, context.SpecInfo.SpecFormat) {
+		for _, property := range properties {
+			_, v := utils.FindKeyNodeTop(property, operation.node.Content)
+			if v != nil && v.Value != "" || v != nil && len(v.Content) > 0 {
+				continue
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: vacuumUtils.SuppliedOrDefault(context.Rule.Message,
+					fmt.Sprintf("operation `%s` is missing a `%s`", operation.name, property)),
+				StartNode: operation.keyNode,
+				EndNode:   vacuumUtils.BuildEndNode(operation.keyNode),
+				Path:      operation.path,
+				Rule:      context.Rule,
+			})
+		}
+	}
+	return results
+}
+
+func operationPropertiesOption(options any) []string {
+	var properties []string
+	switch opts := options.(type) {
+	case map[string]interface{}:
+		switch p := opts["properties"].(type) {
+		case []string:
+			properties = p
+		case []interface{}:
+			for _, v := range p {
+				if s, ok := v.(string); ok {
+					properties = append(properties, s)
+				}
+			}
+		case string:
+			properties = []string{p}
+		}
+	case map[string]string:
+		if opts["properties"] != "" {
+			properties = []string{opts["properties"]}
+		}
+	}
+	return properties
+}
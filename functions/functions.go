@@ -6,7 +6,9 @@ package functions
 import (
 	"sync"
 
+	asyncapi_functions "github.com/daveshanley/vacuum/functions/asyncapi"
 	"github.com/daveshanley/vacuum/functions/core"
+	jsonschema_functions "github.com/daveshanley/vacuum/functions/jsonschema"
 	openapi_functions "github.com/daveshanley/vacuum/functions/openapi"
 	"github.com/daveshanley/vacuum/functions/owasp"
 	"github.com/daveshanley/vacuum/model"
@@ -107,6 +109,7 @@ func MapBuiltinFunctions() Functions {
 		funcs["infoContactProperties"] = openapi_functions.InfoContactProperties{}
 		funcs["noRequestBody"] = openapi_functions.NoRequestBody{}
 		funcs["pathItemReferences"] = openapi_functions.PathItemReferences{}
+		funcs["oasAdditionalOperations"] = openapi_functions.AdditionalOperations{}
 
 		// add owasp functions used by the owasp rules
 		funcs["owaspHeaderDefinition"] = owasp.HeaderDefinition{}
@@ -129,6 +132,17 @@ func MapBuiltinFunctions() Functions {
 		funcs["owaspNoAdditionalPropertiesConstrained"] = owasp.AdditionalPropertiesConstrained{}
 		funcs["owaspHostsHttps"] = owasp.HostsHttps{}
 
+		// add asyncapi functions used by the asyncapi rules
+		funcs["asyncapiChannelAddress"] = asyncapi_functions.ChannelAddress{}
+		funcs["asyncapiOperationProperties"] = asyncapi_functions.OperationProperties{}
+		funcs["asyncapiBindingProtocols"] = asyncapi_functions.BindingProtocols{}
+
+		// add json schema functions used by the json schema rules
+		funcs["jsonSchemaTypes"] = jsonschema_functions.SchemaTypes{}
+		funcs["jsonSchemaEnumExamples"] = jsonschema_functions.EnumExamples{}
+		funcs["jsonSchemaDescriptions"] = jsonschema_functions.Descriptions{}
+		funcs["jsonSchemaUnreachableDefs"] = jsonschema_functions.UnreachableDefinitions{}
+
 	})
 
 	return functionsSingleton
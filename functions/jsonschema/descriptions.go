@@ -0,0 +1,59 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package jsonschema
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// Descriptions checks every property and definition in a JSON Schema document has a description. Schemas that
+// are only a reference take their description from what they reference.
+type Descriptions struct{}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the Descriptions rule.
+func (d Descriptions) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "jsonSchemaDescriptions"}
+}
+
+// GetCategory returns the category of the Descriptions rule.
+func (d Descriptions) GetCategory() string {
+	return model.FunctionCategoryJSONSchema
+}
+
+// RunRule will execute the Descriptions rule, based on supplied context and a supplied []*yaml.Node slice.
+func (d Descriptions) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	var results []model.RuleFunctionResult
+	root := rootNode(nodes)
+	if root == nil {
+		return results
+	}
+
+	walkSchemas(root, func(schema visitedSchema) {
+		switch schema.parent {
+		case "properties", "$defs", "definitions":
+		default:
+			return
+		}
+		if hasKey(schema.node, "description", "$ref") {
+			return
+		}
+		kind := "property"
+		if schema.parent != "properties" {
+			kind = "definition"
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message: vacuumUtils.SuppliedOrDefault(context.Rule.Message,
+				fmt.Sprintf("%s `%s` is missing a description", kind, schema.keyNode.Value)),
+			StartNode: schema.keyNode,
+			EndNode:   vacuumUtils.BuildEndNode(schema.keyNode),
+			Path:      schema.path,
+			Rule:      context.Rule,
+		})
+	})
+	return results
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package jsonschema
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// EnumExamples checks the `examples` and `default` of a schema with an `enum` are values of the enum.
+type EnumExamples struct{}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the EnumExamples rule.
+func (ee EnumExamples) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "jsonSchemaEnumExamples"}
+}
+
+// GetCategory returns the category of the EnumExamples rule.
+func (ee EnumExamples) GetCategory() string {
+	return model.FunctionCategoryJSONSchema
+}
+
+// RunRule will execute the EnumExamples rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ee EnumExamples) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	var results []model.RuleFunctionResult
+	root := rootNode(nodes)
+	if root == nil {
+		return results
+	}
+
+	templates := model.GetStringTemplates()
+	walkSchemas(root, func(schema visitedSchema) {
+		_, enum := utils.FindKeyNodeTop("enum", schema.node.Content)
+		if enum == nil || !utils.IsNodeArray(enum) {
+			return
+		}
+		check := func(value *yaml.Node, path string) {
+			for _, allowed := range enum.Content {
+				if sameValue(allowed, value) {
+					return
+				}
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message: vacuumUtils.SuppliedOrDefault(context.Rule.Message,
+					fmt.Sprintf("`%s` is not one of the `enum` values of schema `%s`", renderValue(value), schema.path)),
+				StartNode: value,
+				EndNode:   vacuumUtils.BuildEndNode(value),
+				Path:      path,
+				Rule:      context.Rule,
+			})
+		}
+
+		if _, examples := utils.FindKeyNodeTop("examples", schema.node.Content); examples != nil && utils.IsNodeArray(examples) {
+			for i, example := range examples.Content {
+				check(example, templates.BuildArrayPath(templates.BuildJSONPath(schema.path, "examples"), i))
+			}
+		}
+		if _, def := utils.FindKeyNodeTop("default", schema.node.Content); def != nil {
+			check(def, templates.BuildJSONPath(schema.path, "default"))
+		}
+	})
+	return results
+}
+
+// sameValue compares two values, scalars by value and type, anything else by its rendered form.
+func sameValue(a, b *yaml.Node) bool {
+	if a.Kind == yaml.ScalarNode && b.Kind == yaml.ScalarNode {
+		return a.Value == b.Value && a.ShortTag() == b.ShortTag()
+	}
+	return a.Kind == b.Kind && renderValue(a) == renderValue(b)
+}
+
+func renderValue(node *yaml.Node) string {
+	if node.Kind == yaml.ScalarNode {
+		return node.Value
+	}
+	out, _ := yaml.Marshal(node)
+	return string(out)
+}
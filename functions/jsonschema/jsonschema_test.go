@@ -0,0 +1,142 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package jsonschema
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func buildJSONSchemaTestContext(t *testing.T, spec string) ([]*yaml.Node, model.RuleFunctionContext) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	rule := model.Rule{Given: "$", Then: &model.RuleAction{Function: "jsonschema"}}
+	return []*yaml.Node{&root}, model.RuleFunctionContext{
+		RuleAction: model.CastToRuleAction(rule.Then),
+		Rule:       &rule,
+	}
+}
+
+func TestSchemaTypes_RunRule(t *testing.T) {
+	spec := `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "name": {"type": "string"},
+    "age": {"description": "no type"},
+    "tags": {"type": "array", "items": {"type": ["string", "text"]}},
+    "pet": {"$ref": "#/$defs/pet"},
+    "size": {"enum": ["s", "m"]}
+  },
+  "$defs": {
+    "pet": {"oneOf": [{"type": "string"}, {"properties": {}}]}
+  }
+}`
+	nodes, ctx := buildJSONSchemaTestContext(t, spec)
+	res := SchemaTypes{}.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "schema `$.properties['age']` does not declare a `type`", res[0].Message)
+	assert.Equal(t, "$.properties['tags'].items.type", res[1].Path)
+	assert.Contains(t, res[1].Message, "`text` is not valid")
+	assert.Equal(t, "$.$defs['pet'].oneOf[1]", res[2].Path)
+}
+
+func TestSchemaTypes_RunRule_DefinitionsOnly(t *testing.T) {
+	nodes, ctx := buildJSONSchemaTestContext(t, `{"$defs": {"a": {"type": "string"}}}`)
+	assert.Empty(t, SchemaTypes{}.RunRule(nodes, ctx))
+}
+
+func TestEnumExamples_RunRule(t *testing.T) {
+	spec := `type: string
+enum: [cake, egg, "1"]
+default: milk
+examples:
+  - cake
+  - 1
+  - egg`
+	nodes, ctx := buildJSONSchemaTestContext(t, spec)
+	res := EnumExamples{}.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "$.examples[1]", res[0].Path)
+	assert.Equal(t, "`milk` is not one of the `enum` values of schema `$`", res[1].Message)
+	assert.Equal(t, "$.default", res[1].Path)
+}
+
+func TestDescriptions_RunRule(t *testing.T) {
+	spec := `type: object
+properties:
+  name:
+    type: string
+    description: The name
+  age:
+    type: integer
+  pet:
+    $ref: '#/definitions/pet'
+definitions:
+  pet:
+    type: string`
+	nodes, ctx := buildJSONSchemaTestContext(t, spec)
+	res := Descriptions{}.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 2)
+	assert.Equal(t, "property `age` is missing a description", res[0].Message)
+	assert.Equal(t, "definition `pet` is missing a description", res[1].Message)
+	assert.Equal(t, "$.definitions['pet']", res[1].Path)
+}
+
+func TestUnreachableDefinitions_RunRule(t *testing.T) {
+	spec := `type: object
+properties:
+  pet:
+    $ref: '#/$defs/pet'
+  external:
+    $ref: 'other.json#/$defs/lonely'
+$defs:
+  pet:
+    properties:
+      owner:
+        $ref: '#/$defs/owner/properties/name'
+  owner:
+    properties:
+      name:
+        type: string
+  lonely:
+    $ref: '#/$defs/lonelier'
+  lonelier:
+    type: string
+  a/b:
+    type: string`
+	nodes, ctx := buildJSONSchemaTestContext(t, spec)
+	res := UnreachableDefinitions{}.RunRule(nodes, ctx)
+
+	assert.Len(t, res, 3)
+	assert.Equal(t, "definition `lonely` cannot be reached from the root schema", res[0].Message)
+	assert.Equal(t, "$.$defs['lonely']", res[0].Path)
+	assert.Equal(t, "$.$defs['lonelier']", res[1].Path)
+	assert.Equal(t, "$.$defs['a/b']", res[2].Path)
+}
+
+func TestUnreachableDefinitions_RunRule_EscapedKey(t *testing.T) {
+	spec := `items:
+  $ref: '#/definitions/a~1b'
+definitions:
+  a/b:
+    type: string`
+	nodes, ctx := buildJSONSchemaTestContext(t, spec)
+	assert.Empty(t, UnreachableDefinitions{}.RunRule(nodes, ctx))
+}
+
+func TestJSONSchemaFunctions_NoDocument(t *testing.T) {
+	_, ctx := buildJSONSchemaTestContext(t, "type: string")
+	assert.Empty(t, SchemaTypes{}.RunRule(nil, ctx))
+	assert.Empty(t, EnumExamples{}.RunRule(nil, ctx))
+	assert.Empty(t, Descriptions{}.RunRule(nil, ctx))
+	assert.Empty(t, UnreachableDefinitions{}.RunRule(nil, ctx))
+	assert.Equal(t, model.FunctionCategoryJSONSchema, UnreachableDefinitions{}.GetCategory())
+}
@@ -0,0 +1,97 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package jsonschema
+
+import (
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// schemaMapKeywords contain a map of named schemas.
+var schemaMapKeywords = []string{"properties", "patternProperties", "$defs", "definitions", "dependentSchemas"}
+
+// schemaKeywords contain a single schema.
+var schemaKeywords = []string{"items", "additionalProperties", "additionalItems", "not", "if", "then", "else",
+	"contains", "propertyNames", "unevaluatedItems", "unevaluatedProperties"}
+
+// schemaArrayKeywords contain an array of schemas.
+var schemaArrayKeywords = []string{"allOf", "anyOf", "oneOf", "prefixItems", "items"}
+
+// visitedSchema is a schema found in a JSON Schema document.
+type visitedSchema struct {
+	node    *yaml.Node // the schema mapping.
+	keyNode *yaml.Node // the key the schema is defined by, or the schema itself if there is no key.
+	path    string
+	parent  string // the keyword the schema was found under, empty for the root schema.
+	root    bool
+}
+
+// rootNode returns the root mapping of the document the rule is being run against.
+func rootNode(nodes []*yaml.Node) *yaml.Node {
+	if len(nodes) == 0 || nodes[0] == nil {
+		return nil
+	}
+	root := nodes[0]
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if !utils.IsNodeMap(root) {
+		return nil
+	}
+	return root
+}
+
+// walkSchemas calls fn for the root schema and every schema nested inside it, in document order.
+func walkSchemas(root *yaml.Node, fn func(schema visitedSchema)) {
+	var walk func(schema visitedSchema)
+	walk = func(schema visitedSchema) {
+		fn(schema)
+		templates := model.GetStringTemplates()
+		for i := 0; i+1 < len(schema.node.Content); i += 2 {
+			key, value := schema.node.Content[i], schema.node.Content[i+1]
+			path := templates.BuildJSONPath(schema.path, key.Value)
+			if contains(schemaMapKeywords, key.Value) && utils.IsNodeMap(value) {
+				for j := 0; j+1 < len(value.Content); j += 2 {
+					if utils.IsNodeMap(value.Content[j+1]) {
+						walk(visitedSchema{node: value.Content[j+1], keyNode: value.Content[j],
+							path: templates.BuildQuotedPath(path, value.Content[j].Value), parent: key.Value})
+					}
+				}
+				continue
+			}
+			if contains(schemaKeywords, key.Value) && utils.IsNodeMap(value) {
+				walk(visitedSchema{node: value, keyNode: key, path: path, parent: key.Value})
+				continue
+			}
+			if contains(schemaArrayKeywords, key.Value) && utils.IsNodeArray(value) {
+				for j, item := range value.Content {
+					if utils.IsNodeMap(item) {
+						walk(visitedSchema{node: item, keyNode: item, path: templates.BuildArrayPath(path, j),
+							parent: key.Value})
+					}
+				}
+			}
+		}
+	}
+	walk(visitedSchema{node: root, keyNode: root, path: "$", root: true})
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func hasKey(node *yaml.Node, keys ...string) bool {
+	for _, key := range keys {
+		if k, _ := utils.FindKeyNodeTop(key, node.Content); k != nil {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package jsonschema
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+var validTypes = []string{"null", "boolean", "object", "array", "number", "string", "integer"}
+
+// SchemaTypes checks every schema in a JSON Schema document declares a valid `type`. Schemas that are composed
+// (references, enums, constants or combinations) and schemas that only hold definitions don't need one.
+type SchemaTypes struct{}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the SchemaTypes rule.
+func (st SchemaTypes) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "jsonSchemaTypes"}
+}
+
+// GetCategory returns the category of the SchemaTypes rule.
+func (st SchemaTypes) GetCategory() string {
+	return model.FunctionCategoryJSONSchema
+}
+
+// RunRule will execute the SchemaTypes rule, based on supplied context and a supplied []*yaml.Node slice.
+func (st SchemaTypes) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	var results []model.RuleFunctionResult
+	root := rootNode(nodes)
+	if root == nil {
+		return results
+	}
+
+	addResult := func(node *yaml.Node, path, message string) {
+		results = append(results, model.RuleFunctionResult{
+			Message:   vacuumUtils.SuppliedOrDefault(context.Rule.Message, message),
+			StartNode: node,
+			EndNode:   vacuumUtils.BuildEndNode(node),
+			Path:      path,
+			Rule:      context.Rule,
+		})
+	}
+
+	walkSchemas(root, func(schema visitedSchema) {
+		typeKey, typeNode := utils.FindKeyNodeTop("type", schema.node.Content)
+		if typeNode == nil {
+			if hasKey(schema.node, "$ref", "$dynamicRef", "const", "enum", "allOf", "anyOf", "oneOf", "not", "if") {
+				return
+			}
+			if hasKey(schema.node, "$defs", "definitions") && !hasKey(schema.node, "properties", "items") {
+				return
+			}
+			addResult(schema.keyNode, schema.path, fmt.Sprintf("schema `%s` does not declare a `type`", schema.path))
+			return
+		}
+
+		var types []*yaml.Node
+		if utils.IsNodeArray(typeNode) {
+			types = typeNode.Content
+		} else {
+			types = []*yaml.Node{typeNode}
+		}
+		for _, t := range types {
+			if !contains(validTypes, t.Value) {
+				addResult(typeKey, model.GetStringTemplates().BuildJSONPath(schema.path, "type"),
+					fmt.Sprintf("schema type `%s` is not valid, it must be one of %v", t.Value, validTypes))
+			}
+		}
+	})
+	return results
+}
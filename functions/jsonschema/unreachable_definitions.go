@@ -0,0 +1,144 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package jsonschema
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// definitionKeywords hold the definitions of a document.
+var definitionKeywords = []string{"$defs", "definitions"}
+
+// UnreachableDefinitions checks every definition in `$defs` (or `definitions`) of the root schema can be reached
+// from the root schema by following local references. A definition only used by another unreachable definition
+// is unreachable as well.
+type UnreachableDefinitions struct{}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the UnreachableDefinitions rule.
+func (ud UnreachableDefinitions) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: "jsonSchemaUnreachableDefs"}
+}
+
+// GetCategory returns the category of the UnreachableDefinitions rule.
+func (ud UnreachableDefinitions) GetCategory() string {
+	return model.FunctionCategoryJSONSchema
+}
+
+type definition struct {
+	keyNode *yaml.Node
+	node    *yaml.Node
+	path    string
+}
+
+// RunRule will execute the UnreachableDefinitions rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ud UnreachableDefinitions) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	var results []model.RuleFunctionResult
+	root := rootNode(nodes)
+	if root == nil {
+		return results
+	}
+
+	// collect definitions, keyed by their local reference.
+	definitions := make(map[string]definition)
+	var ordered []string
+	for _, keyword := range definitionKeywords {
+		_, defs := utils.FindKeyNodeTop(keyword, root.Content)
+		if defs == nil || !utils.IsNodeMap(defs) {
+			continue
+		}
+		for i := 0; i+1 < len(defs.Content); i += 2 {
+			ref := "#/" + keyword + "/" + escapePointer(defs.Content[i].Value)
+			definitions[ref] = definition{keyNode: defs.Content[i], node: defs.Content[i+1],
+				path: model.GetStringTemplates().BuildQuotedPath("$."+keyword, defs.Content[i].Value)}
+			ordered = append(ordered, ref)
+		}
+	}
+	if len(definitions) == 0 {
+		return results
+	}
+
+	// follow references from the root schema, ignoring the definitions themselves.
+	var queue []string
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		if !contains(definitionKeywords, root.Content[i].Value) {
+			queue = append(queue, collectRefs(root.Content[i+1])...)
+		}
+	}
+	reached := make(map[string]bool)
+	for len(queue) > 0 {
+		ref := definitionRef(queue[0], definitions)
+		queue = queue[1:]
+		if ref == "" || reached[ref] {
+			continue
+		}
+		reached[ref] = true
+		queue = append(queue, collectRefs(definitions[ref].node)...)
+	}
+
+	for _, ref := range ordered {
+		if reached[ref] {
+			continue
+		}
+		def := definitions[ref]
+		results = append(results, model.RuleFunctionResult{
+			Message: vacuumUtils.SuppliedOrDefault(context.Rule.Message,
+				fmt.Sprintf("definition `%s` cannot be reached from the root schema", def.keyNode.Value)),
+			StartNode: def.keyNode,
+			EndNode:   vacuumUtils.BuildEndNode(def.keyNode),
+			Path:      def.path,
+			Rule:      context.Rule,
+		})
+	}
+	return results
+}
+
+// collectRefs returns the value of every `$ref` under a node.
+func collectRefs(node *yaml.Node) []string {
+	var refs []string
+	if node == nil {
+		return refs
+	}
+	if utils.IsNodeMap(node) {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == "$ref" && node.Content[i+1].Kind == yaml.ScalarNode {
+				refs = append(refs, node.Content[i+1].Value)
+				continue
+			}
+			refs = append(refs, collectRefs(node.Content[i+1])...)
+		}
+		return refs
+	}
+	for _, child := range node.Content {
+		refs = append(refs, collectRefs(child)...)
+	}
+	return refs
+}
+
+// definitionRef returns the definition a reference points to (or into), or an empty string if it's not local
+// to a definition.
+func definitionRef(ref string, definitions map[string]definition) string {
+	if !strings.HasPrefix(ref, "#/") {
+		return "" // external references can't reach local definitions.
+	}
+	for candidate := ref; ; {
+		if _, ok := definitions[candidate]; ok {
+			return candidate
+		}
+		i := strings.LastIndex(candidate, "/")
+		if i <= 1 {
+			return ""
+		}
+		candidate = candidate[:i]
+	}
+}
+
+func escapePointer(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
@@ -0,0 +1,57 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// AdditionalOperations checks the additional operations of OpenAPI 3.2 path items, which must not be for a method
+// the path item has a field for (like GET or QUERY).
+type AdditionalOperations struct {
+}
+
+// GetSchema returns a model.RuleFunctionSchema defining the schema of the AdditionalOperations rule.
+func (ao AdditionalOperations) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "oasAdditionalOperations",
+	}
+}
+
+// GetCategory returns the category of the AdditionalOperations rule.
+func (ao AdditionalOperations) GetCategory() string {
+	return model.FunctionCategoryOpenAPI
+}
+
+// RunRule will execute the AdditionalOperations rule, based on supplied context and a supplied []*yaml.Node slice.
+func (ao AdditionalOperations) RunRule(_ []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+
+	var results []model.RuleFunctionResult
+
+	for _, op := range getOpenAPI32Operations(context) {
+		if op.Method == op.Path {
+			continue // the query operation.
+		}
+		field := strings.ToLower(op.Method)
+		if !utils.IsHttpVerb(field) && field != queryMethod {
+			continue
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message: vacuumUtils.SuppliedOrDefault(context.Rule.Message,
+				fmt.Sprintf("path `%s` has an additional `%s` operation, use the `%s` operation of the path item instead",
+					op.PathKey, op.Method, field)),
+			StartNode: op.KeyNode,
+			EndNode:   vacuumUtils.BuildEndNode(op.KeyNode),
+			Path:      op.JSONPath(),
+			Rule:      context.Rule,
+		})
+	}
+	return results
+}
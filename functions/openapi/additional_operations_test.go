@@ -0,0 +1,29 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAdditionalOperations_GetSchema(t *testing.T) {
+	def := AdditionalOperations{}
+	assert.Equal(t, "oasAdditionalOperations", def.GetSchema().Name)
+}
+
+func TestAdditionalOperations_RunRule(t *testing.T) {
+	def := AdditionalOperations{}
+	res := def.RunRule(nil, model.RuleFunctionContext{})
+	assert.Len(t, res, 0)
+}
+
+func TestAdditionalOperations_RunRule_Fail(t *testing.T) {
+	ctx, _ := buildOpenAPI32TestContext(t, openAPI32Spec)
+
+	res := AdditionalOperations{}.RunRule(nil, ctx)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "path `/songs` has an additional `Get` operation, use the `get` operation of the path item instead",
+		res[0].Message)
+	assert.Equal(t, "$.paths['/songs'].additionalOperations['Get']", res[0].Path)
+}
@@ -0,0 +1,99 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package openapi
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// queryMethod is the OpenAPI 3.2 operation for a safe request with a body, used for complex queries.
+const queryMethod = "query"
+
+// additionalOperationsField holds the operations of an OpenAPI 3.2 path item for other HTTP methods, by method.
+const additionalOperationsField = "additionalOperations"
+
+// pathItemOperation is an operation of a path item found in the YAML, along with its path from the path item,
+// like 'get', 'query' or "additionalOperations['LINK']".
+type pathItemOperation struct {
+	Method  string
+	Path    string
+	KeyNode *yaml.Node
+	Node    *yaml.Node
+}
+
+// documentOperation is an operation of a path item, along with the path it is for.
+type documentOperation struct {
+	pathItemOperation
+	PathKey string
+}
+
+// JSONPath returns the JSON path of the operation in the document.
+func (o documentOperation) JSONPath() string {
+	return fmt.Sprintf("$.paths['%s'].%s", o.PathKey, o.Path)
+}
+
+// isOpenAPI32 returns true if rules run against an OpenAPI 3.2 document.
+func isOpenAPI32(context model.RuleFunctionContext) bool {
+	return context.SpecInfo != nil && context.SpecInfo.SpecFormat == model.OAS32
+}
+
+// getPathItemOperations returns the operations of a path item, for every HTTP verb document models know, and (for
+// OpenAPI 3.2 documents) the query operation and every additional operation.
+func getPathItemOperations(pathItem *yaml.Node, openAPI32 bool) []pathItemOperation {
+	var operations []pathItemOperation
+	if !utils.IsNodeMap(pathItem) {
+		return nil
+	}
+	for i := 0; i+1 < len(pathItem.Content); i += 2 {
+		key, value := pathItem.Content[i], pathItem.Content[i+1]
+		switch {
+		case utils.IsHttpVerb(key.Value):
+			operations = append(operations, pathItemOperation{Method: key.Value, Path: key.Value, KeyNode: key, Node: value})
+		case openAPI32 && key.Value == queryMethod:
+			operations = append(operations, pathItemOperation{Method: key.Value, Path: key.Value, KeyNode: key, Node: value})
+		case openAPI32 && key.Value == additionalOperationsField && utils.IsNodeMap(value):
+			for j := 0; j+1 < len(value.Content); j += 2 {
+				method := value.Content[j]
+				operations = append(operations, pathItemOperation{
+					Method:  method.Value,
+					Path:    fmt.Sprintf("%s['%s']", additionalOperationsField, method.Value),
+					KeyNode: method,
+					Node:    value.Content[j+1],
+				})
+			}
+		}
+	}
+	return operations
+}
+
+// getOpenAPI32Operations returns the operations of an OpenAPI 3.2 document that document models (and the index)
+// don't have yet: the query operation of each path item, and every additional operation. Nothing is returned for
+// other documents.
+func getOpenAPI32Operations(context model.RuleFunctionContext) []documentOperation {
+	if !isOpenAPI32(context) || context.Index == nil || context.Index.GetRootNode() == nil {
+		return nil
+	}
+	root := context.Index.GetRootNode()
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	_, paths := utils.FindKeyNodeTop("paths", root.Content)
+	if !utils.IsNodeMap(paths) {
+		return nil
+	}
+	var operations []documentOperation
+	for i := 0; i+1 < len(paths.Content); i += 2 {
+		for _, op := range getPathItemOperations(paths.Content[i+1], true) {
+			if utils.IsHttpVerb(op.Method) && op.Path == op.Method {
+				continue // document models already have these.
+			}
+			operations = append(operations, documentOperation{pathItemOperation: op, PathKey: paths.Content[i].Value})
+		}
+	}
+	return operations
+}
@@ -0,0 +1,120 @@
+package openapi
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	drModel "github.com/pb33f/doctor/model"
+	"github.com/pb33f/libopenapi"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var openAPI32Spec = `openapi: 3.2.0
+components:
+  securitySchemes:
+    apiKey:
+      type: apiKey
+      in: header
+      name: X-API-Key
+paths:
+  /songs:
+    get:
+      operationId: listSongs
+      responses:
+        "200":
+          description: ok
+    query:
+      security:
+        - apiKey: []
+        - "#/components/securitySchemes/apiKey": []
+        - "#/components/securitySchemes/missing": []
+        - "https://example.com/schemes.yaml#/components/securitySchemes/oauth": []
+      responses:
+        "500":
+          description: nope
+    additionalOperations:
+      LINK:
+        operationId: listSongs
+        responses:
+          "204":
+            description: linked
+      Get:
+        operationId: getSongs
+        responses:
+          "200":
+            description: ok`
+
+// buildOpenAPI32TestContext builds the models of an OpenAPI 3.2 document the way the motor does, as a 3.1
+// document, and then puts the 3.2 format back.
+func buildOpenAPI32TestContext(t *testing.T, yml string) (model.RuleFunctionContext, *yaml.Node) {
+	document, err := libopenapi.NewDocument([]byte(yml))
+	assert.NoError(t, err)
+	info := document.GetSpecInfo()
+	assert.Equal(t, model.OAS32, info.SpecFormat)
+
+	info.SpecFormat = model.OAS31
+	m, errs := document.BuildV3Model()
+	info.SpecFormat = model.OAS32
+	assert.Empty(t, errs)
+
+	rule := buildOpenApiTestRuleAction("$", "oasOpId", "responses", nil)
+	ctx := buildOpenApiTestContext(model.CastToRuleAction(rule.Then), nil)
+	ctx.Rule = &rule
+	ctx.Document = document
+	ctx.DrDocument = drModel.NewDrDocument(m)
+	ctx.SpecInfo = info
+	ctx.Index = index.NewSpecIndexWithConfig(info.RootNode, index.CreateOpenAPIIndexConfig())
+	return ctx, info.RootNode.Content[0]
+}
+
+func TestGetOpenAPI32Operations(t *testing.T) {
+	ctx, _ := buildOpenAPI32TestContext(t, openAPI32Spec)
+
+	ops := getOpenAPI32Operations(ctx)
+	assert.Len(t, ops, 3)
+	assert.Equal(t, "$.paths['/songs'].query", ops[0].JSONPath())
+	assert.Equal(t, "$.paths['/songs'].additionalOperations['LINK']", ops[1].JSONPath())
+	assert.Equal(t, "Get", ops[2].Method)
+
+	// nothing for other versions.
+	ctx.SpecInfo.SpecFormat = model.OAS31
+	assert.Empty(t, getOpenAPI32Operations(ctx))
+}
+
+func TestOperationId_RunRule_OpenAPI32(t *testing.T) {
+	ctx, _ := buildOpenAPI32TestContext(t, openAPI32Spec)
+
+	res := OperationId{}.RunRule(nil, ctx)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "the `QUERY` operation does not contain an `operationId`", res[0].Message)
+	assert.Equal(t, "$.paths['/songs'].query", res[0].Path)
+}
+
+func TestUniqueOperationId_RunRule_OpenAPI32(t *testing.T) {
+	ctx, root := buildOpenAPI32TestContext(t, openAPI32Spec)
+
+	res := UniqueOperationId{}.RunRule([]*yaml.Node{root}, ctx)
+	var paths []string
+	for _, r := range res {
+		paths = append(paths, r.Path)
+	}
+	assert.Contains(t, paths, "$.paths['/songs'].additionalOperations['LINK']")
+}
+
+func TestSuccessResponse_RunRule_OpenAPI32(t *testing.T) {
+	ctx, root := buildOpenAPI32TestContext(t, openAPI32Spec)
+
+	res := SuccessResponse{}.RunRule([]*yaml.Node{root}, ctx)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths['/songs'].query.responses", res[0].Path)
+}
+
+func TestOperationSecurityDefined_RunRule_OpenAPI32(t *testing.T) {
+	ctx, root := buildOpenAPI32TestContext(t, openAPI32Spec)
+
+	res := OperationSecurityDefined{}.RunRule([]*yaml.Node{root}, ctx)
+	assert.Len(t, res, 1)
+	assert.Contains(t, res[0].Message, "#/components/securitySchemes/missing")
+}
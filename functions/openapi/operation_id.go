@@ -8,6 +8,7 @@ import (
     "github.com/daveshanley/vacuum/model"
     vacuumUtils "github.com/daveshanley/vacuum/utils"
     "github.com/pb33f/doctor/model/high/v3"
+    "github.com/pb33f/libopenapi/utils"
     "gopkg.in/yaml.v3"
     "strings"
 )
@@ -63,5 +64,19 @@ func (oId OperationId) RunRule(nodes []*yaml.Node, context model.RuleFunctionCon
 			}
 		}
 	}
+
+	// the query operation and additional operations of OpenAPI 3.2 are not in the document model.
+	for _, op := range getOpenAPI32Operations(context) {
+		if _, id := utils.FindKeyNodeTop("operationId", op.Node.Content); id == nil || id.Value == "" {
+			results = append(results, model.RuleFunctionResult{
+				Message: vacuumUtils.SuppliedOrDefault(context.Rule.Message, fmt.Sprintf("the `%s` operation does not contain an `operationId`",
+					strings.ToUpper(op.Method))),
+				StartNode: op.KeyNode,
+				EndNode:   vacuumUtils.BuildEndNode(op.KeyNode),
+				Path:      op.JSONPath(),
+				Rule:      context.Rule,
+			})
+		}
+	}
 	return results
 }
@@ -10,6 +10,7 @@ import (
 	"github.com/pb33f/libopenapi/index"
 	"github.com/pb33f/libopenapi/utils"
 	"gopkg.in/yaml.v3"
+	"strings"
 )
 
 // OperationSecurityDefined is a rule that checks operation security against defined global schemes.
@@ -56,6 +57,14 @@ func (osd OperationSecurityDefined) RunRule(nodes []*yaml.Node, context model.Ru
 		}
 	}
 
+	// the query operation and additional operations of OpenAPI 3.2 are not in the index.
+	for _, op := range getOpenAPI32Operations(context) {
+		if _, securityNode := utils.FindKeyNode("security", op.Node.Content); securityNode != nil {
+			results = osd.checkSecurityNode(securityNode, securityDefinitions, results,
+				op.JSONPath(), op.Node, context)
+		}
+	}
+
 	// look through root security if it has been set.
 	rootSecurity := context.Index.GetRootSecurityNode()
 	if rootSecurity != nil {
@@ -82,7 +91,7 @@ func (osd OperationSecurityDefined) checkSecurityNode(securityNode *yaml.Node,
 
 				// lookup in security definitions
 				lookup := fmt.Sprintf("#/components/securitySchemes/%s", name.Value)
-				if securityDefinitions[lookup] == nil {
+				if securityDefinitions[lookup] == nil && !securitySchemeURIDefined(name.Value, securityDefinitions, context) {
 
 					results = append(results, model.RuleFunctionResult{
 						Message: fmt.Sprintf("Security definition points a non-existent "+
@@ -98,3 +107,16 @@ func (osd OperationSecurityDefined) checkSecurityNode(securityNode *yaml.Node,
 	}
 	return results
 }
+
+// securitySchemeURIDefined returns true if an OpenAPI 3.2 security requirement refers to a security scheme by URI,
+// instead of by name. A reference to a component of the document must exist, other URIs can't be checked.
+func securitySchemeURIDefined(name string, securityDefinitions map[string]*index.Reference,
+	context model.RuleFunctionContext) bool {
+	if !isOpenAPI32(context) || !strings.ContainsAny(name, "#/:") {
+		return false
+	}
+	if strings.HasPrefix(name, "#/") {
+		return securityDefinitions[name] != nil
+	}
+	return true
+}
@@ -53,13 +53,10 @@ func (sr SuccessResponse) RunRule(nodes []*yaml.Node, context model.RuleFunction
 			}
 			if utils.IsNodeMap(operationNode) {
 
-				for h, verbMapNode := range operationNode.Content {
-					if utils.IsNodeStringValue(verbMapNode) && utils.IsHttpVerb(verbMapNode.Value) {
-						currentVerb = verbMapNode.Value
-					} else {
-						continue
-					}
-					verbDataNode := operationNode.Content[h+1]
+				// OpenAPI 3.2 adds the query operation, and additional operations for any other method.
+				for _, operation := range getPathItemOperations(operationNode, isOpenAPI32(context)) {
+					currentVerb = operation.Path
+					verbDataNode := operation.Node
 
 					fieldNode, valNode := utils.FindKeyNodeTop(context.RuleAction.Field, verbDataNode.Content)
 
@@ -62,5 +62,25 @@ func (oId UniqueOperationId) RunRule(nodes []*yaml.Node, context model.RuleFunct
 			}
 		}
 	}
+
+	// the query operation and additional operations of OpenAPI 3.2 are not in the index.
+	for _, op := range getOpenAPI32Operations(context) {
+		_, operationId := utils.FindKeyNode("operationId", op.Node.Content)
+		if operationId == nil {
+			continue
+		}
+		if seenIds[operationId.Value] {
+			results = append(results, model.RuleFunctionResult{
+				Message: fmt.Sprintf("the '%s' operation at path '%s' contains a "+
+					"duplicate operationId '%s'", op.Method, op.PathKey, operationId.Value),
+				StartNode: op.KeyNode,
+				EndNode:   vacuumUtils.BuildEndNode(op.KeyNode),
+				Path:      op.JSONPath(),
+				Rule:      context.Rule,
+			})
+		} else {
+			seenIds[operationId.Value] = true
+		}
+	}
 	return results
 }
@@ -11,11 +11,11 @@ import (
 	html_format "github.com/alecthomas/chroma/v2/formatters/html"
 	"github.com/alecthomas/chroma/v2/lexers"
 	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/daveshanley/vacuum/i18n"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/index"
-	"github.com/pb33f/libopenapi/utils"
 	"github.com/pterm/pterm"
 	"sort"
 	"strings"
@@ -63,6 +63,7 @@ type ReportData struct {
 	Version          string                    `json:"version"`
 	DisableTimestamp bool                      `json:"-"`
 	SpecString       []string                  `json:"-"`
+	Theme            *RenderedTheme            `json:"-"`
 }
 
 func NewHTMLReport(
@@ -71,7 +72,19 @@ func NewHTMLReport(
 	results *model.RuleResultSet,
 	stats *reports.ReportStatistics,
 	disableTimestamp bool) HTMLReport {
-	return &htmlReport{index, info, results, stats, disableTimestamp, false}
+	return NewThemedHTMLReport(index, info, results, stats, disableTimestamp, nil)
+}
+
+// NewThemedHTMLReport creates an HTML report branded with a theme (title, logo, colors and footer), a nil theme
+// renders the default vacuum report.
+func NewThemedHTMLReport(
+	index *index.SpecIndex,
+	info *datamodel.SpecInfo,
+	results *model.RuleResultSet,
+	stats *reports.ReportStatistics,
+	disableTimestamp bool,
+	theme *Theme) HTMLReport {
+	return &htmlReport{index, info, results, stats, disableTimestamp, false, theme}
 }
 
 type htmlReport struct {
@@ -81,6 +94,7 @@ type htmlReport struct {
 	stats            *reports.ReportStatistics
 	disableTimestamp bool
 	disableSnippets  bool // disable code snippets in the report if the spec is on a single line
+	theme            *Theme
 }
 
 func (html htmlReport) GenerateReport(test bool, version string) []byte {
@@ -126,6 +140,17 @@ func (html htmlReport) GenerateReport(test bool, version string) []byte {
 			})
 			return results
 		},
+		"t":  i18n.T,
+		"tf": i18n.Tf,
+		"locale": func() string {
+			if c := i18n.GetCatalog(); c != nil {
+				return c.Locale
+			}
+			return i18n.DefaultLocale
+		},
+		"join": func(values []string) string {
+			return strings.Join(values, ", ")
+		},
 		"timeGenerated": func(t time.Time) string {
 			return t.Format("02 Jan 2006 15:04:05 MST")
 		},
@@ -159,23 +184,31 @@ func (html htmlReport) GenerateReport(test bool, version string) []byte {
 			return ""
 		},
 		"renderSource": func(r *model.RuleFunctionResult, specData []string) string {
+			if html.disableSnippets {
+				return "code snippets disabled due to single line spec"
+			}
+			frame := r.CodeFrame
+			if frame == nil && r.StartNode != nil {
+				start, end := highlightedLines(r)
+				frame = model.NewCodeFrame(specData, start, r.StartNode.Column, end, model.DefaultCodeFrameOptions)
+			}
+			if frame == nil {
+				return ""
+			}
 
 			// let's go chroma!
 			lexer := lexers.Get("yaml")
 			lexer = chroma.Coalesce(lexer)
 
 			style := styles.Get("swapoff")
-			iterator, _ := lexer.Tokenise(nil, html.renderCodeSnippetForResult(r, specData, 3, 3))
+			iterator, _ := lexer.Tokenise(nil, frame.Source()+"\n")
 			b := new(strings.Builder)
 
-			l := r.StartNode.Line
-			lineRange := [][2]int{{l, l}}
-
 			formatter := html_format.New(
 				html_format.WithClasses(true),
 				html_format.WithLineNumbers(true),
-				html_format.BaseLineNumber(r.StartNode.Line-2),
-				html_format.HighlightLines(lineRange))
+				html_format.BaseLineNumber(frame.StartLine),
+				html_format.HighlightLines([][2]int{{frame.Line, frame.EndLine}}))
 			err := formatter.Format(b, style, iterator)
 
 			if err != nil {
@@ -199,10 +232,9 @@ func (html htmlReport) GenerateReport(test bool, version string) []byte {
 	var byteBuf bytes.Buffer
 
 	// we need a new category here 'all'
-	cats := model.RuleCategoriesOrdered
-
 	var catsFiltered []*model.RuleCategory
 	if html.results != nil {
+		cats := html.results.GetRuleCategories()
 		for i := range cats {
 			res := html.results.GetResultsByRuleCategory(cats[i].Id)
 			if len(res) >= 1 {
@@ -212,6 +244,10 @@ func (html htmlReport) GenerateReport(test bool, version string) []byte {
 	}
 	n := []*model.RuleCategory{model.RuleCategories[model.CategoryAll]}
 	catsFiltered = append(n, catsFiltered...)
+	catalog := i18n.GetCatalog()
+	for i := range catsFiltered {
+		catsFiltered[i] = catalog.Category(catsFiltered[i])
+	}
 
 	var specStringData []string
 
@@ -239,6 +275,7 @@ func (html htmlReport) GenerateReport(test bool, version string) []byte {
 		MaxViolations:  MaxViolations,
 		SpecString:     specStringData,
 		Version:        version,
+		Theme:          html.theme.render(),
 	}
 	if html.info != nil {
 		reportData.Generated = html.info.Generated
@@ -254,9 +291,15 @@ func (html htmlReport) GenerateReport(test bool, version string) []byte {
 	return byteBuf.Bytes()
 }
 
-func (html htmlReport) renderCodeSnippetForResult(r *model.RuleFunctionResult, specData []string, before, after int) string {
-	if html.disableSnippets {
-		return "code snippets disabled due to single line spec"
+// maxHighlightedLines is the most lines of a result highlighted in its code snippet, the snippet is cut off there.
+const maxHighlightedLines = 10
+
+// highlightedLines returns the first and last line a result covers in its code snippet, from its start to its end.
+func highlightedLines(r *model.RuleFunctionResult) (int, int) {
+	start := r.StartNode.Line
+	end := start
+	if r.EndNode != nil && r.EndNode.Line > start {
+		end = min(r.EndNode.Line, start+maxHighlightedLines-1)
 	}
-	return utils.RenderCodeSnippet(r.StartNode, specData, before, after)
+	return start, end
 }
@@ -1,11 +1,14 @@
 package html_report
 
 import (
+	"github.com/daveshanley/vacuum/i18n"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/daveshanley/vacuum/motor"
 	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/daveshanley/vacuum/statistics"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 	"os"
 	"testing"
 )
@@ -55,3 +58,80 @@ func TestNewHTMLReport_FullRender_Stripe(t *testing.T) {
 	assert.True(t, len(generated) > 0)
 
 }
+
+func TestNewHTMLReport_DocumentationURL(t *testing.T) {
+
+	specBytes, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+	selectedRS := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+
+	ruleset := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet: selectedRS,
+		Spec:    specBytes,
+	})
+	resultSet := model.NewRuleResultSet(ruleset.Results)
+	stats := statistics.CreateReportStatistics(ruleset.Index, ruleset.SpecInfo, resultSet)
+
+	report := NewHTMLReport(ruleset.Index, ruleset.SpecInfo, resultSet, stats, false)
+	generated := string(report.GenerateReport(false, ""))
+	assert.Contains(t, generated, `documentationUrl="https://quobix.com/vacuum/rules/examples/oas3-missing-example"`)
+}
+
+func TestNewHTMLReport_SecuritySummary(t *testing.T) {
+
+	specBytes, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	ruleset := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet: rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet(),
+		Spec:    specBytes,
+	})
+	resultSet := model.NewRuleResultSet(ruleset.Results)
+	stats := statistics.CreateReportStatistics(ruleset.Index, ruleset.SpecInfo, resultSet)
+	stats.SecuritySummary = &reports.SecuritySummary{
+		Findings: 2,
+		OWASP:    []*reports.SecurityGroup{{Id: "API2:2023", Name: "Broken Authentication", Findings: 2, Rules: []string{"a", "b"}}},
+	}
+
+	generated := string(NewHTMLReport(ruleset.Index, ruleset.SpecInfo, resultSet, stats, false).GenerateReport(false, ""))
+	assert.Contains(t, generated, `id="security-summary"`)
+	assert.Contains(t, generated, "API2:2023 Broken Authentication")
+	assert.Contains(t, generated, "<td>a, b</td>")
+	assert.NotContains(t, generated, "security-summary-cwe")
+}
+
+func TestNewHTMLReport_Localized(t *testing.T) {
+	t.Cleanup(func() { i18n.SetCatalog(nil) })
+	assert.NoError(t, i18n.SetLocale("es"))
+
+	specBytes, _ := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+
+	ruleset := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet: rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet(),
+		Spec:    specBytes,
+	})
+	resultSet := model.NewRuleResultSet(ruleset.Results)
+	stats := statistics.CreateReportStatistics(ruleset.Index, ruleset.SpecInfo, resultSet)
+	stats.SecuritySummary = &reports.SecuritySummary{Findings: 2}
+
+	generated := string(NewHTMLReport(ruleset.Index, ruleset.SpecInfo, resultSet, stats, false).GenerateReport(false, ""))
+	assert.Contains(t, generated, `<html lang="es"`)
+	assert.Contains(t, generated, "label='Calidad'")
+	assert.Contains(t, generated, "<h2>Hallazgos de seguridad</h2>")
+	assert.Contains(t, generated, "2 hallazgos de reglas de seguridad")
+	assert.Contains(t, generated, "Todas las categorías")
+}
+
+func TestHighlightedLines(t *testing.T) {
+	r := &model.RuleFunctionResult{StartNode: &yaml.Node{Line: 5}, EndNode: &yaml.Node{Line: 8, Column: 4}}
+	start, end := highlightedLines(r)
+	assert.Equal(t, 5, start)
+	assert.Equal(t, 8, end)
+
+	r.EndNode = nil
+	_, end = highlightedLines(r)
+	assert.Equal(t, 5, end)
+
+	// long results are cut off.
+	r.EndNode = &yaml.Node{Line: 80, Column: 2}
+	_, end = highlightedLines(r)
+	assert.Equal(t, 14, end)
+}
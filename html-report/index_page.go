@@ -0,0 +1,56 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package html_report
+
+import (
+	"bytes"
+	_ "embed"
+	"html"
+	"text/template"
+	"time"
+)
+
+//go:embed templates/index.gohtml
+var indexTemplate string
+
+// IndexDocument is a single specification listed on the index page of a report bundle.
+type IndexDocument struct {
+	Name     string // the specification that was linted.
+	Link     string // the report for the specification, relative to the index page.
+	Score    int
+	Errors   int
+	Warnings int
+	Info     int
+}
+
+type indexData struct {
+	Documents        []*IndexDocument
+	Theme            *RenderedTheme
+	Generated        time.Time
+	DisableTimestamp bool
+	Version          string
+}
+
+// BuildIndexPage renders the index page of a report bundle, listing every document with its score and number of
+// issues by severity, linking to the report for each document. The page is branded with the same theme as the
+// reports, a nil theme renders the default.
+func BuildIndexPage(documents []*IndexDocument, theme *Theme, generated time.Time, disableTimestamp bool,
+	version string) ([]byte, error) {
+	tmpl, err := template.New("index").Funcs(template.FuncMap{"escape": html.EscapeString}).Parse(indexTemplate)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	err = tmpl.Execute(&buf, &indexData{
+		Documents:        documents,
+		Theme:            theme.render(),
+		Generated:        generated,
+		DisableTimestamp: disableTimestamp,
+		Version:          version,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
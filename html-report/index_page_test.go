@@ -0,0 +1,35 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package html_report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildIndexPage(t *testing.T) {
+	docs := []*IndexDocument{
+		{Name: "<petstore>.yaml", Link: "1-petstore.html", Score: 95, Errors: 0, Warnings: 2, Info: 1},
+		{Name: "burgershop.yaml", Link: "2-burgershop.html", Score: 40, Errors: 7, Warnings: 3},
+	}
+	data, err := BuildIndexPage(docs, &Theme{Title: "Acme APIs", Mode: ThemeModeLight}, time.Now(), true, "1.0.0")
+	assert.NoError(t, err)
+
+	page := string(data)
+	assert.Contains(t, page, "&lt;petstore&gt;.yaml")
+	assert.Contains(t, page, `href="2-burgershop.html"`)
+	assert.Contains(t, page, "Acme APIs")
+	assert.Contains(t, page, `class="sl-theme-light"`)
+	assert.Contains(t, page, `<td class="number error">7</td>`)
+	assert.NotContains(t, page, "generated:")
+}
+
+func TestBuildIndexPage_NoTheme(t *testing.T) {
+	data, err := BuildIndexPage(nil, nil, time.Now(), false, "1.0.0")
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "vacuum report")
+	assert.Contains(t, string(data), "0 specifications, generated:")
+}
@@ -0,0 +1,172 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package html_report
+
+import (
+	"encoding/base64"
+	"fmt"
+	"html"
+	"mime"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Theme modes, dark is the default. auto follows the preference of the browser.
+const (
+	ThemeModeDark  = "dark"
+	ThemeModeLight = "light"
+	ThemeModeAuto  = "auto"
+)
+
+// Theme brands an HTML report, so it can be published on internal portals. Everything is optional, anything left
+// empty renders the way vacuum always has.
+type Theme struct {
+	Title          string `json:"title,omitempty" yaml:"title,omitempty"`                   // replaces 'vacuum report'.
+	Logo           string `json:"logo,omitempty" yaml:"logo,omitempty"`                     // URL, data URI, base64 or a path to an image.
+	PrimaryColor   string `json:"primaryColor,omitempty" yaml:"primaryColor,omitempty"`     // like '#62c4ff'
+	SecondaryColor string `json:"secondaryColor,omitempty" yaml:"secondaryColor,omitempty"` // like '#f83aff'
+	FooterText     string `json:"footerText,omitempty" yaml:"footerText,omitempty"`
+	Mode           string `json:"mode,omitempty" yaml:"mode,omitempty"` // dark, light or auto.
+}
+
+// RenderedTheme is the CSS and markup injected into a report for a theme, escaped and ready to render.
+type RenderedTheme struct {
+	PageTitle  string
+	Title      string
+	LogoSrc    string
+	FooterText string
+	HTMLClass  string
+	CSS        string
+}
+
+var cssColor = regexp.MustCompile(`^(#[0-9a-fA-F]{3,8}|[a-zA-Z]+|rgba?\([0-9\s.,%]+\))$`)
+var hexColor = regexp.MustCompile(`^#([0-9a-fA-F]{6})$`)
+
+// lightModeCSS overrides the dark colors of the report.
+const lightModeCSS = `--background-color: #ffffff;
+--background-color-with-opacity: rgba(255, 255, 255, 0.85);
+--font-color: #1f2328;
+--invert-font-color: #ffffff;
+--tertiary-color: #57606a;
+--code-bg-color: #f6f8fa;
+--progress-bar-background: #d0d7de;
+--hrcolor: #d0d7de;
+--code-border: #d0d7de;
+--card-bgcolor: rgba(175, 184, 193, 0.1);
+--card-bordercolor: rgba(175, 184, 193, 0.4);
+`
+
+// LoadTheme reads a theme from a YAML or JSON file.
+func LoadTheme(path string) (*Theme, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read theme '%s': %w", path, err)
+	}
+	var theme Theme
+	if err = yaml.Unmarshal(data, &theme); err != nil {
+		return nil, fmt.Errorf("theme '%s' is invalid: %w", path, err)
+	}
+	return &theme, theme.Validate()
+}
+
+// Validate checks the colors and mode of a theme, colors are injected into the report CSS so they must be colors.
+func (t *Theme) Validate() error {
+	for name, color := range map[string]string{"primary": t.PrimaryColor, "secondary": t.SecondaryColor} {
+		if color != "" && !cssColor.MatchString(color) {
+			return fmt.Errorf("%s color '%s' is not a valid color, use a hex, rgb() or named color", name, color)
+		}
+	}
+	switch t.Mode {
+	case "", ThemeModeDark, ThemeModeLight, ThemeModeAuto:
+		return nil
+	}
+	return fmt.Errorf("theme mode '%s' is not valid, use one of: dark, light, auto", t.Mode)
+}
+
+// render works out everything a theme injects into a report. Text is escaped, as the report is rendered with
+// text/template.
+func (t *Theme) render() *RenderedTheme {
+	rendered := &RenderedTheme{PageTitle: "vacuum HTML Report", Title: "vacuum report", HTMLClass: "sl-theme-dark"}
+	if t == nil {
+		return rendered
+	}
+	if t.Title != "" {
+		rendered.Title = html.EscapeString(t.Title)
+		rendered.PageTitle = rendered.Title
+	}
+	rendered.FooterText = html.EscapeString(t.FooterText)
+	rendered.LogoSrc = html.EscapeString(logoSource(t.Logo))
+
+	var css strings.Builder
+	if rendered.LogoSrc != "" {
+		css.WriteString(".report-logo { max-height: 40px; vertical-align: middle; margin-right: 10px; }\n")
+	}
+	if rendered.FooterText != "" {
+		css.WriteString(".report-footer { text-align: center; color: var(--tertiary-color); padding: var(--global-padding); }\n")
+	}
+	var colors strings.Builder
+	if t.PrimaryColor != "" {
+		colors.WriteString(colorVariables(t.PrimaryColor, "--primary-color", "--progress-bar-fill"))
+	}
+	if t.SecondaryColor != "" {
+		colors.WriteString(colorVariables(t.SecondaryColor, "--secondary-color", "--bold-text"))
+	}
+	if colors.Len() > 0 {
+		css.WriteString(":root {\n" + colors.String() + "}\n")
+	}
+	rendered.CSS = css.String()
+	light := ":root {\n" + lightModeCSS + "}\nbody { background-color: #ffffff; }\n"
+	switch t.Mode {
+	case ThemeModeLight:
+		rendered.HTMLClass = "sl-theme-light"
+		rendered.CSS += light
+	case ThemeModeAuto:
+		rendered.CSS += "@media (prefers-color-scheme: light) {\n" + light + "}\n"
+	}
+	return rendered
+}
+
+// colorVariables sets CSS variables to a color, and the translucent versions of the color if it is a hex color.
+func colorVariables(color string, variables ...string) string {
+	var sb strings.Builder
+	var r, g, b int64
+	hex := hexColor.FindStringSubmatch(color)
+	if hex != nil {
+		r, _ = strconv.ParseInt(hex[1][0:2], 16, 0)
+		g, _ = strconv.ParseInt(hex[1][2:4], 16, 0)
+		b, _ = strconv.ParseInt(hex[1][4:6], 16, 0)
+	}
+	for _, v := range variables {
+		sb.WriteString(fmt.Sprintf("%s: %s;\n", v, color))
+		if hex != nil && strings.HasSuffix(v, "-color") {
+			sb.WriteString(fmt.Sprintf("%s-lowalpha: rgba(%d, %d, %d, 0.55);\n", v, r, g, b))
+			sb.WriteString(fmt.Sprintf("%s-x-lowalpha: rgba(%d, %d, %d, 0.2);\n", v, r, g, b))
+		}
+	}
+	return sb.String()
+}
+
+// logoSource returns the src of the logo image. URLs and data URIs are used as they are, a path to an image is
+// embedded into the report, anything else is considered to be a base64 encoded image.
+func logoSource(logo string) string {
+	switch {
+	case logo == "":
+		return ""
+	case strings.HasPrefix(logo, "http://"), strings.HasPrefix(logo, "https://"), strings.HasPrefix(logo, "data:"):
+		return logo
+	}
+	if data, err := os.ReadFile(logo); err == nil {
+		mimeType := mime.TypeByExtension(filepath.Ext(logo))
+		if mimeType == "" {
+			mimeType = "image/png"
+		}
+		return fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data))
+	}
+	return "data:image/png;base64," + logo
+}
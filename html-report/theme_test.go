@@ -0,0 +1,79 @@
+package html_report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTheme(t *testing.T) {
+	dir := t.TempDir()
+	themeFile := filepath.Join(dir, "theme.yaml")
+	assert.NoError(t, os.WriteFile(themeFile, []byte("title: Acme APIs\nprimaryColor: '#ff0000'\nmode: light\n"), 0664))
+
+	theme, err := LoadTheme(themeFile)
+	assert.NoError(t, err)
+	assert.Equal(t, &Theme{Title: "Acme APIs", PrimaryColor: "#ff0000", Mode: ThemeModeLight}, theme)
+
+	assert.NoError(t, os.WriteFile(themeFile, []byte("primaryColor: 'red; } body { display: none'\n"), 0664))
+	_, err = LoadTheme(themeFile)
+	assert.ErrorContains(t, err, "is not a valid color")
+
+	_, err = LoadTheme(filepath.Join(dir, "nope.yaml"))
+	assert.ErrorContains(t, err, "unable to read theme")
+
+	assert.ErrorContains(t, (&Theme{Mode: "neon"}).Validate(), "theme mode 'neon' is not valid")
+}
+
+func TestTheme_Render(t *testing.T) {
+	rendered := (*Theme)(nil).render()
+	assert.Equal(t, "vacuum report", rendered.Title)
+	assert.Equal(t, "sl-theme-dark", rendered.HTMLClass)
+	assert.Empty(t, rendered.CSS)
+
+	rendered = (&Theme{
+		Title:        "<Acme>",
+		PrimaryColor: "#ff8000",
+		FooterText:   "Internal use only",
+		Logo:         "https://acme.com/logo.png",
+		Mode:         ThemeModeAuto,
+	}).render()
+	assert.Equal(t, "&lt;Acme&gt;", rendered.Title)
+	assert.Equal(t, "https://acme.com/logo.png", rendered.LogoSrc)
+	assert.Contains(t, rendered.CSS, "--primary-color: #ff8000;")
+	assert.Contains(t, rendered.CSS, "--primary-color-lowalpha: rgba(255, 128, 0, 0.55);")
+	assert.Contains(t, rendered.CSS, "@media (prefers-color-scheme: light)")
+	assert.Equal(t, "sl-theme-dark", rendered.HTMLClass)
+}
+
+func TestLogoSource(t *testing.T) {
+	logo := filepath.Join(t.TempDir(), "logo.svg")
+	assert.NoError(t, os.WriteFile(logo, []byte("<svg/>"), 0664))
+	assert.Equal(t, "data:image/svg+xml;base64,PHN2Zy8+", logoSource(logo))
+	assert.Equal(t, "data:image/png;base64,iVBORw0KGgo=", logoSource("iVBORw0KGgo="))
+	assert.Equal(t, "data:image/gif;base64,R0lG", logoSource("data:image/gif;base64,R0lG"))
+	assert.Empty(t, logoSource(""))
+}
+
+func TestNewThemedHTMLReport(t *testing.T) {
+	spec := []byte("openapi: 3.1.0\ninfo:\n  title: pizza\n")
+	info := &datamodel.SpecInfo{SpecBytes: &spec}
+	stats := &reports.ReportStatistics{OverallScore: 90}
+	theme := &Theme{Title: "Acme APIs", FooterText: "Acme platform team", SecondaryColor: "#00ff00", Mode: ThemeModeLight}
+
+	generated := string(NewThemedHTMLReport(nil, info, model.NewRuleResultSet(nil), stats, true, theme).GenerateReport(true, ""))
+	assert.Contains(t, generated, "<title>Acme APIs</title>")
+	assert.Contains(t, generated, `class="sl-theme-light"`)
+	assert.Contains(t, generated, `<div class="report-footer">Acme platform team</div>`)
+	assert.Contains(t, generated, "--secondary-color: #00ff00;")
+
+	generated = string(NewHTMLReport(nil, info, model.NewRuleResultSet(nil), stats, true).GenerateReport(true, ""))
+	assert.Contains(t, generated, "<title>vacuum HTML Report</title>")
+	assert.False(t, strings.Contains(generated, "report-footer"))
+}
@@ -138,7 +138,7 @@
       </nav>
       <div class="code-render">
         <slot></slot>
-      </div>`}_violationClicked(){let e;this._renderedCode?e=this._renderedCode:(e=this._slottedChildren[0],this._renderedCode=e);const t={detail:{message:this.message,id:this.ruleId,startLine:this.startLine,startCol:this.startCol,endLine:this.endLine,endCol:this.endCol,path:this.path,category:this.category,howToFix:this.howToFix,violationId:this.violationId,renderedCode:e},bubbles:!0,composed:!0};this.dispatchEvent(new CustomEvent("violationSelected",t))}};Te.styles=Pe,Oe([xe({type:String})],Te.prototype,"message",void 0),Oe([xe({type:String})],Te.prototype,"category",void 0),Oe([xe({type:String})],Te.prototype,"ruleId",void 0),Oe([xe({type:Number})],Te.prototype,"startLine",void 0),Oe([xe({type:Number})],Te.prototype,"startCol",void 0),Oe([xe({type:Number})],Te.prototype,"endLine",void 0),Oe([xe({type:Number})],Te.prototype,"endCol",void 0),Oe([xe({type:String})],Te.prototype,"path",void 0),Oe([xe({type:String})],Te.prototype,"howToFix",void 0),Oe([xe({type:Boolean})],Te.prototype,"selected",void 0),Te=Oe([ye("category-rule-result")],Te);const Me=w`
+      </div>`}_violationClicked(){let e;this._renderedCode?e=this._renderedCode:(e=this._slottedChildren[0],this._renderedCode=e);const t={detail:{message:this.message,id:this.ruleId,startLine:this.startLine,startCol:this.startCol,endLine:this.endLine,endCol:this.endCol,path:this.path,category:this.category,howToFix:this.howToFix,documentationUrl:this.documentationUrl,violationId:this.violationId,renderedCode:e},bubbles:!0,composed:!0};this.dispatchEvent(new CustomEvent("violationSelected",t))}};Te.styles=Pe,Oe([xe({type:String})],Te.prototype,"message",void 0),Oe([xe({type:String})],Te.prototype,"category",void 0),Oe([xe({type:String})],Te.prototype,"ruleId",void 0),Oe([xe({type:Number})],Te.prototype,"startLine",void 0),Oe([xe({type:Number})],Te.prototype,"startCol",void 0),Oe([xe({type:Number})],Te.prototype,"endLine",void 0),Oe([xe({type:Number})],Te.prototype,"endCol",void 0),Oe([xe({type:String})],Te.prototype,"path",void 0),Oe([xe({type:String})],Te.prototype,"howToFix",void 0),Oe([xe({type:String})],Te.prototype,"documentationUrl",void 0),Oe([xe({type:Boolean})],Te.prototype,"selected",void 0),Te=Oe([ye("category-rule-result")],Te);const Me=w`
   .rule-icon {
     font-family: 'Arial';
     font-size: var(--sl-font-size-small);
@@ -865,7 +865,7 @@
         name="violation"
       ></slot>
       <slot name="details"></slot>
-    `}_violationSelectedListener(e){const t=this.shadowRoot.querySelectorAll("slot")[1].assignedElements({flatten:!0})[0];t.ruleId=e.detail.id,t.message=e.detail.message,t.code=e.detail.renderedCode,t.howToFix=e.detail.howToFix,t.category=e.detail.category,t.path=e.detail.path}};Ge=function(e,t,o,r){var i,a=arguments.length,n=a<3?t:null===r?r=Object.getOwnPropertyDescriptor(t,o):r;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)n=Reflect.decorate(e,t,o,r);else for(var l=e.length-1;l>=0;l--)(i=e[l])&&(n=(a<3?i(n):a>3?i(t,o,n):i(t,o))||n);return a>3&&n&&Object.defineProperty(t,o,n),n}([ye("result-grid")],Ge);const Ke=[Fe,w`
+    `}_violationSelectedListener(e){const t=this.shadowRoot.querySelectorAll("slot")[1].assignedElements({flatten:!0})[0];t.ruleId=e.detail.id,t.message=e.detail.message,t.code=e.detail.renderedCode,t.howToFix=e.detail.howToFix,t.documentationUrl=e.detail.documentationUrl,t.category=e.detail.category,t.path=e.detail.path}};Ge=function(e,t,o,r){var i,a=arguments.length,n=a<3?t:null===r?r=Object.getOwnPropertyDescriptor(t,o):r;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)n=Reflect.decorate(e,t,o,r);else for(var l=e.length-1;l>=0;l--)(i=e[l])&&(n=(a<3?i(n):a>3?i(t,o,n):i(t,o))||n);return a>3&&n&&Object.defineProperty(t,o,n),n}([ye("result-grid")],Ge);const Ke=[Fe,w`
     hr {
       border: 0;
       border-top: 1px dashed var(--secondary-color-lowalpha);
@@ -955,16 +955,13 @@
         <hr />
         <p class="violated">
           Learn more about:
-          <a
-            href="https://quobix.com/vacuum/rules/${this.category.toLowerCase()}/${this.ruleId.replace("$","").toLowerCase()}"
-            >${this.ruleId}</a
-          >
+          <a href="${this.documentationUrl}">${this.ruleId}</a>
         </p>
       `:Q`
         <section class="select-violation">
           <p>Please select a rule violation from a category.</p>
         </section>
-      `}get drawer(){return document.querySelector("violation-drawer")}show(){this._visible=!0,this.drawer.classList.add("drawer-active"),this.requestUpdate()}hide(){this._visible=!1,this.drawer.classList.remove("drawer-active"),this.requestUpdate()}};Je.styles=Ke,Ze([xe({type:Element})],Je.prototype,"code",void 0),Ze([xe({type:String})],Je.prototype,"message",void 0),Ze([xe({type:String})],Je.prototype,"path",void 0),Ze([xe({type:String})],Je.prototype,"category",void 0),Ze([xe({type:String})],Je.prototype,"ruleId",void 0),Ze([xe({type:String})],Je.prototype,"howToFix",void 0),Je=We=Ze([ye("violation-drawer")],Je);var Xe=function(e,t,o,r){var i,a=arguments.length,n=a<3?t:null===r?r=Object.getOwnPropertyDescriptor(t,o):r;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)n=Reflect.decorate(e,t,o,r);else for(var l=e.length-1;l>=0;l--)(i=e[l])&&(n=(a<3?i(n):a>3?i(t,o,n):i(t,o))||n);return a>3&&n&&Object.defineProperty(t,o,n),n};let Qe=class extends ke{static get styles(){return[w`
+      `}get drawer(){return document.querySelector("violation-drawer")}show(){this._visible=!0,this.drawer.classList.add("drawer-active"),this.requestUpdate()}hide(){this._visible=!1,this.drawer.classList.remove("drawer-active"),this.requestUpdate()}};Je.styles=Ke,Ze([xe({type:Element})],Je.prototype,"code",void 0),Ze([xe({type:String})],Je.prototype,"message",void 0),Ze([xe({type:String})],Je.prototype,"path",void 0),Ze([xe({type:String})],Je.prototype,"category",void 0),Ze([xe({type:String})],Je.prototype,"ruleId",void 0),Ze([xe({type:String})],Je.prototype,"howToFix",void 0),Ze([xe({type:String})],Je.prototype,"documentationUrl",void 0),Je=We=Ze([ye("violation-drawer")],Je);var Xe=function(e,t,o,r){var i,a=arguments.length,n=a<3?t:null===r?r=Object.getOwnPropertyDescriptor(t,o):r;if("object"==typeof Reflect&&"function"==typeof Reflect.decorate)n=Reflect.decorate(e,t,o,r);else for(var l=e.length-1;l>=0;l--)(i=e[l])&&(n=(a<3?i(n):a>3?i(t,o,n):i(t,o))||n);return a>3&&n&&Object.defineProperty(t,o,n),n};let Qe=class extends ke{static get styles(){return[w`
       span {
         display: block;
       }
@@ -25,6 +25,9 @@ export class ViolationDrawerComponent extends BaseComponent {
   @property({ type: String })
   howToFix: string;
 
+  @property({ type: String })
+  documentationUrl: string;
+
   private _visible: boolean;
 
   private static replaceTicks(message: string): TemplateResult[] {
@@ -61,12 +64,7 @@ export class ViolationDrawerComponent extends BaseComponent {
         <hr />
         <p class="violated">
           Learn more about:
-          <a
-            href="https://quobix.com/vacuum/rules/${this.category.toLowerCase()}/${this.ruleId
-              .replace('$', '')
-              .toLowerCase()}"
-            >${this.ruleId}</a
-          >
+          <a href="${this.documentationUrl}">${this.ruleId}</a>
         </p>
       `;
     } else {
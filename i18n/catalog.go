@@ -0,0 +1,226 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+// Package i18n translates rule messages and report titles. Translations are kept in catalogs, one YAML file per
+// locale (see catalogs/en.yaml), English is the default and is used for anything a catalog does not translate.
+package i18n
+
+import (
+	"embed"
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLocale is the locale of the messages in the source, and of the default catalog.
+const DefaultLocale = "en"
+
+//go:embed catalogs/*.yaml
+var catalogFiles embed.FS
+
+// Catalog is the translations for a single locale.
+type Catalog struct {
+	Locale     string                   `yaml:"locale"`
+	Name       string                   `yaml:"name"`
+	Text       map[string]string        `yaml:"text"`       // report titles and labels, by key.
+	Categories map[string]*CategoryText `yaml:"categories"` // rule categories, by id.
+	Rules      map[string]*RuleText     `yaml:"rules"`      // rule descriptions and fixes, by rule id.
+	Messages   []*MessageText           `yaml:"messages"`   // result messages.
+	patterns   []*messagePattern
+}
+
+// CategoryText translates a rule category.
+type CategoryText struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+}
+
+// RuleText translates the description, and how to fix a rule.
+type RuleText struct {
+	Description string `yaml:"description"`
+	HowToFix    string `yaml:"howToFix"`
+}
+
+// MessageText translates a result message. Match is the English message, with %s (or %d) in place of the parts that
+// change (like a path, or a name), Text is the translation, with the same parts in the same order (or reordered
+// with %[2]s).
+type MessageText struct {
+	Match string `yaml:"match"`
+	Text  string `yaml:"text"`
+}
+
+type messagePattern struct {
+	regex   *regexp.Regexp
+	numbers []bool // the parts that are numbers (%d).
+	text    string
+}
+
+var (
+	current *Catalog
+	mutex   sync.RWMutex
+)
+
+// Locales returns every locale there is a catalog for.
+func Locales() []string {
+	entries, _ := catalogFiles.ReadDir("catalogs")
+	var locales []string
+	for _, e := range entries {
+		locales = append(locales, strings.TrimSuffix(e.Name(), path.Ext(e.Name())))
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// NormalizeLocale turns a locale (like 'pt_BR.UTF-8', or 'pt-br') into the name of a catalog ('pt-BR').
+func NormalizeLocale(locale string) string {
+	locale, _, _ = strings.Cut(locale, ".")
+	locale, _, _ = strings.Cut(locale, "@")
+	lang, region, found := strings.Cut(strings.ReplaceAll(locale, "_", "-"), "-")
+	if !found {
+		return strings.ToLower(lang)
+	}
+	return strings.ToLower(lang) + "-" + strings.ToUpper(region)
+}
+
+// LoadCatalog returns the catalog for a locale. A catalog for a region ('pt-BR') falls back to the language ('pt'),
+// an error is returned if there is neither.
+func LoadCatalog(locale string) (*Catalog, error) {
+	locale = NormalizeLocale(locale)
+	candidates := []string{locale}
+	if lang, _, found := strings.Cut(locale, "-"); found {
+		candidates = append(candidates, lang)
+	}
+	for _, c := range candidates {
+		data, err := catalogFiles.ReadFile("catalogs/" + c + ".yaml")
+		if err != nil {
+			continue
+		}
+		return ParseCatalog(data)
+	}
+	return nil, fmt.Errorf("there is no catalog for locale '%s', available locales are %s", locale,
+		strings.Join(Locales(), ", "))
+}
+
+// ParseCatalog reads a catalog.
+func ParseCatalog(data []byte) (*Catalog, error) {
+	c := &Catalog{}
+	if err := yaml.Unmarshal(data, c); err != nil {
+		return nil, fmt.Errorf("unable to read catalog: %w", err)
+	}
+	for i, m := range c.Messages {
+		if m.Match == "" || m.Text == "" {
+			return nil, fmt.Errorf("catalog '%s' message %d needs a match and a text", c.Locale, i+1)
+		}
+		regex, numbers := compileMessagePattern(m.Match)
+		c.patterns = append(c.patterns, &messagePattern{regex: regex, numbers: numbers, text: m.Text})
+	}
+	return c, nil
+}
+
+// compileMessagePattern turns an English message, with %s and %d for the parts that change, into an expression
+// that captures those parts.
+func compileMessagePattern(match string) (*regexp.Regexp, []bool) {
+	var numbers []bool
+	var b strings.Builder
+	b.WriteString("^")
+	for len(match) > 0 {
+		i := strings.IndexByte(match, '%')
+		if i < 0 || i == len(match)-1 {
+			b.WriteString(regexp.QuoteMeta(match))
+			break
+		}
+		b.WriteString(regexp.QuoteMeta(match[:i]))
+		switch match[i+1] {
+		case 'd':
+			b.WriteString(`(-?\d+)`)
+			numbers = append(numbers, true)
+		case 's', 'v', 'q':
+			b.WriteString(`(.+?)`)
+			numbers = append(numbers, false)
+		case '%':
+			b.WriteString("%")
+		default:
+			b.WriteString(regexp.QuoteMeta(match[i : i+2]))
+		}
+		match = match[i+2:]
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String()), numbers
+}
+
+// SetLocale translates everything into a locale from now on, the default locale turns translation off.
+func SetLocale(locale string) error {
+	if locale == "" || NormalizeLocale(locale) == DefaultLocale {
+		SetCatalog(nil)
+		return nil
+	}
+	c, err := LoadCatalog(locale)
+	if err != nil {
+		return err
+	}
+	SetCatalog(c)
+	return nil
+}
+
+// SetCatalog translates everything with a catalog from now on, nil turns translation off.
+func SetCatalog(c *Catalog) {
+	mutex.Lock()
+	defer mutex.Unlock()
+	current = c
+}
+
+// GetCatalog returns the catalog in use, or nil if nothing is translated.
+func GetCatalog() *Catalog {
+	mutex.RLock()
+	defer mutex.RUnlock()
+	return current
+}
+
+// T returns the text for a key in the catalog in use, or the English text if it is not translated.
+func T(key, english string) string {
+	return GetCatalog().T(key, english)
+}
+
+// Tf formats the text for a key in the catalog in use (or the English text), like fmt.Sprintf.
+func Tf(key, english string, args ...any) string {
+	return fmt.Sprintf(T(key, english), args...)
+}
+
+// T returns the text for a key, or the English text if it is not translated.
+func (c *Catalog) T(key, english string) string {
+	if c == nil {
+		return english
+	}
+	if text, ok := c.Text[key]; ok && text != "" {
+		return text
+	}
+	return english
+}
+
+// Message translates a result message, it is returned untouched if no message of the catalog matches.
+func (c *Catalog) Message(message string) string {
+	if c == nil {
+		return message
+	}
+	for _, p := range c.patterns {
+		parts := p.regex.FindStringSubmatch(message)
+		if parts == nil {
+			continue
+		}
+		args := make([]any, len(parts)-1)
+		for i, part := range parts[1:] {
+			args[i] = part
+			if p.numbers[i] {
+				args[i], _ = strconv.Atoi(part)
+			}
+		}
+		return fmt.Sprintf(p.text, args...)
+	}
+	return message
+}
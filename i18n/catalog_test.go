@@ -0,0 +1,106 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizeLocale(t *testing.T) {
+	assert.Equal(t, "pt-BR", NormalizeLocale("pt_BR.UTF-8"))
+	assert.Equal(t, "pt-BR", NormalizeLocale("pt-br"))
+	assert.Equal(t, "es", NormalizeLocale("ES"))
+	assert.Equal(t, "de", NormalizeLocale("de@euro"))
+}
+
+func TestLocales(t *testing.T) {
+	assert.Equal(t, []string{"en", "es"}, Locales())
+}
+
+func TestLoadCatalog(t *testing.T) {
+	c, err := LoadCatalog("es")
+	assert.NoError(t, err)
+	assert.Equal(t, "es", c.Locale)
+	assert.Equal(t, "Español", c.Name)
+}
+
+func TestLoadCatalog_Region(t *testing.T) {
+	c, err := LoadCatalog("es_MX.UTF-8")
+	assert.NoError(t, err)
+	assert.Equal(t, "es", c.Locale)
+}
+
+func TestLoadCatalog_Unknown(t *testing.T) {
+	_, err := LoadCatalog("xx-YY")
+	assert.EqualError(t, err, "there is no catalog for locale 'xx-YY', available locales are en, es")
+}
+
+func TestParseCatalog_MessageWithoutText(t *testing.T) {
+	_, err := ParseCatalog([]byte("locale: xx\nmessages:\n  - match: 'a %s'\n"))
+	assert.EqualError(t, err, "catalog 'xx' message 1 needs a match and a text")
+}
+
+func TestCatalog_Message(t *testing.T) {
+	c, err := ParseCatalog([]byte(`locale: xx
+messages:
+  - match: "the '%s' operation has %d problems"
+    text: "%[2]d problemas en la operación '%[1]s'"
+  - match: "100%% (%s)"
+    text: "el 100%% (%s)"
+`))
+	assert.NoError(t, err)
+	assert.Equal(t, "3 problemas en la operación 'GET /pets'", c.Message("the 'GET /pets' operation has 3 problems"))
+	assert.Equal(t, "el 100% (done)", c.Message("100% (done)"))
+	assert.Equal(t, "something else", c.Message("something else"))
+	assert.Equal(t, "the 'a' operation has many problems", c.Message("the 'a' operation has many problems"))
+}
+
+func TestCatalog_Nil(t *testing.T) {
+	var c *Catalog
+	assert.Equal(t, "Errors", c.T("summary.errors", "Errors"))
+	assert.Equal(t, "a message", c.Message("a message"))
+}
+
+func TestSetLocale(t *testing.T) {
+	t.Cleanup(func() { SetCatalog(nil) })
+
+	assert.NoError(t, SetLocale("es"))
+	assert.Equal(t, "Errores", T("summary.errors", "Errors"))
+	assert.Equal(t, "'a.yaml' pasó, con 2 avisos", Tf("summary.filePassedWithInforms", "'%s' passed, %v informs reported", "a.yaml", 2))
+	// keys a catalog does not translate are English.
+	assert.Equal(t, "Nothing", T("nothing", "Nothing"))
+
+	assert.NoError(t, SetLocale("en"))
+	assert.Nil(t, GetCatalog())
+	assert.Equal(t, "Errors", T("summary.errors", "Errors"))
+
+	assert.Error(t, SetLocale("xx"))
+}
+
+// every catalog only translates keys the English catalog has, with the same number of verbs.
+func TestCatalogs_MatchEnglish(t *testing.T) {
+	en, err := LoadCatalog(DefaultLocale)
+	assert.NoError(t, err)
+	for _, locale := range Locales() {
+		c, cErr := LoadCatalog(locale)
+		assert.NoError(t, cErr)
+		for key, text := range c.Text {
+			english, ok := en.Text[key]
+			if assert.True(t, ok, "%s: unknown key '%s'", locale, key) {
+				assert.Equal(t, countVerbs(english), countVerbs(text), "%s: key '%s'", locale, key)
+			}
+		}
+		for id := range c.Categories {
+			_, ok := en.Categories[id]
+			assert.True(t, ok, "%s: unknown category '%s'", locale, id)
+		}
+		for _, m := range c.Messages {
+			assert.Equal(t, countVerbs(m.Match), countVerbs(m.Text), "%s: message '%s'", locale, m.Match)
+		}
+	}
+}
+
+func countVerbs(s string) int {
+	_, numbers := compileMessagePattern(s)
+	return len(numbers)
+}
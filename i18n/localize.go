@@ -0,0 +1,82 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package i18n
+
+import "github.com/daveshanley/vacuum/model"
+
+// LocalizeResults translates the messages of results, and the descriptions (and fixes) of their rules, with the
+// catalog in use. Rules are shared by rulesets, so results are given translated copies of their rules.
+func LocalizeResults(results []*model.RuleFunctionResult) {
+	GetCatalog().LocalizeResults(results)
+}
+
+// LocalizeResults translates the messages of results, and the descriptions (and fixes) of their rules.
+func (c *Catalog) LocalizeResults(results []*model.RuleFunctionResult) {
+	if c == nil {
+		return
+	}
+	rules := make(map[*model.Rule]*model.Rule)
+	for _, r := range results {
+		r.Message = c.Message(r.Message)
+		if r.Rule == nil {
+			continue
+		}
+		localized, ok := rules[r.Rule]
+		if !ok {
+			localized = c.Rule(r.Rule)
+			rules[r.Rule] = localized
+		}
+		r.Rule = localized
+	}
+}
+
+// Rule returns a copy of a rule with its description and fix translated, or the rule itself if the catalog does not
+// translate it.
+func (c *Catalog) Rule(rule *model.Rule) *model.Rule {
+	if c == nil || rule == nil {
+		return rule
+	}
+	text, ok := c.Rules[rule.Id]
+	category := c.Category(rule.RuleCategory)
+	if !ok && category == rule.RuleCategory {
+		return rule
+	}
+	localized := *rule
+	localized.RuleCategory = category
+	if ok && text.Description != "" {
+		localized.Description = text.Description
+	}
+	if ok && text.HowToFix != "" {
+		localized.HowToFix = text.HowToFix
+	}
+	return &localized
+}
+
+// CategoryName returns the name of a rule category in the catalog in use.
+func CategoryName(category *model.RuleCategory) string {
+	if category == nil {
+		return ""
+	}
+	return GetCatalog().Category(category).Name
+}
+
+// Category returns a copy of a rule category with its name and description translated, or the category itself if
+// the catalog does not translate it.
+func (c *Catalog) Category(category *model.RuleCategory) *model.RuleCategory {
+	if c == nil || category == nil {
+		return category
+	}
+	text, ok := c.Categories[category.Id]
+	if !ok {
+		return category
+	}
+	localized := *category
+	if text.Name != "" {
+		localized.Name = text.Name
+	}
+	if text.Description != "" {
+		localized.Description = text.Description
+	}
+	return &localized
+}
@@ -0,0 +1,56 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalizeResults(t *testing.T) {
+	c, err := LoadCatalog("es")
+	assert.NoError(t, err)
+
+	rule := &model.Rule{
+		Id:           "operation-operationId",
+		Description:  "Every operation must contain an `operationId`.",
+		HowToFix:     "Add an operationId.",
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+	}
+	results := []*model.RuleFunctionResult{
+		{Message: "the `GET` operation does not contain an `operationId`", Rule: rule},
+		{Message: "the `POST` operation does not contain an `operationId`", Rule: rule},
+		{Message: "not in the catalog"},
+	}
+	c.LocalizeResults(results)
+
+	assert.Equal(t, "la operación `GET` no tiene un `operationId`", results[0].Message)
+	assert.Equal(t, "la operación `POST` no tiene un `operationId`", results[1].Message)
+	assert.Equal(t, "not in the catalog", results[2].Message)
+
+	// results share a translated copy, the rule itself is untouched.
+	assert.Same(t, results[0].Rule, results[1].Rule)
+	assert.Equal(t, "Cada operación debe tener un `operationId`.", results[0].Rule.Description)
+	assert.Equal(t, "Add an operationId.", results[0].Rule.HowToFix)
+	assert.Equal(t, "Operaciones", results[0].Rule.RuleCategory.Name)
+	assert.Equal(t, model.CategoryOperations, results[0].Rule.RuleCategory.Id)
+	assert.Equal(t, "Every operation must contain an `operationId`.", rule.Description)
+	assert.Equal(t, "Operations", model.RuleCategories[model.CategoryOperations].Name)
+}
+
+func TestLocalizeResults_English(t *testing.T) {
+	SetCatalog(nil)
+	rule := &model.Rule{Id: "operation-operationId"}
+	results := []*model.RuleFunctionResult{{Message: "the `GET` operation does not contain an `operationId`", Rule: rule}}
+	LocalizeResults(results)
+	assert.Equal(t, "the `GET` operation does not contain an `operationId`", results[0].Message)
+	assert.Same(t, rule, results[0].Rule)
+}
+
+func TestCategoryName(t *testing.T) {
+	t.Cleanup(func() { SetCatalog(nil) })
+	assert.Equal(t, "Schemas", CategoryName(model.RuleCategories[model.CategorySchemas]))
+	assert.NoError(t, SetLocale("es"))
+	assert.Equal(t, "Esquemas", CategoryName(model.RuleCategories[model.CategorySchemas]))
+	assert.Equal(t, "", CategoryName(nil))
+}
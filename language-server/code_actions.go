@@ -0,0 +1,120 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+// https://pb33f.io
+
+package languageserver
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/motor"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+// codeActionKindSourceFixAll is not defined by the 3.16 protocol package.
+const codeActionKindSourceFixAll = protocol.CodeActionKind("source.fixAll")
+
+// codeActions returns a quick fix for every fixable result in a document that overlaps the range, each fix is
+// applied by the same engine as 'lint --fix' and returned as an edit. If more than one result in the document can
+// be fixed, an action to fix them all is added. Nothing is returned if the document has changed since it was
+// linted, as the results no longer line up with the content.
+func (s *ServerState) codeActions(doc *Document, rng protocol.Range) []protocol.CodeAction {
+	content, results := doc.getResults()
+	if content != doc.Content {
+		return nil
+	}
+	fileName := documentPath(doc.URI)
+
+	var fixable []model.RuleFunctionResult
+	var actions []protocol.CodeAction
+	for _, r := range results {
+		if r.Rule == nil || r.Rule.Fix == nil {
+			continue
+		}
+		fixable = append(fixable, r)
+		diagnostic := ConvertResultIntoDiagnostic(&r)
+		if !rangesOverlap(diagnostic.Range, rng) {
+			continue
+		}
+		edit := fixEdit(content, fileName, []model.RuleFunctionResult{r})
+		if edit == nil {
+			continue
+		}
+		title := r.Rule.FixTitle
+		if title == "" {
+			title = fmt.Sprintf("Fix '%s'", r.Rule.Id)
+		}
+		kind := protocol.CodeActionKindQuickFix
+		preferred := true
+		actions = append(actions, protocol.CodeAction{
+			Title:       fmt.Sprintf("%s (%s)", title, r.Rule.Id),
+			Kind:        &kind,
+			Diagnostics: []protocol.Diagnostic{diagnostic},
+			IsPreferred: &preferred,
+			Edit:        &protocol.WorkspaceEdit{Changes: map[protocol.DocumentUri][]protocol.TextEdit{doc.URI: {*edit}}},
+		})
+	}
+
+	if len(fixable) > 1 && len(actions) > 0 {
+		if edit := fixEdit(content, fileName, fixable); edit != nil {
+			kind := codeActionKindSourceFixAll
+			actions = append(actions, protocol.CodeAction{
+				Title: fmt.Sprintf("Fix all auto-fixable problems (%d)", len(fixable)),
+				Kind:  &kind,
+				Edit:  &protocol.WorkspaceEdit{Changes: map[protocol.DocumentUri][]protocol.TextEdit{doc.URI: {*edit}}},
+			})
+		}
+	}
+	return actions
+}
+
+// fixEdit applies the fixes for results to the content and returns the change as a single edit, nil is returned if
+// nothing could be fixed.
+func fixEdit(content, fileName string, results []model.RuleFunctionResult) *protocol.TextEdit {
+	fr, err := motor.ApplyFixes([]byte(content), fileName, results)
+	if err != nil || len(fr.Fixed) == 0 {
+		return nil
+	}
+	return minimalEdit(content, string(fr.Output))
+}
+
+// minimalEdit works out the smallest block of lines that changed between two versions of a document, so only the
+// lines touched by a fix are replaced, rather than the whole document.
+func minimalEdit(original, fixed string) *protocol.TextEdit {
+	a := strings.SplitAfter(original, "\n")
+	b := strings.SplitAfter(fixed, "\n")
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		suffix++
+	}
+	if prefix == len(a) && prefix == len(b) {
+		return nil
+	}
+	end := protocol.Position{Line: protocol.UInteger(len(a) - suffix)}
+	if len(a)-suffix == len(a) && !strings.HasSuffix(original, "\n") {
+		// the last line has no line break, so the edit ends at the end of it.
+		last := a[len(a)-1]
+		end = protocol.Position{Line: protocol.UInteger(len(a) - 1),
+			Character: protocol.UInteger(len(utf16.Encode([]rune(last))))}
+	}
+	return &protocol.TextEdit{
+		Range:   protocol.Range{Start: protocol.Position{Line: protocol.UInteger(prefix)}, End: end},
+		NewText: strings.Join(b[prefix:len(b)-suffix], ""),
+	}
+}
+
+// rangesOverlap returns true if two ranges share at least one position.
+func rangesOverlap(a, b protocol.Range) bool {
+	return !positionBefore(a.End, b.Start) && !positionBefore(b.End, a.Start)
+}
+
+func positionBefore(a, b protocol.Position) bool {
+	return a.Line < b.Line || (a.Line == b.Line && a.Character < b.Character)
+}
@@ -0,0 +1,86 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package languageserver
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+var fixableSpec = `openapi: 3.1.0
+info:
+  title: test
+  version: 1.0.0
+  description: a test
+paths:
+  /burgers:
+    get:
+      operationId: get burgers
+      tags: [a]
+      responses:
+        "200":
+          description: ok
+`
+
+func lintTestDocument(content string) *Document {
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	result := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{RuleSet: rs, Spec: []byte(content)})
+	doc := newDocumentStore().Add("file:///tmp/burgers.yaml", content)
+	doc.setResults(content, result.Results)
+	return doc
+}
+
+func TestServerState_CodeActions(t *testing.T) {
+	doc := lintTestDocument(fixableSpec)
+	s := &ServerState{}
+
+	// the operationId is on line 9 (8 when counting from zero).
+	line := protocol.Range{Start: protocol.Position{Line: 8}, End: protocol.Position{Line: 8, Character: 30}}
+	actions := s.codeActions(doc, line)
+	if assert.Len(t, actions, 2) {
+		assert.Equal(t, "Convert the operationId to camelCase (operation-operationId-valid-in-url)", actions[0].Title)
+		assert.Equal(t, protocol.CodeActionKindQuickFix, *actions[0].Kind)
+		assert.Len(t, actions[0].Diagnostics, 1)
+		edit := actions[0].Edit.Changes[doc.URI][0]
+		assert.Equal(t, protocol.UInteger(8), edit.Range.Start.Line)
+		assert.Equal(t, protocol.UInteger(9), edit.Range.End.Line)
+		assert.Equal(t, "      operationId: getBurgers\n", edit.NewText)
+
+		assert.Equal(t, codeActionKindSourceFixAll, *actions[1].Kind)
+		fixAll := actions[1].Edit.Changes[doc.URI][0].NewText
+		assert.Contains(t, fixAll, "getBurgers")
+		assert.Contains(t, fixAll, rulesets.OperationDescriptionPlaceholder)
+	}
+
+	// nothing to fix on the title.
+	assert.Empty(t, s.codeActions(doc, protocol.Range{Start: protocol.Position{Line: 2}, End: protocol.Position{Line: 2}}))
+
+	// the document has changed since it was linted.
+	doc.Content = strings.Replace(fixableSpec, "test", "changed", 1)
+	assert.Empty(t, s.codeActions(doc, line))
+}
+
+func TestMinimalEdit(t *testing.T) {
+	assert.Nil(t, minimalEdit("a\nb\n", "a\nb\n"))
+
+	edit := minimalEdit("a\nb\nc\n", "a\nB\nc\n")
+	assert.Equal(t, protocol.UInteger(1), edit.Range.Start.Line)
+	assert.Equal(t, protocol.UInteger(2), edit.Range.End.Line)
+	assert.Equal(t, "B\n", edit.NewText)
+
+	// no line break at the end of the document.
+	edit = minimalEdit("a\nb", "a\nbé\n")
+	assert.Equal(t, protocol.Position{Line: 1, Character: 1}, edit.Range.End)
+	assert.Equal(t, "bé\n", edit.NewText)
+}
+
+func TestDocumentPath(t *testing.T) {
+	assert.Equal(t, "/tmp/my spec.yaml", documentPath("file:///tmp/my%20spec.yaml"))
+	assert.Equal(t, "untitled:1", documentPath("untitled:1"))
+}
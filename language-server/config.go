@@ -2,8 +2,10 @@ package languageserver
 
 import (
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/plugin"
 	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/fsnotify/fsnotify"
@@ -88,6 +90,7 @@ func (s *ServerState) loadRulesetFromConfig(rulesetFlag string) {
 	if rsErr == nil {
 		userRS, userErr := rulesets.CreateRuleSetFromData(rsBytes)
 		if userErr == nil {
+			userRS.OverridesDir = filepath.Dir(rulesetFlag)
 			s.lintRequest.SelectedRS = s.lintRequest.DefaultRuleSets.GenerateRuleSetFromSuppliedRuleSet(userRS)
 		}
 	}
@@ -96,6 +99,7 @@ func (s *ServerState) loadRulesetFromConfig(rulesetFlag string) {
 func (s *ServerState) loadFunctionsFromConfig(functionsFlag string) {
 	pm, err := plugin.LoadFunctions(functionsFlag, true)
 	if err == nil {
+		plugin.CloseFunctions(s.lintRequest.Functions)
 		s.lintRequest.Functions = pm.GetCustomFunctions()
 	}
 }
@@ -116,11 +120,13 @@ func (s *ServerState) onConfigChange(e fsnotify.Event) {
 	defaultRuleSets := rulesets.BuildDefaultRuleSetsWithLogger(s.lintRequest.Logger)
 	selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
 	functions := s.lintRequest.Functions
+	var unloaded map[string]model.RuleFunction
 
 	// FUNCTIONS
 	if functionsFlag != "" {
 		pm, err := plugin.LoadFunctions(functionsFlag, true)
 		if err == nil {
+			unloaded = functions
 			functions = pm.GetCustomFunctions()
 		}
 	}
@@ -144,6 +150,7 @@ func (s *ServerState) onConfigChange(e fsnotify.Event) {
 			// load in our user supplied ruleset and try to validate it.
 			userRS, userErr := rulesets.CreateRuleSetFromData(rsBytes)
 			if userErr == nil {
+				userRS.OverridesDir = filepath.Dir(rulesetFlag)
 				selectedRS = defaultRuleSets.GenerateRuleSetFromSuppliedRuleSet(userRS)
 			}
 		}
@@ -155,6 +162,7 @@ func (s *ServerState) onConfigChange(e fsnotify.Event) {
 	s.lintRequest.DefaultRuleSets = defaultRuleSets
 	s.lintRequest.SelectedRS = selectedRS
 	s.lintRequest.Functions = functions
+	plugin.CloseFunctions(unloaded) // the functions that have been reloaded are unloaded.
 	s.lintRequest.TimeoutFlag = timeoutFlag
 	s.lintRequest.IgnoreArrayCircleRef = ignoreArrayCircleRef
 	s.lintRequest.IgnorePolymorphCircleRef = ignorePolymorphCircleRef
@@ -0,0 +1,56 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+// https://pb33f.io
+
+package languageserver
+
+import (
+	"sort"
+	"sync"
+
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+// diagnosticStore keeps the diagnostics published for every document, by the document that was linted to find
+// them. A shared file can be included by several documents, its diagnostics are everything they found in it.
+type diagnosticStore struct {
+	mu       sync.Mutex
+	bySource map[protocol.DocumentUri]map[protocol.DocumentUri][]protocol.Diagnostic
+}
+
+func newDiagnosticStore() *diagnosticStore {
+	return &diagnosticStore{bySource: make(map[protocol.DocumentUri]map[protocol.DocumentUri][]protocol.Diagnostic)}
+}
+
+// Replace sets the diagnostics found by linting a document, and returns the diagnostics to publish for every
+// document that has changed. Documents the source no longer has diagnostics for are included, so they are cleared.
+func (d *diagnosticStore) Replace(source protocol.DocumentUri,
+	diagnostics map[protocol.DocumentUri][]protocol.Diagnostic) map[protocol.DocumentUri][]protocol.Diagnostic {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	affected := make(map[protocol.DocumentUri]bool)
+	for uri := range d.bySource[source] {
+		affected[uri] = true
+	}
+	for uri := range diagnostics {
+		affected[uri] = true
+	}
+	d.bySource[source] = diagnostics
+
+	sources := make([]protocol.DocumentUri, 0, len(d.bySource))
+	for s := range d.bySource {
+		sources = append(sources, s)
+	}
+	sort.Strings(sources)
+
+	publish := make(map[protocol.DocumentUri][]protocol.Diagnostic)
+	for uri := range affected {
+		all := []protocol.Diagnostic{}
+		for _, s := range sources {
+			all = append(all, d.bySource[s][uri]...)
+		}
+		publish[uri] = all
+	}
+	return publish
+}
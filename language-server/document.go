@@ -15,16 +15,26 @@
 package languageserver
 
 import (
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/daveshanley/vacuum/model"
 	protocol "github.com/tliron/glsp/protocol_3_16"
 )
 
 type DocumentStore struct {
+	mu        sync.RWMutex
 	documents map[string]*Document
 }
 type Document struct {
 	URI               protocol.DocumentUri
 	RunningDiagnostic bool
 	Content           string
+
+	mu            sync.Mutex
+	lintedContent string                     // the content the results were linted from.
+	results       []model.RuleFunctionResult // the results of the last lint.
 }
 
 func newDocumentStore() *DocumentStore {
@@ -37,13 +47,54 @@ func (s *DocumentStore) Add(uri string, content string) *Document {
 		URI:     uri,
 		Content: content,
 	}
+	s.mu.Lock()
 	s.documents[uri] = doc
+	s.mu.Unlock()
 	return doc
 }
 func (s *DocumentStore) Get(uri string) (*Document, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
 	d, ok := s.documents[uri]
 	return d, ok
 }
+
+// Find returns the open document for a file path, editors do not always encode URIs the same way.
+func (s *DocumentStore) Find(path string) (*Document, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for uri, d := range s.documents {
+		if documentPath(uri) == path {
+			return d, true
+		}
+	}
+	return nil, false
+}
 func (s *DocumentStore) Remove(uri string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	delete(s.documents, uri)
 }
+
+// setResults keeps the results of linting the document, along with the content that was linted.
+func (d *Document) setResults(content string, results []model.RuleFunctionResult) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.lintedContent = content
+	d.results = results
+}
+
+// getResults returns the results of the last lint, and the content that was linted.
+func (d *Document) getResults() (string, []model.RuleFunctionResult) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.lintedContent, d.results
+}
+
+// documentPath returns the file path of a document URI.
+func documentPath(uri protocol.DocumentUri) string {
+	if u, err := url.Parse(uri); err == nil && u.Scheme == "file" {
+		return u.Path
+	}
+	return strings.TrimPrefix(uri, "file://")
+}
@@ -0,0 +1,90 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+// https://pb33f.io
+
+package languageserver
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+// hover documents the rules behind every diagnostic at a position, so the rule does not need to be looked up by id.
+// nil is returned if there are no diagnostics at the position.
+func (s *ServerState) hover(doc *Document, pos protocol.Position) *protocol.Hover {
+	_, results := doc.getResults()
+
+	var sections []string
+	var hoverRange *protocol.Range
+	seen := make(map[string]bool)
+	for _, r := range results {
+		if r.Rule == nil || seen[r.Rule.Id] {
+			continue
+		}
+		rng := ConvertResultIntoDiagnostic(&r).Range
+		if !diagnosticContains(rng, pos) {
+			continue
+		}
+		seen[r.Rule.Id] = true
+		if hoverRange == nil {
+			hoverRange = &rng
+		}
+		sections = append(sections, RenderRuleMarkdown(r.Rule))
+	}
+	if len(sections) == 0 {
+		return nil
+	}
+	return &protocol.Hover{
+		Contents: protocol.MarkupContent{Kind: protocol.MarkupKindMarkdown, Value: strings.Join(sections, "\n---\n\n")},
+		Range:    hoverRange,
+	}
+}
+
+// RenderRuleMarkdown documents a rule as markdown: what it checks, why, how to fix it, examples (if the rule has
+// them) and a link to the full documentation.
+func RenderRuleMarkdown(rule *model.Rule) string {
+	var sb strings.Builder
+	name := rule.Name
+	if name == "" {
+		name = rule.Id
+	}
+	sb.WriteString(fmt.Sprintf("### %s\n\n", name))
+	meta := []string{fmt.Sprintf("`%s`", rule.Id)}
+	if rule.Severity != "" {
+		meta = append(meta, rule.Severity)
+	}
+	if rule.RuleCategory != nil {
+		meta = append(meta, rule.RuleCategory.Name)
+	}
+	sb.WriteString(strings.Join(meta, " | ") + "\n\n")
+	if rule.Description != "" {
+		sb.WriteString(rule.Description + "\n\n")
+	}
+	if rule.RuleCategory != nil && rule.RuleCategory.Description != "" {
+		sb.WriteString(fmt.Sprintf("**Why?** %s\n\n", rule.RuleCategory.Description))
+	}
+	if rule.HowToFix != "" {
+		sb.WriteString(fmt.Sprintf("**How to fix:** %s\n\n", rule.HowToFix))
+	}
+	if rule.GoodExample != "" {
+		sb.WriteString(fmt.Sprintf("**Good:**\n```yaml\n%s\n```\n\n", strings.TrimRight(rule.GoodExample, "\n")))
+	}
+	if rule.BadExample != "" {
+		sb.WriteString(fmt.Sprintf("**Bad:**\n```yaml\n%s\n```\n\n", strings.TrimRight(rule.BadExample, "\n")))
+	}
+	sb.WriteString(fmt.Sprintf("[Rule documentation](%s)\n", RuleDocumentationURL(rule)))
+	return sb.String()
+}
+
+// diagnosticContains returns true if a position is within the range of a diagnostic. Diagnostics that do not end
+// after they start (a lot of results point at a single node) cover the rest of the line they start on.
+func diagnosticContains(rng protocol.Range, pos protocol.Position) bool {
+	if !positionBefore(rng.Start, rng.End) {
+		rng.End = protocol.Position{Line: rng.Start.Line, Character: math.MaxUint32}
+	}
+	return !positionBefore(pos, rng.Start) && !positionBefore(rng.End, pos)
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package languageserver
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+	"gopkg.in/yaml.v3"
+)
+
+func TestServerState_Hover(t *testing.T) {
+	doc := lintTestDocument(fixableSpec)
+	s := &ServerState{}
+
+	// hover anywhere over the operationId.
+	hover := s.hover(doc, protocol.Position{Line: 8, Character: 20})
+	if assert.NotNil(t, hover) {
+		content := hover.Contents.(protocol.MarkupContent)
+		assert.Equal(t, protocol.MarkupKindMarkdown, content.Kind)
+		assert.Contains(t, content.Value, "### Check operationId is URL friendly")
+		assert.Contains(t, content.Value, "`operation-operationId-valid-in-url` | error | Operations")
+		assert.Contains(t, content.Value, "**How to fix:**")
+		assert.Contains(t, content.Value, "**Bad:**\n```yaml\noperationId: get burgers\n```")
+		assert.Contains(t, content.Value, "[Rule documentation](https://quobix.com/vacuum/rules/operations/operation-operationid-valid-in-url)")
+		assert.Equal(t, protocol.UInteger(8), hover.Range.Start.Line)
+	}
+
+	assert.Nil(t, s.hover(doc, protocol.Position{Line: 3, Character: 2}))
+}
+
+func TestRenderRuleMarkdown(t *testing.T) {
+	md := RenderRuleMarkdown(&model.Rule{Id: "my-rule", Description: "does things"})
+	assert.Equal(t, "### my-rule\n\n`my-rule`\n\ndoes things\n\n[Rule documentation](https://quobix.com/vacuum/rules/unknown)\n", md)
+}
+
+func TestDiagnosticContains(t *testing.T) {
+	rng := protocol.Range{Start: protocol.Position{Line: 2, Character: 4}, End: protocol.Position{Line: 4, Character: 1}}
+	assert.True(t, diagnosticContains(rng, protocol.Position{Line: 3}))
+	assert.True(t, diagnosticContains(rng, protocol.Position{Line: 4, Character: 1}))
+	assert.False(t, diagnosticContains(rng, protocol.Position{Line: 2, Character: 3}))
+	assert.False(t, diagnosticContains(rng, protocol.Position{Line: 4, Character: 2}))
+
+	point := protocol.Range{Start: protocol.Position{Line: 2, Character: 4}, End: protocol.Position{Line: 2, Character: 4}}
+	assert.True(t, diagnosticContains(point, protocol.Position{Line: 2, Character: 40}))
+	assert.False(t, diagnosticContains(point, protocol.Position{Line: 3}))
+}
+
+func TestRuleDocumentationURL(t *testing.T) {
+	rule := &model.Rule{Id: "house-style", DocumentationURL: "https://example.com/style#house-style"}
+	assert.Equal(t, "https://example.com/style#house-style", RuleDocumentationURL(rule))
+
+	d := ConvertResultIntoDiagnostic(&model.RuleFunctionResult{Rule: rule, Message: "nope"})
+	assert.Equal(t, "https://example.com/style#house-style", d.CodeDescription.HRef)
+}
+
+func TestConvertResultIntoDiagnostic_Range(t *testing.T) {
+	doc := lintTestDocument(fixableSpec)
+	_, results := doc.getResults()
+	assert.NotEmpty(t, results)
+	for _, r := range results {
+		d := ConvertResultIntoDiagnostic(&r)
+		assert.True(t, d.Range.End.Line > d.Range.Start.Line ||
+			(d.Range.End.Line == d.Range.Start.Line && d.Range.End.Character > d.Range.Start.Character),
+			"%s ends where it starts", r.Rule.Id)
+	}
+
+	// the operationId is underlined to the end of its value.
+	for _, r := range results {
+		if r.Rule.Id == "operation-operationId-valid-in-url" {
+			d := ConvertResultIntoDiagnostic(&r)
+			assert.Equal(t, protocol.Position{Line: 8, Character: 19}, d.Range.Start)
+			assert.Equal(t, protocol.Position{Line: 8, Character: 30}, d.Range.End)
+		}
+	}
+
+	// a result that only has a start ends there.
+	d := ConvertResultIntoDiagnostic(&model.RuleFunctionResult{Rule: &model.Rule{Id: "a"},
+		StartNode: &yaml.Node{Line: 5, Column: 3}})
+	assert.Equal(t, d.Range.Start, d.Range.End)
+}
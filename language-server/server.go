@@ -16,9 +16,8 @@
 package languageserver
 
 import (
-	"fmt"
+	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
 	"github.com/daveshanley/vacuum/model"
@@ -36,6 +35,9 @@ type ServerState struct {
 	server        *glspserv.Server
 	documentStore *DocumentStore
 	lintRequest   *utils.LintFileRequest
+	workspace     *Workspace
+	diagnostics   *diagnosticStore
+	folders       []string // workspace folders to index once initialized.
 }
 
 func NewServer(version string, lintRequest *utils.LintFileRequest) *ServerState {
@@ -46,15 +48,33 @@ func NewServer(version string, lintRequest *utils.LintFileRequest) *ServerState
 		server:        server,
 		lintRequest:   lintRequest,
 		documentStore: newDocumentStore(),
+		workspace:     newWorkspace(),
+		diagnostics:   newDiagnosticStore(),
 	}
 	handler.Initialize = func(context *glsp.Context, params *protocol.InitializeParams) (interface{}, error) {
 		if params.Trace != nil {
 			protocol.SetTraceValue(*params.Trace)
 		}
 
+		state.folders = workspaceFolders(params)
+
 		serverCapabilities := handler.CreateServerCapabilities()
-		serverCapabilities.TextDocumentSync = protocol.TextDocumentSyncKindIncremental
+		syncKind := protocol.TextDocumentSyncKindIncremental
+		serverCapabilities.TextDocumentSync = protocol.TextDocumentSyncOptions{
+			OpenClose: &protocol.True,
+			Change:    &syncKind,
+			Save:      true,
+		}
+		serverCapabilities.Workspace = &protocol.ServerCapabilitiesWorkspace{
+			WorkspaceFolders: &protocol.WorkspaceFoldersServerCapabilities{
+				Supported:           &protocol.True,
+				ChangeNotifications: &protocol.BoolOrString{Value: true},
+			},
+		}
 		serverCapabilities.CompletionProvider = &protocol.CompletionOptions{}
+		serverCapabilities.CodeActionProvider = &protocol.CodeActionOptions{
+			CodeActionKinds: []protocol.CodeActionKind{protocol.CodeActionKindQuickFix, codeActionKindSourceFixAll},
+		}
 
 		return protocol.InitializeResult{
 			Capabilities: serverCapabilities,
@@ -64,6 +84,14 @@ func NewServer(version string, lintRequest *utils.LintFileRequest) *ServerState
 			},
 		}, nil
 	}
+	handler.Initialized = func(context *glsp.Context, params *protocol.InitializedParams) error {
+		go func() {
+			for _, folder := range state.folders {
+				state.workspace.AddFolder(folder)
+			}
+		}()
+		return nil
+	}
 	handler.SetTrace = func(context *glsp.Context, params *protocol.SetTraceParams) error {
 		protocol.SetTraceValue(params.Value)
 		return nil
@@ -91,6 +119,49 @@ func NewServer(version string, lintRequest *utils.LintFileRequest) *ServerState
 		return nil
 	}
 
+	handler.TextDocumentDidSave = func(context *glsp.Context, params *protocol.DidSaveTextDocumentParams) error {
+		if doc, ok := state.documentStore.Get(params.TextDocument.URI); ok {
+			state.runDiagnostic(doc, context.Notify, false)
+		}
+		return nil
+	}
+
+	handler.WorkspaceDidChangeWorkspaceFolders = func(context *glsp.Context,
+		params *protocol.DidChangeWorkspaceFoldersParams) error {
+		for _, folder := range params.Event.Removed {
+			state.workspace.RemoveFolder(documentPath(folder.URI))
+		}
+		go func() {
+			for _, folder := range params.Event.Added {
+				state.workspace.AddFolder(documentPath(folder.URI))
+			}
+		}()
+		return nil
+	}
+
+	handler.WorkspaceDidChangeWatchedFiles = func(context *glsp.Context, params *protocol.DidChangeWatchedFilesParams) error {
+		for _, change := range params.Changes {
+			path := documentPath(change.URI)
+			if _, open := state.documentStore.Find(path); open {
+				// open documents are kept up to date by the editor.
+				continue
+			}
+			dependents := state.workspace.Dependents(path)
+			if change.Type == protocol.FileChangeTypeDeleted {
+				state.workspace.Remove(path)
+			} else if data, err := os.ReadFile(path); err == nil {
+				state.workspace.Index(path, data)
+				dependents = state.workspace.Dependents(path)
+			}
+			go func() {
+				for _, dependent := range dependents {
+					state.lintFile(dependent, context.Notify)
+				}
+			}()
+		}
+		return nil
+	}
+
 	handler.TextDocumentDidClose = func(context *glsp.Context, params *protocol.DidCloseTextDocumentParams) error {
 		state.documentStore.Remove(params.TextDocument.URI)
 		return nil
@@ -99,9 +170,41 @@ func NewServer(version string, lintRequest *utils.LintFileRequest) *ServerState
 	handler.TextDocumentCompletion = func(context *glsp.Context, params *protocol.CompletionParams) (any, error) {
 		return nil, nil
 	}
+
+	handler.TextDocumentHover = func(context *glsp.Context, params *protocol.HoverParams) (*protocol.Hover, error) {
+		doc, ok := state.documentStore.Get(params.TextDocument.URI)
+		if !ok {
+			return nil, nil
+		}
+		return state.hover(doc, params.Position), nil
+	}
+
+	handler.TextDocumentCodeAction = func(context *glsp.Context, params *protocol.CodeActionParams) (any, error) {
+		doc, ok := state.documentStore.Get(params.TextDocument.URI)
+		if !ok {
+			return nil, nil
+		}
+		return state.codeActions(doc, params.Range), nil
+	}
 	return state
 }
 
+// workspaceFolders returns the paths of the folders open in the editor.
+func workspaceFolders(params *protocol.InitializeParams) []string {
+	var folders []string
+	for _, folder := range params.WorkspaceFolders {
+		folders = append(folders, documentPath(folder.URI))
+	}
+	switch {
+	case len(folders) > 0:
+	case params.RootURI != nil && *params.RootURI != "":
+		folders = append(folders, documentPath(*params.RootURI))
+	case params.RootPath != nil && *params.RootPath != "":
+		folders = append(folders, *params.RootPath)
+	}
+	return folders
+}
+
 func (s *ServerState) Run() error {
 	// Initialize configuration on startup
 	s.initializeConfig()
@@ -111,44 +214,132 @@ func (s *ServerState) Run() error {
 	return s.server.RunStdio()
 }
 
+// runDiagnostic lints a document and publishes the diagnostics, along with every document in the workspace that
+// references it. Documents that reference other files are linted with those files read from disk, so unsaved
+// changes to a referenced file show up once it has been saved.
 func (s *ServerState) runDiagnostic(doc *Document, notify glsp.NotifyFunc, delay bool) {
 
+	content := doc.Content
+	path := documentPath(doc.URI)
+	s.workspace.Index(path, []byte(content))
+	dependents := s.workspace.Dependents(path)
 	go func() {
-		// Determine the base path for this document
-		// Priority: 1. Configured base, 2. Document's directory
-		baseForDoc := s.lintRequest.BaseFlag
-		if baseForDoc == "" {
-			// Use the document's directory as base if no global base is configured
-			baseForDoc = filepath.Dir(strings.TrimPrefix(doc.URI, "file://"))
-		}
-
-		result := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
-			RuleSet:                      s.lintRequest.SelectedRS,
-			Timeout:                      time.Duration(s.lintRequest.TimeoutFlag) * time.Second,
-			CustomFunctions:              s.lintRequest.Functions,
-			IgnoreCircularArrayRef:       s.lintRequest.IgnoreArrayCircleRef,
-			IgnoreCircularPolymorphicRef: s.lintRequest.IgnorePolymorphCircleRef,
-			AllowLookup:                  true,
-			Base:                         baseForDoc,
-			Spec:                         []byte(doc.Content),
-			SkipDocumentCheck:            s.lintRequest.SkipCheckFlag,
-			Logger:                       s.lintRequest.Logger,
-		})
-		// Filter ignored results before converting to diagnostics
-		filteredResults := utils.FilterIgnoredResults(result.Results, s.lintRequest.IgnoredResults)
-		result.Results = filteredResults
-		diagnostics := ConvertResultsIntoDiagnostics(result)
-		if len(diagnostics) > 0 {
-			go notify(protocol.ServerTextDocumentPublishDiagnostics, protocol.PublishDiagnosticsParams{
-				URI:         doc.URI,
-				Diagnostics: diagnostics,
-			})
+		// a file that is only included by other documents is linted through them, on its own it would fail to
+		// be recognized as an OpenAPI document.
+		if len(dependents) == 0 || s.workspace.IsSpec(path) {
+			s.lintAndPublish(doc.URI, path, content, doc, notify)
+		}
+		for _, dependent := range dependents {
+			s.lintFile(dependent, notify)
 		}
 	}()
 }
 
+// lintFile lints a document in the workspace that is not necessarily open, and publishes the diagnostics.
+func (s *ServerState) lintFile(path string, notify glsp.NotifyFunc) {
+	if doc, ok := s.documentStore.Find(path); ok {
+		s.lintAndPublish(doc.URI, path, doc.Content, doc, notify)
+		return
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return
+	}
+	s.lintAndPublish(documentURI(path), path, string(content), nil, notify)
+}
+
+// lintAndPublish lints content and publishes diagnostics for the document, and for every file results were found
+// in. The results for the document are kept for hovers and code actions, if it is open.
+func (s *ServerState) lintAndPublish(uri protocol.DocumentUri, path, content string, doc *Document,
+	notify glsp.NotifyFunc) {
+	results := s.lint(path, content)
+	grouped := s.groupResultsByDocument(uri, path, results)
+	if doc != nil {
+		doc.setResults(content, grouped[uri])
+	}
+	diagnostics := make(map[protocol.DocumentUri][]protocol.Diagnostic)
+	for target, targetResults := range grouped {
+		diagnostics[target] = ConvertResultsIntoDiagnostics(&motor.RuleSetExecutionResult{Results: targetResults})
+		if target == uri {
+			continue
+		}
+		// results in an open referenced file can be hovered and fixed too, as long as it matches what was linted.
+		if ref, ok := s.documentStore.Get(target); ok {
+			if data, err := os.ReadFile(documentPath(target)); err == nil && string(data) == ref.Content {
+				ref.setResults(ref.Content, targetResults)
+			}
+		}
+	}
+	for target, targetDiagnostics := range s.diagnostics.Replace(uri, diagnostics) {
+		go notify(protocol.ServerTextDocumentPublishDiagnostics, protocol.PublishDiagnosticsParams{
+			URI:         target,
+			Diagnostics: targetDiagnostics,
+		})
+	}
+}
+
+// lint runs the rules against a document.
+func (s *ServerState) lint(path, content string) []model.RuleFunctionResult {
+	// Determine the base path for this document
+	// Priority: 1. Configured base, 2. Document's directory
+	baseForDoc := s.lintRequest.BaseFlag
+	if baseForDoc == "" {
+		// Use the document's directory as base if no global base is configured
+		baseForDoc = filepath.Dir(path)
+	}
+	specFileName := ""
+	var vacuumIgnore *utils.VacuumIgnore
+	if filepath.IsAbs(path) {
+		specFileName = path
+		vi, err := utils.FindVacuumIgnore(filepath.Dir(path))
+		if err != nil && s.lintRequest.Logger != nil {
+			s.lintRequest.Logger.Warn("unable to read the ignore file", "error", err.Error())
+		}
+		vacuumIgnore = vi
+	}
+	// generated and vendored documents listed in the ignore file have no diagnostics.
+	if vacuumIgnore.IgnoresFile(path) {
+		return nil
+	}
+
+	result := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet:                      s.lintRequest.SelectedRS,
+		Timeout:                      time.Duration(s.lintRequest.TimeoutFlag) * time.Second,
+		CustomFunctions:              s.lintRequest.Functions,
+		IgnoreCircularArrayRef:       s.lintRequest.IgnoreArrayCircleRef,
+		IgnoreCircularPolymorphicRef: s.lintRequest.IgnorePolymorphCircleRef,
+		AllowLookup:                  true,
+		Base:                         baseForDoc,
+		SpecFileName:                 specFileName,
+		Spec:                         []byte(content),
+		SkipDocumentCheck:            s.lintRequest.SkipCheckFlag,
+		Logger:                       s.lintRequest.Logger,
+	})
+	// Filter ignored results before converting to diagnostics
+	results := utils.FilterIgnoredResults(result.Results, s.lintRequest.IgnoredResults)
+	return vacuumIgnore.FilterResults(path, results)
+}
+
+// groupResultsByDocument splits results by the file they were found in, results found in a file referenced by the
+// document belong to that file. The document is always included, so fixed diagnostics are cleared.
+func (s *ServerState) groupResultsByDocument(uri protocol.DocumentUri, path string,
+	results []model.RuleFunctionResult) map[protocol.DocumentUri][]model.RuleFunctionResult {
+	grouped := map[protocol.DocumentUri][]model.RuleFunctionResult{uri: {}}
+	for _, r := range results {
+		target := uri
+		if r.Origin != nil && r.Origin.AbsoluteLocation != path && filepath.IsAbs(r.Origin.AbsoluteLocation) {
+			target = documentURI(r.Origin.AbsoluteLocation)
+			if doc, ok := s.documentStore.Find(r.Origin.AbsoluteLocation); ok {
+				target = doc.URI
+			}
+		}
+		grouped[target] = append(grouped[target], r)
+	}
+	return grouped
+}
+
 func ConvertResultsIntoDiagnostics(result *motor.RuleSetExecutionResult) []protocol.Diagnostic {
-	var diagnostics []protocol.Diagnostic
+	diagnostics := []protocol.Diagnostic{}
 	for _, vacuumResult := range result.Results {
 		diagnostics = append(diagnostics, ConvertResultIntoDiagnostic(&vacuumResult))
 
@@ -159,12 +350,7 @@ func ConvertResultsIntoDiagnostics(result *motor.RuleSetExecutionResult) []proto
 func ConvertResultIntoDiagnostic(vacuumResult *model.RuleFunctionResult) protocol.Diagnostic {
 	severity := GetDiagnosticSeverityFromRule(vacuumResult.Rule)
 
-	diagnosticErrorHref := fmt.Sprintf("%s/rules/unknown", model.WebsiteUrl)
-	if vacuumResult.Rule.RuleCategory != nil {
-		diagnosticErrorHref = fmt.Sprintf("%s/rules/%s/%s", model.WebsiteUrl,
-			strings.ToLower(vacuumResult.Rule.RuleCategory.Id),
-			strings.ReplaceAll(strings.ToLower(vacuumResult.Rule.Id), "$", ""))
-	}
+	diagnosticErrorHref := RuleDocumentationURL(vacuumResult.Rule)
 	startLine := 1
 	startChar := 1
 	endLine := 1
@@ -173,6 +359,7 @@ func ConvertResultIntoDiagnostic(vacuumResult *model.RuleFunctionResult) protoco
 	if vacuumResult.StartNode != nil && vacuumResult.StartNode.Line > 0 {
 		startLine = vacuumResult.StartNode.Line - 1
 		startChar = vacuumResult.StartNode.Column - 1
+		endLine, endChar = startLine, startChar
 	}
 	if vacuumResult.EndNode != nil && vacuumResult.EndNode.Line > 0 {
 		endLine = vacuumResult.EndNode.Line - 1
@@ -194,6 +381,11 @@ func ConvertResultIntoDiagnostic(vacuumResult *model.RuleFunctionResult) protoco
 	}
 }
 
+// RuleDocumentationURL returns the link to the documentation for a rule.
+func RuleDocumentationURL(rule *model.Rule) string {
+	return rule.GetDocumentationURL()
+}
+
 func GetDiagnosticSeverityFromRule(rule *model.Rule) protocol.DiagnosticSeverity {
 	switch rule.Severity {
 	case model.SeverityError:
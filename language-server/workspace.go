@@ -0,0 +1,175 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+// https://pb33f.io
+
+package languageserver
+
+import (
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/daveshanley/vacuum/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// maxWorkspaceFileSize caps the size of files indexed when scanning a workspace.
+const maxWorkspaceFileSize = 10 * 1024 * 1024
+
+// Workspace indexes every YAML and JSON file in the workspace folders, to know which files are OpenAPI documents
+// and which files each of them reference (via $ref). That way an edit to a shared file can be linted through every
+// document that includes it.
+type Workspace struct {
+	mu      sync.Mutex
+	folders []string
+	refs    map[string][]string // file -> files it references directly.
+	specs   map[string]bool     // files that are OpenAPI or Swagger documents.
+}
+
+func newWorkspace() *Workspace {
+	return &Workspace{refs: make(map[string][]string), specs: make(map[string]bool)}
+}
+
+// AddFolder scans a workspace folder and indexes every YAML and JSON file in it. Hidden directories, node_modules
+// and vendor directories are skipped.
+func (w *Workspace) AddFolder(folder string) {
+	w.mu.Lock()
+	w.folders = append(w.folders, folder)
+	w.mu.Unlock()
+
+	_ = filepath.WalkDir(folder, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			if path != folder && (strings.HasPrefix(d.Name(), ".") || d.Name() == "node_modules" || d.Name() == "vendor") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !utils.HasSpecificationExtension(path) {
+			return nil
+		}
+		if info, iErr := d.Info(); iErr != nil || info.Size() > maxWorkspaceFileSize {
+			return nil
+		}
+		if data, rErr := os.ReadFile(path); rErr == nil {
+			w.Index(path, data)
+		}
+		return nil
+	})
+}
+
+// RemoveFolder forgets everything indexed in a workspace folder.
+func (w *Workspace) RemoveFolder(folder string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for i, f := range w.folders {
+		if f == folder {
+			w.folders = append(w.folders[:i], w.folders[i+1:]...)
+			break
+		}
+	}
+	prefix := folder + string(filepath.Separator)
+	for path := range w.refs {
+		if strings.HasPrefix(path, prefix) {
+			delete(w.refs, path)
+			delete(w.specs, path)
+		}
+	}
+}
+
+// Index (re)reads the references of a file, from its content.
+func (w *Workspace) Index(path string, content []byte) {
+	var root yaml.Node
+	refs, spec := []string{}, false
+	if yaml.Unmarshal(content, &root) == nil && len(root.Content) > 0 {
+		spec = utils.IsOpenAPINode(root.Content[0])
+		refs = fileReferences(&root, filepath.Dir(path))
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.refs[path] = refs
+	w.specs[path] = spec
+}
+
+// Remove forgets a file, like when it has been deleted.
+func (w *Workspace) Remove(path string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	delete(w.refs, path)
+	delete(w.specs, path)
+}
+
+// IsSpec returns true if a file is an OpenAPI or Swagger document.
+func (w *Workspace) IsSpec(path string) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.specs[path]
+}
+
+// Dependents returns every OpenAPI document that references a file, directly or through other files, sorted.
+func (w *Workspace) Dependents(path string) []string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	var dependents []string
+	for spec, ok := range w.specs {
+		if ok && spec != path && w.references(spec, make(map[string]bool))[path] {
+			dependents = append(dependents, spec)
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// references collects every file a file references, directly or through other files.
+func (w *Workspace) references(path string, seen map[string]bool) map[string]bool {
+	for _, ref := range w.refs[path] {
+		if !seen[ref] {
+			seen[ref] = true
+			w.references(ref, seen)
+		}
+	}
+	return seen
+}
+
+// fileReferences returns the files referenced by every $ref in a document, resolved against the directory of the
+// document. Local and remote references are ignored.
+func fileReferences(node *yaml.Node, dir string) []string {
+	seen := make(map[string]bool)
+	var refs []string
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(n.Content); i += 2 {
+				if n.Content[i].Value == "$ref" && n.Content[i+1].Kind == yaml.ScalarNode {
+					file, _, _ := strings.Cut(n.Content[i+1].Value, "#")
+					if file == "" || strings.Contains(file, "://") {
+						continue
+					}
+					if !filepath.IsAbs(file) {
+						file = filepath.Join(dir, file)
+					}
+					if file = filepath.Clean(file); !seen[file] {
+						seen[file] = true
+						refs = append(refs, file)
+					}
+				}
+			}
+		}
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+	walk(node)
+	return refs
+}
+
+// documentURI returns the URI of a file path.
+func documentURI(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
@@ -0,0 +1,201 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package languageserver
+
+import (
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/utils"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"github.com/tliron/glsp"
+	protocol "github.com/tliron/glsp/protocol_3_16"
+)
+
+const workspaceRootSpec = `openapi: 3.1.0
+info:
+  title: Burgers
+  version: 1.0.0
+paths:
+  /burgers:
+    $ref: './paths/burgers.yaml'
+`
+
+const workspacePathsFile = `get:
+  operationId: getBurgers
+  responses:
+    "200":
+      description: ok
+      content:
+        application/json:
+          schema:
+            $ref: '../components.yaml#/Burger'
+`
+
+const workspaceComponentsFile = `Burger:
+  type: object
+`
+
+func writeWorkspace(t *testing.T) string {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "paths"), 0o755))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "node_modules"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "openapi.yaml"), []byte(workspaceRootSpec), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "paths", "burgers.yaml"), []byte(workspacePathsFile), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "components.yaml"), []byte(workspaceComponentsFile), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "node_modules", "spec.yaml"), []byte(workspaceRootSpec), 0o644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("$ref: openapi.yaml"), 0o644))
+	return dir
+}
+
+func TestWorkspace_Dependents(t *testing.T) {
+	dir := writeWorkspace(t)
+	w := newWorkspace()
+	w.AddFolder(dir)
+
+	root := filepath.Join(dir, "openapi.yaml")
+	paths := filepath.Join(dir, "paths", "burgers.yaml")
+	components := filepath.Join(dir, "components.yaml")
+
+	assert.True(t, w.IsSpec(root))
+	assert.False(t, w.IsSpec(paths))
+	assert.False(t, w.IsSpec(filepath.Join(dir, "node_modules", "spec.yaml")))
+
+	// the root includes the components through the paths file.
+	assert.Equal(t, []string{root}, w.Dependents(components))
+	assert.Equal(t, []string{root}, w.Dependents(paths))
+	assert.Empty(t, w.Dependents(root))
+
+	// the paths file no longer references the components.
+	w.Index(paths, []byte("get:\n  operationId: getBurgers\n"))
+	assert.Empty(t, w.Dependents(components))
+
+	// a deleted file is still referenced, so the root is linted again.
+	w.Remove(paths)
+	assert.Equal(t, []string{root}, w.Dependents(paths))
+
+	w.RemoveFolder(dir)
+	assert.False(t, w.IsSpec(root))
+}
+
+func TestWorkspace_Index_Cycle(t *testing.T) {
+	w := newWorkspace()
+	w.Index("/specs/a.yaml", []byte("openapi: 3.1.0\nthing:\n  $ref: 'b.yaml#/thing'\n"))
+	w.Index("/specs/b.yaml", []byte("thing:\n  $ref: 'a.yaml#/thing'\n"))
+	assert.Equal(t, []string{"/specs/a.yaml"}, w.Dependents("/specs/b.yaml"))
+	assert.Empty(t, w.Dependents("/specs/a.yaml"))
+}
+
+func TestServerState_GroupResultsByDocument(t *testing.T) {
+	s := &ServerState{documentStore: newDocumentStore()}
+	s.documentStore.Add("file:///specs/paths.yaml", "")
+
+	results := []model.RuleFunctionResult{
+		{Message: "root", Origin: &index.NodeOrigin{AbsoluteLocation: "/specs/openapi.yaml"}},
+		{Message: "no origin"},
+		{Message: "open", Origin: &index.NodeOrigin{AbsoluteLocation: "/specs/paths.yaml"}},
+		{Message: "closed", Origin: &index.NodeOrigin{AbsoluteLocation: "/specs/components.yaml"}},
+		{Message: "remote", Origin: &index.NodeOrigin{AbsoluteLocation: "https://pb33f.io/spec.yaml"}},
+	}
+	grouped := s.groupResultsByDocument("file:///specs/openapi.yaml", "/specs/openapi.yaml", results)
+	assert.Len(t, grouped, 3)
+	assert.Len(t, grouped["file:///specs/openapi.yaml"], 3)
+	assert.Equal(t, "open", grouped["file:///specs/paths.yaml"][0].Message)
+	assert.Equal(t, "closed", grouped["file:///specs/components.yaml"][0].Message)
+
+	// the document is always included, so its diagnostics are cleared.
+	grouped = s.groupResultsByDocument("file:///specs/openapi.yaml", "/specs/openapi.yaml", nil)
+	assert.Len(t, grouped, 1)
+	assert.Empty(t, grouped["file:///specs/openapi.yaml"])
+}
+
+func TestDiagnosticStore_Replace(t *testing.T) {
+	store := newDiagnosticStore()
+	a := protocol.Diagnostic{Message: "from a"}
+	b := protocol.Diagnostic{Message: "from b"}
+
+	publish := store.Replace("file:///a.yaml", map[string][]protocol.Diagnostic{
+		"file:///a.yaml":      {},
+		"file:///shared.yaml": {a},
+	})
+	assert.Equal(t, []protocol.Diagnostic{a}, publish["file:///shared.yaml"])
+	assert.Empty(t, publish["file:///a.yaml"])
+
+	// a shared file has the diagnostics of every document that includes it.
+	publish = store.Replace("file:///b.yaml", map[string][]protocol.Diagnostic{
+		"file:///b.yaml":      {},
+		"file:///shared.yaml": {b},
+	})
+	assert.Equal(t, []protocol.Diagnostic{a, b}, publish["file:///shared.yaml"])
+	assert.NotContains(t, publish, "file:///a.yaml")
+
+	// diagnostics that are no longer found are cleared.
+	publish = store.Replace("file:///a.yaml", map[string][]protocol.Diagnostic{"file:///a.yaml": {}})
+	assert.Equal(t, []protocol.Diagnostic{b}, publish["file:///shared.yaml"])
+}
+
+func TestServerState_LintFile_PublishesReferencedFiles(t *testing.T) {
+	dir := writeWorkspace(t)
+	s := &ServerState{
+		documentStore: newDocumentStore(),
+		workspace:     newWorkspace(),
+		diagnostics:   newDiagnosticStore(),
+		lintRequest: &utils.LintFileRequest{
+			SelectedRS: rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet(),
+		},
+	}
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	published := make(map[string][]protocol.Diagnostic)
+	var notify glsp.NotifyFunc = func(method string, params any) {
+		defer wg.Done()
+		p := params.(protocol.PublishDiagnosticsParams)
+		mu.Lock()
+		published[p.URI] = p.Diagnostics
+		mu.Unlock()
+	}
+
+	root := filepath.Join(dir, "openapi.yaml")
+	wg.Add(2)
+	s.lintFile(root, notify)
+	wg.Wait()
+
+	// the media type without an example is in the paths file.
+	pathsDiagnostics := published[documentURI(filepath.Join(dir, "paths", "burgers.yaml"))]
+	var found bool
+	for _, d := range pathsDiagnostics {
+		if d.Code != nil && d.Code.Value == "oas3-missing-example" {
+			found = true
+		}
+	}
+	assert.True(t, found)
+	for _, d := range published[documentURI(root)] {
+		assert.NotEqual(t, "oas3-missing-example", d.Code.Value)
+	}
+}
+
+func TestServerState_Lint_VacuumIgnore(t *testing.T) {
+	dir := writeWorkspace(t)
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, utils.VacuumIgnoreFileName),
+		[]byte("node_modules\npaths/ oas3-missing-example\n"), 0o644))
+	s := &ServerState{
+		lintRequest: &utils.LintFileRequest{
+			SelectedRS: rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet(),
+		},
+	}
+
+	vendored := filepath.Join(dir, "node_modules", "spec.yaml")
+	assert.Nil(t, s.lint(vendored, workspaceRootSpec))
+
+	results := s.lint(filepath.Join(dir, "openapi.yaml"), workspaceRootSpec)
+	assert.NotEmpty(t, results)
+	for _, r := range results {
+		assert.NotEqual(t, "oas3-missing-example", r.Rule.Id)
+	}
+}
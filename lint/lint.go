@@ -0,0 +1,168 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+// Package lint is the entry point for using vacuum as a library. Lint resolves a ruleset, runs it against a
+// specification and builds the report, the same way the CLI does, without any of the CLI's output.
+//
+//	result, err := lint.Lint(ctx, spec,
+//		lint.WithRuleSetLocation("my-ruleset.yaml"),
+//		lint.WithSkippedRules("operation-tags"))
+package lint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/motor"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/statistics"
+	"github.com/daveshanley/vacuum/utils"
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+// Result is the outcome of linting a specification.
+type Result struct {
+	ResultSet   *model.RuleResultSet       // Every result, sorted by line number.
+	Statistics  *reports.ReportStatistics  // The quality score, and statistics about the specification.
+	SpecInfo    *datamodel.SpecInfo        // What the specification is (type, version, format).
+	RuleSet     *rulesets.RuleSet          // The ruleset the specification was linted with.
+	RuleTimings []*reports.RuleTiming      // How long each rule took to run, slowest first.
+	Suppressed  []model.RuleFunctionResult // Results suppressed by x-vacuum-ignore extensions in the specification.
+}
+
+// Lint lints a specification (OpenAPI, or AsyncAPI) and returns the results. Without a ruleset option, the
+// recommended rules are used. An error is returned if the ruleset cannot be resolved, or the specification cannot
+// be processed, linting can be cancelled with the context.
+func Lint(ctx context.Context, spec []byte, opts ...Option) (*Result, error) {
+	c := &config{remote: true}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	var httpClient *http.Client
+	if utils.ShouldUseCustomHTTPClient(c.httpClientConfig) {
+		client, err := utils.CreateCustomHTTPClient(c.httpClientConfig)
+		if err != nil {
+			return nil, fmt.Errorf("unable to create the HTTP client: %w", err)
+		}
+		httpClient = client
+	}
+
+	logger := c.logger
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+
+	rs, err := resolveRuleSet(ctx, c, spec, logger, httpClient)
+	if err != nil {
+		return nil, err
+	}
+	if rs, err = skipRules(rs, c.skippedRules); err != nil {
+		return nil, err
+	}
+
+	execution := motor.ApplyRulesToRuleSetWithContext(ctx, &motor.RuleSetExecution{
+		RuleSet:          rs,
+		Spec:             spec,
+		SpecFileName:     c.fileName,
+		CustomFunctions:  c.customFunctions,
+		SilenceLogs:      c.logger == nil,
+		Logger:           c.logger,
+		Base:             c.basePath,
+		AllowLookup:      c.remote,
+		Timeout:          c.timeout,
+		HTTPClientConfig: c.httpClientConfig,
+		RefCache:         c.refCache,
+	})
+	if len(execution.Errors) > 0 {
+		return nil, errors.Join(execution.Errors...)
+	}
+
+	resultSet := model.NewRuleResultSet(execution.Results)
+	resultSet.RuleSetCategories = rs.Categories
+	resultSet.SetSuppressedResults(execution.Suppressed)
+	resultSet.SortResultsByLineNumber()
+
+	return &Result{
+		ResultSet:   resultSet,
+		Statistics:  statistics.CreateReportStatistics(execution.Index, execution.SpecInfo, resultSet),
+		SpecInfo:    execution.SpecInfo,
+		RuleSet:     rs,
+		RuleTimings: execution.RuleTimings,
+		Suppressed:  execution.Suppressed,
+	}, nil
+}
+
+// resolveRuleSet picks the ruleset to lint with: a ready to run ruleset, a ruleset definition, a ruleset location,
+// or (without any of them) the recommended rules for the kind of specification.
+func resolveRuleSet(ctx context.Context, c *config, spec []byte, logger *slog.Logger,
+	httpClient *http.Client) (*rulesets.RuleSet, error) {
+	if c.ruleSet != nil {
+		return c.ruleSet, nil
+	}
+	defaultRuleSets := rulesets.BuildDefaultRuleSetsWithLogger(logger)
+
+	data := c.ruleSetData
+	overridesDir := ""
+	if builtIn := rulesets.GenerateBuiltInRuleSet(c.ruleSetLocation, defaultRuleSets); builtIn != nil {
+		return builtIn, nil
+	}
+	if c.ruleSetLocation != "" {
+		switch {
+		case strings.HasPrefix(c.ruleSetLocation, "http"):
+			if !c.remote {
+				return nil, fmt.Errorf("cannot download ruleset '%s', remote lookups are not allowed", c.ruleSetLocation)
+			}
+			downloaded, err := rulesets.DownloadRemoteRuleSet(ctx, c.ruleSetLocation, httpClient)
+			if err != nil {
+				return nil, err
+			}
+			return defaultRuleSets.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(downloaded, httpClient), nil
+		default:
+			read, err := os.ReadFile(c.ruleSetLocation)
+			if err != nil {
+				return nil, fmt.Errorf("cannot read ruleset '%s': %w", c.ruleSetLocation, err)
+			}
+			data = read
+			overridesDir = filepath.Dir(c.ruleSetLocation)
+		}
+	}
+	if data != nil {
+		userRS, err := rulesets.CreateRuleSetFromData(data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse ruleset: %w", err)
+		}
+		userRS.OverridesDir = overridesDir
+		return defaultRuleSets.GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(userRS, httpClient), nil
+	}
+	if motor.DetectAsyncAPIFormat(spec) != "" {
+		return defaultRuleSets.GenerateAsyncAPIRecommendedRuleSet(), nil
+	}
+	return defaultRuleSets.GenerateOpenAPIRecommendedRuleSet(), nil
+}
+
+// skipRules returns a copy of a ruleset without the skipped rules, rulesets are often shared.
+func skipRules(rs *rulesets.RuleSet, skipped []string) (*rulesets.RuleSet, error) {
+	if len(skipped) == 0 {
+		return rs, nil
+	}
+	for _, id := range skipped {
+		if rs.Rules[id] == nil {
+			return nil, fmt.Errorf("cannot skip rule '%s', it is not in the ruleset", id)
+		}
+	}
+	filtered := rs.Copy()
+	for _, id := range skipped {
+		delete(filtered.Rules, id)
+	}
+	return filtered, nil
+}
@@ -0,0 +1,181 @@
+package lint
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+)
+
+var lintTestSpec = []byte(`openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: the pets`)
+
+func ruleIds(result *Result) map[string]int {
+	ids := make(map[string]int)
+	for _, r := range result.ResultSet.Results {
+		ids[r.Rule.Id]++
+	}
+	return ids
+}
+
+func TestLint_Recommended(t *testing.T) {
+	result, err := Lint(context.Background(), lintTestSpec)
+	assert.NoError(t, err)
+	assert.NotNil(t, result.Statistics)
+	assert.NotNil(t, result.SpecInfo)
+	assert.NotEmpty(t, result.ResultSet.Results)
+	assert.NotEmpty(t, result.RuleTimings)
+	assert.Contains(t, ruleIds(result), "operation-operationId")
+	assert.Equal(t, len(rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet().Rules),
+		len(result.RuleSet.Rules))
+}
+
+func TestLint_SkippedRules(t *testing.T) {
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	rules := len(rs.Rules)
+
+	result, err := Lint(context.Background(), lintTestSpec,
+		WithRuleSet(rs), WithSkippedRules("operation-operationId"))
+	assert.NoError(t, err)
+	assert.NotContains(t, ruleIds(result), "operation-operationId")
+	assert.Len(t, result.RuleSet.Rules, rules-1)
+
+	// the ruleset supplied is not changed.
+	assert.Len(t, rs.Rules, rules)
+}
+
+func TestLint_SkippedRules_OverridesAndCategories(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ruleset.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`extends: [[spectral:oas, off]]
+categories:
+  - id: pets
+    name: Pets
+rules:
+  info-contact:
+    category: pets
+    severity: error
+    given: $.info
+    then:
+      field: contact
+      function: truthy
+  operation-summary:
+    given: $.paths[*][*]
+    then:
+      field: summary
+      function: truthy
+overrides:
+  - files: ["legacy.yaml"]
+    rules:
+      info-contact: warn`), 0o644))
+
+	result, err := Lint(context.Background(), lintTestSpec, WithRuleSetLocation(path),
+		WithFileName(filepath.Join(dir, "legacy.yaml")), WithSkippedRules("operation-summary"))
+	assert.NoError(t, err)
+	assert.Len(t, result.ResultSet.Results, 1)
+	assert.Len(t, result.RuleSet.Rules, 1)
+
+	// skipping a rule keeps the overrides (relative to the ruleset) and the categories of the ruleset.
+	assert.Equal(t, dir, result.RuleSet.OverridesDir)
+	assert.Equal(t, "warn", result.ResultSet.Results[0].Rule.Severity)
+	assert.Len(t, result.RuleSet.Categories, 1)
+	assert.Equal(t, "Pets", result.ResultSet.GetRuleCategory("pets").Name)
+}
+
+func TestLint_SkippedRules_Unknown(t *testing.T) {
+	_, err := Lint(context.Background(), lintTestSpec, WithSkippedRules("not-a-rule"))
+	assert.ErrorContains(t, err, "cannot skip rule 'not-a-rule'")
+}
+
+func TestLint_RuleSetData(t *testing.T) {
+	rs := []byte(`extends: [[spectral:oas, off]]
+rules:
+  operation-summary:
+    given: $.paths[*][*]
+    severity: error
+    then:
+      field: summary
+      function: truthy`)
+
+	result, err := Lint(context.Background(), lintTestSpec, WithRuleSetData(rs))
+	assert.NoError(t, err)
+	assert.Len(t, result.RuleSet.Rules, 1)
+	assert.Equal(t, map[string]int{"operation-summary": 1}, ruleIds(result))
+	assert.Equal(t, 1, result.ResultSet.GetErrorCount())
+}
+
+func TestLint_RuleSetData_Invalid(t *testing.T) {
+	_, err := Lint(context.Background(), lintTestSpec, WithRuleSetData([]byte("rules: [")))
+	assert.ErrorContains(t, err, "unable to parse ruleset")
+}
+
+func TestLint_RuleSetLocation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ruleset.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`extends: [[spectral:oas, off]]
+rules:
+  info-contact:
+    given: $.info
+    then:
+      field: contact
+      function: truthy`), 0o644))
+
+	result, err := Lint(context.Background(), lintTestSpec, WithRuleSetLocation(path))
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"info-contact": 1}, ruleIds(result))
+}
+
+func TestLint_RuleSetLocation_Missing(t *testing.T) {
+	_, err := Lint(context.Background(), lintTestSpec, WithRuleSetLocation("no-such-ruleset.yaml"))
+	assert.ErrorContains(t, err, "cannot read ruleset 'no-such-ruleset.yaml'")
+}
+
+func TestLint_RuleSetLocation_RemoteNotAllowed(t *testing.T) {
+	_, err := Lint(context.Background(), lintTestSpec,
+		WithRuleSetLocation("https://example.com/ruleset.yaml"), WithRemoteReferences(false))
+	assert.ErrorContains(t, err, "remote lookups are not allowed")
+}
+
+func TestLint_OWASP(t *testing.T) {
+	result, err := Lint(context.Background(), lintTestSpec, WithRuleSetLocation("owasp"))
+	assert.NoError(t, err)
+	for id := range result.RuleSet.Rules {
+		assert.Contains(t, id, "owasp")
+	}
+}
+
+func TestLint_AsyncAPI(t *testing.T) {
+	spec, err := os.ReadFile("../model/test_files/asyncapi-streetlights-v3.yaml")
+	assert.NoError(t, err)
+
+	result, err := Lint(context.Background(), spec, WithTimeout(10*time.Second))
+	assert.NoError(t, err)
+	assert.Equal(t, len(rulesets.BuildDefaultRuleSets().GenerateAsyncAPIRecommendedRuleSet().Rules),
+		len(result.RuleSet.Rules))
+}
+
+func TestLint_BasePath(t *testing.T) {
+	spec, err := os.ReadFile("../model/test_files/localfile-burgershop.openapi.yaml")
+	assert.NoError(t, err)
+
+	result, err := Lint(context.Background(), spec,
+		WithBasePath("../model/test_files"), WithFileName("localfile-burgershop.openapi.yaml"))
+	assert.NoError(t, err)
+	assert.NotNil(t, result.ResultSet)
+}
+
+func TestLint_InvalidSpec(t *testing.T) {
+	_, err := Lint(context.Background(), []byte("not: a: spec: ["))
+	assert.Error(t, err)
+}
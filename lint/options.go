@@ -0,0 +1,119 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package lint
+
+import (
+	"log/slog"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/daveshanley/vacuum/utils"
+)
+
+// Option configures a call to Lint.
+type Option func(*config)
+
+type config struct {
+	ruleSet          *rulesets.RuleSet
+	ruleSetData      []byte
+	ruleSetLocation  string
+	fileName         string
+	basePath         string
+	remote           bool
+	timeout          time.Duration
+	skippedRules     []string
+	customFunctions  map[string]model.RuleFunction
+	logger           *slog.Logger
+	httpClientConfig utils.HTTPClientConfig
+	refCache         *utils.RefCache
+}
+
+// WithRuleSet lints with a ready to run ruleset, like one generated from rulesets.BuildDefaultRuleSets().
+// The ruleset is not changed by Lint.
+func WithRuleSet(rs *rulesets.RuleSet) Option {
+	return func(c *config) {
+		c.ruleSet = rs
+	}
+}
+
+// WithRuleSetData lints with a vacuum or Spectral ruleset (YAML or JSON), resolved against the built-in rulesets
+// the way the CLI resolves '--ruleset', including the rulesets it extends.
+func WithRuleSetData(data []byte) Option {
+	return func(c *config) {
+		c.ruleSetData = data
+	}
+}
+
+// WithRuleSetLocation lints with a vacuum or Spectral ruleset read from a file, or downloaded from a URL (which
+// needs remote lookups), or a built-in ruleset by name ('owasp').
+func WithRuleSetLocation(location string) Option {
+	return func(c *config) {
+		c.ruleSetLocation = location
+	}
+}
+
+// WithFileName sets the name of the specification, used to label where results were found, and to pick ruleset
+// overrides for the file.
+func WithFileName(fileName string) Option {
+	return func(c *config) {
+		c.fileName = fileName
+	}
+}
+
+// WithBasePath sets the base path or URL references in the specification are resolved from.
+func WithBasePath(basePath string) Option {
+	return func(c *config) {
+		c.basePath = basePath
+	}
+}
+
+// WithRemoteReferences allows (or stops) references to other files and URLs being looked up, allowed by default.
+func WithRemoteReferences(allow bool) Option {
+	return func(c *config) {
+		c.remote = allow
+	}
+}
+
+// WithTimeout sets how long each rule can run for, the default is five seconds.
+func WithTimeout(timeout time.Duration) Option {
+	return func(c *config) {
+		c.timeout = timeout
+	}
+}
+
+// WithSkippedRules stops rules from running, by id. Every rule has to be in the ruleset.
+func WithSkippedRules(ruleIds ...string) Option {
+	return func(c *config) {
+		c.skippedRules = append(c.skippedRules, ruleIds...)
+	}
+}
+
+// WithCustomFunctions makes custom functions available to the rules of the ruleset, by name.
+func WithCustomFunctions(functions map[string]model.RuleFunction) Option {
+	return func(c *config) {
+		c.customFunctions = functions
+	}
+}
+
+// WithLogger logs with a custom logger, nothing is logged by default.
+func WithLogger(logger *slog.Logger) Option {
+	return func(c *config) {
+		c.logger = logger
+	}
+}
+
+// WithHTTPClientConfig sets the certificates used for HTTPS requests, when looking up remote references and rulesets.
+func WithHTTPClientConfig(httpClientConfig utils.HTTPClientConfig) Option {
+	return func(c *config) {
+		c.httpClientConfig = httpClientConfig
+	}
+}
+
+// WithRefCache caches remote documents referenced by the specification on disk, like the CLI does.
+func WithRefCache(refCache *utils.RefCache) Option {
+	return func(c *config) {
+		c.refCache = refCache
+	}
+}
@@ -0,0 +1,477 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+// Package locator maps the JSON paths of results (like $.paths['/users'].get) to where they are in the source of a
+// specification: the file, and the line and column the node starts and ends at. It is the same resolution vacuum
+// uses itself, for reporters that only have the path of a result, or need to know where a node ends.
+//
+//	doc, err := locator.Parse(spec, "openapi.yaml")
+//	loc, err := locator.Resolve(doc, "$.paths./users.get")
+//	fmt.Printf("%s:%d:%d-%d:%d", loc.File, loc.Line, loc.Column, loc.EndLine, loc.EndColumn)
+package locator
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"gopkg.in/yaml.v3"
+)
+
+// maxReferences stops references that refer to each other from being followed forever.
+const maxReferences = 32
+
+// Location is where a node is in the source of a specification. Lines and columns start at 1, the end column is
+// the column after the last character of the node.
+type Location struct {
+	File      string `json:"file,omitempty" yaml:"file,omitempty"`
+	Line      int    `json:"line" yaml:"line"`
+	Column    int    `json:"column" yaml:"column"`
+	EndLine   int    `json:"endLine" yaml:"endLine"`
+	EndColumn int    `json:"endColumn" yaml:"endColumn"`
+}
+
+// String renders a location like file:line:column-endLine:endColumn.
+func (l *Location) String() string {
+	if l == nil {
+		return ""
+	}
+	return fmt.Sprintf("%s:%d:%d-%d:%d", l.File, l.Line, l.Column, l.EndLine, l.EndColumn)
+}
+
+// Document is a parsed specification (YAML or JSON) that paths are resolved against.
+type Document struct {
+	File  string
+	Root  *yaml.Node // the root mapping (or sequence) of the document.
+	lines []string   // the source, if the document was parsed, used to find the end of scalars.
+}
+
+// ErrNotFound is returned when there is nothing at a path.
+var ErrNotFound = errors.New("there is nothing at the path")
+
+// Parse parses a specification, file is reported as the file of every location.
+func Parse(spec []byte, file string) (*Document, error) {
+	var root yaml.Node
+	if err := yaml.Unmarshal(spec, &root); err != nil {
+		return nil, fmt.Errorf("unable to parse '%s': %w", file, err)
+	}
+	doc := NewDocument(&root, file)
+	doc.SetSource(spec)
+	return doc, nil
+}
+
+// NewDocument creates a document from a node that has already been parsed, like the root node of a libopenapi
+// document. A document node is unwrapped to its content.
+func NewDocument(root *yaml.Node, file string) *Document {
+	if root != nil && root.Kind == yaml.DocumentNode {
+		if len(root.Content) == 0 {
+			root = nil
+		} else {
+			root = root.Content[0]
+		}
+	}
+	return &Document{File: file, Root: root}
+}
+
+// Resolve returns the location of the node at a path in a document. See (*Document).Resolve.
+func Resolve(doc *Document, path string) (*Location, error) {
+	return doc.Resolve(path)
+}
+
+// Resolve returns the location of the node at a path, from its key (if it has one) to the end of its value. Paths
+// use the notation of results, $.paths['/users'].get or $.paths./users.get, with [0] for items of a sequence. Local
+// references ($ref: '#/components/...') are followed when the path goes through them.
+func (d *Document) Resolve(path string) (*Location, error) {
+	key, value, err := d.Lookup(path)
+	if err != nil {
+		return nil, err
+	}
+	loc := d.NodeLocation(value)
+	if key != nil {
+		loc.Line, loc.Column = key.Line, key.Column
+	}
+	return loc, nil
+}
+
+// Lookup returns the node at a path, and the key of the node if it is the value of a mapping.
+func (d *Document) Lookup(path string) (key, value *yaml.Node, err error) {
+	if d == nil || d.Root == nil {
+		return nil, nil, fmt.Errorf("%w '%s', the document is empty", ErrNotFound, path)
+	}
+	node := d.Root
+	references := 0
+	for _, segment := range Segments(path) {
+		next, nextKey := child(node, segment)
+		for next == nil {
+			ref := reference(node)
+			if ref == "" {
+				return nil, nil, fmt.Errorf("%w '%s', '%s' was not found", ErrNotFound, path, segment)
+			}
+			if !strings.HasPrefix(ref, "#") {
+				return nil, nil, fmt.Errorf("%w '%s', it refers to another document '%s'", ErrNotFound, path, ref)
+			}
+			if references++; references > maxReferences {
+				return nil, nil, fmt.Errorf("%w '%s', there are too many references", ErrNotFound, path)
+			}
+			if node, err = d.pointer(ref); err != nil {
+				return nil, nil, fmt.Errorf("%w '%s', %s", ErrNotFound, path, err.Error())
+			}
+			next, nextKey = child(node, segment)
+		}
+		node, key = next, nextKey
+	}
+	return key, node, nil
+}
+
+// pointer returns the node at a local reference, like #/components/schemas/Pet.
+func (d *Document) pointer(ref string) (*yaml.Node, error) {
+	node := d.Root
+	pointer := strings.TrimPrefix(strings.TrimPrefix(ref, "#"), "/")
+	if pointer == "" {
+		return node, nil
+	}
+	for _, segment := range strings.Split(pointer, "/") {
+		segment = strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")
+		if node, _ = child(node, segment); node == nil {
+			return nil, fmt.Errorf("the reference '%s' cannot be found", ref)
+		}
+	}
+	return node, nil
+}
+
+// PathAt returns the path (like $.paths['/pets'].get) of the node that starts at a line and column, and the node.
+// A key has the path of its value. The deepest node is returned when many nodes start at the same place, like a
+// mapping and its first key.
+func (d *Document) PathAt(line, column int) (string, *yaml.Node, error) {
+	if d == nil || d.Root == nil {
+		return "", nil, fmt.Errorf("%w at %d:%d, the document is empty", ErrNotFound, line, column)
+	}
+	path, node := nodeAt(d.Root, line, column, "$")
+	if node == nil {
+		return "", nil, fmt.Errorf("%w at %d:%d", ErrNotFound, line, column)
+	}
+	return path, node, nil
+}
+
+func nodeAt(node *yaml.Node, line, column int, path string) (string, *yaml.Node) {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			keyPath := path + pathKey(key.Value)
+			if key.Line == line && key.Column == column {
+				return keyPath, key
+			}
+			if found, n := nodeAt(value, line, column, keyPath); n != nil {
+				return found, n
+			}
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			if found, n := nodeAt(item, line, column, fmt.Sprintf("%s[%d]", path, i)); n != nil {
+				return found, n
+			}
+		}
+	}
+	if node.Line == line && node.Column == column {
+		return path, node
+	}
+	return "", nil
+}
+
+// pathKey renders a key of a path, keys that are not plain words are quoted, like ['/pets'] or ['200'].
+func pathKey(key string) string {
+	plain := key != "" && !(key[0] >= '0' && key[0] <= '9')
+	for _, c := range key {
+		if !(c == '_' || c == '-' || c == '$' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') ||
+			(c >= '0' && c <= '9')) {
+			plain = false
+		}
+	}
+	if plain {
+		return "." + key
+	}
+	return "['" + key + "']"
+}
+
+func child(node *yaml.Node, segment string) (value, key *yaml.Node) {
+	if node == nil {
+		return nil, nil
+	}
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				return node.Content[i+1], node.Content[i]
+			}
+		}
+	case yaml.SequenceNode:
+		if i, err := strconv.Atoi(segment); err == nil && i >= 0 && i < len(node.Content) {
+			return node.Content[i], nil
+		}
+	case yaml.AliasNode:
+		return child(node.Alias, segment)
+	}
+	return nil, nil
+}
+
+func reference(node *yaml.Node) string {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return ""
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "$ref" {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
+}
+
+// SetSource sets the source of a document created from a node, so the end of scalars that carry on over many lines
+// (and of quoted scalars with escapes) can be found. Parse sets it.
+func (d *Document) SetSource(spec []byte) {
+	d.lines = strings.Split(string(spec), "\n")
+}
+
+// NodeLocation returns where a node starts and ends in the document.
+func (d *Document) NodeLocation(node *yaml.Node) *Location {
+	file := ""
+	if d != nil {
+		file = d.File
+	}
+	loc := &Location{File: file}
+	if node == nil {
+		return loc
+	}
+	var lines []string
+	if d != nil {
+		lines = d.lines
+	}
+	loc.Line, loc.Column = node.Line, node.Column
+	loc.EndLine, loc.EndColumn = end(node, lines)
+	return loc
+}
+
+// NodeLocation returns where a node starts and ends, in a file. Without the source, the end of a scalar that
+// carries on over many lines is a guess.
+func NodeLocation(node *yaml.Node, file string) *Location {
+	return (&Document{File: file}).NodeLocation(node)
+}
+
+// ResultLocation returns where a result is. A result found in another file (through a reference) is located in
+// that file. Results that no longer hold on to their nodes are located by their range, as far as it is known.
+func ResultLocation(r *model.RuleFunctionResult, file string) *Location {
+	if r == nil {
+		return nil
+	}
+	if r.Origin != nil && r.Origin.AbsoluteLocation != "" {
+		file = r.Origin.AbsoluteLocation
+	}
+	if r.StartNode == nil {
+		loc := &Location{File: file, Line: r.Range.Start.Line, Column: r.Range.Start.Char,
+			EndLine: r.Range.End.Line, EndColumn: r.Range.End.Char}
+		if r.Origin != nil && r.Origin.Line > 0 {
+			loc.Line, loc.Column = r.Origin.Line, r.Origin.Column
+		}
+		if loc.EndLine < loc.Line || (loc.EndLine == loc.Line && loc.EndColumn < loc.Column) {
+			loc.EndLine, loc.EndColumn = loc.Line, loc.Column
+		}
+		return loc
+	}
+	loc := NodeLocation(r.StartNode, file)
+	if r.EndNode != nil && after(r.EndNode.Line, r.EndNode.Column, loc.EndLine, loc.EndColumn) {
+		loc.EndLine, loc.EndColumn = r.EndNode.Line, r.EndNode.Column
+	}
+	return loc
+}
+
+func after(line, column, thanLine, thanColumn int) bool {
+	return line > thanLine || (line == thanLine && column > thanColumn)
+}
+
+// end returns the line and column after the last character of a node.
+func end(node *yaml.Node, lines []string) (int, int) {
+	switch node.Kind {
+	case yaml.MappingNode, yaml.SequenceNode:
+		line, column := node.Line, node.Column
+		for _, c := range node.Content {
+			if l, col := end(c, lines); after(l, col, line, column) {
+				line, column = l, col
+			}
+		}
+		if node.Style&yaml.FlowStyle != 0 {
+			if len(node.Content) == 0 {
+				return node.Line, node.Column + 2 // {} or []
+			}
+			if _, ok := nodeSource(node, lines); !ok {
+				return line, column + 1
+			}
+			return closingBracket(line, column, lines)
+		}
+		return line, column
+	case yaml.AliasNode:
+		return node.Line, node.Column + len(node.Value) + 1 // *name
+	case yaml.DocumentNode:
+		if len(node.Content) > 0 {
+			return end(node.Content[0], lines)
+		}
+		return node.Line, node.Column
+	}
+	return scalarEnd(node, lines)
+}
+
+func scalarEnd(node *yaml.Node, lines []string) (int, int) {
+	source, hasSource := nodeSource(node, lines)
+	switch {
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		if hasSource {
+			return continuationEnd(node, lines, source)
+		}
+		value := strings.TrimSuffix(node.Value, "\n")
+		last := value
+		if i := strings.LastIndexByte(value, '\n'); i >= 0 {
+			last = value[i+1:]
+		}
+		// without the source, the indentation of the block is not known, it's at least the column of the node.
+		return node.Line + strings.Count(value, "\n") + 1, node.Column + len(last)
+	case node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0:
+		// the value is unescaped, so the source is measured if it's there.
+		if hasSource && node.Column-1 < len(source) {
+			if col := quotedEnd(source[node.Column-1:], source[node.Column-1]); col > 0 {
+				return node.Line, node.Column + col
+			}
+			return continuationEnd(node, lines, source)
+		}
+		return node.Line, node.Column + len(node.Value) + 2
+	}
+	if node.Tag == "!!null" && node.Value == "" {
+		return node.Line, node.Column
+	}
+	// a plain scalar longer than the rest of its line carries on over the lines that follow.
+	if hasSource && len(source)-(node.Column-1) < len(node.Value) {
+		return continuationEnd(node, lines, source)
+	}
+	return node.Line, node.Column + len(node.Value)
+}
+
+// quotedEnd returns the length of a quoted scalar at the start of the source, including its quotes, or 0 if the
+// closing quote is on another line.
+func quotedEnd(source string, quote byte) int {
+	for i := 1; i < len(source); i++ {
+		switch {
+		case quote == '"' && source[i] == '\\':
+			i++
+		case source[i] == quote && quote == '\'' && i+1 < len(source) && source[i+1] == '\'':
+			i++ // '' is an escaped quote.
+		case source[i] == quote:
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// continuationEnd finds the end of a scalar that carries on over the lines after the line it starts on (like a
+// block scalar), the lines that are indented more than the line it starts on.
+func continuationEnd(node *yaml.Node, lines []string, first string) (int, int) {
+	indent := len(first) - len(strings.TrimLeft(first, " "))
+	line, column := node.Line, len(strings.TrimRight(first, " \r"))+1
+	for l := node.Line + 1; l <= len(lines); l++ {
+		source, _ := sourceLine(lines, l)
+		if strings.TrimSpace(source) == "" {
+			continue
+		}
+		if len(source)-len(strings.TrimLeft(source, " ")) <= indent {
+			break
+		}
+		line, column = l, len(strings.TrimRight(source, " \r"))+1
+	}
+	return line, column
+}
+
+// closingBracket finds the bracket that closes a flow mapping or sequence, after the end of its last item.
+func closingBracket(line, column int, lines []string) (int, int) {
+	for l := line; l <= len(lines) && l > 0; l++ {
+		source, _ := sourceLine(lines, l)
+		start := 0
+		if l == line {
+			start = column - 1
+		}
+		for i := start; i < len(source); i++ {
+			if source[i] == '}' || source[i] == ']' {
+				return l, i + 2
+			}
+		}
+	}
+	return line, column + 1
+}
+
+// nodeSource returns the line of the source a node starts on, if the node is in the source. A node can come from
+// another file (through a reference), its line in the source is then something else.
+func nodeSource(node *yaml.Node, lines []string) (string, bool) {
+	source, ok := sourceLine(lines, node.Line)
+	if !ok || node.Column < 1 || node.Column > len(source) {
+		return source, ok && node.Value == "" && node.Kind == yaml.ScalarNode
+	}
+	c := source[node.Column-1]
+	switch {
+	case node.Kind == yaml.MappingNode:
+		return source, node.Style&yaml.FlowStyle == 0 || c == '{'
+	case node.Kind == yaml.SequenceNode:
+		return source, node.Style&yaml.FlowStyle == 0 || c == '['
+	case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+		return source, c == '|' || c == '>'
+	case node.Style&yaml.DoubleQuotedStyle != 0:
+		return source, c == '"'
+	case node.Style&yaml.SingleQuotedStyle != 0:
+		return source, c == '\''
+	case node.Value != "":
+		return source, c == node.Value[0]
+	}
+	return source, true
+}
+
+func sourceLine(lines []string, line int) (string, bool) {
+	if line < 1 || line > len(lines) {
+		return "", false
+	}
+	return lines[line-1], true
+}
+
+// Segments splits a path (as used in results) into segments, e.g. $.paths['/pets'].get.tags[0] is paths, /pets,
+// get, tags and 0. Keys can be quoted with ' or ", or follow a '.'.
+func Segments(path string) []string {
+	var segments []string
+	path = strings.TrimPrefix(path, "$")
+	for len(path) > 0 {
+		switch {
+		case strings.HasPrefix(path, "['"), strings.HasPrefix(path, `["`):
+			closing := path[1:2] + "]"
+			end := strings.Index(path[2:], closing)
+			if end < 0 {
+				return append(segments, path[2:])
+			}
+			segments = append(segments, path[2:end+2])
+			path = path[end+4:]
+		case path[0] == '[':
+			end := strings.IndexByte(path, ']')
+			if end < 0 {
+				return append(segments, path[1:])
+			}
+			segments = append(segments, path[1:end])
+			path = path[end+1:]
+		case path[0] == '.':
+			path = path[1:]
+			end := strings.IndexAny(path, ".[")
+			if end < 0 {
+				end = len(path)
+			}
+			segments = append(segments, path[:end])
+			path = path[end:]
+		default:
+			return segments
+		}
+	}
+	return segments
+}
@@ -0,0 +1,224 @@
+package locator
+
+import (
+	"os"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var spec = `openapi: 3.1.0
+info:
+  title: "Burgers"
+  description: |
+    All the burgers.
+    Every one of them.
+  version: 1.0.0
+paths:
+  /users:
+    get:
+      operationId: listUsers
+      tags: [a, b]
+      responses:
+        "200":
+          $ref: '#/components/responses/Users'
+components:
+  responses:
+    Users:
+      description: the users
+      content: {}
+`
+
+func TestSegments(t *testing.T) {
+	assert.Equal(t, []string{"paths", "/pets", "get", "tags", "0"}, Segments("$.paths['/pets'].get.tags[0]"))
+	assert.Equal(t, []string{"paths", "/users", "get"}, Segments("$.paths./users.get"))
+	assert.Equal(t, []string{"paths", "/a.b", "get"}, Segments(`$.paths["/a.b"].get`))
+	assert.Equal(t, []string{"info", "contact"}, Segments("$.info.contact"))
+	assert.Empty(t, Segments("$"))
+}
+
+func TestResolve(t *testing.T) {
+	doc, err := Parse([]byte(spec), "openapi.yaml")
+	assert.NoError(t, err)
+
+	loc, err := Resolve(doc, "$.paths./users.get")
+	assert.NoError(t, err)
+	assert.Equal(t, &Location{File: "openapi.yaml", Line: 10, Column: 5, EndLine: 15, EndColumn: 47}, loc)
+	assert.Equal(t, "openapi.yaml:10:5-15:47", loc.String())
+
+	loc, err = doc.Resolve("$.paths['/users'].get.operationId")
+	assert.NoError(t, err)
+	assert.Equal(t, &Location{File: "openapi.yaml", Line: 11, Column: 7, EndLine: 11, EndColumn: 29}, loc)
+
+	loc, err = doc.Resolve("$.paths['/users'].get.tags[1]")
+	assert.NoError(t, err)
+	assert.Equal(t, &Location{File: "openapi.yaml", Line: 12, Column: 17, EndLine: 12, EndColumn: 18}, loc)
+
+	// flow sequences end at their closing bracket.
+	loc, err = doc.Resolve("$.paths['/users'].get.tags")
+	assert.NoError(t, err)
+	assert.Equal(t, 12, loc.EndLine)
+	assert.Equal(t, 19, loc.EndColumn)
+
+	// quotes are part of the value.
+	loc, err = doc.Resolve("$.info.title")
+	assert.NoError(t, err)
+	assert.Equal(t, &Location{File: "openapi.yaml", Line: 3, Column: 3, EndLine: 3, EndColumn: 19}, loc)
+
+	// block scalars end at their last line.
+	loc, err = doc.Resolve("$.info.description")
+	assert.NoError(t, err)
+	assert.Equal(t, &Location{File: "openapi.yaml", Line: 4, Column: 3, EndLine: 6, EndColumn: 23}, loc)
+}
+
+func TestResolve_References(t *testing.T) {
+	doc, err := Parse([]byte(spec), "openapi.yaml")
+	assert.NoError(t, err)
+
+	loc, err := doc.Resolve("$.paths['/users'].get.responses['200'].description")
+	assert.NoError(t, err)
+	assert.Equal(t, &Location{File: "openapi.yaml", Line: 19, Column: 7, EndLine: 19, EndColumn: 29}, loc)
+
+	// empty flow mappings are {}.
+	loc, err = doc.Resolve("$.paths['/users'].get.responses['200'].content")
+	assert.NoError(t, err)
+	assert.Equal(t, 20, loc.EndLine)
+	assert.Equal(t, 18, loc.EndColumn)
+}
+
+func TestResolve_NotFound(t *testing.T) {
+	doc, err := Parse([]byte(spec), "openapi.yaml")
+	assert.NoError(t, err)
+
+	_, err = doc.Resolve("$.paths['/pets']")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.EqualError(t, err, "there is nothing at the path '$.paths['/pets']', '/pets' was not found")
+
+	_, err = doc.Resolve("$.paths['/users'].get.tags[5]")
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	doc, err = Parse([]byte("a:\n  $ref: 'other.yaml#/b'\n"), "openapi.yaml")
+	assert.NoError(t, err)
+	_, err = doc.Resolve("$.a.b")
+	assert.EqualError(t, err, "there is nothing at the path '$.a.b', it refers to another document 'other.yaml#/b'")
+
+	doc, err = Parse([]byte("a:\n  $ref: '#/a'\n"), "openapi.yaml")
+	assert.NoError(t, err)
+	_, err = doc.Resolve("$.a.b")
+	assert.EqualError(t, err, "there is nothing at the path '$.a.b', there are too many references")
+
+	_, err = NewDocument(&yaml.Node{Kind: yaml.DocumentNode}, "").Resolve("$.a")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
+
+func TestResolve_JSON(t *testing.T) {
+	doc, err := Parse([]byte(`{
+  "info": {
+    "title": "a \"quoted\" title",
+    "version": "1.0.0"
+  }
+}`), "openapi.json")
+	assert.NoError(t, err)
+
+	loc, err := doc.Resolve("$.info.title")
+	assert.NoError(t, err)
+	assert.Equal(t, &Location{File: "openapi.json", Line: 3, Column: 5, EndLine: 3, EndColumn: 34}, loc)
+
+	loc, err = doc.Resolve("$.info")
+	assert.NoError(t, err)
+	assert.Equal(t, &Location{File: "openapi.json", Line: 2, Column: 3, EndLine: 5, EndColumn: 4}, loc)
+}
+
+func TestNodeLocation(t *testing.T) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	doc := NewDocument(&root, "openapi.yaml")
+
+	_, value, err := doc.Lookup("$.paths['/users'].get.operationId")
+	assert.NoError(t, err)
+	assert.Equal(t, &Location{File: "openapi.yaml", Line: 11, Column: 20, EndLine: 11, EndColumn: 29},
+		NodeLocation(value, "openapi.yaml"))
+
+	// without the source, a block scalar is measured from its value.
+	_, value, err = doc.Lookup("$.info.description")
+	assert.NoError(t, err)
+	assert.Equal(t, &Location{Line: 4, Column: 16, EndLine: 6, EndColumn: 34}, NodeLocation(value, ""))
+}
+
+func TestResultLocation(t *testing.T) {
+	doc, err := Parse([]byte(spec), "openapi.yaml")
+	assert.NoError(t, err)
+	key, value, err := doc.Lookup("$.paths['/users'].get.operationId")
+	assert.NoError(t, err)
+
+	r := &model.RuleFunctionResult{StartNode: key, EndNode: value}
+	assert.Equal(t, &Location{File: "openapi.yaml", Line: 11, Column: 7, EndLine: 11, EndColumn: 20},
+		ResultLocation(r, "openapi.yaml"))
+
+	// found in another file.
+	r.Origin = &index.NodeOrigin{AbsoluteLocation: "/specs/users.yaml", Line: 11, Column: 7}
+	assert.Equal(t, "/specs/users.yaml", ResultLocation(r, "openapi.yaml").File)
+
+	// results that no longer have their nodes are located by their range.
+	r = &model.RuleFunctionResult{Range: reports.Range{
+		Start: reports.RangeItem{Line: 3, Char: 3},
+		End:   reports.RangeItem{Line: 3, Char: 19},
+	}}
+	assert.Equal(t, &Location{File: "openapi.yaml", Line: 3, Column: 3, EndLine: 3, EndColumn: 19},
+		ResultLocation(r, "openapi.yaml"))
+	assert.Nil(t, ResultLocation(nil, ""))
+}
+
+func TestResolve_Specification(t *testing.T) {
+	spec, err := os.ReadFile("../model/test_files/burgershop.openapi.yaml")
+	assert.NoError(t, err)
+	doc, err := Parse(spec, "burgershop.openapi.yaml")
+	assert.NoError(t, err)
+
+	loc, err := doc.Resolve("$.paths['/burgers'].post")
+	assert.NoError(t, err)
+	assert.Greater(t, loc.EndLine, loc.Line)
+}
+
+func TestNodeLocation_AnotherFile(t *testing.T) {
+	doc, err := Parse([]byte(spec), "openapi.yaml")
+	assert.NoError(t, err)
+
+	// a node from another file is measured from its value, the source is for something else.
+	node := &yaml.Node{Kind: yaml.ScalarNode, Style: yaml.DoubleQuotedStyle, Value: "pets", Line: 3, Column: 3}
+	assert.Equal(t, &Location{File: "openapi.yaml", Line: 3, Column: 3, EndLine: 3, EndColumn: 9}, doc.NodeLocation(node))
+
+	node = &yaml.Node{Kind: yaml.ScalarNode, Style: yaml.LiteralStyle, Value: "one\ntwo\n", Line: 2, Column: 10}
+	assert.Equal(t, &Location{File: "openapi.yaml", Line: 2, Column: 10, EndLine: 4, EndColumn: 13}, doc.NodeLocation(node))
+}
+
+func TestDocument_PathAt(t *testing.T) {
+	doc, err := Parse([]byte(spec), "openapi.yaml")
+	assert.NoError(t, err)
+
+	path, node, err := doc.PathAt(9, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "$.paths['/users']", path)
+	assert.Equal(t, "/users", node.Value)
+
+	path, node, err = doc.PathAt(12, 17)
+	assert.NoError(t, err)
+	assert.Equal(t, "$.paths['/users'].get.tags[1]", path)
+	assert.Equal(t, "b", node.Value)
+
+	path, _, err = doc.PathAt(15, 11)
+	assert.NoError(t, err)
+	assert.Equal(t, "$.paths['/users'].get.responses['200'].$ref", path)
+
+	// a path can be resolved again.
+	_, value, err := doc.Lookup(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "#/components/responses/Users", value.Value)
+
+	_, _, err = doc.PathAt(100, 1)
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,39 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Baseline is a snapshot of the findings that already exist in a specification. Findings in the baseline are
+// not reported again, so vacuum can be adopted on large specifications without fixing everything up front.
+type Baseline struct {
+	Generated time.Time         `json:"generated" yaml:"generated"`
+	Results   []*BaselineResult `json:"results" yaml:"results"`
+}
+
+// BaselineResult is a single fingerprinted finding inside a Baseline. The rule and path are kept only so
+// the file can be read by a human, matching is done by the fingerprint.
+type BaselineResult struct {
+	Fingerprint string `json:"fingerprint" yaml:"fingerprint"`
+	RuleId      string `json:"ruleId" yaml:"ruleId"`
+	Path        string `json:"path,omitempty" yaml:"path,omitempty"`
+}
+
+// BaselineFingerprint generates a fingerprint for a result from the rule id and path. Line numbers are not used, so
+// unrelated edits to a specification do not invalidate the baseline. Neither is the message, it changes with the locale.
+func BaselineFingerprint(result *RuleFunctionResult) string {
+	ruleId := result.RuleId
+	if result.Rule != nil && result.Rule.Id != "" {
+		ruleId = result.Rule.Id
+	}
+	h := sha256.New()
+	h.Write([]byte(ruleId))
+	h.Write([]byte{0})
+	h.Write([]byte(result.Path))
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,188 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Defaults for the source kept around a result.
+const (
+	DefaultCodeFrameContextLines  = 3
+	DefaultCodeFrameMaxLineLength = 120
+	maxCodeFrameHighlightedLines  = 10 // the most lines of a result that are highlighted, the frame is cut off there.
+)
+
+// CodeFrameOptions decide how much of the source is kept around a result.
+type CodeFrameOptions struct {
+	ContextLines  int // lines kept before and after the lines of the result.
+	MaxLineLength int // longer lines are cut off (like minified JSON, or embedded examples), 0 keeps every line whole.
+}
+
+// DefaultCodeFrameOptions keeps three lines either side of a result, and cuts lines off at 120 characters.
+var DefaultCodeFrameOptions = CodeFrameOptions{
+	ContextLines:  DefaultCodeFrameContextLines,
+	MaxLineLength: DefaultCodeFrameMaxLineLength,
+}
+
+// CodeFrame is the source around a result, so a result can be understood without opening the specification. The
+// lines of the result (from Line to EndLine) are highlighted, the column is where the result starts.
+type CodeFrame struct {
+	StartLine int      `json:"startLine" yaml:"startLine"` // the line number of the first line.
+	Line      int      `json:"line" yaml:"line"`
+	Column    int      `json:"column" yaml:"column"`
+	EndLine   int      `json:"endLine" yaml:"endLine"`
+	Lines     []string `json:"lines" yaml:"lines"`
+}
+
+// NewCodeFrame creates a frame from the lines of a specification, around the lines of a result (starting at 1).
+// Nil is returned if the lines are not in the specification.
+func NewCodeFrame(specLines []string, line, column, endLine int, options CodeFrameOptions) *CodeFrame {
+	if n := len(specLines); n > 0 && specLines[n-1] == "" {
+		specLines = specLines[:n-1] // the line break at the end of the file.
+	}
+	if line < 1 || line > len(specLines) {
+		return nil
+	}
+	endLine = min(max(endLine, line), line+maxCodeFrameHighlightedLines-1, len(specLines))
+	context := max(options.ContextLines, 0)
+	frame := &CodeFrame{
+		StartLine: max(line-context, 1),
+		Line:      line,
+		Column:    column,
+		EndLine:   endLine,
+	}
+	last := min(endLine+context, len(specLines))
+	for l := frame.StartLine; l <= last; l++ {
+		frame.Lines = append(frame.Lines, cutLine(strings.TrimRight(specLines[l-1], "\r"), options.MaxLineLength))
+	}
+	return frame
+}
+
+// cutLine cuts a line off at a number of characters, and says how many were cut.
+func cutLine(line string, maxLength int) string {
+	if maxLength <= 0 {
+		return line
+	}
+	runes := []rune(line)
+	if len(runes) <= maxLength {
+		return line
+	}
+	return fmt.Sprintf("%s... (%d more characters)", string(runes[:maxLength]), len(runes)-maxLength)
+}
+
+// Source returns the lines of the frame as they are in the specification.
+func (f *CodeFrame) Source() string {
+	if f == nil {
+		return ""
+	}
+	return strings.Join(f.Lines, "\n")
+}
+
+// String renders the frame with line numbers, the lines of the result are marked with '>', and a '^' points at the
+// column the result starts at.
+//
+//	  10 |     get:
+//	> 11 |       operationId: listUsers
+//	     |       ^
+//	  12 |       tags: [a, b]
+func (f *CodeFrame) String() string {
+	if f == nil {
+		return ""
+	}
+	width := len(fmt.Sprint(f.StartLine + len(f.Lines) - 1))
+	var sb strings.Builder
+	for i, line := range f.Lines {
+		n := f.StartLine + i
+		marker := " "
+		if n >= f.Line && n <= f.EndLine {
+			marker = ">"
+		}
+		sb.WriteString(strings.TrimRight(fmt.Sprintf("%s %*d | %s", marker, width, n, line), " "))
+		sb.WriteByte('\n')
+		if n == f.Line && f.Column > 0 {
+			column := min(f.Column, len([]rune(line))+1)
+			sb.WriteString(fmt.Sprintf("  %s | %s^\n", strings.Repeat(" ", width), strings.Repeat(" ", column-1)))
+		}
+	}
+	return sb.String()
+}
+
+// CodeFrameForResult returns the frame of a result: the frame captured for it, or a frame from the lines of the
+// specification (the file) it was linted in. A result found in another file (through a reference) only has the
+// frame it captured.
+func CodeFrameForResult(r *RuleFunctionResult, specFile string, specLines []string, options CodeFrameOptions) *CodeFrame {
+	if r == nil {
+		return nil
+	}
+	if r.CodeFrame != nil {
+		return r.CodeFrame
+	}
+	if r.Origin != nil && r.Origin.AbsoluteLocation != "" && !sameFile(r.Origin.AbsoluteLocation, specFile) {
+		return nil
+	}
+	return newResultCodeFrame(r, specLines, options)
+}
+
+func newResultCodeFrame(r *RuleFunctionResult, lines []string, options CodeFrameOptions) *CodeFrame {
+	line, column, endLine := r.Range.Start.Line, r.Range.Start.Char, r.Range.End.Line
+	if r.StartNode != nil {
+		line, column, endLine = r.StartNode.Line, r.StartNode.Column, r.StartNode.Line
+	}
+	if r.EndNode != nil {
+		endLine = r.EndNode.Line
+	}
+	if r.Origin != nil && r.Origin.Line > 0 && r.Origin.Line != line {
+		// the result is somewhere else in the file it came from, only its start is known there.
+		line, column, endLine = r.Origin.Line, r.Origin.Column, r.Origin.Line
+	}
+	return NewCodeFrame(lines, line, column, endLine, options)
+}
+
+// CaptureCodeFrames keeps the source around every result, so reports can render code frames once the specification
+// is gone (like a saved report). Results found in other local files (through references) are framed from those
+// files, results in remote files are not framed.
+func (rr *RuleResultSet) CaptureCodeFrames(specFile string, spec []byte, options CodeFrameOptions) {
+	if rr == nil {
+		return
+	}
+	specLines := strings.Split(string(spec), "\n")
+	files := make(map[string][]string)
+	for _, r := range rr.Results {
+		lines := specLines
+		if r.Origin != nil && r.Origin.AbsoluteLocation != "" && !sameFile(r.Origin.AbsoluteLocation, specFile) {
+			location := r.Origin.AbsoluteLocation
+			var ok bool
+			if lines, ok = files[location]; !ok {
+				lines = localFileLines(location)
+				files[location] = lines
+			}
+		}
+		r.CodeFrame = newResultCodeFrame(r, lines, options)
+	}
+}
+
+func sameFile(a, b string) bool {
+	if a == b {
+		return true
+	}
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// localFileLines reads the lines of a local file, nil is returned for anything else (like a URL).
+func localFileLines(location string) []string {
+	if strings.Contains(location, "://") {
+		return nil
+	}
+	data, err := os.ReadFile(location)
+	if err != nil {
+		return nil
+	}
+	return strings.Split(string(data), "\n")
+}
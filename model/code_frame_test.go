@@ -0,0 +1,106 @@
+package model
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var codeFrameSpec = `openapi: 3.1.0
+info:
+  title: pets
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        "200":
+          description: ok
+`
+
+func TestNewCodeFrame(t *testing.T) {
+	lines := strings.Split(codeFrameSpec, "\n")
+	frame := NewCodeFrame(lines, 7, 7, 7, CodeFrameOptions{ContextLines: 2})
+	assert.Equal(t, 5, frame.StartLine)
+	assert.Equal(t, []string{"  /pets:", "    get:", "      operationId: listPets", "      responses:", `        "200":`},
+		frame.Lines)
+	assert.Equal(t, `  5 |   /pets:
+  6 |     get:
+> 7 |       operationId: listPets
+    |       ^
+  8 |       responses:
+  9 |         "200":
+`, frame.String())
+
+	// the start and end of the specification cut the context off.
+	frame = NewCodeFrame(lines, 1, 1, 2, DefaultCodeFrameOptions)
+	assert.Equal(t, 1, frame.StartLine)
+	assert.Equal(t, 2, frame.EndLine)
+	assert.Len(t, frame.Lines, 5)
+	assert.True(t, strings.HasPrefix(frame.String(), "> 1 | openapi: 3.1.0\n    | ^\n> 2 | info:\n"))
+
+	frame = NewCodeFrame(lines, 10, 9, 30, CodeFrameOptions{})
+	assert.Equal(t, 10, frame.EndLine)
+	assert.Equal(t, []string{"          description: ok"}, frame.Lines)
+
+	assert.Nil(t, NewCodeFrame(lines, 0, 1, 1, DefaultCodeFrameOptions))
+	assert.Nil(t, NewCodeFrame(lines, 100, 1, 1, DefaultCodeFrameOptions))
+	assert.Empty(t, (*CodeFrame)(nil).String())
+}
+
+func TestNewCodeFrame_LongLines(t *testing.T) {
+	lines := []string{"a: " + strings.Repeat("x", 50), "b: c"}
+	frame := NewCodeFrame(lines, 1, 40, 1, CodeFrameOptions{ContextLines: 1, MaxLineLength: 10})
+	assert.Equal(t, "a: xxxxxxx... (43 more characters)", frame.Lines[0])
+	assert.Equal(t, "b: c", frame.Lines[1])
+
+	frame = NewCodeFrame(lines, 1, 1, 1, CodeFrameOptions{})
+	assert.Equal(t, lines[0], frame.Lines[0])
+}
+
+func TestRuleResultSet_CaptureCodeFrames(t *testing.T) {
+	other := filepath.Join(t.TempDir(), "pet.yaml")
+	assert.NoError(t, os.WriteFile(other, []byte("type: object\nproperties:\n  name:\n    type: string\n"), 0664))
+
+	rs := NewRuleResultSet([]RuleFunctionResult{
+		{Message: "one", StartNode: &yaml.Node{Line: 7, Column: 7}, EndNode: &yaml.Node{Line: 10, Column: 11}},
+		{Message: "two", StartNode: &yaml.Node{Line: 3, Column: 5},
+			Origin: &index.NodeOrigin{AbsoluteLocation: other, Line: 3, Column: 3}},
+		{Message: "three", StartNode: &yaml.Node{Line: 1, Column: 1},
+			Origin: &index.NodeOrigin{AbsoluteLocation: "https://example.com/pet.yaml", Line: 1, Column: 1}},
+		{Message: "four"},
+	})
+	rs.CaptureCodeFrames("openapi.yaml", []byte(codeFrameSpec), CodeFrameOptions{ContextLines: 1})
+
+	frame := rs.Results[0].CodeFrame
+	assert.Equal(t, 6, frame.StartLine)
+	assert.Equal(t, 10, frame.EndLine)
+	assert.Equal(t, "    get:", frame.Lines[0])
+
+	frame = rs.Results[1].CodeFrame
+	assert.Equal(t, []string{"properties:", "  name:", "    type: string"}, frame.Lines)
+	assert.Nil(t, rs.Results[2].CodeFrame)
+	assert.Nil(t, rs.Results[3].CodeFrame)
+}
+
+func TestCodeFrameForResult(t *testing.T) {
+	lines := strings.Split(codeFrameSpec, "\n")
+	r := &RuleFunctionResult{StartNode: &yaml.Node{Line: 3, Column: 3}}
+	frame := CodeFrameForResult(r, "openapi.yaml", lines, CodeFrameOptions{})
+	assert.Equal(t, []string{"  title: pets"}, frame.Lines)
+
+	// a captured frame wins.
+	r.CodeFrame = &CodeFrame{StartLine: 1, Line: 1, EndLine: 1, Lines: []string{"captured"}}
+	assert.Equal(t, r.CodeFrame, CodeFrameForResult(r, "openapi.yaml", lines, CodeFrameOptions{}))
+
+	// the lines of the specification are not the lines of another file.
+	r = &RuleFunctionResult{StartNode: &yaml.Node{Line: 3, Column: 3},
+		Origin: &index.NodeOrigin{AbsoluteLocation: "other.yaml", Line: 3, Column: 3}}
+	assert.Nil(t, CodeFrameForResult(r, "openapi.yaml", lines, CodeFrameOptions{}))
+	assert.Nil(t, CodeFrameForResult(nil, "openapi.yaml", lines, CodeFrameOptions{}))
+}
@@ -0,0 +1,55 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package model
+
+import "slices"
+
+// DocumentKind is the kind of document being linted. vacuum was built for OpenAPI, but it can also lint documents
+// that are not OpenAPI, which don't have the same shape, and so don't have the same rule categories.
+type DocumentKind string
+
+const (
+	DocumentKindOpenAPI    DocumentKind = "openapi"
+	DocumentKindAsyncAPI   DocumentKind = "asyncapi"
+	DocumentKindJSONSchema DocumentKind = "jsonschema"
+)
+
+// DocumentKindForFormat returns the kind of document for a spec format (like OAS3 or AsyncAPI2). Unknown formats
+// are considered to be OpenAPI, which is what vacuum has always done.
+func DocumentKindForFormat(specFormat string) DocumentKind {
+	switch specFormat {
+	case AsyncAPI2, AsyncAPI3:
+		return DocumentKindAsyncAPI
+	case JSONSchema:
+		return DocumentKindJSONSchema
+	}
+	return DocumentKindOpenAPI
+}
+
+// RuleCategories returns the ordered built-in rule categories that make sense for this kind of document, there is
+// no point in reporting on tags or security for a JSON Schema.
+func (dk DocumentKind) RuleCategories() []*RuleCategory {
+	return dk.RuleCategoriesWith(RuleCategoriesOrdered)
+}
+
+// RuleCategoriesWith filters ordered categories (like those of a ruleset) down to the ones that make sense for
+// this kind of document. Categories defined by a ruleset are always included.
+func (dk DocumentKind) RuleCategoriesWith(categories []*RuleCategory) []*RuleCategory {
+	var builtIn []string
+	switch dk {
+	case DocumentKindAsyncAPI:
+		builtIn = []string{CategoryInfo, CategoryOperations, CategorySchemas, CategoryValidation, CategoryDescriptions}
+	case DocumentKindJSONSchema:
+		builtIn = []string{CategorySchemas, CategoryValidation, CategoryDescriptions, CategoryExamples}
+	default:
+		return categories
+	}
+	var all []*RuleCategory
+	for _, cat := range categories {
+		if !IsBuiltInRuleCategory(cat.Id) || slices.Contains(builtIn, cat.Id) {
+			all = append(all, cat)
+		}
+	}
+	return all
+}
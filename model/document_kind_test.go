@@ -0,0 +1,22 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDocumentKindForFormat(t *testing.T) {
+	assert.Equal(t, DocumentKindOpenAPI, DocumentKindForFormat(OAS31))
+	assert.Equal(t, DocumentKindOpenAPI, DocumentKindForFormat(""))
+	assert.Equal(t, DocumentKindAsyncAPI, DocumentKindForFormat(AsyncAPI3))
+	assert.Equal(t, DocumentKindJSONSchema, DocumentKindForFormat(JSONSchema))
+}
+
+func TestDocumentKind_RuleCategories(t *testing.T) {
+	assert.Equal(t, RuleCategoriesOrdered, DocumentKindOpenAPI.RuleCategories())
+	cats := DocumentKindJSONSchema.RuleCategories()
+	assert.Equal(t, CategorySchemas, cats[0].Id)
+	assert.NotContains(t, cats, RuleCategories[CategoryTags])
+	assert.NotContains(t, DocumentKindAsyncAPI.RuleCategories(), RuleCategories[CategorySecurity])
+}
@@ -0,0 +1,75 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package model
+
+import "fmt"
+
+// FailureThreshold decides which results break a build. It is shared by the exit code of the CLI and the failure
+// counts of reports (like JUnit), so they always agree on what a failure is.
+type FailureThreshold struct {
+	Severity    string // results at or above this severity are failures, 'none' means nothing fails.
+	MaxWarnings int    // fail when there are more warnings than this, regardless of severity. Negative disables it.
+}
+
+// NewFailureThreshold creates a FailureThreshold for a severity, and a maximum number of warnings (-1 for no limit).
+func NewFailureThreshold(severity string, maxWarnings int) *FailureThreshold {
+	return &FailureThreshold{Severity: severity, MaxWarnings: maxWarnings}
+}
+
+// DefaultJUnitFailureThreshold is used by reports when no threshold is supplied, errors and warnings are failures.
+var DefaultJUnitFailureThreshold = &FailureThreshold{Severity: SeverityWarn, MaxWarnings: -1}
+
+// IsFailure returns true if a result of this severity is a failure.
+func (f *FailureThreshold) IsFailure(severity string) bool {
+	threshold := severityWeight(f.Severity)
+	if threshold < 0 {
+		return false
+	}
+	return severityWeight(severity) >= threshold
+}
+
+// Check returns an error if the counts break the threshold.
+func (f *FailureThreshold) Check(errors, warnings, informs int) error {
+	switch f.Severity {
+	case SeverityNone:
+		return nil
+	case SeverityError:
+		if errors > 0 {
+			return fmt.Errorf("failed with %d errors", errors)
+		}
+	case SeverityWarn:
+		if warnings > 0 || errors > 0 {
+			return fmt.Errorf("failed with %d errors and %d warnings", errors, warnings)
+		}
+	case SeverityInfo:
+		if informs > 0 || warnings > 0 || errors > 0 {
+			return fmt.Errorf("failed with %d errors, %d warnings and %d informs",
+				errors, warnings, informs)
+		}
+	}
+	return f.CheckWarnings(warnings)
+}
+
+// CheckWarnings returns an error if there are more warnings than allowed.
+func (f *FailureThreshold) CheckWarnings(warnings int) error {
+	if f.MaxWarnings >= 0 && warnings > f.MaxWarnings {
+		return fmt.Errorf("failed with %d warnings, the maximum allowed is %d", warnings, f.MaxWarnings)
+	}
+	return nil
+}
+
+// severityWeight ranks severities, anything unknown (including 'none') is -1.
+func severityWeight(severity string) int {
+	switch severity {
+	case SeverityError:
+		return 3
+	case SeverityWarn:
+		return 2
+	case SeverityInfo:
+		return 1
+	case SeverityHint:
+		return 0
+	}
+	return -1
+}
@@ -0,0 +1,38 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFailureThreshold_IsFailure(t *testing.T) {
+	th := NewFailureThreshold(SeverityWarn, -1)
+	assert.True(t, th.IsFailure(SeverityError))
+	assert.True(t, th.IsFailure(SeverityWarn))
+	assert.False(t, th.IsFailure(SeverityInfo))
+	assert.False(t, th.IsFailure(SeverityHint))
+	assert.False(t, th.IsFailure(""))
+
+	assert.False(t, NewFailureThreshold(SeverityNone, -1).IsFailure(SeverityError))
+	assert.True(t, NewFailureThreshold(SeverityInfo, -1).IsFailure(SeverityInfo))
+	assert.False(t, NewFailureThreshold(SeverityError, -1).IsFailure(SeverityWarn))
+}
+
+func TestFailureThreshold_Check(t *testing.T) {
+	assert.NoError(t, NewFailureThreshold(SeverityError, -1).Check(0, 10, 10))
+	assert.Error(t, NewFailureThreshold(SeverityError, -1).Check(1, 0, 0))
+	assert.Error(t, NewFailureThreshold(SeverityWarn, -1).Check(0, 1, 0))
+	assert.Error(t, NewFailureThreshold(SeverityInfo, -1).Check(0, 0, 1))
+	assert.NoError(t, NewFailureThreshold(SeverityNone, 0).Check(5, 5, 5))
+	assert.NoError(t, NewFailureThreshold("unknown", -1).Check(5, 5, 5))
+}
+
+func TestFailureThreshold_MaxWarnings(t *testing.T) {
+	th := NewFailureThreshold(SeverityError, 2)
+	assert.NoError(t, th.Check(0, 2, 0))
+	err := th.Check(0, 3, 0)
+	assert.Error(t, err)
+	assert.Equal(t, "failed with 3 warnings, the maximum allowed is 2", err.Error())
+	assert.NoError(t, NewFailureThreshold(SeverityError, -1).CheckWarnings(1000))
+}
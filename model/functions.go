@@ -6,4 +6,7 @@ package model
 const FunctionCategoryCore = "core"
 const FunctionCategoryOpenAPI = "openapi"
 const FunctionCategoryOWASP = "owasp"
+const FunctionCategoryAsyncAPI = "asyncapi"
+const FunctionCategoryJSONSchema = "jsonschema"
 const FunctionCategoryCustomJS = "customjs"
+const FunctionCategoryCustomWASM = "customwasm"
@@ -0,0 +1,108 @@
+package model
+
+import (
+	"fmt"
+	"slices"
+)
+
+// CollapseReferencedResults collapses identical results (the same rule, with the same message, for the same node)
+// into the first of them. A component that is referenced ($ref) in many places is reached by rules every time it is
+// referenced, so the same problem with the component is found again, and again. The collapsed result keeps every
+// path the problem was found at, and referencedFrom (if not nil) is used to fill in where the component is
+// referenced from, for each result that was found more than once.
+func CollapseReferencedResults(results []RuleFunctionResult,
+	referencedFrom func(result *RuleFunctionResult) []string) []RuleFunctionResult {
+	pointers := make([]*RuleFunctionResult, len(results))
+	for i := range results {
+		pointers[i] = &results[i]
+	}
+	collapsed := collapseReferencedResults(pointers, referencedFrom)
+	if len(collapsed) == len(results) {
+		return results
+	}
+	out := make([]RuleFunctionResult, 0, len(collapsed))
+	for _, r := range collapsed {
+		out = append(out, *r)
+	}
+	return out
+}
+
+// CollapseReferencedResults collapses identical results in the set into one (see CollapseReferencedResults), the
+// counts and categories are recalculated. The number of results removed is returned.
+func (rr *RuleResultSet) CollapseReferencedResults(referencedFrom func(result *RuleFunctionResult) []string) int {
+	before := len(rr.Results)
+	rr.Results = collapseReferencedResults(rr.Results, referencedFrom)
+	if removed := before - len(rr.Results); removed > 0 {
+		rr.ResetCounts()
+		rr.CategoryMap = make(map[*RuleCategory][]*RuleFunctionResult)
+		rr.GetErrorCount()
+		rr.GetWarnCount()
+		rr.GetInfoCount()
+		return removed
+	}
+	return 0
+}
+
+func collapseReferencedResults(results []*RuleFunctionResult,
+	referencedFrom func(result *RuleFunctionResult) []string) []*RuleFunctionResult {
+	seen := make(map[string]*RuleFunctionResult, len(results))
+	isRepeated := make(map[*RuleFunctionResult]bool)
+	var repeated []*RuleFunctionResult
+	collapsed := make([]*RuleFunctionResult, 0, len(results))
+	for _, r := range results {
+		key := referencedResultKey(r)
+		first, found := seen[key]
+		if !found {
+			seen[key] = r
+			collapsed = append(collapsed, r)
+			continue
+		}
+		if !isRepeated[first] {
+			isRepeated[first] = true
+			repeated = append(repeated, first)
+			if len(first.Paths) == 0 && first.Path != "" {
+				first.Paths = []string{first.Path}
+			}
+		}
+		paths := r.Paths
+		if len(paths) == 0 && r.Path != "" {
+			paths = []string{r.Path}
+		}
+		for _, p := range paths {
+			if !slices.Contains(first.Paths, p) {
+				first.Paths = append(first.Paths, p)
+			}
+		}
+	}
+	for _, r := range repeated {
+		if len(r.Paths) == 1 {
+			r.Paths = nil // found at the same path every time.
+		}
+		if referencedFrom != nil {
+			for _, ref := range referencedFrom(r) {
+				if !slices.Contains(r.ReferencedFrom, ref) {
+					r.ReferencedFrom = append(r.ReferencedFrom, ref)
+				}
+			}
+		}
+	}
+	return collapsed
+}
+
+// referencedResultKey identifies a result by its rule, message and where it was found. The node is used if there
+// is one, nodes reached through a reference are the same node. Without a node (or once results have been detached
+// from the document), the file and position are used.
+func referencedResultKey(r *RuleFunctionResult) string {
+	ruleId := r.RuleId
+	if ruleId == "" && r.Rule != nil {
+		ruleId = r.Rule.Id
+	}
+	if r.StartNode != nil && r.Origin == nil {
+		return fmt.Sprintf("%s|%s|%p", ruleId, r.Message, r.StartNode)
+	}
+	file, line, col := "", r.Range.Start.Line, r.Range.Start.Char
+	if r.Origin != nil {
+		file, line, col = r.Origin.AbsoluteLocation, r.Origin.Line, r.Origin.Column
+	}
+	return fmt.Sprintf("%s|%s|%s|%d:%d", ruleId, r.Message, file, line, col)
+}
@@ -0,0 +1,54 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCollapseReferencedResults(t *testing.T) {
+	node := &yaml.Node{Line: 10, Column: 5}
+	rule := &Rule{Id: "pet-name", Severity: SeverityWarn, RuleCategory: RuleCategories[CategoryInfo]}
+	results := []RuleFunctionResult{
+		{Rule: rule, RuleId: rule.Id, Message: "no name", StartNode: node, Path: "$.components.schemas['Pet']"},
+		{Rule: rule, RuleId: rule.Id, Message: "no name", StartNode: node, Path: "$.paths['/a'].get.schema"},
+		{Rule: rule, RuleId: rule.Id, Message: "no name", StartNode: node, Path: "$.paths['/b'].get.schema"},
+		{Rule: rule, RuleId: rule.Id, Message: "other", StartNode: node, Path: "$.components.schemas['Pet']"},
+		{Rule: rule, RuleId: rule.Id, Message: "no name", StartNode: &yaml.Node{Line: 10, Column: 5}, Path: "$.info"},
+	}
+
+	var asked int
+	collapsed := CollapseReferencedResults(results, func(result *RuleFunctionResult) []string {
+		asked++
+		return []string{"4:5", "8:5", "4:5"}
+	})
+	assert.Len(t, collapsed, 3)
+	assert.Equal(t, 1, asked)
+	assert.Equal(t, []string{"$.components.schemas['Pet']", "$.paths['/a'].get.schema", "$.paths['/b'].get.schema"},
+		collapsed[0].Paths)
+	assert.Equal(t, []string{"4:5", "8:5"}, collapsed[0].ReferencedFrom)
+	assert.Empty(t, collapsed[1].ReferencedFrom)
+	assert.Empty(t, collapsed[2].ReferencedFrom)
+}
+
+func TestCollapseReferencedResults_ByLocation(t *testing.T) {
+	rule := &Rule{Id: "pet-name", Severity: SeverityError, RuleCategory: RuleCategories[CategoryInfo]}
+	origin := func() *RuleFunctionResult {
+		r := &RuleFunctionResult{Rule: rule, RuleId: rule.Id, Message: "no name", Path: "$.info",
+			StartNode: &yaml.Node{}}
+		r.Origin = &index.NodeOrigin{AbsoluteLocation: "pet.yaml", Line: 3, Column: 1}
+		return r
+	}
+	rs := NewRuleResultSetPointer([]*RuleFunctionResult{origin(), origin(), {Rule: rule, RuleId: rule.Id,
+		Message: "no name", Path: "$.info", Range: reports.Range{Start: reports.RangeItem{Line: 3, Char: 1}}}})
+	assert.Equal(t, 3, rs.GetErrorCount())
+
+	assert.Equal(t, 1, rs.CollapseReferencedResults(nil))
+	assert.Len(t, rs.Results, 2)
+	assert.Nil(t, rs.Results[0].Paths) // found at the same path both times.
+	assert.Equal(t, 2, rs.GetErrorCount())
+	assert.Equal(t, 0, rs.CollapseReferencedResults(nil))
+}
@@ -6,31 +6,67 @@ import (
 
 // ReportStatistics represents statistics for an individual specification report.
 type ReportStatistics struct {
-	ID                 uint                 `gorm:"primaryKey" json:"-" yaml:"-"`
-	CreatedAt          time.Time            `json:"-" yaml:"-"`
-	UpdatedAt          time.Time            `json:"-" yaml:"-"`
-	FilesizeKB         int                  `json:"filesizeKb,omitempty" yaml:"filesizeKb,omitempty"`
-	FilesizeBytes      int                  `json:"filesizeBytes,omitempty" yaml:"filesizeBytes,omitempty"`
-	SpecType           string               `json:"specType,omitempty" yaml:"specType,omitempty"`
-	SpecFormat         string               `json:"specFormat,omitempty" yaml:"specFormat,omitempty"`
-	Version            string               `json:"version,omitempty" yaml:"version,omitempty"`
-	References         int                  `json:"references,omitempty" yaml:"references,omitempty"`
-	ExternalDocs       int                  `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
-	Schemas            int                  `json:"schemas,omitempty" yaml:"schemas,omitempty"`
-	Parameters         int                  `json:"parameters,omitempty" yaml:"parameters,omitempty"`
-	Links              int                  `json:"links,omitempty" yaml:"links,omitempty"`
-	Paths              int                  `json:"paths,omitempty" yaml:"paths,omitempty"`
-	Operations         int                  `json:"operations,omitempty" yaml:"operations,omitempty"`
-	Tags               int                  `json:"tags,omitempty" yaml:"tags,omitempty"`
-	Examples           int                  `json:"examples,omitempty" yaml:"examples,omitempty"`
-	Enums              int                  `json:"enums,omitempty" yaml:"enums,omitempty"`
-	Security           int                  `json:"security,omitempty" yaml:"security,omitempty"`
-	OverallScore       int                  `json:"overallScore,omitempty" yaml:"overallScore,omitempty"`
-	TotalErrors        int                  `json:"totalErrors,omitempty" yaml:"totalErrors,omitempty"`
-	TotalWarnings      int                  `json:"totalWarnings,omitempty" yaml:"totalWarnings,omitempty"`
-	TotalInfo          int                  `json:"totalInfo,omitempty" yaml:"totalInfo,omitempty"`
-	TotalHints         int                  `json:"totalHints,omitempty" yaml:"totalHints,omitempty"`
-	CategoryStatistics []*CategoryStatistic `gorm:"foreignKey:ID" json:"categoryStatistics,omitempty" yaml:"categoryStatistics,omitempty"`
+	ID                  uint                  `gorm:"primaryKey" json:"-" yaml:"-"`
+	CreatedAt           time.Time             `json:"-" yaml:"-"`
+	UpdatedAt           time.Time             `json:"-" yaml:"-"`
+	FilesizeKB          int                   `json:"filesizeKb,omitempty" yaml:"filesizeKb,omitempty"`
+	FilesizeBytes       int                   `json:"filesizeBytes,omitempty" yaml:"filesizeBytes,omitempty"`
+	SpecType            string                `json:"specType,omitempty" yaml:"specType,omitempty"`
+	SpecFormat          string                `json:"specFormat,omitempty" yaml:"specFormat,omitempty"`
+	DocumentKind        string                `json:"documentKind,omitempty" yaml:"documentKind,omitempty"`
+	Version             string                `json:"version,omitempty" yaml:"version,omitempty"`
+	References          int                   `json:"references,omitempty" yaml:"references,omitempty"`
+	ExternalDocs        int                   `json:"externalDocs,omitempty" yaml:"externalDocs,omitempty"`
+	Schemas             int                   `json:"schemas,omitempty" yaml:"schemas,omitempty"`
+	Parameters          int                   `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Links               int                   `json:"links,omitempty" yaml:"links,omitempty"`
+	Paths               int                   `json:"paths,omitempty" yaml:"paths,omitempty"`
+	Operations          int                   `json:"operations,omitempty" yaml:"operations,omitempty"`
+	Tags                int                   `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Examples            int                   `json:"examples,omitempty" yaml:"examples,omitempty"`
+	Enums               int                   `json:"enums,omitempty" yaml:"enums,omitempty"`
+	Security            int                   `json:"security,omitempty" yaml:"security,omitempty"`
+	OverallScore        int                   `json:"overallScore,omitempty" yaml:"overallScore,omitempty"`
+	TotalErrors         int                   `json:"totalErrors,omitempty" yaml:"totalErrors,omitempty"`
+	TotalWarnings       int                   `json:"totalWarnings,omitempty" yaml:"totalWarnings,omitempty"`
+	TotalInfo           int                   `json:"totalInfo,omitempty" yaml:"totalInfo,omitempty"`
+	TotalHints          int                   `json:"totalHints,omitempty" yaml:"totalHints,omitempty"`
+	CategoryStatistics  []*CategoryStatistic  `gorm:"foreignKey:ID" json:"categoryStatistics,omitempty" yaml:"categoryStatistics,omitempty"`
+	OperationStatistics []*OperationStatistic `gorm:"foreignKey:ID" json:"operationStatistics,omitempty" yaml:"operationStatistics,omitempty"`
+	RuleTimings         []*RuleTiming         `gorm:"foreignKey:ID" json:"ruleTimings,omitempty" yaml:"ruleTimings,omitempty"`
+	SecuritySummary     *SecuritySummary      `gorm:"-" json:"securitySummary,omitempty" yaml:"securitySummary,omitempty"`
+	RuleStatistics      []*RuleStatistic      `gorm:"foreignKey:ID" json:"ruleStatistics,omitempty" yaml:"ruleStatistics,omitempty"`
+	Scoring             *Scoring              `gorm:"-" json:"scoring,omitempty" yaml:"scoring,omitempty"`
+}
+
+// ScoreWeights are how many points a single result of each severity takes off a score (out of 100).
+type ScoreWeights struct {
+	Error   float64 `json:"error" yaml:"error"`
+	Warning float64 `json:"warning" yaml:"warning"`
+	Info    float64 `json:"info" yaml:"info"`
+	Hint    float64 `json:"hint" yaml:"hint"`
+}
+
+// Scoring is how the scores of a report were calculated, the weights of every severity, replaced by the weights of
+// a category for the results of that category. It's recorded in the statistics, so a score can be audited.
+type Scoring struct {
+	ScoreWeights `json:",inline" yaml:",inline"`
+	Categories   map[string]*ScoreWeights `json:"categories,omitempty" yaml:"categories,omitempty"`
+}
+
+// RuleStatistic represents the score and number of issues for a single rule that has results
+type RuleStatistic struct {
+	ID         uint      `gorm:"primaryKey" json:"-" yaml:"-"`
+	CreatedAt  time.Time `json:"-" yaml:"-"`
+	UpdatedAt  time.Time `json:"-" yaml:"-"`
+	RuleId     string    `json:"ruleId" yaml:"ruleId"`
+	CategoryId string    `json:"categoryId,omitempty" yaml:"categoryId,omitempty"`
+	NumIssues  int       `json:"numIssues" yaml:"numIssues"`
+	Score      int       `json:"score" yaml:"score"`
+	Warnings   int       `json:"warnings" yaml:"warnings"`
+	Errors     int       `json:"errors" yaml:"errors"`
+	Info       int       `json:"info" yaml:"info"`
+	Hints      int       `json:"hints" yaml:"hints"`
 }
 
 // CategoryStatistic represents the number of issues for a particular category
@@ -47,3 +83,49 @@ type CategoryStatistic struct {
 	Info         int       `json:"info" yaml:"info"`
 	Hints        int       `json:"hints" yaml:"hints"`
 }
+
+// OperationStatistic represents the quality score and number of issues for a single operation (path and method)
+type OperationStatistic struct {
+	ID          uint      `gorm:"primaryKey" json:"-" yaml:"-"`
+	CreatedAt   time.Time `json:"-" yaml:"-"`
+	UpdatedAt   time.Time `json:"-" yaml:"-"`
+	Path        string    `json:"path" yaml:"path"`
+	Method      string    `json:"method" yaml:"method"`
+	OperationId string    `json:"operationId,omitempty" yaml:"operationId,omitempty"`
+	NumIssues   int       `json:"numIssues" yaml:"numIssues"`
+	Score       int       `json:"score" yaml:"score"`
+	Warnings    int       `json:"warnings" yaml:"warnings"`
+	Errors      int       `json:"errors" yaml:"errors"`
+	Info        int       `json:"info" yaml:"info"`
+	Hints       int       `json:"hints" yaml:"hints"`
+}
+
+// RuleTiming represents how long a single rule took to run (wall time), and how many results it found
+type RuleTiming struct {
+	ID         uint      `gorm:"primaryKey" json:"-" yaml:"-"`
+	CreatedAt  time.Time `json:"-" yaml:"-"`
+	UpdatedAt  time.Time `json:"-" yaml:"-"`
+	RuleId     string    `json:"ruleId" yaml:"ruleId"`
+	DurationMs float64   `json:"durationMs" yaml:"durationMs"`
+	Results    int       `json:"results" yaml:"results"`
+	TimedOut   bool      `json:"timedOut,omitempty" yaml:"timedOut,omitempty"`
+}
+
+// SecuritySummary groups the findings of security rules by the CWE weaknesses and OWASP API Security Top 10 entries
+// their rules map to. A finding is counted in every group its rule maps to.
+type SecuritySummary struct {
+	Findings int              `json:"findings" yaml:"findings"`
+	CWE      []*SecurityGroup `json:"cwe,omitempty" yaml:"cwe,omitempty"`
+	OWASP    []*SecurityGroup `json:"owasp,omitempty" yaml:"owasp,omitempty"`
+}
+
+// SecurityGroup is the number of findings for a single CWE weakness, or OWASP API Security Top 10 entry
+type SecurityGroup struct {
+	Id       string   `json:"id" yaml:"id"`
+	Name     string   `json:"name,omitempty" yaml:"name,omitempty"`
+	Findings int      `json:"findings" yaml:"findings"`
+	Errors   int      `json:"errors" yaml:"errors"`
+	Warnings int      `json:"warnings" yaml:"warnings"`
+	Info     int      `json:"info" yaml:"info"`
+	Rules    []string `json:"rules" yaml:"rules"`
+}
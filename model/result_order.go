@@ -0,0 +1,136 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package model
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ResultOrder is the order results are sorted in.
+type ResultOrder string
+
+// Orders results can be sorted in, ties are broken by line, then by rule.
+const (
+	OrderByLine     ResultOrder = "line"     // by line number, the default.
+	OrderBySeverity ResultOrder = "severity" // errors first, then warnings, info and hints.
+	OrderByRule     ResultOrder = "rule"     // by rule id.
+	OrderByFile     ResultOrder = "file"     // by the file a result was found in, results in the specification first.
+)
+
+// ResultOrders are all the orders results can be sorted in.
+var ResultOrders = []ResultOrder{OrderByLine, OrderBySeverity, OrderByRule, OrderByFile}
+
+// ParseResultOrder returns the order with the given name, an empty name is the default order (by line).
+func ParseResultOrder(name string) (ResultOrder, error) {
+	if name == "" {
+		return OrderByLine, nil
+	}
+	for _, o := range ResultOrders {
+		if strings.EqualFold(name, string(o)) {
+			return o, nil
+		}
+	}
+	names := make([]string, len(ResultOrders))
+	for i, o := range ResultOrders {
+		names[i] = string(o)
+	}
+	return "", fmt.Errorf("unknown sort order '%s', valid orders are: %s", name, strings.Join(names, ", "))
+}
+
+// SortResults re-orders the results of the set. Like SortResultsByLineNumber, this is a destructive sort.
+func (rr *RuleResultSet) SortResults(order ResultOrder) []*RuleFunctionResult {
+	if rr == nil {
+		return nil
+	}
+	SortRuleFunctionResults(rr.Results, order)
+	return rr.Results
+}
+
+// PageResults returns a page of the results of the set, up to limit results starting at offset. The results are
+// not copied or changed, a limit of zero or less returns everything after the offset.
+func (rr *RuleResultSet) PageResults(offset, limit int) []*RuleFunctionResult {
+	if rr == nil {
+		return nil
+	}
+	return PageRuleFunctionResults(rr.Results, offset, limit)
+}
+
+// SortRuleFunctionResults sorts results in place, for results that are not held by a RuleResultSet (like the
+// results of a group, or a category).
+func SortRuleFunctionResults(results []*RuleFunctionResult, order ResultOrder) {
+	sort.SliceStable(results, func(i, j int) bool {
+		a, b := results[i], results[j]
+		switch order {
+		case OrderBySeverity:
+			if sa, sb := resultSeverityRank(a), resultSeverityRank(b); sa != sb {
+				return sa < sb
+			}
+		case OrderByRule:
+			if ra, rb := resultRuleId(a), resultRuleId(b); ra != rb {
+				return ra < rb
+			}
+		case OrderByFile:
+			if fa, fb := resultFile(a), resultFile(b); fa != fb {
+				return fa < fb
+			}
+		}
+		if la, lb := resultLine(a), resultLine(b); la != lb {
+			return la < lb
+		}
+		return resultRuleId(a) < resultRuleId(b)
+	})
+}
+
+// PageRuleFunctionResults returns up to limit results starting at offset, a limit of zero or less returns
+// everything after the offset.
+func PageRuleFunctionResults(results []*RuleFunctionResult, offset, limit int) []*RuleFunctionResult {
+	offset = min(max(offset, 0), len(results))
+	results = results[offset:]
+	if limit > 0 && len(results) > limit {
+		results = results[:limit]
+	}
+	return results
+}
+
+// resultSeverityRank ranks errors first, results without a severity are warnings (see getCount).
+func resultSeverityRank(r *RuleFunctionResult) int {
+	if r.Rule == nil {
+		return 4
+	}
+	if r.Rule.Severity == "" {
+		return 1
+	}
+	if rank := r.Rule.GetSeverityAsIntValue(); rank >= 0 {
+		return rank
+	}
+	return 4
+}
+
+func resultRuleId(r *RuleFunctionResult) string {
+	if r.Rule != nil {
+		return r.Rule.Id
+	}
+	return r.RuleId
+}
+
+// resultLine is the line a result starts at, results without a line are sorted last.
+func resultLine(r *RuleFunctionResult) int {
+	if r.StartNode != nil {
+		return r.StartNode.Line
+	}
+	if r.Range.Start.Line > 0 {
+		return r.Range.Start.Line
+	}
+	return int(^uint(0) >> 1)
+}
+
+// resultFile is where a result was found, empty for the specification itself.
+func resultFile(r *RuleFunctionResult) string {
+	if r.Origin != nil {
+		return r.Origin.AbsoluteLocation
+	}
+	return ""
+}
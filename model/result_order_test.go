@@ -0,0 +1,67 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func buildOrderedResultSet() *RuleResultSet {
+	errRule := &Rule{Id: "b-rule", Severity: SeverityError}
+	warnRule := &Rule{Id: "a-rule", Severity: SeverityWarn}
+	hintRule := &Rule{Id: "c-rule", Severity: SeverityHint}
+	return NewRuleResultSetPointer([]*RuleFunctionResult{
+		{Rule: warnRule, Message: "one", StartNode: &yaml.Node{Line: 1}},
+		{Rule: hintRule, Message: "two", StartNode: &yaml.Node{Line: 20}},
+		{Rule: errRule, Message: "three", StartNode: &yaml.Node{Line: 30}},
+		{Rule: errRule, Message: "four", StartNode: &yaml.Node{Line: 5},
+			Origin: &index.NodeOrigin{AbsoluteLocation: "/specs/pets.yaml"}},
+		{Rule: warnRule, Message: "five"},
+	})
+}
+
+func messages(results []*RuleFunctionResult) []string {
+	var m []string
+	for _, r := range results {
+		m = append(m, r.Message)
+	}
+	return m
+}
+
+func TestRuleResultSet_SortResults(t *testing.T) {
+	rs := buildOrderedResultSet()
+	assert.Equal(t, []string{"one", "four", "two", "three", "five"}, messages(rs.SortResults(OrderByLine)))
+	assert.Equal(t, []string{"four", "three", "one", "five", "two"}, messages(rs.SortResults(OrderBySeverity)))
+	assert.Equal(t, []string{"one", "five", "four", "three", "two"}, messages(rs.SortResults(OrderByRule)))
+	assert.Equal(t, []string{"one", "two", "three", "five", "four"}, messages(rs.SortResults(OrderByFile)))
+
+	// the default is by line.
+	assert.Equal(t, []string{"one", "four", "two", "three", "five"}, messages(rs.SortResults("")))
+}
+
+func TestRuleResultSet_PageResults(t *testing.T) {
+	rs := buildOrderedResultSet()
+	assert.Len(t, rs.PageResults(0, 0), 5)
+	assert.Equal(t, []string{"one", "two"}, messages(rs.PageResults(0, 2)))
+	assert.Equal(t, []string{"four", "five"}, messages(rs.PageResults(3, 10)))
+	assert.Empty(t, rs.PageResults(10, 2))
+	assert.Len(t, rs.PageResults(-1, 0), 5)
+
+	var nilSet *RuleResultSet
+	assert.Nil(t, nilSet.PageResults(0, 1))
+}
+
+func TestParseResultOrder(t *testing.T) {
+	order, err := ParseResultOrder("Severity")
+	assert.NoError(t, err)
+	assert.Equal(t, OrderBySeverity, order)
+
+	order, err = ParseResultOrder("")
+	assert.NoError(t, err)
+	assert.Equal(t, OrderByLine, order)
+
+	_, err = ParseResultOrder("colour")
+	assert.ErrorContains(t, err, "valid orders are: line, severity, rule, file")
+}
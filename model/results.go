@@ -193,12 +193,55 @@ func (rr *RuleResultSet) GetInfoCount() int {
 	}
 }
 
+// SetSuppressedResults will add results that were suppressed inline by the specification to the set, suppressed
+// results are not counted as errors, warnings or info, they are kept so they can be reported separately.
+func (rr *RuleResultSet) SetSuppressedResults(results []RuleFunctionResult) {
+	rr.Suppressed = nil
+	for _, res := range results {
+		n := res
+		rr.Suppressed = append(rr.Suppressed, &n)
+	}
+}
+
+// GetSuppressedCount will return the number of results that were suppressed inline by the specification.
+func (rr *RuleResultSet) GetSuppressedCount() int {
+	return len(rr.Suppressed)
+}
+
+// GetRuleCategories returns the ordered categories results are reported under, the built-in categories along with
+// the categories of the ruleset that produced the results, and any other categories the rules were filed under.
+func (rr *RuleResultSet) GetRuleCategories() []*RuleCategory {
+	var categories []*RuleCategory
+	seen := make(map[string]bool)
+	for _, result := range rr.Results {
+		if result.Rule == nil || result.Rule.RuleCategory == nil || seen[result.Rule.RuleCategory.Id] {
+			continue
+		}
+		seen[result.Rule.RuleCategory.Id] = true
+		if !IsBuiltInRuleCategory(result.Rule.RuleCategory.Id) {
+			categories = append(categories, result.Rule.RuleCategory)
+		}
+	}
+	return OrderRuleCategories(append(categories, rr.RuleSetCategories...))
+}
+
+// GetRuleCategory returns the category with the supplied id, as listed by GetRuleCategories.
+func (rr *RuleResultSet) GetRuleCategory(category string) *RuleCategory {
+	for _, cat := range rr.GetRuleCategories() {
+		if cat.Id == category {
+			return cat
+		}
+	}
+	return RuleCategories[category]
+}
+
 // GetResultsByRuleCategory will return results filtered by the supplied category
 func (rr *RuleResultSet) GetResultsByRuleCategory(category string) []*RuleFunctionResult {
 
 	// check for seen state.
-	if RuleCategories[category] != nil && rr.CategoryMap[RuleCategories[category]] != nil {
-		return rr.CategoryMap[RuleCategories[category]]
+	cat := rr.GetRuleCategory(category)
+	if cat != nil && rr.CategoryMap[cat] != nil {
+		return rr.CategoryMap[cat]
 	}
 
 	var results []*RuleFunctionResult
@@ -216,8 +259,8 @@ func (rr *RuleResultSet) GetResultsByRuleCategory(category string) []*RuleFuncti
 			}
 		}
 	}
-	if RuleCategories[category] != nil && len(results) > 0 {
-		rr.CategoryMap[RuleCategories[category]] = results
+	if cat != nil && len(results) > 0 {
+		rr.CategoryMap[cat] = results
 	}
 	return results
 }
@@ -281,7 +324,7 @@ func (rr *RuleResultSet) GetHintByRuleCategory(category string) []*RuleFunctionR
 // GetRuleResultsForCategory will return all rules that returned results during linting, complete with pre
 // compiled statistics for easy indexing.
 func (rr *RuleResultSet) GetRuleResultsForCategory(category string) *RuleResultsForCategory {
-	cat := RuleCategories[category]
+	cat := rr.GetRuleCategory(category)
 	if cat == nil {
 		return nil
 	}
@@ -377,7 +420,7 @@ func (rr *RuleResultSet) PrepareForSerialization(info *datamodel.SpecInfo) {
 	if rr == nil || info == nil {
 		return
 	}
-	wg.Add(len(rr.Results))
+	wg.Add(len(rr.Results) + len(rr.Suppressed))
 
 	data := strings.Split(string(*info.SpecBytes), "\n")
 
@@ -405,6 +448,7 @@ func (rr *RuleResultSet) PrepareForSerialization(info *datamodel.SpecInfo) {
 		if result.Rule != nil {
 			result.RuleId = result.Rule.Id
 			result.RuleSeverity = result.Rule.Severity
+			result.RuleTags = result.Rule.Tags
 		}
 		wg.Done()
 	}
@@ -412,6 +456,9 @@ func (rr *RuleResultSet) PrepareForSerialization(info *datamodel.SpecInfo) {
 	for _, res := range rr.Results {
 		go prep(res, &wg, data)
 	}
+	for _, res := range rr.Suppressed {
+		go prep(res, &wg, data)
+	}
 
 	wg.Wait()
 }
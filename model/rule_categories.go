@@ -3,9 +3,18 @@
 
 package model
 
+import (
+	"math"
+	"slices"
+	"sort"
+)
+
 var RuleCategories = make(map[string]*RuleCategory)
 var RuleCategoriesOrdered []*RuleCategory
 
+// builtInCategories are the categories vacuum ships with, rulesets have their own copies, these never change.
+var builtInCategories = make(map[string]bool)
+
 func init() {
 	RuleCategories[CategoryExamples] = &RuleCategory{
 		Id:   CategoryExamples,
@@ -83,4 +92,75 @@ func init() {
 		RuleCategories[CategoryExamples],
 		RuleCategories[CategoryOWASP],
 	)
+
+	// built-in categories are ten apart, so a ruleset can put its own categories between them.
+	for i, cat := range RuleCategoriesOrdered {
+		cat.Order = (i + 1) * 10
+		builtInCategories[cat.Id] = true
+	}
+	builtInCategories[CategoryAll] = true
+}
+
+// NewRuleSetCategory returns a copy of a category defined by a ruleset, for its rules to be filed under. A category
+// with the id of a built-in one starts as a copy of it, so the ruleset can rename or reorder it, the built-in
+// categories themselves are never changed. Returns nil if the category has no id, or is the 'all' category.
+func NewRuleSetCategory(category *RuleCategory) *RuleCategory {
+	if category == nil || category.Id == "" || category.Id == CategoryAll {
+		return nil
+	}
+	cat := *category
+	if builtIn := RuleCategories[cat.Id]; builtIn != nil {
+		cat = *builtIn
+		if category.Name != "" {
+			cat.Name = category.Name
+		}
+		if category.Description != "" {
+			cat.Description = category.Description
+		}
+		if category.Order != 0 {
+			cat.Order = category.Order
+		}
+	}
+	if cat.Name == "" {
+		cat.Name = cat.Id
+	}
+	return &cat
+}
+
+// OrderRuleCategories returns the built-in categories along with the categories of a ruleset, by their order
+// (categories without an order are listed last). A ruleset category with the id of a built-in one takes its place.
+func OrderRuleCategories(categories []*RuleCategory) []*RuleCategory {
+	if len(categories) == 0 {
+		return RuleCategoriesOrdered
+	}
+	ordered := slices.Clone(RuleCategoriesOrdered)
+	for _, cat := range categories {
+		i := slices.IndexFunc(ordered, func(c *RuleCategory) bool { return c.Id == cat.Id })
+		if i >= 0 {
+			ordered[i] = cat
+		} else {
+			ordered = append(ordered, cat)
+		}
+	}
+	return sortRuleCategories(ordered)
+}
+
+// IsBuiltInRuleCategory returns true if the category is one vacuum ships with, rather than one from a ruleset.
+func IsBuiltInRuleCategory(id string) bool {
+	return builtInCategories[id]
+}
+
+// sortRuleCategories returns a sorted copy, anything already walking the categories keeps its own list.
+func sortRuleCategories(categories []*RuleCategory) []*RuleCategory {
+	sorted := slices.Clone(categories)
+	order := func(c *RuleCategory) int {
+		if c.Order == 0 {
+			return math.MaxInt
+		}
+		return c.Order
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return order(sorted[i]) < order(sorted[j])
+	})
+	return sorted
 }
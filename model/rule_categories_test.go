@@ -0,0 +1,61 @@
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewRuleSetCategory(t *testing.T) {
+	naming := NewRuleSetCategory(&RuleCategory{Id: "naming", Name: "Naming", Description: "Chapter 2", Order: 35})
+	errors := NewRuleSetCategory(&RuleCategory{Id: "errors"})
+
+	assert.Equal(t, "Naming", naming.Name)
+	assert.Equal(t, "errors", errors.Name)
+	assert.Nil(t, RuleCategories["naming"])
+	assert.False(t, IsBuiltInRuleCategory("naming"))
+	assert.True(t, IsBuiltInRuleCategory(CategoryTags))
+
+	// between tags (30) and schemas (40), categories without an order are last.
+	ordered := OrderRuleCategories([]*RuleCategory{errors, naming})
+	assert.Equal(t, RuleCategories[CategoryTags], ordered[2])
+	assert.Equal(t, naming, ordered[3])
+	assert.Equal(t, RuleCategories[CategorySchemas], ordered[4])
+	assert.Equal(t, errors, ordered[len(ordered)-1])
+	assert.Len(t, ordered, len(RuleCategoriesOrdered)+2)
+	assert.Equal(t, RuleCategoriesOrdered, OrderRuleCategories(nil))
+}
+
+func TestNewRuleSetCategory_BuiltIn(t *testing.T) {
+	tags := NewRuleSetCategory(&RuleCategory{Id: CategoryTags, Name: "Navigation", Order: 5})
+	assert.NotSame(t, RuleCategories[CategoryTags], tags)
+	assert.Equal(t, "Navigation", tags.Name)
+	assert.Equal(t, RuleCategories[CategoryTags].Description, tags.Description)
+	assert.Equal(t, tags, OrderRuleCategories([]*RuleCategory{tags})[0])
+
+	// the built-in category is never changed.
+	assert.Equal(t, "Tags", RuleCategories[CategoryTags].Name)
+	assert.Equal(t, 30, RuleCategories[CategoryTags].Order)
+
+	assert.Nil(t, NewRuleSetCategory(&RuleCategory{Id: CategoryAll, Name: "Party"}))
+	assert.Nil(t, NewRuleSetCategory(&RuleCategory{Name: "No id"}))
+}
+
+func TestRuleResultSet_GetResultsByRuleCategory_RuleSetCategory(t *testing.T) {
+	cat := NewRuleSetCategory(&RuleCategory{Id: "naming", Name: "Naming"})
+	tags := NewRuleSetCategory(&RuleCategory{Id: CategoryTags, Name: "Navigation"})
+	results := []RuleFunctionResult{
+		{Message: "camel", Rule: &Rule{Id: "camel-case", RuleCategory: cat, Severity: SeverityError}},
+		{Message: "tags", Rule: &Rule{Id: "tags", RuleCategory: RuleCategories[CategoryTags]}},
+	}
+	rs := NewRuleResultSet(results)
+	rs.RuleSetCategories = []*RuleCategory{tags}
+	assert.Len(t, rs.GetResultsByRuleCategory("naming"), 1)
+	assert.Equal(t, "naming", rs.GetRuleResultsForCategory("naming").Category.Id)
+	assert.Contains(t, DocumentKindJSONSchema.RuleCategoriesWith(rs.GetRuleCategories()), cat)
+
+	// built-in rules are reported under the category of the ruleset, with the same id.
+	assert.Len(t, rs.GetResultsByRuleCategory(CategoryTags), 1)
+	assert.Equal(t, "Navigation", rs.GetRuleResultsForCategory(CategoryTags).Category.Name)
+	assert.Same(t, tags, rs.GetRuleCategory(CategoryTags))
+}
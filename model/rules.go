@@ -1,8 +1,10 @@
 package model
 
 import (
+	"context"
 	_ "embed" // embedding is not supported by golint,
 	"encoding/json"
+	"fmt"
 	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/pb33f/doctor/model"
 	"github.com/pb33f/libopenapi"
@@ -12,6 +14,7 @@ import (
 	"gopkg.in/yaml.v3"
 	"log/slog"
 	"regexp"
+	"strings"
 	"sync"
 	"time"
 )
@@ -45,6 +48,7 @@ type RuleCategory struct {
 	Id          string `json:"id" yaml:"id"`                             // The category ID
 	Name        string `json:"name,omitempty" yaml:"name"`               // The name of the category
 	Description string `json:"description,omitempty" yaml:"description"` // What is the category all about?
+	Order       int    `json:"order,omitempty" yaml:"order,omitempty"`   // Where the category is listed, lowest first.
 }
 
 // RuleFunctionContext defines a RuleAction, Rule and Options for a RuleFunction being run.
@@ -58,6 +62,7 @@ type RuleFunctionContext struct {
 	Document   libopenapi.Document `json:"-" yaml:"-"`                                       // A reference to the document being parsed
 	DrDocument *model.DrDocument   `json:"-" yaml:"-"`                                       // A high level, more powerful representation of the document being parsed. Powered by the doctor.
 	Logger     *slog.Logger        `json:"-" yaml:"-"`                                       // Custom logger
+	Context    context.Context     `json:"-" yaml:"-"`                                       // Done when the rule has timed out, or linting has been cancelled.
 
 	// optionsCache caches the converted options map to avoid repeated interface conversions
 	optionsCache map[string]string `json:"-" yaml:"-"`
@@ -77,6 +82,16 @@ type RuleFunctionResult struct {
 	EndNode      *yaml.Node        `json:"-" yaml:"-"`                               // end of the violation
 	Timestamp    *time.Time        `json:"-" yaml:"-"`                               // When the result was created.
 
+	// RuleTags are the tags of the rule used, so results can be filtered and grouped by tag.
+	RuleTags []string `json:"ruleTags,omitempty" yaml:"ruleTags,omitempty"`
+
+	// ReferencedFrom are the locations (line:column) of every $ref to the component a result is for, once the results
+	// found each time the component was reached have been collapsed into this one (see CollapseReferencedResults).
+	ReferencedFrom []string `json:"referencedFrom,omitempty" yaml:"referencedFrom,omitempty"`
+
+	// CodeFrame is the source around the result, when it has been captured (see CaptureCodeFrames).
+	CodeFrame *CodeFrame `json:"codeFrame,omitempty" yaml:"codeFrame,omitempty"`
+
 	// ModelContext may or may nor be populated, depending on the rule used and the context of the rule. If it is
 	// populated, then this is a reference to the model that fired the rule. (not currently used yet)
 	ModelContext any `json:"-" yaml:"-"`
@@ -88,13 +103,22 @@ type IgnoredItems map[string][]string
 // RuleResultSet contains all the results found during a linting run, and all the methods required to
 // filter, sort and calculate counts.
 type RuleResultSet struct {
-	Results     []*RuleFunctionResult                   `json:"results,omitempty" yaml:"results,omitempty"` // All the results!
-	WarnCount   int                                     `json:"warningCount" yaml:"warningCount"`           // Total warnings
-	ErrorCount  int                                     `json:"errorCount" yaml:"errorCount"`               // Total errors
-	InfoCount   int                                     `json:"infoCount" yaml:"infoCount"`                 // Total info
+	Results     []*RuleFunctionResult                   `json:"results,omitempty" yaml:"results,omitempty"`       // All the results!
+	WarnCount   int                                     `json:"warningCount" yaml:"warningCount"`                 // Total warnings
+	ErrorCount  int                                     `json:"errorCount" yaml:"errorCount"`                     // Total errors
+	InfoCount   int                                     `json:"infoCount" yaml:"infoCount"`                       // Total info
+	Suppressed  []*RuleFunctionResult                   `json:"suppressed,omitempty" yaml:"suppressed,omitempty"` // Results suppressed inline by the spec
 	CategoryMap map[*RuleCategory][]*RuleFunctionResult `json:"-" yaml:"-"`
+
+	// RuleSetCategories are the categories of the ruleset that produced the results, see GetRuleCategories.
+	RuleSetCategories []*RuleCategory `json:"-" yaml:"-"`
 }
 
+// RuleFix is an optional mutation a rule can register, to automatically resolve a result. The node is the node the
+// result was reported against, and the parent is the mapping or sequence that contains it. A fix must only make
+// safe changes and return true if the node was changed.
+type RuleFix func(node, parent *yaml.Node) bool
+
 // RuleFunction is any compatible structure that can be used to run vacuum rules.
 type RuleFunction interface {
 	RunRule(nodes []*yaml.Node, context RuleFunctionContext) []RuleFunctionResult // The place where logic is run
@@ -125,6 +149,14 @@ type Rule struct {
 	RuleCategory       *RuleCategory  `json:"category,omitempty" yaml:"category,omitempty"`
 	Name               string         `json:"-" yaml:"-"`
 	HowToFix           string         `json:"howToFix,omitempty" yaml:"howToFix,omitempty"`
+	DocumentationURL   string         `json:"documentationUrl,omitempty" yaml:"documentationUrl,omitempty"`
+	GoodExample        string         `json:"goodExample,omitempty" yaml:"goodExample,omitempty"` // a snippet that passes the rule.
+	BadExample         string         `json:"badExample,omitempty" yaml:"badExample,omitempty"`   // a snippet that fails the rule.
+	Tags               []string       `json:"tags,omitempty" yaml:"tags,omitempty"`
+	CWE                []string       `json:"cwe,omitempty" yaml:"cwe,omitempty"`     // CWE identifiers of the weaknesses the rule finds (CWE-79).
+	OWASP              []string       `json:"owasp,omitempty" yaml:"owasp,omitempty"` // OWASP API Security Top 10 entries the rule maps to (API1:2023).
+	Fix                RuleFix        `json:"-" yaml:"-"`                             // optional, used by 'lint --fix'
+	FixTitle           string         `json:"-" yaml:"-"`                             // describes what Fix does, used by language server quick fixes.
 }
 
 // RuleFunctionProperty is used by RuleFunctionSchema to describe the functionOptions a Rule accepts
@@ -144,6 +176,19 @@ type RuleFunctionSchema struct {
 	ErrorMessage  string                 `json:"errorMessage,omitempty" yaml:"errorMessage,omitempty"`   // Error message to be used in case of failed validartion.
 }
 
+// GetDocumentationURL returns the link to the documentation for the rule, its documentationUrl if it has one,
+// otherwise the page for the rule on the vacuum website.
+func (r *Rule) GetDocumentationURL() string {
+	if r.DocumentationURL != "" {
+		return r.DocumentationURL
+	}
+	if r.RuleCategory == nil {
+		return fmt.Sprintf("%s/rules/unknown", WebsiteUrl)
+	}
+	return fmt.Sprintf("%s/rules/%s/%s", WebsiteUrl, strings.ToLower(r.RuleCategory.Id),
+		strings.ReplaceAll(strings.ToLower(r.Id), "$", ""))
+}
+
 // GetSeverityAsIntValue will return the severity state of the rule as an integer. If the severity is not known
 // then -1 is returned.
 func (r *Rule) GetSeverityAsIntValue() int {
@@ -160,6 +205,35 @@ func (r *Rule) GetSeverityAsIntValue() int {
 	return -1
 }
 
+// HasTag returns true if the rule has been tagged with the tag.
+func (r *Rule) HasTag(tag string) bool {
+	for _, t := range r.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesTags returns true if the rule has at least one of the include tags (or include is empty), and none of the
+// exclude tags.
+func (r *Rule) MatchesTags(include, exclude []string) bool {
+	for _, tag := range exclude {
+		if r.HasTag(tag) {
+			return false
+		}
+	}
+	if len(include) == 0 {
+		return true
+	}
+	for _, tag := range include {
+		if r.HasTag(tag) {
+			return true
+		}
+	}
+	return false
+}
+
 // GetPropertyDescription is a shortcut method for extracting the description of a property by its name.
 func (rfs RuleFunctionSchema) GetPropertyDescription(name string) string {
 	for _, prop := range rfs.Properties {
@@ -218,6 +292,7 @@ func ReturnPooledRuleFunctionResult(result *RuleFunctionResult) {
 		result.Origin = nil
 		result.Timestamp = nil
 		result.ModelContext = nil
+		result.CodeFrame = nil
 		ruleFunctionResultPool.Put(result)
 	}
 }
@@ -452,6 +452,19 @@ func TestRule_GetSeverityAsIntValue(t *testing.T) {
 
 }
 
+func TestRule_MatchesTags(t *testing.T) {
+	r := &Rule{Tags: []string{"security", "Style"}}
+	assert.True(t, r.HasTag("style"))
+	assert.False(t, r.HasTag("breaking"))
+
+	assert.True(t, r.MatchesTags(nil, nil))
+	assert.True(t, r.MatchesTags([]string{"breaking", "security"}, nil))
+	assert.False(t, r.MatchesTags([]string{"breaking"}, nil))
+	assert.False(t, r.MatchesTags([]string{"security"}, []string{"style"}))
+	assert.False(t, (&Rule{}).MatchesTags([]string{"security"}, nil))
+	assert.True(t, (&Rule{}).MatchesTags(nil, []string{"style"}))
+}
+
 func TestRuleResultsForCategory_Sort(t *testing.T) {
 
 	r1 := RuleFunctionResult{Rule: &Rule{
@@ -487,3 +500,13 @@ func TestRuleResultsForCategory_Sort(t *testing.T) {
 	assert.Equal(t, "three", catResults.RuleResults[0].Rule.Description) // first result should be lowest sev.
 
 }
+
+func TestRule_GetDocumentationURL(t *testing.T) {
+	r := &Rule{Id: "$my-Rule", RuleCategory: RuleCategories[CategoryOperations]}
+	assert.Equal(t, "https://quobix.com/vacuum/rules/operations/my-rule", r.GetDocumentationURL())
+
+	r.DocumentationURL = "https://example.com/style-guide#my-rule"
+	assert.Equal(t, "https://example.com/style-guide#my-rule", r.GetDocumentationURL())
+
+	assert.Equal(t, "https://quobix.com/vacuum/rules/unknown", (&Rule{Id: "nope"}).GetDocumentationURL())
+}
@@ -0,0 +1,40 @@
+package model
+
+// OWASPAPITop10 names the entries of the OWASP API Security Top 10 (2023), rules map to them with their owasp ids.
+var OWASPAPITop10 = map[string]string{
+	"API1:2023":  "Broken Object Level Authorization",
+	"API2:2023":  "Broken Authentication",
+	"API3:2023":  "Broken Object Property Level Authorization",
+	"API4:2023":  "Unrestricted Resource Consumption",
+	"API5:2023":  "Broken Function Level Authorization",
+	"API6:2023":  "Unrestricted Access to Sensitive Business Flows",
+	"API7:2023":  "Server Side Request Forgery",
+	"API8:2023":  "Security Misconfiguration",
+	"API9:2023":  "Improper Inventory Management",
+	"API10:2023": "Unsafe Consumption of APIs",
+}
+
+// CWENames names the CWE weaknesses found by the built-in rules. Rules can use any CWE identifier, these are only
+// used to label reports.
+var CWENames = map[string]string{
+	"CWE-20":  "Improper Input Validation",
+	"CWE-79":  "Improper Neutralization of Input During Web Page Generation ('Cross-site Scripting')",
+	"CWE-190": "Integer Overflow or Wraparound",
+	"CWE-209": "Generation of Error Message Containing Sensitive Information",
+	"CWE-287": "Improper Authentication",
+	"CWE-306": "Missing Authentication for Critical Function",
+	"CWE-319": "Cleartext Transmission of Sensitive Information",
+	"CWE-327": "Use of a Broken or Risky Cryptographic Algorithm",
+	"CWE-347": "Improper Verification of Cryptographic Signature",
+	"CWE-522": "Insufficiently Protected Credentials",
+	"CWE-598": "Use of GET Request Method With Sensitive Query Strings",
+	"CWE-639": "Authorization Bypass Through User-Controlled Key",
+	"CWE-755": "Improper Handling of Exceptional Conditions",
+	"CWE-770": "Allocation of Resources Without Limits or Throttling",
+	"CWE-915": "Improperly Controlled Modification of Dynamically-Determined Object Attributes",
+}
+
+// HasSecurityMapping returns true if the rule maps to a CWE weakness, or an OWASP API Security Top 10 entry.
+func (r *Rule) HasSecurityMapping() bool {
+	return len(r.CWE) > 0 || len(r.OWASP) > 0
+}
@@ -0,0 +1,36 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"gopkg.in/yaml.v3"
+)
+
+// asyncAPIKey is a cheap check to avoid parsing documents that can't be AsyncAPI documents.
+var asyncAPIKey = regexp.MustCompile(`["']?asyncapi["']?\s*:`)
+
+// DetectAsyncAPIFormat returns model.AsyncAPI2 or model.AsyncAPI3 if the specification is an AsyncAPI document
+// (it has an 'asyncapi' root key), or an empty string if it's not.
+func DetectAsyncAPIFormat(spec []byte) string {
+	if !asyncAPIKey.Match(spec) {
+		return ""
+	}
+	var root struct {
+		AsyncAPI string `yaml:"asyncapi"`
+	}
+	if err := yaml.Unmarshal(spec, &root); err != nil {
+		return ""
+	}
+	switch {
+	case strings.HasPrefix(root.AsyncAPI, "2."):
+		return model.AsyncAPI2
+	case strings.HasPrefix(root.AsyncAPI, "3."):
+		return model.AsyncAPI3
+	}
+	return ""
+}
@@ -0,0 +1,73 @@
+package motor
+
+import (
+	"os"
+	"sort"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDetectAsyncAPIFormat(t *testing.T) {
+	assert.Equal(t, model.AsyncAPI2, DetectAsyncAPIFormat([]byte("asyncapi: 2.6.0\ninfo: {}")))
+	assert.Equal(t, model.AsyncAPI3, DetectAsyncAPIFormat([]byte(`{"asyncapi": "3.0.0"}`)))
+	assert.Empty(t, DetectAsyncAPIFormat([]byte("asyncapi: 1.2.0")))
+	assert.Empty(t, DetectAsyncAPIFormat([]byte("openapi: 3.1.0\ninfo:\n  description: asyncapi: nope")))
+	assert.Empty(t, DetectAsyncAPIFormat([]byte("openapi: 3.1.0")))
+	assert.Empty(t, DetectAsyncAPIFormat([]byte("asyncapi: [broken")))
+}
+
+func resultRuleIds(results []model.RuleFunctionResult) []string {
+	var ids []string
+	for _, r := range results {
+		ids = append(ids, r.Rule.Id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+func TestApplyRules_AsyncAPI2(t *testing.T) {
+	spec, _ := os.ReadFile("../model/test_files/asyncapi-streetlights-v2.yaml")
+	rs := rulesets.BuildDefaultRuleSets().GenerateAsyncAPIRecommendedRuleSet()
+
+	result := ApplyRulesToRuleSet(&RuleSetExecution{RuleSet: rs, Spec: spec})
+
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, []string{
+		rulesets.AsyncAPIBindingProtocol,
+		rulesets.AsyncAPIChannelAddress,
+		rulesets.AsyncAPIOperationDescription,
+		rulesets.AsyncAPIOperationOperationId,
+	}, resultRuleIds(result.Results))
+}
+
+func TestApplyRules_AsyncAPI3(t *testing.T) {
+	spec, _ := os.ReadFile("../model/test_files/asyncapi-streetlights-v3.yaml")
+	rs := rulesets.BuildDefaultRuleSets().GenerateAsyncAPIRecommendedRuleSet()
+
+	result := ApplyRulesToRuleSet(&RuleSetExecution{RuleSet: rs, Spec: spec})
+
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, []string{
+		rulesets.AsyncAPIChannelAddress,
+		rulesets.AsyncAPIInfoContact,
+		rulesets.AsyncAPIInfoDescription,
+		rulesets.AsyncAPIOperationAction,
+		rulesets.AsyncAPIOperationDescription,
+		rulesets.AsyncAPIServers,
+	}, resultRuleIds(result.Results))
+}
+
+func TestApplyRules_AsyncAPI_SkipsOpenAPIRules(t *testing.T) {
+	spec, _ := os.ReadFile("../model/test_files/asyncapi-streetlights-v3.yaml")
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+
+	result := ApplyRulesToRuleSet(&RuleSetExecution{RuleSet: rs, Spec: spec})
+
+	assert.Empty(t, result.Errors)
+	for _, r := range result.Results {
+		assert.Empty(t, r.Rule.Formats, r.Rule.Id)
+	}
+}
@@ -0,0 +1,400 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"bytes"
+	"errors"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/daveshanley/vacuum/model"
+	"gopkg.in/yaml.v3"
+)
+
+// FixResult is the outcome of applying the fixes registered by rules, to a specification.
+type FixResult struct {
+	Fixed    []*model.RuleFunctionResult // results that were fixed.
+	Original []byte                      // the specification before any fixes were applied.
+	Output   []byte                      // the specification after all fixes were applied.
+}
+
+type fixTarget struct {
+	result *model.RuleFunctionResult
+	node   *yaml.Node
+	parent *yaml.Node
+}
+
+// ApplyFixes will apply the fixes registered by rules (via model.Rule.Fix), for every result supplied. The
+// specification is parsed again, so fixes are applied to a clean tree. The changes are then written into the original
+// text, at the position of the nodes that changed, so everything else (formatting, quoting, comments) is untouched.
+// Changes that can't be written in place (like a key added to a flow mapping) fall back to encoding the whole
+// document again, which keeps comments and ordering, but not the formatting of the rest of the document. Results
+// that belong to a different file than fileName are left alone. Only YAML can be fixed.
+func ApplyFixes(spec []byte, fileName string, results []model.RuleFunctionResult) (*FixResult, error) {
+	fr := &FixResult{Original: spec, Output: spec}
+
+	var fixable []*model.RuleFunctionResult
+	for i := range results {
+		r := &results[i]
+		if r.Rule != nil && r.Rule.Fix != nil && r.StartNode != nil && resultInFile(r, fileName) {
+			fixable = append(fixable, r)
+		}
+	}
+	if len(fixable) == 0 {
+		return fr, nil
+	}
+
+	if trimmed := bytes.TrimSpace(spec); len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[') {
+		return nil, errors.New("only YAML specifications can be fixed, JSON is not supported")
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(spec, &root); err != nil {
+		return nil, err
+	}
+
+	// locate every node before changing anything, as fixes can move things around. The tree is remembered, so the
+	// changes made by fixes can be found afterwards.
+	snapshots := snapshotNodes(&root)
+	var targets []*fixTarget
+	for _, r := range fixable {
+		node, parent := locateNode(&root, nil, r.StartNode)
+		if node != nil {
+			targets = append(targets, &fixTarget{result: r, node: node, parent: parent})
+		}
+	}
+
+	for _, t := range targets {
+		if t.result.Rule.Fix(t.node, t.parent) {
+			fr.Fixed = append(fr.Fixed, t.result)
+		}
+	}
+	if len(fr.Fixed) == 0 {
+		return fr, nil
+	}
+
+	if patched, ok := patchSpec(spec, &root, snapshots); ok {
+		fr.Output = patched
+		return fr, nil
+	}
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(detectIndent(spec))
+	if err := enc.Encode(&root); err != nil {
+		return nil, err
+	}
+	_ = enc.Close()
+	fr.Output = buf.Bytes()
+	return fr, nil
+}
+
+// locateNode finds the node in a tree that sits at the same position (and holds the same value) as the needle,
+// returning it along with its parent.
+func locateNode(node, parent, needle *yaml.Node) (*yaml.Node, *yaml.Node) {
+	if node == nil {
+		return nil, nil
+	}
+	if node.Line == needle.Line && node.Column == needle.Column && node.Kind == needle.Kind &&
+		sameValue(node.Value, needle.Value) && node.Kind != yaml.DocumentNode {
+		return node, parent
+	}
+	if node.Line > needle.Line && node.Kind != yaml.DocumentNode {
+		// nodes are in document order, nothing further down can match.
+		return nil, nil
+	}
+	for _, c := range node.Content {
+		if found, p := locateNode(c, node, needle); found != nil {
+			return found, p
+		}
+	}
+	return nil, nil
+}
+
+// sameValue checks the value of a node matches the value of a result node, which is truncated in low memory mode.
+func sameValue(value, needle string) bool {
+	if len(value) <= LowMemoryValueLength {
+		return value == needle
+	}
+	return strings.HasPrefix(value, needle)
+}
+
+func resultInFile(r *model.RuleFunctionResult, fileName string) bool {
+	if r.Origin == nil || r.Origin.AbsoluteLocation == "" {
+		return true
+	}
+	if fileName == "" {
+		return false
+	}
+	a, aErr := filepath.Abs(fileName)
+	b, bErr := filepath.Abs(r.Origin.AbsoluteLocation)
+	return aErr == nil && bErr == nil && a == b
+}
+
+// detectIndent returns the indentation used by the first indented line of a YAML document, defaulting to two.
+func detectIndent(spec []byte) int {
+	for _, line := range strings.Split(string(spec), "\n") {
+		trimmed := strings.TrimLeft(line, " ")
+		if trimmed == "" || trimmed == line || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if n := len(line) - len(trimmed); n > 1 {
+			return n
+		}
+		return 2
+	}
+	return 2
+}
+
+// nodeSnapshot is the value and content of a node before any fixes were applied.
+type nodeSnapshot struct {
+	value   string
+	content []*yaml.Node
+}
+
+// textEdit replaces length bytes of the specification at offset, with text.
+type textEdit struct {
+	offset int
+	length int
+	text   string
+}
+
+func snapshotNodes(root *yaml.Node) map[*yaml.Node]*nodeSnapshot {
+	snapshots := make(map[*yaml.Node]*nodeSnapshot)
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		if n == nil || snapshots[n] != nil {
+			return
+		}
+		snapshots[n] = &nodeSnapshot{value: n.Value, content: slices.Clone(n.Content)}
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+	walk(root)
+	return snapshots
+}
+
+// patchSpec writes the changes made to the tree into the original text of the specification. Scalars that changed
+// are replaced where they are, keys added to a block mapping are inserted as new lines. False is returned if a change
+// can't be written in place.
+func patchSpec(spec []byte, root *yaml.Node, snapshots map[*yaml.Node]*nodeSnapshot) ([]byte, bool) {
+	lines := lineOffsets(spec)
+	indent := detectIndent(spec)
+	var edits []textEdit
+	ok := true
+	var walk func(n *yaml.Node)
+	walk = func(n *yaml.Node) {
+		snap := snapshots[n]
+		if !ok || snap == nil {
+			return // nodes added by a fix are written along with the mapping they were added to.
+		}
+		switch {
+		case n.Kind == yaml.ScalarNode && n.Value != snap.value:
+			start, length := scalarExtent(spec, lines, n, snap.value)
+			rendered, err := yaml.Marshal(&yaml.Node{Kind: yaml.ScalarNode, Tag: n.Tag, Value: n.Value, Style: n.Style})
+			text := strings.TrimSuffix(string(rendered), "\n")
+			if length == 0 || err != nil || strings.Contains(text, "\n") {
+				ok = false
+				return
+			}
+			edits = append(edits, textEdit{offset: start, length: length, text: text})
+		case !slices.Equal(n.Content, snap.content):
+			inserts, found := mappingInserts(spec, lines, n, snap.content, snapshots, indent)
+			if !found {
+				ok = false
+				return
+			}
+			edits = append(edits, inserts...)
+		}
+		for _, c := range n.Content {
+			walk(c)
+		}
+	}
+	walk(root)
+	if !ok {
+		return nil, false
+	}
+
+	sort.SliceStable(edits, func(i, j int) bool { return edits[i].offset > edits[j].offset })
+	out := slices.Clone(spec)
+	end := len(spec) + 1
+	for _, e := range edits {
+		if e.offset+e.length > end {
+			return nil, false // edits overlap.
+		}
+		out = slices.Concat(out[:e.offset], []byte(e.text), out[e.offset+e.length:])
+		end = e.offset
+	}
+	return out, true
+}
+
+// mappingInserts writes the keys a fix added to a block mapping as new lines, indented like the keys around them.
+// New keys go after the value of the key before them, or before the first key of the mapping.
+func mappingInserts(spec []byte, lines []int, n *yaml.Node, old []*yaml.Node,
+	snapshots map[*yaml.Node]*nodeSnapshot, indent int) ([]textEdit, bool) {
+	if n.Kind != yaml.MappingNode || n.Style&yaml.FlowStyle != 0 || len(old) == 0 || len(n.Content)%2 != 0 {
+		return nil, false
+	}
+	column := old[0].Column
+	keyStart, found := lineColumnOffset(spec, lines, old[0].Line, column)
+	if !found {
+		return nil, false
+	}
+	prefix := strings.Repeat(" ", column-1)
+
+	var edits []textEdit
+	var kept []*yaml.Node
+	var added []*yaml.Node
+	var previous *yaml.Node // the value of the last key that was already there.
+	flush := func(next *yaml.Node) bool {
+		if len(added) == 0 {
+			return true
+		}
+		var buf bytes.Buffer
+		enc := yaml.NewEncoder(&buf)
+		enc.SetIndent(indent)
+		if err := enc.Encode(&yaml.Node{Kind: yaml.MappingNode, Content: added}); err != nil {
+			return false
+		}
+		_ = enc.Close()
+		var text strings.Builder
+		for _, line := range strings.SplitAfter(strings.TrimSuffix(buf.String(), "\n"), "\n") {
+			text.WriteString(prefix + line)
+		}
+		text.WriteString("\n")
+
+		var offset int
+		switch {
+		case previous != nil && endsOnLastLine(spec, lines, previous):
+			last := lastLine(previous)
+			if last < len(lines) {
+				offset = lines[last]
+			} else {
+				offset = len(spec)
+				if !bytes.HasSuffix(spec, []byte("\n")) {
+					text.Reset()
+					text.WriteString("\n" + strings.TrimSuffix(buf.String(), "\n"))
+				}
+			}
+		case previous == nil && next != nil && next.HeadComment == "" &&
+			strings.TrimSpace(string(spec[lines[next.Line-1]:keyStart])) == "":
+			offset = lines[next.Line-1]
+		default:
+			return false
+		}
+		edits = append(edits, textEdit{offset: offset, text: text.String()})
+		added = nil
+		return true
+	}
+	for i := 0; i < len(n.Content); i += 2 {
+		key, value := n.Content[i], n.Content[i+1]
+		if snapshots[key] == nil && snapshots[value] == nil {
+			added = append(added, key, value)
+			continue
+		}
+		if !flush(key) {
+			return nil, false
+		}
+		kept = append(kept, key, value)
+		previous = value
+	}
+	if !flush(nil) || !slices.Equal(kept, old) {
+		return nil, false // keys were removed or moved around.
+	}
+	return edits, true
+}
+
+// endsOnLastLine returns true if nothing in a node carries on past the last line of its nodes, which is where new
+// keys are inserted after it. Block scalars and flow collections spread over many lines carry on.
+func endsOnLastLine(spec []byte, lines []int, n *yaml.Node) bool {
+	switch n.Kind {
+	case yaml.ScalarNode:
+		_, length := scalarExtent(spec, lines, n, n.Value)
+		return length > 0
+	case yaml.AliasNode:
+		return true
+	}
+	if n.Style&yaml.FlowStyle != 0 && lastLine(n) != n.Line {
+		return false
+	}
+	for _, c := range n.Content {
+		if !endsOnLastLine(spec, lines, c) {
+			return false
+		}
+	}
+	return true
+}
+
+// scalarExtent returns the offset and length of the text of a scalar in the specification, as long as it sits on one
+// line. The length is zero if it can't be found.
+func scalarExtent(spec []byte, lines []int, n *yaml.Node, value string) (int, int) {
+	start, found := lineColumnOffset(spec, lines, n.Line, n.Column)
+	if !found {
+		return 0, 0
+	}
+	rest := string(spec[start:])
+	if i := strings.IndexByte(rest, '\n'); i >= 0 {
+		rest = rest[:i]
+	}
+	switch n.Style {
+	case 0:
+		if value != "" && strings.HasPrefix(rest, value) {
+			return start, len(value)
+		}
+	case yaml.SingleQuotedStyle:
+		return start, quotedLength(rest, '\'')
+	case yaml.DoubleQuotedStyle:
+		return start, quotedLength(rest, '"')
+	}
+	return start, 0
+}
+
+// quotedLength returns the length of the quoted scalar the text starts with, or zero if it doesn't end on the line.
+func quotedLength(text string, quote byte) int {
+	if len(text) == 0 || text[0] != quote {
+		return 0
+	}
+	for i := 1; i < len(text); i++ {
+		switch {
+		case quote == '"' && text[i] == '\\':
+			i++
+		case text[i] == quote && quote == '\'' && i+1 < len(text) && text[i+1] == '\'':
+			i++
+		case text[i] == quote:
+			return i + 1
+		}
+	}
+	return 0
+}
+
+// lineOffsets returns the offset of the start of every line.
+func lineOffsets(spec []byte) []int {
+	offsets := []int{0}
+	for i, b := range spec {
+		if b == '\n' && i+1 < len(spec) {
+			offsets = append(offsets, i+1)
+		}
+	}
+	return offsets
+}
+
+// lineColumnOffset converts a line and column (both starting at one, columns count characters) into an offset.
+func lineColumnOffset(spec []byte, lines []int, line, column int) (int, bool) {
+	if line < 1 || line > len(lines) || column < 1 {
+		return 0, false
+	}
+	offset := lines[line-1]
+	for c := 1; c < column; c++ {
+		if offset >= len(spec) || spec[offset] == '\n' {
+			return 0, false
+		}
+		_, size := utf8.DecodeRune(spec[offset:])
+		offset += size
+	}
+	return offset, true
+}
@@ -0,0 +1,148 @@
+package motor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var fixableSpec = `openapi: 3.1.0
+info:
+  title: test
+  version: 1.0.0
+  description: a test
+servers:
+  # the main server
+  - url: https://api.example.com/
+paths:
+  /burgers/:
+    get:
+      operationId: get burgers
+      tags: [a]
+      responses:
+        "200":
+          description: ok
+  /orders:
+    post:
+      summary: create an order
+      operationId: createOrder
+      responses:
+        "201":
+          description: created
+`
+
+func lintFixableSpec(spec string) *RuleSetExecutionResult {
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	rs.Rules[rulesets.Oas3HostTrailingSlash] = rulesets.GetOAS3HostTrailingSlashRule()
+	return ApplyRulesToRuleSet(&RuleSetExecution{RuleSet: rs, Spec: []byte(spec)})
+}
+
+func TestApplyFixes(t *testing.T) {
+	result := lintFixableSpec(fixableSpec)
+
+	fr, err := ApplyFixes([]byte(fixableSpec), "", result.Results)
+	assert.NoError(t, err)
+	assert.Len(t, fr.Fixed, 4)
+
+	out := string(fr.Output)
+	assert.Contains(t, out, "# the main server")
+	assert.Contains(t, out, "- url: https://api.example.com\n")
+	assert.Contains(t, out, "  /burgers:\n")
+	assert.Contains(t, out, "operationId: getBurgers")
+	assert.Contains(t, out, "tags: [a]")
+	assert.Contains(t, out, "description: "+rulesets.OperationDescriptionPlaceholder)
+
+	// ordering is kept, paths are still in the same place.
+	assert.Less(t, strings.Index(out, "/burgers"), strings.Index(out, "/orders"))
+
+	// lint again, everything that was fixable is gone.
+	again := lintFixableSpec(out)
+	fr, err = ApplyFixes(fr.Output, "", again.Results)
+	assert.NoError(t, err)
+	assert.Empty(t, fr.Fixed)
+}
+
+func TestApplyFixes_PathConflict(t *testing.T) {
+	spec := strings.Replace(fixableSpec, "/orders:", "/burgers:", 1)
+	result := lintFixableSpec(spec)
+
+	fr, err := ApplyFixes([]byte(spec), "", result.Results)
+	assert.NoError(t, err)
+	assert.Contains(t, string(fr.Output), "/burgers/:")
+}
+
+func TestApplyFixes_NothingToFix(t *testing.T) {
+	fr, err := ApplyFixes([]byte(fixableSpec), "", nil)
+	assert.NoError(t, err)
+	assert.Empty(t, fr.Fixed)
+	assert.Equal(t, fixableSpec, string(fr.Output))
+}
+
+func TestApplyFixes_JSON(t *testing.T) {
+	spec := `{"openapi": "3.1.0", "info": {"title": "t", "version": "1"}, "paths": {"/a/": {}}}`
+	result := lintFixableSpec(spec)
+	_, err := ApplyFixes([]byte(spec), "", result.Results)
+	assert.Error(t, err)
+}
+
+func TestApplyFixes_KeepsFormatting(t *testing.T) {
+	spec := `openapi: "3.1.0"
+info: {title: test, version: 1.0.0, description: a test}
+servers:
+    - url:   'https://api.example.com/'   # trailing slash
+paths:
+
+    /burgers/:
+        get:
+            operationId: "get burgers"
+            tags: [a]
+            responses:
+                "200": {description: ok}
+`
+	result := lintFixableSpec(spec)
+	fr, err := ApplyFixes([]byte(spec), "", result.Results)
+	assert.NoError(t, err)
+	assert.Len(t, fr.Fixed, 4)
+
+	// only the fixed values change, everything else is left exactly as it was written.
+	assert.Equal(t, `openapi: "3.1.0"
+info: {title: test, version: 1.0.0, description: a test}
+servers:
+    - url:   'https://api.example.com'   # trailing slash
+paths:
+
+    /burgers:
+        get:
+            description: `+rulesets.OperationDescriptionPlaceholder+`
+            operationId: "getBurgers"
+            tags: [a]
+            responses:
+                "200": {description: ok}
+`, string(fr.Output))
+}
+
+func TestPatchSpec(t *testing.T) {
+	spec := "a:\n  b: [1,\n    2]\n  c:   'it''s'  # quoted\nd: |\n  text"
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	snapshots := snapshotNodes(&root)
+
+	a := root.Content[0].Content[1]
+	a.Content[3].Value = "it is"
+	a.Content = append(a.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: "e"}, &yaml.Node{Kind: yaml.ScalarNode, Value: "new"})
+
+	patched, ok := patchSpec([]byte(spec), &root, snapshots)
+	assert.True(t, ok)
+	assert.Equal(t, "a:\n  b: [1,\n    2]\n  c:   'it is'  # quoted\n  e: new\nd: |\n  text", string(patched))
+
+	// a key after a block scalar can't be written in place, the document is encoded again instead.
+	top := root.Content[0]
+	top.Content = append(top.Content,
+		&yaml.Node{Kind: yaml.ScalarNode, Value: "f"}, &yaml.Node{Kind: yaml.ScalarNode, Value: "new"})
+	_, ok = patchSpec([]byte(spec), &root, snapshots)
+	assert.False(t, ok)
+}
@@ -0,0 +1,176 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"path/filepath"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
+)
+
+// InlineIgnoreExtension is the extension used to suppress rules for an object (and everything inside it),
+// directly in the specification. The value is a rule id, or a list of rule ids. A value of '*' suppresses all rules.
+//
+//	get:
+//	  x-vacuum-ignore: [operation-description, operation-tags]
+const InlineIgnoreExtension = "x-vacuum-ignore"
+
+type inlineSuppression struct {
+	startLine int
+	endLine   int
+	all       bool
+	rules     map[string]bool
+}
+
+// suppresses returns true if the result was found inside the suppressed object. Suppressions are collected from
+// the root document, so a result that originates in another (referenced) document is never suppressed, and a result
+// that has an origin in the root document is located by the line of the origin. The root document is known by any
+// of the supplied locations (where the rolodex found it, and the spec file name).
+func (s *inlineSuppression) suppresses(result *model.RuleFunctionResult, rootLocations []string) bool {
+	line := 0
+	if result.StartNode != nil {
+		line = result.StartNode.Line
+	}
+	if result.Origin != nil {
+		if !isRootOrigin(result.Origin, rootLocations) {
+			return false
+		}
+		if result.Origin.Line > 0 {
+			line = result.Origin.Line
+		}
+	}
+	if line == 0 || line < s.startLine || line > s.endLine {
+		return false
+	}
+	if s.all {
+		return true
+	}
+	ruleId := result.RuleId
+	if result.Rule != nil {
+		ruleId = result.Rule.Id
+	}
+	return s.rules[ruleId]
+}
+
+// isRootOrigin returns true if an origin is the root document being linted, known by any of the supplied locations.
+func isRootOrigin(origin *index.NodeOrigin, rootLocations []string) bool {
+	location := origin.AbsoluteLocation
+	if location == "" {
+		return true
+	}
+	absLocation, lErr := filepath.Abs(location)
+	for _, root := range rootLocations {
+		if root == "" {
+			continue
+		}
+		if location == root {
+			return true
+		}
+		absRoot, rErr := filepath.Abs(root)
+		if lErr == nil && rErr == nil && absLocation == absRoot {
+			return true
+		}
+	}
+	return false
+}
+
+// collectInlineSuppressions walks the root document looking for objects that contain the InlineIgnoreExtension.
+// Suppressions are located by line, the resolved and unresolved documents are parsed separately, so the nodes
+// are not shared between them, but the lines are.
+func collectInlineSuppressions(root *yaml.Node) []*inlineSuppression {
+	var suppressions []*inlineSuppression
+	var walk func(node, key *yaml.Node)
+	walk = func(node, key *yaml.Node) {
+		if node == nil {
+			return
+		}
+		switch node.Kind {
+		case yaml.DocumentNode, yaml.SequenceNode:
+			for _, n := range node.Content {
+				walk(n, nil)
+			}
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == InlineIgnoreExtension {
+					if s := buildInlineSuppression(node, key, node.Content[i+1]); s != nil {
+						suppressions = append(suppressions, s)
+					}
+					continue
+				}
+				walk(node.Content[i+1], node.Content[i])
+			}
+		}
+	}
+	walk(root, nil)
+	return suppressions
+}
+
+func buildInlineSuppression(node, key, value *yaml.Node) *inlineSuppression {
+	s := &inlineSuppression{
+		startLine: node.Line,
+		endLine:   lastLine(node),
+		rules:     make(map[string]bool),
+	}
+	// results for an object are often reported against the key that owns it.
+	if key != nil && key.Line > 0 && key.Line < s.startLine {
+		s.startLine = key.Line
+	}
+	var ids []*yaml.Node
+	switch value.Kind {
+	case yaml.ScalarNode:
+		ids = []*yaml.Node{value}
+	case yaml.SequenceNode:
+		ids = value.Content
+	}
+	for _, id := range ids {
+		if id.Kind != yaml.ScalarNode || id.Value == "" {
+			continue
+		}
+		if id.Value == "*" {
+			s.all = true
+		}
+		s.rules[id.Value] = true
+	}
+	if !s.all && len(s.rules) == 0 {
+		return nil
+	}
+	return s
+}
+
+func lastLine(node *yaml.Node) int {
+	line := node.Line
+	for _, n := range node.Content {
+		if l := lastLine(n); l > line {
+			line = l
+		}
+	}
+	return line
+}
+
+// filterInlineSuppressions splits results into those that should be reported, and those that were suppressed. The
+// rootLocations name the root document the suppressions were collected from.
+func filterInlineSuppressions(suppressions []*inlineSuppression, rootLocations []string,
+	results []model.RuleFunctionResult) ([]model.RuleFunctionResult, []model.RuleFunctionResult) {
+	if len(suppressions) == 0 {
+		return results, nil
+	}
+	var kept, suppressed []model.RuleFunctionResult
+	for i := range results {
+		found := false
+		for _, s := range suppressions {
+			if s.suppresses(&results[i], rootLocations) {
+				found = true
+				break
+			}
+		}
+		if found {
+			suppressed = append(suppressed, results[i])
+		} else {
+			kept = append(kept, results[i])
+		}
+	}
+	return kept, suppressed
+}
@@ -0,0 +1,101 @@
+package motor
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestApplyRulesToRuleSet_InlineSuppression(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: suppressed
+  version: 1.0.0
+paths:
+  /burgers:
+    get:
+      x-vacuum-ignore: [operation-description]
+      operationId: getBurgers
+      responses:
+        "200":
+          description: ok
+  /fries:
+    get:
+      operationId: getFries
+      responses:
+        "200":
+          description: ok`
+
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	results := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet: rs,
+		Spec:    []byte(spec),
+	})
+
+	var reported, suppressed int
+	for _, r := range results.Results {
+		if r.Rule.Id == "operation-description" {
+			reported++
+			assert.Equal(t, 14, r.StartNode.Line)
+		}
+	}
+	for _, r := range results.Suppressed {
+		assert.Equal(t, "operation-description", r.Rule.Id)
+		suppressed++
+	}
+	assert.Equal(t, 1, reported)
+	assert.Equal(t, 1, suppressed)
+}
+
+func TestCollectInlineSuppressions(t *testing.T) {
+	spec := `x-vacuum-ignore: "*"
+paths:
+  /a:
+    x-vacuum-ignore: rule-a
+    get:
+      summary: a
+  /b:
+    x-vacuum-ignore: []`
+
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	suppressions := collectInlineSuppressions(&root)
+	assert.Len(t, suppressions, 2)
+
+	assert.True(t, suppressions[0].all)
+	assert.Equal(t, 1, suppressions[0].startLine)
+	assert.Equal(t, 8, suppressions[0].endLine)
+
+	assert.False(t, suppressions[1].all)
+	assert.Equal(t, 3, suppressions[1].startLine)
+	assert.Equal(t, 6, suppressions[1].endLine)
+
+	inside := &model.RuleFunctionResult{Rule: &model.Rule{Id: "rule-a"}, StartNode: &yaml.Node{Line: 6}}
+	outside := &model.RuleFunctionResult{Rule: &model.Rule{Id: "rule-a"}, StartNode: &yaml.Node{Line: 7}}
+	other := &model.RuleFunctionResult{Rule: &model.Rule{Id: "rule-b"}, StartNode: &yaml.Node{Line: 5}}
+	assert.True(t, suppressions[1].suppresses(inside, []string{"spec.yaml"}))
+	assert.False(t, suppressions[1].suppresses(outside, []string{"spec.yaml"}))
+	assert.False(t, suppressions[1].suppresses(other, []string{"spec.yaml"}))
+
+	// a result from a referenced document is never suppressed, even if the line matches.
+	referenced := &model.RuleFunctionResult{Rule: &model.Rule{Id: "rule-a"}, StartNode: &yaml.Node{Line: 6},
+		Origin: &index.NodeOrigin{AbsoluteLocation: "components/schemas.yaml", Line: 6}}
+	assert.False(t, suppressions[1].suppresses(referenced, []string{"spec.yaml"}))
+
+	// a result that originates in the root document is located by the line of the origin.
+	rooted := &model.RuleFunctionResult{Rule: &model.Rule{Id: "rule-a"}, StartNode: &yaml.Node{Line: 40},
+		Origin: &index.NodeOrigin{AbsoluteLocation: "spec.yaml", Line: 5}}
+	assert.True(t, suppressions[1].suppresses(rooted, []string{"spec.yaml"}))
+
+	// a root document without a file is known by the location the rolodex gives it, other files with the same
+	// name are not the root document.
+	placeholder := &model.RuleFunctionResult{Rule: &model.Rule{Id: "rule-a"}, StartNode: &yaml.Node{Line: 40},
+		Origin: &index.NodeOrigin{AbsoluteLocation: "/specs/root.yaml", Line: 5}}
+	assert.True(t, suppressions[1].suppresses(placeholder, []string{"/specs/root.yaml"}))
+	placeholder.Origin.AbsoluteLocation = "/specs/components/root.yaml"
+	assert.False(t, suppressions[1].suppresses(placeholder, []string{"/specs/root.yaml"}))
+}
@@ -0,0 +1,46 @@
+package motor
+
+import (
+	"os"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyRules_JSONSchema(t *testing.T) {
+	spec, _ := os.ReadFile("../model/test_files/json-schema-pets.json")
+	rs := rulesets.BuildDefaultRuleSets().GenerateJSONSchemaRuleSet()
+
+	result := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet:      rs,
+		Spec:         spec,
+		DocumentKind: model.DocumentKindJSONSchema,
+	})
+
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, model.JSONSchema, result.SpecInfo.SpecFormat)
+	assert.Equal(t, []string{
+		rulesets.JSONSchemaDescriptions,
+		rulesets.JSONSchemaEnumExamples,
+		rulesets.JSONSchemaTypes,
+		rulesets.JSONSchemaUnreachableDefs,
+	}, resultRuleIds(result.Results))
+}
+
+func TestApplyRules_JSONSchema_SkipsOpenAPIRules(t *testing.T) {
+	spec, _ := os.ReadFile("../model/test_files/json-schema-pets.json")
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+
+	result := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet:      rs,
+		Spec:         spec,
+		DocumentKind: model.DocumentKindJSONSchema,
+	})
+
+	assert.Empty(t, result.Errors)
+	for _, r := range result.Results {
+		assert.Empty(t, r.Rule.Formats, r.Rule.Id)
+	}
+}
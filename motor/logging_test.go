@@ -0,0 +1,33 @@
+package motor
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyRulesToRuleSet_LogAttributes(t *testing.T) {
+	var b bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&b, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	ApplyRulesToRuleSet(&RuleSetExecution{RuleSet: rs, Spec: []byte("openapi: 3.1.0\ninfo:\n  title: pets"),
+		SpecFileName: "pets.yaml", Logger: logger})
+
+	ruleRecords := 0
+	for _, line := range bytes.Split(bytes.TrimSpace(b.Bytes()), []byte("\n")) {
+		var record map[string]any
+		assert.NoError(t, json.Unmarshal(line, &record))
+		assert.Equal(t, "pets.yaml", record["document"])
+		if record["msg"] == "rule completed" {
+			ruleRecords++
+			assert.NotEmpty(t, record["rule"])
+			assert.Contains(t, record, "duration")
+		}
+	}
+	assert.Equal(t, len(rs.Rules), ruleRecords)
+}
@@ -0,0 +1,96 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"unicode/utf8"
+
+	"github.com/daveshanley/vacuum/model"
+	"gopkg.in/yaml.v3"
+)
+
+// LowMemoryValueLength is the longest value a result keeps for a node in low memory mode, anything longer is
+// truncated. Values are only kept so results can be matched back to the specification, snippets are rendered from
+// the specification itself.
+const LowMemoryValueLength = 256
+
+// LowMemoryGCPercent is the garbage collection target used by vacuum in low memory mode (see debug.SetGCPercent),
+// collecting more often keeps the peak much lower, for a little more time spent linting.
+const LowMemoryGCPercent = 50
+
+// nodeBlockSize is the number of nodes a nodePool allocates at a time.
+const nodeBlockSize = 512
+
+// nodePool creates detached copies of the nodes results point to. A result only needs to know where the node is,
+// but holding on to the node itself keeps everything below it (and the trees it was rendered in) alive. Nodes are
+// allocated in blocks, and a node that is used by more than one result is only copied once.
+type nodePool struct {
+	copies map[*yaml.Node]*yaml.Node
+	block  []yaml.Node
+}
+
+func newNodePool() *nodePool {
+	return &nodePool{copies: make(map[*yaml.Node]*yaml.Node)}
+}
+
+// detach returns a copy of the node without any content, comments or (very long) values.
+func (p *nodePool) detach(node *yaml.Node) *yaml.Node {
+	if node == nil {
+		return nil
+	}
+	if c, ok := p.copies[node]; ok {
+		return c
+	}
+	if len(p.block) == 0 {
+		p.block = make([]yaml.Node, nodeBlockSize)
+	}
+	c := &p.block[0]
+	p.block = p.block[1:]
+
+	c.Kind = node.Kind
+	c.Style = node.Style
+	c.Tag = node.Tag
+	c.Value = truncateValue(node.Value)
+	c.Line = node.Line
+	c.Column = node.Column
+	p.copies[node] = c
+	return c
+}
+
+// compact detaches every node held by the results, and drops anything else that points back into the document.
+func (p *nodePool) compact(results []model.RuleFunctionResult) {
+	for i := range results {
+		r := &results[i]
+		r.StartNode = p.detach(r.StartNode)
+		r.EndNode = p.detach(r.EndNode)
+		r.ModelContext = nil
+		if r.Origin != nil {
+			origin := *r.Origin
+			origin.Node = nil
+			origin.ValueNode = nil
+			origin.Index = nil
+			r.Origin = &origin
+		}
+	}
+}
+
+// truncateValue cuts a value down to LowMemoryValueLength, without splitting a character. The copy is made so the
+// truncated value does not hold on to the original string.
+func truncateValue(value string) string {
+	if len(value) <= LowMemoryValueLength {
+		return value
+	}
+	cut := LowMemoryValueLength
+	for cut > 0 && !utf8.RuneStart(value[cut]) {
+		cut--
+	}
+	return string([]byte(value[:cut]))
+}
+
+// releaseDocuments drops everything the execution holds on to that is only needed while rules run (the unresolved
+// document and the doctor), so it can be collected as soon as linting is done.
+func releaseDocuments(execution *RuleSetExecution) {
+	execution.DrDocument = nil
+	execution.IndexUnresolved = nil
+}
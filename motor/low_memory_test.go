@@ -0,0 +1,154 @@
+package motor
+
+import (
+	"os"
+	"runtime"
+	"runtime/debug"
+	"runtime/metrics"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestNodePool_Detach(t *testing.T) {
+	var root yaml.Node
+	_ = yaml.Unmarshal([]byte("a:\n  b: c\n"), &root)
+	mapping := root.Content[0]
+
+	pool := newNodePool()
+	detached := pool.detach(mapping)
+	assert.Equal(t, mapping.Line, detached.Line)
+	assert.Equal(t, mapping.Column, detached.Column)
+	assert.Equal(t, yaml.MappingNode, detached.Kind)
+	assert.Empty(t, detached.Content)
+	assert.Same(t, detached, pool.detach(mapping))
+	assert.Nil(t, pool.detach(nil))
+
+	long := &yaml.Node{Kind: yaml.ScalarNode, Value: strings.Repeat("é", LowMemoryValueLength)}
+	value := pool.detach(long).Value
+	assert.Len(t, value, LowMemoryValueLength)
+	assert.True(t, strings.HasPrefix(long.Value, value))
+	assert.True(t, sameValue(long.Value, value))
+	assert.False(t, sameValue("short", "shor"))
+}
+
+func TestNodePool_Compact(t *testing.T) {
+	node := &yaml.Node{Kind: yaml.MappingNode, Line: 3, Column: 2, Content: []*yaml.Node{{}, {}}}
+	results := []model.RuleFunctionResult{{
+		StartNode:    node,
+		EndNode:      node,
+		ModelContext: "model",
+		Origin:       &index.NodeOrigin{Node: node, Line: 3, Index: &index.SpecIndex{}},
+	}}
+	newNodePool().compact(results)
+
+	assert.Empty(t, results[0].StartNode.Content)
+	assert.Same(t, results[0].StartNode, results[0].EndNode)
+	assert.Nil(t, results[0].ModelContext)
+	assert.Nil(t, results[0].Origin.Node)
+	assert.Nil(t, results[0].Origin.Index)
+	assert.Equal(t, 3, results[0].Origin.Line)
+}
+
+func TestApplyRulesToRuleSet_LowMemory(t *testing.T) {
+	spec, _ := os.ReadFile("../model/test_files/petstorev3.json")
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+
+	standard := ApplyRulesToRuleSet(&RuleSetExecution{RuleSet: rs, Spec: spec, SilenceLogs: true})
+	execution := &RuleSetExecution{RuleSet: rs, Spec: spec, SilenceLogs: true, LowMemory: true}
+	lowMemory := ApplyRulesToRuleSet(execution)
+
+	assert.Empty(t, lowMemory.Errors)
+	assert.Len(t, lowMemory.Results, len(standard.Results))
+	assert.NotEmpty(t, lowMemory.Results)
+
+	lines := func(results []model.RuleFunctionResult) map[string]int {
+		found := make(map[string]int)
+		for _, r := range results {
+			found[r.RuleId+r.Path+buildLocationString(r.StartNode.Line, r.StartNode.Column)]++
+		}
+		return found
+	}
+	assert.Equal(t, lines(standard.Results), lines(lowMemory.Results))
+	for _, r := range lowMemory.Results {
+		assert.Empty(t, r.StartNode.Content)
+		assert.LessOrEqual(t, len(r.StartNode.Value), LowMemoryValueLength)
+	}
+	assert.Nil(t, execution.DrDocument)
+	assert.Nil(t, execution.IndexUnresolved)
+	assert.NotNil(t, lowMemory.Index)
+}
+
+// benchmarkRetainedMemory lints the stripe spec and reports the peak heap while linting, and the heap that is
+// still in use (after a collection) while only the results are held on to. Low memory mode collects garbage with
+// the same target vacuum uses.
+func benchmarkRetainedMemory(b *testing.B, lowMemory bool) {
+	if lowMemory {
+		defer debug.SetGCPercent(debug.SetGCPercent(LowMemoryGCPercent))
+	}
+	spec, _ := os.ReadFile("../model/test_files/stripe.yaml")
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	samples := []metrics.Sample{{Name: "/memory/classes/heap/objects:bytes"}}
+	heap := func() uint64 {
+		metrics.Read(samples)
+		return samples[0].Value.Uint64()
+	}
+
+	var peak, retained uint64
+	for n := 0; n < b.N; n++ {
+		runtime.GC()
+		base := heap()
+
+		done := make(chan bool)
+		peaked := make(chan uint64)
+		go func() {
+			var p uint64
+			ticker := time.NewTicker(time.Millisecond * 5)
+			defer ticker.Stop()
+			for {
+				select {
+				case <-done:
+					peaked <- p
+					return
+				case <-ticker.C:
+					if h := heap(); h > p {
+						p = h
+					}
+				}
+			}
+		}()
+
+		results := ApplyRulesToRuleSet(&RuleSetExecution{
+			RuleSet:     rs,
+			Spec:        spec,
+			SilenceLogs: true,
+			LowMemory:   lowMemory,
+		}).Results
+
+		done <- true
+		if p := <-peaked; p > base {
+			peak += p - base
+		}
+		runtime.GC()
+		if h := heap(); h > base {
+			retained += h - base
+		}
+		runtime.KeepAlive(results)
+	}
+	b.ReportMetric(float64(peak)/float64(b.N)/1024/1024, "peak-heap-MB")
+	b.ReportMetric(float64(retained)/float64(b.N)/1024/1024, "retained-MB")
+}
+
+func Benchmark_StripeSpecRetainedMemory(b *testing.B) {
+	benchmarkRetainedMemory(b, false)
+}
+
+func Benchmark_StripeSpecRetainedMemory_LowMemory(b *testing.B) {
+	benchmarkRetainedMemory(b, true)
+}
@@ -0,0 +1,28 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/datamodel"
+)
+
+// buildAsOpenAPI31 lets libopenapi build a model of an OpenAPI 3.2 document, which it only does for OpenAPI 3.0 and
+// 3.1 documents. 3.2 is a superset of 3.1, so the document is built as 3.1. The returned function puts the format
+// back once the models are built, so rules are still picked for 3.2, and the document is checked against the 3.2
+// schema.
+func buildAsOpenAPI31(infos ...*datamodel.SpecInfo) func() {
+	var changed []*datamodel.SpecInfo
+	for _, info := range infos {
+		if info != nil && info.SpecFormat == model.OAS32 {
+			info.SpecFormat = model.OAS31
+			changed = append(changed, info)
+		}
+	}
+	return func() {
+		for _, info := range changed {
+			info.SpecFormat = model.OAS32
+		}
+	}
+}
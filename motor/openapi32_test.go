@@ -0,0 +1,66 @@
+package motor
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyRulesToRuleSet_OpenAPI32(t *testing.T) {
+	spec := `openapi: 3.2.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    query:
+      operationId: searchPets
+      requestBody:
+        content:
+          application/json:
+            schema:
+              type: object
+      responses:
+        "200":
+          description: the pets
+    additionalOperations:
+      LINK:
+        responses:
+          "204":
+            description: linked
+      Get:
+        operationId: getPets
+        responses:
+          "200":
+            description: the pets`
+
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	result := ApplyRulesToRuleSet(&RuleSetExecution{RuleSet: rs, Spec: []byte(spec), SilenceLogs: true})
+
+	assert.Empty(t, result.Errors)
+	assert.Equal(t, model.OAS32, result.SpecInfo.SpecFormat)
+
+	found := make(map[string][]string)
+	for _, r := range result.Results {
+		found[r.Rule.Id] = append(found[r.Rule.Id], r.Path)
+	}
+	assert.Empty(t, found[rulesets.Oas3Schema])
+	assert.Equal(t, []string{"$.paths['/pets'].additionalOperations['LINK']"}, found["operation-operationId"])
+	assert.Equal(t, []string{"$.paths['/pets'].additionalOperations['Get']"}, found[rulesets.Oas3AdditionalOperations])
+}
+
+func TestBuildAsOpenAPI31(t *testing.T) {
+	info := &datamodel.SpecInfo{SpecFormat: model.OAS32}
+	other := &datamodel.SpecInfo{SpecFormat: model.OAS3}
+
+	restore := buildAsOpenAPI31(info, other, nil)
+	assert.Equal(t, model.OAS31, info.SpecFormat)
+	assert.Equal(t, model.OAS3, other.SpecFormat)
+
+	restore()
+	assert.Equal(t, model.OAS32, info.SpecFormat)
+	assert.Equal(t, model.OAS3, other.SpecFormat)
+}
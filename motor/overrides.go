@@ -0,0 +1,186 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+)
+
+// pathOverride changes a rule for the part of a document below a path, it's created from an override with a
+// JSON pointer (like 'openapi.yaml#/paths/~1pets').
+type pathOverride struct {
+	ruleId   string
+	segments []string
+	severity string
+	enabled  bool
+}
+
+// applyOverrides returns the ruleset to run against a document, with the overrides matching the document applied.
+// Globs are matched against the path of the document, relative globs are relative to the OverridesDir of the ruleset.
+// The ruleset is never changed, a copy is returned. Overrides are resolved in order, so later overrides win, and a
+// rule turned off by one override can be turned back on by another. Overrides with a JSON pointer can only be applied
+// to the results, they are returned so they can be applied once the rules have run.
+func applyOverrides(rs *rulesets.RuleSet, specFileName string) (*rulesets.RuleSet, []pathOverride) {
+	if rs == nil || len(rs.Overrides) == 0 || specFileName == "" {
+		return rs, nil
+	}
+
+	overridden := rs.Copy()
+
+	// rules are only removed once every override has been resolved.
+	disabled := make(map[string]bool)
+	var pathOverrides []pathOverride
+	for _, override := range rs.Overrides {
+		for _, file := range override.Files {
+			glob, pointer, _ := strings.Cut(file, "#")
+			if rs.OverridesDir != "" && !filepath.IsAbs(glob) {
+				glob = filepath.Join(rs.OverridesDir, glob)
+			}
+			if !vacuumUtils.MatchGlob(glob, specFileName) {
+				continue
+			}
+			for ruleId, value := range override.Rules {
+				severity, enabled, ok := overrideSeverity(value)
+				if !ok {
+					continue
+				}
+				if pointer != "" {
+					pathOverrides = append(pathOverrides, pathOverride{
+						ruleId:   ruleId,
+						segments: pointerSegments(pointer),
+						severity: severity,
+						enabled:  enabled,
+					})
+					continue
+				}
+				rule := overridden.Rules[ruleId]
+				if rule == nil {
+					continue
+				}
+				if !enabled {
+					disabled[ruleId] = true
+					continue
+				}
+				delete(disabled, ruleId)
+				if severity != "" {
+					copied := *rule
+					copied.Severity = severity
+					overridden.Rules[ruleId] = &copied
+				}
+			}
+		}
+	}
+	for ruleId := range disabled {
+		delete(overridden.Rules, ruleId)
+	}
+	return overridden, pathOverrides
+}
+
+// applyPathOverrides removes, or changes the severity of, the results below the path of an override. Like the
+// overrides of rules, later overrides win.
+func applyPathOverrides(results []model.RuleFunctionResult, overrides []pathOverride) []model.RuleFunctionResult {
+	if len(overrides) == 0 {
+		return results
+	}
+	copies := make(map[*model.Rule]map[string]*model.Rule)
+	var filtered []model.RuleFunctionResult
+	for _, result := range results {
+		keep := true
+		severity := ""
+		for _, override := range overrides {
+			if result.Rule == nil || result.Rule.Id != override.ruleId ||
+				!hasSegmentPrefix(vacuumUtils.JSONPathSegments(result.Path), override.segments) {
+				continue
+			}
+			keep = override.enabled
+			if override.severity != "" {
+				severity = override.severity
+			}
+		}
+		if !keep {
+			continue
+		}
+		if severity != "" && result.Rule.Severity != severity {
+			// results share rules, so every rule is copied once per severity.
+			if copies[result.Rule] == nil {
+				copies[result.Rule] = make(map[string]*model.Rule)
+			}
+			if copies[result.Rule][severity] == nil {
+				copied := *result.Rule
+				copied.Severity = severity
+				copies[result.Rule][severity] = &copied
+			}
+			result.Rule = copies[result.Rule][severity]
+		}
+		filtered = append(filtered, result)
+	}
+	return filtered
+}
+
+// overrideSeverity converts the value of a rule in an override into a severity, and whether the rule is enabled.
+// Values can be a severity, one of Spectral's numeric severities (-1 is off, 0 is an error, 3 is a hint), 'off' or
+// a boolean. Any other value isn't valid, and the override of the rule is ignored.
+func overrideSeverity(value interface{}) (severity string, enabled bool, ok bool) {
+	switch v := value.(type) {
+	case bool:
+		return "", v, true
+	case int:
+		return numericSeverity(float64(v))
+	case float64:
+		return numericSeverity(v)
+	case string:
+		switch v {
+		case "off":
+			return "", false, true
+		case model.SeverityError, model.SeverityWarn, model.SeverityInfo, model.SeverityHint:
+			return v, true, true
+		}
+	}
+	return "", true, false
+}
+
+func numericSeverity(v float64) (string, bool, bool) {
+	switch v {
+	case -1:
+		return "", false, true
+	case 0:
+		return model.SeverityError, true, true
+	case 1:
+		return model.SeverityWarn, true, true
+	case 2:
+		return model.SeverityInfo, true, true
+	case 3:
+		return model.SeverityHint, true, true
+	}
+	return "", true, false
+}
+
+// pointerSegments splits a JSON pointer into unescaped segments.
+func pointerSegments(pointer string) []string {
+	var segments []string
+	for _, s := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		if s == "" {
+			continue
+		}
+		segments = append(segments, strings.ReplaceAll(strings.ReplaceAll(s, "~1", "/"), "~0", "~"))
+	}
+	return segments
+}
+
+func hasSegmentPrefix(segments, prefix []string) bool {
+	if len(prefix) > len(segments) {
+		return false
+	}
+	for i := range prefix {
+		if segments[i] != prefix[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,220 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+)
+
+var overridesRuleSet = `rules:
+  info-description:
+    given: $.info
+    severity: error
+    then:
+      field: description
+      function: truthy
+  operation-summary:
+    given: $.paths[*][*]
+    severity: error
+    then:
+      field: summary
+      function: truthy
+overrides:
+  - files: ["**/legacy-*.yaml"]
+    rules:
+      info-description: "off"
+      operation-summary: warn
+  - files: ["**/legacy-*.yaml#/paths/~1old"]
+    rules:
+      operation-summary: "off"`
+
+var overridesSpec = `openapi: 3.1.0
+info:
+  title: overrides
+paths:
+  /old:
+    get:
+      description: old
+  /new:
+    get:
+      description: new`
+
+func runOverridesRuleSet(t *testing.T, specFileName string) []model.RuleFunctionResult {
+	rs, err := rulesets.CreateRuleSetFromData([]byte(overridesRuleSet))
+	assert.NoError(t, err)
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+
+	result := ApplyRulesToRuleSet(&RuleSetExecution{
+		RuleSet:      rs,
+		Spec:         []byte(overridesSpec),
+		SpecFileName: specFileName,
+	})
+	assert.Empty(t, result.Errors)
+	return result.Results
+}
+
+func TestApplyRules_Overrides_NoMatch(t *testing.T) {
+	results := runOverridesRuleSet(t, "specs/api.yaml")
+	assert.Len(t, results, 3)
+	for _, r := range results {
+		assert.Equal(t, model.SeverityError, r.Rule.Severity)
+	}
+}
+
+func TestApplyRules_Overrides(t *testing.T) {
+	results := runOverridesRuleSet(t, "specs/legacy-api.yaml")
+	assert.Len(t, results, 1)
+	assert.Equal(t, "operation-summary", results[0].Rule.Id)
+	assert.Equal(t, "$.paths['/new'].get.summary", results[0].Path)
+	assert.Equal(t, model.SeverityWarn, results[0].Rule.Severity)
+}
+
+func TestApplyOverrides_RuleSetUnchanged(t *testing.T) {
+	rs, _ := rulesets.CreateRuleSetFromData([]byte(overridesRuleSet))
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+
+	overridden, pathOverrides := applyOverrides(rs, "legacy-api.yaml")
+	assert.Len(t, overridden.Rules, 1)
+	assert.Len(t, pathOverrides, 1)
+	assert.Len(t, rs.Rules, 2)
+	assert.Equal(t, model.SeverityError, rs.Rules["operation-summary"].Severity)
+}
+
+func TestOverrideSeverity(t *testing.T) {
+	for value, expected := range map[interface{}]string{
+		"warn": model.SeverityWarn, float64(0): model.SeverityError, 3: model.SeverityHint, true: "",
+	} {
+		severity, enabled, ok := overrideSeverity(value)
+		assert.True(t, ok)
+		assert.True(t, enabled)
+		assert.Equal(t, expected, severity)
+	}
+	for _, value := range []interface{}{"off", false, -1} {
+		_, enabled, ok := overrideSeverity(value)
+		assert.True(t, ok)
+		assert.False(t, enabled)
+	}
+}
+
+func TestOverrideSeverity_Invalid(t *testing.T) {
+	for _, value := range []interface{}{7, float64(-2), 1.5, "loud"} {
+		_, _, ok := overrideSeverity(value)
+		assert.False(t, ok)
+	}
+}
+
+func TestApplyOverrides_InvalidSeverityIgnored(t *testing.T) {
+	rs, _ := rulesets.CreateRuleSetFromData([]byte(overridesRuleSet))
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	rs.Overrides = []rulesets.RuleSetOverride{{Files: []string{"**/*.yaml"}, Rules: map[string]interface{}{
+		"operation-summary": 7,
+	}}}
+
+	overridden, _ := applyOverrides(rs, "api.yaml")
+	assert.Len(t, overridden.Rules, 2)
+	assert.Equal(t, model.SeverityError, overridden.Rules["operation-summary"].Severity)
+}
+
+func TestApplyOverrides_LaterOverridesWin(t *testing.T) {
+	rs, _ := rulesets.CreateRuleSetFromData([]byte(overridesRuleSet))
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	rs.Overrides = []rulesets.RuleSetOverride{
+		{Files: []string{"**/*.yaml"}, Rules: map[string]interface{}{"info-description": "off", "operation-summary": "off"}},
+		{Files: []string{"**/legacy-*.yaml"}, Rules: map[string]interface{}{"info-description": "warn"}},
+		{Files: []string{"**/legacy-*.yaml#/paths/~1old"}, Rules: map[string]interface{}{"operation-summary": "off"}},
+		{Files: []string{"**/legacy-*.yaml#/paths/~1old/get"}, Rules: map[string]interface{}{"operation-summary": "hint"}},
+	}
+
+	// a rule turned off by an earlier override is turned back on by a later one.
+	overridden, pathOverrides := applyOverrides(rs, "specs/legacy-api.yaml")
+	assert.Len(t, overridden.Rules, 1)
+	assert.Equal(t, model.SeverityWarn, overridden.Rules["info-description"].Severity)
+	assert.Len(t, rs.Rules, 2)
+
+	overridden, _ = applyOverrides(rs, "specs/api.yaml")
+	assert.Empty(t, overridden.Rules)
+
+	rule := rs.Rules["operation-summary"]
+	results := applyPathOverrides([]model.RuleFunctionResult{
+		{Rule: rule, Path: "$.paths['/old'].get"},
+		{Rule: rule, Path: "$.paths['/old'].post"},
+	}, pathOverrides)
+	assert.Len(t, results, 1)
+	assert.Equal(t, model.SeverityHint, results[0].Rule.Severity)
+}
+
+func TestApplyOverrides_RelativeToRuleSet(t *testing.T) {
+	rs, _ := rulesets.CreateRuleSetFromData([]byte(overridesRuleSet))
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	rs.Overrides = []rulesets.RuleSetOverride{{Files: []string{"legacy.yaml"}, Rules: map[string]interface{}{
+		"info-description": "off",
+	}}}
+	rs.OverridesDir = "config"
+
+	overridden, _ := applyOverrides(rs, "config/legacy.yaml")
+	assert.Len(t, overridden.Rules, 1)
+
+	overridden, _ = applyOverrides(rs, "legacy.yaml")
+	assert.Len(t, overridden.Rules, 2)
+}
+
+func TestApplyRules_Overrides_ExecutionUnchanged(t *testing.T) {
+	rs, _ := rulesets.CreateRuleSetFromData([]byte(overridesRuleSet))
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+
+	execution := &RuleSetExecution{
+		RuleSet:      rs,
+		Spec:         []byte(overridesSpec),
+		SpecFileName: "specs/legacy-api.yaml",
+	}
+	ApplyRulesToRuleSet(execution)
+	assert.Same(t, rs, execution.RuleSet)
+	assert.Len(t, execution.RuleSet.Rules, 2)
+}
+
+func TestPointerSegments(t *testing.T) {
+	assert.Equal(t, []string{"paths", "/a~b"}, pointerSegments("/paths/~1a~0b"))
+}
+
+func TestApplyOverrides_KeepsRuleSet(t *testing.T) {
+	yaml := `categories:
+  - id: legacy-style
+    name: Legacy Style
+aliases:
+  Info: $.info
+parserOptions:
+  incompatibleValues: warn
+rules:
+  info-description:
+    given: "#Info"
+    category: legacy-style
+    severity: error
+    then:
+      field: description
+      function: truthy
+overrides:
+  - files: ["**/legacy-*.yaml"]
+    rules:
+      info-description: warn`
+
+	rs, err := rulesets.CreateRuleSetFromData([]byte(yaml))
+	assert.NoError(t, err)
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+
+	assert.Len(t, rs.Categories, 1)
+	assert.NotEmpty(t, rs.Aliases)
+	assert.NotEmpty(t, rs.ParserOptions)
+
+	overridden, _ := applyOverrides(rs, "specs/legacy-api.yaml")
+	assert.NotSame(t, rs, overridden)
+	assert.Equal(t, rs.Categories, overridden.Categories)
+	assert.Equal(t, rs.Aliases, overridden.Aliases)
+	assert.Equal(t, rs.ParserOptions, overridden.ParserOptions)
+	assert.Equal(t, model.SeverityWarn, overridden.Rules["info-description"].Severity)
+	assert.Equal(t, model.SeverityError, rs.Rules["info-description"].Severity)
+}
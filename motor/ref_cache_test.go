@@ -0,0 +1,59 @@
+package motor
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/rulesets"
+	vacuumUtils "github.com/daveshanley/vacuum/utils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyRulesToRuleSet_RefCache(t *testing.T) {
+	var downloads atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		downloads.Add(1)
+		_, _ = w.Write([]byte("type: object\nproperties:\n  name:\n    type: string\n"))
+	}))
+	defer server.Close()
+
+	spec := []byte(fmt.Sprintf(`openapi: 3.1.0
+info:
+  title: Pets
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      responses:
+        '200':
+          description: a pet
+          content:
+            application/json:
+              schema:
+                $ref: '%s/pet.yaml'`, server.URL))
+
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	cache := &vacuumUtils.RefCache{Dir: t.TempDir(), TTL: time.Hour}
+	lint := func() {
+		result := ApplyRulesToRuleSet(&RuleSetExecution{
+			RuleSet:     rs,
+			Spec:        spec,
+			AllowLookup: true,
+			SilenceLogs: true,
+			RefCache:    cache,
+		})
+		assert.Empty(t, result.Errors)
+	}
+
+	lint()
+	downloaded := downloads.Load()
+	assert.NotZero(t, downloaded)
+
+	// linting again uses the cache.
+	lint()
+	assert.Equal(t, downloaded, downloads.Load())
+}
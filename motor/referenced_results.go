@@ -0,0 +1,66 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+)
+
+// componentPathRegex finds the component (OpenAPI 3 components, or Swagger definitions, parameters and responses)
+// at the start of a result path, named with brackets ($.components.schemas['Pet']) or dots ($.definitions.Pet).
+var componentPathRegex = regexp.MustCompile(`^\$\.(components\.\w+|definitions|parameters|responses)(?:\['([^']+)'\]|\.([\w-]+))`)
+
+// referencingLocations returns a function that finds where a result's component is referenced from (the line and
+// column of each $ref, with the file first for references in other files), using every $ref in the index. Results
+// that are not in a component are not referenced from anywhere.
+func referencingLocations(idx *index.SpecIndex) func(result *model.RuleFunctionResult) []string {
+	if idx == nil {
+		return nil
+	}
+	refs := idx.GetRawReferencesSequenced()
+	return func(result *model.RuleFunctionResult) []string {
+		paths := result.Paths
+		if len(paths) == 0 {
+			paths = []string{result.Path}
+		}
+		var from []string
+		for _, p := range paths {
+			definition := componentDefinition(p)
+			if definition == "" {
+				continue
+			}
+			for _, ref := range refs {
+				if ref.KeyNode == nil || (ref.Definition != definition && !strings.HasSuffix(ref.FullDefinition, definition)) {
+					continue
+				}
+				location := fmt.Sprintf("%d:%d", ref.KeyNode.Line, ref.KeyNode.Column)
+				if ref.RemoteLocation != "" {
+					location = fmt.Sprintf("%s:%s", ref.RemoteLocation, location)
+				}
+				from = append(from, location)
+			}
+		}
+		return from
+	}
+}
+
+// componentDefinition converts the path of (something inside) a component into the reference used for the
+// component, like '#/components/schemas/Pet'. Empty if the path is not in a component.
+func componentDefinition(path string) string {
+	m := componentPathRegex.FindStringSubmatch(path)
+	if m == nil {
+		return ""
+	}
+	name := m[2]
+	if name == "" {
+		name = m[3]
+	}
+	name = strings.ReplaceAll(strings.ReplaceAll(name, "~", "~0"), "/", "~1")
+	return "#/" + strings.ReplaceAll(m[1], ".", "/") + "/" + name
+}
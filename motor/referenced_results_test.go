@@ -0,0 +1,72 @@
+package motor
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyRulesToRuleSet_CollapseReferencedResults(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: pets
+  version: "1"
+paths:
+  /a:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+  /b:
+    get:
+      responses:
+        "200":
+          description: ok
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Pet'
+components:
+  schemas:
+    Pet:
+      type: object
+      properties:
+        name:
+          type: string`
+
+	rs, err := rulesets.CreateRuleSetFromData([]byte(`rules:
+  property-description:
+    given: $.paths[*][*].responses[*].content[*].schema.properties[*]
+    severity: warn
+    resolved: true
+    then:
+      function: truthy
+      field: description`))
+	assert.NoError(t, err)
+	rs = rulesets.BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+
+	standard := ApplyRulesToRuleSet(&RuleSetExecution{RuleSet: rs, Spec: []byte(spec), SilenceLogs: true})
+	assert.Len(t, standard.Results, 2)
+
+	collapsed := ApplyRulesToRuleSet(&RuleSetExecution{RuleSet: rs, Spec: []byte(spec), SilenceLogs: true,
+		CollapseReferencedResults: true})
+	assert.Len(t, collapsed.Results, 1)
+	assert.Equal(t, []string{"14:23", "23:23"}, collapsed.Results[0].ReferencedFrom)
+	assert.Len(t, collapsed.Results[0].Paths, 3)
+}
+
+func TestComponentDefinition(t *testing.T) {
+	assert.Equal(t, "#/components/schemas/Pet", componentDefinition("$.components.schemas['Pet'].properties['name']"))
+	assert.Equal(t, "#/components/responses/Error", componentDefinition("$.components.responses.Error"))
+	assert.Equal(t, "#/definitions/a~1b", componentDefinition("$.definitions['a/b'].type"))
+	assert.Empty(t, componentDefinition("$.paths['/a'].get"))
+}
+
+func TestReferencingLocations_NoIndex(t *testing.T) {
+	assert.Nil(t, referencingLocations(nil))
+}
@@ -0,0 +1,49 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"github.com/daveshanley/vacuum/locator"
+	"github.com/daveshanley/vacuum/model"
+	"gopkg.in/yaml.v3"
+)
+
+// completeResultRanges makes sure every result knows where it ends, as well as where it starts, so editors and
+// reports can highlight all of it. Rules often only point at a node (or end where they start), those results end
+// where the node ends: the end of a scalar, or the last line of a mapping or sequence. Results without a node are
+// located by their path, from the key to the end of the value.
+func completeResultRanges(results []model.RuleFunctionResult, doc *locator.Document) {
+	for i := range results {
+		r := &results[i]
+		if r.StartNode == nil {
+			if doc == nil || len(locator.Segments(r.Path)) == 0 {
+				continue
+			}
+			key, value, err := doc.Lookup(r.Path)
+			if err != nil {
+				continue
+			}
+			r.StartNode = value
+			if key != nil {
+				r.StartNode = key
+			}
+			r.EndNode = endNode(doc.NodeLocation(value))
+			continue
+		}
+		if r.StartNode.Line == 0 {
+			continue
+		}
+		if r.EndNode == nil || !endsAfter(r.EndNode, r.StartNode) {
+			r.EndNode = endNode(doc.NodeLocation(r.StartNode))
+		}
+	}
+}
+
+func endsAfter(end, start *yaml.Node) bool {
+	return end.Line > start.Line || (end.Line == start.Line && end.Column > start.Column)
+}
+
+func endNode(loc *locator.Location) *yaml.Node {
+	return &yaml.Node{Kind: yaml.ScalarNode, Line: loc.EndLine, Column: loc.EndColumn}
+}
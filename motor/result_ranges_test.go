@@ -0,0 +1,66 @@
+package motor
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/locator"
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+var rangeSpec = `openapi: 3.1.0
+info:
+  title: ranges
+  version: 1.0.0
+paths:
+  /pets:
+    get:
+      description: |
+        Lists the pets.
+        All of them.
+      responses:
+        "200":
+          description: ok
+`
+
+func TestCompleteResultRanges(t *testing.T) {
+	doc, err := locator.Parse([]byte(rangeSpec), "spec.yaml")
+	assert.NoError(t, err)
+	_, get, _ := doc.Lookup("$.paths['/pets'].get")
+	key, description, _ := doc.Lookup("$.paths['/pets'].get.description")
+
+	results := []model.RuleFunctionResult{
+		{StartNode: get, EndNode: get},                             // a mapping that ends where it starts.
+		{StartNode: description},                                   // a block scalar without an end.
+		{StartNode: key, EndNode: &yaml.Node{Line: 8, Column: 20}}, // an end is kept.
+		{Path: "$.info.title"},                                     // located by its path.
+		{Path: "$.info.contact"},                                   // not in the document.
+		{Path: "$"},
+	}
+	completeResultRanges(results, doc)
+
+	assert.Equal(t, 13, results[0].EndNode.Line)
+	assert.Equal(t, 26, results[0].EndNode.Column)
+	assert.Equal(t, 10, results[1].EndNode.Line)
+	assert.Equal(t, 21, results[1].EndNode.Column)
+	assert.Equal(t, 20, results[2].EndNode.Column)
+	assert.Equal(t, 3, results[3].StartNode.Line)
+	assert.Equal(t, 3, results[3].StartNode.Column)
+	assert.Equal(t, 3, results[3].EndNode.Line)
+	assert.Equal(t, 16, results[3].EndNode.Column)
+	assert.Nil(t, results[4].StartNode)
+	assert.Nil(t, results[5].StartNode)
+}
+
+func TestApplyRules_ResultRanges(t *testing.T) {
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	result := ApplyRulesToRuleSet(&RuleSetExecution{RuleSet: rs, Spec: []byte(rangeSpec), SpecFileName: "spec.yaml"})
+	assert.NotEmpty(t, result.Results)
+	for _, r := range result.Results {
+		if assert.NotNil(t, r.StartNode, r.Rule.Id) && assert.NotNil(t, r.EndNode, r.Rule.Id) {
+			assert.True(t, endsAfter(r.EndNode, r.StartNode), "%s ends where it starts", r.Rule.Id)
+		}
+	}
+}
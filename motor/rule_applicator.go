@@ -13,6 +13,7 @@ import (
 	"io"
 	"io/fs"
 	"log/slog"
+	"net/http"
 	"net/url"
 	"os"
 	"path/filepath"
@@ -22,7 +23,9 @@ import (
 	"time"
 
 	"github.com/daveshanley/vacuum/functions"
+	"github.com/daveshanley/vacuum/locator"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/daveshanley/vacuum/rulesets"
 	"github.com/mitchellh/mapstructure"
 	doctorModel "github.com/pb33f/doctor/model"
@@ -51,6 +54,9 @@ type ruleContext struct {
 	skipDocumentCheck  bool
 	logger             *slog.Logger
 	nodeLookupTimeout  time.Duration
+
+	// runContext is done when the rule has timed out, or linting has been cancelled.
+	runContext context.Context
 }
 
 // RuleSetExecution is an instruction set for executing a ruleset. It's a convenience structure to allow the signature
@@ -58,6 +64,7 @@ type ruleContext struct {
 type RuleSetExecution struct {
 	RuleSet                         *rulesets.RuleSet             // The RuleSet in which to apply
 	SpecFileName                    string                        // The path of the specification file, used to correctly label location
+	SpecDisplayName                 string                        // Labels the root document when there is no SpecFileName, like 'stdin' or a URL.
 	Spec                            []byte                        // The raw bytes of the OpenAPI specification.
 	SpecInfo                        *datamodel.SpecInfo           // Pre-parsed spec-info.
 	IndexUnresolved                 *index.SpecIndex              // The unresolved index, even if a file is not an OpenAPI spec, it's still indexed.
@@ -70,6 +77,7 @@ type RuleSetExecution struct {
 	Document                        libopenapi.Document           // a ready to render model.
 	DrDocument                      *doctorModel.DrDocument       // a high level, more powerful model, powered by the doctorModel.
 	SkipDocumentCheck               bool                          // Skip the document check, useful for fragments and non openapi specs.
+	DocumentKind                    model.DocumentKind            // The kind of document, empty means OpenAPI or AsyncAPI (detected from the spec).
 	Logger                          *slog.Logger                  // A custom logger.
 	Timeout                         time.Duration                 // The timeout for each rule to run, prevents run-away rules, default is five seconds.
 	NodeLookupTimeout               time.Duration                 // The timeout for each node yaml path lookup, prevents any endless loops, default is 500ms (https://github.com/daveshanley/vacuum/issues/502)
@@ -89,6 +97,19 @@ type RuleSetExecution struct {
 
 	// HTTP client configuration for TLS/certificate support
 	HTTPClientConfig vacuumUtils.HTTPClientConfig // Configuration for custom HTTP client with certificate support
+
+	// LowMemory stops results from holding on to the document, for very large specifications. Results only keep
+	// detached copies of their nodes (with values truncated to LowMemoryValueLength), and the unresolved document
+	// is released once the rules have run.
+	LowMemory bool
+
+	// RefCache caches remote documents referenced by the specification on disk, the cache set with
+	// utils.SetRefCache is used if nil.
+	RefCache *vacuumUtils.RefCache
+
+	// CollapseReferencedResults reports a problem with a component that is referenced ($ref) in many places once,
+	// instead of every time the component is reached. The result lists where the component is referenced from.
+	CollapseReferencedResults bool
 }
 
 // buildLocationString efficiently builds a location string in format "line:column"
@@ -113,6 +134,8 @@ type RuleSetExecutionResult struct {
 	FilesProcessed   int                              // number of files extracted by the rolodex
 	FileSize         int64                            // total filesize loaded by the rolodex
 	DocumentConfig   *datamodel.DocumentConfiguration // The document configuration used to create the document.
+	Suppressed       []model.RuleFunctionResult       // Results suppressed by x-vacuum-ignore extensions in the specification.
+	RuleTimings      []*reports.RuleTiming            // How long each rule took to run and how many results it found, slowest first.
 }
 
 // todo: move copy into virtual file system or some kind of map.
@@ -125,13 +148,88 @@ const CircularReferencesFix string = "Circular references are created by schemas
 // vacuum as an API. The signature is not sufficient, but is embedded everywhere. This new method
 // uses a message structure, to allow the signature to grow, without breaking anything.
 func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
+	return applyRules(context.Background(), execution, nil)
+}
+
+// ApplyRulesToRuleSetWithContext works just like ApplyRulesToRuleSet, but linting can be cancelled with the context.
+// Rules that are still running when the context is done are abandoned, and the cancellation is returned as an error.
+func ApplyRulesToRuleSetWithContext(ctx context.Context, execution *RuleSetExecution) *RuleSetExecutionResult {
+	return applyRules(ctx, execution, nil)
+}
+
+// RuleProgress describes how far through a ruleset an execution is.
+type RuleProgress struct {
+	Rule      *model.Rule // The rule that has just completed, nil for results found before any rules run.
+	Completed int         // The number of rules that have completed.
+	Total     int         // The number of rules in the ruleset.
+	TimedOut  bool        // The rule did not complete in time, its results are incomplete.
+}
+
+// ResultCallback receives the results of a rule as soon as the rule has completed. Results are filtered by overrides
+// and inline suppressions before they are handed over. Callbacks are never called concurrently.
+type ResultCallback func(results []model.RuleFunctionResult, progress RuleProgress)
+
+// ApplyRulesWithCallback works just like ApplyRulesToRuleSet, but streams results (and progress) to the callback
+// as every rule completes, instead of only returning everything at the end. Rules stop being waited for once the
+// context is done. Everything that was streamed is also returned in the result.
+func ApplyRulesWithCallback(ctx context.Context, execution *RuleSetExecution, callback ResultCallback) *RuleSetExecutionResult {
+	return applyRules(ctx, execution, callback)
+}
+
+// ruleTimeoutRule is reported when a rule did not complete within the timeout of the execution.
+var ruleTimeoutRule = &model.Rule{
+	Name:         "Check that every rule completes in time",
+	Id:           "rule-timeout",
+	Description:  "rules that take longer than the timeout are stopped, so one rule cannot hang linting",
+	Given:        "$",
+	RuleCategory: model.RuleCategories[model.CategoryValidation],
+	Type:         "validation",
+	Severity:     model.SeverityWarn,
+	Then: model.RuleAction{
+		Function: "blank",
+	},
+	HowToFix: "The results of the rule are incomplete. Increase the timeout, or check the rule (and any custom " +
+		"function it uses) does not loop forever.",
+}
+
+func ruleTimeoutResult(rule *model.Rule, timeout time.Duration) model.RuleFunctionResult {
+	return model.RuleFunctionResult{
+		RuleId:       ruleTimeoutRule.Id,
+		Rule:         ruleTimeoutRule,
+		RuleSeverity: ruleTimeoutRule.Severity,
+		StartNode:    &yaml.Node{Line: 1, Column: 1},
+		EndNode:      &yaml.Node{Line: 1, Column: 2},
+		Message:      fmt.Sprintf("rule '%s' did not complete within %s, its results are incomplete", rule.Id, timeout),
+		Path:         "$",
+	}
+}
+
+type ruleCompletion struct {
+	rule     *model.Rule
+	results  []model.RuleFunctionResult
+	timedOut bool
+	duration time.Duration
+}
+
+func applyRules(runCtx context.Context, execution *RuleSetExecution, callback ResultCallback) *RuleSetExecutionResult {
+
+	if runCtx.Err() != nil {
+		return &RuleSetExecutionResult{
+			RuleSetExecution: execution,
+			Errors:           []error{fmt.Errorf("linting cancelled: %w", runCtx.Err())},
+		}
+	}
 
 	now := time.Now()
+
+	// overrides change the rules run against this document, the ruleset of the execution is shared so isn't changed.
+	ruleSet, pathOverrides := applyOverrides(execution.RuleSet, execution.SpecFileName)
+
 	builtinFunctions := functions.MapBuiltinFunctions()
 	var ruleResults []model.RuleFunctionResult
 	var ruleWaitGroup sync.WaitGroup
-	if execution.RuleSet != nil && execution.RuleSet.Rules != nil {
-		ruleWaitGroup.Add(len(execution.RuleSet.Rules))
+	if ruleSet != nil && ruleSet.Rules != nil {
+		ruleWaitGroup.Add(len(ruleSet.Rules))
 	}
 
 	var specResolved *yaml.Node
@@ -195,20 +293,38 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 		docConfig.Logger = execution.Logger
 		indexConfig.Logger = execution.Logger
 	}
+	if execution.SpecFileName != "" {
+		// every record is for the document being linted.
+		docConfig.Logger = docConfig.Logger.With("document", execution.SpecFileName)
+		indexConfig.Logger = docConfig.Logger
+	}
 
 	indexConfig.Logger.Debug("applying rules to rule set")
 
 	// Configure custom HTTP client if TLS/certificate options are provided
+	var httpClient *http.Client
 	if vacuumUtils.ShouldUseCustomHTTPClient(execution.HTTPClientConfig) {
-		httpClient, httpErr := vacuumUtils.CreateCustomHTTPClient(execution.HTTPClientConfig)
+		var httpErr error
+		httpClient, httpErr = vacuumUtils.CreateCustomHTTPClient(execution.HTTPClientConfig)
 		if httpErr != nil {
 			return &RuleSetExecutionResult{Errors: []error{fmt.Errorf("failed to create custom HTTP client: %w", httpErr)}}
 		}
-		
+
 		// Set the custom RemoteURLHandler for libopenapi
 		docConfig.RemoteURLHandler = vacuumUtils.CreateRemoteURLHandler(httpClient)
 	}
 
+	// remote references are looked up through the cache, if there is one.
+	refCache := execution.RefCache
+	if refCache == nil {
+		refCache = vacuumUtils.GetRefCache()
+	}
+	if refCache != nil {
+		docConfig.RemoteURLHandler = refCache.Handler(httpClient)
+		indexConfig.RemoteURLHandler = docConfig.RemoteURLHandler
+		indexConfigUnresolved.RemoteURLHandler = docConfig.RemoteURLHandler
+	}
+
 	if execution.Base != "" {
 
 		// check if this is a URL or not
@@ -271,6 +387,20 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 		docConfig.BypassDocumentCheck = true
 	}
 
+	// AsyncAPI and JSON Schema documents cannot be modelled by libopenapi, so they are indexed as generic
+	// documents, and only the rules for their formats (or rules without formats) are applied.
+	var genericFormat string
+	if execution.Document == nil {
+		if execution.DocumentKind == model.DocumentKindJSONSchema {
+			genericFormat = model.JSONSchema
+		} else {
+			genericFormat = DetectAsyncAPIFormat(execution.Spec)
+		}
+		if genericFormat != "" {
+			docConfig.BypassDocumentCheck = true
+		}
+	}
+
 	docResolved := execution.Document
 	var docUnresolved libopenapi.Document
 
@@ -336,8 +466,7 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 		indexConfigUnresolved.IgnoreArrayCircularReferences = suppliedDocConfig.IgnoreArrayCircularReferences
 	}
 
-	timeTaken := time.Since(nowDocs).Milliseconds()
-	indexConfig.Logger.Debug("building docs completed", "ms", timeTaken)
+	indexConfig.Logger.Debug("building docs completed", "duration", time.Since(nowDocs))
 
 	// build model
 	var resolvedModelErrors []error
@@ -345,6 +474,13 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 	var indexUnresolved *index.SpecIndex
 
 	version := docResolved.GetVersion()
+	if genericFormat != "" {
+		version = ""
+		specInfo.SpecFormat = genericFormat
+		if specInfoUnresolved != nil {
+			specInfoUnresolved.SpecFormat = genericFormat
+		}
+	}
 
 	// When skip-check is enabled, the document version might not be detected
 	// but we still need to know if it's OAS2 or OAS3 for rule filtering
@@ -434,12 +570,12 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 			now = time.Now()
 			var mod *libopenapi.DocumentModel[v3.Document]
 
+			restoreFormat := buildAsOpenAPI31(specInfo, specInfoUnresolved)
 			_, resolvedModelErrors = docResolved.BuildV3Model()
 
 			rolodexResolved = docResolved.GetRolodex()
 
-			then := time.Since(now).Milliseconds()
-			indexConfig.Logger.Debug("built resolved model", "ms", then)
+			indexConfig.Logger.Debug("built resolved model", "duration", time.Since(now))
 
 			now = time.Now()
 			var errs []error
@@ -452,9 +588,9 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 				}
 			}
 			rolodexUnresolved = docUnresolved.GetRolodex()
+			restoreFormat()
 
-			then = time.Since(now).Milliseconds()
-			indexConfig.Logger.Debug("built unresolved model", "ms", then)
+			indexConfig.Logger.Debug("built unresolved model", "duration", time.Since(now))
 
 			indexResolved = rolodexResolved.GetRootIndex()
 			indexUnresolved = rolodexUnresolved.GetRootIndex()
@@ -489,8 +625,7 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 				// we only resolve one.
 				resolvedTime := time.Now()
 				rolodexResolved.Resolve()
-				resolvedTaken := time.Since(resolvedTime).Milliseconds()
-				indexConfig.Logger.Debug("resolved model", "ms", resolvedTaken)
+				indexConfig.Logger.Debug("resolved model", "duration", time.Since(resolvedTime))
 			})
 			wg.Wait()
 			specResolved = rolodexResolved.GetRootIndex().GetRootNode()
@@ -540,8 +675,7 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 		}
 	}
 
-	then := time.Since(nowModel).Milliseconds()
-	indexConfig.Logger.Debug("built model", "ms", then)
+	indexConfig.Logger.Debug("built model", "duration", time.Since(nowModel))
 
 	execution.IndexResolved = indexResolved
 	execution.IndexUnresolved = indexUnresolved
@@ -730,10 +864,55 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 		ruleResults = append(ruleResults, res)
 	}
 
-	if execution.RuleSet != nil && indexUnresolved != nil {
+	// results are filtered by overrides and inline suppressions as they arrive, so they can be streamed.
+	var suppressions []*inlineSuppression
+	if specUnresolved != nil {
+		suppressions = collectInlineSuppressions(specUnresolved)
+	}
+	// the root document is known by where the rolodex found it (a placeholder when it has no file), or its file name.
+	var rootLocations []string
+	for _, idx := range []*index.SpecIndex{indexUnresolved, indexResolved} {
+		if idx != nil && idx.GetSpecAbsolutePath() != "" {
+			rootLocations = append(rootLocations, idx.GetSpecAbsolutePath())
+		}
+	}
+	if execution.SpecFileName != "" {
+		rootLocations = append(rootLocations, execution.SpecFileName)
+	}
+	var suppressed []model.RuleFunctionResult
+	var ruleTimings []*reports.RuleTiming
+	var pool *nodePool
+	if execution.LowMemory {
+		pool = newNodePool()
+	}
+	totalRules := 0
+	if ruleSet != nil && indexUnresolved != nil {
+		totalRules = len(ruleSet.Rules)
+	}
+	// results are located in the unresolved specification, so they can be found by their path.
+	var rangeDocument *locator.Document
+	if specUnresolved != nil {
+		rangeDocument = locator.NewDocument(specUnresolved, execution.SpecFileName)
+		rangeDocument.SetSource(execution.Spec)
+	}
+	emit := func(results []model.RuleFunctionResult, progress RuleProgress) []model.RuleFunctionResult {
+		completeResultRanges(results, rangeDocument)
+		kept, s := filterInlineSuppressions(suppressions, rootLocations, applyPathOverrides(results, pathOverrides))
+		if pool != nil {
+			pool.compact(kept)
+			pool.compact(s)
+		}
+		suppressed = append(suppressed, s...)
+		if callback != nil {
+			callback(kept, progress)
+		}
+		return kept
+	}
+	ruleResults = emit(ruleResults, RuleProgress{Total: totalRules})
+
+	if ruleSet != nil && indexUnresolved != nil {
 
-		totalRules := len(execution.RuleSet.Rules)
-		done := make(chan bool)
+		done := make(chan ruleCompletion)
 		indexConfig.Logger.Debug("running rules", "total", totalRules)
 		now = time.Now()
 
@@ -745,10 +924,11 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 			execution.NodeLookupTimeout = time.Millisecond * 500
 		}
 
-		for _, rule := range execution.RuleSet.Rules {
+		for _, rule := range ruleSet.Rules {
 
-			go func(rule *model.Rule, done chan bool) {
+			go func(rule *model.Rule, done chan ruleCompletion) {
 
+				started := time.Now()
 				ruleSpec := specResolved
 				ruleIndex := indexResolved
 				info := specInfo
@@ -758,13 +938,16 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 					ruleIndex = indexUnresolved
 				}
 
+				// every rule collects its own results, so they can be handed over as soon as the rule is done.
+				var results []model.RuleFunctionResult
+
 				// this list of things is most likely going to grow a bit, so we use a nice clean message design.
 				ctx := ruleContext{
 					rule:               rule,
 					specNode:           ruleSpec,
 					specNodeUnresolved: specUnresolved,
 					builtinFunctions:   builtinFunctions,
-					ruleResults:        &ruleResults,
+					ruleResults:        &results,
 					errors:             &errs,
 					specInfo:           info,
 					index:              ruleIndex,
@@ -773,37 +956,81 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 					customFunctions:    execution.CustomFunctions,
 					silenceLogs:        execution.SilenceLogs,
 					skipDocumentCheck:  execution.SkipDocumentCheck,
-					logger:             docConfig.Logger,
+					logger:             docConfig.Logger.With("rule", rule.Id),
 					nodeLookupTimeout:  execution.NodeLookupTimeout,
 				}
 				if execution.PanicFunction != nil {
 					ctx.panicFunc = execution.PanicFunction
 				}
 
-				timeoutCtx, ruleCancel := context.WithTimeout(context.Background(), execution.Timeout)
+				timeoutCtx, ruleCancel := context.WithTimeout(runCtx, execution.Timeout)
 				defer ruleCancel()
-				doneChan := make(chan bool)
+				ctx.runContext = timeoutCtx
+
+				// buffered, so a rule that has been abandoned can still finish.
+				doneChan := make(chan bool, 1)
 
 				go runRule(ctx, doneChan)
 
+				completion := ruleCompletion{rule: rule}
 				select {
 				case <-timeoutCtx.Done():
-					ctx.logger.Error("Rule timed out, skipping", "rule", rule.Id, "timeout", execution.Timeout)
+					// a cancelled lint is not the fault of the rule.
+					if runCtx.Err() == nil {
+						ctx.logger.Error("Rule timed out, skipping", "timeout", execution.Timeout)
+						completion.timedOut = true
+					}
 					break
 				case <-doneChan:
 					break
 				}
-				done <- true
+
+				// a rule that timed out may still be running, anything it finds from now on is dropped.
+				lock.Lock()
+				completion.results = results[:len(results):len(results)]
+				lock.Unlock()
+				completion.duration = time.Since(started)
+				if completion.timedOut {
+					completion.results = append(completion.results, ruleTimeoutResult(rule, execution.Timeout))
+				}
+				done <- completion
 			}(rule, done)
 		}
 
-		completed := 0
-		for completed < totalRules {
-			<-done
-			completed++
+		for completed := 1; completed <= totalRules; completed++ {
+			c := <-done
+			kept := emit(c.results, RuleProgress{
+				Rule:      c.rule,
+				Completed: completed,
+				Total:     totalRules,
+				TimedOut:  c.timedOut,
+			})
+			ruleResults = append(ruleResults, kept...)
+			indexConfig.Logger.Debug("rule completed", "rule", c.rule.Id, "duration", c.duration,
+				"results", len(kept), "timedOut", c.timedOut)
+			ruleTimings = append(ruleTimings, &reports.RuleTiming{
+				RuleId:     c.rule.Id,
+				DurationMs: float64(c.duration.Microseconds()) / 1000,
+				Results:    len(kept),
+				TimedOut:   c.timedOut,
+			})
 		}
-		then = time.Since(now).Milliseconds()
-		indexConfig.Logger.Debug("rules completed", "totalRules", totalRules, "ms", then)
+		sortRuleTimings(ruleTimings)
+		indexConfig.Logger.Debug("rules completed", "totalRules", totalRules, "duration", time.Since(now))
+	}
+
+	if runCtx.Err() != nil {
+		errs = append(errs, fmt.Errorf("linting cancelled: %w", runCtx.Err()))
+	}
+	if execution.CollapseReferencedResults {
+		ruleResults = model.CollapseReferencedResults(ruleResults, referencingLocations(indexUnresolved))
+	}
+	if execution.SpecFileName == "" && execution.SpecDisplayName != "" {
+		labelRootOrigins(ruleResults, rootLocations, execution.SpecDisplayName)
+		labelRootOrigins(suppressed, rootLocations, execution.SpecDisplayName)
+	}
+	if execution.LowMemory {
+		releaseDocuments(execution)
 	}
 
 	filesProcessed := 0
@@ -815,12 +1042,13 @@ func ApplyRulesToRuleSet(execution *RuleSetExecution) *RuleSetExecutionResult {
 		//ruleResults = *removeDuplicates(&ruleResults, execution, indexResolved)
 	}
 
-	then = time.Since(now).Milliseconds()
-	indexConfig.Logger.Debug("applied all rules and completed", "ms", then)
+	indexConfig.Logger.Debug("applied all rules and completed", "duration", time.Since(now))
 
 	return &RuleSetExecutionResult{
 		RuleSetExecution: execution,
 		Results:          ruleResults,
+		Suppressed:       suppressed,
+		RuleTimings:      ruleTimings,
 		Index:            indexResolved,
 		SpecInfo:         specInfo,
 		Errors:           errs,
@@ -874,7 +1102,7 @@ func runRule(ctx ruleContext, doneChan chan bool) {
 
 			// create a timeout on this, if we can't get a result within 2s, then
 			// try again, but with the unresolved spec.
-			lookupCtx, cancel := context.WithTimeout(context.Background(), time.Second*2)
+			lookupCtx, cancel := context.WithTimeout(ctx.runContext, time.Second*2)
 			defer cancel()
 			nodesChan := make(chan []*yaml.Node)
 			errChan := make(chan error)
@@ -885,13 +1113,18 @@ func runRule(ctx ruleContext, doneChan chan bool) {
 			case nodes = <-nodesChan:
 				break
 			case err = <-errChan:
-				ctx.logger.Error("error looking for nodes", "path", givenPath, "rule", ctx.rule.Id, "error", err)
+				ctx.logger.Error("error looking for nodes", "path", givenPath, "error", err)
 				break
 			case <-lookupCtx.Done():
+				if ctx.runContext.Err() != nil {
+					// the rule has timed out, or linting has been cancelled.
+					doneChan <- true
+					return
+				}
 				ctx.logger.Warn("timeout looking for nodes, trying again with unresolved spec.", "path", givenPath)
 
 				// ok, this timed out, let's try again with the unresolved spec.
-				lookupCtxFinal, finalCancel := context.WithTimeout(context.Background(), time.Second*2)
+				lookupCtxFinal, finalCancel := context.WithTimeout(ctx.runContext, time.Second*2)
 				defer finalCancel()
 
 				go findNodes(ctx.specNodeUnresolved, givenPath, errChan, nodesChan)
@@ -903,8 +1136,7 @@ func runRule(ctx ruleContext, doneChan chan bool) {
 					break
 				case <-lookupCtxFinal.Done():
 					err = fmt.Errorf("timed out looking for nodes using path '%s'", givenPath)
-					ctx.logger.Error("timeout looking for unresolved nodes, giving up.", "path", givenPath, "rule",
-						ctx.rule.Id)
+					ctx.logger.Error("timeout looking for unresolved nodes, giving up.", "path", givenPath)
 					break topBreak
 				}
 			}
@@ -973,11 +1205,12 @@ func buildResults(ctx ruleContext, ruleAction model.RuleAction, nodes []*yaml.No
 			Document:   ctx.document,
 			DrDocument: ctx.drDocument,
 			Logger:     ctx.logger,
+			Context:    ctx.runContext,
 		}
 
 		if !ctx.skipDocumentCheck && ctx.specInfo.SpecFormat == "" && ctx.specInfo.Version == "" {
 			if !ctx.silenceLogs {
-				pterm.Warning.Printf("Specification version not detected, cannot apply rule `%s`\n", ctx.rule.Id)
+				ctx.logger.Warn("specification version not detected, cannot apply rule")
 			}
 			return ctx.ruleResults
 		}
@@ -1002,6 +1235,11 @@ func buildResults(ctx ruleContext, ruleAction model.RuleAction, nodes []*yaml.No
 			// iterate through nodes and supply them one at a time so we don't pollute each run
 			for _, node := range nodes {
 
+				// there is no point carrying on once the rule has been abandoned.
+				if ctx.runContext.Err() != nil {
+					break
+				}
+
 				// if this rule is designed for a different version, skip it.
 				if len(ctx.rule.Formats) > 0 {
 					match := false
@@ -1016,6 +1254,9 @@ func buildResults(ctx ruleContext, ruleAction model.RuleAction, nodes []*yaml.No
 				}
 
 				runRuleResults := ruleFunction.RunRule([]*yaml.Node{node}, rfc)
+				if ctx.runContext.Err() != nil {
+					break // the rule has been abandoned, anything it found is incomplete.
+				}
 
 				// Ensure RuleId and RuleSeverity are populated from the rule context
 				// This is necessary for programmatic API usage where these fields might not be set
@@ -1051,11 +1292,11 @@ func buildResults(ctx ruleContext, ruleAction model.RuleAction, nodes []*yaml.No
 			}
 			
 			if len(availableCustomFuncs) > 0 {
-				pterm.Error.Printf("Rule '%s' uses unknown function '%s'. Available custom functions: %v\n", 
-					ctx.rule.Id, ruleAction.Function, availableCustomFuncs)
+				ctx.logger.Error("rule uses an unknown function", "function", ruleAction.Function,
+					"customFunctions", availableCustomFuncs)
 			} else {
-				pterm.Error.Printf("Rule '%s' uses unknown function '%s'. No custom functions loaded. Use --functions flag to load custom functions.\n", 
-					ctx.rule.Id, ruleAction.Function)
+				ctx.logger.Error("rule uses an unknown function, no custom functions loaded (use --functions to load them)",
+					"function", ruleAction.Function)
 			}
 		}
 		
@@ -1075,6 +1316,20 @@ func buildResults(ctx ruleContext, ruleAction model.RuleAction, nodes []*yaml.No
 	return ctx.ruleResults
 }
 
+// labelRootOrigins replaces the name the rolodex gives a root document without a file, with the name of where the
+// specification came from. Origins can be shared with the index, so results are given copies.
+func labelRootOrigins(results []model.RuleFunctionResult, rootLocations []string, name string) {
+	for i := range results {
+		origin := results[i].Origin
+		if origin == nil || origin.AbsoluteLocation == "" || !isRootOrigin(origin, rootLocations) {
+			continue
+		}
+		labelled := *origin
+		labelled.AbsoluteLocation = name
+		results[i].Origin = &labelled
+	}
+}
+
 type seenResult struct {
 	location string
 	message  string
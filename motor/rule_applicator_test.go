@@ -1,6 +1,7 @@
 package motor
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"os"
@@ -2577,3 +2578,144 @@ func TestIssue523_ExtensionReferenceToNonYAMLFile(t *testing.T) {
 		assert.Equal(t, 0, refErrors, "Should not have reference errors after fix - files are found and loaded")
 	})
 }
+
+func TestApplyRulesWithCallback(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: streamed
+  version: 1.0.0
+paths:
+  /burgers:
+    get:
+      x-vacuum-ignore: [operation-description]
+      operationId: getBurgers
+      responses:
+        "200":
+          description: ok
+  /fries:
+    get:
+      operationId: getFries
+      responses:
+        "200":
+          description: ok`
+
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+
+	var streamed []model.RuleFunctionResult
+	var progress []RuleProgress
+	result := ApplyRulesWithCallback(context.Background(), &RuleSetExecution{
+		RuleSet: rs,
+		Spec:    []byte(spec),
+	}, func(results []model.RuleFunctionResult, p RuleProgress) {
+		streamed = append(streamed, results...)
+		progress = append(progress, p)
+	})
+
+	assert.Empty(t, result.Errors)
+	assert.Len(t, streamed, len(result.Results))
+	assert.NotEmpty(t, result.Suppressed)
+	for _, r := range streamed {
+		assert.False(t, r.Rule.Id == "operation-description" && r.StartNode.Line == 8)
+	}
+
+	// results found before the rules run come first, then every rule reports once.
+	assert.Len(t, progress, len(rs.Rules)+1)
+	assert.Nil(t, progress[0].Rule)
+	for i, p := range progress {
+		assert.Equal(t, i, p.Completed)
+		assert.Equal(t, len(rs.Rules), p.Total)
+	}
+}
+
+type testRuleSlow struct{}
+
+func (r *testRuleSlow) GetCategory() string {
+	return model.CategoryValidation
+}
+
+func (r *testRuleSlow) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	<-context.Context.Done()
+	return []model.RuleFunctionResult{{Message: "too late"}}
+}
+
+func (r *testRuleSlow) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name: "slow",
+	}
+}
+
+func slowRuleExecution() *RuleSetExecution {
+	return &RuleSetExecution{
+		RuleSet: &rulesets.RuleSet{
+			Rules: map[string]*model.Rule{
+				"slow": {
+					Id:           "slow",
+					Given:        "$",
+					RuleCategory: model.RuleCategories[model.CategoryValidation],
+					Type:         rulesets.Validation,
+					Severity:     model.SeverityError,
+					Then: model.RuleAction{
+						Function: "slow",
+					},
+				},
+			},
+		},
+		Spec:        []byte("openapi: 3.1.0\ninfo:\n  title: slow\n  version: 1.0.0\npaths: {}"),
+		Timeout:     time.Millisecond * 50,
+		SilenceLogs: true,
+		CustomFunctions: map[string]model.RuleFunction{
+			"slow": &testRuleSlow{},
+		},
+	}
+}
+
+func TestApplyRulesToRuleSet_RuleTimeout(t *testing.T) {
+	result := ApplyRulesToRuleSet(slowRuleExecution())
+
+	assert.Empty(t, result.Errors)
+	assert.Len(t, result.Results, 1)
+	assert.Equal(t, "rule-timeout", result.Results[0].RuleId)
+	assert.Equal(t, model.SeverityWarn, result.Results[0].Rule.Severity)
+	assert.Equal(t, "rule 'slow' did not complete within 50ms, its results are incomplete", result.Results[0].Message)
+}
+
+func TestApplyRulesToRuleSet_RuleTimeout_WithOtherRules(t *testing.T) {
+	execution := slowRuleExecution()
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	rs.Rules["slow"] = execution.RuleSet.Rules["slow"]
+	execution.RuleSet = rs
+
+	result := ApplyRulesToRuleSet(execution)
+	assert.Empty(t, result.Errors)
+
+	// the rules that completed are reported as usual, the one that timed out is a single warning.
+	var timedOut []model.RuleFunctionResult
+	for _, r := range result.Results {
+		assert.NotEqual(t, "slow", r.Rule.Id)
+		if r.RuleId == "rule-timeout" {
+			timedOut = append(timedOut, r)
+		}
+	}
+	assert.Len(t, timedOut, 1)
+	assert.Greater(t, len(result.Results), 1)
+	assert.Equal(t, model.SeverityWarn, timedOut[0].RuleSeverity)
+	assert.Contains(t, timedOut[0].Message, "rule 'slow' did not complete within 50ms")
+}
+
+func TestApplyRulesToRuleSetWithContext_Cancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result := ApplyRulesToRuleSetWithContext(ctx, slowRuleExecution())
+	assert.ErrorIs(t, result.Errors[0], context.Canceled)
+	assert.Empty(t, result.Results)
+
+	// cancelling mid-run stops waiting for rules, without reporting them as timed out.
+	ctx, cancel = context.WithCancel(context.Background())
+	execution := slowRuleExecution()
+	execution.Timeout = time.Minute
+	time.AfterFunc(time.Millisecond*50, cancel)
+	result = ApplyRulesToRuleSetWithContext(ctx, execution)
+	assert.Len(t, result.Errors, 1)
+	assert.ErrorIs(t, result.Errors[0], context.Canceled)
+	assert.Empty(t, result.Results)
+}
@@ -0,0 +1,20 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package motor
+
+import (
+	"sort"
+
+	"github.com/daveshanley/vacuum/model/reports"
+)
+
+// sortRuleTimings sorts timings with the slowest rule first, rules that took the same time are sorted by id.
+func sortRuleTimings(timings []*reports.RuleTiming) {
+	sort.SliceStable(timings, func(i, j int) bool {
+		if timings[i].DurationMs != timings[j].DurationMs {
+			return timings[i].DurationMs > timings[j].DurationMs
+		}
+		return timings[i].RuleId < timings[j].RuleId
+	})
+}
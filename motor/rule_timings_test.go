@@ -0,0 +1,57 @@
+package motor
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/rulesets"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyRulesToRuleSet_RuleTimings(t *testing.T) {
+	spec := `openapi: 3.1.0
+info:
+  title: pets
+  version: "1"
+paths:
+  /pets:
+    get:
+      responses:
+        "200":
+          description: ok`
+
+	rs := rulesets.BuildDefaultRuleSets().GenerateOpenAPIRecommendedRuleSet()
+	result := ApplyRulesToRuleSet(&RuleSetExecution{RuleSet: rs, Spec: []byte(spec), SilenceLogs: true})
+
+	assert.Len(t, result.RuleTimings, len(rs.Rules))
+	results := 0
+	for i, timing := range result.RuleTimings {
+		assert.NotNil(t, rs.Rules[timing.RuleId])
+		if i > 0 {
+			assert.GreaterOrEqual(t, result.RuleTimings[i-1].DurationMs, timing.DurationMs)
+		}
+		results += timing.Results
+	}
+	assert.Equal(t, len(result.Results), results)
+}
+
+func TestApplyRulesToRuleSet_RuleTimings_TimedOut(t *testing.T) {
+	result := ApplyRulesToRuleSet(slowRuleExecution())
+
+	assert.Len(t, result.RuleTimings, 1)
+	assert.Equal(t, "slow", result.RuleTimings[0].RuleId)
+	assert.True(t, result.RuleTimings[0].TimedOut)
+	assert.GreaterOrEqual(t, result.RuleTimings[0].DurationMs, float64(50))
+}
+
+func TestSortRuleTimings(t *testing.T) {
+	timings := []*reports.RuleTiming{
+		{RuleId: "b", DurationMs: 1},
+		{RuleId: "c", DurationMs: 10},
+		{RuleId: "a", DurationMs: 1},
+	}
+	sortRuleTimings(timings)
+	assert.Equal(t, "c", timings[0].RuleId)
+	assert.Equal(t, "a", timings[1].RuleId)
+	assert.Equal(t, "b", timings[2].RuleId)
+}
@@ -4,6 +4,7 @@
 package javascript
 
 import (
+	"context"
 	"fmt"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/model/reports"
@@ -113,6 +114,7 @@ func (j *JSRuleFunction) RunRule(nodes []*yaml.Node, context model.RuleFunctionC
 
 	// rule need their own runtime because these functions run async, the same runtime will become polluted.
 	rt := BuildVM()
+	defer interruptWhenDone(context.Context, rt)()
 
 	var results []model.RuleFunctionResult
 	var runtimeErr error
@@ -187,6 +189,9 @@ func (j *JSRuleFunction) RunRule(nodes []*yaml.Node, context model.RuleFunctionC
 					},
 				}
 			}
+			if _, okI := rErr.(*goja.InterruptedError); okI {
+				return results // the rule has timed out, or linting has been cancelled.
+			}
 			panic(rErr) // not an exception
 		}
 		op := ruleOutput.Export()
@@ -225,6 +230,17 @@ func (j *JSRuleFunction) RunRule(nodes []*yaml.Node, context model.RuleFunctionC
 	return results
 }
 
+// interruptWhenDone interrupts the runtime once the context is done (the rule has timed out, or linting has been
+// cancelled), so a script that never returns does not keep running. The returned function stops waiting.
+func interruptWhenDone(ctx context.Context, rt *goja.Runtime) func() bool {
+	if ctx == nil {
+		return func() bool { return false }
+	}
+	return context.AfterFunc(ctx, func() {
+		rt.Interrupt(ctx.Err())
+	})
+}
+
 func BuildVM() *goja.Runtime {
 	rt := goja.New()
 	rt.SetFieldNameMapper(goja.TagFieldNameMapper("json", true))
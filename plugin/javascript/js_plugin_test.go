@@ -4,6 +4,7 @@
 package javascript
 
 import (
+	"context"
 	"fmt"
 	"github.com/daveshanley/vacuum/functions/core"
 	"github.com/daveshanley/vacuum/model"
@@ -11,6 +12,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 	"testing"
+	"time"
 )
 
 func Test_JSPlugin_Basic_Fail(t *testing.T) {
@@ -226,3 +228,20 @@ func Test_JSPlugin_Fail_NoRunRuleFunc(t *testing.T) {
 	assert.Equal(t, "runRule function not found", err.Error())
 
 }
+
+func Test_JSPlugin_Interrupted(t *testing.T) {
+
+	script := `function runRule() {
+   while (true) {}
+}`
+	f := NewJSRuleFunction("test", script)
+	assert.NoError(t, f.CheckScript())
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+
+	var y yaml.Node
+	_ = yaml.Unmarshal([]byte("hello"), &y)
+	results := f.RunRule([]*yaml.Node{y.Content[0]}, model.RuleFunctionContext{Context: ctx})
+	assert.Empty(t, results)
+}
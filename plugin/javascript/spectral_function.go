@@ -0,0 +1,341 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package javascript
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"sync"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/dop251/goja"
+	"github.com/dop251/goja_nodejs/console"
+	"github.com/dop251/goja_nodejs/require"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// SpectralCoreModule is the Spectral module custom functions import helpers from.
+const SpectralCoreModule = "@stoplight/spectral-core"
+
+var (
+	esmDefaultExport = regexp.MustCompile(`(?m)^[ \t]*export\s+default\s+`)
+	esmNamedImport   = regexp.MustCompile(`(?m)^[ \t]*import\s+(\{[^}]*\})\s+from\s+(['"][^'"]+['"])[ \t]*;?`)
+	esmDefaultImport = regexp.MustCompile(`(?m)^[ \t]*import\s+([\w$]+)\s+from\s+(['"][^'"]+['"])[ \t]*;?`)
+	plainPathSegment = regexp.MustCompile(`^[\w-]+$`)
+)
+
+// SpectralJSRuleFunction runs a Spectral style JavaScript function, a module that exports a function called with
+// (targetVal, options, context), that returns an array of results ({message, path}) or nothing. This means the
+// `functions` declared by a Spectral ruleset can be used unmodified.
+type SpectralJSRuleFunction struct {
+	name       string
+	script     string
+	compile    sync.Once
+	program    *goja.Program
+	compileErr error
+	lock       sync.Mutex
+	document   *spectralDocument // the last document the function was run against.
+}
+
+// spectralDocument is a document decoded for functions, along with the path of every node in it.
+type spectralDocument struct {
+	root  *yaml.Node
+	data  map[string]interface{}
+	paths map[*yaml.Node][]interface{}
+}
+
+// NewSpectralJSRuleFunction creates a function from the script of a Spectral function. The name is the name the
+// ruleset declares the function with.
+func NewSpectralJSRuleFunction(name, script string) *SpectralJSRuleFunction {
+	return &SpectralJSRuleFunction{
+		name:   name,
+		script: TranslateESM(script),
+	}
+}
+
+// TranslateESM rewrites the ES module syntax Spectral functions are written in (default exports and imports) into
+// CommonJS, which the runtime understands.
+func TranslateESM(script string) string {
+	script = esmNamedImport.ReplaceAllString(script, "const $1 = require($2);")
+	script = esmDefaultImport.ReplaceAllString(script, "const $1 = require($2);")
+	return esmDefaultExport.ReplaceAllString(script, "module.exports = ")
+}
+
+// GetSchema returns a schema named after the function, Spectral functions describe their options with JSON Schema,
+// which is not checked by vacuum.
+func (s *SpectralJSRuleFunction) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{Name: s.name}
+}
+
+// GetCategory returns the category of the function.
+func (s *SpectralJSRuleFunction) GetCategory() string {
+	return model.FunctionCategoryCustomJS
+}
+
+// CheckScript makes sure the script runs, and exports a function.
+func (s *SpectralJSRuleFunction) CheckScript() error {
+	_, _, err := s.load(nil)
+	return err
+}
+
+// load runs the script in a new runtime and returns the function it exports. The script is only compiled once.
+func (s *SpectralJSRuleFunction) load(logger *slog.Logger) (*goja.Runtime, goja.Callable, error) {
+	s.compile.Do(func() {
+		s.program, s.compileErr = goja.Compile(s.name, s.script, false)
+	})
+	if s.compileErr != nil {
+		return nil, nil, s.compileErr
+	}
+	rt := BuildSpectralVM(logger)
+	module := rt.NewObject()
+	exports := rt.NewObject()
+	_ = module.Set("exports", exports)
+	_ = rt.Set("module", module)
+	_ = rt.Set("exports", exports)
+
+	if _, err := rt.RunProgram(s.program); err != nil {
+		return nil, nil, err
+	}
+	exported := module.Get("exports")
+	if fn, ok := goja.AssertFunction(exported); ok {
+		return rt, fn, nil
+	}
+	// transpiled modules export the function as 'default'.
+	if obj, ok := exported.(*goja.Object); ok {
+		if fn, okD := goja.AssertFunction(obj.Get("default")); okD {
+			return rt, fn, nil
+		}
+	}
+	return nil, nil, fmt.Errorf("function '%s' does not export a function", s.name)
+}
+
+// RunRule calls the function for every node, just like Spectral calls it for every value matched by 'given'.
+func (s *SpectralJSRuleFunction) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	var results []model.RuleFunctionResult
+	var document map[string]interface{}
+	var paths map[*yaml.Node][]interface{}
+	if doc := s.documentFor(spectralDocumentRoot(context)); doc != nil {
+		document = doc.data
+		paths = doc.paths
+	}
+	for _, node := range nodes {
+
+		// functions run async, so every node gets a fresh runtime.
+		rt, fn, err := s.load(context.Logger)
+		if err != nil {
+			return []model.RuleFunctionResult{s.failure(node, context, err)}
+		}
+
+		target := node
+		field := ""
+		if context.RuleAction != nil {
+			field = context.RuleAction.Field
+		}
+		targetVal := goja.Undefined()
+		nodePath, found := paths[node]
+		if field != "" {
+			_, target = utils.FindKeyNodeTop(field, node.Content)
+			nodePath = append(append([]interface{}{}, nodePath...), field)
+		}
+		if target != nil {
+			var enc interface{}
+			_ = target.Decode(&enc)
+			targetVal = rt.ToValue(enc)
+		} else {
+			target = node
+		}
+
+		ruleName := ""
+		if context.Rule != nil {
+			ruleName = context.Rule.Id
+		}
+		if nodePath == nil {
+			nodePath = []interface{}{}
+		}
+		jsContext := map[string]interface{}{
+			"path": nodePath,
+			"rule": map[string]interface{}{"name": ruleName},
+		}
+		if document != nil {
+			jsContext["document"] = document
+		}
+
+		stop := interruptWhenDone(context.Context, rt)
+		output, rErr := fn(goja.Undefined(), targetVal, rt.ToValue(context.Options), rt.ToValue(jsContext))
+		stop()
+		if rErr != nil {
+			if _, ok := rErr.(*goja.InterruptedError); ok {
+				return results // the rule has timed out, or linting has been cancelled.
+			}
+			if jsErr, ok := rErr.(*goja.Exception); ok {
+				rErr = fmt.Errorf("%s", jsErr.Value().String())
+			}
+			return []model.RuleFunctionResult{s.failure(node, context, rErr)}
+		}
+		if output == nil || goja.IsUndefined(output) || goja.IsNull(output) {
+			continue
+		}
+
+		exported, ok := output.Export().([]interface{})
+		if !ok {
+			return []model.RuleFunctionResult{s.failure(node, context,
+				fmt.Errorf("results must be an array, not '%s'", output.String()))}
+		}
+		for _, e := range exported {
+			r, okR := e.(map[string]interface{})
+			if !okR {
+				continue
+			}
+			path := fmt.Sprint(context.Given)
+			if found {
+				path = BuildSpectralPath(nodePath) // like Spectral, a result without a path is at the target.
+			}
+			if segments, okP := r["path"].([]interface{}); okP && len(segments) > 0 {
+				path = BuildSpectralPath(segments)
+			}
+			results = append(results, model.RuleFunctionResult{
+				Message:   fmt.Sprint(r["message"]),
+				StartNode: target,
+				EndNode:   target,
+				Range: reports.Range{
+					Start: reports.RangeItem{Line: target.Line, Char: target.Column},
+					End:   reports.RangeItem{Line: target.Line, Char: target.Column},
+				},
+				Path: path,
+				Rule: context.Rule,
+			})
+		}
+	}
+	return results
+}
+
+// spectralDocumentRoot returns the root of the document the rule is being run against, if there is one.
+func spectralDocumentRoot(context model.RuleFunctionContext) *yaml.Node {
+	var root *yaml.Node
+	if context.Index != nil {
+		root = context.Index.GetRootNode()
+	}
+	if root == nil && context.SpecInfo != nil {
+		root = context.SpecInfo.RootNode
+	}
+	if root != nil && root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	return root
+}
+
+// documentFor decodes the document with the supplied root, and finds the path of every node in it. The function is
+// run once for every matched node, so the last document is kept, rather than decoding it again for every node.
+func (s *SpectralJSRuleFunction) documentFor(root *yaml.Node) *spectralDocument {
+	if root == nil {
+		return nil
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if s.document != nil && s.document.root == root {
+		return s.document
+	}
+	var data interface{}
+	_ = root.Decode(&data)
+	s.document = &spectralDocument{
+		root:  root,
+		data:  map[string]interface{}{"data": data},
+		paths: nodePaths(root),
+	}
+	return s.document
+}
+
+// nodePaths walks the document once, to find the path segments (keys and indexes) of every node, so functions get
+// the same context.path as Spectral gives them. Nodes that are not in the document have no path.
+func nodePaths(root *yaml.Node) map[*yaml.Node][]interface{} {
+	paths := make(map[*yaml.Node][]interface{})
+	var walk func(node *yaml.Node, path []interface{})
+	walk = func(node *yaml.Node, path []interface{}) {
+		if _, ok := paths[node]; ok {
+			return // an alias of a node that has been seen already.
+		}
+		paths[node] = append([]interface{}{}, path...)
+		switch node.Kind {
+		case yaml.MappingNode:
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				walk(node.Content[i+1], append(path, node.Content[i].Value))
+			}
+		case yaml.SequenceNode:
+			for i, n := range node.Content {
+				walk(n, append(path, i))
+			}
+		}
+	}
+	walk(root, nil)
+	return paths
+}
+
+func (s *SpectralJSRuleFunction) failure(node *yaml.Node, context model.RuleFunctionContext, err error) model.RuleFunctionResult {
+	return model.RuleFunctionResult{
+		Message:   fmt.Sprintf("Unable to execute Spectral function: '%s': %s", s.name, err.Error()),
+		StartNode: node,
+		EndNode:   node,
+		Path:      fmt.Sprint(context.Given),
+		Rule:      context.Rule,
+	}
+}
+
+// BuildSpectralPath converts the path segments a Spectral function returns, into a JSON path.
+func BuildSpectralPath(segments []interface{}) string {
+	templates := model.GetStringTemplates()
+	path := "$"
+	for _, segment := range segments {
+		switch v := segment.(type) {
+		case int:
+			path = templates.BuildArrayPath(path, v)
+		case int64:
+			path = templates.BuildArrayPath(path, int(v))
+		case float64:
+			path = templates.BuildArrayPath(path, int(v))
+		default:
+			key := fmt.Sprint(v)
+			if plainPathSegment.MatchString(key) {
+				path = templates.BuildJSONPath(path, key)
+			} else {
+				path = templates.BuildQuotedPath(path, key)
+			}
+		}
+	}
+	return path
+}
+
+// BuildSpectralVM creates a runtime that can 'require' the Spectral helpers custom functions use. Anything functions
+// write to the console goes to the logger, so it never ends up in a report written to stdout.
+func BuildSpectralVM(logger *slog.Logger) *goja.Runtime {
+	if logger == nil {
+		logger = slog.New(slog.NewTextHandler(io.Discard, nil))
+	}
+	rt := goja.New()
+	rt.SetFieldNameMapper(goja.TagFieldNameMapper("json", true))
+	reg := new(require.Registry)
+	reg.RegisterNativeModule(SpectralCoreModule, func(rt *goja.Runtime, module *goja.Object) {
+		exports := module.Get("exports").(*goja.Object)
+		// createRulesetFunction validates input and options with JSON Schema, vacuum just runs the function.
+		_ = exports.Set("createRulesetFunction", func(call goja.FunctionCall) goja.Value {
+			return call.Argument(1)
+		})
+	})
+	reg.RegisterNativeModule(console.ModuleName, console.RequireWithPrinter(consolePrinter{logger: logger}))
+	reg.Enable(rt)
+	console.Enable(rt)
+	return rt
+}
+
+// consolePrinter prints the console output of functions with a logger.
+type consolePrinter struct {
+	logger *slog.Logger
+}
+
+func (p consolePrinter) Log(s string)   { p.logger.Info(s) }
+func (p consolePrinter) Warn(s string)  { p.logger.Warn(s) }
+func (p consolePrinter) Error(s string) { p.logger.Error(s) }
@@ -0,0 +1,150 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package javascript
+
+import (
+	"bytes"
+	"log/slog"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/datamodel"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func parseSpectralTestNode(t *testing.T, yml string) *yaml.Node {
+	var y yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(yml), &y))
+	return y.Content[0]
+}
+
+func TestSpectralJSRuleFunction_CommonJS(t *testing.T) {
+	script := `module.exports = function (targetVal, opts, context) {
+  if (targetVal !== opts.value) {
+    return [{ message: context.rule.name + ': ' + targetVal + ' is not ' + opts.value }];
+  }
+};`
+
+	f := NewSpectralJSRuleFunction("equals", script)
+	assert.NoError(t, f.CheckScript())
+	assert.Equal(t, "equals", f.GetSchema().Name)
+	assert.Equal(t, model.FunctionCategoryCustomJS, f.GetCategory())
+
+	rule := &model.Rule{Id: "must-be-cake"}
+	res := f.RunRule([]*yaml.Node{parseSpectralTestNode(t, "pizza"), parseSpectralTestNode(t, "cake")},
+		model.RuleFunctionContext{
+			Rule:    rule,
+			Given:   "$.info.title",
+			Options: map[string]interface{}{"value": "cake"},
+		})
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "must-be-cake: pizza is not cake", res[0].Message)
+	assert.Equal(t, "$.info.title", res[0].Path)
+	assert.Equal(t, rule, res[0].Rule)
+}
+
+func TestSpectralJSRuleFunction_ESM_Field(t *testing.T) {
+	script := `import { createRulesetFunction } from '@stoplight/spectral-core';
+
+export default createRulesetFunction({ input: null, options: null }, (targetVal) => {
+  if (targetVal === undefined) {
+    return [{ message: 'missing', path: ['paths', '/pizza', 'get', 'tags', 0] }];
+  }
+  return [];
+});`
+
+	f := NewSpectralJSRuleFunction("missing", script)
+	assert.NoError(t, f.CheckScript())
+
+	node := parseSpectralTestNode(t, "summary: hello\ndescription: there")
+	res := f.RunRule([]*yaml.Node{node}, model.RuleFunctionContext{
+		RuleAction: &model.RuleAction{Field: "description"},
+	})
+	assert.Empty(t, res)
+
+	res = f.RunRule([]*yaml.Node{node}, model.RuleFunctionContext{
+		RuleAction: &model.RuleAction{Field: "operationId"},
+	})
+	assert.Len(t, res, 1)
+	assert.Equal(t, "$.paths['/pizza'].get.tags[0]", res[0].Path)
+	assert.Equal(t, node, res[0].StartNode)
+}
+
+func TestSpectralJSRuleFunction_PathAndDocument(t *testing.T) {
+	script := `module.exports = function (targetVal, opts, context) {
+  return [{ message: context.path.join('/') + ' in ' + context.document.data.info.title }];
+};`
+
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte("info:\n  title: pizza\ntags:\n  - name: a\n  - name: b"), &root))
+	tag := root.Content[0].Content[3].Content[1]
+
+	f := NewSpectralJSRuleFunction("where", script)
+	res := f.RunRule([]*yaml.Node{tag}, model.RuleFunctionContext{
+		Given:      "$.tags[*]",
+		RuleAction: &model.RuleAction{Field: "name"},
+		SpecInfo:   &datamodel.SpecInfo{RootNode: &root},
+	})
+	assert.Len(t, res, 1)
+	assert.Equal(t, "tags/1/name in pizza", res[0].Message)
+	assert.Equal(t, "$.tags[1].name", res[0].Path)
+}
+
+func TestSpectralJSRuleFunction_DocumentDecodedOnce(t *testing.T) {
+	script := `module.exports = function (targetVal, opts, context) {
+  console.log('checking ' + context.path.join('/'));
+  return [{ message: targetVal }];
+};`
+
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte("tags:\n  - name: a\n  - name: b"), &root))
+	tags := root.Content[0].Content[1]
+
+	var logs bytes.Buffer
+	f := NewSpectralJSRuleFunction("once", script)
+	ctx := model.RuleFunctionContext{
+		RuleAction: &model.RuleAction{Field: "name"},
+		SpecInfo:   &datamodel.SpecInfo{RootNode: &root},
+		Logger:     slog.New(slog.NewTextHandler(&logs, nil)),
+	}
+
+	res := f.RunRule([]*yaml.Node{tags.Content[0]}, ctx)
+	assert.Len(t, res, 1)
+	document := f.document
+	program := f.program
+
+	res = f.RunRule([]*yaml.Node{tags.Content[1]}, ctx)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "b", res[0].Message)
+	assert.Equal(t, "$.tags[1].name", res[0].Path)
+	assert.Same(t, document, f.document)
+	assert.Same(t, program, f.program)
+
+	// console output goes to the logger, never to stdout.
+	assert.Contains(t, logs.String(), "checking tags/0/name")
+	assert.Contains(t, logs.String(), "checking tags/1/name")
+}
+
+func TestSpectralJSRuleFunction_Failures(t *testing.T) {
+	assert.Error(t, NewSpectralJSRuleFunction("nope", `module.exports = 'not a function';`).CheckScript())
+	assert.Error(t, NewSpectralJSRuleFunction("broken", `module.exports = (`).CheckScript())
+
+	node := parseSpectralTestNode(t, "pizza")
+	res := NewSpectralJSRuleFunction("throws", `module.exports = () => { throw new Error('oh no'); };`).
+		RunRule([]*yaml.Node{node}, model.RuleFunctionContext{})
+	assert.Len(t, res, 1)
+	assert.Equal(t, "Unable to execute Spectral function: 'throws': Error: oh no", res[0].Message)
+
+	res = NewSpectralJSRuleFunction("object", `module.exports = () => ({ message: 'nope' });`).
+		RunRule([]*yaml.Node{node}, model.RuleFunctionContext{})
+	assert.Len(t, res, 1)
+	assert.Contains(t, res[0].Message, "results must be an array")
+}
+
+func TestTranslateESM(t *testing.T) {
+	assert.Equal(t, "const { a, b } = require('x');\nconst c = require(\"y\");\nmodule.exports = a;",
+		TranslateESM("import { a, b } from 'x';\nimport c from \"y\"\nexport default a;"))
+}
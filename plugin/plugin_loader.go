@@ -5,6 +5,7 @@ import (
 	"github.com/daveshanley/vacuum/functions/core"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/plugin/javascript"
+	"github.com/daveshanley/vacuum/plugin/wasm"
 	"github.com/pterm/pterm"
 	"gopkg.in/yaml.v3"
 	"os"
@@ -92,6 +93,31 @@ func LoadFunctions(path string, silence bool) (*Manager, error) {
 				pterm.Info.Printf("Registered custom function: '%s' -> available for use in rulesets\n", schemaName)
 			}
 		}
+
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".wasm") {
+			fPath := filepath.Join(path, entry.Name())
+			fName := strings.Split(entry.Name(), ".")[0]
+
+			// let's try and read the file
+			p, e := os.ReadFile(fPath)
+			if e != nil {
+				return nil, e
+			}
+
+			// compile the module, invalid modules are skipped, just like invalid scripts.
+			function, wErr := wasm.NewWASMRuleFunction(fName, p)
+			if wErr != nil {
+				pterm.Error.Printf("Failed to load WASM function '%s': %s\n", fName, wErr.Error())
+				continue
+			}
+
+			schemaName := function.GetSchema().Name
+			pm.RegisterFunction(schemaName, function)
+
+			if !silence {
+				pterm.Info.Printf("Registered custom WASM function: '%s' from file: %s\n", schemaName, fPath)
+			}
+		}
 	}
 	return pm, nil
 }
@@ -151,3 +177,27 @@ func RegisterCoreFunctions(rule javascript.JSEnabledRuleFunction) {
 	rule.RegisterCoreFunction("xor", xor)
 	rule.RegisterCoreFunction("blank", blank)
 }
+
+// LoadSpectralFunctions will load the Spectral style JavaScript functions declared by a ruleset (`functions`), from
+// the ruleset's functions directory (`functionsDir`). Every function is a file named after the function.
+func LoadSpectralFunctions(path string, names []string, silence bool) (*Manager, error) {
+	pm := CreatePluginManager()
+	for _, name := range names {
+		fPath := filepath.Join(path, name+".js")
+		p, err := os.ReadFile(fPath)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read function '%s': %w", name, err)
+		}
+
+		function := javascript.NewSpectralJSRuleFunction(name, string(p))
+		if sErr := function.CheckScript(); sErr != nil {
+			return nil, fmt.Errorf("failed to load function '%s': %w", name, sErr)
+		}
+		pm.RegisterFunction(name, function)
+
+		if !silence {
+			pterm.Info.Printf("Registered Spectral function: '%s' from file: %s\n", name, fPath)
+		}
+	}
+	return pm, nil
+}
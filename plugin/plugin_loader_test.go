@@ -47,3 +47,22 @@ func TestLoadFunctions_TestCompile(t *testing.T) {
 		assert.Equal(t, 0, pm.LoadedFunctionCount())
 	}
 }
+
+func TestLoadFunctions_WASM_OK(t *testing.T) {
+	pm, err := LoadFunctions("sample/wasm", false)
+	assert.NotNil(t, pm)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, pm.LoadedFunctionCount())
+	assert.Equal(t, "infoCheck",
+		pm.GetCustomFunctions()["infoCheck"].GetSchema().Name)
+}
+
+func TestLoadSpectralFunctions(t *testing.T) {
+	pm, err := LoadSpectralFunctions("../rulesets/examples/functions", []string{"operationSummary", "tagNames"}, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, pm.LoadedFunctionCount())
+	assert.Equal(t, "tagNames", pm.GetCustomFunctions()["tagNames"].GetSchema().Name)
+
+	_, err = LoadSpectralFunctions("../rulesets/examples/functions", []string{"missing"}, true)
+	assert.Error(t, err)
+}
@@ -1,6 +1,8 @@
 package plugin
 
 import (
+	"io"
+
 	"github.com/daveshanley/vacuum/model"
 	"gopkg.in/yaml.v3"
 )
@@ -31,3 +33,13 @@ func (pm *Manager) LoadedFunctionCount() int {
 func (pm *Manager) GetCustomFunctions() map[string]model.RuleFunction {
 	return pm.customFunctions
 }
+
+// CloseFunctions releases anything held by custom functions that are being unloaded, like the runtimes of WASM
+// modules. Functions that hold nothing are left alone.
+func CloseFunctions(functions map[string]model.RuleFunction) {
+	for _, f := range functions {
+		if c, ok := f.(io.Closer); ok {
+			_ = c.Close()
+		}
+	}
+}
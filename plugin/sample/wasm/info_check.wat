@@ -0,0 +1,20 @@
+;; A tiny hand written vacuum WASM function, compiled into info_check.wasm. It reads the input and reports a single
+;; result against the second node it was given (node 1), which tests the whole host ABI without a toolchain.
+(module
+  (import "vacuum" "input_len" (func $input_len (result i32)))
+  (import "vacuum" "input_read" (func $input_read (param i32)))
+  (import "vacuum" "emit_result" (func $emit_result (param i32 i32)))
+  (import "vacuum" "emit_schema" (func $emit_schema (param i32 i32)))
+  (memory (export "memory") 1)
+  (data (i32.const 16) "{\"name\":\"infoCheck\"}")
+  (data (i32.const 64) "{\"message\":\"info check ran\",\"node\":1}")
+  (func (export "vacuum_schema")
+    (call $emit_schema (i32.const 16) (i32.const 20)))
+  (func (export "vacuum_run")
+    (local $n i32)
+    (local.set $n (call $input_len))
+    ;; make sure there is room for the input at offset 1024.
+    (drop (memory.grow (i32.add (i32.shr_u (local.get $n) (i32.const 16)) (i32.const 1))))
+    (call $input_read (i32.const 1024))
+    (if (local.get $n)
+      (then (call $emit_result (i32.const 64) (i32.const 37))))))
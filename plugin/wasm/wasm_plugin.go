@@ -0,0 +1,304 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package wasm runs custom rule functions that have been compiled to WebAssembly. Modules are run by wazero, so
+// they work the same way on every platform (unlike Go plugins) and can be written in any language that targets WASM.
+//
+// A module talks to vacuum through the host module 'vacuum', which provides the following functions:
+//
+//	input_len() i32                  - the size of the JSON input for this call.
+//	input_read(ptr i32)              - copies the JSON input into guest memory at ptr.
+//	emit_result(ptr i32, len i32)    - reports a result, the JSON at ptr is {"message", "path", "node"}.
+//	emit_schema(ptr i32, len i32)    - describes the function, the JSON at ptr is a model.RuleFunctionSchema.
+//
+// A module must export its memory and a 'vacuum_run' function, it may also export 'vacuum_schema' (which should call
+// emit_schema). The JSON input contains the rule, the rule options and the nodes matched by the rule's 'given' path.
+// Every node has an id, a result can reference a node by id so that it is reported at the right line and column.
+package wasm
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	hostModule   = "vacuum"
+	runExport    = "vacuum_run"
+	schemaExport = "vacuum_schema"
+)
+
+// WASMRuleFunction is a custom rule function that runs a WASM module.
+type WASMRuleFunction struct {
+	ruleName string
+	runtime  wazero.Runtime
+	compiled wazero.CompiledModule
+	schema   model.RuleFunctionSchema
+}
+
+// Node is a yaml node as it is passed to a WASM module.
+type Node struct {
+	Id      int     `json:"id"`
+	Kind    string  `json:"kind"`
+	Tag     string  `json:"tag,omitempty"`
+	Value   string  `json:"value,omitempty"`
+	Line    int     `json:"line"`
+	Column  int     `json:"column"`
+	Content []*Node `json:"content,omitempty"`
+}
+
+// Input is the JSON document a WASM module reads with input_read.
+type Input struct {
+	Rule    InputRule   `json:"rule"`
+	Options interface{} `json:"options,omitempty"`
+	Nodes   []*Node     `json:"nodes"`
+}
+
+// InputRule describes the rule a WASM module is being run for.
+type InputRule struct {
+	Id       string `json:"id"`
+	Given    string `json:"given"`
+	Severity string `json:"severity,omitempty"`
+}
+
+// Result is the JSON document a WASM module reports with emit_result.
+type Result struct {
+	Message string `json:"message"`
+	Path    string `json:"path,omitempty"`
+	Node    *int   `json:"node,omitempty"`
+}
+
+// call holds the state of a single run of a module, the host functions find it in the context.
+type call struct {
+	input   []byte
+	nodes   []*yaml.Node
+	results []Result
+	schema  *model.RuleFunctionSchema
+}
+
+type callKey struct{}
+
+// NewWASMRuleFunction compiles the supplied WASM binary into a rule function. The rule name is used as the name of
+// the function, unless the module describes itself with a schema.
+func NewWASMRuleFunction(ruleName string, binary []byte) (*WASMRuleFunction, error) {
+	ctx := context.Background()
+	// modules are stopped when a rule times out, or linting is cancelled.
+	rt := wazero.NewRuntimeWithConfig(ctx, wazero.NewRuntimeConfig().WithCloseOnContextDone(true))
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, rt); err != nil {
+		_ = rt.Close(ctx)
+		return nil, err
+	}
+	if err := instantiateHostModule(ctx, rt); err != nil {
+		_ = rt.Close(ctx)
+		return nil, err
+	}
+	compiled, err := rt.CompileModule(ctx, binary)
+	if err != nil {
+		_ = rt.Close(ctx)
+		return nil, err
+	}
+	exports := compiled.ExportedFunctions()
+	if _, ok := exports[runExport]; !ok {
+		_ = rt.Close(ctx)
+		return nil, fmt.Errorf("%s function not found", runExport)
+	}
+
+	w := &WASMRuleFunction{
+		ruleName: ruleName,
+		runtime:  rt,
+		compiled: compiled,
+		schema:   model.RuleFunctionSchema{Name: ruleName},
+	}
+	if _, ok := exports[schemaExport]; ok {
+		c := &call{}
+		if err = w.run(ctx, c, schemaExport); err != nil {
+			_ = rt.Close(ctx)
+			return nil, fmt.Errorf("unable to read schema: %s", err.Error())
+		}
+		if c.schema != nil && c.schema.Name != "" {
+			w.schema = *c.schema
+		}
+	}
+	return w, nil
+}
+
+// Close releases the runtime the module was compiled into, the function can't be run once it has been closed.
+func (w *WASMRuleFunction) Close() error {
+	return w.runtime.Close(context.Background())
+}
+
+// GetSchema returns the schema the module described itself with, or a schema named after the rule.
+func (w *WASMRuleFunction) GetSchema() model.RuleFunctionSchema {
+	return w.schema
+}
+
+// GetCategory returns the category of the function.
+func (w *WASMRuleFunction) GetCategory() string {
+	return model.FunctionCategoryCustomWASM
+}
+
+// RunRule runs the module once, with all the nodes matched by the rule.
+func (w *WASMRuleFunction) RunRule(nodes []*yaml.Node, context model.RuleFunctionContext) []model.RuleFunctionResult {
+	if len(nodes) == 0 {
+		return nil
+	}
+	c, err := buildCall(nodes, context)
+	if err == nil {
+		err = w.run(context.Context, c, runExport)
+	}
+	if err != nil {
+		return []model.RuleFunctionResult{
+			{
+				Message: fmt.Sprintf("Unable to execute WASM function: '%s': %s",
+					w.ruleName, err.Error()),
+				StartNode: nodes[0],
+				EndNode:   nodes[0],
+				Path:      fmt.Sprint(context.Given),
+				Rule:      context.Rule,
+			},
+		}
+	}
+
+	var results []model.RuleFunctionResult
+	for _, r := range c.results {
+		node := nodes[0]
+		if r.Node != nil && *r.Node >= 0 && *r.Node < len(c.nodes) {
+			node = c.nodes[*r.Node]
+		}
+		path := r.Path
+		if path == "" {
+			path = fmt.Sprint(context.Given)
+		}
+		results = append(results, model.RuleFunctionResult{
+			Message:   r.Message,
+			StartNode: node,
+			EndNode:   node,
+			Range: reports.Range{
+				Start: reports.RangeItem{Line: node.Line, Char: node.Column},
+				End:   reports.RangeItem{Line: node.Line, Char: node.Column},
+			},
+			Path: path,
+			Rule: context.Rule,
+		})
+	}
+	return results
+}
+
+// run instantiates a fresh copy of the module and calls the export. Rules run concurrently, so every call gets its
+// own instance and nothing leaks between them. The module is stopped when the context is done.
+func (w *WASMRuleFunction) run(runCtx context.Context, c *call, export string) error {
+	if runCtx == nil {
+		runCtx = context.Background()
+	}
+	ctx := context.WithValue(runCtx, callKey{}, c)
+	mod, err := w.runtime.InstantiateModule(ctx, w.compiled,
+		wazero.NewModuleConfig().WithName("").WithStartFunctions("_initialize"))
+	if err != nil {
+		return err
+	}
+	defer mod.Close(ctx)
+	_, err = mod.ExportedFunction(export).Call(ctx)
+	return err
+}
+
+// buildCall encodes the nodes and rule context into the JSON input for the module.
+func buildCall(nodes []*yaml.Node, context model.RuleFunctionContext) (*call, error) {
+	c := &call{}
+	input := Input{Options: context.Options}
+	if context.Rule != nil {
+		input.Rule = InputRule{Id: context.Rule.Id, Given: fmt.Sprint(context.Rule.Given), Severity: context.Rule.Severity}
+	}
+	for _, n := range nodes {
+		input.Nodes = append(input.Nodes, c.encodeNode(n))
+	}
+	var err error
+	if c.input, err = json.Marshal(input); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// encodeNode converts a yaml node (and everything below it) into a Node, ids are assigned in document order.
+func (c *call) encodeNode(n *yaml.Node) *Node {
+	encoded := &Node{
+		Id:     len(c.nodes),
+		Kind:   nodeKind(n.Kind),
+		Tag:    n.Tag,
+		Value:  n.Value,
+		Line:   n.Line,
+		Column: n.Column,
+	}
+	c.nodes = append(c.nodes, n)
+	// aliases are not followed, they can point back up the tree.
+	for _, child := range n.Content {
+		encoded.Content = append(encoded.Content, c.encodeNode(child))
+	}
+	return encoded
+}
+
+func nodeKind(kind yaml.Kind) string {
+	switch kind {
+	case yaml.DocumentNode:
+		return "document"
+	case yaml.SequenceNode:
+		return "sequence"
+	case yaml.MappingNode:
+		return "mapping"
+	case yaml.AliasNode:
+		return "alias"
+	default:
+		return "scalar"
+	}
+}
+
+func instantiateHostModule(ctx context.Context, rt wazero.Runtime) error {
+	_, err := rt.NewHostModuleBuilder(hostModule).
+		NewFunctionBuilder().WithFunc(func(ctx context.Context) uint32 {
+		return uint32(len(currentCall(ctx).input))
+	}).Export("input_len").
+		NewFunctionBuilder().WithFunc(func(ctx context.Context, m api.Module, ptr uint32) {
+		if m.Memory() == nil || !m.Memory().Write(ptr, currentCall(ctx).input) {
+			panic(errors.New("input_read: memory out of range"))
+		}
+	}).Export("input_read").
+		NewFunctionBuilder().WithFunc(func(ctx context.Context, m api.Module, ptr, size uint32) {
+		var r Result
+		decodeGuestJSON(m, ptr, size, "emit_result", &r)
+		c := currentCall(ctx)
+		c.results = append(c.results, r)
+	}).Export("emit_result").
+		NewFunctionBuilder().WithFunc(func(ctx context.Context, m api.Module, ptr, size uint32) {
+		var s model.RuleFunctionSchema
+		decodeGuestJSON(m, ptr, size, "emit_schema", &s)
+		currentCall(ctx).schema = &s
+	}).Export("emit_schema").
+		Instantiate(ctx)
+	return err
+}
+
+// decodeGuestJSON reads JSON out of guest memory, a panic inside a host function traps the module and is returned
+// to the caller as an error.
+func decodeGuestJSON(m api.Module, ptr, size uint32, function string, v interface{}) {
+	if m.Memory() == nil {
+		panic(fmt.Errorf("%s: module does not export memory", function))
+	}
+	b, ok := m.Memory().Read(ptr, size)
+	if !ok {
+		panic(fmt.Errorf("%s: memory out of range", function))
+	}
+	if err := json.Unmarshal(b, v); err != nil {
+		panic(fmt.Errorf("%s: %s", function, err.Error()))
+	}
+}
+
+func currentCall(ctx context.Context) *call {
+	return ctx.Value(callKey{}).(*call)
+}
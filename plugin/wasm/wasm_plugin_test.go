@@ -0,0 +1,93 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package wasm
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func loadInfoCheck(t *testing.T) *WASMRuleFunction {
+	binary, err := os.ReadFile("../sample/wasm/info_check.wasm")
+	assert.NoError(t, err)
+	f, err := NewWASMRuleFunction("info_check", binary)
+	assert.NoError(t, err)
+	return f
+}
+
+func TestNewWASMRuleFunction(t *testing.T) {
+	f := loadInfoCheck(t)
+	assert.Equal(t, "infoCheck", f.GetSchema().Name)
+	assert.Equal(t, model.FunctionCategoryCustomWASM, f.GetCategory())
+}
+
+func TestNewWASMRuleFunction_Invalid(t *testing.T) {
+	_, err := NewWASMRuleFunction("nope", []byte("not wasm"))
+	assert.Error(t, err)
+
+	// an empty, but valid module.
+	_, err = NewWASMRuleFunction("empty", []byte("\x00asm\x01\x00\x00\x00"))
+	assert.EqualError(t, err, "vacuum_run function not found")
+}
+
+func TestWASMRuleFunction_RunRule(t *testing.T) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte("info:\n  title: pizza"), &root))
+	rule := &model.Rule{Id: "wasm-info", Given: "$"}
+
+	res := loadInfoCheck(t).RunRule([]*yaml.Node{root.Content[0]},
+		model.RuleFunctionContext{Rule: rule, Given: "$"})
+
+	assert.Len(t, res, 1)
+	assert.Equal(t, "info check ran", res[0].Message)
+	assert.Equal(t, "$", res[0].Path)
+	assert.Equal(t, root.Content[0].Content[0], res[0].StartNode)
+	assert.Equal(t, 1, res[0].Range.Start.Line)
+	assert.Equal(t, rule, res[0].Rule)
+}
+
+func TestWASMRuleFunction_Close(t *testing.T) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte("info:\n  title: pizza"), &root))
+	f := loadInfoCheck(t)
+	assert.NoError(t, f.Close())
+
+	// a closed module can't be run any more.
+	res := f.RunRule([]*yaml.Node{root.Content[0]}, model.RuleFunctionContext{Rule: &model.Rule{Id: "wasm-info"}})
+	assert.Len(t, res, 1)
+	assert.Contains(t, res[0].Message, "Unable to execute WASM function")
+}
+
+func TestWASMRuleFunction_RunRule_NoNodes(t *testing.T) {
+	assert.Nil(t, loadInfoCheck(t).RunRule(nil, model.RuleFunctionContext{}))
+}
+
+func TestBuildCall(t *testing.T) {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte("tags:\n  - pizza"), &root))
+	rule := &model.Rule{Id: "wasm-tags", Given: "$.tags", Severity: model.SeverityWarn}
+
+	c, err := buildCall([]*yaml.Node{root.Content[0]}, model.RuleFunctionContext{
+		Rule:    rule,
+		Options: map[string]interface{}{"max": 3},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, c.nodes, 4)
+
+	var input Input
+	assert.NoError(t, json.Unmarshal(c.input, &input))
+	assert.Equal(t, InputRule{Id: "wasm-tags", Given: "$.tags", Severity: model.SeverityWarn}, input.Rule)
+	assert.Equal(t, map[string]interface{}{"max": float64(3)}, input.Options)
+	assert.Equal(t, "mapping", input.Nodes[0].Kind)
+	seq := input.Nodes[0].Content[1]
+	assert.Equal(t, "sequence", seq.Kind)
+	assert.Equal(t, 3, seq.Content[0].Id)
+	assert.Equal(t, "pizza", seq.Content[0].Value)
+	assert.Equal(t, 2, seq.Content[0].Line)
+}
@@ -0,0 +1,236 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package publish
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+)
+
+// BitbucketAPIURL is the Bitbucket Cloud REST API.
+const BitbucketAPIURL = "https://api.bitbucket.org/2.0"
+
+// BitbucketMaxAnnotations is the most annotations Code Insights accepts for a report, and
+// BitbucketAnnotationBatch the most that can be sent in one request.
+const (
+	BitbucketMaxAnnotations  = 1000
+	BitbucketAnnotationBatch = 100
+)
+
+// DefaultBitbucketReportID identifies the Code Insights report, a commit has a single report for each id.
+const DefaultBitbucketReportID = "vacuum"
+
+// BitbucketPublisher publishes a Code Insights report, with an annotation for each finding, on a commit. Bitbucket
+// decorates pull requests with the reports of their commits. Bitbucket Cloud is used unless ServerURL is set, for
+// Bitbucket Server and Data Center.
+type BitbucketPublisher struct {
+	Client     *http.Client // defaults to http.DefaultClient.
+	APIURL     string       // Bitbucket Cloud API, defaults to BitbucketAPIURL.
+	ServerURL  string       // Bitbucket Server or Data Center, like 'https://bitbucket.example.com'.
+	Token      string       // an access token, or an app password if Username is set.
+	Username   string
+	Workspace  string // the workspace (Cloud) or project key (Server) of the repository.
+	Repo       string // the repository slug.
+	Commit     string
+	ReportID   string                    // defaults to DefaultBitbucketReportID.
+	Statistics *reports.ReportStatistics // optional, the score is added to the report.
+}
+
+type bitbucketData struct {
+	Title string `json:"title"`
+	Type  string `json:"type"`
+	Value any    `json:"value"`
+}
+
+type bitbucketReport struct {
+	Title      string           `json:"title"`
+	Details    string           `json:"details"`
+	Reporter   string           `json:"reporter"`
+	ReportType string           `json:"report_type,omitempty"` // Cloud only.
+	Result     string           `json:"result"`
+	Data       []*bitbucketData `json:"data"`
+}
+
+// bitbucketAnnotation carries the fields of both Cloud and Server annotations, each ignores the other's.
+type bitbucketAnnotation struct {
+	ExternalID     string `json:"external_id,omitempty"`
+	AnnotationType string `json:"annotation_type,omitempty"`
+	Summary        string `json:"summary,omitempty"`
+	Details        string `json:"details,omitempty"`
+	ServerID       string `json:"externalId,omitempty"`
+	Type           string `json:"type,omitempty"`
+	Message        string `json:"message,omitempty"`
+	Severity       string `json:"severity"`
+	Path           string `json:"path"`
+	Line           int    `json:"line"`
+}
+
+// GetBitbucketSeverity converts a vacuum severity into a Code Insights severity.
+func GetBitbucketSeverity(severity string) string {
+	switch severity {
+	case model.SeverityError:
+		return "HIGH"
+	case model.SeverityWarn:
+		return "MEDIUM"
+	}
+	return "LOW"
+}
+
+// Publish replaces the report of the commit, so nothing from an earlier run is left behind, then adds the
+// annotations. Code Insights caps the annotations of a report, the most severe findings are kept.
+func (b *BitbucketPublisher) Publish(findings []*Finding) (*Summary, error) {
+	if b.Workspace == "" || b.Repo == "" || b.Commit == "" {
+		return nil, errors.New("a workspace (or project), repository and commit are required")
+	}
+	reportID := b.ReportID
+	if reportID == "" {
+		reportID = DefaultBitbucketReportID
+	}
+	api := b.api()
+	server := b.ServerURL != ""
+	var report string
+	if server {
+		report = fmt.Sprintf("/rest/insights/1.0/projects/%s/repos/%s/commits/%s/reports/%s", url.PathEscape(b.Workspace),
+			url.PathEscape(b.Repo), b.Commit, url.PathEscape(reportID))
+	} else {
+		report = fmt.Sprintf("/repositories/%s/%s/commit/%s/reports/%s", url.PathEscape(b.Workspace),
+			url.PathEscape(b.Repo), b.Commit, url.PathEscape(reportID))
+	}
+
+	if err := api.request(http.MethodDelete, report, nil, nil); err != nil {
+		var apiErr *apiError
+		if !errors.As(err, &apiErr) || apiErr.statusCode != http.StatusNotFound {
+			return nil, err
+		}
+	}
+	if err := api.request(http.MethodPut, report, b.report(findings, server), nil); err != nil {
+		return nil, err
+	}
+
+	sorted := make([]*Finding, len(findings))
+	copy(sorted, findings)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return severityRank(sorted[i].Severity) < severityRank(sorted[j].Severity)
+	})
+	summary := &Summary{}
+	if len(sorted) > BitbucketMaxAnnotations {
+		summary.Skipped = len(sorted) - BitbucketMaxAnnotations
+		sorted = sorted[:BitbucketMaxAnnotations]
+	}
+	for start := 0; start < len(sorted); start += BitbucketAnnotationBatch {
+		end := min(start+BitbucketAnnotationBatch, len(sorted))
+		batch := make([]*bitbucketAnnotation, 0, end-start)
+		for _, f := range sorted[start:end] {
+			batch = append(batch, bitbucketAnnotationForFinding(f, server))
+		}
+		var err error
+		if server {
+			err = api.request(http.MethodPost, report+"/annotations", map[string]any{"annotations": batch}, nil)
+		} else {
+			err = api.request(http.MethodPost, report+"/annotations", batch, nil)
+		}
+		if err != nil {
+			return summary, err
+		}
+		summary.Created += len(batch)
+	}
+	return summary, nil
+}
+
+func (b *BitbucketPublisher) report(findings []*Finding, server bool) *bitbucketReport {
+	var errs, warnings, info int
+	for _, f := range findings {
+		switch f.Severity {
+		case model.SeverityError:
+			errs++
+		case model.SeverityWarn:
+			warnings++
+		default:
+			info++
+		}
+	}
+	r := &bitbucketReport{
+		Title:    "vacuum",
+		Details:  fmt.Sprintf("vacuum found %d errors, %d warnings and %d informative findings.", errs, warnings, info),
+		Reporter: "vacuum",
+		Result:   "PASSED",
+	}
+	if b.Statistics != nil {
+		r.Data = append(r.Data, &bitbucketData{Title: "Quality score", Type: "PERCENTAGE", Value: b.Statistics.OverallScore})
+	}
+	r.Data = append(r.Data,
+		&bitbucketData{Title: "Errors", Type: "NUMBER", Value: errs},
+		&bitbucketData{Title: "Warnings", Type: "NUMBER", Value: warnings},
+		&bitbucketData{Title: "Info", Type: "NUMBER", Value: info})
+	// Server calls the results PASS and FAIL, and has no report types.
+	switch {
+	case server && errs > 0:
+		r.Result = "FAIL"
+	case server:
+		r.Result = "PASS"
+	case errs > 0:
+		r.Result, r.ReportType = "FAILED", "BUG"
+	default:
+		r.ReportType = "BUG"
+	}
+	return r
+}
+
+func bitbucketAnnotationForFinding(f *Finding, server bool) *bitbucketAnnotation {
+	message := fmt.Sprintf("%s: %s", f.RuleId, f.Message)
+	a := &bitbucketAnnotation{Severity: GetBitbucketSeverity(f.Severity), Path: f.Path, Line: f.Line}
+	if server {
+		a.ServerID, a.Type, a.Message = f.Fingerprint, "CODE_SMELL", truncate(message, 2000)
+		return a
+	}
+	a.ExternalID, a.AnnotationType, a.Summary = f.Fingerprint, "CODE_SMELL", truncate(message, 450)
+	if f.JSONPath != "" {
+		a.Details = "Path: " + f.JSONPath
+	}
+	return a
+}
+
+func (b *BitbucketPublisher) api() *apiClient {
+	api := b.APIURL
+	if b.ServerURL != "" {
+		api = b.ServerURL
+	} else if api == "" {
+		api = BitbucketAPIURL
+	}
+	headers := map[string]string{"Accept": "application/json"}
+	switch {
+	case b.Username != "":
+		headers["Authorization"] = "Basic " + base64.StdEncoding.EncodeToString([]byte(b.Username+":"+b.Token))
+	case b.Token != "":
+		headers["Authorization"] = "Bearer " + b.Token
+	}
+	return &apiClient{client: b.Client, baseURL: api, headers: headers}
+}
+
+// truncate cuts a message down to the length an API accepts.
+func truncate(s string, length int) string {
+	if r := []rune(s); len(r) > length {
+		return string(r[:length-1]) + "…"
+	}
+	return s
+}
+
+func severityRank(severity string) int {
+	switch severity {
+	case model.SeverityError:
+		return 0
+	case model.SeverityWarn:
+		return 1
+	case model.SeverityInfo:
+		return 2
+	}
+	return 3
+}
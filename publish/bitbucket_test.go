@@ -0,0 +1,145 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package publish
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeBitbucket struct {
+	mu          sync.Mutex
+	requests    []string
+	report      map[string]any
+	annotations []map[string]any
+	batches     int
+}
+
+func (f *fakeBitbucket) handler(t *testing.T, server bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodDelete:
+			if f.report == nil {
+				http.NotFound(w, r)
+				return
+			}
+			f.report, f.annotations = nil, nil
+		case r.Method == http.MethodPut:
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&f.report))
+		case r.Method == http.MethodPost && server:
+			var batch struct {
+				Annotations []map[string]any `json:"annotations"`
+			}
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			f.annotations = append(f.annotations, batch.Annotations...)
+			f.batches++
+		case r.Method == http.MethodPost:
+			var batch []map[string]any
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&batch))
+			assert.LessOrEqual(t, len(batch), BitbucketAnnotationBatch)
+			f.annotations = append(f.annotations, batch...)
+			f.batches++
+		}
+		fmt.Fprint(w, `{}`)
+	})
+}
+
+func bitbucketFindings(n int) []*Finding {
+	var findings []*Finding
+	for i := 0; i < n; i++ {
+		severity := model.SeverityInfo
+		if i == n-1 {
+			// the last finding is the one that matters most.
+			severity = model.SeverityError
+		}
+		findings = append(findings, &Finding{Path: "openapi.yaml", Line: i + 1, RuleId: "info-contact",
+			Severity: severity, Message: "no contact", JSONPath: "$.info", Fingerprint: fmt.Sprintf("fp%d", i)})
+	}
+	return findings
+}
+
+func TestBitbucketPublisher_Publish_Cloud(t *testing.T) {
+	fake := &fakeBitbucket{}
+	server := httptest.NewServer(fake.handler(t, false))
+	defer server.Close()
+
+	publisher := &BitbucketPublisher{APIURL: server.URL, Token: "secret", Workspace: "pb33f", Repo: "burgers",
+		Commit: "cafe", Statistics: &reports.ReportStatistics{OverallScore: 75}}
+
+	summary, err := publisher.Publish(bitbucketFindings(BitbucketMaxAnnotations + 5))
+	assert.NoError(t, err)
+	assert.Equal(t, &Summary{Created: BitbucketMaxAnnotations, Skipped: 5}, summary)
+	assert.Len(t, fake.annotations, BitbucketMaxAnnotations)
+	assert.Equal(t, BitbucketMaxAnnotations/BitbucketAnnotationBatch, fake.batches)
+	assert.Equal(t, "DELETE /repositories/pb33f/burgers/commit/cafe/reports/vacuum", fake.requests[0])
+
+	// the error is kept, even though it was last.
+	first := fake.annotations[0]
+	assert.Equal(t, "HIGH", first["severity"])
+	assert.Equal(t, "info-contact: no contact", first["summary"])
+	assert.Equal(t, "CODE_SMELL", first["annotation_type"])
+	assert.Equal(t, "fp1004", first["external_id"])
+	assert.Equal(t, "FAILED", fake.report["result"])
+	assert.Equal(t, "BUG", fake.report["report_type"])
+	assert.Contains(t, fake.report["details"], "1 errors")
+	assert.Equal(t, "Quality score", fake.report["data"].([]any)[0].(map[string]any)["title"])
+
+	// publishing again replaces the report.
+	summary, err = publisher.Publish(bitbucketFindings(3)[:2])
+	assert.NoError(t, err)
+	assert.Equal(t, &Summary{Created: 2}, summary)
+	assert.Len(t, fake.annotations, 2)
+	assert.Equal(t, "PASSED", fake.report["result"])
+}
+
+func TestBitbucketPublisher_Publish_Server(t *testing.T) {
+	fake := &fakeBitbucket{}
+	server := httptest.NewServer(fake.handler(t, true))
+	defer server.Close()
+
+	publisher := &BitbucketPublisher{ServerURL: server.URL, Token: "secret", Workspace: "PB", Repo: "burgers",
+		Commit: "cafe", ReportID: "api-lint"}
+	summary, err := publisher.Publish(bitbucketFindings(2))
+	assert.NoError(t, err)
+	assert.Equal(t, &Summary{Created: 2}, summary)
+	assert.Equal(t, "PUT /rest/insights/1.0/projects/PB/repos/burgers/commits/cafe/reports/api-lint", fake.requests[1])
+	assert.Equal(t, "FAIL", fake.report["result"])
+	assert.NotContains(t, fake.report, "report_type")
+	assert.Equal(t, "fp1", fake.annotations[0]["externalId"])
+	assert.Equal(t, "info-contact: no contact", fake.annotations[0]["message"])
+	assert.NotContains(t, fake.annotations[0], "summary")
+}
+
+func TestBitbucketPublisher_Publish_Errors(t *testing.T) {
+	_, err := (&BitbucketPublisher{Workspace: "pb33f", Repo: "burgers"}).Publish(nil)
+	assert.ErrorContains(t, err, "commit are required")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Basic "))
+		http.Error(w, "forbidden", http.StatusForbidden)
+	}))
+	defer server.Close()
+	_, err = (&BitbucketPublisher{APIURL: server.URL, Username: "dave", Token: "app-password", Workspace: "pb33f",
+		Repo: "burgers", Commit: "cafe"}).Publish(nil)
+	assert.ErrorContains(t, err, "403")
+}
+
+func TestGetBitbucketSeverity(t *testing.T) {
+	assert.Equal(t, "HIGH", GetBitbucketSeverity(model.SeverityError))
+	assert.Equal(t, "MEDIUM", GetBitbucketSeverity(model.SeverityWarn))
+	assert.Equal(t, "LOW", GetBitbucketSeverity(model.SeverityInfo))
+	assert.Equal(t, "LOW", GetBitbucketSeverity(model.SeverityHint))
+}
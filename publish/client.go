@@ -0,0 +1,113 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package publish
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+var nextLink = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// apiError is a response from an API that was not a success.
+type apiError struct {
+	method     string
+	url        string
+	status     string
+	statusCode int
+	body       string
+	retryAfter string // the Retry-After header, if there was one.
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("%s %s failed: %s: %s", e.method, e.url, e.status, e.body)
+}
+
+// apiClient talks JSON to the REST API of a code review platform.
+type apiClient struct {
+	client  *http.Client // defaults to http.DefaultClient.
+	baseURL string
+	headers map[string]string // sent with every request, like authentication.
+}
+
+// list reads every page of a list, following the 'next' links. Platforms that return the next page in the body
+// (rather than a Link header) can return its URL from page.
+func (a *apiClient) list(path string, page func(data []byte) (next string, err error)) error {
+	url := a.url(path)
+	for url != "" {
+		res, data, err := a.do(http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		next, err := page(data)
+		if err != nil {
+			return fmt.Errorf("unable to read response from '%s': %w", url, err)
+		}
+		if m := nextLink.FindStringSubmatch(res.Header.Get("Link")); next == "" && m != nil {
+			next = m[1]
+		}
+		url = next
+	}
+	return nil
+}
+
+// request sends body as JSON, and reads the response into 'into' if it is not nil.
+func (a *apiClient) request(method, path string, body, into any) error {
+	var payload io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		payload = bytes.NewReader(data)
+	}
+	_, data, err := a.do(method, a.url(path), payload)
+	if err != nil || into == nil {
+		return err
+	}
+	return json.Unmarshal(data, into)
+}
+
+func (a *apiClient) do(method, url string, body io.Reader) (*http.Response, []byte, error) {
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return nil, nil, err
+	}
+	for k, v := range a.headers {
+		req.Header.Set(k, v)
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	client := a.client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	res, err := client.Do(req)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, nil, err
+	}
+	if res.StatusCode >= 300 {
+		return nil, nil, &apiError{method: method, url: url, status: res.Status, statusCode: res.StatusCode,
+			body: strings.TrimSpace(string(data)), retryAfter: res.Header.Get("Retry-After")}
+	}
+	return res, data, nil
+}
+
+func (a *apiClient) url(path string) string {
+	if strings.HasPrefix(path, "http://") || strings.HasPrefix(path, "https://") {
+		return path
+	}
+	return strings.TrimSuffix(a.baseURL, "/") + path
+}
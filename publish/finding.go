@@ -0,0 +1,141 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+// Package publish posts linting results to code review platforms, as comments on the lines of a change that
+// introduced them. Comments carry a hidden marker, so later runs update or resolve them instead of repeating them.
+package publish
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	vacuum_report "github.com/daveshanley/vacuum/vacuum-report"
+)
+
+// Finding is a single result, located on a line of a file in the repository.
+type Finding struct {
+	Path        string // relative to the root of the repository, with forward slashes.
+	Line        int
+	RuleId      string
+	Severity    string
+	Message     string
+	JSONPath    string
+	Fingerprint string
+}
+
+// Summary counts what a publisher did.
+type Summary struct {
+	Created   int // new comments.
+	Updated   int // existing comments that changed.
+	Unchanged int // existing comments that were left alone.
+	Resolved  int // comments for findings that have gone.
+	Skipped   int // findings on lines that the change did not touch, they cannot be commented on.
+}
+
+var commentMarker = regexp.MustCompile(`<!-- vacuum:([0-9a-f]+)( resolved)? -->`)
+
+// BuildFindings locates results in the repository. Paths are made relative to root (the working directory if
+// empty), fileName is used for any result that has no origin.
+func BuildFindings(results []*model.RuleFunctionResult, fileName, root string) []*Finding {
+	if root == "" {
+		root, _ = os.Getwd()
+	}
+	findings := make([]*Finding, 0, len(results))
+	seen := make(map[string]int)
+	for _, r := range results {
+		f := fileName
+		if r.Origin != nil && r.Origin.AbsoluteLocation != "" {
+			f = r.Origin.AbsoluteLocation
+		}
+		if strings.Contains(f, "://") {
+			// remote references are not in the repository.
+			continue
+		}
+		if abs, err := filepath.Abs(f); err == nil && root != "" {
+			if rel, err := filepath.Rel(root, abs); err == nil {
+				f = rel
+			}
+		}
+		line := r.Range.Start.Line
+		if r.Origin != nil && r.Origin.Line > 0 {
+			line = r.Origin.Line
+		} else if r.StartNode != nil && r.StartNode.Line > 0 {
+			line = r.StartNode.Line
+		}
+		if line < 1 {
+			line = 1
+		}
+		ruleId, severity := r.RuleId, r.RuleSeverity
+		if r.Rule != nil {
+			if ruleId == "" {
+				ruleId = r.Rule.Id
+			}
+			if r.Rule.Severity != "" {
+				severity = r.Rule.Severity
+			}
+		}
+		path := filepath.ToSlash(f)
+
+		// like code quality reports, the line and the (translated) message are left out of the fingerprint, so
+		// comments follow a finding around the file, whatever the locale.
+		fp := vacuum_report.CodeQualityFingerprint(ruleId, path, r.Path)
+		if n, ok := seen[fp]; ok {
+			seen[fp] = n + 1
+			fp = vacuum_report.CodeQualityFingerprint(ruleId, path, r.Path, fmt.Sprint(n+1))
+		} else {
+			seen[fp] = 0
+		}
+		findings = append(findings, &Finding{
+			Path:        path,
+			Line:        line,
+			RuleId:      ruleId,
+			Severity:    severity,
+			Message:     r.Message,
+			JSONPath:    r.Path,
+			Fingerprint: fp,
+		})
+	}
+	return findings
+}
+
+// CommentBody renders the markdown comment for a finding, including the marker that identifies it.
+func CommentBody(f *Finding) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("<!-- vacuum:%s -->\n", f.Fingerprint))
+	sb.WriteString(fmt.Sprintf("%s **vacuum** `%s`: %s\n", severityIcon(f.Severity), f.RuleId, f.Message))
+	if f.JSONPath != "" {
+		sb.WriteString(fmt.Sprintf("\nPath: `%s`\n", f.JSONPath))
+	}
+	return sb.String()
+}
+
+// ResolvedCommentBody marks a comment as resolved, by a later run that no longer finds it.
+func ResolvedCommentBody(fingerprint, body string) string {
+	body = commentMarker.ReplaceAllString(body, "")
+	return fmt.Sprintf("<!-- vacuum:%s resolved -->\n:white_check_mark: **Resolved**, vacuum no longer reports this.\n\n~~%s~~\n",
+		fingerprint, strings.TrimSpace(strings.ReplaceAll(body, "\n", " ")))
+}
+
+// ParseCommentMarker returns the fingerprint of a comment posted by vacuum, and if it has been resolved. Comments
+// that were not posted by vacuum return an empty fingerprint.
+func ParseCommentMarker(body string) (fingerprint string, resolved bool) {
+	m := commentMarker.FindStringSubmatch(body)
+	if m == nil {
+		return "", false
+	}
+	return m[1], m[2] != ""
+}
+
+func severityIcon(severity string) string {
+	switch severity {
+	case model.SeverityError:
+		return ":x:"
+	case model.SeverityWarn:
+		return ":warning:"
+	}
+	return ":information_source:"
+}
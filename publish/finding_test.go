@@ -0,0 +1,95 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package publish
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+)
+
+func testResults(root string) []*model.RuleFunctionResult {
+	rule := &model.Rule{Id: "operation-description", Severity: model.SeverityWarn}
+	return []*model.RuleFunctionResult{
+		{RuleId: "operation-description", Rule: rule, Message: "missing description", Path: "$.paths./burgers.get",
+			Range: reports.Range{Start: reports.RangeItem{Line: 10}}},
+		{RuleId: "operation-description", Rule: rule, Message: "missing description", Path: "$.paths./burgers.get",
+			Range: reports.Range{Start: reports.RangeItem{Line: 10}}},
+		{RuleId: "info-contact", RuleSeverity: model.SeverityInfo, Message: "no contact", Path: "$.info",
+			Origin: &index.NodeOrigin{AbsoluteLocation: filepath.Join(root, "specs", "info.yaml"), Line: 3}},
+		{RuleId: "remote", Message: "remote", Origin: &index.NodeOrigin{AbsoluteLocation: "https://pb33f.io/spec.yaml", Line: 3}},
+	}
+}
+
+func TestBuildFindings(t *testing.T) {
+	root := t.TempDir()
+	findings := BuildFindings(testResults(root), filepath.Join(root, "openapi.yaml"), root)
+
+	// remote references are not in the repository.
+	assert.Len(t, findings, 3)
+	assert.Equal(t, "openapi.yaml", findings[0].Path)
+	assert.Equal(t, 10, findings[0].Line)
+	assert.Equal(t, model.SeverityWarn, findings[0].Severity)
+	assert.NotEqual(t, findings[0].Fingerprint, findings[1].Fingerprint)
+	assert.Equal(t, "specs/info.yaml", findings[2].Path)
+	assert.Equal(t, 3, findings[2].Line)
+	assert.Equal(t, model.SeverityInfo, findings[2].Severity)
+
+	// fingerprints do not change when a finding moves.
+	moved := testResults(root)
+	moved[0].Range.Start.Line = 20
+	assert.Equal(t, findings[0].Fingerprint, BuildFindings(moved, filepath.Join(root, "openapi.yaml"), root)[0].Fingerprint)
+}
+
+func TestCommentMarkers(t *testing.T) {
+	f := &Finding{RuleId: "info-contact", Severity: model.SeverityError, Message: "no contact", JSONPath: "$.info",
+		Fingerprint: "abc123"}
+	body := CommentBody(f)
+	assert.Contains(t, body, ":x: **vacuum** `info-contact`: no contact")
+	assert.Contains(t, body, "Path: `$.info`")
+
+	fp, resolved := ParseCommentMarker(body)
+	assert.Equal(t, "abc123", fp)
+	assert.False(t, resolved)
+
+	resolvedBody := ResolvedCommentBody(fp, body)
+	assert.Contains(t, resolvedBody, "**Resolved**")
+	fp, resolved = ParseCommentMarker(resolvedBody)
+	assert.Equal(t, "abc123", fp)
+	assert.True(t, resolved)
+
+	fp, _ = ParseCommentMarker("looks good to me")
+	assert.Empty(t, fp)
+}
+
+func TestReconcile(t *testing.T) {
+	current := &Finding{Path: "openapi.yaml", Line: 4, Fingerprint: "aa", Message: "current"}
+	changedBody := &Finding{Path: "openapi.yaml", Line: 5, Fingerprint: "bb", Message: "changed"}
+	reopened := &Finding{Path: "openapi.yaml", Line: 6, Fingerprint: "cc", Message: "reopened"}
+	added := &Finding{Path: "openapi.yaml", Line: 7, Fingerprint: "dd", Message: "new"}
+	untouched := &Finding{Path: "openapi.yaml", Line: 100, Fingerprint: "ee", Message: "not changed"}
+	otherFile := &Finding{Path: "other.yaml", Line: 1, Fingerprint: "ff", Message: "not in the change"}
+
+	existing := []*comment{
+		{id: "1", fingerprint: "aa", body: CommentBody(current)},
+		{id: "2", fingerprint: "bb", body: "old"},
+		{id: "3", fingerprint: "cc", resolved: true, body: "resolved"},
+		{id: "4", fingerprint: "99", body: "gone"},
+		{id: "5", fingerprint: "98", resolved: true, body: "already resolved"},
+	}
+	changed := map[string]map[int]bool{"openapi.yaml": {4: true, 5: true, 6: true, 7: true}}
+
+	p := reconcile([]*Finding{current, changedBody, reopened, added, untouched, otherFile}, existing, changed)
+	assert.Equal(t, []*Finding{added}, p.create)
+	assert.Len(t, p.update, 2)
+	assert.Equal(t, "2", p.update[0].comment.id)
+	assert.Equal(t, "3", p.update[1].comment.id)
+	assert.Equal(t, []*comment{existing[3]}, p.resolve)
+	assert.Equal(t, 1, p.summary.Unchanged)
+	assert.Equal(t, 2, p.summary.Skipped)
+}
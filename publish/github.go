@@ -0,0 +1,159 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package publish
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/daveshanley/vacuum/utils"
+)
+
+// GitHubAPIURL is the default GitHub REST API, GitHub Enterprise servers use https://<host>/api/v3.
+const GitHubAPIURL = "https://api.github.com"
+
+// GitHubPublisher posts findings as review comments on a GitHub pull request.
+type GitHubPublisher struct {
+	Client      *http.Client // defaults to http.DefaultClient.
+	APIURL      string       // defaults to GitHubAPIURL.
+	Token       string
+	Owner       string
+	Repo        string
+	PullRequest int
+	CommitSHA   string // the commit to comment on, defaults to the head of the pull request.
+}
+
+type gitHubPullRequest struct {
+	Head struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+type gitHubFile struct {
+	Filename string `json:"filename"`
+	Patch    string `json:"patch"`
+}
+
+type gitHubComment struct {
+	ID       int64  `json:"id,omitempty"`
+	Body     string `json:"body"`
+	CommitID string `json:"commit_id,omitempty"`
+	Path     string `json:"path,omitempty"`
+	Line     int    `json:"line,omitempty"`
+	Side     string `json:"side,omitempty"`
+}
+
+// Publish comments on the findings that are on lines changed by the pull request, updates the comments of earlier
+// runs and resolves the comments of findings that have gone.
+func (g *GitHubPublisher) Publish(findings []*Finding) (*Summary, error) {
+	if g.Owner == "" || g.Repo == "" || g.PullRequest < 1 {
+		return nil, fmt.Errorf("a repository (owner/name) and a pull request number are required")
+	}
+	api := g.api()
+	pr := fmt.Sprintf("/repos/%s/%s/pulls/%d", g.Owner, g.Repo, g.PullRequest)
+
+	commit := g.CommitSHA
+	if commit == "" {
+		var pull gitHubPullRequest
+		if err := api.request(http.MethodGet, pr, nil, &pull); err != nil {
+			return nil, err
+		}
+		commit = pull.Head.SHA
+	}
+
+	changed := make(map[string]map[int]bool)
+	err := api.list(pr+"/files?per_page=100", func(data []byte) (string, error) {
+		var files []*gitHubFile
+		if err := json.Unmarshal(data, &files); err != nil {
+			return "", err
+		}
+		for _, f := range files {
+			changed[f.Filename] = utils.ParseChangedLines(f.Patch)
+		}
+		return "", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []*comment
+	err = api.list(pr+"/comments?per_page=100", func(data []byte) (string, error) {
+		var comments []*gitHubComment
+		if err := json.Unmarshal(data, &comments); err != nil {
+			return "", err
+		}
+		for _, c := range comments {
+			if fp, resolved := ParseCommentMarker(c.Body); fp != "" {
+				existing = append(existing, &comment{id: strconv.FormatInt(c.ID, 10), fingerprint: fp,
+					resolved: resolved, body: c.Body})
+			}
+		}
+		return "", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := reconcile(findings, existing, changed)
+	for _, f := range p.create {
+		c := &gitHubComment{Body: CommentBody(f), CommitID: commit, Path: f.Path, Line: f.Line, Side: "RIGHT"}
+		if err = api.request(http.MethodPost, pr+"/comments", c, nil); err != nil {
+			return p.summary, err
+		}
+		p.summary.Created++
+	}
+	for _, u := range p.update {
+		if err = g.editComment(api, u.comment.id, u.body); err != nil {
+			return p.summary, err
+		}
+		p.summary.Updated++
+	}
+	for _, c := range p.resolve {
+		if err = g.editComment(api, c.id, ResolvedCommentBody(c.fingerprint, c.body)); err != nil {
+			return p.summary, err
+		}
+		p.summary.Resolved++
+	}
+	return p.summary, nil
+}
+
+func (g *GitHubPublisher) editComment(api *apiClient, id, body string) error {
+	return api.request(http.MethodPatch, fmt.Sprintf("/repos/%s/%s/pulls/comments/%s", g.Owner, g.Repo, id),
+		&gitHubComment{Body: body}, nil)
+}
+
+func (g *GitHubPublisher) api() *apiClient {
+	api := g.APIURL
+	if api == "" {
+		api = GitHubAPIURL
+	}
+	headers := map[string]string{"Accept": "application/vnd.github+json", "X-GitHub-Api-Version": "2022-11-28"}
+	if g.Token != "" {
+		headers["Authorization"] = "Bearer " + g.Token
+	}
+	return &apiClient{client: g.Client, baseURL: api, headers: headers}
+}
+
+// ParseGitHubRepository splits a repository like 'owner/name' into the owner and name.
+func ParseGitHubRepository(repository string) (owner, repo string, err error) {
+	owner, repo, ok := strings.Cut(repository, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return "", "", fmt.Errorf("repository '%s' is not valid, use 'owner/name'", repository)
+	}
+	return owner, repo, nil
+}
+
+// ParseGitHubPullRequestRef reads the pull request number out of a ref like 'refs/pull/42/merge', as set in
+// GITHUB_REF by pull request workflows. Zero is returned for any other ref.
+func ParseGitHubPullRequestRef(ref string) int {
+	parts := strings.Split(ref, "/")
+	if len(parts) == 4 && parts[0] == "refs" && parts[1] == "pull" {
+		n, _ := strconv.Atoi(parts[2])
+		return n
+	}
+	return 0
+}
@@ -0,0 +1,146 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package publish
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeGitHub serves enough of the pull request API to publish review comments, a page of comments holds one
+// comment so pagination is used.
+type fakeGitHub struct {
+	mu       sync.Mutex
+	comments []*gitHubComment
+	nextID   int64
+}
+
+func (f *fakeGitHub) handler(t *testing.T) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /repos/pb33f/burgers/pulls/42", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"head":{"sha":"cafe"}}`)
+	})
+	mux.HandleFunc("GET /repos/pb33f/burgers/pulls/42/files", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"filename":"openapi.yaml","patch":"@@ -1,2 +1,12 @@\n+openapi: 3.1.0"}]`)
+	})
+	mux.HandleFunc("GET /repos/pb33f/burgers/pulls/42/comments", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page < 1 {
+			page = 1
+		}
+		if page < len(f.comments) {
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s?page=%d>; rel="next"`, r.Host, r.URL.Path, page+1))
+		}
+		var comments []*gitHubComment
+		if page <= len(f.comments) {
+			comments = f.comments[page-1 : page]
+		}
+		_ = json.NewEncoder(w).Encode(append([]*gitHubComment{}, comments...))
+	})
+	mux.HandleFunc("POST /repos/pb33f/burgers/pulls/42/comments", func(w http.ResponseWriter, r *http.Request) {
+		var c gitHubComment
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&c))
+		assert.Equal(t, "cafe", c.CommitID)
+		assert.Equal(t, "RIGHT", c.Side)
+		f.mu.Lock()
+		f.nextID++
+		c.ID = f.nextID
+		f.comments = append(f.comments, &c)
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(c)
+	})
+	mux.HandleFunc("PATCH /repos/pb33f/burgers/pulls/comments/{id}", func(w http.ResponseWriter, r *http.Request) {
+		var c gitHubComment
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&c))
+		id, _ := strconv.ParseInt(r.PathValue("id"), 10, 64)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for _, existing := range f.comments {
+			if existing.ID == id {
+				existing.Body = c.Body
+				_ = json.NewEncoder(w).Encode(existing)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	})
+	return mux
+}
+
+func TestGitHubPublisher_Publish(t *testing.T) {
+	fake := &fakeGitHub{}
+	// somebody else has commented on the pull request.
+	fake.comments = append(fake.comments, &gitHubComment{ID: 100, Body: "nice burgers", Path: "openapi.yaml", Line: 1})
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	publisher := &GitHubPublisher{APIURL: server.URL, Token: "secret", Owner: "pb33f", Repo: "burgers", PullRequest: 42}
+	first := &Finding{Path: "openapi.yaml", Line: 4, RuleId: "info-contact", Message: "no contact", Fingerprint: "aa"}
+	second := &Finding{Path: "openapi.yaml", Line: 8, RuleId: "info-license", Message: "no license", Fingerprint: "bb"}
+	outside := &Finding{Path: "openapi.yaml", Line: 40, RuleId: "info-license", Message: "no license", Fingerprint: "cc"}
+
+	summary, err := publisher.Publish([]*Finding{first, second, outside})
+	assert.NoError(t, err)
+	assert.Equal(t, &Summary{Created: 2, Skipped: 1}, summary)
+	assert.Len(t, fake.comments, 3)
+
+	// running again does not post the same comments again.
+	summary, err = publisher.Publish([]*Finding{first, second, outside})
+	assert.NoError(t, err)
+	assert.Equal(t, &Summary{Unchanged: 2, Skipped: 1}, summary)
+	assert.Len(t, fake.comments, 3)
+
+	// the second finding has been fixed, and the first has a new message.
+	first.Message = "still no contact"
+	summary, err = publisher.Publish([]*Finding{first})
+	assert.NoError(t, err)
+	assert.Equal(t, &Summary{Updated: 1, Resolved: 1}, summary)
+	assert.Len(t, fake.comments, 3)
+	assert.Equal(t, "nice burgers", fake.comments[0].Body)
+	assert.Contains(t, fake.comments[1].Body, "still no contact")
+	assert.Contains(t, fake.comments[2].Body, "**Resolved**")
+	assert.True(t, strings.HasPrefix(fake.comments[2].Body, "<!-- vacuum:bb resolved -->"))
+}
+
+func TestGitHubPublisher_Publish_Errors(t *testing.T) {
+	_, err := (&GitHubPublisher{Owner: "pb33f"}).Publish(nil)
+	assert.ErrorContains(t, err, "pull request number")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+	_, err = (&GitHubPublisher{APIURL: server.URL, Owner: "pb33f", Repo: "burgers", PullRequest: 1}).Publish(nil)
+	assert.ErrorContains(t, err, "Bad credentials")
+}
+
+func TestParseGitHubRepository(t *testing.T) {
+	owner, repo, err := ParseGitHubRepository("pb33f/burgers")
+	assert.NoError(t, err)
+	assert.Equal(t, "pb33f", owner)
+	assert.Equal(t, "burgers", repo)
+
+	for _, bad := range []string{"", "pb33f", "/burgers", "pb33f/", "a/b/c"} {
+		_, _, err = ParseGitHubRepository(bad)
+		assert.Error(t, err, bad)
+	}
+}
+
+func TestParseGitHubPullRequestRef(t *testing.T) {
+	assert.Equal(t, 42, ParseGitHubPullRequestRef("refs/pull/42/merge"))
+	assert.Equal(t, 0, ParseGitHubPullRequestRef("refs/heads/main"))
+	assert.Equal(t, 0, ParseGitHubPullRequestRef(""))
+}
@@ -0,0 +1,231 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package publish
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/utils"
+)
+
+// GitLabAPIURL is the default GitLab REST API, self-managed instances use https://<host>/api/v4.
+const GitLabAPIURL = "https://gitlab.com/api/v4"
+
+const gitLabSummaryMarker = "<!-- vacuum:summary -->"
+
+// GitLabPublisher posts findings as discussions on the lines of a GitLab merge request, along with a summary note.
+type GitLabPublisher struct {
+	Client       *http.Client              // defaults to http.DefaultClient.
+	APIURL       string                    // defaults to GitLabAPIURL.
+	Token        string                    // a personal, project or group access token with the 'api' scope.
+	Project      string                    // the numeric id, or the path (like 'pb33f/burgers') of the project.
+	MergeRequest int                       // the iid of the merge request.
+	Statistics   *reports.ReportStatistics // optional, used for the score in the summary note.
+}
+
+type gitLabMergeRequest struct {
+	DiffRefs *gitLabDiffRefs `json:"diff_refs"`
+}
+
+type gitLabDiffRefs struct {
+	BaseSHA  string `json:"base_sha"`
+	HeadSHA  string `json:"head_sha"`
+	StartSHA string `json:"start_sha"`
+}
+
+type gitLabDiff struct {
+	NewPath     string `json:"new_path"`
+	Diff        string `json:"diff"`
+	DeletedFile bool   `json:"deleted_file"`
+}
+
+type gitLabDiscussion struct {
+	ID    string        `json:"id"`
+	Notes []*gitLabNote `json:"notes"`
+}
+
+type gitLabNote struct {
+	ID     int64  `json:"id"`
+	Body   string `json:"body"`
+	System bool   `json:"system"`
+}
+
+type gitLabPosition struct {
+	PositionType string `json:"position_type"`
+	BaseSHA      string `json:"base_sha"`
+	StartSHA     string `json:"start_sha"`
+	HeadSHA      string `json:"head_sha"`
+	OldPath      string `json:"old_path"`
+	NewPath      string `json:"new_path"`
+	NewLine      int    `json:"new_line"`
+}
+
+type gitLabNewDiscussion struct {
+	Body     string          `json:"body"`
+	Position *gitLabPosition `json:"position,omitempty"`
+}
+
+// Publish starts a discussion on each finding that is on a line changed by the merge request, updates the
+// discussions of earlier runs and resolves the discussions of findings that have gone. A summary note, with the
+// score and counts, is created or updated.
+func (g *GitLabPublisher) Publish(findings []*Finding) (*Summary, error) {
+	if g.Project == "" || g.MergeRequest < 1 {
+		return nil, fmt.Errorf("a project and a merge request iid are required")
+	}
+	api := g.api()
+	mr := fmt.Sprintf("/projects/%s/merge_requests/%d", url.PathEscape(g.Project), g.MergeRequest)
+
+	var mergeRequest gitLabMergeRequest
+	if err := api.request(http.MethodGet, mr, nil, &mergeRequest); err != nil {
+		return nil, err
+	}
+	if mergeRequest.DiffRefs == nil {
+		return nil, fmt.Errorf("merge request !%d has no diff yet", g.MergeRequest)
+	}
+
+	changed := make(map[string]map[int]bool)
+	err := api.list(mr+"/diffs?per_page=100", func(data []byte) (string, error) {
+		var diffs []*gitLabDiff
+		if err := json.Unmarshal(data, &diffs); err != nil {
+			return "", err
+		}
+		for _, d := range diffs {
+			if !d.DeletedFile {
+				changed[d.NewPath] = utils.ParseChangedLines(d.Diff)
+			}
+		}
+		return "", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []*comment
+	var summaryNote *gitLabNote
+	err = api.list(mr+"/discussions?per_page=100", func(data []byte) (string, error) {
+		var discussions []*gitLabDiscussion
+		if err := json.Unmarshal(data, &discussions); err != nil {
+			return "", err
+		}
+		for _, d := range discussions {
+			if len(d.Notes) == 0 || d.Notes[0].System {
+				continue
+			}
+			first := d.Notes[0]
+			if strings.HasPrefix(first.Body, gitLabSummaryMarker) {
+				summaryNote = first
+				continue
+			}
+			if fp, resolved := ParseCommentMarker(first.Body); fp != "" {
+				existing = append(existing, &comment{id: d.ID, note: fmt.Sprint(first.ID), fingerprint: fp,
+					resolved: resolved, body: first.Body})
+			}
+		}
+		return "", nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	p := reconcile(findings, existing, changed)
+	refs := mergeRequest.DiffRefs
+	for _, f := range p.create {
+		discussion := &gitLabNewDiscussion{
+			Body: CommentBody(f),
+			Position: &gitLabPosition{
+				PositionType: "text",
+				BaseSHA:      refs.BaseSHA,
+				StartSHA:     refs.StartSHA,
+				HeadSHA:      refs.HeadSHA,
+				OldPath:      f.Path,
+				NewPath:      f.Path,
+				NewLine:      f.Line,
+			},
+		}
+		if err = api.request(http.MethodPost, mr+"/discussions", discussion, nil); err != nil {
+			return p.summary, err
+		}
+		p.summary.Created++
+	}
+	for _, u := range p.update {
+		if err = g.editDiscussion(api, mr, u.comment, u.body, false); err != nil {
+			return p.summary, err
+		}
+		p.summary.Updated++
+	}
+	for _, c := range p.resolve {
+		if err = g.editDiscussion(api, mr, c, ResolvedCommentBody(c.fingerprint, c.body), true); err != nil {
+			return p.summary, err
+		}
+		p.summary.Resolved++
+	}
+
+	note := map[string]string{"body": GitLabSummaryNoteBody(g.Statistics, findings, p.summary)}
+	if summaryNote == nil {
+		err = api.request(http.MethodPost, mr+"/notes", note, nil)
+	} else if summaryNote.Body != note["body"] {
+		err = api.request(http.MethodPut, fmt.Sprintf("%s/notes/%d", mr, summaryNote.ID), note, nil)
+	}
+	return p.summary, err
+}
+
+// editDiscussion changes the body of the first note in a discussion, and resolves or re-opens it.
+func (g *GitLabPublisher) editDiscussion(api *apiClient, mr string, c *comment, body string, resolved bool) error {
+	discussion := fmt.Sprintf("%s/discussions/%s", mr, c.id)
+	if err := api.request(http.MethodPut, discussion+"/notes/"+c.note, map[string]string{"body": body}, nil); err != nil {
+		return err
+	}
+	if resolved == c.resolved {
+		return nil
+	}
+	return api.request(http.MethodPut, fmt.Sprintf("%s?resolved=%t", discussion, resolved), nil, nil)
+}
+
+func (g *GitLabPublisher) api() *apiClient {
+	api := g.APIURL
+	if api == "" {
+		api = GitLabAPIURL
+	}
+	headers := map[string]string{}
+	if g.Token != "" {
+		headers["PRIVATE-TOKEN"] = g.Token
+	}
+	return &apiClient{client: g.Client, baseURL: api, headers: headers}
+}
+
+// GitLabSummaryNoteBody renders the summary note of a merge request, with the score (if there are statistics),
+// the findings by severity and what was published.
+func GitLabSummaryNoteBody(stats *reports.ReportStatistics, findings []*Finding, summary *Summary) string {
+	var sb strings.Builder
+	sb.WriteString(gitLabSummaryMarker + "\n## vacuum report\n\n")
+	if stats != nil {
+		sb.WriteString(fmt.Sprintf("**Quality score: %d/100**\n\n", stats.OverallScore))
+	}
+	if len(findings) == 0 {
+		sb.WriteString("No issues found, nice work! :tada:\n")
+		return sb.String()
+	}
+	var errs, warnings, info int
+	for _, f := range findings {
+		switch f.Severity {
+		case model.SeverityError:
+			errs++
+		case model.SeverityWarn:
+			warnings++
+		default:
+			info++
+		}
+	}
+	sb.WriteString(utils.RenderMarkdownTable([]string{"Errors", "Warnings", "Info"},
+		[][]string{{fmt.Sprint(errs), fmt.Sprint(warnings), fmt.Sprint(info)}}))
+	sb.WriteString(fmt.Sprintf("\n%d of %d findings are on lines changed by this merge request.\n",
+		len(findings)-summary.Skipped, len(findings)))
+	return sb.String()
+}
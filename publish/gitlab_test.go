@@ -0,0 +1,158 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package publish
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeGitLab serves enough of the merge request API to publish discussions.
+type fakeGitLab struct {
+	mu          sync.Mutex
+	discussions []*gitLabDiscussion
+	positions   map[string]*gitLabPosition
+	resolved    map[string]bool
+	nextID      int64
+}
+
+func (f *fakeGitLab) add(body string, position *gitLabPosition) {
+	f.nextID++
+	d := &gitLabDiscussion{ID: fmt.Sprintf("d%d", f.nextID), Notes: []*gitLabNote{{ID: f.nextID, Body: body}}}
+	f.discussions = append(f.discussions, d)
+	f.positions[d.ID] = position
+}
+
+func (f *fakeGitLab) handler(t *testing.T) http.Handler {
+	mr := "/projects/pb33f%2Fburgers/merge_requests/7"
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET "+mr, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "secret", r.Header.Get("PRIVATE-TOKEN"))
+		fmt.Fprint(w, `{"diff_refs":{"base_sha":"base","head_sha":"head","start_sha":"start"}}`)
+	})
+	mux.HandleFunc("GET "+mr+"/diffs", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"new_path":"openapi.yaml","diff":"@@ -1,2 +1,12 @@\n+openapi: 3.1.0"}]`)
+	})
+	mux.HandleFunc("GET "+mr+"/discussions", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		_ = json.NewEncoder(w).Encode(f.discussions)
+	})
+	mux.HandleFunc("POST "+mr+"/discussions", func(w http.ResponseWriter, r *http.Request) {
+		var d gitLabNewDiscussion
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&d))
+		f.mu.Lock()
+		f.add(d.Body, d.Position)
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("POST "+mr+"/notes", func(w http.ResponseWriter, r *http.Request) {
+		var n gitLabNote
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&n))
+		f.mu.Lock()
+		f.add(n.Body, nil)
+		f.mu.Unlock()
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{}`)
+	})
+	edit := func(w http.ResponseWriter, r *http.Request) {
+		var n gitLabNote
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&n))
+		id, _ := strconv.ParseInt(r.PathValue("note"), 10, 64)
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		for _, d := range f.discussions {
+			if d.Notes[0].ID == id {
+				d.Notes[0].Body = n.Body
+				fmt.Fprint(w, `{}`)
+				return
+			}
+		}
+		http.NotFound(w, r)
+	}
+	mux.HandleFunc("PUT "+mr+"/notes/{note}", edit)
+	mux.HandleFunc("PUT "+mr+"/discussions/{discussion}/notes/{note}", edit)
+	mux.HandleFunc("PUT "+mr+"/discussions/{discussion}", func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.resolved[r.PathValue("discussion")] = r.URL.Query().Get("resolved") == "true"
+		fmt.Fprint(w, `{}`)
+	})
+	return mux
+}
+
+func TestGitLabPublisher_Publish(t *testing.T) {
+	fake := &fakeGitLab{positions: make(map[string]*gitLabPosition), resolved: make(map[string]bool)}
+	server := httptest.NewServer(fake.handler(t))
+	defer server.Close()
+
+	publisher := &GitLabPublisher{APIURL: server.URL, Token: "secret", Project: "pb33f/burgers", MergeRequest: 7,
+		Statistics: &reports.ReportStatistics{OverallScore: 87}}
+	first := &Finding{Path: "openapi.yaml", Line: 4, RuleId: "info-contact", Severity: model.SeverityError,
+		Message: "no contact", Fingerprint: "aa"}
+	second := &Finding{Path: "openapi.yaml", Line: 8, RuleId: "info-license", Severity: model.SeverityWarn,
+		Message: "no license", Fingerprint: "bb"}
+	outside := &Finding{Path: "paths.yaml", Line: 1, RuleId: "info-license", Message: "no license", Fingerprint: "cc"}
+
+	summary, err := publisher.Publish([]*Finding{first, second, outside})
+	assert.NoError(t, err)
+	assert.Equal(t, &Summary{Created: 2, Skipped: 1}, summary)
+	assert.Len(t, fake.discussions, 3)
+	assert.Equal(t, &gitLabPosition{PositionType: "text", BaseSHA: "base", StartSHA: "start", HeadSHA: "head",
+		OldPath: "openapi.yaml", NewPath: "openapi.yaml", NewLine: 4}, fake.positions["d1"])
+	note := fake.discussions[2].Notes[0].Body
+	assert.Contains(t, note, "**Quality score: 87/100**")
+	assert.Contains(t, note, "| 1      | 1        | 1    |")
+	assert.Contains(t, note, "2 of 3 findings are on lines changed by this merge request.")
+
+	// running again does not start the same discussions, or add another summary.
+	summary, err = publisher.Publish([]*Finding{first, second, outside})
+	assert.NoError(t, err)
+	assert.Equal(t, &Summary{Unchanged: 2, Skipped: 1}, summary)
+	assert.Len(t, fake.discussions, 3)
+
+	// the second finding has been fixed, then comes back.
+	publisher.Statistics.OverallScore = 90
+	summary, err = publisher.Publish([]*Finding{first})
+	assert.NoError(t, err)
+	assert.Equal(t, &Summary{Unchanged: 1, Resolved: 1}, summary)
+	assert.True(t, fake.resolved["d2"])
+	assert.Contains(t, fake.discussions[1].Notes[0].Body, "**Resolved**")
+	assert.Contains(t, fake.discussions[2].Notes[0].Body, "**Quality score: 90/100**")
+	assert.Len(t, fake.discussions, 3)
+
+	summary, err = publisher.Publish([]*Finding{first, second})
+	assert.NoError(t, err)
+	assert.Equal(t, &Summary{Unchanged: 1, Updated: 1}, summary)
+	assert.False(t, fake.resolved["d2"])
+	assert.Equal(t, CommentBody(second), fake.discussions[1].Notes[0].Body)
+}
+
+func TestGitLabPublisher_Publish_Errors(t *testing.T) {
+	_, err := (&GitLabPublisher{Project: "pb33f/burgers"}).Publish(nil)
+	assert.ErrorContains(t, err, "merge request iid")
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"diff_refs":null}`)
+	}))
+	defer server.Close()
+	_, err = (&GitLabPublisher{APIURL: server.URL, Project: "1", MergeRequest: 7}).Publish(nil)
+	assert.ErrorContains(t, err, "has no diff yet")
+}
+
+func TestGitLabSummaryNoteBody(t *testing.T) {
+	body := GitLabSummaryNoteBody(nil, nil, &Summary{})
+	assert.Contains(t, body, "No issues found")
+	assert.NotContains(t, body, "Quality score")
+}
@@ -0,0 +1,64 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package publish
+
+// comment is a comment that has already been posted by vacuum.
+type comment struct {
+	id          string
+	note        string // the note holding the comment, on platforms where comments are threads of notes.
+	fingerprint string
+	resolved    bool
+	body        string
+}
+
+// commentUpdate is a new body for an existing comment.
+type commentUpdate struct {
+	comment *comment
+	body    string
+}
+
+// plan is what has to change, for the comments on a change to match the findings.
+type plan struct {
+	create  []*Finding
+	update  []*commentUpdate
+	resolve []*comment
+	summary *Summary
+}
+
+// reconcile works out which findings need a new comment, which existing comments need updating and which are
+// for findings that have gone. Findings can only be commented on if their line is part of the change, changed
+// holds the changed lines of each path.
+func reconcile(findings []*Finding, existing []*comment, changed map[string]map[int]bool) *plan {
+	p := &plan{summary: &Summary{}}
+	byFingerprint := make(map[string]*comment)
+	for _, c := range existing {
+		if c.fingerprint != "" {
+			byFingerprint[c.fingerprint] = c
+		}
+	}
+	current := make(map[string]bool)
+	for _, f := range findings {
+		current[f.Fingerprint] = true
+		c := byFingerprint[f.Fingerprint]
+		if c == nil {
+			if changed[f.Path][f.Line] {
+				p.create = append(p.create, f)
+			} else {
+				p.summary.Skipped++
+			}
+			continue
+		}
+		if body := CommentBody(f); c.resolved || c.body != body {
+			p.update = append(p.update, &commentUpdate{comment: c, body: body})
+		} else {
+			p.summary.Unchanged++
+		}
+	}
+	for _, c := range existing {
+		if c.fingerprint != "" && !c.resolved && !current[c.fingerprint] {
+			p.resolve = append(p.resolve, c)
+		}
+	}
+	return p
+}
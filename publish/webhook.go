@@ -0,0 +1,91 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package publish
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// Defaults for posting a report to a webhook.
+const (
+	DefaultWebhookRetries = 3
+	DefaultWebhookBackoff = time.Second
+	maxWebhookBackoff     = 30 * time.Second
+)
+
+// WebhookPublisher posts a JSON report to an HTTP endpoint, like an API governance platform. Requests that fail
+// because of the network, or with a 429 or 5xx response, are tried again, waiting twice as long each time (or as
+// long as a Retry-After header asks, up to 30 seconds).
+type WebhookPublisher struct {
+	Client  *http.Client // defaults to http.DefaultClient.
+	URL     string
+	Token   string            // sent as a bearer token, if set.
+	Headers map[string]string // sent with the request, they replace the token if they set Authorization.
+	Retries int               // how many times a failed request is tried again.
+	Backoff time.Duration     // how long to wait before the first retry, defaults to DefaultWebhookBackoff.
+
+	sleep func(time.Duration) // replaced by tests.
+}
+
+// Publish posts a report, and returns how many attempts it took. The error is from the last attempt.
+func (w *WebhookPublisher) Publish(report []byte) (int, error) {
+	if w.URL == "" {
+		return 0, errors.New("there is no URL to post the report to")
+	}
+	headers := make(map[string]string, len(w.Headers)+1)
+	if w.Token != "" {
+		headers["Authorization"] = "Bearer " + w.Token
+	}
+	for k, v := range w.Headers {
+		headers[k] = v
+	}
+	client := &apiClient{client: w.Client, headers: headers}
+	sleep := w.sleep
+	if sleep == nil {
+		sleep = time.Sleep
+	}
+	backoff := w.Backoff
+	if backoff <= 0 {
+		backoff = DefaultWebhookBackoff
+	}
+
+	attempt := 0
+	for {
+		attempt++
+		_, _, err := client.do(http.MethodPost, w.URL, bytes.NewReader(report))
+		if err == nil {
+			return attempt, nil
+		}
+		wait, retry := retryAfter(err, backoff)
+		if !retry || attempt > w.Retries {
+			return attempt, err
+		}
+		sleep(wait)
+		backoff = min(backoff*2, maxWebhookBackoff)
+	}
+}
+
+// retryAfter decides if a failed request is worth trying again, and how long to wait first.
+func retryAfter(err error, backoff time.Duration) (time.Duration, bool) {
+	var apiErr *apiError
+	if !errors.As(err, &apiErr) {
+		return backoff, true // the network, the endpoint may be back soon.
+	}
+	if apiErr.statusCode != http.StatusTooManyRequests && apiErr.statusCode < 500 {
+		return 0, false
+	}
+	if apiErr.retryAfter != "" {
+		if seconds, pErr := strconv.Atoi(apiErr.retryAfter); pErr == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, maxWebhookBackoff), true
+		}
+		if at, pErr := http.ParseTime(apiErr.retryAfter); pErr == nil {
+			return min(max(time.Until(at), 0), maxWebhookBackoff), true
+		}
+	}
+	return backoff, true
+}
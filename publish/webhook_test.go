@@ -0,0 +1,96 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package publish
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	var body, auth, team, contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		body, auth, team, contentType = string(b), r.Header.Get("Authorization"), r.Header.Get("X-Team"),
+			r.Header.Get("Content-Type")
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	defer server.Close()
+
+	w := &WebhookPublisher{URL: server.URL, Token: "sekret", Headers: map[string]string{"X-Team": "payments"}}
+	attempts, err := w.Publish([]byte(`{"files":[]}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, `{"files":[]}`, body)
+	assert.Equal(t, "Bearer sekret", auth)
+	assert.Equal(t, "payments", team)
+	assert.Equal(t, "application/json", contentType)
+}
+
+func TestWebhookPublisher_Retry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		switch calls {
+		case 1:
+			w.WriteHeader(http.StatusBadGateway)
+		case 2:
+			w.Header().Set("Retry-After", "7")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	w := &WebhookPublisher{URL: server.URL, Retries: 3, Backoff: time.Second,
+		sleep: func(d time.Duration) { waits = append(waits, d) }}
+	attempts, err := w.Publish([]byte(`{}`))
+	assert.NoError(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []time.Duration{time.Second, 7 * time.Second}, waits)
+}
+
+func TestWebhookPublisher_GiveUp(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	w := &WebhookPublisher{URL: server.URL, Retries: 2, Backoff: time.Second,
+		sleep: func(d time.Duration) { waits = append(waits, d) }}
+	attempts, err := w.Publish([]byte(`{}`))
+	assert.Error(t, err)
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, waits)
+}
+
+func TestWebhookPublisher_NoRetry(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	// a request that is refused will be refused again.
+	w := &WebhookPublisher{URL: server.URL, Retries: 3, sleep: func(time.Duration) {}}
+	attempts, err := w.Publish([]byte(`{}`))
+	assert.ErrorContains(t, err, "401 Unauthorized")
+	assert.Equal(t, 1, attempts)
+	assert.Equal(t, 1, calls)
+
+	_, err = (&WebhookPublisher{}).Publish(nil)
+	assert.EqualError(t, err, "there is no URL to post the report to")
+}
@@ -0,0 +1,216 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"github.com/daveshanley/vacuum/model"
+)
+
+const (
+	AsyncAPIInfoContact          = "asyncapi-info-contact"
+	AsyncAPIInfoDescription      = "asyncapi-info-description"
+	AsyncAPIInfoLicense          = "asyncapi-info-license"
+	AsyncAPIServers              = "asyncapi-servers"
+	AsyncAPIChannelAddress       = "asyncapi-channel-address"
+	AsyncAPIOperationOperationId = "asyncapi-operation-operationId"
+	AsyncAPIOperationDescription = "asyncapi-operation-description"
+	AsyncAPIOperationAction      = "asyncapi-operation-action"
+	AsyncAPIBindingProtocol      = "asyncapi-binding-protocol"
+	SpectralAsyncAPI             = "spectral:asyncapi"
+	VacuumAsyncAPI               = "vacuum:asyncapi"
+)
+
+// GetAsyncAPIInfoContactRule will return a rule that checks the info object has a contact.
+func GetAsyncAPIInfoContactRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check info contact",
+		Id:           AsyncAPIInfoContact,
+		Formats:      model.AsyncAPIFormats,
+		Description:  "Info section is missing contact details",
+		Given:        "$.info",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryInfo],
+		Recommended:  true,
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Field:    "contact",
+			Function: "truthy",
+		},
+		HowToFix: asyncAPIInfoContactFix,
+	}
+}
+
+// GetAsyncAPIInfoDescriptionRule will return a rule that checks the info object has a description.
+func GetAsyncAPIInfoDescriptionRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check info description",
+		Id:           AsyncAPIInfoDescription,
+		Formats:      model.AsyncAPIFormats,
+		Description:  "Info section is missing a description",
+		Given:        "$.info",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryInfo],
+		Recommended:  true,
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Field:    "description",
+			Function: "truthy",
+		},
+		HowToFix: asyncAPIInfoDescriptionFix,
+	}
+}
+
+// GetAsyncAPIInfoLicenseRule will return a rule that checks the info object has a license.
+func GetAsyncAPIInfoLicenseRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check info license",
+		Id:           AsyncAPIInfoLicense,
+		Formats:      model.AsyncAPIFormats,
+		Description:  "Info section should contain a license",
+		Given:        "$.info",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryInfo],
+		Recommended:  false,
+		Type:         Style,
+		Severity:     model.SeverityInfo,
+		Then: model.RuleAction{
+			Field:    "license",
+			Function: "truthy",
+		},
+		HowToFix: asyncAPIInfoLicenseFix,
+	}
+}
+
+// GetAsyncAPIServersRule will return a rule that checks servers are defined.
+func GetAsyncAPIServersRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check servers are defined",
+		Id:           AsyncAPIServers,
+		Formats:      model.AsyncAPIFormats,
+		Description:  "AsyncAPI document should define at least one server",
+		Given:        "$",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryValidation],
+		Recommended:  true,
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Field:    "servers",
+			Function: "truthy",
+		},
+		HowToFix: asyncAPIServersFix,
+	}
+}
+
+// GetAsyncAPIChannelAddressRule will return a rule that checks channel addresses are well-formed.
+func GetAsyncAPIChannelAddressRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check channel addresses",
+		Id:           AsyncAPIChannelAddress,
+		Formats:      model.AsyncAPIFormats,
+		Description:  "Channel addresses must not end with a slash, or include a query, fragment or empty parameter",
+		Given:        "$",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Recommended:  true,
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "asyncapiChannelAddress",
+		},
+		HowToFix: asyncAPIChannelAddressFix,
+	}
+}
+
+// GetAsyncAPIOperationOperationIdRule will return a rule that checks AsyncAPI 2 operations have an operationId.
+// AsyncAPI 3 operations are named by their key, so there is nothing to check.
+func GetAsyncAPIOperationOperationIdRule() *model.Rule {
+	opts := make(map[string]interface{})
+	opts["properties"] = []interface{}{"operationId"}
+	return &model.Rule{
+		Name:         "Check operations have an operationId",
+		Id:           AsyncAPIOperationOperationId,
+		Formats:      model.AsyncAPI2Format,
+		Description:  "Every operation must contain an operationId",
+		Given:        "$",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Recommended:  true,
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function:        "asyncapiOperationProperties",
+			FunctionOptions: opts,
+		},
+		HowToFix: asyncAPIOperationOperationIdFix,
+	}
+}
+
+// GetAsyncAPIOperationDescriptionRule will return a rule that checks operations have a description.
+func GetAsyncAPIOperationDescriptionRule() *model.Rule {
+	opts := make(map[string]interface{})
+	opts["properties"] = []interface{}{"description"}
+	return &model.Rule{
+		Name:         "Check operations have a description",
+		Id:           AsyncAPIOperationDescription,
+		Formats:      model.AsyncAPIFormats,
+		Description:  "Operation description checks",
+		Given:        "$",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Recommended:  true,
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function:        "asyncapiOperationProperties",
+			FunctionOptions: opts,
+		},
+		HowToFix: asyncAPIOperationDescriptionFix,
+	}
+}
+
+// GetAsyncAPIOperationActionRule will return a rule that checks AsyncAPI 3 operations have a valid action.
+func GetAsyncAPIOperationActionRule() *model.Rule {
+	opts := make(map[string]interface{})
+	opts["values"] = []interface{}{"send", "receive"}
+	return &model.Rule{
+		Name:         "Check operation actions",
+		Id:           AsyncAPIOperationAction,
+		Formats:      model.AsyncAPI3Format,
+		Description:  "Operation action must be either `send` or `receive`",
+		Given:        "$.operations[*].action",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Recommended:  true,
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function:        "enumeration",
+			FunctionOptions: opts,
+		},
+		HowToFix: asyncAPIOperationActionFix,
+	}
+}
+
+// GetAsyncAPIBindingProtocolRule will return a rule that checks bindings only use known protocols.
+func GetAsyncAPIBindingProtocolRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check binding protocols",
+		Id:           AsyncAPIBindingProtocol,
+		Formats:      model.AsyncAPIFormats,
+		Description:  "Bindings must use a protocol known to AsyncAPI",
+		Given:        "$",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryValidation],
+		Recommended:  true,
+		Type:         Validation,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "asyncapiBindingProtocols",
+		},
+		HowToFix: asyncAPIBindingProtocolFix,
+	}
+}
@@ -0,0 +1,6 @@
+// A Spectral function (CommonJS), used by spectral-functions-ruleset.yaml
+module.exports = (targetVal, opts, context) => {
+  if (targetVal && typeof targetVal === 'object' && !targetVal.summary) {
+    return [{ message: 'Operation is missing a summary.' }];
+  }
+};
@@ -0,0 +1,15 @@
+// A Spectral function (ES module), used by spectral-functions-ruleset.yaml
+import { createRulesetFunction } from '@stoplight/spectral-core';
+
+export default createRulesetFunction(
+  {
+    input: { type: 'string' },
+    options: { type: 'object', properties: { disallowed: { type: 'array' } } },
+  },
+  function tagNames(targetVal, opts) {
+    if ((opts.disallowed || []).includes(targetVal)) {
+      return [{ message: `Tag name \`${targetVal}\` is not allowed.`, path: ['tags', 'name'] }];
+    }
+    return [];
+  },
+);
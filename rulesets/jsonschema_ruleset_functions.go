@@ -0,0 +1,95 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"github.com/daveshanley/vacuum/model"
+)
+
+const (
+	JSONSchemaTypes           = "json-schema-types"
+	JSONSchemaEnumExamples    = "json-schema-enum-examples"
+	JSONSchemaDescriptions    = "json-schema-descriptions"
+	JSONSchemaUnreachableDefs = "json-schema-unreachable-defs"
+)
+
+// GetJSONSchemaTypesRule will return a rule that checks every schema declares a valid type.
+func GetJSONSchemaTypesRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check schema types",
+		Id:           JSONSchemaTypes,
+		Formats:      model.JSONSchemaFormat,
+		Description:  "Schemas must declare a valid `type`",
+		Given:        "$",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Recommended:  true,
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "jsonSchemaTypes",
+		},
+		HowToFix: jsonSchemaTypesFix,
+	}
+}
+
+// GetJSONSchemaEnumExamplesRule will return a rule that checks examples and defaults of enums are enum values.
+func GetJSONSchemaEnumExamplesRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check enum examples",
+		Id:           JSONSchemaEnumExamples,
+		Formats:      model.JSONSchemaFormat,
+		Description:  "Examples and defaults of an enum must be enum values",
+		Given:        "$",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryExamples],
+		Recommended:  true,
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "jsonSchemaEnumExamples",
+		},
+		HowToFix: jsonSchemaEnumExamplesFix,
+	}
+}
+
+// GetJSONSchemaDescriptionsRule will return a rule that checks properties and definitions have descriptions.
+func GetJSONSchemaDescriptionsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check schema descriptions",
+		Id:           JSONSchemaDescriptions,
+		Formats:      model.JSONSchemaFormat,
+		Description:  "Properties and definitions should have a description",
+		Given:        "$",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryDescriptions],
+		Recommended:  true,
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "jsonSchemaDescriptions",
+		},
+		HowToFix: jsonSchemaDescriptionsFix,
+	}
+}
+
+// GetJSONSchemaUnreachableDefsRule will return a rule that checks every definition can be reached from the root.
+func GetJSONSchemaUnreachableDefsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check for unreachable definitions",
+		Id:           JSONSchemaUnreachableDefs,
+		Formats:      model.JSONSchemaFormat,
+		Description:  "Definitions should be reachable from the root schema",
+		Given:        "$",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategorySchemas],
+		Recommended:  true,
+		Type:         Style,
+		Severity:     model.SeverityWarn,
+		Then: model.RuleAction{
+			Function: "jsonSchemaUnreachableDefs",
+		},
+		HowToFix: jsonSchemaUnreachableDefsFix,
+	}
+}
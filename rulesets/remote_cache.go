@@ -0,0 +1,235 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLockFile is the name of the lockfile that pins the digests of remote rulesets.
+const DefaultLockFile = "vacuum.lock"
+
+// RemoteCache keeps a copy of every remote ruleset on disk. Rulesets are only downloaded again if they have
+// changed (using ETags), and can be used without a network when running offline. If a lockfile is configured,
+// the digest of every remote ruleset is pinned, so CI always lints with exactly the same rules.
+type RemoteCache struct {
+	// Dir is where rulesets are cached, see DefaultRemoteCacheDir.
+	Dir string
+
+	// Offline never touches the network, a ruleset that is not cached is an error.
+	Offline bool
+
+	// LockFile is the path to a lockfile, it is created (and new rulesets are added to it) when rulesets are
+	// fetched. Leave empty to not pin anything.
+	LockFile string
+
+	lock       *RuleSetLock
+	errors     []error
+	mutex      sync.Mutex
+	fetchMutex sync.Mutex // the cached files of a ruleset are written by one fetch at a time.
+}
+
+// RuleSetLock is the content of a lockfile, a map of remote ruleset URLs to the digest of their content.
+type RuleSetLock struct {
+	Rulesets map[string]string `yaml:"rulesets"`
+}
+
+var (
+	remoteCache      *RemoteCache
+	remoteCacheMutex sync.RWMutex
+)
+
+// SetRemoteCache configures the cache used when downloading remote rulesets, nil turns caching off.
+func SetRemoteCache(cache *RemoteCache) {
+	remoteCacheMutex.Lock()
+	defer remoteCacheMutex.Unlock()
+	remoteCache = cache
+}
+
+// GetRemoteCache returns the cache used when downloading remote rulesets, or nil if there isn't one.
+func GetRemoteCache() *RemoteCache {
+	remoteCacheMutex.RLock()
+	defer remoteCacheMutex.RUnlock()
+	return remoteCache
+}
+
+// DefaultRemoteCacheDir returns the directory remote rulesets are cached in (~/.cache/vacuum/rulesets on Linux).
+func DefaultRemoteCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "vacuum", "rulesets")
+}
+
+// Digest returns the digest of ruleset content, as it is recorded in a lockfile.
+func Digest(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Fetch returns the content of a remote ruleset, from the cache if it has not changed. The content is checked
+// against the lockfile (if there is one).
+func (c *RemoteCache) Fetch(ctx context.Context, location string, httpClient *http.Client) ([]byte, error) {
+	c.fetchMutex.Lock()
+	body, err := c.fetch(ctx, location, httpClient)
+	c.fetchMutex.Unlock()
+	if err == nil {
+		err = c.checkLock(location, body)
+	}
+	if err != nil {
+		c.mutex.Lock()
+		c.errors = append(c.errors, err)
+		c.mutex.Unlock()
+		return nil, err
+	}
+	return body, nil
+}
+
+// Reset forgets the errors of earlier fetches, call it before loading a ruleset so Err only reports the errors of
+// that ruleset.
+func (c *RemoteCache) Reset() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.errors = nil
+}
+
+// Err returns every error encountered fetching rulesets since the last Reset. Extended rulesets that can't be
+// fetched are logged and skipped, this lets the caller fail instead (when offline, or when a ruleset does not match
+// the lockfile).
+func (c *RemoteCache) Err() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return errors.Join(c.errors...)
+}
+
+func (c *RemoteCache) fetch(ctx context.Context, location string, httpClient *http.Client) ([]byte, error) {
+	bodyPath, etagPath := c.paths(location)
+	cached, cacheErr := os.ReadFile(bodyPath)
+
+	if c.Offline {
+		if cacheErr != nil {
+			return nil, fmt.Errorf("remote ruleset '%s' is not cached, cannot run offline", location)
+		}
+		return cached, nil
+	}
+
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, location, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", location, err)
+	}
+	if etag, eErr := os.ReadFile(etagPath); eErr == nil && cacheErr == nil {
+		req.Header.Set("If-None-Match", string(etag))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		// the network is down, the cached copy is better than nothing.
+		if cacheErr == nil {
+			return cached, nil
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified && cacheErr == nil {
+		return cached, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to download remote ruleset '%s': %s", location, resp.Status)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	// a cache that can't be written is not a reason to fail.
+	if mErr := os.MkdirAll(c.Dir, 0755); mErr == nil {
+		_ = os.WriteFile(bodyPath, body, 0644)
+		if etag := resp.Header.Get("ETag"); etag != "" {
+			_ = os.WriteFile(etagPath, []byte(etag), 0644)
+		} else {
+			_ = os.Remove(etagPath)
+		}
+	}
+	return body, nil
+}
+
+// paths returns the paths of the cached content and ETag for a location.
+func (c *RemoteCache) paths(location string) (string, string) {
+	sum := sha256.Sum256([]byte(location))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(c.Dir, name+".yaml"), filepath.Join(c.Dir, name+".etag")
+}
+
+// checkLock makes sure the content matches the digest pinned in the lockfile. Rulesets that are not pinned yet
+// are added to the lockfile.
+func (c *RemoteCache) checkLock(location string, content []byte) error {
+	if c.LockFile == "" {
+		return nil
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.lock == nil {
+		lock, err := ReadRuleSetLock(c.LockFile)
+		if err != nil {
+			return err
+		}
+		c.lock = lock
+	}
+
+	digest := Digest(content)
+	pinned, ok := c.lock.Rulesets[location]
+	if ok && pinned != digest {
+		return fmt.Errorf("remote ruleset '%s' does not match the digest in '%s' (expected %s, got %s), "+
+			"remove it from the lockfile to update it", location, c.LockFile, pinned, digest)
+	}
+	if ok {
+		return nil
+	}
+	c.lock.Rulesets[location] = digest
+	return WriteRuleSetLock(c.LockFile, c.lock)
+}
+
+// ReadRuleSetLock reads a lockfile, a lockfile that does not exist yet is empty.
+func ReadRuleSetLock(path string) (*RuleSetLock, error) {
+	lock := &RuleSetLock{}
+	content, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		if yErr := yaml.Unmarshal(content, lock); yErr != nil {
+			return nil, fmt.Errorf("unable to read lockfile '%s': %w", path, yErr)
+		}
+	}
+	if lock.Rulesets == nil {
+		lock.Rulesets = make(map[string]string)
+	}
+	return lock, nil
+}
+
+// WriteRuleSetLock writes a lockfile.
+func WriteRuleSetLock(path string, lock *RuleSetLock) error {
+	content, err := yaml.Marshal(lock)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0644)
+}
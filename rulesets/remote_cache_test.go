@@ -0,0 +1,124 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mockETagServer(content []byte, requests *int, notModified *int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		*requests++
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			*notModified++
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		rw.Header().Set("ETag", `"v1"`)
+		_, _ = rw.Write(content)
+	}))
+}
+
+func TestRemoteCache_Fetch_ETag(t *testing.T) {
+	content, _ := os.ReadFile("examples/custom-ruleset.yaml")
+	requests, notModified := 0, 0
+	server := mockETagServer(content, &requests, &notModified)
+	defer server.Close()
+
+	cache := &RemoteCache{Dir: t.TempDir()}
+	body, err := cache.Fetch(context.Background(), server.URL, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, content, body)
+
+	body, err = cache.Fetch(context.Background(), server.URL, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, content, body)
+	assert.Equal(t, 2, requests)
+	assert.Equal(t, 1, notModified)
+	assert.NoError(t, cache.Err())
+}
+
+func TestRemoteCache_Fetch_Offline(t *testing.T) {
+	content, _ := os.ReadFile("examples/custom-ruleset.yaml")
+	requests, notModified := 0, 0
+	server := mockETagServer(content, &requests, &notModified)
+	defer server.Close()
+
+	dir := t.TempDir()
+	offline := &RemoteCache{Dir: dir, Offline: true}
+	_, err := offline.Fetch(context.Background(), server.URL, nil)
+	assert.ErrorContains(t, err, "is not cached, cannot run offline")
+	assert.Error(t, offline.Err())
+	assert.Equal(t, 0, requests)
+
+	// the errors of an earlier ruleset are not reported for the next one.
+	offline.Reset()
+	assert.NoError(t, offline.Err())
+
+	_, err = (&RemoteCache{Dir: dir}).Fetch(context.Background(), server.URL, nil)
+	assert.NoError(t, err)
+
+	offline = &RemoteCache{Dir: dir, Offline: true}
+	body, err := offline.Fetch(context.Background(), server.URL, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, content, body)
+	assert.Equal(t, 1, requests)
+}
+
+func TestRemoteCache_Fetch_LockFile(t *testing.T) {
+	content := []byte("rules: {}")
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write(content)
+	}))
+	defer server.Close()
+
+	lockFile := filepath.Join(t.TempDir(), DefaultLockFile)
+	cache := &RemoteCache{Dir: t.TempDir(), LockFile: lockFile}
+	_, err := cache.Fetch(context.Background(), server.URL, nil)
+	assert.NoError(t, err)
+
+	lock, err := ReadRuleSetLock(lockFile)
+	assert.NoError(t, err)
+	assert.Equal(t, Digest(content), lock.Rulesets[server.URL])
+
+	// the ruleset changes, the lockfile no longer matches.
+	content = []byte("rules: {changed: off}")
+	cache = &RemoteCache{Dir: t.TempDir(), LockFile: lockFile}
+	_, err = cache.Fetch(context.Background(), server.URL, nil)
+	assert.ErrorContains(t, err, "does not match the digest")
+}
+
+func TestRemoteCache_Fetch_BadStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	_, err := (&RemoteCache{Dir: t.TempDir()}).Fetch(context.Background(), server.URL, nil)
+	assert.ErrorContains(t, err, "404")
+}
+
+func TestDownloadRemoteRuleSet_Cached(t *testing.T) {
+	content, _ := os.ReadFile("examples/custom-ruleset.yaml")
+	requests, notModified := 0, 0
+	server := mockETagServer(content, &requests, &notModified)
+	defer server.Close()
+
+	SetRemoteCache(&RemoteCache{Dir: t.TempDir()})
+	defer SetRemoteCache(nil)
+
+	for i := 0; i < 2; i++ {
+		rs, err := DownloadRemoteRuleSet(context.Background(), server.URL, nil)
+		assert.NoError(t, err)
+		assert.NotNil(t, rs)
+	}
+	assert.Equal(t, 1, notModified)
+}
@@ -47,23 +47,33 @@ func DownloadRemoteRuleSet(ctx context.Context, location string, httpClient *htt
 		return nil, fmt.Errorf("cannot download ruleset, location is empty")
 	}
 
-	if httpClient == nil {
-		httpClient = http.DefaultClient
-	}
+	var ruleBytes []byte
+	if cache := GetRemoteCache(); cache != nil {
+		var cacheErr error
+		ruleBytes, cacheErr = cache.Fetch(ctx, location, httpClient)
+		if cacheErr != nil {
+			return nil, cacheErr
+		}
+	} else {
+		if httpClient == nil {
+			httpClient = http.DefaultClient
+		}
 
-	req, err := http.NewRequestWithContext(ctx, "GET", location, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request for %s: %w", location, err)
-	}
+		req, err := http.NewRequestWithContext(ctx, "GET", location, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request for %s: %w", location, err)
+		}
 
-	ruleResp, ruleRemoteErr := httpClient.Do(req)
-	if ruleRemoteErr != nil {
-		return nil, ruleRemoteErr
-	}
+		ruleResp, ruleRemoteErr := httpClient.Do(req)
+		if ruleRemoteErr != nil {
+			return nil, ruleRemoteErr
+		}
 
-	ruleBytes, bytesErr := io.ReadAll(ruleResp.Body)
-	if bytesErr != nil {
-		return nil, bytesErr
+		var bytesErr error
+		ruleBytes, bytesErr = io.ReadAll(ruleResp.Body)
+		if bytesErr != nil {
+			return nil, bytesErr
+		}
 	}
 
 	if len(ruleBytes) <= 0 {
@@ -136,10 +146,26 @@ func SniffOutAllExternalRules(
 		rs.mutex.Unlock()
 	}
 	for ruleName, ruleValue := range drs.RuleDefinitions {
+		// the formats of a ruleset only apply to its own rules.
+		if def, ok := ruleValue.(map[string]interface{}); ok && def["formats"] == nil && len(drs.Formats) > 0 {
+			def["formats"] = drs.Formats
+		}
 		rs.mutex.Lock()
 		rs.RuleDefinitions[ruleName] = ruleValue
 		rs.mutex.Unlock()
 	}
+	for aliasName, alias := range drs.Aliases {
+		rs.mutex.Lock()
+		if _, exists := rs.Aliases[aliasName]; !exists && rs.Aliases != nil {
+			rs.Aliases[aliasName] = alias
+		}
+		rs.mutex.Unlock()
+	}
+	for _, cat := range drs.Categories {
+		rs.mutex.Lock()
+		rs.addRuleCategory(cat)
+		rs.mutex.Unlock()
+	}
 
 	visited = append(visited, location)
 
@@ -0,0 +1,131 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OperationDescriptionPlaceholder is added to operations that have no description, by 'lint --fix'.
+const OperationDescriptionPlaceholder = "No description provided."
+
+// fixTrailingSlash removes any trailing slashes from a scalar, like a server URL or host. A lone '/' is left alone.
+func fixTrailingSlash(node, _ *yaml.Node) bool {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return false
+	}
+	trimmed := strings.TrimRight(node.Value, "/")
+	if trimmed == "" || trimmed == node.Value {
+		return false
+	}
+	node.Value = trimmed
+	return true
+}
+
+// fixPathTrailingSlash removes a trailing slash from a path key, as long as the path without the slash does not
+// already exist, because then the path items would need to be merged by hand.
+func fixPathTrailingSlash(node, parent *yaml.Node) bool {
+	if node == nil || node.Kind != yaml.ScalarNode || parent == nil || parent.Kind != yaml.MappingNode {
+		return false
+	}
+	trimmed := strings.TrimRight(node.Value, "/")
+	if trimmed == "" || trimmed == node.Value {
+		return false
+	}
+	for i := 0; i < len(parent.Content); i += 2 {
+		if parent.Content[i].Value == trimmed {
+			return false
+		}
+	}
+	node.Value = trimmed
+	return true
+}
+
+// fixOperationIdCasing converts an operationId that is not URL friendly into camelCase, e.g. 'get burgers' becomes
+// 'getBurgers'.
+func fixOperationIdCasing(node, _ *yaml.Node) bool {
+	if node == nil || node.Kind != yaml.ScalarNode {
+		return false
+	}
+	words := strings.FieldsFunc(node.Value, func(r rune) bool {
+		return !(r < unicode.MaxASCII && (unicode.IsLetter(r) || unicode.IsDigit(r)))
+	})
+	if len(words) == 0 {
+		return false
+	}
+	var sb strings.Builder
+	for i, w := range words {
+		if i == 0 {
+			sb.WriteString(strings.ToLower(w[:1]) + w[1:])
+			continue
+		}
+		sb.WriteString(strings.ToUpper(w[:1]) + w[1:])
+	}
+	if sb.String() == node.Value {
+		return false
+	}
+	node.Value = sb.String()
+	return true
+}
+
+// pathItemOperations are the keys of a path item that hold an operation.
+var pathItemOperations = map[string]bool{"get": true, "put": true, "post": true, "delete": true, "options": true,
+	"head": true, "patch": true, "trace": true, "query": true}
+
+// pathItemFields are the keys of a path item that are not operations.
+var pathItemFields = map[string]bool{"$ref": true, "summary": true, "description": true, "servers": true,
+	"parameters": true, "additionalOperations": true}
+
+// isPathItemOperation returns true if the node is an http method key of a path item, so anything else keyed by
+// the same name (like a schema property called 'get') is left alone.
+func isPathItemOperation(node, parent *yaml.Node) bool {
+	if node == nil || parent == nil || parent.Kind != yaml.MappingNode || !pathItemOperations[node.Value] {
+		return false
+	}
+	for i := 0; i < len(parent.Content)-1; i += 2 {
+		key := parent.Content[i].Value
+		if !pathItemOperations[key] && !pathItemFields[key] && !strings.HasPrefix(key, "x-") {
+			return false
+		}
+	}
+	return true
+}
+
+// fixOperationDescription adds a placeholder description to an operation that is missing one. The node is the
+// operation key (the http method), the operation itself is the value that follows it in the path item.
+func fixOperationDescription(node, parent *yaml.Node) bool {
+	if !isPathItemOperation(node, parent) {
+		return false
+	}
+	for i := 0; i < len(parent.Content)-1; i += 2 {
+		if parent.Content[i] != node {
+			continue
+		}
+		op := parent.Content[i+1]
+		if op.Kind != yaml.MappingNode {
+			return false
+		}
+		// the description goes straight after the summary if there is one, otherwise at the top of the operation.
+		at := 0
+		for j := 0; j < len(op.Content)-1; j += 2 {
+			switch op.Content[j].Value {
+			case "description":
+				// a description exists, it's just not good enough, that needs a human.
+				return false
+			case "summary":
+				at = j + 2
+			}
+		}
+		desc := []*yaml.Node{
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: "description"},
+			{Kind: yaml.ScalarNode, Tag: "!!str", Value: OperationDescriptionPlaceholder},
+		}
+		op.Content = append(op.Content[:at], append(desc, op.Content[at:]...)...)
+		return true
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package rulesets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func parseAutofixMapping(t *testing.T, spec string) *yaml.Node {
+	var root yaml.Node
+	assert.NoError(t, yaml.Unmarshal([]byte(spec), &root))
+	return root.Content[0]
+}
+
+func TestFixOperationDescription(t *testing.T) {
+	pathItem := parseAutofixMapping(t, "parameters: []\nget:\n  summary: burgers\n")
+	assert.True(t, fixOperationDescription(pathItem.Content[2], pathItem))
+	op := pathItem.Content[3]
+	assert.Equal(t, "description", op.Content[2].Value)
+	assert.Equal(t, OperationDescriptionPlaceholder, op.Content[3].Value)
+}
+
+func TestFixOperationDescription_NotAPathItem(t *testing.T) {
+	// a schema with a property called 'get' is not an operation.
+	properties := parseAutofixMapping(t, "get:\n  type: string\nname:\n  type: string\n")
+	assert.False(t, fixOperationDescription(properties.Content[0], properties))
+	assert.Len(t, properties.Content[1].Content, 2)
+
+	// and a key that isn't an http method is never an operation.
+	pathItem := parseAutofixMapping(t, "summary:\n  type: string\n")
+	assert.False(t, fixOperationDescription(pathItem.Content[0], pathItem))
+}
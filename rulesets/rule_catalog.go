@@ -0,0 +1,107 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// RuleCatalog describes every rule of a ruleset, so portals and documentation sites can build style guide pages
+// from the rules that are actually being run.
+type RuleCatalog struct {
+	Description      string                `json:"description,omitempty" yaml:"description,omitempty"`
+	DocumentationURL string                `json:"documentationUrl,omitempty" yaml:"documentationUrl,omitempty"`
+	Categories       []*model.RuleCategory `json:"categories" yaml:"categories"` // the categories of the rules, in order.
+	Rules            []*RuleCatalogEntry   `json:"rules" yaml:"rules"`
+}
+
+// RuleCatalogEntry describes a single rule of a RuleCatalog.
+type RuleCatalogEntry struct {
+	Id               string   `json:"id" yaml:"id"`
+	Description      string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Severity         string   `json:"severity" yaml:"severity"`
+	Category         string   `json:"category,omitempty" yaml:"category,omitempty"` // the id of a catalog category.
+	Recommended      bool     `json:"recommended" yaml:"recommended"`
+	Tags             []string `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Formats          []string `json:"formats,omitempty" yaml:"formats,omitempty"` // empty if the rule runs against any format.
+	Fixable          bool     `json:"fixable" yaml:"fixable"`                     // results can be fixed by 'lint --fix'.
+	Fix              string   `json:"fix,omitempty" yaml:"fix,omitempty"`         // what the automatic fix does.
+	DocumentationURL string   `json:"documentationUrl" yaml:"documentationUrl"`
+	HowToFix         string   `json:"howToFix,omitempty" yaml:"howToFix,omitempty"`
+	GoodExample      string   `json:"goodExample,omitempty" yaml:"goodExample,omitempty"`
+	BadExample       string   `json:"badExample,omitempty" yaml:"badExample,omitempty"`
+	CWE              []string `json:"cwe,omitempty" yaml:"cwe,omitempty"`
+	OWASP            []string `json:"owasp,omitempty" yaml:"owasp,omitempty"`
+}
+
+// BuildRuleCatalog describes every rule of a ruleset, grouped by category (in the order categories are listed),
+// then sorted by id. Rules without a severity are warnings.
+func BuildRuleCatalog(rs *RuleSet) *RuleCatalog {
+	catalog := &RuleCatalog{
+		Categories: []*model.RuleCategory{},
+		Rules:      []*RuleCatalogEntry{},
+	}
+	if rs == nil {
+		return catalog
+	}
+	catalog.Description = rs.Description
+	catalog.DocumentationURL = rs.DocumentationURI
+
+	used := make(map[string]bool)
+	for id, rule := range rs.Rules {
+		entry := &RuleCatalogEntry{
+			Id:               rule.Id,
+			Description:      rule.Description,
+			Severity:         rule.Severity,
+			Recommended:      rule.Recommended,
+			Tags:             rule.Tags,
+			Formats:          rule.Formats,
+			Fixable:          rule.Fix != nil,
+			Fix:              rule.FixTitle,
+			DocumentationURL: rule.GetDocumentationURL(),
+			HowToFix:         rule.HowToFix,
+			GoodExample:      rule.GoodExample,
+			BadExample:       rule.BadExample,
+			CWE:              rule.CWE,
+			OWASP:            rule.OWASP,
+		}
+		if entry.Id == "" {
+			entry.Id = id
+		}
+		if entry.Severity == "" {
+			entry.Severity = model.SeverityWarn
+		}
+		if rule.RuleCategory != nil {
+			entry.Category = rule.RuleCategory.Id
+			used[entry.Category] = true
+		}
+		catalog.Rules = append(catalog.Rules, entry)
+	}
+
+	var order []string
+	for _, cat := range rs.GetRuleCategories() {
+		if used[cat.Id] {
+			catalog.Categories = append(catalog.Categories, cat)
+			order = append(order, cat.Id)
+		}
+	}
+	// rules without a category (or one that isn't listed) come last.
+	rank := func(e *RuleCatalogEntry) int {
+		if i := slices.Index(order, e.Category); i >= 0 {
+			return i
+		}
+		return len(order)
+	}
+	sort.Slice(catalog.Rules, func(i, j int) bool {
+		ri, rj := rank(catalog.Rules[i]), rank(catalog.Rules[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return catalog.Rules[i].Id < catalog.Rules[j].Id
+	})
+	return catalog
+}
@@ -0,0 +1,54 @@
+package rulesets
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildRuleCatalog(t *testing.T) {
+	rs := BuildDefaultRuleSets().GenerateOpenAPIDefaultRuleSet()
+	catalog := BuildRuleCatalog(rs)
+
+	assert.Equal(t, rs.DocumentationURI, catalog.DocumentationURL)
+	assert.Len(t, catalog.Rules, len(rs.Rules))
+	assert.Equal(t, model.CategoryInfo, catalog.Categories[0].Id)
+	assert.Equal(t, model.CategoryInfo, catalog.Rules[0].Category)
+
+	var slash *RuleCatalogEntry
+	for _, r := range catalog.Rules {
+		if r.Id == Oas3HostTrailingSlash {
+			slash = r
+		}
+		assert.NotEmpty(t, r.Severity)
+		assert.NotEmpty(t, r.DocumentationURL)
+	}
+	assert.NotNil(t, slash)
+	assert.True(t, slash.Fixable)
+	assert.Equal(t, "Remove the trailing slash", slash.Fix)
+	assert.False(t, slash.Recommended)
+}
+
+func TestBuildRuleCatalog_Custom(t *testing.T) {
+	rs := &RuleSet{
+		DocumentationURI: "https://example.com/style-guide",
+		Rules: map[string]*model.Rule{
+			"b-rule": {Id: "b-rule", RuleCategory: model.RuleCategories[model.CategoryTags]},
+			"a-rule": {Id: "a-rule", RuleCategory: model.RuleCategories[model.CategoryTags], Severity: model.SeverityError,
+				CWE: []string{"CWE-200"}},
+			"no-category": {},
+		},
+	}
+	catalog := BuildRuleCatalog(rs)
+	assert.Len(t, catalog.Categories, 1)
+	assert.Equal(t, []string{"a-rule", "b-rule", "no-category"},
+		[]string{catalog.Rules[0].Id, catalog.Rules[1].Id, catalog.Rules[2].Id})
+	assert.Equal(t, model.SeverityWarn, catalog.Rules[1].Severity)
+	assert.Equal(t, []string{"CWE-200"}, catalog.Rules[0].CWE)
+	assert.False(t, catalog.Rules[0].Fixable)
+
+	empty := BuildRuleCatalog(nil)
+	assert.Empty(t, empty.Rules)
+	assert.NotNil(t, empty.Rules)
+}
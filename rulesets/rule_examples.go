@@ -0,0 +1,24 @@
+package rulesets
+
+// Good and bad examples for rules, rendered by the language server when hovering over a result.
+const (
+	operationDescriptionGoodExample string = `paths:
+  /burgers:
+    get:
+      description: Returns every burger on the menu.`
+
+	operationDescriptionBadExample string = `paths:
+  /burgers:
+    get:
+      operationId: getBurgers`
+
+	operationIdValidInUrlGoodExample string = `operationId: getBurgers`
+
+	operationIdValidInUrlBadExample string = `operationId: get burgers`
+
+	pathNoTrailingSlashGoodExample string = `paths:
+  /burgers:`
+
+	pathNoTrailingSlashBadExample string = `paths:
+  /burgers/:`
+)
@@ -42,6 +42,9 @@ const (
 
 	PathItemReferencesFix string = "Path items should not use references for defining operations. It's technically allowed, but not great style"
 
+	oas3AdditionalOperationsFix string = "Move the operation out of 'additionalOperations' and into the field of the path " +
+		"item for the method (like 'get' or 'query'). Additional operations are only for methods without a field."
+
 	oas2HostTrailingSlashFix string = "Remove the trailing slash from the host URL. This may cause some tools to incorrectly " +
 		"add a double slash to paths."
 
@@ -191,3 +194,22 @@ const (
 	owaspSecurityHostsHttpsOAS2Fix  = "Ensure that you are using the HTTPS protocol. Learn more about the importance of TLS (over SSL) here: https://cheatsheetseries.owasp.org/cheatsheets/Transport_Layer_Protection_Cheat_Sheet.html."
 	owaspSecurityHostsHttpsOAS3Fix  = "Prefix server URLs with the HTTPS protocol: `https://`. Learn more about the importance of TLS (over SSL) here: https://cheatsheetseries.owasp.org/cheatsheets/Transport_Layer_Protection_Cheat_Sheet.html."
 )
+
+const (
+	asyncAPIInfoContactFix          = "The `info` object should contain a `contact` object, so consumers know who owns the API and how to reach them."
+	asyncAPIInfoDescriptionFix      = "The `info` object should contain a `description` explaining what the API does, and who it's for."
+	asyncAPIInfoLicenseFix          = "Add a `license` object to the `info` object, so consumers know how they are allowed to use the API."
+	asyncAPIServersFix              = "Add a `servers` object, with at least one server that applications can connect to."
+	asyncAPIChannelAddressFix       = "Remove any trailing slash, query (`?`) or fragment (`#`) from the channel address, and name every `{}` parameter."
+	asyncAPIOperationOperationIdFix = "Add an `operationId` to every `publish` and `subscribe` operation, it's used to generate code and to reference the operation."
+	asyncAPIOperationDescriptionFix = "Add a `description` to every operation, explaining what it does and when a message is sent or received."
+	asyncAPIOperationActionFix      = "Set the operation `action` to `send` or `receive`, no other values are allowed."
+	asyncAPIBindingProtocolFix      = "Bindings are keyed by protocol. Check the protocol name is a known AsyncAPI binding (for example `kafka`, `amqp` or `mqtt`), or prefix custom bindings with `x-`."
+)
+
+const (
+	jsonSchemaTypesFix           = "Add a `type` to the schema (`null`, `boolean`, `object`, `array`, `number`, `string` or `integer`), or compose it with `$ref`, `allOf`, `anyOf` or `oneOf`."
+	jsonSchemaEnumExamplesFix    = "Make sure every value in `examples`, and the `default`, is one of the `enum` values. Check the types match, `1` is not the same as `\"1\"`."
+	jsonSchemaDescriptionsFix    = "Add a `description` to every property and definition, explaining what it is and how it's used."
+	jsonSchemaUnreachableDefsFix = "Remove the definition if it's no longer used, or reference it with `$ref` from the root schema (or from a definition that is used)."
+)
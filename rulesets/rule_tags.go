@@ -0,0 +1,55 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import "github.com/daveshanley/vacuum/model"
+
+// Tags used by the built-in rules, every rule is tagged with the tags of its category.
+const (
+	TagSecurity      = "security"
+	TagStyle         = "style"
+	TagDocumentation = "documentation"
+	TagOWASP         = "owasp"
+)
+
+var categoryTags = map[string][]string{
+	model.CategoryExamples:     {model.CategoryExamples, TagDocumentation},
+	model.CategoryOperations:   {model.CategoryOperations},
+	model.CategoryInfo:         {model.CategoryInfo, TagDocumentation},
+	model.CategoryDescriptions: {model.CategoryDescriptions, TagDocumentation},
+	model.CategorySchemas:      {model.CategorySchemas},
+	model.CategorySecurity:     {TagSecurity},
+	model.CategoryTags:         {model.CategoryTags},
+	model.CategoryValidation:   {model.CategoryValidation},
+	model.CategoryOWASP:        {TagOWASP, TagSecurity},
+}
+
+// extraTags are added to built-in rules that check conventions (style), or that guard against attacks (security),
+// outside of those categories.
+var extraTags = map[string][]string{
+	OpenAPITagsAlphabetical: {TagStyle},
+	OperationSingularTag:    {TagStyle},
+	PathKeysNoTrailingSlash: {TagStyle},
+	NoVerbsInPath:           {TagStyle},
+	PathsKebabCase:          {TagStyle},
+	DescriptionDuplication:  {TagStyle},
+	Oas2HostTrailingSlash:   {TagStyle},
+	Oas3HostTrailingSlash:   {TagStyle},
+	NoEvalInMarkdown:        {TagSecurity},
+	NoScriptTagsInMarkdown:  {TagSecurity},
+}
+
+// tagBuiltInRules tags every rule that has not been given tags already.
+func tagBuiltInRules(rules map[string]*model.Rule) map[string]*model.Rule {
+	for id, rule := range rules {
+		if len(rule.Tags) > 0 {
+			continue
+		}
+		if rule.RuleCategory != nil {
+			rule.Tags = append(rule.Tags, categoryTags[rule.RuleCategory.Id]...)
+		}
+		rule.Tags = append(rule.Tags, extraTags[id]...)
+	}
+	return rules
+}
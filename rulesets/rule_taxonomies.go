@@ -0,0 +1,58 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import "github.com/daveshanley/vacuum/model"
+
+// securityMapping is what a built-in security rule finds, as CWE weaknesses and OWASP API Security Top 10 entries.
+type securityMapping struct {
+	cwe   []string
+	owasp []string
+}
+
+// securityMappings map the built-in security rules to CWE weaknesses and OWASP API Security Top 10 (2023) entries,
+// so findings can be grouped by them.
+var securityMappings = map[string]securityMapping{
+	Oas2OperationSecurityDefined:         {cwe: []string{"CWE-306"}, owasp: []string{"API2:2023"}},
+	Oas3OperationSecurityDefined:         {cwe: []string{"CWE-306"}, owasp: []string{"API2:2023"}},
+	NoEvalInMarkdown:                     {cwe: []string{"CWE-79"}},
+	NoScriptTagsInMarkdown:               {cwe: []string{"CWE-79"}},
+	OwaspProtectionGlobalUnsafe:          {cwe: []string{"CWE-306"}, owasp: []string{"API2:2023", "API5:2023"}},
+	OwaspProtectionGlobalUnsafeStrict:    {cwe: []string{"CWE-306"}, owasp: []string{"API2:2023", "API5:2023"}},
+	OwaspProtectionGlobalSafe:            {cwe: []string{"CWE-306"}, owasp: []string{"API2:2023", "API5:2023"}},
+	OwaspDefineErrorResponses401:         {cwe: []string{"CWE-755"}, owasp: []string{"API8:2023"}},
+	OwaspDefineErrorResponses500:         {cwe: []string{"CWE-209", "CWE-755"}, owasp: []string{"API8:2023"}},
+	OwaspDefineErrorValidation:           {cwe: []string{"CWE-755"}, owasp: []string{"API8:2023"}},
+	OwaspRateLimit:                       {cwe: []string{"CWE-770"}, owasp: []string{"API4:2023"}},
+	OwaspRateLimitRetryAfter:             {cwe: []string{"CWE-770"}, owasp: []string{"API4:2023"}},
+	OwaspDefineErrorResponses429:         {cwe: []string{"CWE-770"}, owasp: []string{"API4:2023"}},
+	OwaspArrayLimit:                      {cwe: []string{"CWE-770"}, owasp: []string{"API4:2023"}},
+	OwaspStringLimit:                     {cwe: []string{"CWE-770"}, owasp: []string{"API4:2023"}},
+	OwaspIntegerLimit:                    {cwe: []string{"CWE-20", "CWE-770"}, owasp: []string{"API4:2023"}},
+	OwaspIntegerFormat:                   {cwe: []string{"CWE-190"}, owasp: []string{"API4:2023"}},
+	OwaspStringRestricted:                {cwe: []string{"CWE-20"}, owasp: []string{"API8:2023"}},
+	OwaspJWTBestPractices:                {cwe: []string{"CWE-347"}, owasp: []string{"API2:2023"}},
+	OwaspAuthInsecureSchemes:             {cwe: []string{"CWE-287", "CWE-327"}, owasp: []string{"API2:2023"}},
+	OwaspNoHttpBasic:                     {cwe: []string{"CWE-522"}, owasp: []string{"API2:2023"}},
+	OwaspNoAPIKeysInURL:                  {cwe: []string{"CWE-598"}, owasp: []string{"API2:2023"}},
+	OwaspNoCredentialsInURL:              {cwe: []string{"CWE-598"}, owasp: []string{"API2:2023"}},
+	OwaspNoNumericIDs:                    {cwe: []string{"CWE-639"}, owasp: []string{"API1:2023"}},
+	OwaspNoAdditionalProperties:          {cwe: []string{"CWE-915"}, owasp: []string{"API3:2023"}},
+	OwaspConstrainedAdditionalProperties: {cwe: []string{"CWE-915"}, owasp: []string{"API3:2023"}},
+	OwaspSecurityHostsHttpsOAS3:          {cwe: []string{"CWE-319"}, owasp: []string{"API8:2023"}},
+}
+
+// mapBuiltInRuleTaxonomies maps every built-in security rule (that has not been mapped already) to CWE weaknesses
+// and OWASP API Security Top 10 entries.
+func mapBuiltInRuleTaxonomies(rules map[string]*model.Rule) map[string]*model.Rule {
+	for id, rule := range rules {
+		mapping, ok := securityMappings[id]
+		if !ok || rule.HasSecurityMapping() {
+			continue
+		}
+		rule.CWE = mapping.cwe
+		rule.OWASP = mapping.owasp
+	}
+	return rules
+}
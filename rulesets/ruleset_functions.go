@@ -362,6 +362,8 @@ func GetOAS2HostTrailingSlashRule() *model.Rule {
 		},
 		PrecompiledPattern: comp,
 		HowToFix:           oas2HostTrailingSlashFix,
+		Fix:                fixTrailingSlash,
+		FixTitle:           "Remove the trailing slash",
 	}
 }
 
@@ -388,6 +390,8 @@ func GetOAS3HostTrailingSlashRule() *model.Rule {
 		},
 		PrecompiledPattern: comp,
 		HowToFix:           oas3HostTrailingSlashFix,
+		Fix:                fixTrailingSlash,
+		FixTitle:           "Remove the trailing slash",
 	}
 }
 
@@ -411,7 +415,11 @@ func GetOperationDescriptionRule() *model.Rule {
 			Function:        "oasDescriptions",
 			FunctionOptions: opts,
 		},
-		HowToFix: operationDescriptionFix,
+		HowToFix:    operationDescriptionFix,
+		GoodExample: operationDescriptionGoodExample,
+		BadExample:  operationDescriptionBadExample,
+		Fix:         fixOperationDescription,
+		FixTitle:    "Add a placeholder description",
 	}
 }
 
@@ -540,6 +548,10 @@ func GetOperationIdValidInUrlRule() *model.Rule {
 		},
 		PrecompiledPattern: comp,
 		HowToFix:           operationIdValidInUrlFix,
+		GoodExample:        operationIdValidInUrlGoodExample,
+		BadExample:         operationIdValidInUrlBadExample,
+		Fix:                fixOperationIdCasing,
+		FixTitle:           "Convert the operationId to camelCase",
 	}
 }
 
@@ -611,6 +623,10 @@ func GetPathNoTrailingSlashRule() *model.Rule {
 		},
 		PrecompiledPattern: comp,
 		HowToFix:           pathNoTrailingSlashFix,
+		GoodExample:        pathNoTrailingSlashGoodExample,
+		BadExample:         pathNoTrailingSlashBadExample,
+		Fix:                fixPathTrailingSlash,
+		FixTitle:           "Remove the trailing slash from the path",
 	}
 }
 
@@ -991,7 +1007,7 @@ func GetOAS3NoRefSiblingsRule() *model.Rule {
 	return &model.Rule{
 		Name:         "Check for siblings to $ref values",
 		Id:           Oas3NoRefSiblings,
-		Formats:      model.OAS3_1Format,
+		Formats:      model.OAS3_1AndLaterFormat,
 		Description:  "`$ref` values cannot be placed next to other properties, except `description` and `summary`",
 		Given:        "$",
 		Resolved:     false,
@@ -1370,3 +1386,24 @@ func GetPathItemReferencesRule() *model.Rule {
 		HowToFix: PathItemReferencesFix,
 	}
 }
+
+// GetOAS3AdditionalOperationsRule will check that the additional operations of OpenAPI 3.2 path items are not for
+// a method the path item already has a field for.
+func GetOAS3AdditionalOperationsRule() *model.Rule {
+	return &model.Rule{
+		Name:         "Check additional operations are for other methods",
+		Id:           Oas3AdditionalOperations,
+		Formats:      model.OAS3_2Format,
+		Description:  "Additional operations must not be for a method the path item has a field for, like GET or QUERY",
+		Given:        "$",
+		Resolved:     false,
+		RuleCategory: model.RuleCategories[model.CategoryOperations],
+		Recommended:  true,
+		Type:         Validation,
+		Severity:     model.SeverityError,
+		Then: model.RuleAction{
+			Function: "oasAdditionalOperations",
+		},
+		HowToFix: oas3AdditionalOperationsFix,
+	}
+}
@@ -0,0 +1,147 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// Built-in ruleset profiles, starting points for a ruleset generated with 'generate-ruleset --profile'.
+const (
+	ProfileStrict      = "strict"
+	ProfileRecommended = "recommended"
+	ProfileMinimal     = "minimal"
+	ProfileSecurity    = "security"
+)
+
+// RuleSetProfiles are the names of the built-in profiles, from the most rules to the fewest.
+var RuleSetProfiles = []string{ProfileStrict, ProfileRecommended, ProfileSecurity, ProfileMinimal}
+
+// RuleSetProfile picks built-in OpenAPI and OWASP rules for a generated ruleset, and the severity they run at.
+type RuleSetProfile struct {
+	Name        string
+	Description string
+	Recommended bool              // only rules vacuum recommends (OWASP rules are never recommended).
+	Categories  []string          // only rules in these categories, every category if empty.
+	Tags        []string          // only rules with at least one of these tags, every rule if empty.
+	MinSeverity string            // only rules at least this severe, every severity if empty.
+	Severities  map[string]string // the severity of the rules in a category ('all' for every category).
+}
+
+// GetRuleSetProfile returns a built-in profile by name, or nil if there is no such profile.
+func GetRuleSetProfile(name string) *RuleSetProfile {
+	switch name {
+	case ProfileStrict:
+		return &RuleSetProfile{
+			Name:        ProfileStrict,
+			Description: "Every OpenAPI and OWASP rule, every finding is an error",
+			Severities:  map[string]string{model.CategoryAll: model.SeverityError},
+		}
+	case ProfileRecommended:
+		return &RuleSetProfile{
+			Name:        ProfileRecommended,
+			Description: "The rules vacuum recommends, the same as linting without a ruleset",
+			Recommended: true,
+		}
+	case ProfileSecurity:
+		return &RuleSetProfile{
+			Name:        ProfileSecurity,
+			Description: "Every security rule, including the OWASP rules",
+			Tags:        []string{TagSecurity},
+		}
+	case ProfileMinimal:
+		return &RuleSetProfile{
+			Name:        ProfileMinimal,
+			Description: "Only the recommended rules that find errors, the things that break",
+			Recommended: true,
+			MinSeverity: model.SeverityError,
+		}
+	}
+	return nil
+}
+
+// ProfileRules returns every built-in rule a profile can pick from, the OpenAPI and OWASP rules.
+func ProfileRules() map[string]*model.Rule {
+	rules := GetAllBuiltInRules()
+	for id, rule := range GetAllOWASPRules() {
+		rules[id] = rule
+	}
+	return rules
+}
+
+// Includes returns true if the profile picks a rule.
+func (p *RuleSetProfile) Includes(rule *model.Rule) bool {
+	if p.Recommended && (!rule.Recommended || ruleCategoryId(rule) == model.CategoryOWASP) {
+		return false
+	}
+	if len(p.Categories) > 0 && !slices.Contains(p.Categories, ruleCategoryId(rule)) {
+		return false
+	}
+	if len(p.Tags) > 0 && !slices.ContainsFunc(p.Tags, rule.HasTag) {
+		return false
+	}
+	if p.MinSeverity != "" {
+		// the lower the value, the more severe the rule.
+		minimum := (&model.Rule{Severity: p.MinSeverity}).GetSeverityAsIntValue()
+		if s := rule.GetSeverityAsIntValue(); s < 0 || s > minimum {
+			return false
+		}
+	}
+	return true
+}
+
+// Severity returns the severity a picked rule runs at.
+func (p *RuleSetProfile) Severity(rule *model.Rule) string {
+	if s, ok := p.Severities[ruleCategoryId(rule)]; ok {
+		return s
+	}
+	if s, ok := p.Severities[model.CategoryAll]; ok {
+		return s
+	}
+	return rule.Severity
+}
+
+// GenerateRuleSet generates a ruleset for the profile. The ruleset extends every built-in rule and lists each one,
+// with its severity if the profile picks it, or 'off' if not, so it's easy to change later.
+func (p *RuleSetProfile) GenerateRuleSet() *RuleSet {
+	rules := ProfileRules()
+	ids := make([]string, 0, len(rules))
+	for id := range rules {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	definitions := make(map[string]interface{}, len(ids))
+	picked := 0
+	for _, id := range ids {
+		if p.Includes(rules[id]) {
+			definitions[id] = p.Severity(rules[id])
+			picked++
+		} else {
+			definitions[id] = VacuumOff
+		}
+	}
+
+	description := fmt.Sprintf("Generated from the '%s' profile, %d of %d rules", p.Name, picked, len(ids))
+	if p.Description != "" {
+		description = fmt.Sprintf("%s: %s", description, p.Description)
+	}
+	return &RuleSet{
+		Description:      description,
+		DocumentationURI: "https://quobix.com/vacuum/rulesets/understanding",
+		Extends:          []interface{}{[]interface{}{VacuumAllRulesets, VacuumAll}},
+		RuleDefinitions:  definitions,
+	}
+}
+
+func ruleCategoryId(rule *model.Rule) string {
+	if rule.RuleCategory == nil {
+		return ""
+	}
+	return rule.RuleCategory.Id
+}
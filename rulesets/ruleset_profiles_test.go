@@ -0,0 +1,71 @@
+package rulesets
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateProfile(t *testing.T, name string) *RuleSet {
+	profile := GetRuleSetProfile(name)
+	assert.NotNil(t, profile)
+	return BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(profile.GenerateRuleSet())
+}
+
+func TestRuleSetProfile_Recommended(t *testing.T) {
+	rs := generateProfile(t, ProfileRecommended)
+	assert.Len(t, rs.Rules, totalRecommendedRules)
+	for _, rule := range rs.Rules {
+		assert.True(t, rule.Recommended)
+	}
+}
+
+func TestRuleSetProfile_Strict(t *testing.T) {
+	rs := generateProfile(t, ProfileStrict)
+	assert.Len(t, rs.Rules, len(ProfileRules()))
+	for _, rule := range rs.Rules {
+		assert.Equal(t, model.SeverityError, rule.Severity)
+	}
+}
+
+func TestRuleSetProfile_Minimal(t *testing.T) {
+	rs := generateProfile(t, ProfileMinimal)
+	assert.NotEmpty(t, rs.Rules)
+	assert.Less(t, len(rs.Rules), totalRecommendedRules)
+	for _, rule := range rs.Rules {
+		assert.True(t, rule.Recommended)
+		assert.Equal(t, model.SeverityError, rule.Severity)
+	}
+}
+
+func TestRuleSetProfile_Security(t *testing.T) {
+	rs := generateProfile(t, ProfileSecurity)
+	assert.NotNil(t, rs.Rules[OwaspNoHttpBasic])
+	assert.NotNil(t, rs.Rules[NoEvalInMarkdown])
+	for _, rule := range rs.Rules {
+		assert.True(t, rule.HasTag(TagSecurity), rule.Id)
+	}
+}
+
+func TestRuleSetProfile_Custom(t *testing.T) {
+	profile := &RuleSetProfile{
+		Name:       "custom",
+		Categories: []string{model.CategoryTags},
+		Severities: map[string]string{model.CategoryTags: model.SeverityHint},
+	}
+	generated := profile.GenerateRuleSet()
+	assert.Contains(t, generated.Description, "Generated from the 'custom' profile")
+	assert.Equal(t, VacuumOff, generated.RuleDefinitions[OperationSuccessResponse])
+
+	rs := BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(generated)
+	assert.NotEmpty(t, rs.Rules)
+	for _, rule := range rs.Rules {
+		assert.Equal(t, model.CategoryTags, rule.RuleCategory.Id)
+		assert.Equal(t, model.SeverityHint, rule.Severity)
+	}
+}
+
+func TestGetRuleSetProfile_Unknown(t *testing.T) {
+	assert.Nil(t, GetRuleSetProfile("fish-cakes"))
+}
@@ -0,0 +1,325 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"github.com/santhosh-tekuri/jsonschema/v6"
+	"github.com/santhosh-tekuri/jsonschema/v6/kind"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleSetProblem is something wrong with a ruleset, found by ValidateRuleSet. Line and Column are zero if the problem
+// isn't with a single part of the ruleset.
+type RuleSetProblem struct {
+	Line    int    `json:"line,omitempty" yaml:"line,omitempty"`
+	Column  int    `json:"column,omitempty" yaml:"column,omitempty"`
+	Rule    string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	Message string `json:"message" yaml:"message"`
+}
+
+func (p *RuleSetProblem) String() string {
+	var b strings.Builder
+	if p.Line > 0 {
+		b.WriteString(fmt.Sprintf("%d:%d: ", p.Line, p.Column))
+	}
+	if p.Rule != "" {
+		b.WriteString(fmt.Sprintf("rule '%s': ", p.Rule))
+	}
+	b.WriteString(p.Message)
+	return b.String()
+}
+
+// ValidateRuleSet checks a ruleset (YAML or JSON) without using it. The ruleset is checked against the ruleset
+// schema (including for unknown fields), every function used by a rule must be one of the supplied functions and be
+// given options it accepts, and every given must be a JSONPath vacuum can evaluate (or an alias that exists).
+// Problems are returned in the order they appear in the ruleset.
+func ValidateRuleSet(data []byte, functions map[string]model.RuleFunction) []*RuleSetProblem {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return []*RuleSetProblem{{Message: fmt.Sprintf("unable to parse ruleset: %s", err.Error())}}
+	}
+	if len(root.Content) == 0 || root.Content[0].Kind != yaml.MappingNode {
+		return []*RuleSetProblem{{Message: "the ruleset must be an object"}}
+	}
+	doc := root.Content[0]
+
+	problems := validateRuleSetSchema(data, doc)
+
+	var aliases map[string]interface{}
+	if _, aliasNode := mappingValue(doc, "aliases"); aliasNode != nil {
+		_ = aliasNode.Decode(&aliases)
+	}
+	sc := &spectralRuleContext{aliases: aliases}
+
+	if _, rulesNode := mappingValue(doc, "rules"); rulesNode != nil && rulesNode.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(rulesNode.Content); i += 2 {
+			name, ruleNode := rulesNode.Content[i].Value, rulesNode.Content[i+1]
+			if ruleNode.Kind != yaml.MappingNode {
+				continue
+			}
+			problems = append(problems, validateRuleGiven(name, ruleNode, sc)...)
+			problems = append(problems, validateRuleFunctions(name, ruleNode, functions)...)
+		}
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool {
+		if problems[i].Line != problems[j].Line {
+			return problems[i].Line < problems[j].Line
+		}
+		return problems[i].Column < problems[j].Column
+	})
+	return problems
+}
+
+// validateRuleSetSchema checks the ruleset against the ruleset schema, each problem is placed on the part of the
+// ruleset it is about.
+func validateRuleSetSchema(data []byte, doc *yaml.Node) []*RuleSetProblem {
+	jsonData := data
+	if !utils.IsJSON(string(data)) {
+		var err error
+		if jsonData, err = utils.ConvertYAMLtoJSON(data); err != nil {
+			return []*RuleSetProblem{{Message: fmt.Sprintf("unable to read ruleset: %s", err.Error())}}
+		}
+	}
+	var instance interface{}
+	if err := json.Unmarshal(jsonData, &instance); err != nil {
+		return []*RuleSetProblem{{Message: fmt.Sprintf("unable to read ruleset: %s", err.Error())}}
+	}
+
+	compiler := jsonschema.NewCompiler()
+	var parsed map[string]interface{}
+	_ = json.Unmarshal([]byte(RulesetSchema), &parsed)
+	_ = compiler.AddResource("schema.json", parsed)
+	jsch, _ := compiler.Compile("schema.json")
+
+	vErr, ok := jsch.Validate(instance).(*jsonschema.ValidationError)
+	if !ok || vErr == nil {
+		return nil
+	}
+
+	printer := message.NewPrinter(language.English)
+	var problems []*RuleSetProblem
+	var seen []string
+	for _, e := range schemaLeafErrors(vErr) {
+		var found []*RuleSetProblem
+		if ap, isAP := e.ErrorKind.(*kind.AdditionalProperties); isAP {
+			// unknown fields are placed on the field itself.
+			parent := nodeAtLocation(doc, e.InstanceLocation)
+			for _, prop := range ap.Properties {
+				key, _ := mappingValue(parent, prop)
+				found = append(found, schemaProblem(key, e.InstanceLocation, fmt.Sprintf("unknown field '%s'", prop)))
+			}
+		} else {
+			found = append(found, schemaProblem(nodeAtLocation(doc, e.InstanceLocation), e.InstanceLocation,
+				e.ErrorKind.LocalizedString(printer)))
+		}
+		for _, p := range found {
+			if s := p.String(); !slices.Contains(seen, s) {
+				seen = append(seen, s)
+				problems = append(problems, p)
+			}
+		}
+	}
+	return problems
+}
+
+func schemaProblem(node *yaml.Node, location []string, msg string) *RuleSetProblem {
+	p := &RuleSetProblem{Message: msg}
+	if len(location) > 1 && location[0] == "rules" {
+		p.Rule, location = location[1], location[2:]
+	}
+	if node != nil {
+		p.Line, p.Column = node.Line, node.Column
+	}
+	if path := strings.Join(location, "."); path != "" && !strings.HasPrefix(msg, "unknown field") {
+		p.Message = fmt.Sprintf("%s: %s", path, msg)
+	}
+	return p
+}
+
+// schemaLeafErrors flattens a schema validation error into the errors that explain it. When a value can take one
+// of several shapes (a rule can be an object, a severity or a boolean), the shapes the value is clearly not (the
+// wrong type) are left out, so a rule object with a typo is reported as an unknown field, not as an invalid severity.
+func schemaLeafErrors(e *jsonschema.ValidationError) []*jsonschema.ValidationError {
+	if len(e.Causes) == 0 {
+		return []*jsonschema.ValidationError{e}
+	}
+	causes := e.Causes
+	switch e.ErrorKind.(type) {
+	case *kind.OneOf, *kind.AnyOf:
+		var relevant []*jsonschema.ValidationError
+		for _, c := range causes {
+			if !shapeMismatch(c, e.InstanceLocation) {
+				relevant = append(relevant, c)
+			}
+		}
+		if len(relevant) == 0 {
+			// the value isn't any of the shapes, the closest is a list of values of the same type.
+			return closestShapes(causes)
+		}
+		causes = relevant
+	}
+	var leaves []*jsonschema.ValidationError
+	for _, c := range causes {
+		leaves = append(leaves, schemaLeafErrors(c)...)
+	}
+	return leaves
+}
+
+// shapeMismatch returns true if an error is only that the value has the wrong type, or isn't one of some values.
+func shapeMismatch(e *jsonschema.ValidationError, location []string) bool {
+	for len(e.Causes) == 1 {
+		e = e.Causes[0]
+	}
+	if len(e.Causes) > 0 || !slices.Equal(e.InstanceLocation, location) {
+		return false
+	}
+	switch e.ErrorKind.(type) {
+	case *kind.Type, *kind.Enum, *kind.Const:
+		return true
+	}
+	return false
+}
+
+// closestShapes picks the errors for the shapes closest to a value that is none of them, the lists of values of
+// the same type as the value, falling back to every shape.
+func closestShapes(causes []*jsonschema.ValidationError) []*jsonschema.ValidationError {
+	var leaves, closest []*jsonschema.ValidationError
+	for _, c := range causes {
+		leaves = append(leaves, schemaLeafErrors(c)...)
+	}
+	for _, l := range leaves {
+		if enum, ok := l.ErrorKind.(*kind.Enum); ok && slices.ContainsFunc(enum.Want, func(w any) bool {
+			return fmt.Sprintf("%T", w) == fmt.Sprintf("%T", enum.Got)
+		}) {
+			closest = append(closest, l)
+		}
+	}
+	if len(closest) == 0 {
+		return leaves
+	}
+	return closest
+}
+
+// validateRuleGiven checks every given of a rule is a JSONPath that can be evaluated, after expanding aliases.
+func validateRuleGiven(name string, ruleNode *yaml.Node, sc *spectralRuleContext) []*RuleSetProblem {
+	_, givenNode := mappingValue(ruleNode, "given")
+	if givenNode == nil {
+		return nil
+	}
+	givenNodes := []*yaml.Node{givenNode}
+	if givenNode.Kind == yaml.SequenceNode {
+		givenNodes = givenNode.Content
+	}
+	var problems []*RuleSetProblem
+	for _, g := range givenNodes {
+		if g.Kind != yaml.ScalarNode {
+			continue
+		}
+		paths, err := sc.expandAlias(g.Value, nil, nil)
+		if err != nil {
+			problems = append(problems, &RuleSetProblem{Line: g.Line, Column: g.Column, Rule: name,
+				Message: fmt.Sprintf("given '%s': %s", g.Value, err.Error())})
+			continue
+		}
+		for _, p := range paths {
+			if p == "$" {
+				continue
+			}
+			if _, fErr := utils.FindNodesWithoutDeserializing(&yaml.Node{Kind: yaml.MappingNode}, p); fErr != nil {
+				problems = append(problems, &RuleSetProblem{Line: g.Line, Column: g.Column, Rule: name,
+					Message: fmt.Sprintf("given '%s' is not a valid JSONPath: %s", p,
+						strings.SplitN(fErr.Error(), "\n", 2)[0])})
+			}
+		}
+	}
+	return problems
+}
+
+// validateRuleFunctions checks every function a rule uses exists, and is given options it accepts.
+func validateRuleFunctions(name string, ruleNode *yaml.Node, functions map[string]model.RuleFunction) []*RuleSetProblem {
+	_, thenNode := mappingValue(ruleNode, "then")
+	if thenNode == nil {
+		return nil
+	}
+	thenNodes := []*yaml.Node{thenNode}
+	if thenNode.Kind == yaml.SequenceNode {
+		thenNodes = thenNode.Content
+	}
+	var problems []*RuleSetProblem
+	for _, t := range thenNodes {
+		_, fnNode := mappingValue(t, "function")
+		if fnNode == nil || fnNode.Kind != yaml.ScalarNode {
+			continue
+		}
+		fn := functions[fnNode.Value]
+		if fn == nil {
+			problems = append(problems, &RuleSetProblem{Line: fnNode.Line, Column: fnNode.Column, Rule: name,
+				Message: fmt.Sprintf("function '%s' does not exist", fnNode.Value)})
+			continue
+		}
+		var action model.RuleAction
+		if err := t.Decode(&action); err != nil {
+			continue
+		}
+		if valid, errs := model.ValidateRuleFunctionContextAgainstSchema(fn, model.RuleFunctionContext{
+			Options:    action.FunctionOptions,
+			RuleAction: &action,
+		}); !valid || len(errs) > 0 {
+			at := fnNode
+			if _, optionsNode := mappingValue(t, "functionOptions"); optionsNode != nil {
+				at = optionsNode
+			}
+			for _, e := range errs {
+				problems = append(problems, &RuleSetProblem{Line: at.Line, Column: at.Column, Rule: name,
+					Message: fmt.Sprintf("function '%s': %s", fnNode.Value, e)})
+			}
+		}
+	}
+	return problems
+}
+
+// mappingValue returns the key and value nodes of a key in a mapping node, or nil if there is no such key.
+func mappingValue(node *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil, nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i], node.Content[i+1]
+		}
+	}
+	return nil, nil
+}
+
+// nodeAtLocation finds the node at a (JSON pointer style) location in a document, or the closest node to it.
+func nodeAtLocation(node *yaml.Node, location []string) *yaml.Node {
+	for _, segment := range location {
+		var next *yaml.Node
+		switch node.Kind {
+		case yaml.MappingNode:
+			_, next = mappingValue(node, segment)
+		case yaml.SequenceNode:
+			if i, err := strconv.Atoi(segment); err == nil && i >= 0 && i < len(node.Content) {
+				next = node.Content[i]
+			}
+		}
+		if next == nil {
+			return node
+		}
+		node = next
+	}
+	return node
+}
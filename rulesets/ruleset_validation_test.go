@@ -0,0 +1,153 @@
+package rulesets
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type validationTestFunction struct{}
+
+func (validationTestFunction) RunRule(_ []*yaml.Node, _ model.RuleFunctionContext) []model.RuleFunctionResult {
+	return nil
+}
+
+func (validationTestFunction) GetSchema() model.RuleFunctionSchema {
+	return model.RuleFunctionSchema{
+		Name:       "check",
+		Required:   []string{"match"},
+		Properties: []model.RuleFunctionProperty{{Name: "match"}},
+	}
+}
+
+func (validationTestFunction) GetCategory() string {
+	return model.FunctionCategoryCore
+}
+
+func validationProblems(yml string) []string {
+	var problems []string
+	for _, p := range ValidateRuleSet([]byte(yml), map[string]model.RuleFunction{"check": validationTestFunction{}}) {
+		problems = append(problems, p.String())
+	}
+	return problems
+}
+
+func TestValidateRuleSet_Valid(t *testing.T) {
+	yml := `extends: [[spectral:oas, off]]
+aliases:
+  Info: $.info
+rules:
+  operation-tags: warn
+  my-rule:
+    given: "#Info"
+    then:
+      function: check
+      functionOptions:
+        match: yes`
+
+	assert.Empty(t, validationProblems(yml))
+}
+
+func TestValidateRuleSet_UnknownFields(t *testing.T) {
+	yml := `descripton: typo
+rules:
+  my-rule:
+    givn: $.info
+    given: $.info
+    then:
+      function: check
+      functionOptions:
+        match: yes`
+
+	assert.Equal(t, []string{
+		"1:1: unknown field 'descripton'",
+		"4:5: rule 'my-rule': unknown field 'givn'",
+	}, validationProblems(yml))
+}
+
+func TestValidateRuleSet_Severity(t *testing.T) {
+	yml := `rules:
+  operation-tags: warnx
+  my-rule:
+    given: $.info
+    severity: warning
+    then:
+      function: check
+      functionOptions:
+        match: yes`
+
+	assert.Equal(t, []string{
+		"2:19: rule 'operation-tags': value must be one of 'error', 'warn', 'info', 'hint', 'off'",
+		"5:15: rule 'my-rule': severity: value must be one of 'error', 'warn', 'info', 'hint', 'off'",
+	}, validationProblems(yml))
+}
+
+func TestValidateRuleSet_Functions(t *testing.T) {
+	yml := `rules:
+  missing:
+    given: $.info
+    then:
+      function: nope
+  options:
+    given: $.info
+    then:
+      - function: check
+        field: title
+        functionOptions:
+          matches: x`
+
+	problems := validationProblems(yml)
+	assert.Len(t, problems, 3)
+	assert.Equal(t, "5:17: rule 'missing': function 'nope' does not exist", problems[0])
+	assert.Contains(t, problems[1], "12:11: rule 'options': function 'check': ")
+	assert.Contains(t, problems[1]+problems[2], "missing required property: match")
+	assert.Contains(t, problems[1]+problems[2], "property 'matches' is not a valid property")
+}
+
+func TestValidateRuleSet_Given(t *testing.T) {
+	yml := `aliases:
+  Loop: "#Loop"
+rules:
+  bad-path:
+    given:
+      - $.info
+      - $.paths[?(@.x ==
+    then:
+      function: check
+      functionOptions:
+        match: yes
+  bad-alias:
+    given: "#Loop.name"
+    then:
+      function: check
+      functionOptions:
+        match: yes`
+
+	problems := validationProblems(yml)
+	assert.Len(t, problems, 2)
+	assert.Contains(t, problems[0], "7:9: rule 'bad-path': given '$.paths[?(@.x ==' is not a valid JSONPath")
+	assert.Equal(t, "13:12: rule 'bad-alias': given '#Loop.name': alias 'Loop' refers to itself", problems[1])
+}
+
+func TestValidateRuleSet_NotARuleset(t *testing.T) {
+	assert.Equal(t, []string{"the ruleset must be an object"}, validationProblems("- nope"))
+	assert.Contains(t, validationProblems("rules: [")[0], "unable to parse ruleset")
+}
+
+func TestValidateRuleSet_SecurityMapping(t *testing.T) {
+	yml := `rules:
+  my-rule:
+    given: $.info
+    cwe: [CWE-79, XSS]
+    owasp: [API2:2023]
+    then:
+      function: check
+      functionOptions:
+        match: yes`
+
+	assert.Equal(t, []string{
+		"4:19: rule 'my-rule': cwe.1: 'XSS' does not match pattern '^CWE-[0-9]+$'",
+	}, validationProblems(yml))
+}
@@ -13,6 +13,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"sync"
 	"time"
@@ -94,6 +95,7 @@ const (
 	OasSchemaCheck                       = "oas-schema-check"
 	OasMissingType                       = "oas-missing-type"
 	PathItemReferences                   = "path-item-refs"
+	Oas3AdditionalOperations             = "oas3-additional-operations"
 	OwaspNoNumericIDs                    = "owasp-no-numeric-ids"
 	OwaspNoHttpBasic                     = "owasp-no-http-basic"
 	OwaspNoAPIKeysInURL                  = "owasp-no-api-keys-in-url"
@@ -148,6 +150,14 @@ type RuleSets interface {
 	// recommended rules (not all rules). Passing all these rules would result in a quality specification
 	GenerateOpenAPIRecommendedRuleSet() *RuleSet
 
+	// GenerateAsyncAPIRecommendedRuleSet generates a ready to run pointer to a model.RuleSet that contains the
+	// recommended AsyncAPI rules. This is used when linting AsyncAPI documents without a custom ruleset.
+	GenerateAsyncAPIRecommendedRuleSet() *RuleSet
+
+	// GenerateJSONSchemaRuleSet generates a ready to run pointer to a model.RuleSet that contains the rules for
+	// standalone JSON Schema documents.
+	GenerateJSONSchemaRuleSet() *RuleSet
+
 	// GenerateRuleSetFromSuppliedRuleSet will generate a ready to run ruleset based on a supplied configuration. This
 	// will look for any extensions and apply all rules turned on, turned off and any custom rules.
 	GenerateRuleSetFromSuppliedRuleSet(config *RuleSet) *RuleSet
@@ -188,12 +198,27 @@ func (rsm ruleSetsModel) GenerateOpenAPIRecommendedRuleSet() *RuleSet {
 		}
 	}
 
-	// copy.
-	modifiedRS := *rsm.openAPIRuleSet
+	modifiedRS := rsm.openAPIRuleSet.Copy()
 	modifiedRS.Rules = filtered
 	modifiedRS.DocumentationURI = "https://quobix.com/vacuum/rulesets/recommended"
 	modifiedRS.Description = "Recommended rules for a high quality specification."
-	return &modifiedRS
+	return modifiedRS
+}
+
+func (rsm ruleSetsModel) GenerateAsyncAPIRecommendedRuleSet() *RuleSet {
+	return &RuleSet{
+		DocumentationURI: "https://quobix.com/vacuum/rulesets/asyncapi",
+		Rules:            GetRecommendedAsyncAPIRules(),
+		Description:      "Recommended rules for a high quality AsyncAPI specification.",
+	}
+}
+
+func (rsm ruleSetsModel) GenerateJSONSchemaRuleSet() *RuleSet {
+	return &RuleSet{
+		DocumentationURI: "https://quobix.com/vacuum/rulesets/json-schema",
+		Rules:            GetAllJSONSchemaRules(),
+		Description:      "Rules for a high quality JSON Schema.",
+	}
 }
 
 func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSet(ruleset *RuleSet) *RuleSet {
@@ -203,6 +228,7 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSet(ruleset *RuleSet) *R
 func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(ruleset *RuleSet, httpClient *http.Client) *RuleSet {
 
 	extends := ruleset.GetExtendsValue()
+	documentationURI := ruleset.DocumentationURI
 
 	rs := &RuleSet{
 		DocumentationURI: ruleset.DocumentationURI,
@@ -225,13 +251,13 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(rulese
 
 	// all rules
 	if extends[SpectralOpenAPI] == VacuumAll || extends[VacuumOpenAPI] == VacuumAll {
-		rs = rsm.openAPIRuleSet
+		rs = rsm.openAPIRuleSet.Copy()
 	}
 
 	// vacuum:all - combines both OpenAPI and OWASP rules
 	if extends[VacuumAllRulesets] == VacuumAll || extends[VacuumAllRulesets] == VacuumAllRulesets {
 		// Start with OpenAPI rules
-		rs = rsm.openAPIRuleSet
+		rs = rsm.openAPIRuleSet.Copy()
 		// Add all OWASP rules
 		for ruleName, rule := range GetAllOWASPRules() {
 			rs.Rules[ruleName] = rule
@@ -274,13 +300,29 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(rulese
 		}
 	}
 
-	// owasp rules with spectral and vacuum namespace (recommended)
-	if extends[SpectralOwasp] == VacuumRecommended || extends[VacuumOwasp] == VacuumRecommended {
+	// owasp rules with spectral and vacuum namespace (recommended, the default)
+	if extends[SpectralOwasp] == VacuumRecommended || extends[SpectralOwasp] == SpectralOwasp ||
+		extends[VacuumOwasp] == VacuumRecommended || extends[VacuumOwasp] == VacuumOwasp {
 		for ruleName, rule := range GetRecommendedOWASPRules() {
 			rs.Rules[ruleName] = rule
 		}
 	}
 
+	// asyncapi rules with spectral and vacuum namespace
+	if extends[SpectralAsyncAPI] == VacuumAll || extends[VacuumAsyncAPI] == VacuumAll {
+		for ruleName, rule := range GetAllAsyncAPIRules() {
+			rs.Rules[ruleName] = rule
+		}
+	}
+
+	// asyncapi rules with spectral and vacuum namespace (recommended)
+	if extends[SpectralAsyncAPI] == VacuumRecommended || extends[SpectralAsyncAPI] == SpectralAsyncAPI ||
+		extends[VacuumAsyncAPI] == VacuumRecommended || extends[VacuumAsyncAPI] == VacuumAsyncAPI {
+		for ruleName, rule := range GetRecommendedAsyncAPIRules() {
+			rs.Rules[ruleName] = rule
+		}
+	}
+
 	// add definitions.
 	rs.RuleDefinitions = ruleset.RuleDefinitions
 
@@ -288,6 +330,17 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(rulese
 		rs.RuleDefinitions = make(map[string]any)
 	}
 
+	// aliases are shared with extended rulesets, the ones in this ruleset win.
+	rs.Aliases = make(map[string]interface{})
+	for k, v := range ruleset.Aliases {
+		rs.Aliases[k] = v
+	}
+
+	// categories belong to the ruleset, they are copied before any rules, so rules (including extended ones) can use them.
+	for _, cat := range ruleset.Categories {
+		rs.addRuleCategory(cat)
+	}
+
 	// download remote rulesets
 	if CheckForRemoteExtends(extends) || CheckForLocalExtends(extends) {
 
@@ -326,6 +379,10 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(rulese
 
 	}
 
+	// anything Spectral does that vacuum can't is reported, rather than silently behaving differently.
+	sc := &spectralRuleContext{aliases: rs.Aliases}
+	sc.checkRuleSet(ruleset)
+
 	// now all the base rules are in, let's run through the raw definitions and decide
 	// what we need to add, enable, disable, replace or change severity on.
 	rs.mutex.Lock()
@@ -358,6 +415,10 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(rulese
 				// First check if it's in the OpenAPI ruleset
 				if rsm.openAPIRuleSet.Rules[k] != nil {
 					rs.Rules[k] = rsm.openAPIRuleSet.Rules[k]
+				} else if asyncAPIRule := GetAllAsyncAPIRules()[k]; asyncAPIRule != nil {
+					rs.Rules[k] = asyncAPIRule
+				} else if jsonSchemaRule := GetAllJSONSchemaRules()[k]; jsonSchemaRule != nil {
+					rs.Rules[k] = jsonSchemaRule
 				} else {
 					// Check if it's an OWASP rule when vacuum:all is used
 					if extends[VacuumAllRulesets] == VacuumOff || extends[VacuumAllRulesets] == VacuumAll || extends[VacuumAllRulesets] == VacuumAllRulesets {
@@ -390,9 +451,15 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(rulese
 			if dErr != nil {
 				rsm.logger.Error("Unable to decode rule", "error", dErr.Error())
 			}
-			dErr = mapstructure.Decode(newRule["category"], &rc)
-			if dErr != nil {
-				rsm.logger.Error("Unable to decode rule category", "error", dErr.Error())
+			// a category can be the id of a category, or a category of its own.
+			catId, catIsId := newRule["category"].(string)
+			if catIsId {
+				rc.Id = catId
+			} else {
+				dErr = mapstructure.Decode(newRule["category"], &rc)
+				if dErr != nil {
+					rsm.logger.Error("Unable to decode rule category", "error", dErr.Error())
+				}
 			}
 
 			// add to validation category if it's not supplied
@@ -400,23 +467,50 @@ func (rsm ruleSetsModel) GenerateRuleSetFromSuppliedRuleSetWithHTTPClient(rulese
 				nr.RuleCategory = model.RuleCategories[model.CategoryValidation]
 				nr.Id = k
 			} else {
-				if model.RuleCategories[rc.Id] != nil {
-					nr.RuleCategory = model.RuleCategories[rc.Id]
+				if cat := rs.GetRuleCategory(rc.Id); cat != nil {
+					nr.RuleCategory = cat
+				} else if !catIsId {
+					// a rule that brings its own category, adds it to the ruleset.
+					nr.RuleCategory = rs.addRuleCategory(&rc)
+				} else {
+					rsm.logger.Warn("Rule category does not exist, using validation", "rule", k, "category", rc.Id)
 				}
 			}
 
-			if nr.RuleCategory == nil && rs.Rules[k].RuleCategory != nil {
+			if nr.RuleCategory == nil && rs.Rules[k] != nil && rs.Rules[k].RuleCategory != nil {
 				nr.RuleCategory = rs.Rules[k].RuleCategory
 			}
+			if nr.RuleCategory == nil {
+				nr.RuleCategory = model.RuleCategories[model.CategoryValidation]
+			}
+
+			// like Spectral, rules link to the documentation of their ruleset, unless they have their own.
+			if nr.DocumentationURL == "" && documentationURI != "" {
+				nr.DocumentationURL = fmt.Sprintf("%s#%s", documentationURI, k)
+			}
 
 			// default new rule to be resolved if not supplied.
 			if newRule["resolved"] == nil {
 				nr.Resolved = true
 			}
 
+			// expand aliases and normalize formats, a rule that can't run at all is left out.
+			if !sc.applyToRule(k, &nr) {
+				delete(rs.Rules, k)
+				continue
+			}
+
 			rs.Rules[k] = &nr
 		}
 	}
+
+	// custom functions are loaded by the caller, relative to the ruleset.
+	rs.Functions = ruleset.Functions
+	rs.FunctionsDir = ruleset.FunctionsDir
+	rs.Overrides = ruleset.Overrides
+	rs.OverridesDir = ruleset.OverridesDir
+	rs.ParserOptions = ruleset.ParserOptions
+	rs.Downgrades = sc.downgrades
 	rs.mutex.Unlock()
 	return rs
 }
@@ -499,11 +593,12 @@ func GetAllBuiltInRules() map[string]*model.Rule {
 	rules[PostResponseSuccess] = GetPostSuccessResponseRule()
 	rules[NoRequestBody] = GetNoRequestBodyRule()
 	rules[PathItemReferences] = GetPathItemReferencesRule()
+	rules[Oas3AdditionalOperations] = GetOAS3AdditionalOperationsRule()
 
 	// dead.
 	//rules[Oas2ValidSchemaExample] = GetOAS2ExamplesRule()
 
-	return rules
+	return mapBuiltInRuleTaxonomies(tagBuiltInRules(rules))
 }
 
 // GetAllOWASPRules returns a map of all the OWASP rules available, ready to be used in a RuleSet.
@@ -534,7 +629,7 @@ func GetAllOWASPRules() map[string]*model.Rule {
 	rules[OwaspConstrainedAdditionalProperties] = GetOWASPConstrainedAdditionalPropertiesRule()
 	rules[OwaspSecurityHostsHttpsOAS3] = GetOWASPSecurityHostsHttpsOAS3Rule()
 
-	return rules
+	return mapBuiltInRuleTaxonomies(tagBuiltInRules(rules))
 }
 
 // GetRecommendedOWASPRules returns a map of all the OWASP rules available, ready to be used in a RuleSet.
@@ -542,6 +637,46 @@ func GetRecommendedOWASPRules() map[string]*model.Rule {
 	return GetAllOWASPRules() // change if we need to customize this in the future.
 }
 
+// GetAllAsyncAPIRules returns a map of all the AsyncAPI rules available, ready to be used in a RuleSet.
+func GetAllAsyncAPIRules() map[string]*model.Rule {
+	rules := make(map[string]*model.Rule)
+
+	rules[AsyncAPIInfoContact] = GetAsyncAPIInfoContactRule()
+	rules[AsyncAPIInfoDescription] = GetAsyncAPIInfoDescriptionRule()
+	rules[AsyncAPIInfoLicense] = GetAsyncAPIInfoLicenseRule()
+	rules[AsyncAPIServers] = GetAsyncAPIServersRule()
+	rules[AsyncAPIChannelAddress] = GetAsyncAPIChannelAddressRule()
+	rules[AsyncAPIOperationOperationId] = GetAsyncAPIOperationOperationIdRule()
+	rules[AsyncAPIOperationDescription] = GetAsyncAPIOperationDescriptionRule()
+	rules[AsyncAPIOperationAction] = GetAsyncAPIOperationActionRule()
+	rules[AsyncAPIBindingProtocol] = GetAsyncAPIBindingProtocolRule()
+
+	return mapBuiltInRuleTaxonomies(tagBuiltInRules(rules))
+}
+
+// GetRecommendedAsyncAPIRules returns a map of the recommended AsyncAPI rules, ready to be used in a RuleSet.
+func GetRecommendedAsyncAPIRules() map[string]*model.Rule {
+	rules := make(map[string]*model.Rule)
+	for ruleName, rule := range GetAllAsyncAPIRules() {
+		if rule.Recommended {
+			rules[ruleName] = rule
+		}
+	}
+	return rules
+}
+
+// GetAllJSONSchemaRules returns a map of all the JSON Schema rules available, ready to be used in a RuleSet.
+func GetAllJSONSchemaRules() map[string]*model.Rule {
+	rules := make(map[string]*model.Rule)
+
+	rules[JSONSchemaTypes] = GetJSONSchemaTypesRule()
+	rules[JSONSchemaEnumExamples] = GetJSONSchemaEnumExamplesRule()
+	rules[JSONSchemaDescriptions] = GetJSONSchemaDescriptionsRule()
+	rules[JSONSchemaUnreachableDefs] = GetJSONSchemaUnreachableDefsRule()
+
+	return mapBuiltInRuleTaxonomies(tagBuiltInRules(rules))
+}
+
 // GenerateDefaultOpenAPIRuleSet generates a default ruleset for OpenAPI. All the built-in rules, ready to go.
 func GenerateDefaultOpenAPIRuleSet() *RuleSet {
 	set := &RuleSet{
@@ -562,18 +697,65 @@ func GenerateOWASPOpenAPIRuleSet() *RuleSet {
 	return set
 }
 
+// IsBuiltInRuleSetName returns true if the name selects a ruleset built into vacuum, instead of a ruleset file.
+func IsBuiltInRuleSetName(name string) bool {
+	switch name {
+	case "owasp", VacuumOwasp, SpectralOwasp:
+		return true
+	}
+	return false
+}
+
+// GenerateBuiltInRuleSet returns the ruleset built into vacuum with the supplied name, or nil if there isn't one. This
+// lets a ruleset be selected by name (like '--ruleset owasp'), a file with the same name always wins. The ruleset is
+// generated just like a ruleset that extends it, so 'owasp' runs the same rules as extends: [[vacuum:owasp, recommended]].
+func GenerateBuiltInRuleSet(name string, rsm RuleSets) *RuleSet {
+	if !IsBuiltInRuleSetName(name) {
+		return nil
+	}
+	if _, err := os.Stat(name); err == nil {
+		return nil
+	}
+	extends := name
+	if name == "owasp" {
+		extends = VacuumOwasp
+	}
+	return rsm.GenerateRuleSetFromSuppliedRuleSet(&RuleSet{
+		DocumentationURI: "https://quobix.com/vacuum/rulesets/owasp",
+		Description:      "Recommended OWASP rules",
+		Extends:          []interface{}{[]interface{}{extends, VacuumRecommended}},
+	})
+}
+
 // RuleSet represents a collection of Rule definitions.
 type RuleSet struct {
 	Description      string                 `json:"description,omitempty" yaml:"description,omitempty"`
 	DocumentationURI string                 `json:"documentationUrl,omitempty" yaml:"documentationUrl,omitempty"`
 	Formats          []string               `json:"formats,omitempty" yaml:"formats,omitempty"`
-	RuleDefinitions  map[string]interface{} `json:"rules" yaml:"rules"` // this can be either a string, or an entire rule (super annoying, stoplight).
+	Categories       []*model.RuleCategory  `json:"categories,omitempty" yaml:"categories,omitempty"` // categories of its own, for rules to be filed under.
+	RuleDefinitions  map[string]interface{} `json:"rules" yaml:"rules"`                               // this can be either a string, or an entire rule (super annoying, stoplight).
 	Rules            map[string]*model.Rule `json:"-" yaml:"-"`
 	Extends          interface{}            `json:"extends,omitempty" yaml:"extends,omitempty"` // can be string or tuple (again... why stoplight?)
+	Functions        []string               `json:"functions,omitempty" yaml:"functions,omitempty"`
+	FunctionsDir     string                 `json:"functionsDir,omitempty" yaml:"functionsDir,omitempty"`
+	Overrides        []RuleSetOverride      `json:"overrides,omitempty" yaml:"overrides,omitempty"`
+	OverridesDir     string                 `json:"-" yaml:"-"` // relative override globs are relative to this, the directory of the ruleset file.
+	Aliases          map[string]interface{} `json:"aliases,omitempty" yaml:"aliases,omitempty"` // a list of paths, or targets scoped by format.
+	ParserOptions    map[string]interface{} `json:"parserOptions,omitempty" yaml:"parserOptions,omitempty"`
+	Downgrades       []*RuleSetDowngrade    `json:"-" yaml:"-"` // the parts of a Spectral ruleset vacuum can't honour.
 	extendsMeta      map[string]string
 	mutex            sync.Mutex
 }
 
+// RuleSetOverride changes rules for the documents that match Files, just like Spectral overrides. Files are globs
+// relative to the ruleset file (or the working directory, for a ruleset that isn't a file), a glob can end with a
+// JSON pointer (like 'openapi.yaml#/paths/~1pets') to only change part of a document. Rules is a map of rule names
+// to a severity, or 'off'.
+type RuleSetOverride struct {
+	Files []string               `json:"files" yaml:"files"`
+	Rules map[string]interface{} `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
 // GetExtendsValue returns an array of maps defining which ruleset this one extends. The value can be
 // a single string or an array of tuples, so this normalizes things into a standard structure.
 func (rs *RuleSet) GetExtendsValue() map[string]string {
@@ -603,6 +785,59 @@ func (rs *RuleSet) GetExtendsValue() map[string]string {
 	return m
 }
 
+// Copy returns a copy of the ruleset, with a map of rules of its own, so rules can be added or removed from the copy
+// without changing the ruleset. The rules themselves are shared.
+func (rs *RuleSet) Copy() *RuleSet {
+	rules := make(map[string]*model.Rule, len(rs.Rules))
+	for k, v := range rs.Rules {
+		rules[k] = v
+	}
+	return &RuleSet{
+		Description:      rs.Description,
+		DocumentationURI: rs.DocumentationURI,
+		Formats:          rs.Formats,
+		Categories:       slices.Clone(rs.Categories),
+		RuleDefinitions:  rs.RuleDefinitions,
+		Rules:            rules,
+		Extends:          rs.Extends,
+		Functions:        rs.Functions,
+		FunctionsDir:     rs.FunctionsDir,
+		Overrides:        rs.Overrides,
+		OverridesDir:     rs.OverridesDir,
+		Aliases:          rs.Aliases,
+		ParserOptions:    rs.ParserOptions,
+		Downgrades:       rs.Downgrades,
+		extendsMeta:      rs.extendsMeta,
+	}
+}
+
+// GetRuleCategories returns the built-in categories along with the categories of the ruleset, in order.
+func (rs *RuleSet) GetRuleCategories() []*model.RuleCategory {
+	return model.OrderRuleCategories(rs.Categories)
+}
+
+// GetRuleCategory returns the category of the ruleset with the supplied id, or the built-in one.
+func (rs *RuleSet) GetRuleCategory(id string) *model.RuleCategory {
+	for _, cat := range rs.Categories {
+		if cat.Id == id {
+			return cat
+		}
+	}
+	return model.RuleCategories[id]
+}
+
+// addRuleCategory adds a copy of a category to the ruleset, unless it already has a category with that id.
+func (rs *RuleSet) addRuleCategory(category *model.RuleCategory) *model.RuleCategory {
+	if category != nil && slices.ContainsFunc(rs.Categories, func(c *model.RuleCategory) bool { return c.Id == category.Id }) {
+		return rs.GetRuleCategory(category.Id)
+	}
+	cat := model.NewRuleSetCategory(category)
+	if cat != nil {
+		rs.Categories = append(rs.Categories, cat)
+	}
+	return cat
+}
+
 // CreateRuleSetUsingJSON will create a new RuleSet instance from a JSON byte array
 func CreateRuleSetUsingJSON(jsonData []byte) (*RuleSet, error) {
 	jsonString := string(jsonData)
@@ -662,7 +897,10 @@ func CreateRuleSetUsingJSON(jsonData []byte) (*RuleSet, error) {
 				}
 			}
 			rs.Rules[k] = &rule
-			rule.Resolved = true // default resolved.
+			// default resolved, unless the rule says otherwise.
+			if b["resolved"] == nil {
+				rule.Resolved = true
+			}
 		}
 
 		if b, ok := v.(model.Rule); ok {
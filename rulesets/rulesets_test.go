@@ -10,13 +10,14 @@ import (
 	"net/http/httptest"
 	"os"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
 
-var totalRules = 61
+var totalRules = 62
 var totalOwaspRules = 23
-var totalRecommendedRules = 49
+var totalRecommendedRules = 50
 
 func TestBuildDefaultRuleSets(t *testing.T) {
 
@@ -383,6 +384,159 @@ func TestRuleSetsModel_GenerateRuleSetFromConfig_Oas_VacuumOwasp(t *testing.T) {
 
 }
 
+func TestRuleSetsModel_GenerateRuleSetFromConfig_VacuumOwasp_String(t *testing.T) {
+
+	yaml := `extends: vacuum:owasp`
+
+	def := BuildDefaultRuleSets()
+	rs, _ := CreateRuleSetFromData([]byte(yaml))
+	repl := def.GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.Len(t, repl.Rules, len(GetRecommendedOWASPRules()))
+	assert.NotNil(t, repl.Rules[OwaspSecurityHostsHttpsOAS3])
+
+}
+
+func TestGenerateBuiltInRuleSet(t *testing.T) {
+
+	def := BuildDefaultRuleSets()
+	rs, _ := CreateRuleSetFromData([]byte(`extends: [[vacuum:owasp, recommended]]`))
+	extended := def.GenerateRuleSetFromSuppliedRuleSet(rs)
+
+	for _, name := range []string{"owasp", VacuumOwasp, SpectralOwasp} {
+		builtIn := GenerateBuiltInRuleSet(name, def)
+		assert.NotNil(t, builtIn)
+		assert.Equal(t, extended.Rules, builtIn.Rules)
+	}
+
+	assert.Nil(t, GenerateBuiltInRuleSet("rulesets.go", def))
+	assert.Nil(t, GenerateBuiltInRuleSet("", def))
+	assert.True(t, IsBuiltInRuleSetName(VacuumOwasp))
+	assert.False(t, IsBuiltInRuleSetName(VacuumAllRulesets))
+
+	// a file with the same name always wins.
+	t.Chdir(t.TempDir())
+	assert.NoError(t, os.WriteFile("owasp", []byte("rules: {}"), 0o644))
+	assert.Nil(t, GenerateBuiltInRuleSet("owasp", def))
+}
+
+func TestBuiltInRules_Tags(t *testing.T) {
+	for _, rule := range GetAllBuiltInRules() {
+		assert.NotEmpty(t, rule.Tags, rule.Id)
+	}
+	for _, rule := range GetAllOWASPRules() {
+		assert.True(t, rule.HasTag(TagSecurity), rule.Id)
+		assert.True(t, rule.HasTag(TagOWASP), rule.Id)
+	}
+	rules := GetAllBuiltInRules()
+	assert.Equal(t, []string{model.CategoryOperations, TagStyle}, rules[PathsKebabCase].Tags)
+	assert.True(t, rules[NoScriptTagsInMarkdown].HasTag(TagSecurity))
+}
+
+func TestBuiltInRules_SecurityMappings(t *testing.T) {
+	for _, rule := range GetAllOWASPRules() {
+		assert.NotEmpty(t, rule.CWE, rule.Id)
+		assert.NotEmpty(t, rule.OWASP, rule.Id)
+	}
+	for id, mapping := range securityMappings {
+		for _, cwe := range mapping.cwe {
+			assert.NotEmpty(t, model.CWENames[cwe], id)
+		}
+		for _, owasp := range mapping.owasp {
+			assert.NotEmpty(t, model.OWASPAPITop10[owasp], id)
+		}
+	}
+	rules := GetAllBuiltInRules()
+	assert.Equal(t, []string{"CWE-79"}, rules[NoEvalInMarkdown].CWE)
+	assert.False(t, rules[PathsKebabCase].HasSecurityMapping())
+}
+
+func TestRuleSetsModel_GenerateRuleSetFromConfig_SecurityMapping(t *testing.T) {
+
+	yaml := `extends: [[vacuum:oas, recommended]]
+rules:
+  custom-rule:
+    description: custom
+    given: $.info
+    cwe: [CWE-200]
+    owasp: [API3:2023]
+    then:
+      function: truthy
+      field: title`
+
+	rs, err := CreateRuleSetFromData([]byte(yaml))
+	assert.NoError(t, err)
+	repl := BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.Equal(t, []string{"CWE-200"}, repl.Rules["custom-rule"].CWE)
+	assert.Equal(t, []string{"API3:2023"}, repl.Rules["custom-rule"].OWASP)
+}
+
+func TestRuleSetsModel_GenerateRuleSetFromConfig_Tags(t *testing.T) {
+
+	yaml := `extends: [[spectral:oas, recommended]]
+rules:
+  operation-tags: warn
+  custom-rule:
+    description: custom
+    given: $.info
+    tags: [breaking, security]
+    then:
+      function: truthy
+      field: title`
+
+	def := BuildDefaultRuleSets()
+	rs, err := CreateRuleSetFromData([]byte(yaml))
+	assert.NoError(t, err)
+	repl := def.GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.Equal(t, []string{"breaking", "security"}, repl.Rules["custom-rule"].Tags)
+	assert.Equal(t, GetAllBuiltInRules()[OperationTags].Tags, repl.Rules[OperationTags].Tags)
+}
+
+func TestRuleSetsModel_GenerateAsyncAPIRecommendedRuleSet(t *testing.T) {
+	rs := BuildDefaultRuleSets().GenerateAsyncAPIRecommendedRuleSet()
+	assert.Len(t, rs.Rules, len(GetAllAsyncAPIRules())-1)
+	assert.Nil(t, rs.Rules[AsyncAPIInfoLicense])
+	for _, rule := range GetAllAsyncAPIRules() {
+		assert.NotEmpty(t, rule.Formats, rule.Id)
+		assert.NotEmpty(t, rule.HowToFix, rule.Id)
+	}
+}
+
+func TestRuleSetsModel_GenerateRuleSetFromConfig_VacuumAsyncAPI(t *testing.T) {
+
+	yaml := `extends: [[vacuum:asyncapi, all]]`
+
+	def := BuildDefaultRuleSets()
+	rs, _ := CreateRuleSetFromData([]byte(yaml))
+	repl := def.GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.Len(t, repl.Rules, len(GetAllAsyncAPIRules()))
+
+}
+
+func TestRuleSetsModel_GenerateRuleSetFromConfig_SpectralAsyncAPI(t *testing.T) {
+
+	yaml := `extends: spectral:asyncapi
+rules:
+  asyncapi-servers: off
+  asyncapi-info-license: true`
+
+	def := BuildDefaultRuleSets()
+	rs, _ := CreateRuleSetFromData([]byte(yaml))
+	repl := def.GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.Len(t, repl.Rules, len(GetAllAsyncAPIRules())-1)
+	assert.Nil(t, repl.Rules[AsyncAPIServers])
+	assert.NotNil(t, repl.Rules[AsyncAPIInfoLicense])
+
+}
+
+func TestRuleSetsModel_GenerateJSONSchemaRuleSet(t *testing.T) {
+	rs := BuildDefaultRuleSets().GenerateJSONSchemaRuleSet()
+	assert.Len(t, rs.Rules, len(GetAllJSONSchemaRules()))
+	for _, rule := range rs.Rules {
+		assert.Equal(t, model.JSONSchemaFormat, rule.Formats, rule.Id)
+		assert.NotEmpty(t, rule.HowToFix, rule.Id)
+	}
+}
+
 func TestGetAllBuiltInRules(t *testing.T) {
 	assert.Len(t, GetAllBuiltInRules(), totalRules)
 }
@@ -545,7 +699,7 @@ rules:
 	rs, err := CreateRuleSetFromData([]byte(yamlA))
 	assert.NoError(t, err)
 	override := def.GenerateRuleSetFromSuppliedRuleSet(rs)
-	assert.Len(t, override.Rules, 51)
+	assert.Len(t, override.Rules, 52)
 	assert.Len(t, override.RuleDefinitions, 2)
 	assert.NotNil(t, rs.Rules["ding"])
 	assert.NotNil(t, rs.Rules["dong"])
@@ -734,7 +888,7 @@ func TestRuleSet_GetExtendsLocalSpec_Multi_Chain(t *testing.T) {
 	rs, err := CreateRuleSetFromData([]byte(yaml))
 	assert.NoError(t, err)
 	override := def.GenerateRuleSetFromSuppliedRuleSet(rs)
-	assert.Len(t, override.Rules, 62)
+	assert.Len(t, override.Rules, 63)
 	assert.Len(t, override.RuleDefinitions, 1)
 
 }
@@ -825,3 +979,122 @@ func TestRuleSet_GetExtendsLocalSpec_Multi_Chain_Loop(t *testing.T) {
 	assert.Contains(t, logBuffer.String(), "ruleset links to its self, circular rulesets are not permitted")
 
 }
+
+func TestRuleSetsModel_GenerateRuleSetFromConfig_DocumentationURL(t *testing.T) {
+
+	yaml := `extends: [[vacuum:oas, off]]
+documentationUrl: https://example.com/api-style-guide
+rules:
+ fish-cakes:
+   description: yummy sea food
+   given: "$.some.JSON.PATH"
+   then:
+     function: truthy
+ fish-fingers:
+   description: crispy sea food
+   documentationUrl: https://example.com/fish-fingers
+   given: "$.some.JSON.PATH"
+   then:
+     function: truthy`
+
+	def := BuildDefaultRuleSets()
+	rs, err := CreateRuleSetFromData([]byte(yaml))
+	assert.NoError(t, err)
+	newrs := def.GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.Equal(t, "https://example.com/api-style-guide#fish-cakes", newrs.Rules["fish-cakes"].DocumentationURL)
+	assert.Equal(t, "https://example.com/fish-fingers", newrs.Rules["fish-fingers"].GetDocumentationURL())
+}
+
+func TestRuleSetsModel_GenerateRuleSetFromConfig_Categories(t *testing.T) {
+
+	yaml := `extends: [[vacuum:oas, off]]
+categories:
+ - id: style-naming
+   name: Naming
+   description: Chapter 2 of the style guide
+   order: 15
+ - id: tags
+   name: Navigation
+rules:
+ camel-case:
+   description: paths are camel case
+   category: style-naming
+   given: "$.paths"
+   then:
+     function: truthy
+ plural-resources:
+   description: resources are plural
+   category:
+     id: style-resources
+     name: Resources
+   given: "$.paths"
+   then:
+     function: truthy
+ fish-cakes:
+   description: yummy sea food
+   category: fish
+   given: "$.paths"
+   then:
+     function: truthy`
+
+	def := BuildDefaultRuleSets()
+	rs, err := CreateRuleSetFromData([]byte(yaml))
+	assert.NoError(t, err)
+	newrs := def.GenerateRuleSetFromSuppliedRuleSet(rs)
+
+	assert.Len(t, newrs.Categories, 3)
+	assert.Same(t, newrs.GetRuleCategory("style-naming"), newrs.Rules["camel-case"].RuleCategory)
+	assert.Equal(t, "Chapter 2 of the style guide", newrs.Rules["camel-case"].RuleCategory.Description)
+	assert.Equal(t, "Resources", newrs.Rules["plural-resources"].RuleCategory.Name)
+	assert.Same(t, newrs.GetRuleCategory("style-resources"), newrs.Rules["plural-resources"].RuleCategory)
+	assert.Equal(t, model.CategoryValidation, newrs.Rules["fish-cakes"].RuleCategory.Id)
+
+	// between info and operations.
+	assert.Equal(t, "style-naming", newrs.GetRuleCategories()[1].Id)
+	assert.Equal(t, "Navigation", newrs.GetRuleCategory(model.CategoryTags).Name)
+
+	// the categories belong to the ruleset, the built-in categories are left alone.
+	assert.Nil(t, model.RuleCategories["style-naming"])
+	assert.Equal(t, "Tags", model.RuleCategories[model.CategoryTags].Name)
+	assert.Len(t, model.RuleCategoriesOrdered, 9)
+}
+
+func TestRuleSetsModel_GenerateRuleSetFromConfig_CategoriesParallel(t *testing.T) {
+	def := BuildDefaultRuleSets()
+	generate := func(name string) *RuleSet {
+		rs, err := CreateRuleSetFromData([]byte(fmt.Sprintf(`extends: [[vacuum:oas, recommended]]
+categories:
+ - id: tags
+   name: %[1]s
+ - id: %[1]s
+   order: 15
+rules:
+ %[1]s-rule:
+   description: a rule of its own
+   category: %[1]s
+   given: "$.paths"
+   then:
+     function: truthy`, name)))
+		assert.NoError(t, err)
+		return def.GenerateRuleSetFromSuppliedRuleSet(rs)
+	}
+
+	var wg sync.WaitGroup
+	generated := make([]*RuleSet, 2)
+	for i, name := range []string{"pizza", "burger"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			generated[i] = generate(name)
+			assert.Equal(t, name, generated[i].GetRuleCategories()[1].Id)
+		}()
+	}
+	wg.Wait()
+
+	for i, name := range []string{"pizza", "burger"} {
+		assert.Equal(t, name, generated[i].GetRuleCategory(model.CategoryTags).Name)
+		assert.Equal(t, name, generated[i].Rules[name+"-rule"].RuleCategory.Id)
+		assert.Len(t, generated[i].GetRuleCategories(), 10)
+	}
+	assert.Equal(t, "Tags", model.RuleCategories[model.CategoryTags].Name)
+}
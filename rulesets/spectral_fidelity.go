@@ -0,0 +1,291 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package rulesets
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RuleSetDowngrade is a part of a Spectral ruleset that vacuum can't honour exactly. It has either been changed to
+// the closest thing vacuum supports, or ignored, so the ruleset may not behave the same as it does with Spectral.
+type RuleSetDowngrade struct {
+	Rule      string `json:"rule,omitempty" yaml:"rule,omitempty"` // empty if it applies to the whole ruleset.
+	Construct string `json:"construct" yaml:"construct"`
+	Ignored   bool   `json:"ignored,omitempty" yaml:"ignored,omitempty"`
+	Reason    string `json:"reason" yaml:"reason"`
+}
+
+func (d *RuleSetDowngrade) String() string {
+	outcome := "downgraded"
+	if d.Ignored {
+		outcome = "ignored"
+	}
+	if d.Rule == "" {
+		return fmt.Sprintf("%s %s: %s", d.Construct, outcome, d.Reason)
+	}
+	return fmt.Sprintf("rule '%s' %s %s: %s", d.Rule, d.Construct, outcome, d.Reason)
+}
+
+// spectralFormats maps the formats a Spectral ruleset can use, to the spec formats vacuum detects. Spectral's 'oas3'
+// matches every version of OpenAPI 3.
+var spectralFormats = map[string][]string{
+	"oas2":                   {model.OAS2},
+	"oas3":                   model.OAS3AllFormat,
+	"oas3.0":                 {model.OAS3},
+	"oas3_0":                 {model.OAS3},
+	"oas3.1":                 {model.OAS31},
+	"oas3_1":                 {model.OAS31},
+	"oas3.2":                 {model.OAS32},
+	"oas3_2":                 {model.OAS32},
+	"asyncapi2":              {model.AsyncAPI2},
+	"aas2":                   {model.AsyncAPI2},
+	"asyncapi3":              {model.AsyncAPI3},
+	"aas3":                   {model.AsyncAPI3},
+	"json-schema":            {model.JSONSchema},
+	"json-schema-loose":      {model.JSONSchema},
+	model.JSONSchema:         {model.JSONSchema},
+	"json-schema-draft4":     {model.JSONSchema},
+	"json-schema-draft6":     {model.JSONSchema},
+	"json-schema-draft7":     {model.JSONSchema},
+	"json-schema-2019-09":    {model.JSONSchema},
+	"json-schema-2020-12":    {model.JSONSchema},
+	"json-schema-draft-2019": {model.JSONSchema},
+	"json-schema-draft-2020": {model.JSONSchema},
+}
+
+// versionedFormat returns true if a Spectral format is for a single minor version, that vacuum can't tell apart
+// from the others (AsyncAPI and JSON Schema versions).
+func versionedFormat(format string) bool {
+	return strings.HasPrefix(format, "aas2_") || strings.HasPrefix(format, "aas3_") ||
+		(strings.HasPrefix(format, "json-schema-") && format != "json-schema-loose")
+}
+
+// NormalizeSpectralFormats converts Spectral formats into the spec formats vacuum detects, in order and without
+// duplicates. Formats vacuum doesn't know are kept, so a rule limited to them never runs, the same as Spectral.
+func NormalizeSpectralFormats(formats []string) (normalized []string, downgraded []string, unknown []string) {
+	for _, f := range formats {
+		mapped, ok := spectralFormats[f]
+		if !ok && versionedFormat(f) {
+			if strings.HasPrefix(f, "aas2_") {
+				mapped = []string{model.AsyncAPI2}
+			} else if strings.HasPrefix(f, "aas3_") {
+				mapped = []string{model.AsyncAPI3}
+			}
+			ok = mapped != nil
+		}
+		if ok && versionedFormat(f) {
+			downgraded = append(downgraded, f)
+		}
+		if !ok {
+			unknown = append(unknown, f)
+			mapped = []string{f}
+		}
+		for _, m := range mapped {
+			if !slices.Contains(normalized, m) {
+				normalized = append(normalized, m)
+			}
+		}
+	}
+	return normalized, downgraded, unknown
+}
+
+// spectralRuleContext holds everything about a Spectral ruleset needed to run its rules like Spectral would.
+type spectralRuleContext struct {
+	aliases    map[string]interface{}
+	formats    []string
+	downgrades []*RuleSetDowngrade
+}
+
+func (sc *spectralRuleContext) downgrade(rule, construct string, ignored bool, reason string, args ...any) {
+	sc.downgrades = append(sc.downgrades, &RuleSetDowngrade{
+		Rule:      rule,
+		Construct: construct,
+		Ignored:   ignored,
+		Reason:    fmt.Sprintf(reason, args...),
+	})
+}
+
+// checkRuleSet records the parts of a ruleset itself that can't be honoured.
+func (sc *spectralRuleContext) checkRuleSet(ruleset *RuleSet) {
+	options := make([]string, 0, len(ruleset.ParserOptions))
+	for k := range ruleset.ParserOptions {
+		options = append(options, k)
+	}
+	sort.Strings(options)
+	for _, k := range options {
+		sc.downgrade("", "parserOptions."+k, true,
+			"vacuum parses documents itself, and does not report %s as a finding", parserOptionDescription(k))
+	}
+
+	known := []string{SpectralOpenAPI, SpectralOwasp, SpectralAsyncAPI}
+	var extends []string
+	for k := range ruleset.GetExtendsValue() {
+		if strings.HasPrefix(k, "spectral:") && !slices.Contains(known, k) {
+			extends = append(extends, k)
+		}
+	}
+	sort.Strings(extends)
+	for _, k := range extends {
+		sc.downgrade("", "extends", true, "'%s' is not a ruleset vacuum has", k)
+	}
+
+	if len(ruleset.Formats) > 0 {
+		normalized, downgraded, unknown := NormalizeSpectralFormats(ruleset.Formats)
+		sc.formats = normalized
+		sc.reportFormats("", downgraded, unknown)
+	}
+}
+
+func parserOptionDescription(option string) string {
+	switch option {
+	case "duplicateKeys":
+		return "duplicate keys"
+	case "incompatibleValues":
+		return "values YAML and JSON treat differently"
+	}
+	return option
+}
+
+func (sc *spectralRuleContext) reportFormats(rule string, downgraded, unknown []string) {
+	if len(downgraded) > 0 {
+		sc.downgrade(rule, "formats", false, "vacuum can't tell versions of %s apart, the rule runs for every version",
+			strings.Join(downgraded, ", "))
+	}
+	if len(unknown) > 0 {
+		sc.downgrade(rule, "formats", true, "unknown formats %s never match a document",
+			strings.Join(unknown, ", "))
+	}
+}
+
+// applyToRule changes a rule decoded from a Spectral ruleset to behave like it does in Spectral. Formats are
+// normalized (or inherited from the ruleset), and aliases in given are expanded. False is returned if the rule
+// can't run at all.
+func (sc *spectralRuleContext) applyToRule(id string, rule *model.Rule) bool {
+	if len(rule.Formats) > 0 {
+		normalized, downgraded, unknown := NormalizeSpectralFormats(rule.Formats)
+		rule.Formats = normalized
+		sc.reportFormats(id, downgraded, unknown)
+	} else if len(sc.formats) > 0 {
+		rule.Formats = slices.Clone(sc.formats)
+	}
+
+	given := givenPaths(rule.Given)
+	if given == nil {
+		return true
+	}
+	var expanded []string
+	for _, g := range given {
+		paths, err := sc.expandAlias(g, rule.Formats, nil)
+		if err != nil {
+			sc.downgrade(id, "given", true, "%s, the rule can't run", err.Error())
+			return false
+		}
+		for _, p := range paths {
+			if p != "$" {
+				if _, fErr := utils.FindNodesWithoutDeserializing(&yaml.Node{Kind: yaml.MappingNode}, p); fErr != nil {
+					sc.downgrade(id, "given", true, "'%s' is not a JSONPath vacuum can evaluate, nothing matches it", p)
+				}
+			}
+			if !slices.Contains(expanded, p) {
+				expanded = append(expanded, p)
+			}
+		}
+	}
+	if len(expanded) == 1 {
+		rule.Given = expanded[0]
+	} else {
+		rule.Given = expanded
+	}
+	return true
+}
+
+// expandAlias expands a given path that starts with an alias ('#Name', optionally followed by more of a path) into
+// the paths the alias is for. Aliases can use other aliases.
+func (sc *spectralRuleContext) expandAlias(given string, formats []string, seen []string) ([]string, error) {
+	if !strings.HasPrefix(given, "#") {
+		return []string{given}, nil
+	}
+	name := given[1:]
+	rest := ""
+	if i := strings.IndexAny(name, ".["); i >= 0 {
+		name, rest = name[:i], name[i:]
+	}
+	if slices.Contains(seen, name) {
+		return nil, fmt.Errorf("alias '%s' refers to itself", name)
+	}
+	alias, ok := sc.aliases[name]
+	if !ok {
+		return nil, fmt.Errorf("alias '%s' does not exist", name)
+	}
+
+	var targets []string
+	if scoped, isScoped := alias.(map[string]interface{}); isScoped {
+		targets = sc.scopedAliasTargets(name, scoped, formats)
+	} else {
+		targets = givenPaths(alias)
+	}
+
+	var paths []string
+	for _, t := range targets {
+		expanded, err := sc.expandAlias(t, formats, append(seen, name))
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range expanded {
+			paths = append(paths, e+rest)
+		}
+	}
+	return paths, nil
+}
+
+// scopedAliasTargets picks the targets of a scoped alias for the formats of a rule. Spectral picks a target for
+// each document, vacuum runs a rule with the same paths for every document, so the targets of every format the rule
+// runs for are combined.
+func (sc *spectralRuleContext) scopedAliasTargets(name string, alias map[string]interface{}, formats []string) []string {
+	targets, _ := alias["targets"].([]interface{})
+	var paths []string
+	matched := 0
+	for _, t := range targets {
+		target, _ := t.(map[string]interface{})
+		normalized, _, _ := NormalizeSpectralFormats(givenPaths(target["formats"]))
+		if len(formats) > 0 && !slices.ContainsFunc(normalized, func(f string) bool {
+			return slices.Contains(formats, f)
+		}) {
+			continue
+		}
+		matched++
+		paths = append(paths, givenPaths(target["given"])...)
+	}
+	if matched > 1 {
+		sc.downgrade("", "aliases."+name, false, "the targets for %d formats are combined, and used for every document",
+			matched)
+	}
+	return paths
+}
+
+// givenPaths returns a given (a single path, or a list of them) as a list of paths.
+func givenPaths(given interface{}) []string {
+	switch g := given.(type) {
+	case string:
+		return []string{g}
+	case []string:
+		return g
+	case []interface{}:
+		var paths []string
+		for _, p := range g {
+			if s, ok := p.(string); ok {
+				paths = append(paths, s)
+			}
+		}
+		return paths
+	}
+	return nil
+}
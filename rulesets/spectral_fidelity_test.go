@@ -0,0 +1,182 @@
+package rulesets
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func generateSpectralRuleSet(t *testing.T, yaml string) *RuleSet {
+	rs, err := CreateRuleSetFromData([]byte(yaml))
+	assert.NoError(t, err)
+	return BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+}
+
+func TestNormalizeSpectralFormats(t *testing.T) {
+	normalized, downgraded, unknown := NormalizeSpectralFormats([]string{"oas2", "oas3", "oas3_1", "aas2_6",
+		"json-schema-draft7", "json-schema-loose", "graphql"})
+	assert.Equal(t, []string{model.OAS2, model.OAS3, model.OAS31, model.OAS32, model.AsyncAPI2,
+		model.JSONSchema, "graphql"}, normalized)
+	assert.Equal(t, []string{"aas2_6", "json-schema-draft7"}, downgraded)
+	assert.Equal(t, []string{"graphql"}, unknown)
+}
+
+func TestGenerateRuleSet_SpectralFormats(t *testing.T) {
+	yaml := `extends: [[spectral:oas, off]]
+formats: [oas3_1]
+rules:
+  every-version:
+    given: $.info
+    formats: [oas3]
+    then:
+      function: truthy
+      field: title
+  inherited:
+    given: $.info
+    then:
+      function: truthy
+      field: title`
+
+	rs := generateSpectralRuleSet(t, yaml)
+	assert.Equal(t, model.OAS3AllFormat, rs.Rules["every-version"].Formats)
+	assert.Equal(t, []string{model.OAS31}, rs.Rules["inherited"].Formats)
+	assert.Empty(t, rs.Downgrades)
+}
+
+func TestGenerateRuleSet_SpectralFormats_Unknown(t *testing.T) {
+	yaml := `extends: [[spectral:oas, off]]
+rules:
+  graph:
+    given: $.info
+    formats: [graphql]
+    then:
+      function: truthy`
+
+	rs := generateSpectralRuleSet(t, yaml)
+	assert.Equal(t, []string{"graphql"}, rs.Rules["graph"].Formats)
+	assert.Len(t, rs.Downgrades, 1)
+	assert.Equal(t, "rule 'graph' formats ignored: unknown formats graphql never match a document",
+		rs.Downgrades[0].String())
+}
+
+func TestGenerateRuleSet_SpectralAliases(t *testing.T) {
+	yaml := `extends: [[spectral:oas, off]]
+aliases:
+  PathItem: $.paths[*]
+  Operation:
+    - "#PathItem['get','put']"
+rules:
+  operation-summary:
+    given: "#Operation.summary"
+    then:
+      function: truthy
+  paths:
+    given:
+      - "#PathItem"
+      - $.webhooks[*]
+    then:
+      function: truthy`
+
+	rs := generateSpectralRuleSet(t, yaml)
+	assert.Equal(t, "$.paths[*]['get','put'].summary", rs.Rules["operation-summary"].Given)
+	assert.Equal(t, []string{"$.paths[*]", "$.webhooks[*]"}, rs.Rules["paths"].Given)
+	assert.Empty(t, rs.Downgrades)
+	assert.Len(t, rs.Aliases, 2)
+}
+
+func TestGenerateRuleSet_SpectralAliases_Scoped(t *testing.T) {
+	yaml := `extends: [[spectral:oas, off]]
+aliases:
+  SchemaObject:
+    targets:
+      - formats: [oas2]
+        given: ["$.definitions[*]"]
+      - formats: [oas3]
+        given: ["$.components.schemas[*]"]
+rules:
+  oas3-schemas:
+    given: "#SchemaObject"
+    formats: [oas3]
+    then:
+      function: truthy
+  all-schemas:
+    given: "#SchemaObject"
+    then:
+      function: truthy`
+
+	rs := generateSpectralRuleSet(t, yaml)
+	assert.Equal(t, "$.components.schemas[*]", rs.Rules["oas3-schemas"].Given)
+	assert.Equal(t, []string{"$.definitions[*]", "$.components.schemas[*]"}, rs.Rules["all-schemas"].Given)
+	assert.Len(t, rs.Downgrades, 1)
+	assert.Equal(t, "aliases.SchemaObject", rs.Downgrades[0].Construct)
+	assert.False(t, rs.Downgrades[0].Ignored)
+}
+
+func TestGenerateRuleSet_SpectralAliases_Missing(t *testing.T) {
+	yaml := `extends: [[spectral:oas, off]]
+aliases:
+  Loop: "#Loop.name"
+rules:
+  missing:
+    given: "#Nope"
+    then:
+      function: truthy
+  loop:
+    given: "#Loop"
+    then:
+      function: truthy`
+
+	rs := generateSpectralRuleSet(t, yaml)
+	assert.Nil(t, rs.Rules["missing"])
+	assert.Nil(t, rs.Rules["loop"])
+	assert.Len(t, rs.Downgrades, 2)
+	for _, d := range rs.Downgrades {
+		assert.True(t, d.Ignored)
+		assert.Equal(t, "given", d.Construct)
+	}
+}
+
+func TestGenerateRuleSet_SpectralParserOptionsAndExtends(t *testing.T) {
+	yaml := `extends: [spectral:arazzo, spectral:oas]
+parserOptions:
+  duplicateKeys: warn
+  incompatibleValues: error
+rules:
+  bad-path:
+    given: $.paths[?(@.x ==
+    then:
+      function: truthy`
+
+	rs := generateSpectralRuleSet(t, yaml)
+	var constructs []string
+	for _, d := range rs.Downgrades {
+		constructs = append(constructs, d.Construct)
+	}
+	assert.Equal(t, []string{"parserOptions.duplicateKeys", "parserOptions.incompatibleValues", "extends", "given"},
+		constructs)
+	assert.Equal(t, "warn", rs.ParserOptions["duplicateKeys"])
+	assert.NotNil(t, rs.Rules["bad-path"])
+}
+
+func TestGenerateRuleSet_SpectralResolved(t *testing.T) {
+	yaml := `rules:
+  unresolved:
+    given: $.info
+    resolved: false
+    then:
+      function: truthy
+  resolved:
+    given: $.info
+    then:
+      function: truthy`
+
+	rs, err := CreateRuleSetFromData([]byte(yaml))
+	assert.NoError(t, err)
+	assert.False(t, rs.Rules["unresolved"].Resolved)
+	assert.True(t, rs.Rules["resolved"].Resolved)
+
+	generated := BuildDefaultRuleSets().GenerateRuleSetFromSuppliedRuleSet(rs)
+	assert.False(t, generated.Rules["unresolved"].Resolved)
+	assert.True(t, generated.Rules["resolved"].Resolved)
+}
@@ -0,0 +1,244 @@
+package statistics
+
+import (
+	"fmt"
+	"os"
+	"slices"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"gopkg.in/yaml.v3"
+)
+
+// Quality gate metrics, the values a gate can check.
+const (
+	GateMetricScore    = "score"
+	GateMetricIssues   = "issues"
+	GateMetricErrors   = "errors"
+	GateMetricWarnings = "warnings"
+	GateMetricInfo     = "info"
+	GateMetricHints    = "hints"
+)
+
+// GateMetrics are all the metrics a quality gate can check.
+var GateMetrics = []string{GateMetricScore, GateMetricIssues, GateMetricErrors, GateMetricWarnings,
+	GateMetricInfo, GateMetricHints}
+
+// QualityGate is a single condition a linted specification must meet, a metric (optionally for a single rule
+// category, or a single rule) that must be at least Min, and / or at most Max.
+type QualityGate struct {
+	Name     string `json:"name,omitempty" yaml:"name,omitempty"`
+	Metric   string `json:"metric" yaml:"metric"`
+	Category string `json:"category,omitempty" yaml:"category,omitempty"`
+	Rule     string `json:"rule,omitempty" yaml:"rule,omitempty"`
+	Min      *int   `json:"min,omitempty" yaml:"min,omitempty"`
+	Max      *int   `json:"max,omitempty" yaml:"max,omitempty"`
+}
+
+// QualityGatePolicy is a set of quality gates, every gate must pass for the policy to pass. The scores the gates
+// check are calculated by the scoring of the policy.
+type QualityGatePolicy struct {
+	Gates   []*QualityGate `json:"gates" yaml:"gates"`
+	Scoring *ScoringPolicy `json:"scoring,omitempty" yaml:"scoring,omitempty"`
+}
+
+// QualityGateResult is the outcome of checking a quality gate against the statistics of a specification.
+type QualityGateResult struct {
+	Gate   *QualityGate `json:"gate" yaml:"gate"`
+	Value  int          `json:"value" yaml:"value"`
+	Passed bool         `json:"passed" yaml:"passed"`
+}
+
+// ReadQualityGatePolicy reads a quality gate policy from a YAML (or JSON) file, the gates are listed under a
+// top level 'gates' key, the same as a project config file. Gates can check the categories of the ruleset in use.
+func ReadQualityGatePolicy(path string, categories ...*model.RuleCategory) (*QualityGatePolicy, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to read quality gates file '%s': %w", path, err)
+	}
+	var policy QualityGatePolicy
+	if err = yaml.Unmarshal(raw, &policy); err != nil {
+		return nil, fmt.Errorf("unable to parse quality gates file '%s': %w", path, err)
+	}
+	if err = policy.Validate(categories...); err != nil {
+		return nil, fmt.Errorf("invalid quality gates file '%s': %w", path, err)
+	}
+	return &policy, nil
+}
+
+// Validate returns an error for the first gate that can't be checked, or a scoring that can't be used. Categories
+// are those of a ruleset, as well as the built-in categories.
+func (p *QualityGatePolicy) Validate(categories ...*model.RuleCategory) error {
+	if err := p.Scoring.Validate(categories...); err != nil {
+		return err
+	}
+	for i, g := range p.Gates {
+		if err := g.Validate(categories...); err != nil {
+			return fmt.Errorf("gate %d: %w", i+1, err)
+		}
+	}
+	return nil
+}
+
+// Evaluate checks every gate against the statistics of a specification, the results are in the order of the gates.
+func (p *QualityGatePolicy) Evaluate(stats *reports.ReportStatistics) []*QualityGateResult {
+	results := make([]*QualityGateResult, 0, len(p.Gates))
+	for _, g := range p.Gates {
+		results = append(results, g.Evaluate(stats))
+	}
+	return results
+}
+
+// Validate returns an error if the gate has an unknown metric or category, or nothing to check.
+func (g *QualityGate) Validate(categories ...*model.RuleCategory) error {
+	if !slices.Contains(GateMetrics, g.Metric) {
+		return fmt.Errorf("unknown metric '%s', supported metrics are %v", g.Metric, GateMetrics)
+	}
+	if g.Category != "" {
+		if !isRuleCategory(g.Category, categories) {
+			return fmt.Errorf("unknown category '%s'", g.Category)
+		}
+	}
+	if g.Category != "" && g.Rule != "" {
+		return fmt.Errorf("a gate checks a category or a rule, not both")
+	}
+	if g.Min == nil && g.Max == nil {
+		return fmt.Errorf("'%s' needs a min or a max", g.Metric)
+	}
+	return nil
+}
+
+// Evaluate checks the gate against the statistics of a specification. Without statistics, every metric is zero.
+func (g *QualityGate) Evaluate(stats *reports.ReportStatistics) *QualityGateResult {
+	value := g.value(stats)
+	passed := (g.Min == nil || value >= *g.Min) && (g.Max == nil || value <= *g.Max)
+	return &QualityGateResult{Gate: g, Value: value, Passed: passed}
+}
+
+// String describes the gate, its name if it has one, otherwise the condition, e.g. 'errors in security <= 0'.
+func (g *QualityGate) String() string {
+	if g.Name != "" {
+		return g.Name
+	}
+	return g.Condition()
+}
+
+// Condition describes what the gate checks, e.g. 'score >= 85' or 'warnings in schemas <= 10'.
+func (g *QualityGate) Condition() string {
+	metric := g.Metric
+	if g.Category != "" && g.Category != model.CategoryAll {
+		metric = fmt.Sprintf("%s in %s", g.Metric, g.Category)
+	}
+	if g.Rule != "" {
+		metric = fmt.Sprintf("%s of %s", g.Metric, g.Rule)
+	}
+	switch {
+	case g.Min != nil && g.Max != nil:
+		return fmt.Sprintf("%d <= %s <= %d", *g.Min, metric, *g.Max)
+	case g.Min != nil:
+		return fmt.Sprintf("%s >= %d", metric, *g.Min)
+	case g.Max != nil:
+		return fmt.Sprintf("%s <= %d", metric, *g.Max)
+	}
+	return metric
+}
+
+func (g *QualityGate) value(stats *reports.ReportStatistics) int {
+	if stats == nil {
+		return 0
+	}
+	if g.Rule != "" {
+		for _, rule := range stats.RuleStatistics {
+			if rule.RuleId == g.Rule {
+				return ruleValue(g.Metric, rule)
+			}
+		}
+		return noIssuesValue(g.Metric)
+	}
+	if g.Category != "" && g.Category != model.CategoryAll {
+		for _, cat := range stats.CategoryStatistics {
+			if cat.CategoryId == g.Category {
+				return categoryValue(g.Metric, cat)
+			}
+		}
+		return noIssuesValue(g.Metric)
+	}
+	switch g.Metric {
+	case GateMetricScore:
+		return stats.OverallScore
+	case GateMetricErrors:
+		return stats.TotalErrors
+	case GateMetricWarnings:
+		return stats.TotalWarnings
+	case GateMetricInfo:
+		return stats.TotalInfo
+	}
+	// issues and hints are not totalled, every result is in a single category.
+	total := 0
+	for _, cat := range stats.CategoryStatistics {
+		total += categoryValue(g.Metric, cat)
+	}
+	return total
+}
+
+func categoryValue(metric string, cat *reports.CategoryStatistic) int {
+	switch metric {
+	case GateMetricScore:
+		return cat.Score
+	case GateMetricIssues:
+		return cat.NumIssues
+	case GateMetricErrors:
+		return cat.Errors
+	case GateMetricWarnings:
+		return cat.Warnings
+	case GateMetricInfo:
+		return cat.Info
+	case GateMetricHints:
+		return cat.Hints
+	}
+	return 0
+}
+
+func ruleValue(metric string, rule *reports.RuleStatistic) int {
+	switch metric {
+	case GateMetricScore:
+		return rule.Score
+	case GateMetricIssues:
+		return rule.NumIssues
+	case GateMetricErrors:
+		return rule.Errors
+	case GateMetricWarnings:
+		return rule.Warnings
+	case GateMetricInfo:
+		return rule.Info
+	case GateMetricHints:
+		return rule.Hints
+	}
+	return 0
+}
+
+// noIssuesValue is the value of a metric for a category or rule without any results, a perfect score.
+func noIssuesValue(metric string) int {
+	if metric == GateMetricScore {
+		return 100
+	}
+	return 0
+}
+
+// QualityGatesPassed returns true if every gate passed.
+func QualityGatesPassed(results []*QualityGateResult) bool {
+	for _, r := range results {
+		if !r.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// isRuleCategory returns true for a built-in category, or one of the categories supplied.
+func isRuleCategory(id string, categories []*model.RuleCategory) bool {
+	if model.RuleCategories[id] != nil {
+		return true
+	}
+	return slices.ContainsFunc(categories, func(c *model.RuleCategory) bool { return c.Id == id })
+}
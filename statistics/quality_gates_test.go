@@ -0,0 +1,149 @@
+package statistics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/stretchr/testify/assert"
+)
+
+func gateLimit(v int) *int {
+	return &v
+}
+
+func testGateStatistics() *reports.ReportStatistics {
+	return &reports.ReportStatistics{
+		OverallScore:  80,
+		TotalErrors:   2,
+		TotalWarnings: 12,
+		TotalInfo:     1,
+		CategoryStatistics: []*reports.CategoryStatistic{
+			{CategoryId: model.CategorySecurity, NumIssues: 1, Errors: 1},
+			{CategoryId: model.CategorySchemas, NumIssues: 12, Errors: 1, Warnings: 11},
+			{CategoryId: model.CategoryTags, NumIssues: 3, Warnings: 1, Info: 1, Hints: 1, Score: 99},
+		},
+		RuleStatistics: []*reports.RuleStatistic{
+			{RuleId: "operation-operationId", CategoryId: model.CategoryOperations, NumIssues: 2, Errors: 2, Score: 70},
+		},
+	}
+}
+
+func TestQualityGate_Evaluate(t *testing.T) {
+	stats := testGateStatistics()
+
+	score := &QualityGate{Metric: GateMetricScore, Min: gateLimit(85)}
+	r := score.Evaluate(stats)
+	assert.False(t, r.Passed)
+	assert.Equal(t, 80, r.Value)
+	assert.True(t, (&QualityGate{Metric: GateMetricScore, Min: gateLimit(80)}).Evaluate(stats).Passed)
+
+	security := &QualityGate{Metric: GateMetricErrors, Category: model.CategorySecurity, Max: gateLimit(0)}
+	r = security.Evaluate(stats)
+	assert.False(t, r.Passed)
+	assert.Equal(t, 1, r.Value)
+
+	schemas := &QualityGate{Metric: GateMetricWarnings, Category: model.CategorySchemas, Max: gateLimit(11)}
+	assert.True(t, schemas.Evaluate(stats).Passed)
+
+	// a category without statistics has nothing in it.
+	examples := &QualityGate{Metric: GateMetricErrors, Category: model.CategoryExamples, Max: gateLimit(0)}
+	assert.True(t, examples.Evaluate(stats).Passed)
+
+	// issues and hints are totalled across every category.
+	assert.Equal(t, 16, (&QualityGate{Metric: GateMetricIssues, Max: gateLimit(0)}).Evaluate(stats).Value)
+	assert.Equal(t, 1, (&QualityGate{Metric: GateMetricHints, Max: gateLimit(0)}).Evaluate(stats).Value)
+
+	// categories and rules have their own scores, a perfect score if they have no results.
+	assert.Equal(t, 99, (&QualityGate{Metric: GateMetricScore, Category: model.CategoryTags}).Evaluate(stats).Value)
+	assert.Equal(t, 100, (&QualityGate{Metric: GateMetricScore, Category: model.CategoryExamples}).Evaluate(stats).Value)
+	operationId := &QualityGate{Metric: GateMetricErrors, Rule: "operation-operationId", Max: gateLimit(1)}
+	assert.False(t, operationId.Evaluate(stats).Passed)
+	assert.Equal(t, 70, (&QualityGate{Metric: GateMetricScore, Rule: "operation-operationId"}).Evaluate(stats).Value)
+	assert.Equal(t, 100, (&QualityGate{Metric: GateMetricScore, Rule: "info-contact"}).Evaluate(stats).Value)
+	assert.Equal(t, 0, (&QualityGate{Metric: GateMetricIssues, Rule: "info-contact"}).Evaluate(stats).Value)
+
+	between := &QualityGate{Metric: GateMetricWarnings, Min: gateLimit(1), Max: gateLimit(12)}
+	assert.True(t, between.Evaluate(stats).Passed)
+	assert.False(t, between.Evaluate(nil).Passed)
+}
+
+func TestQualityGate_Condition(t *testing.T) {
+	assert.Equal(t, "score >= 85", (&QualityGate{Metric: GateMetricScore, Min: gateLimit(85)}).Condition())
+	assert.Equal(t, "errors in security <= 0",
+		(&QualityGate{Metric: GateMetricErrors, Category: model.CategorySecurity, Max: gateLimit(0)}).Condition())
+	assert.Equal(t, "errors of operation-operationId <= 0",
+		(&QualityGate{Metric: GateMetricErrors, Rule: "operation-operationId", Max: gateLimit(0)}).Condition())
+	assert.Equal(t, "1 <= warnings <= 10",
+		(&QualityGate{Metric: GateMetricWarnings, Min: gateLimit(1), Max: gateLimit(10)}).Condition())
+	assert.Equal(t, "Ship it", (&QualityGate{Name: "Ship it", Metric: GateMetricScore, Min: gateLimit(1)}).String())
+}
+
+func TestQualityGate_Validate(t *testing.T) {
+	assert.NoError(t, (&QualityGate{Metric: GateMetricScore, Min: gateLimit(85)}).Validate())
+	assert.ErrorContains(t, (&QualityGate{Metric: "bananas", Min: gateLimit(1)}).Validate(), "unknown metric")
+	assert.ErrorContains(t, (&QualityGate{Metric: GateMetricErrors, Category: "pizza", Max: gateLimit(1)}).Validate(),
+		"unknown category")
+	assert.NoError(t, (&QualityGate{Metric: GateMetricScore, Category: model.CategoryTags, Min: gateLimit(1)}).Validate())
+	assert.ErrorContains(t, (&QualityGate{Metric: GateMetricScore, Category: model.CategoryTags, Rule: "tag-description",
+		Min: gateLimit(1)}).Validate(), "not both")
+	assert.ErrorContains(t, (&QualityGate{Metric: GateMetricErrors}).Validate(), "needs a min or a max")
+}
+
+func TestQualityGatePolicy_Evaluate(t *testing.T) {
+	policy := &QualityGatePolicy{Gates: []*QualityGate{
+		{Metric: GateMetricScore, Min: gateLimit(50)},
+		{Metric: GateMetricErrors, Category: model.CategorySecurity, Max: gateLimit(0)},
+	}}
+	results := policy.Evaluate(testGateStatistics())
+	assert.Len(t, results, 2)
+	assert.True(t, results[0].Passed)
+	assert.False(t, results[1].Passed)
+	assert.False(t, QualityGatesPassed(results))
+	assert.True(t, QualityGatesPassed(results[:1]))
+}
+
+func TestReadQualityGatePolicy(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "gates.yaml")
+	assert.NoError(t, os.WriteFile(path, []byte(`gates:
+  - name: Overall quality
+    metric: score
+    min: 85
+  - metric: warnings
+    category: schemas
+    max: 10
+scoring:
+  weights:
+    warning: 1
+  categories:
+    security:
+      error: 50
+`), 0664))
+
+	policy, err := ReadQualityGatePolicy(path)
+	assert.NoError(t, err)
+	assert.Len(t, policy.Gates, 2)
+	assert.Equal(t, "Overall quality", policy.Gates[0].Name)
+	assert.Equal(t, 85, *policy.Gates[0].Min)
+	assert.Equal(t, model.CategorySchemas, policy.Gates[1].Category)
+	assert.Nil(t, policy.Gates[1].Min)
+	scoring := policy.Scoring.Scoring()
+	assert.Equal(t, 1.0, scoring.Warning)
+	assert.Equal(t, DefaultErrorWeight, scoring.Error)
+	assert.Equal(t, 50.0, scoring.Categories[model.CategorySecurity].Error)
+	assert.Equal(t, 1.0, scoring.Categories[model.CategorySecurity].Warning)
+
+	assert.NoError(t, os.WriteFile(path, []byte("scoring:\n  categories:\n    pizza:\n      error: 1\n"), 0664))
+	_, err = ReadQualityGatePolicy(path)
+	assert.ErrorContains(t, err, "unknown scoring category 'pizza'")
+
+	assert.NoError(t, os.WriteFile(path, []byte("gates:\n  - metric: bananas\n    max: 1\n"), 0664))
+	_, err = ReadQualityGatePolicy(path)
+	assert.ErrorContains(t, err, "gate 1: unknown metric")
+
+	_, err = ReadQualityGatePolicy(filepath.Join(dir, "missing.yaml"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,211 @@
+package statistics
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+)
+
+// Default score weights, how many points a single result of each severity takes off a score.
+const (
+	DefaultErrorWeight   = 15.0 // errors are failures they should be judged harshly.
+	DefaultWarningWeight = 0.4
+	DefaultInfoWeight    = 0.1
+	DefaultHintWeight    = 0.0
+)
+
+// DefaultScoring returns the scoring used when none is configured.
+func DefaultScoring() *reports.Scoring {
+	return &reports.Scoring{ScoreWeights: reports.ScoreWeights{
+		Error:   DefaultErrorWeight,
+		Warning: DefaultWarningWeight,
+		Info:    DefaultInfoWeight,
+		Hint:    DefaultHintWeight,
+	}}
+}
+
+// ScoreWeightsPolicy sets the weights of severities, a weight that isn't set keeps the weight it replaces.
+type ScoreWeightsPolicy struct {
+	Error   *float64 `json:"error,omitempty" yaml:"error,omitempty"`
+	Warning *float64 `json:"warning,omitempty" yaml:"warning,omitempty"`
+	Info    *float64 `json:"info,omitempty" yaml:"info,omitempty"`
+	Hint    *float64 `json:"hint,omitempty" yaml:"hint,omitempty"`
+}
+
+// ScoringPolicy configures how scores are calculated. Weights replace the default weights, and the weights of a
+// category replace those for the results of the category (and the score of the category).
+type ScoringPolicy struct {
+	Weights    *ScoreWeightsPolicy            `json:"weights,omitempty" yaml:"weights,omitempty"`
+	Categories map[string]*ScoreWeightsPolicy `json:"categories,omitempty" yaml:"categories,omitempty"`
+}
+
+// Validate returns an error for an unknown category (categories of a ruleset are known), or a negative weight.
+func (p *ScoringPolicy) Validate(categories ...*model.RuleCategory) error {
+	if p == nil {
+		return nil
+	}
+	if err := p.Weights.validate(); err != nil {
+		return err
+	}
+	for _, id := range sortedKeys(p.Categories) {
+		if !isRuleCategory(id, categories) || id == model.CategoryAll {
+			return fmt.Errorf("unknown scoring category '%s'", id)
+		}
+		if err := p.Categories[id].validate(); err != nil {
+			return fmt.Errorf("scoring category '%s': %w", id, err)
+		}
+	}
+	return nil
+}
+
+// Scoring resolves the policy into the weights that are used, a nil policy is the default scoring.
+func (p *ScoringPolicy) Scoring() *reports.Scoring {
+	scoring := DefaultScoring()
+	if p == nil {
+		return scoring
+	}
+	scoring.ScoreWeights = p.Weights.apply(scoring.ScoreWeights)
+	for id, w := range p.Categories {
+		if scoring.Categories == nil {
+			scoring.Categories = make(map[string]*reports.ScoreWeights)
+		}
+		weights := w.apply(scoring.ScoreWeights)
+		scoring.Categories[id] = &weights
+	}
+	return scoring
+}
+
+func (w *ScoreWeightsPolicy) validate() error {
+	if w == nil {
+		return nil
+	}
+	for i, v := range []*float64{w.Error, w.Warning, w.Info, w.Hint} {
+		if v != nil && *v < 0 {
+			return fmt.Errorf("the %s weight can't be negative", []string{"error", "warning", "info", "hint"}[i])
+		}
+	}
+	return nil
+}
+
+func (w *ScoreWeightsPolicy) apply(weights reports.ScoreWeights) reports.ScoreWeights {
+	if w == nil {
+		return weights
+	}
+	for _, set := range []struct {
+		value  *float64
+		weight *float64
+	}{{w.Error, &weights.Error}, {w.Warning, &weights.Warning}, {w.Info, &weights.Info}, {w.Hint, &weights.Hint}} {
+		if set.value != nil {
+			*set.weight = *set.value
+		}
+	}
+	return weights
+}
+
+// CalculateScore works out a score (out of 100) for a set of results with the weights of a scoring, a nil scoring
+// is the default scoring. It's the score of a category or a rule, the overall score is CalculateQualityScore.
+func CalculateScore(results []*model.RuleFunctionResult, scoring *reports.Scoring) int {
+	score := deduct(100.0, results, scoring)
+	if score < 0 {
+		return 0
+	}
+	return int(score)
+}
+
+// deduct takes the weight of every result off a score, results of a category with its own weights are weighed by
+// those.
+func deduct(score float64, results []*model.RuleFunctionResult, scoring *reports.Scoring) float64 {
+	if scoring == nil {
+		scoring = DefaultScoring()
+	}
+	type counts struct{ errors, warnings, info, hints int }
+	grouped := make(map[string]*counts)
+	for _, r := range results {
+		if r.Rule == nil {
+			continue
+		}
+		group := ""
+		if r.Rule.RuleCategory != nil && scoring.Categories[r.Rule.RuleCategory.Id] != nil {
+			group = r.Rule.RuleCategory.Id
+		}
+		c := grouped[group]
+		if c == nil {
+			c = &counts{}
+			grouped[group] = c
+		}
+		switch r.Rule.Severity {
+		case model.SeverityError:
+			c.errors++
+		case model.SeverityWarn, "": // if there is no severity, it's a warning.
+			c.warnings++
+		case model.SeverityInfo:
+			c.info++
+		case model.SeverityHint:
+			c.hints++
+		}
+	}
+	for _, group := range sortedKeys(grouped) {
+		weights := &scoring.ScoreWeights
+		if group != "" {
+			weights = scoring.Categories[group]
+		}
+		c := grouped[group]
+		score = score - float64(c.info)*weights.Info
+		score = score - (weights.Warning * float64(c.warnings))
+		score = score - (weights.Error * float64(c.errors))
+		score = score - (weights.Hint * float64(c.hints))
+	}
+	return score
+}
+
+// CreateRuleStatistics breaks down the results by each rule that has results, sorted by rule id.
+func CreateRuleStatistics(results *model.RuleResultSet, scoring *reports.Scoring) []*reports.RuleStatistic {
+	if results == nil {
+		return nil
+	}
+	byRule := make(map[string][]*model.RuleFunctionResult)
+	for _, r := range results.Results {
+		if r.Rule != nil {
+			byRule[r.Rule.Id] = append(byRule[r.Rule.Id], r)
+		}
+	}
+	ruleStats := make([]*reports.RuleStatistic, 0, len(byRule))
+	for _, id := range sortedKeys(byRule) {
+		rs := model.NewRuleResultSetPointer(byRule[id])
+		stat := &reports.RuleStatistic{
+			RuleId:    id,
+			NumIssues: len(rs.Results),
+			Score:     CalculateScore(rs.Results, scoring),
+			Errors:    rs.GetErrorCount(),
+			Warnings:  rs.GetWarnCount(),
+			Info:      rs.GetInfoCount(),
+			Hints:     countHints(rs.Results),
+		}
+		if category := rs.Results[0].Rule.RuleCategory; category != nil {
+			stat.CategoryId = category.Id
+		}
+		ruleStats = append(ruleStats, stat)
+	}
+	return ruleStats
+}
+
+func countHints(results []*model.RuleFunctionResult) int {
+	hints := 0
+	for _, r := range results {
+		if r.Rule != nil && r.Rule.Severity == model.SeverityHint {
+			hints++
+		}
+	}
+	return hints
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,78 @@
+package statistics
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/stretchr/testify/assert"
+)
+
+func scoringWeight(v float64) *float64 {
+	return &v
+}
+
+func buildScoringResults() *model.RuleResultSet {
+	security := &model.Rule{Id: "owasp-auth-insecure-schemes", Severity: model.SeverityError,
+		RuleCategory: model.RuleCategories[model.CategorySecurity]}
+	tags := &model.Rule{Id: "tag-description", Severity: model.SeverityWarn,
+		RuleCategory: model.RuleCategories[model.CategoryTags]}
+	return model.NewRuleResultSetPointer([]*model.RuleFunctionResult{
+		{Rule: security, Message: "insecure"},
+		{Rule: tags, Message: "no description"},
+		{Rule: tags, Message: "no description"},
+	})
+}
+
+func TestCalculateQualityScoreWithScoring(t *testing.T) {
+	results := buildScoringResults()
+	assert.Equal(t, 84, CalculateQualityScoreWithScoring(results, nil))
+	assert.Equal(t, CalculateQualityScore(results), CalculateQualityScoreWithScoring(results, DefaultScoring()))
+
+	policy := &ScoringPolicy{
+		Weights:    &ScoreWeightsPolicy{Warning: scoringWeight(5)},
+		Categories: map[string]*ScoreWeightsPolicy{model.CategorySecurity: {Error: scoringWeight(40)}},
+	}
+	assert.NoError(t, policy.Validate())
+	assert.Equal(t, 50, CalculateQualityScoreWithScoring(results, policy.Scoring()))
+}
+
+func TestCreateCategoryStatisticsWithScoring(t *testing.T) {
+	policy := &ScoringPolicy{Categories: map[string]*ScoreWeightsPolicy{model.CategoryTags: {Warning: scoringWeight(10)}}}
+	stats := CreateCategoryStatisticsWithScoring(model.DocumentKindOpenAPI, buildScoringResults(), policy.Scoring())
+	scores := make(map[string]int)
+	for _, s := range stats {
+		scores[s.CategoryId] = s.Score
+	}
+	assert.Equal(t, 85, scores[model.CategorySecurity])
+	assert.Equal(t, 80, scores[model.CategoryTags])
+	assert.Equal(t, 100, scores[model.CategoryExamples])
+}
+
+func TestCreateRuleStatistics(t *testing.T) {
+	stats := CreateRuleStatistics(buildScoringResults(), nil)
+	assert.Len(t, stats, 2)
+	assert.Equal(t, "owasp-auth-insecure-schemes", stats[0].RuleId)
+	assert.Equal(t, model.CategorySecurity, stats[0].CategoryId)
+	assert.Equal(t, 1, stats[0].Errors)
+	assert.Equal(t, 85, stats[0].Score)
+	assert.Equal(t, "tag-description", stats[1].RuleId)
+	assert.Equal(t, 2, stats[1].Warnings)
+	assert.Equal(t, 99, stats[1].Score)
+}
+
+func TestScoringPolicy_Validate(t *testing.T) {
+	assert.ErrorContains(t, (&ScoringPolicy{Weights: &ScoreWeightsPolicy{Info: scoringWeight(-1)}}).Validate(),
+		"the info weight can't be negative")
+	assert.ErrorContains(t, (&ScoringPolicy{Categories: map[string]*ScoreWeightsPolicy{model.CategoryAll: {}}}).Validate(),
+		"unknown scoring category")
+}
+
+func TestScoring_JSON(t *testing.T) {
+	data, err := json.Marshal(&reports.Scoring{ScoreWeights: reports.ScoreWeights{Error: 15},
+		Categories: map[string]*reports.ScoreWeights{model.CategorySecurity: {Error: 40}}})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"error":15,"warning":0,"info":0,"hint":0,"categories":{"security":{"error":40,"warning":0,"info":0,"hint":0}}}`,
+		string(data))
+}
@@ -0,0 +1,74 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package statistics
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+)
+
+// CreateSecuritySummary groups the results of rules that map to CWE weaknesses or OWASP API Security Top 10 entries
+// by them. Nil is returned if none of the results are for a mapped rule.
+func CreateSecuritySummary(results []*model.RuleFunctionResult) *reports.SecuritySummary {
+	summary := &reports.SecuritySummary{}
+	cwe := make(map[string]*reports.SecurityGroup)
+	owasp := make(map[string]*reports.SecurityGroup)
+	for _, r := range results {
+		if r.Rule == nil || !r.Rule.HasSecurityMapping() {
+			continue
+		}
+		summary.Findings++
+		for _, id := range r.Rule.CWE {
+			countSecurityFinding(cwe, id, model.CWENames[id], r)
+		}
+		for _, id := range r.Rule.OWASP {
+			countSecurityFinding(owasp, id, model.OWASPAPITop10[id], r)
+		}
+	}
+	if summary.Findings == 0 {
+		return nil
+	}
+	summary.CWE = sortSecurityGroups(cwe)
+	summary.OWASP = sortSecurityGroups(owasp)
+	return summary
+}
+
+func countSecurityFinding(groups map[string]*reports.SecurityGroup, id, name string, r *model.RuleFunctionResult) {
+	group, ok := groups[id]
+	if !ok {
+		group = &reports.SecurityGroup{Id: id, Name: name}
+		groups[id] = group
+	}
+	group.Findings++
+	switch r.Rule.Severity {
+	case model.SeverityError:
+		group.Errors++
+	case model.SeverityWarn:
+		group.Warnings++
+	default:
+		group.Info++
+	}
+	if !slices.Contains(group.Rules, r.Rule.Id) {
+		group.Rules = append(group.Rules, r.Rule.Id)
+	}
+}
+
+// sortSecurityGroups orders groups by the number of findings (the most first), then by id.
+func sortSecurityGroups(groups map[string]*reports.SecurityGroup) []*reports.SecurityGroup {
+	sorted := make([]*reports.SecurityGroup, 0, len(groups))
+	for _, g := range groups {
+		sort.Strings(g.Rules)
+		sorted = append(sorted, g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Findings != sorted[j].Findings {
+			return sorted[i].Findings > sorted[j].Findings
+		}
+		return sorted[i].Id < sorted[j].Id
+	})
+	return sorted
+}
@@ -0,0 +1,43 @@
+package statistics
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateSecuritySummary(t *testing.T) {
+	basic := &model.Rule{Id: "no-http-basic", Severity: model.SeverityError,
+		CWE: []string{"CWE-522"}, OWASP: []string{"API2:2023"}}
+	keys := &model.Rule{Id: "no-api-keys-in-url", Severity: model.SeverityWarn,
+		CWE: []string{"CWE-598"}, OWASP: []string{"API2:2023"}}
+	style := &model.Rule{Id: "paths-kebab-case", Severity: model.SeverityWarn}
+
+	summary := CreateSecuritySummary([]*model.RuleFunctionResult{
+		{Rule: basic}, {Rule: basic}, {Rule: keys}, {Rule: style},
+	})
+	assert.Equal(t, 3, summary.Findings)
+
+	assert.Len(t, summary.OWASP, 1)
+	owasp := summary.OWASP[0]
+	assert.Equal(t, "API2:2023", owasp.Id)
+	assert.Equal(t, "Broken Authentication", owasp.Name)
+	assert.Equal(t, 3, owasp.Findings)
+	assert.Equal(t, 2, owasp.Errors)
+	assert.Equal(t, 1, owasp.Warnings)
+	assert.Equal(t, []string{"no-api-keys-in-url", "no-http-basic"}, owasp.Rules)
+
+	// the most findings first.
+	assert.Len(t, summary.CWE, 2)
+	assert.Equal(t, "CWE-522", summary.CWE[0].Id)
+	assert.Equal(t, 2, summary.CWE[0].Findings)
+	assert.Equal(t, "CWE-598", summary.CWE[1].Id)
+	assert.Equal(t, "Use of GET Request Method With Sensitive Query Strings", summary.CWE[1].Name)
+}
+
+func TestCreateSecuritySummary_NoSecurityFindings(t *testing.T) {
+	assert.Nil(t, CreateSecuritySummary([]*model.RuleFunctionResult{
+		{Rule: &model.Rule{Id: "paths-kebab-case"}}, {Message: "no rule"},
+	}))
+}
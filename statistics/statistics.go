@@ -1,15 +1,27 @@
 package statistics
 
 import (
+	"slices"
+	"sort"
+
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/utils"
 	"github.com/pb33f/libopenapi/datamodel"
 	"github.com/pb33f/libopenapi/index"
+	"gopkg.in/yaml.v3"
 )
 
 // CreateReportStatistics generates a ready to render breakdown of the document's statistics. A convenience function
 // that reduces churn on building stats over and over for different interfaces.
 func CreateReportStatistics(index *index.SpecIndex, info *datamodel.SpecInfo, results *model.RuleResultSet) *reports.ReportStatistics {
+	return CreateReportStatisticsWithScoring(index, info, results, nil)
+}
+
+// CreateReportStatisticsWithScoring is CreateReportStatistics, with the scores calculated by a scoring (nil is the
+// default scoring). The scoring is recorded in the statistics.
+func CreateReportStatisticsWithScoring(index *index.SpecIndex, info *datamodel.SpecInfo, results *model.RuleResultSet,
+	scoring *reports.Scoring) *reports.ReportStatistics {
 
 	// don't go looking for stats if we don't have the necessary data
 	if index == nil || info == nil || results == nil {
@@ -19,21 +31,68 @@ func CreateReportStatistics(index *index.SpecIndex, info *datamodel.SpecInfo, re
 	opPCount := index.GetOperationsParameterCount()
 	cPCount := index.GetComponentParameterCount()
 
+	kind := model.DocumentKindForFormat(info.SpecFormat)
+
+	if scoring == nil {
+		scoring = DefaultScoring()
+	}
+	score := CalculateQualityScoreWithScoring(results, scoring)
+	catStats := CreateCategoryStatisticsWithScoring(kind, results, scoring)
+	var opStats []*reports.OperationStatistic
+	if kind == model.DocumentKindOpenAPI {
+		opStats = createOperationStatistics(index, results, scoring)
+	}
+
+	stats := &reports.ReportStatistics{
+		FilesizeBytes:       len(*info.SpecBytes),
+		FilesizeKB:          len(*info.SpecBytes) / 1024,
+		SpecType:            info.SpecType,
+		SpecFormat:          info.SpecFormat,
+		DocumentKind:        string(kind),
+		Version:             info.Version,
+		References:          len(index.GetMappedReferences()),
+		ExternalDocs:        len(index.GetAllExternalDocuments()),
+		Schemas:             len(index.GetAllSchemas()),
+		Parameters:          opPCount + cPCount,
+		Links:               len(index.GetAllLinks()),
+		Paths:               index.GetPathCount(),
+		Operations:          index.GetOperationCount(),
+		Tags:                index.GetTotalTagsCount(),
+		Examples:            len(index.GetAllExamples()),
+		Enums:               len(index.GetAllEnums()),
+		Security:            len(index.GetAllSecuritySchemes()),
+		OverallScore:        score,
+		TotalErrors:         results.GetErrorCount(),
+		TotalWarnings:       results.GetWarnCount(),
+		TotalInfo:           results.GetInfoCount(),
+		CategoryStatistics:  catStats,
+		OperationStatistics: opStats,
+		SecuritySummary:     CreateSecuritySummary(results.Results),
+		RuleStatistics:      CreateRuleStatistics(results, scoring),
+		Scoring:             scoring,
+	}
+	return stats
+}
+
+// CreateCategoryStatistics breaks down the results by each rule category of a document kind.
+func CreateCategoryStatistics(kind model.DocumentKind, results *model.RuleResultSet) []*reports.CategoryStatistic {
+	return CreateCategoryStatisticsWithScoring(kind, results, nil)
+}
+
+// CreateCategoryStatisticsWithScoring is CreateCategoryStatistics, with the score of every category calculated by a
+// scoring (nil is the default scoring), from the results of the category.
+func CreateCategoryStatisticsWithScoring(kind model.DocumentKind, results *model.RuleResultSet,
+	scoring *reports.Scoring) []*reports.CategoryStatistic {
 	var catStats []*reports.CategoryStatistic
-	for _, cat := range model.RuleCategoriesOrdered {
+	for _, cat := range kind.RuleCategoriesWith(results.GetRuleCategories()) {
 		var numIssues, numWarnings, numErrors, numInfo, numHints int
-		numIssues = len(results.GetResultsByRuleCategory(cat.Id))
+		categoryResults := results.GetResultsByRuleCategory(cat.Id)
+		numIssues = len(categoryResults)
 		numWarnings = len(results.GetWarningsByRuleCategory(cat.Id))
 		numErrors = len(results.GetErrorsByRuleCategory(cat.Id))
 		numInfo = len(results.GetInfoByRuleCategory(cat.Id))
 		numHints = len(results.GetHintByRuleCategory(cat.Id))
-		numResults := len(results.Results)
-		var score int
-		if numResults == 0 && numIssues == 0 {
-			score = 100 // perfect
-		} else {
-			score = numIssues / numResults * 100
-		}
+		score := CalculateScore(categoryResults, scoring)
 		catStats = append(catStats, &reports.CategoryStatistic{
 			CategoryName: cat.Name,
 			CategoryId:   cat.Id,
@@ -45,11 +104,18 @@ func CreateReportStatistics(index *index.SpecIndex, info *datamodel.SpecInfo, re
 			Score:        score,
 		})
 	}
+	return catStats
+}
+
+// CalculateQualityScore works out the overall quality score (out of 100) for a set of results.
+func CalculateQualityScore(results *model.RuleResultSet) int {
+	return CalculateQualityScoreWithScoring(results, nil)
+}
 
-	total := 100.0
-	score := total - float64(results.GetInfoCount())*0.1
-	score = score - (0.4 * float64(results.GetWarnCount()))
-	score = score - (15.0 * float64(results.GetErrorCount())) // errors are failures they should be judged harshly.
+// CalculateQualityScoreWithScoring is CalculateQualityScore, with the weights of a scoring (nil is the default
+// scoring).
+func CalculateQualityScoreWithScoring(results *model.RuleResultSet, scoring *reports.Scoring) int {
+	score := deduct(100.0, results.Results, scoring)
 
 	if results.GetErrorCount() <= 0 && score < 0 {
 		// floor at 25% if there are no errors, but a ton of warnings lowering the score
@@ -66,29 +132,83 @@ func CreateReportStatistics(index *index.SpecIndex, info *datamodel.SpecInfo, re
 	if score < 0 {
 		score = 10 // the lowest score we want to present can't be 0, there has to be some hope!
 	}
+	return int(score)
+}
 
-	stats := &reports.ReportStatistics{
-		FilesizeBytes:      len(*info.SpecBytes),
-		FilesizeKB:         len(*info.SpecBytes) / 1024,
-		SpecType:           info.SpecType,
-		SpecFormat:         info.SpecFormat,
-		Version:            info.Version,
-		References:         len(index.GetMappedReferences()),
-		ExternalDocs:       len(index.GetAllExternalDocuments()),
-		Schemas:            len(index.GetAllSchemas()),
-		Parameters:         opPCount + cPCount,
-		Links:              len(index.GetAllLinks()),
-		Paths:              index.GetPathCount(),
-		Operations:         index.GetOperationCount(),
-		Tags:               index.GetTotalTagsCount(),
-		Examples:           len(index.GetAllExamples()),
-		Enums:              len(index.GetAllEnums()),
-		Security:           len(index.GetAllSecuritySchemes()),
-		OverallScore:       int(score),
-		TotalErrors:        results.GetErrorCount(),
-		TotalWarnings:      results.GetWarnCount(),
-		TotalInfo:          results.GetInfoCount(),
-		CategoryStatistics: catStats,
+// operationMethods are the methods of a path item that are operations, in the order they are listed.
+var operationMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace", "query"}
+
+// CreateOperationStatistics breaks down the results by each operation (path and method) in the document, so the
+// operations dragging the score down can be found. Results are attributed to an operation by their path. Every
+// operation in the index is included, even without any results. Operations are sorted by path, then method.
+func CreateOperationStatistics(index *index.SpecIndex, results *model.RuleResultSet) []*reports.OperationStatistic {
+	return createOperationStatistics(index, results, nil)
+}
+
+func createOperationStatistics(index *index.SpecIndex, results *model.RuleResultSet,
+	scoring *reports.Scoring) []*reports.OperationStatistic {
+	type operation struct {
+		path, method, operationId string
+		results                   []*model.RuleFunctionResult
 	}
-	return stats
+	operations := make(map[string]*operation)
+	key := func(path, method string) string { return method + " " + path }
+	if index != nil {
+		for path, methods := range index.GetAllPaths() {
+			for method, ref := range methods {
+				op := &operation{path: path, method: method}
+				if ref != nil && ref.Node != nil {
+					op.operationId = operationId(ref.Node)
+				}
+				operations[key(path, method)] = op
+			}
+		}
+	}
+	if results != nil {
+		for _, r := range results.Results {
+			segments := utils.JSONPathSegments(r.Path)
+			if len(segments) < 3 || segments[0] != "paths" || !slices.Contains(operationMethods, segments[2]) {
+				continue
+			}
+			op := operations[key(segments[1], segments[2])]
+			if op == nil {
+				op = &operation{path: segments[1], method: segments[2]}
+				operations[key(segments[1], segments[2])] = op
+			}
+			op.results = append(op.results, r)
+		}
+	}
+
+	var opStats []*reports.OperationStatistic
+	for _, op := range operations {
+		rs := model.NewRuleResultSetPointer(op.results)
+		opStats = append(opStats, &reports.OperationStatistic{
+			Path:        op.path,
+			Method:      op.method,
+			OperationId: op.operationId,
+			NumIssues:   len(op.results),
+			Score:       CalculateQualityScoreWithScoring(rs, scoring),
+			Warnings:    rs.GetWarnCount(),
+			Errors:      rs.GetErrorCount(),
+			Info:        rs.GetInfoCount(),
+			Hints:       countHints(op.results),
+		})
+	}
+	sort.Slice(opStats, func(i, j int) bool {
+		if opStats[i].Path != opStats[j].Path {
+			return opStats[i].Path < opStats[j].Path
+		}
+		return slices.Index(operationMethods, opStats[i].Method) < slices.Index(operationMethods, opStats[j].Method)
+	})
+	return opStats
+}
+
+// operationId returns the operationId of an operation node, if it has one.
+func operationId(node *yaml.Node) string {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == "operationId" {
+			return node.Content[i+1].Value
+		}
+	}
+	return ""
 }
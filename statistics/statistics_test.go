@@ -30,6 +30,26 @@ func TestCreateReportStatistics(t *testing.T) {
 	assert.Equal(t, 9, stats.Parameters)
 }
 
+func TestCreateReportStatistics_JSONSchema(t *testing.T) {
+
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	specBytes, _ := os.ReadFile("../model/test_files/json-schema-pets.json")
+
+	ruleset := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet:      defaultRuleSets.GenerateJSONSchemaRuleSet(),
+		Spec:         specBytes,
+		DocumentKind: model.DocumentKindJSONSchema,
+	})
+
+	resultSet := model.NewRuleResultSet(ruleset.Results)
+	stats := CreateReportStatistics(ruleset.Index, ruleset.SpecInfo, resultSet)
+
+	assert.Equal(t, string(model.DocumentKindJSONSchema), stats.DocumentKind)
+	assert.Len(t, stats.CategoryStatistics, len(model.DocumentKindJSONSchema.RuleCategories()))
+	assert.Equal(t, model.CategorySchemas, stats.CategoryStatistics[0].CategoryId)
+	assert.Equal(t, 2, stats.CategoryStatistics[0].NumIssues)
+}
+
 func TestCreateReportStatistics_AlmostPerfect(t *testing.T) {
 
 	defaultRuleSets := rulesets.BuildDefaultRuleSets()
@@ -80,3 +100,59 @@ func TestCreateReportStatistics_BigLoadOfIssues(t *testing.T) {
 		break
 	}
 }
+
+func TestCreateReportStatistics_Operations(t *testing.T) {
+
+	defaultRuleSets := rulesets.BuildDefaultRuleSets()
+	selectedRS := defaultRuleSets.GenerateOpenAPIRecommendedRuleSet()
+	specBytes, _ := os.ReadFile("../model/test_files/petstorev3.json")
+
+	ruleset := motor.ApplyRulesToRuleSet(&motor.RuleSetExecution{
+		RuleSet: selectedRS,
+		Spec:    specBytes,
+	})
+
+	resultSet := model.NewRuleResultSet(ruleset.Results)
+	stats := CreateReportStatistics(ruleset.Index, ruleset.SpecInfo, resultSet)
+
+	assert.Len(t, stats.OperationStatistics, stats.Operations)
+	first := stats.OperationStatistics[0]
+	assert.Equal(t, "/pet", first.Path)
+	assert.Equal(t, "put", first.Method)
+	assert.Equal(t, "updatePet", first.OperationId)
+	assert.Equal(t, "post", stats.OperationStatistics[1].Method)
+
+	total := 0
+	for _, op := range stats.OperationStatistics {
+		assert.Equal(t, op.NumIssues, op.Errors+op.Warnings+op.Info+op.Hints)
+		assert.LessOrEqual(t, op.Score, 100)
+		total += op.NumIssues
+	}
+	assert.Greater(t, total, 0)
+	assert.Less(t, total, len(resultSet.Results))
+}
+
+func TestCreateOperationStatistics(t *testing.T) {
+	warn := &model.Rule{Id: "operation-tags", Severity: model.SeverityWarn}
+	errRule := &model.Rule{Id: "operation-success-response", Severity: model.SeverityError}
+	resultSet := model.NewRuleResultSet([]model.RuleFunctionResult{
+		{Path: "$.paths['/burgers'].get.tags", Rule: warn},
+		{Path: "$.paths['/burgers'].get.responses", Rule: errRule},
+		{Path: "$.paths['/burgers'].post", Rule: warn},
+		{Path: "$.paths['/burgers'].parameters[0]", Rule: warn},
+		{Path: "$.info.contact", Rule: warn},
+	})
+
+	// without an index, operations come from the results.
+	opStats := CreateOperationStatistics(nil, resultSet)
+	assert.Len(t, opStats, 2)
+	assert.Equal(t, "get", opStats[0].Method)
+	assert.Equal(t, 2, opStats[0].NumIssues)
+	assert.Equal(t, 1, opStats[0].Errors)
+	assert.Equal(t, 1, opStats[0].Warnings)
+	assert.Equal(t, 84, opStats[0].Score)
+	assert.Equal(t, "post", opStats[1].Method)
+	assert.Equal(t, 99, opStats[1].Score)
+
+	assert.Empty(t, CreateOperationStatistics(nil, nil))
+}
@@ -0,0 +1,84 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// BuildBaseline creates a baseline from a set of results.
+func BuildBaseline(results []*model.RuleFunctionResult) *model.Baseline {
+	baseline := &model.Baseline{
+		Generated: time.Now(),
+		Results:   make([]*model.BaselineResult, 0, len(results)),
+	}
+	for _, r := range results {
+		ruleId := r.RuleId
+		if r.Rule != nil {
+			ruleId = r.Rule.Id
+		}
+		baseline.Results = append(baseline.Results, &model.BaselineResult{
+			Fingerprint: model.BaselineFingerprint(r),
+			RuleId:      ruleId,
+			Path:        r.Path,
+		})
+	}
+	return baseline
+}
+
+// ReadBaselineFile reads a baseline previously written by the baseline command.
+func ReadBaselineFile(path string) (*model.Baseline, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+	var baseline model.Baseline
+	if err = json.Unmarshal(raw, &baseline); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file: %w", err)
+	}
+	return &baseline, nil
+}
+
+// FilterBaselineResultsPtr removes any results that exist in the baseline. Fingerprints are counted, so if the
+// baseline recorded a finding twice and it now occurs three times, the third occurrence is still reported.
+func FilterBaselineResultsPtr(results []*model.RuleFunctionResult, baseline *model.Baseline) []*model.RuleFunctionResult {
+	if baseline == nil || len(baseline.Results) == 0 {
+		return results
+	}
+	known := make(map[string]int, len(baseline.Results))
+	for _, b := range baseline.Results {
+		known[b.Fingerprint]++
+	}
+	var filteredResults []*model.RuleFunctionResult
+	for _, r := range results {
+		fp := model.BaselineFingerprint(r)
+		if known[fp] > 0 {
+			known[fp]--
+			continue
+		}
+		filteredResults = append(filteredResults, r)
+	}
+	return filteredResults
+}
+
+// FilterBaselineResults does the filtering of baseline results on non-pointer result elements
+func FilterBaselineResults(results []model.RuleFunctionResult, baseline *model.Baseline) []model.RuleFunctionResult {
+	if baseline == nil || len(baseline.Results) == 0 {
+		return results
+	}
+	resultsPtrs := make([]*model.RuleFunctionResult, 0, len(results))
+	for i := range results {
+		resultsPtrs = append(resultsPtrs, &results[i])
+	}
+	resultsFiltered := make([]model.RuleFunctionResult, 0, len(results))
+	for _, r := range FilterBaselineResultsPtr(resultsPtrs, baseline) {
+		resultsFiltered = append(resultsFiltered, *r)
+	}
+	return resultsFiltered
+}
@@ -0,0 +1,65 @@
+package utils
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildBaseline(t *testing.T) {
+	results := []*model.RuleFunctionResult{
+		{Message: "one", Path: "$.info", Rule: &model.Rule{Id: "info-description"}},
+	}
+	baseline := BuildBaseline(results)
+	assert.Len(t, baseline.Results, 1)
+	assert.Equal(t, "info-description", baseline.Results[0].RuleId)
+	assert.Equal(t, "$.info", baseline.Results[0].Path)
+	assert.Equal(t, model.BaselineFingerprint(results[0]), baseline.Results[0].Fingerprint)
+}
+
+func TestFilterBaselineResults(t *testing.T) {
+	existing := model.RuleFunctionResult{Message: "one", Path: "$.info", Rule: &model.Rule{Id: "info-description"}}
+	baseline := BuildBaseline([]*model.RuleFunctionResult{&existing})
+
+	moved := existing
+	moved.Range.Start.Line = 100
+	fresh := model.RuleFunctionResult{Message: "two", Path: "$.info.contact", Rule: &model.Rule{Id: "info-description"}}
+
+	filtered := FilterBaselineResults([]model.RuleFunctionResult{moved, fresh, existing}, baseline)
+	assert.Len(t, filtered, 2)
+	assert.Equal(t, "two", filtered[0].Message)
+	assert.Equal(t, "one", filtered[1].Message)
+}
+
+func TestFilterBaselineResults_IgnoresMessage(t *testing.T) {
+	existing := model.RuleFunctionResult{Message: "no description", Path: "$.info", Rule: &model.Rule{Id: "info-description"}}
+	baseline := BuildBaseline([]*model.RuleFunctionResult{&existing})
+
+	// the same finding, reported in another locale.
+	translated := existing
+	translated.Message = "pas de description"
+	assert.Empty(t, FilterBaselineResults([]model.RuleFunctionResult{translated}, baseline))
+}
+
+func TestFilterBaselineResults_NoBaseline(t *testing.T) {
+	results := []model.RuleFunctionResult{{Message: "one", Rule: &model.Rule{Id: "a"}}}
+	assert.Len(t, FilterBaselineResults(results, nil), 1)
+}
+
+func TestReadBaselineFile(t *testing.T) {
+	baseline := BuildBaseline([]*model.RuleFunctionResult{{Message: "one", Rule: &model.Rule{Id: "a"}}})
+	b, _ := json.Marshal(baseline)
+	f := filepath.Join(t.TempDir(), "baseline.json")
+	assert.NoError(t, os.WriteFile(f, b, 0664))
+
+	read, err := ReadBaselineFile(f)
+	assert.NoError(t, err)
+	assert.Equal(t, baseline.Results[0].Fingerprint, read.Results[0].Fingerprint)
+
+	_, err = ReadBaselineFile(filepath.Join(t.TempDir(), "nope.json"))
+	assert.Error(t, err)
+}
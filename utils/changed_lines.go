@@ -0,0 +1,182 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/daveshanley/vacuum/locator"
+	"github.com/daveshanley/vacuum/model"
+)
+
+var hunkHeader = regexp.MustCompile(`^@@ -\d+(?:,\d+)? \+(\d+)(?:,(\d+))? @@`)
+
+// ChangeSet holds the lines of a specification that have changed, so results can be limited to the parts of a
+// specification that have been touched.
+type ChangeSet struct {
+	lines map[int]bool
+	doc   *locator.Document
+}
+
+// GitChangedLines returns the lines of a file that have changed since a git ref (like origin/main), including
+// changes that have not been committed yet. A file that is not tracked by git is new, so every line has changed,
+// which is returned as a nil map.
+func GitChangedLines(ref, file string) (map[int]bool, error) {
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return nil, err
+	}
+	dir := filepath.Dir(abs)
+
+	tracked := exec.Command("git", "ls-files", "--error-unmatch", abs)
+	tracked.Dir = dir
+	if tracked.Run() != nil {
+		return nil, nil
+	}
+
+	diff := exec.Command("git", "diff", "--unified=0", "--no-color", "--no-ext-diff", ref, "--", abs)
+	diff.Dir = dir
+	var stderr bytes.Buffer
+	diff.Stderr = &stderr
+	out, err := diff.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to diff '%s' against '%s': %s", file, ref, strings.TrimSpace(stderr.String()))
+	}
+	return ParseChangedLines(string(out)), nil
+}
+
+// ParseChangedLines reads the changed lines (in the new version of the file) out of a unified diff. Lines either side
+// of a deletion are marked as changed, as that is where the removed content used to be.
+func ParseChangedLines(diff string) map[int]bool {
+	lines := make(map[int]bool)
+	for _, line := range strings.Split(diff, "\n") {
+		m := hunkHeader.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		start, _ := strconv.Atoi(m[1])
+		count := 1
+		if m[2] != "" {
+			count, _ = strconv.Atoi(m[2])
+		}
+		if count == 0 {
+			lines[start] = true
+			lines[start+1] = true
+			continue
+		}
+		for i := start; i < start+count; i++ {
+			lines[i] = true
+		}
+	}
+	return lines
+}
+
+// NewChangeSet creates a change set for a specification. A nil map of lines means every line has changed.
+func NewChangeSet(spec []byte, lines map[int]bool) *ChangeSet {
+	c := &ChangeSet{lines: lines}
+	c.doc, _ = locator.Parse(spec, "")
+	return c
+}
+
+// Contains returns true if a result intersects the change set. A result does if it has been reported on a changed
+// line, or if the node at its path (and everything below it) contains a changed line.
+func (c *ChangeSet) Contains(r *model.RuleFunctionResult) bool {
+	if c == nil || c.lines == nil {
+		return true
+	}
+	start, end := r.Range.Start.Line, r.Range.End.Line
+	if r.StartNode != nil {
+		start = r.StartNode.Line
+		end = start
+	}
+	if r.EndNode != nil && r.EndNode.Line > end {
+		end = r.EndNode.Line
+	}
+	if c.changed(start, end) {
+		return true
+	}
+	paths := append([]string{r.Path}, r.Paths...)
+	for _, path := range paths {
+		segments := JSONPathSegments(path)
+		// everything is below the root, so it would match every change.
+		if len(segments) == 0 {
+			continue
+		}
+		if loc, err := c.doc.Resolve(path); err == nil && c.changed(loc.Line, loc.EndLine) {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *ChangeSet) changed(first, last int) bool {
+	for line := first; line <= last; line++ {
+		if c.lines[line] {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterChangedResults removes every result that does not intersect the change set.
+func FilterChangedResults(results []model.RuleFunctionResult, changes *ChangeSet) []model.RuleFunctionResult {
+	if changes == nil || changes.lines == nil {
+		return results
+	}
+	filtered := make([]model.RuleFunctionResult, 0, len(results))
+	for i := range results {
+		if changes.Contains(&results[i]) {
+			filtered = append(filtered, results[i])
+		}
+	}
+	return filtered
+}
+
+// GitHeadSHA returns the commit SHA checked out in the git repository that contains a directory, or an empty string
+// if the directory is not in a git repository.
+func GitHeadSHA(dir string) string {
+	rev := exec.Command("git", "rev-parse", "HEAD")
+	rev.Dir = dir
+	out, err := rev.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// GitStagedFiles returns the files staged for the next commit in the repository dir is in, that have been added,
+// copied, modified or renamed (deleted files can't be linted). Paths are absolute.
+func GitStagedFiles(dir string) ([]string, error) {
+	top := exec.Command("git", "rev-parse", "--show-toplevel")
+	top.Dir = dir
+	var stderr bytes.Buffer
+	top.Stderr = &stderr
+	out, err := top.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to find the git repository: %s", strings.TrimSpace(stderr.String()))
+	}
+	root := strings.TrimSpace(string(out))
+
+	staged := exec.Command("git", "diff", "--cached", "--name-only", "--diff-filter=ACMR", "-z")
+	staged.Dir = root
+	stderr.Reset()
+	staged.Stderr = &stderr
+	out, err = staged.Output()
+	if err != nil {
+		return nil, fmt.Errorf("unable to list staged files: %s", strings.TrimSpace(stderr.String()))
+	}
+	var files []string
+	for _, name := range strings.Split(string(out), "\x00") {
+		if name != "" {
+			files = append(files, filepath.Join(root, filepath.FromSlash(name)))
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,140 @@
+package utils
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+const changedSpec = `openapi: 3.1.0
+info:
+  title: pets
+paths:
+  /pets:
+    get:
+      summary: list pets
+      description: |
+        all of the
+        pets
+  /toys:
+    get:
+      summary: list toys
+`
+
+func TestParseChangedLines(t *testing.T) {
+	diff := `diff --git a/openapi.yaml b/openapi.yaml
+--- a/openapi.yaml
++++ b/openapi.yaml
+@@ -3 +3 @@ info:
+-  title: dogs
++  title: pets
+@@ -7,0 +8,2 @@ paths:
++      description: |
++        all of the
+@@ -12,2 +13,0 @@ paths:
+`
+	assert.Equal(t, map[int]bool{3: true, 8: true, 9: true, 13: true, 14: true}, ParseChangedLines(diff))
+	assert.Empty(t, ParseChangedLines(""))
+}
+
+func TestChangeSet_Contains(t *testing.T) {
+	changes := NewChangeSet([]byte(changedSpec), map[int]bool{10: true})
+
+	// the change is below the operation, and inside the description.
+	assert.True(t, changes.Contains(&model.RuleFunctionResult{Path: "$.paths['/pets'].get", StartNode: &yaml.Node{Line: 6}}))
+	assert.True(t, changes.Contains(&model.RuleFunctionResult{Path: "$.paths['/pets'].get.description"}))
+	assert.True(t, changes.Contains(&model.RuleFunctionResult{Path: "$", StartNode: &yaml.Node{Line: 10}}))
+
+	assert.False(t, changes.Contains(&model.RuleFunctionResult{Path: "$.paths['/toys'].get", StartNode: &yaml.Node{Line: 12}}))
+	assert.False(t, changes.Contains(&model.RuleFunctionResult{Path: "$.paths['/pets'].get.summary"}))
+	assert.False(t, changes.Contains(&model.RuleFunctionResult{Path: "$", StartNode: &yaml.Node{Line: 1}}))
+	assert.False(t, changes.Contains(&model.RuleFunctionResult{Path: "$.paths['/nope']"}))
+
+	// every line of a new file has changed.
+	assert.True(t, NewChangeSet([]byte(changedSpec), nil).Contains(&model.RuleFunctionResult{Path: "$.info"}))
+}
+
+func TestFilterChangedResults(t *testing.T) {
+	results := []model.RuleFunctionResult{
+		{Message: "one", Path: "$.info.title"},
+		{Message: "two", Path: "$.paths['/toys']"},
+	}
+	filtered := FilterChangedResults(results, NewChangeSet([]byte(changedSpec), map[int]bool{3: true}))
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "one", filtered[0].Message)
+	assert.Len(t, FilterChangedResults(results, nil), 2)
+}
+
+func TestGitChangedLines(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=vacuum", "-c", "user.email=vacuum@quobix.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+	spec := filepath.Join(dir, "openapi.yaml")
+	git("init", "-q")
+	assert.NoError(t, os.WriteFile(spec, []byte("openapi: 3.1.0\ninfo:\n  title: dogs\n"), 0644))
+	git("add", ".")
+	git("commit", "-q", "-m", "first")
+	assert.Len(t, GitHeadSHA(dir), 40)
+	assert.Empty(t, GitHeadSHA(t.TempDir()))
+
+	assert.NoError(t, os.WriteFile(spec, []byte("openapi: 3.1.0\ninfo:\n  title: pets\n  version: 1\n"), 0644))
+	lines, err := GitChangedLines("HEAD", spec)
+	assert.NoError(t, err)
+	assert.Equal(t, map[int]bool{3: true, 4: true}, lines)
+
+	// untracked files are new.
+	untracked := filepath.Join(dir, "new.yaml")
+	assert.NoError(t, os.WriteFile(untracked, []byte("openapi: 3.1.0\n"), 0644))
+	lines, err = GitChangedLines("HEAD", untracked)
+	assert.NoError(t, err)
+	assert.Nil(t, lines)
+
+	_, err = GitChangedLines("no-such-ref", spec)
+	assert.ErrorContains(t, err, "unable to diff")
+}
+
+func TestGitStagedFiles(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=vacuum", "-c", "user.email=vacuum@quobix.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		assert.NoError(t, err, string(out))
+	}
+	git("init", "-q")
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "specs"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "gone.yaml"), []byte("openapi: 3.1.0\n"), 0644))
+	git("add", ".")
+	git("commit", "-q", "-m", "first")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "specs", "openapi.yaml"), []byte("openapi: 3.1.0\n"), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "unstaged.yaml"), []byte("openapi: 3.1.0\n"), 0644))
+	git("add", "specs/openapi.yaml")
+	git("rm", "-q", "gone.yaml")
+
+	// deleted and unstaged files are left out, paths are found from anywhere in the repository.
+	staged, err := GitStagedFiles(filepath.Join(dir, "specs"))
+	assert.NoError(t, err)
+	if assert.Len(t, staged, 1) {
+		assert.Equal(t, "openapi.yaml", filepath.Base(staged[0]))
+		assert.True(t, filepath.IsAbs(staged[0]))
+	}
+
+	_, err = GitStagedFiles(t.TempDir())
+	assert.ErrorContains(t, err, "unable to find the git repository")
+}
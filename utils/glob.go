@@ -0,0 +1,128 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// IsGlobPattern returns true if the path contains any glob meta characters.
+func IsGlobPattern(path string) bool {
+	return strings.ContainsAny(path, "*?[")
+}
+
+// ExpandGlob returns all files that match a glob pattern. It supports everything filepath.Match does, as well as
+// '**' to match any number of directories, e.g. 'specs/**/*.yaml'. Matches are sorted.
+func ExpandGlob(pattern string) ([]string, error) {
+	if !strings.Contains(pattern, "**") {
+		return filepath.Glob(pattern)
+	}
+
+	re, err := globToRegexp(filepath.ToSlash(pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	// walk from the deepest directory that contains no meta characters.
+	root := "."
+	parts := strings.Split(filepath.ToSlash(pattern), "/")
+	var static []string
+	for _, p := range parts[:len(parts)-1] {
+		if IsGlobPattern(p) {
+			break
+		}
+		static = append(static, p)
+	}
+	if len(static) > 0 {
+		root = strings.Join(static, "/")
+		if root == "" {
+			root = "/"
+		}
+	}
+
+	var matches []string
+	err = filepath.WalkDir(filepath.FromSlash(root), func(path string, d fs.DirEntry, wErr error) error {
+		if wErr != nil {
+			if os.IsPermission(wErr) {
+				return nil
+			}
+			return wErr
+		}
+		if d.IsDir() {
+			return nil
+		}
+		if re.MatchString(filepath.ToSlash(path)) {
+			matches = append(matches, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(matches)
+	return matches, nil
+}
+
+func globToRegexp(pattern string) (*regexp.Regexp, error) {
+	pattern = strings.TrimPrefix(pattern, "./")
+	var sb strings.Builder
+	sb.WriteString("^(\\./)?")
+	for i := 0; i < len(pattern); i++ {
+		c := pattern[i]
+		switch c {
+		case '*':
+			if i+1 < len(pattern) && pattern[i+1] == '*' {
+				i++
+				if i+1 < len(pattern) && pattern[i+1] == '/' {
+					// '**/' matches zero or more directories.
+					i++
+					sb.WriteString("(.*/)?")
+				} else {
+					sb.WriteString(".*")
+				}
+			} else {
+				sb.WriteString("[^/]*")
+			}
+		case '?':
+			sb.WriteString("[^/]")
+		case '[':
+			end := strings.IndexByte(pattern[i:], ']')
+			if end < 0 {
+				sb.WriteString("\\[")
+				continue
+			}
+			class := pattern[i+1 : i+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			sb.WriteString("[" + class + "]")
+			i += end
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	sb.WriteString("$")
+	return regexp.Compile(sb.String())
+}
+
+// MatchGlob returns true if a path matches a glob pattern, '**' matches any number of directories. Both are made
+// absolute first, so a relative pattern and path match regardless of how they are written.
+func MatchGlob(pattern, path string) bool {
+	if absPattern, err := filepath.Abs(pattern); err == nil {
+		pattern = absPattern
+	}
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
+	}
+	re, err := globToRegexp(filepath.ToSlash(pattern))
+	if err != nil {
+		return false
+	}
+	return re.MatchString(filepath.ToSlash(path))
+}
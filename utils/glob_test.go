@@ -0,0 +1,47 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpandGlob_DoubleStar(t *testing.T) {
+	dir := t.TempDir()
+	for _, f := range []string{"a.yaml", "one/b.yaml", "one/two/c.yaml", "one/two/d.json"} {
+		p := filepath.Join(dir, f)
+		assert.NoError(t, os.MkdirAll(filepath.Dir(p), 0775))
+		assert.NoError(t, os.WriteFile(p, []byte("openapi: 3.1.0"), 0664))
+	}
+
+	matches, err := ExpandGlob(filepath.Join(dir, "**", "*.yaml"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "a.yaml"),
+		filepath.Join(dir, "one", "b.yaml"),
+		filepath.Join(dir, "one", "two", "c.yaml"),
+	}, matches)
+
+	matches, err = ExpandGlob(filepath.Join(dir, "one", "**", "*.[jy]son"))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{filepath.Join(dir, "one", "two", "d.json")}, matches)
+}
+
+func TestExpandGlob_Simple(t *testing.T) {
+	matches, err := ExpandGlob("../model/test_files/burger*.yaml")
+	assert.NoError(t, err)
+	assert.Contains(t, matches, "../model/test_files/burgershop.openapi.yaml")
+}
+
+func TestIsGlobPattern(t *testing.T) {
+	assert.True(t, IsGlobPattern("specs/**/*.yaml"))
+	assert.False(t, IsGlobPattern("specs/api.yaml"))
+}
+
+func TestMatchGlob(t *testing.T) {
+	assert.True(t, MatchGlob("specs/legacy/**", "specs/legacy/old/api.yaml"))
+	assert.True(t, MatchGlob("./specs/*.yaml", "specs/api.yaml"))
+	assert.False(t, MatchGlob("specs/legacy/**", "specs/api.yaml"))
+}
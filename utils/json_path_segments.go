@@ -0,0 +1,12 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import "github.com/daveshanley/vacuum/locator"
+
+// JSONPathSegments splits a JSON path (as used in results) into segments, e.g. $.paths['/pets'].get.tags[0] is
+// paths, /pets, get, tags and 0. See locator.Segments.
+func JSONPathSegments(path string) []string {
+	return locator.Segments(path)
+}
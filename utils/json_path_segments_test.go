@@ -0,0 +1,13 @@
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJSONPathSegments(t *testing.T) {
+	assert.Equal(t, []string{"paths", "/pets", "get", "tags", "0"}, JSONPathSegments("$.paths['/pets'].get.tags[0]"))
+	assert.Equal(t, []string{"info", "contact"}, JSONPathSegments("$.info.contact"))
+	assert.Empty(t, JSONPathSegments("$"))
+}
@@ -5,6 +5,7 @@ package utils
 
 import (
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/daveshanley/vacuum/rulesets"
 	"log/slog"
 	"sync"
@@ -12,6 +13,7 @@ import (
 
 type LintFileRequest struct {
 	FileName                 string
+	SpecBytes                []byte
 	BaseFlag                 string
 	MultiFile                bool
 	Remote                   bool
@@ -23,8 +25,10 @@ type LintFileRequest struct {
 	ExtensionRefs            bool
 	AllResultsFlag           bool
 	FailSeverityFlag         string
+	MaxWarnings              int
 	CategoryFlag             string
 	SnippetsFlag             bool
+	CodeFrames               *model.CodeFrameOptions // the source around every result is captured when set.
 	ErrorsFlag               bool
 	TotalFiles               int
 	FileIndex                int
@@ -32,13 +36,41 @@ type LintFileRequest struct {
 	IgnoreArrayCircleRef     bool
 	IgnorePolymorphCircleRef bool
 	NoClip                   bool
+	GroupBy                  string            // render results in groups (by path, rule, severity or file), when set.
+	SortOrder                model.ResultOrder // the order results are sorted in, by line when empty.
+	MaxResults               int               // no more than this many results are rendered in the details view, when set.
+	Scoring                  *reports.Scoring  // how scores are calculated, the default scoring when nil.
 	IgnoredResults           model.IgnoredItems
+	Baseline                 *model.Baseline
+	ChangedSince             string        // git ref, only results in lines changed since the ref are reported.
+	ShowUnchanged            bool          // used with ChangedSince, report every result but only fail on changed lines.
+	ChangedLinesOnly         string        // git ref, only results in lines changed since the ref are annotated (github and gitlab formats).
+	LowMemory                bool          // results don't hold on to the document, for very large specifications.
+	CollapseRefs             bool          // identical results for a component referenced in many places are reported once.
+	ShowRuleTimings          bool          // render how long each rule took, and include the timings in the statistics.
+	VacuumIgnore             *VacuumIgnore // files and rules skipped when linting many files, nil if there is none.
 	DefaultRuleSets          rulesets.RuleSets
 	SelectedRS               *rulesets.RuleSet
+	AsyncAPIRuleSet          *rulesets.RuleSet // used instead of SelectedRS for AsyncAPI documents, when set.
+	DocumentKind             model.DocumentKind
 	Functions                map[string]model.RuleFunction
 	Lock                     *sync.Mutex
 	Logger                   *slog.Logger
 	PipelineOutput           bool
 	ShowRules                bool
 	HTTPClientConfig         HTTPClientConfig
+	Format                   string
+	MaxAnnotations           int
+	JUnitGroupBy             string
+	JUnitTemplate            string
+	JUnitFailOn              string
+	JUnitSeverityOutcomes    map[string]string
+	ReportTemplate           string // the Go template rendered by the template format.
+	HTMLBundle               string // the directory the html format writes a report for every file to.
+	AnnotationCount          map[string]int
+	Fix                      bool
+	DryRun                   bool
+	OnResults                func(fileName string, spec []byte, resultSet *model.RuleResultSet, stats *reports.ReportStatistics)
+	OnFullResults            func(fileName string, resultSet *model.RuleResultSet, stats *reports.ReportStatistics) // every result, before annotations are limited to changed lines.
+	WaitTurn                 func()                                                                                 // blocks until it's the turn of this file to print, so files print in order.
 }
@@ -0,0 +1,50 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// openAPIKey is a cheap check to avoid parsing documents that can't be OpenAPI documents.
+var openAPIKey = regexp.MustCompile(`["']?(openapi|swagger)["']?\s*:`)
+
+// IsOpenAPIDocument returns true if a YAML or JSON document is an OpenAPI (or Swagger) specification, it has an
+// 'openapi' or 'swagger' root key. Fragments that are only referenced by a specification are not specifications.
+func IsOpenAPIDocument(data []byte) bool {
+	if !openAPIKey.Match(data) {
+		return false
+	}
+	var root yaml.Node
+	if yaml.Unmarshal(data, &root) != nil || len(root.Content) == 0 {
+		return false
+	}
+	return IsOpenAPINode(root.Content[0])
+}
+
+// IsOpenAPINode returns true if the root node of a parsed document declares an OpenAPI or Swagger version.
+func IsOpenAPINode(node *yaml.Node) bool {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return false
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if key := node.Content[i].Value; key == "openapi" || key == "swagger" {
+			return node.Content[i+1].Kind == yaml.ScalarNode
+		}
+	}
+	return false
+}
+
+// HasSpecificationExtension returns true if a file could be a specification, going by its extension.
+func HasSpecificationExtension(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml", ".json":
+		return true
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsOpenAPIDocument(t *testing.T) {
+	assert.True(t, IsOpenAPIDocument([]byte("openapi: 3.1.0\ninfo:\n  title: burgers\n")))
+	assert.True(t, IsOpenAPIDocument([]byte("swagger: '2.0'\n")))
+	assert.True(t, IsOpenAPIDocument([]byte(`{"openapi": "3.0.3", "paths": {}}`)))
+
+	// fragments, other documents, and documents that only mention openapi are not specifications.
+	assert.False(t, IsOpenAPIDocument([]byte("Burger:\n  type: object\n")))
+	assert.False(t, IsOpenAPIDocument([]byte("asyncapi: 3.0.0\n")))
+	assert.False(t, IsOpenAPIDocument([]byte("info:\n  openapi: 3.1.0\n")))
+	assert.False(t, IsOpenAPIDocument([]byte("openapi:\n  version: 3\n")))
+	assert.False(t, IsOpenAPIDocument([]byte("- openapi: 3.1.0\n")))
+	assert.False(t, IsOpenAPIDocument([]byte("openapi: [3.1.0")))
+	assert.False(t, IsOpenAPIDocument(nil))
+}
+
+func TestHasSpecificationExtension(t *testing.T) {
+	assert.True(t, HasSpecificationExtension("specs/openapi.yaml"))
+	assert.True(t, HasSpecificationExtension("openapi.YML"))
+	assert.True(t, HasSpecificationExtension("openapi.json"))
+	assert.False(t, HasSpecificationExtension("README.md"))
+	assert.False(t, HasSpecificationExtension("Makefile"))
+}
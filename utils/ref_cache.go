@@ -0,0 +1,209 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// DefaultRefCacheTTL is how long a cached remote reference is used for, before checking if it has changed.
+const DefaultRefCacheTTL = time.Hour
+
+// RefCache keeps a copy of every remote document referenced ($ref) by a specification on disk, keyed by URL. A
+// cached document is used without a request until it is older than the TTL, then it is only downloaded again if it
+// has changed (using ETags). Linting the same specification again (watching, or in an editor) does not download the
+// same documents again, and again.
+type RefCache struct {
+	// Dir is where documents are cached, see DefaultRefCacheDir.
+	Dir string
+
+	// TTL is how long a cached document is used for, before checking if it has changed.
+	TTL time.Duration
+
+	// Refresh downloads every document again, ignoring what is cached.
+	Refresh bool
+
+	// Offline never touches the network, a document that is not cached is an error.
+	Offline bool
+}
+
+// refCacheEntry is what is known about a cached document.
+type refCacheEntry struct {
+	URL         string    `json:"url"`
+	ETag        string    `json:"etag,omitempty"`
+	ContentType string    `json:"contentType,omitempty"`
+	Fetched     time.Time `json:"fetched"`
+}
+
+var refCache *RefCache
+
+// SetRefCache configures the cache used when looking up remote references, nil turns caching off.
+func SetRefCache(cache *RefCache) {
+	refCache = cache
+}
+
+// GetRefCache returns the cache used when looking up remote references, or nil if there isn't one.
+func GetRefCache() *RefCache {
+	return refCache
+}
+
+// DefaultRefCacheDir returns the directory remote references are cached in (~/.cache/vacuum/refs on Linux).
+func DefaultRefCacheDir() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "vacuum", "refs")
+}
+
+// Handler returns a RemoteURLHandler (for libopenapi) that looks up remote documents through the cache, documents
+// that are not cached (or have expired) are downloaded with the client (the default client if nil).
+func (c *RefCache) Handler(client *http.Client) func(url string) (*http.Response, error) {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(url string) (*http.Response, error) {
+		return c.Fetch(client, url)
+	}
+}
+
+// Fetch returns a remote document, from the cache if it has not expired (or has not changed).
+func (c *RefCache) Fetch(client *http.Client, url string) (*http.Response, error) {
+	bodyPath, entryPath := c.paths(url)
+	entry, cached := c.read(entryPath, bodyPath)
+
+	if c.Offline {
+		if cached == nil {
+			return nil, fmt.Errorf("remote reference '%s' is not cached, cannot run offline", url)
+		}
+		return cachedResponse(entry, cached), nil
+	}
+	if cached != nil && !c.Refresh && time.Since(entry.Fetched) < c.TTL {
+		return cachedResponse(entry, cached), nil
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", url, err)
+	}
+	if cached != nil && !c.Refresh && entry.ETag != "" {
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		// the network is down, the cached copy is better than nothing.
+		if cached != nil {
+			return cachedResponse(entry, cached), nil
+		}
+		return nil, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && cached != nil {
+		_ = resp.Body.Close()
+		entry.Fetched = time.Now()
+		c.write(entryPath, bodyPath, entry, nil)
+		return cachedResponse(entry, cached), nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	c.write(entryPath, bodyPath, &refCacheEntry{
+		URL:         url,
+		ETag:        resp.Header.Get("ETag"),
+		ContentType: resp.Header.Get("Content-Type"),
+		Fetched:     time.Now(),
+	}, body)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// read returns a cached document, the content is nil if it is not cached.
+func (c *RefCache) read(entryPath, bodyPath string) (*refCacheEntry, []byte) {
+	data, err := os.ReadFile(entryPath)
+	if err != nil {
+		return nil, nil
+	}
+	entry := &refCacheEntry{}
+	if json.Unmarshal(data, entry) != nil {
+		return nil, nil
+	}
+	body, err := os.ReadFile(bodyPath)
+	if err != nil {
+		return nil, nil
+	}
+	return entry, body
+}
+
+// write caches a document, the content is not written again if it is nil. A cache that can't be written is not a
+// reason to fail. Files are renamed into place, documents are fetched by many goroutines at once.
+func (c *RefCache) write(entryPath, bodyPath string, entry *refCacheEntry, body []byte) {
+	if os.MkdirAll(c.Dir, 0755) != nil {
+		return
+	}
+	if body != nil && writeFileAtomically(bodyPath, body) != nil {
+		return
+	}
+	if data, err := json.Marshal(entry); err == nil {
+		_ = writeFileAtomically(entryPath, data)
+	}
+}
+
+// paths returns the paths of the cached content and what is known about it, for a URL.
+func (c *RefCache) paths(url string) (string, string) {
+	sum := sha256.Sum256([]byte(url))
+	name := hex.EncodeToString(sum[:])
+	return filepath.Join(c.Dir, name+".body"), filepath.Join(c.Dir, name+".json")
+}
+
+func cachedResponse(entry *refCacheEntry, body []byte) *http.Response {
+	header := make(http.Header)
+	if entry.ContentType != "" {
+		header.Set("Content-Type", entry.ContentType)
+	}
+	if entry.ETag != "" {
+		header.Set("ETag", entry.ETag)
+	}
+	return &http.Response{
+		Status:        "200 OK",
+		StatusCode:    http.StatusOK,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+	}
+}
+
+func writeFileAtomically(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	if _, err = tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
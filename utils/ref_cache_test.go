@@ -0,0 +1,137 @@
+package utils
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func refCacheTestServer(t *testing.T) (*httptest.Server, *atomic.Int32, *atomic.Int32) {
+	var downloads, notModified atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/missing.yaml" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			notModified.Add(1)
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		downloads.Add(1)
+		w.Header().Set("ETag", `"v1"`)
+		w.Header().Set("Content-Type", "application/yaml")
+		_, _ = w.Write([]byte("type: string"))
+	}))
+	t.Cleanup(server.Close)
+	return server, &downloads, &notModified
+}
+
+func readRefCacheBody(t *testing.T, resp *http.Response, err error) string {
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	body, _ := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	return string(body)
+}
+
+func TestRefCache_Fetch_WithinTTL(t *testing.T) {
+	server, downloads, _ := refCacheTestServer(t)
+	cache := &RefCache{Dir: t.TempDir(), TTL: time.Hour}
+	handler := cache.Handler(nil)
+
+	for i := 0; i < 3; i++ {
+		resp, err := handler(server.URL + "/schema.yaml")
+		assert.Equal(t, "type: string", readRefCacheBody(t, resp, err))
+	}
+	assert.Equal(t, int32(1), downloads.Load())
+
+	resp, err := handler(server.URL + "/schema.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, "application/yaml", resp.Header.Get("Content-Type"))
+}
+
+func TestRefCache_Fetch_Expired(t *testing.T) {
+	server, downloads, notModified := refCacheTestServer(t)
+	cache := &RefCache{Dir: t.TempDir(), TTL: time.Nanosecond}
+
+	resp, err := cache.Fetch(http.DefaultClient, server.URL+"/schema.yaml")
+	assert.Equal(t, "type: string", readRefCacheBody(t, resp, err))
+	time.Sleep(time.Millisecond)
+
+	// expired, but not changed.
+	resp, err = cache.Fetch(http.DefaultClient, server.URL+"/schema.yaml")
+	assert.Equal(t, "type: string", readRefCacheBody(t, resp, err))
+	assert.Equal(t, int32(1), downloads.Load())
+	assert.Equal(t, int32(1), notModified.Load())
+}
+
+func TestRefCache_Fetch_Refresh(t *testing.T) {
+	server, downloads, notModified := refCacheTestServer(t)
+	dir := t.TempDir()
+
+	resp, err := (&RefCache{Dir: dir, TTL: time.Hour}).Fetch(http.DefaultClient, server.URL+"/schema.yaml")
+	assert.Equal(t, "type: string", readRefCacheBody(t, resp, err))
+
+	resp, err = (&RefCache{Dir: dir, TTL: time.Hour, Refresh: true}).Fetch(http.DefaultClient, server.URL+"/schema.yaml")
+	assert.Equal(t, "type: string", readRefCacheBody(t, resp, err))
+	assert.Equal(t, int32(2), downloads.Load())
+	assert.Equal(t, int32(0), notModified.Load())
+}
+
+func TestRefCache_Fetch_Offline(t *testing.T) {
+	server, downloads, _ := refCacheTestServer(t)
+	dir := t.TempDir()
+	offline := &RefCache{Dir: dir, Offline: true}
+
+	_, err := offline.Fetch(http.DefaultClient, server.URL+"/schema.yaml")
+	assert.ErrorContains(t, err, "is not cached, cannot run offline")
+
+	resp, err := (&RefCache{Dir: dir, TTL: time.Nanosecond}).Fetch(http.DefaultClient, server.URL+"/schema.yaml")
+	assert.Equal(t, "type: string", readRefCacheBody(t, resp, err))
+
+	// the TTL is ignored offline.
+	resp, err = offline.Fetch(http.DefaultClient, server.URL+"/schema.yaml")
+	assert.Equal(t, "type: string", readRefCacheBody(t, resp, err))
+	assert.Equal(t, int32(1), downloads.Load())
+}
+
+func TestRefCache_Fetch_NotFound(t *testing.T) {
+	server, _, _ := refCacheTestServer(t)
+	dir := t.TempDir()
+	cache := &RefCache{Dir: dir, TTL: time.Hour}
+
+	resp, err := cache.Fetch(http.DefaultClient, server.URL+"/missing.yaml")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// failures are not cached.
+	_, err = (&RefCache{Dir: dir, Offline: true}).Fetch(http.DefaultClient, server.URL+"/missing.yaml")
+	assert.Error(t, err)
+}
+
+func TestRefCache_Fetch_NetworkDown(t *testing.T) {
+	server, _, _ := refCacheTestServer(t)
+	url := server.URL + "/schema.yaml"
+	cache := &RefCache{Dir: t.TempDir(), TTL: time.Nanosecond}
+
+	resp, err := cache.Fetch(http.DefaultClient, url)
+	assert.Equal(t, "type: string", readRefCacheBody(t, resp, err))
+	server.Close()
+
+	resp, err = cache.Fetch(http.DefaultClient, url)
+	assert.Equal(t, "type: string", readRefCacheBody(t, resp, err))
+}
+
+func TestSetRefCache(t *testing.T) {
+	defer SetRefCache(nil)
+	cache := &RefCache{Dir: t.TempDir(), TTL: time.Hour}
+	SetRefCache(cache)
+	assert.Equal(t, cache, GetRefCache())
+	assert.Contains(t, DefaultRefCacheDir(), "refs")
+}
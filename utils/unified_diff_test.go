@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUnifiedDiff(t *testing.T) {
+	a := "one\ntwo\nthree\nfour\nfive\nsix\nseven\neight\nnine\nten\n"
+	b := "one\ntwo\nTHREE\nfour\nfive\nsix\nseven\neight\nnine\nten\neleven\n"
+
+	diff := UnifiedDiff("spec.yaml", "spec.yaml", []byte(a), []byte(b), 1)
+	assert.Equal(t, `--- spec.yaml
++++ spec.yaml
+@@ -2,3 +2,3 @@
+ two
+-three
++THREE
+ four
+@@ -10,1 +10,2 @@
+ ten
++eleven
+`, diff)
+}
+
+func TestUnifiedDiff_Same(t *testing.T) {
+	assert.Empty(t, UnifiedDiff("a", "b", []byte("same"), []byte("same"), 3))
+}
+
+func TestUnifiedDiff_MergedHunks(t *testing.T) {
+	a := "a\nb\nc\nd\ne\n"
+	b := "a\nB\nc\nD\ne\n"
+	diff := UnifiedDiff("x", "y", []byte(a), []byte(b), 1)
+	assert.Equal(t, 1, strings.Count(diff, "@@ "))
+	assert.Contains(t, diff, "@@ -1,5 +1,5 @@")
+}
+
+func TestUnifiedDiff_Large(t *testing.T) {
+	var a, b strings.Builder
+	for i := 0; i < 5000; i++ {
+		a.WriteString(fmt.Sprintf("line %d\n", i))
+		if i == 2500 {
+			b.WriteString("inserted\n")
+		}
+		b.WriteString(fmt.Sprintf("line %d\n", i))
+	}
+	diff := UnifiedDiff("x", "y", []byte(a.String()), []byte(b.String()), 3)
+	assert.Contains(t, diff, "@@ -2498,6 +2498,7 @@")
+	assert.Contains(t, diff, "\n+inserted\n")
+	assert.NotContains(t, diff, "\n-")
+}
@@ -0,0 +1,208 @@
+// Copyright 2025 Dave Shanley / Quobix
+// SPDX-License-Identifier: MIT
+
+package utils
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// VacuumIgnoreFileName is the name of an ignore file, discovered by searching from a directory upwards.
+const VacuumIgnoreFileName = ".vacuumignore"
+
+// VacuumIgnoreEntry is a line of an ignore file: a glob pattern for files, and optionally the rules that are not
+// reported for them. Without rules, the files are not linted at all.
+type VacuumIgnoreEntry struct {
+	Pattern string
+	Rules   []string
+	Negate  bool // a '!' pattern, files ignored by an earlier line are linted again.
+	Line    int
+}
+
+// VacuumIgnore is an ignore file that lists files (like .gitignore) and the rules to skip for them. Patterns are
+// relative to the directory of the ignore file. A pattern without a '/' matches a file (or directory) at any depth,
+// a pattern that names a directory matches everything in it.
+type VacuumIgnore struct {
+	Path    string
+	Dir     string
+	Entries []*VacuumIgnoreEntry
+}
+
+// FindVacuumIgnore looks for an ignore file in a directory and every parent, stopping at the root of a git
+// repository. Nil is returned if there isn't one.
+func FindVacuumIgnore(dir string) (*VacuumIgnore, error) {
+	if abs, err := filepath.Abs(dir); err == nil {
+		dir = abs
+	}
+	for {
+		candidate := filepath.Join(dir, VacuumIgnoreFileName)
+		if fi, err := os.Stat(candidate); err == nil && !fi.IsDir() {
+			return LoadVacuumIgnore(candidate)
+		}
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return nil, nil
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return nil, nil
+		}
+		dir = parent
+	}
+}
+
+// LoadVacuumIgnore reads an ignore file.
+func LoadVacuumIgnore(path string) (*VacuumIgnore, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read ignore file '%s': %w", path, err)
+	}
+	dir := filepath.Dir(path)
+	if abs, aErr := filepath.Abs(dir); aErr == nil {
+		dir = abs
+	}
+	vi, err := ParseVacuumIgnore(data, dir)
+	if err != nil {
+		return nil, fmt.Errorf("ignore file '%s' %w", path, err)
+	}
+	vi.Path = path
+	return vi, nil
+}
+
+// ParseVacuumIgnore parses the lines of an ignore file, with patterns relative to dir. Each line is a glob pattern,
+// optionally followed by the ids of rules (separated by spaces or commas). Blank lines and lines starting with '#'
+// are skipped.
+func ParseVacuumIgnore(data []byte, dir string) (*VacuumIgnore, error) {
+	vi := &VacuumIgnore{Dir: dir}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.FieldsFunc(text, func(r rune) bool {
+			return r == ' ' || r == '\t' || r == ','
+		})
+		entry := &VacuumIgnoreEntry{Pattern: fields[0], Rules: fields[1:], Line: line}
+		if strings.HasPrefix(entry.Pattern, "!") {
+			entry.Negate = true
+			entry.Pattern = entry.Pattern[1:]
+			if len(entry.Rules) > 0 {
+				return nil, fmt.Errorf("line %d: '!%s' can't list rules, it only lints files again", line, entry.Pattern)
+			}
+		}
+		if entry.Pattern == "" {
+			return nil, fmt.Errorf("line %d: there is no pattern", line)
+		}
+		vi.Entries = append(vi.Entries, entry)
+	}
+	return vi, scanner.Err()
+}
+
+// IgnoresFile returns true if a file is not linted at all. The last line a file matches decides.
+func (vi *VacuumIgnore) IgnoresFile(path string) bool {
+	if vi == nil {
+		return false
+	}
+	ignored := false
+	for _, e := range vi.Entries {
+		if len(e.Rules) == 0 && vi.matches(e, path) {
+			ignored = !e.Negate
+		}
+	}
+	return ignored
+}
+
+// IgnoredRules returns the ids of the rules that are not reported for a file.
+func (vi *VacuumIgnore) IgnoredRules(path string) []string {
+	if vi == nil {
+		return nil
+	}
+	var rules []string
+	for _, e := range vi.Entries {
+		if len(e.Rules) == 0 || !vi.matches(e, path) {
+			continue
+		}
+		for _, r := range e.Rules {
+			if !slices.Contains(rules, r) {
+				rules = append(rules, r)
+			}
+		}
+	}
+	return rules
+}
+
+// FilterFiles removes every (local) file that is not linted at all.
+func (vi *VacuumIgnore) FilterFiles(files []string) []string {
+	if vi == nil {
+		return files
+	}
+	var kept []string
+	for _, f := range files {
+		if isRemoteLocation(f) || !vi.IgnoresFile(f) {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// FilterResults removes results for the rules that are not reported for a file, and results in files that are not
+// linted at all (like referenced files). Results are for the file at path, unless they came from another file.
+func (vi *VacuumIgnore) FilterResults(path string, results []model.RuleFunctionResult) []model.RuleFunctionResult {
+	if vi == nil || len(vi.Entries) == 0 {
+		return results
+	}
+	type fileRules struct {
+		ignored bool
+		rules   []string
+	}
+	files := make(map[string]*fileRules)
+	filtered := make([]model.RuleFunctionResult, 0, len(results))
+	for _, r := range results {
+		file := path
+		if r.Origin != nil && r.Origin.AbsoluteLocation != "" && !isRemoteLocation(r.Origin.AbsoluteLocation) {
+			file = r.Origin.AbsoluteLocation
+		}
+		fr, ok := files[file]
+		if !ok {
+			fr = &fileRules{ignored: vi.IgnoresFile(file), rules: vi.IgnoredRules(file)}
+			files[file] = fr
+		}
+		ruleId := r.RuleId
+		if r.Rule != nil {
+			ruleId = r.Rule.Id
+		}
+		if fr.ignored || slices.Contains(fr.rules, ruleId) {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+	return filtered
+}
+
+func (vi *VacuumIgnore) matches(e *VacuumIgnoreEntry, path string) bool {
+	if path == "" {
+		return false
+	}
+	pattern := filepath.ToSlash(strings.TrimSuffix(e.Pattern, "/"))
+	if strings.HasPrefix(pattern, "/") {
+		pattern = strings.TrimPrefix(pattern, "/")
+	} else if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+	pattern = filepath.Join(vi.Dir, filepath.FromSlash(pattern))
+	return MatchGlob(pattern, path) || MatchGlob(filepath.Join(pattern, "**"), path)
+}
+
+func isRemoteLocation(location string) bool {
+	return strings.HasPrefix(location, "https://") || strings.HasPrefix(location, "http://")
+}
@@ -0,0 +1,85 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+)
+
+const testVacuumIgnore = `# generated specifications are not linted
+generated/
+*.gen.yaml
+!generated/keep.yaml
+
+# legacy specifications can't be changed
+/legacy/** operation-operationId, operation-tags
+`
+
+func TestParseVacuumIgnore(t *testing.T) {
+	vi, err := ParseVacuumIgnore([]byte(testVacuumIgnore), "/specs")
+	assert.NoError(t, err)
+	assert.Len(t, vi.Entries, 4)
+	assert.True(t, vi.Entries[2].Negate)
+	assert.Equal(t, []string{"operation-operationId", "operation-tags"}, vi.Entries[3].Rules)
+	assert.Equal(t, 7, vi.Entries[3].Line)
+}
+
+func TestParseVacuumIgnore_Invalid(t *testing.T) {
+	_, err := ParseVacuumIgnore([]byte("!legacy/** operation-tags"), "/specs")
+	assert.EqualError(t, err, "line 1: '!legacy/**' can't list rules, it only lints files again")
+}
+
+func TestVacuumIgnore_IgnoresFile(t *testing.T) {
+	vi, _ := ParseVacuumIgnore([]byte(testVacuumIgnore), "/specs")
+	assert.True(t, vi.IgnoresFile("/specs/generated/pets.yaml"))
+	assert.True(t, vi.IgnoresFile("/specs/generated/v1/pets.yaml"))
+	assert.False(t, vi.IgnoresFile("/specs/generated/keep.yaml"))
+	assert.True(t, vi.IgnoresFile("/specs/api/pets.gen.yaml"))
+	assert.False(t, vi.IgnoresFile("/specs/api/pets.yaml"))
+	assert.False(t, vi.IgnoresFile("/specs/legacy/pets.yaml"))
+	assert.False(t, vi.IgnoresFile("/other/generated/pets.yaml"))
+	assert.Equal(t, []string{"/specs/api/pets.yaml", "https://example.com/generated/pets.yaml"},
+		vi.FilterFiles([]string{"/specs/generated/pets.yaml", "/specs/api/pets.yaml",
+			"https://example.com/generated/pets.yaml"}))
+
+	var none *VacuumIgnore
+	assert.False(t, none.IgnoresFile("/specs/generated/pets.yaml"))
+}
+
+func TestVacuumIgnore_FilterResults(t *testing.T) {
+	vi, _ := ParseVacuumIgnore([]byte(testVacuumIgnore), "/specs")
+	assert.Equal(t, []string{"operation-operationId", "operation-tags"}, vi.IgnoredRules("/specs/legacy/v1/pets.yaml"))
+	assert.Empty(t, vi.IgnoredRules("/specs/legacy.yaml"))
+
+	results := []model.RuleFunctionResult{
+		{RuleId: "operation-tags"},
+		{RuleId: "info-contact"},
+		{RuleId: "info-contact", Origin: &index.NodeOrigin{AbsoluteLocation: "/specs/generated/shared.yaml"}},
+	}
+	filtered := vi.FilterResults("/specs/legacy/pets.yaml", results)
+	assert.Len(t, filtered, 1)
+	assert.Equal(t, "info-contact", filtered[0].RuleId)
+	assert.Nil(t, filtered[0].Origin)
+}
+
+func TestFindVacuumIgnore(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "specs", "v1"), 0o755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, VacuumIgnoreFileName), []byte(testVacuumIgnore), 0o644))
+
+	vi, err := FindVacuumIgnore(filepath.Join(dir, "specs", "v1"))
+	assert.NoError(t, err)
+	assert.NotNil(t, vi)
+	assert.Equal(t, filepath.Join(dir, VacuumIgnoreFileName), vi.Path)
+	assert.True(t, vi.IgnoresFile(filepath.Join(dir, "specs", "generated", "pets.yaml")))
+
+	// the search stops at the root of a repository.
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "specs", ".git"), 0o755))
+	vi, err = FindVacuumIgnore(filepath.Join(dir, "specs", "v1"))
+	assert.NoError(t, err)
+	assert.Nil(t, vi)
+}
@@ -0,0 +1,76 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+)
+
+// FileReport holds the results of linting a single specification, as part of a multi-file run.
+type FileReport struct {
+	FileName   string                    `json:"fileName" yaml:"fileName"`
+	Statistics *reports.ReportStatistics `json:"statistics,omitempty" yaml:"statistics,omitempty"`
+	ResultSet  *model.RuleResultSet      `json:"resultSet" yaml:"resultSet"`
+	Spec       []byte                    `json:"-" yaml:"-"` // optional, used to render code snippets.
+}
+
+// AggregatedReport is the combined result of linting many specifications in a single invocation, each
+// specification keeps its own result set and statistics.
+type AggregatedReport struct {
+	Generated time.Time     `json:"generated" yaml:"generated"`
+	Files     []*FileReport `json:"files" yaml:"files"`
+}
+
+// ResultSetForFile returns the result set for a file, or nil if the file is not part of the report.
+func (a *AggregatedReport) ResultSetForFile(fileName string) *model.RuleResultSet {
+	for _, f := range a.Files {
+		if f.FileName == fileName {
+			return f.ResultSet
+		}
+	}
+	return nil
+}
+
+// BuildAggregatedJSONReport renders every file in a multi-file run as a single JSON document.
+func BuildAggregatedJSONReport(files []*FileReport, generated time.Time) []byte {
+	if files == nil {
+		files = []*FileReport{}
+	}
+	data, err := json.MarshalIndent(&AggregatedReport{Generated: generated, Files: files}, "", "  ")
+	if err != nil {
+		return []byte{}
+	}
+	return data
+}
+
+type fileResult struct {
+	result   *model.RuleFunctionResult
+	fileName string
+}
+
+// collectFileResults flattens the results of every file (that belong to a rule) into a single slice, retaining
+// the file each result was found in.
+func collectFileResults(files []*FileReport) []fileResult {
+	var results []fileResult
+	for _, f := range files {
+		if f == nil || f.ResultSet == nil {
+			continue
+		}
+		for _, r := range f.ResultSet.Results {
+			if r.Rule == nil {
+				continue
+			}
+			results = append(results, fileResult{result: r, fileName: f.FileName})
+		}
+	}
+	return results
+}
+
+func singleFileReport(resultSet *model.RuleResultSet, fileName string) []*FileReport {
+	return []*FileReport{{FileName: fileName, ResultSet: resultSet}}
+}
@@ -0,0 +1,85 @@
+package vacuum_report
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildFakeFileReports() []*FileReport {
+	return []*FileReport{
+		{
+			FileName:   "one.yaml",
+			Statistics: &reports.ReportStatistics{OverallScore: 80},
+			ResultSet: buildFakeResultSet("no description", "$.info", "info-description",
+				model.SeverityWarn, model.CategoryInfo, "Info", "", 7),
+		},
+		{
+			FileName:   "two.yaml",
+			Statistics: &reports.ReportStatistics{OverallScore: 40},
+			ResultSet: buildFakeResultSet("bad <path>", "$.paths", "path-params",
+				model.SeverityError, model.CategoryOperations, "Operations", "", 20),
+		},
+		{
+			FileName:   "clean.yaml",
+			Statistics: &reports.ReportStatistics{OverallScore: 100},
+			ResultSet:  model.NewRuleResultSet(nil),
+		},
+	}
+}
+
+func TestBuildJUnitReportForFiles(t *testing.T) {
+	data := BuildJUnitReportForFiles(buildFakeFileReports(), time.Now(), nil)
+
+	var suites TestSuites
+	assert.NoError(t, xml.Unmarshal(data, &suites))
+	assert.Len(t, suites.TestSuites, 3)
+	assert.Equal(t, 2, suites.Tests)
+	assert.Equal(t, 2, suites.Failures)
+	assert.Equal(t, "OAS Linting - one.yaml", suites.TestSuites[0].Name)
+	assert.Equal(t, "OAS Linting - two.yaml", suites.TestSuites[1].Name)
+	assert.Equal(t, "OAS Linting - clean.yaml", suites.TestSuites[2].Name)
+	assert.Equal(t, 0, suites.TestSuites[2].Tests)
+	assert.Contains(t, suites.TestSuites[1].TestCases[0].Failure.Contents, "File: two.yaml")
+}
+
+func TestBuildSARIFReportForFiles(t *testing.T) {
+	data := BuildSARIFReportForFiles(buildFakeFileReports(), "1.0.0")
+
+	var report SARIFReport
+	assert.NoError(t, json.Unmarshal(data, &report))
+	assert.Len(t, report.Runs, 1)
+	assert.Len(t, report.Runs[0].Tool.Driver.Rules, 2)
+	assert.Len(t, report.Runs[0].Results, 2)
+	assert.Equal(t, "one.yaml", report.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, "two.yaml", report.Runs[0].Results[1].Locations[0].PhysicalLocation.ArtifactLocation.URI)
+}
+
+func TestBuildCheckstyleReportForFiles(t *testing.T) {
+	var report Checkstyle
+	assert.NoError(t, xml.Unmarshal(BuildCheckstyleReportForFiles(buildFakeFileReports()), &report))
+	assert.Len(t, report.Files, 2)
+	assert.Equal(t, "one.yaml", report.Files[0].Name)
+	assert.Equal(t, "two.yaml", report.Files[1].Name)
+}
+
+func TestBuildCodeQualityReportForFiles(t *testing.T) {
+	var issues []*CodeQualityIssue
+	assert.NoError(t, json.Unmarshal(BuildCodeQualityReportForFiles(buildFakeFileReports()), &issues))
+	assert.Len(t, issues, 2)
+	assert.Equal(t, "two.yaml", issues[1].Location.Path)
+}
+
+func TestBuildAggregatedJSONReport(t *testing.T) {
+	var report AggregatedReport
+	assert.NoError(t, json.Unmarshal(BuildAggregatedJSONReport(buildFakeFileReports(), time.Now()), &report))
+	assert.Len(t, report.Files, 3)
+	assert.Equal(t, 40, report.Files[1].Statistics.OverallScore)
+	assert.NotNil(t, report.ResultSetForFile("two.yaml"))
+	assert.Nil(t, report.ResultSetForFile("nope.yaml"))
+}
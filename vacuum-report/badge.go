@@ -0,0 +1,101 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// BadgeLabel is the label rendered on the left of a quality badge.
+const BadgeLabel = "API quality"
+
+// ShieldsEndpoint is a shields.io endpoint badge, see https://shields.io/badges/endpoint-badge
+type ShieldsEndpoint struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	Label         string `json:"label"`
+	Message       string `json:"message"`
+	Color         string `json:"color"`
+}
+
+// badgeColors are the colors used for a score, the first threshold the score reaches is used.
+var badgeColors = []struct {
+	threshold int
+	name, hex string
+}{
+	{90, "brightgreen", "#4c1"},
+	{75, "green", "#97ca00"},
+	{60, "yellow", "#dfb317"},
+	{40, "orange", "#fe7d37"},
+	{0, "red", "#e05d44"},
+}
+
+func badgeColor(score int) (string, string) {
+	for _, c := range badgeColors {
+		if score >= c.threshold {
+			return c.name, c.hex
+		}
+	}
+	last := badgeColors[len(badgeColors)-1]
+	return last.name, last.hex
+}
+
+// BuildBadgeEndpoint renders a quality score as shields.io endpoint JSON, the color is picked by the score.
+func BuildBadgeEndpoint(score int) []byte {
+	color, _ := badgeColor(score)
+	data, _ := json.MarshalIndent(&ShieldsEndpoint{
+		SchemaVersion: 1,
+		Label:         BadgeLabel,
+		Message:       fmt.Sprintf("%d%%", score),
+		Color:         color,
+	}, "", "  ")
+	return data
+}
+
+// BuildBadgeSVG renders a quality score as a flat SVG badge, for when the badge is served as a file rather than
+// through shields.io. Text widths are estimated, as the font is not available to measure.
+func BuildBadgeSVG(score int) []byte {
+	_, color := badgeColor(score)
+	message := fmt.Sprintf("%d%%", score)
+	labelWidth := textWidth(BadgeLabel)
+	messageWidth := textWidth(message)
+	width := labelWidth + messageWidth
+
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf(`<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="20" role="img" aria-label="%s: %s">`,
+		width, BadgeLabel, message))
+	sb.WriteString(fmt.Sprintf(`<title>%s: %s</title>`, BadgeLabel, message))
+	sb.WriteString(`<linearGradient id="s" x2="0" y2="100%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/>`)
+	sb.WriteString(`<stop offset="1" stop-opacity=".1"/></linearGradient>`)
+	sb.WriteString(fmt.Sprintf(`<clipPath id="r"><rect width="%d" height="20" rx="3" fill="#fff"/></clipPath>`, width))
+	sb.WriteString(`<g clip-path="url(#r)">`)
+	sb.WriteString(fmt.Sprintf(`<rect width="%d" height="20" fill="#555"/>`, labelWidth))
+	sb.WriteString(fmt.Sprintf(`<rect x="%d" width="%d" height="20" fill="%s"/>`, labelWidth, messageWidth, color))
+	sb.WriteString(fmt.Sprintf(`<rect width="%d" height="20" fill="url(#s)"/></g>`, width))
+	sb.WriteString(`<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">`)
+	sb.WriteString(fmt.Sprintf(`<text x="%d" y="14">%s</text>`, labelWidth/2, BadgeLabel))
+	sb.WriteString(fmt.Sprintf(`<text x="%d" y="14">%s</text>`, labelWidth+messageWidth/2, message))
+	sb.WriteString("</g></svg>\n")
+	return []byte(sb.String())
+}
+
+// textWidth estimates the width of text rendered in 11px Verdana, with padding either side.
+func textWidth(text string) int {
+	return len(text)*7 + 10
+}
+
+// WriteBadge writes a quality badge for a score to a file. Files ending in '.svg' are written as an SVG badge,
+// anything else is written as shields.io endpoint JSON.
+func WriteBadge(path string, score int) error {
+	data := BuildBadgeEndpoint(score)
+	if strings.HasSuffix(strings.ToLower(path), ".svg") {
+		data = BuildBadgeSVG(score)
+	}
+	if err := os.WriteFile(path, data, 0664); err != nil {
+		return fmt.Errorf("unable to write badge '%s': %w", path, err)
+	}
+	return nil
+}
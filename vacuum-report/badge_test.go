@@ -0,0 +1,41 @@
+package vacuum_report
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildBadgeEndpoint(t *testing.T) {
+	var badge ShieldsEndpoint
+	assert.NoError(t, json.Unmarshal(BuildBadgeEndpoint(92), &badge))
+	assert.Equal(t, ShieldsEndpoint{SchemaVersion: 1, Label: "API quality", Message: "92%", Color: "brightgreen"}, badge)
+
+	for score, color := range map[int]string{90: "brightgreen", 80: "green", 60: "yellow", 45: "orange", 10: "red", -1: "red"} {
+		_ = json.Unmarshal(BuildBadgeEndpoint(score), &badge)
+		assert.Equal(t, color, badge.Color, score)
+	}
+}
+
+func TestBuildBadgeSVG(t *testing.T) {
+	svg := string(BuildBadgeSVG(55))
+	assert.Contains(t, svg, "<svg")
+	assert.Contains(t, svg, ">55%</text>")
+	assert.Contains(t, svg, `fill="#fe7d37"`)
+}
+
+func TestWriteBadge(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, WriteBadge(filepath.Join(dir, "badge.json"), 75))
+	data, _ := os.ReadFile(filepath.Join(dir, "badge.json"))
+	assert.Contains(t, string(data), `"message": "75%"`)
+
+	assert.NoError(t, WriteBadge(filepath.Join(dir, "badge.SVG"), 75))
+	data, _ = os.ReadFile(filepath.Join(dir, "badge.SVG"))
+	assert.Contains(t, string(data), "<svg")
+
+	assert.ErrorContains(t, WriteBadge(filepath.Join(dir, "nope", "badge.json"), 75), "unable to write badge")
+}
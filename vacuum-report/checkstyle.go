@@ -0,0 +1,96 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"bytes"
+	"encoding/xml"
+	"sort"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// Checkstyle is the root of a checkstyle XML report.
+type Checkstyle struct {
+	XMLName xml.Name          `xml:"checkstyle"`
+	Version string            `xml:"version,attr"`
+	Files   []*CheckstyleFile `xml:"file"`
+}
+
+// CheckstyleFile is a file in a checkstyle report, with every result found in it.
+type CheckstyleFile struct {
+	Name   string             `xml:"name,attr"`
+	Errors []*CheckstyleError `xml:"error"`
+}
+
+// CheckstyleError is a single result in a checkstyle report.
+type CheckstyleError struct {
+	Line     int    `xml:"line,attr"`
+	Column   int    `xml:"column,attr,omitempty"`
+	Severity string `xml:"severity,attr"`
+	Message  string `xml:"message,attr"`
+	Source   string `xml:"source,attr"`
+}
+
+// GetCheckstyleSeverity converts a vacuum severity into a checkstyle severity.
+func GetCheckstyleSeverity(severity string) string {
+	switch severity {
+	case model.SeverityError:
+		return "error"
+	case model.SeverityWarn:
+		return "warning"
+	}
+	return "info"
+}
+
+// BuildCheckstyleReport will convert a RuleResultSet into checkstyle XML, grouping results by the file they were
+// found in. The fileName is used for any result that has no origin.
+func BuildCheckstyleReport(resultSet *model.RuleResultSet, fileName string) []byte {
+	return BuildCheckstyleReportForFiles(singleFileReport(resultSet, fileName))
+}
+
+// BuildCheckstyleReportForFiles will convert the results of a multi-file run into a single checkstyle XML report.
+func BuildCheckstyleReportForFiles(fileReports []*FileReport) []byte {
+	files := make(map[string]*CheckstyleFile)
+	var names []string
+
+	for _, fr := range collectFileResults(fileReports) {
+		r := fr.result
+		name := resultLocation(r, fr.fileName)
+		f, ok := files[name]
+		if !ok {
+			f = &CheckstyleFile{Name: name}
+			files[name] = f
+			names = append(names, name)
+		}
+		line, col := 1, 0
+		if r.Origin != nil && r.Origin.Line > 0 {
+			line, col = r.Origin.Line, r.Origin.Column
+		} else if r.StartNode != nil && r.StartNode.Line > 0 {
+			line, col = r.StartNode.Line, r.StartNode.Column
+		}
+		f.Errors = append(f.Errors, &CheckstyleError{
+			Line:     line,
+			Column:   col,
+			Severity: GetCheckstyleSeverity(r.Rule.Severity),
+			Message:  r.Message,
+			Source:   r.Rule.Id,
+		})
+	}
+
+	sort.Strings(names)
+	report := &Checkstyle{Version: "4.3"}
+	for _, n := range names {
+		report.Files = append(report.Files, files[n])
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return []byte{}
+	}
+	return buf.Bytes()
+}
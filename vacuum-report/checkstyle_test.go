@@ -0,0 +1,49 @@
+package vacuum_report
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCheckstyleReport(t *testing.T) {
+	rs := buildFakeResultSet("no description", "$.info", "info-description",
+		model.SeverityWarn, model.CategoryInfo, "Info", "spec.yaml", 7)
+	other := buildFakeResultSet("bad & wrong", "$.paths", "path-params",
+		model.SeverityError, model.CategoryOperations, "Operations", "spec.yaml", 20)
+	rs.Results = append(rs.Results, other.Results...)
+
+	data := BuildCheckstyleReport(rs, "spec.yaml")
+	assert.Contains(t, string(data), xml.Header)
+
+	var report Checkstyle
+	assert.NoError(t, xml.Unmarshal(data, &report))
+	assert.Len(t, report.Files, 1)
+	assert.Equal(t, "spec.yaml", report.Files[0].Name)
+	assert.Len(t, report.Files[0].Errors, 2)
+
+	e := report.Files[0].Errors[0]
+	assert.Equal(t, 7, e.Line)
+	assert.Equal(t, "warning", e.Severity)
+	assert.Equal(t, "info-description", e.Source)
+	assert.Equal(t, "no description", e.Message)
+
+	e = report.Files[0].Errors[1]
+	assert.Equal(t, "error", e.Severity)
+	assert.Equal(t, "bad & wrong", e.Message)
+}
+
+func TestGetCheckstyleSeverity(t *testing.T) {
+	assert.Equal(t, "error", GetCheckstyleSeverity(model.SeverityError))
+	assert.Equal(t, "warning", GetCheckstyleSeverity(model.SeverityWarn))
+	assert.Equal(t, "info", GetCheckstyleSeverity(model.SeverityInfo))
+	assert.Equal(t, "info", GetCheckstyleSeverity(model.SeverityHint))
+}
+
+func TestBuildCheckstyleReport_Empty(t *testing.T) {
+	var report Checkstyle
+	assert.NoError(t, xml.Unmarshal(BuildCheckstyleReport(&model.RuleResultSet{}, "spec.yaml"), &report))
+	assert.Empty(t, report.Files)
+}
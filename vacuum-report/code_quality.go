@@ -0,0 +1,117 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// CodeQualityIssue is a single Code Climate issue, as consumed by GitLab Code Quality.
+// https://docs.gitlab.com/ee/ci/testing/code_quality.html#implement-a-custom-tool
+type CodeQualityIssue struct {
+	Type        string               `json:"type"`
+	CheckName   string               `json:"check_name"`
+	Description string               `json:"description"`
+	Categories  []string             `json:"categories,omitempty"`
+	Fingerprint string               `json:"fingerprint"`
+	Severity    string               `json:"severity"`
+	Location    *CodeQualityLocation `json:"location"`
+}
+
+// CodeQualityLocation is the file and line an issue was found on.
+type CodeQualityLocation struct {
+	Path  string            `json:"path"`
+	Lines *CodeQualityLines `json:"lines"`
+}
+
+// CodeQualityLines holds the line an issue begins on.
+type CodeQualityLines struct {
+	Begin int `json:"begin"`
+}
+
+// GetCodeQualitySeverity converts a vacuum severity into a Code Climate severity.
+func GetCodeQualitySeverity(severity string) string {
+	switch severity {
+	case model.SeverityError:
+		return "blocker"
+	case model.SeverityWarn:
+		return "major"
+	case model.SeverityInfo:
+		return "minor"
+	}
+	return "info"
+}
+
+// BuildCodeQualityReport will convert a RuleResultSet into a Code Climate compatible JSON report, that can be
+// used as a GitLab Code Quality artifact. The fileName is used as the path for any result that has no origin.
+func BuildCodeQualityReport(resultSet *model.RuleResultSet, fileName string) []byte {
+	return BuildCodeQualityReportForFiles(singleFileReport(resultSet, fileName))
+}
+
+// BuildCodeQualityReportForFiles will convert the results of a multi-file run into a single Code Climate report.
+func BuildCodeQualityReportForFiles(files []*FileReport) []byte {
+	fileResults := collectFileResults(files)
+	issues := make([]*CodeQualityIssue, 0, len(fileResults))
+	seen := make(map[string]int)
+
+	for _, fr := range fileResults {
+		r := fr.result
+		path := filepath.ToSlash(resultLocation(r, fr.fileName))
+		line := 1
+		if r.Origin != nil && r.Origin.Line > 0 {
+			line = r.Origin.Line
+		} else if r.StartNode != nil && r.StartNode.Line > 0 {
+			line = r.StartNode.Line
+		}
+
+		// the line number is left out of the fingerprint, so findings are not reported as new when
+		// unrelated edits move them up or down the file. So is the message, it changes with the locale.
+		// Duplicates get a counter to keep them unique.
+		fp := CodeQualityFingerprint(r.Rule.Id, path, r.Path)
+		if n, ok := seen[fp]; ok {
+			seen[fp] = n + 1
+			fp = CodeQualityFingerprint(r.Rule.Id, path, r.Path, fmt.Sprint(n+1))
+		} else {
+			seen[fp] = 0
+		}
+
+		issue := &CodeQualityIssue{
+			Type:        "issue",
+			CheckName:   r.Rule.Id,
+			Description: r.Message,
+			Fingerprint: fp,
+			Severity:    GetCodeQualitySeverity(r.Rule.Severity),
+			Location: &CodeQualityLocation{
+				Path:  path,
+				Lines: &CodeQualityLines{Begin: line},
+			},
+		}
+		if r.Rule.RuleCategory != nil {
+			issue.Categories = []string{r.Rule.RuleCategory.Name}
+		}
+		issues = append(issues, issue)
+	}
+
+	data, err := json.MarshalIndent(issues, "", "  ")
+	if err != nil {
+		return []byte("[]")
+	}
+	return data
+}
+
+// CodeQualityFingerprint generates a stable fingerprint from the supplied parts.
+func CodeQualityFingerprint(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+	return hex.EncodeToString(h.Sum(nil))[:32]
+}
@@ -0,0 +1,61 @@
+package vacuum_report
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCodeQualityReport(t *testing.T) {
+	rs := buildFakeResultSet("no description", "$.info", "info-description",
+		model.SeverityError, model.CategoryInfo, "Info", "spec.yaml", 12)
+
+	var issues []*CodeQualityIssue
+	assert.NoError(t, json.Unmarshal(BuildCodeQualityReport(rs, "spec.yaml"), &issues))
+	assert.Len(t, issues, 1)
+	assert.Equal(t, "issue", issues[0].Type)
+	assert.Equal(t, "info-description", issues[0].CheckName)
+	assert.Equal(t, "blocker", issues[0].Severity)
+	assert.Equal(t, "spec.yaml", issues[0].Location.Path)
+	assert.Equal(t, 12, issues[0].Location.Lines.Begin)
+	assert.Equal(t, []string{"Info"}, issues[0].Categories)
+	assert.Len(t, issues[0].Fingerprint, 32)
+}
+
+func TestBuildCodeQualityReport_StableFingerprint(t *testing.T) {
+	a := buildFakeResultSet("msg", "$.info", "rule", model.SeverityWarn, model.CategoryInfo, "Info", "spec.yaml", 10)
+	b := buildFakeResultSet("msg", "$.info", "rule", model.SeverityWarn, model.CategoryInfo, "Info", "spec.yaml", 25)
+
+	var issuesA, issuesB []*CodeQualityIssue
+	assert.NoError(t, json.Unmarshal(BuildCodeQualityReport(a, "spec.yaml"), &issuesA))
+	assert.NoError(t, json.Unmarshal(BuildCodeQualityReport(b, "spec.yaml"), &issuesB))
+	assert.Equal(t, issuesA[0].Fingerprint, issuesB[0].Fingerprint)
+	assert.Equal(t, "major", issuesA[0].Severity)
+}
+
+func TestBuildCodeQualityReport_DuplicateFingerprints(t *testing.T) {
+	rs := buildFakeResultSet("msg", "$.info", "rule", model.SeverityInfo, model.CategoryInfo, "Info", "spec.yaml", 1)
+	rs.Results = append(rs.Results, rs.Results[0])
+
+	var issues []*CodeQualityIssue
+	assert.NoError(t, json.Unmarshal(BuildCodeQualityReport(rs, "spec.yaml"), &issues))
+	assert.Len(t, issues, 2)
+	assert.NotEqual(t, issues[0].Fingerprint, issues[1].Fingerprint)
+	assert.Equal(t, "minor", issues[0].Severity)
+}
+
+func TestBuildCodeQualityReport_Empty(t *testing.T) {
+	assert.Equal(t, "[]", string(BuildCodeQualityReport(&model.RuleResultSet{}, "spec.yaml")))
+}
+
+func TestBuildCodeQualityReport_FingerprintIgnoresMessage(t *testing.T) {
+	a := buildFakeResultSet("no description", "$.info", "rule", model.SeverityWarn, model.CategoryInfo, "Info", "spec.yaml", 10)
+	b := buildFakeResultSet("pas de description", "$.info", "rule", model.SeverityWarn, model.CategoryInfo, "Info", "spec.yaml", 10)
+
+	var issuesA, issuesB []*CodeQualityIssue
+	assert.NoError(t, json.Unmarshal(BuildCodeQualityReport(a, "spec.yaml"), &issuesA))
+	assert.NoError(t, json.Unmarshal(BuildCodeQualityReport(b, "spec.yaml"), &issuesB))
+	assert.Equal(t, issuesA[0].Fingerprint, issuesB[0].Fingerprint)
+}
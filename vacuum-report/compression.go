@@ -0,0 +1,109 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/andybalholm/brotli"
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression codecs that can be used to compress a vacuum report.
+const (
+	CompressionGzip   = "gzip"
+	CompressionZstd   = "zstd"
+	CompressionBrotli = "brotli"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+var zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+
+// CompressionCodecs returns the names of every supported compression codec.
+func CompressionCodecs() []string {
+	return []string{CompressionGzip, CompressionZstd, CompressionBrotli}
+}
+
+// CompressionExtension returns the file extension used for a report compressed with the codec.
+func CompressionExtension(codec string) string {
+	switch codec {
+	case CompressionZstd:
+		return ".json.zst"
+	case CompressionBrotli:
+		return ".json.br"
+	}
+	return ".json.gz"
+}
+
+// CompressionForFileName returns the codec to use for a file name, based on its extension. An empty string is
+// returned if the file should not be compressed.
+func CompressionForFileName(fileName string) string {
+	switch {
+	case strings.HasSuffix(fileName, ".gz"):
+		return CompressionGzip
+	case strings.HasSuffix(fileName, ".zst"):
+		return CompressionZstd
+	case strings.HasSuffix(fileName, ".br"):
+		return CompressionBrotli
+	}
+	return ""
+}
+
+// CompressReport compresses a rendered report using the codec (gzip, zstd or brotli).
+func CompressReport(data []byte, codec string) ([]byte, error) {
+	var b bytes.Buffer
+	var w io.WriteCloser
+	switch codec {
+	case CompressionGzip, "":
+		w = gzip.NewWriter(&b)
+	case CompressionZstd:
+		zw, err := zstd.NewWriter(&b)
+		if err != nil {
+			return nil, err
+		}
+		w = zw
+	case CompressionBrotli:
+		w = brotli.NewWriter(&b)
+	default:
+		return nil, fmt.Errorf("unknown compression '%s', use one of: %s", codec,
+			strings.Join(CompressionCodecs(), ", "))
+	}
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+// DecompressReport returns the uncompressed bytes of a report. gzip and zstd are recognized by their magic bytes.
+// brotli has no magic bytes, so anything else is tried as brotli, and is only used if it decompresses into a JSON
+// object. Otherwise the data is returned as it is.
+func DecompressReport(data []byte) ([]byte, error) {
+	switch {
+	case bytes.HasPrefix(data, gzipMagic):
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		return io.ReadAll(r)
+	case bytes.HasPrefix(data, zstdMagic):
+		r, err := zstd.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+		defer r.Close()
+		return io.ReadAll(r)
+	}
+	decompressed, err := io.ReadAll(brotli.NewReader(bytes.NewReader(data)))
+	if err == nil && bytes.HasPrefix(bytes.TrimLeft(decompressed, " \t\r\n"), []byte("{")) {
+		return decompressed, nil
+	}
+	return data, nil
+}
@@ -0,0 +1,45 @@
+package vacuum_report
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressReport_RoundTrip(t *testing.T) {
+	j := testhelp_uncompressedJSON()
+	for _, codec := range CompressionCodecs() {
+		compressed, err := CompressReport(j, codec)
+		assert.NoError(t, err)
+		assert.Less(t, len(compressed), len(j), codec)
+
+		decompressed, err := DecompressReport(compressed)
+		assert.NoError(t, err)
+		assert.Equal(t, j, decompressed, codec)
+
+		vr, err := CheckFileForVacuumReport(compressed)
+		assert.NoError(t, err)
+		assert.NotNil(t, vr, codec)
+	}
+}
+
+func TestCompressReport_Unknown(t *testing.T) {
+	_, err := CompressReport([]byte("{}"), "lzma")
+	assert.ErrorContains(t, err, "unknown compression 'lzma'")
+}
+
+func TestDecompressReport_NotCompressed(t *testing.T) {
+	spec := []byte("openapi: 3.1.0\ninfo:\n  title: pizza\n")
+	decompressed, err := DecompressReport(spec)
+	assert.NoError(t, err)
+	assert.Equal(t, spec, decompressed)
+}
+
+func TestCompressionForFileName(t *testing.T) {
+	assert.Equal(t, CompressionGzip, CompressionForFileName("report.json.gz"))
+	assert.Equal(t, CompressionZstd, CompressionForFileName("report.json.zst"))
+	assert.Equal(t, CompressionBrotli, CompressionForFileName("report.json.br"))
+	assert.Empty(t, CompressionForFileName("report.json"))
+	assert.Equal(t, ".json.zst", CompressionExtension(CompressionZstd))
+	assert.Equal(t, ".json.gz", CompressionExtension(""))
+}
@@ -0,0 +1,92 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"github.com/daveshanley/vacuum/model"
+)
+
+// ReportDiff is the difference between two vacuum reports.
+type ReportDiff struct {
+	Added      []*model.RuleFunctionResult `json:"added" yaml:"added"`
+	Removed    []*model.RuleFunctionResult `json:"removed" yaml:"removed"`
+	Unchanged  []*model.RuleFunctionResult `json:"unchanged" yaml:"unchanged"`
+	OldScore   int                         `json:"oldScore" yaml:"oldScore"`
+	NewScore   int                         `json:"newScore" yaml:"newScore"`
+	ScoreDelta int                         `json:"scoreDelta" yaml:"scoreDelta"`
+}
+
+// CountBySeverity returns the number of added errors, warnings and info results, used to check for regressions.
+func (d *ReportDiff) CountBySeverity() (errors, warnings, informs int) {
+	for _, r := range d.Added {
+		switch resultSeverity(r) {
+		case model.SeverityError:
+			errors++
+		case model.SeverityWarn:
+			warnings++
+		case model.SeverityInfo:
+			informs++
+		}
+	}
+	return
+}
+
+// DiffReports compares two vacuum reports. Results are matched using the same fingerprint as a baseline (rule id
+// and path), so findings that have only moved up or down a file are considered unchanged.
+func DiffReports(oldReport, newReport *VacuumReport) *ReportDiff {
+	diff := &ReportDiff{
+		Added:     []*model.RuleFunctionResult{},
+		Removed:   []*model.RuleFunctionResult{},
+		Unchanged: []*model.RuleFunctionResult{},
+	}
+
+	oldResults := reportResults(oldReport)
+	known := make(map[string][]*model.RuleFunctionResult)
+	for _, r := range oldResults {
+		fp := model.BaselineFingerprint(r)
+		known[fp] = append(known[fp], r)
+	}
+
+	for _, r := range reportResults(newReport) {
+		fp := model.BaselineFingerprint(r)
+		if len(known[fp]) > 0 {
+			known[fp] = known[fp][1:]
+			diff.Unchanged = append(diff.Unchanged, r)
+			continue
+		}
+		diff.Added = append(diff.Added, r)
+	}
+
+	// anything left over in the old report no longer exists, keep the original order.
+	for _, r := range oldResults {
+		fp := model.BaselineFingerprint(r)
+		if len(known[fp]) > 0 && known[fp][0] == r {
+			known[fp] = known[fp][1:]
+			diff.Removed = append(diff.Removed, r)
+		}
+	}
+
+	if oldReport != nil && oldReport.Statistics != nil {
+		diff.OldScore = oldReport.Statistics.OverallScore
+	}
+	if newReport != nil && newReport.Statistics != nil {
+		diff.NewScore = newReport.Statistics.OverallScore
+	}
+	diff.ScoreDelta = diff.NewScore - diff.OldScore
+	return diff
+}
+
+func reportResults(report *VacuumReport) []*model.RuleFunctionResult {
+	if report == nil || report.ResultSet == nil {
+		return nil
+	}
+	return report.ResultSet.Results
+}
+
+func resultSeverity(r *model.RuleFunctionResult) string {
+	if r.Rule != nil && r.Rule.Severity != "" {
+		return r.Rule.Severity
+	}
+	return r.RuleSeverity
+}
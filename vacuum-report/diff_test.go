@@ -0,0 +1,58 @@
+package vacuum_report
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/stretchr/testify/assert"
+)
+
+func buildDiffReport(score int, results ...*model.RuleFunctionResult) *VacuumReport {
+	return &VacuumReport{
+		Statistics: &reports.ReportStatistics{OverallScore: score},
+		ResultSet:  &model.RuleResultSet{Results: results},
+	}
+}
+
+func TestDiffReports(t *testing.T) {
+	kept := &model.RuleFunctionResult{RuleId: "a", Path: "$.info", Message: "kept", RuleSeverity: model.SeverityWarn}
+	moved := *kept
+	moved.Range.Start.Line = 50
+	fixed := &model.RuleFunctionResult{RuleId: "b", Path: "$.paths", Message: "fixed", RuleSeverity: model.SeverityError}
+	added := &model.RuleFunctionResult{RuleId: "c", Path: "$.tags", Message: "added", RuleSeverity: model.SeverityError}
+
+	diff := DiffReports(buildDiffReport(80, kept, fixed), buildDiffReport(75, &moved, added))
+	assert.Len(t, diff.Unchanged, 1)
+	assert.Len(t, diff.Removed, 1)
+	assert.Equal(t, "fixed", diff.Removed[0].Message)
+	assert.Len(t, diff.Added, 1)
+	assert.Equal(t, "added", diff.Added[0].Message)
+	assert.Equal(t, -5, diff.ScoreDelta)
+
+	errs, warns, infos := diff.CountBySeverity()
+	assert.Equal(t, 1, errs)
+	assert.Equal(t, 0, warns)
+	assert.Equal(t, 0, infos)
+}
+
+func TestDiffReports_Duplicates(t *testing.T) {
+	r := &model.RuleFunctionResult{RuleId: "a", Path: "$.info", Message: "dupe", RuleSeverity: model.SeverityInfo}
+	dupe := *r
+
+	diff := DiffReports(buildDiffReport(90, r), buildDiffReport(90, r, &dupe))
+	assert.Len(t, diff.Unchanged, 1)
+	assert.Len(t, diff.Added, 1)
+	assert.Empty(t, diff.Removed)
+
+	diff = DiffReports(buildDiffReport(90, r, &dupe), buildDiffReport(90, r))
+	assert.Len(t, diff.Unchanged, 1)
+	assert.Len(t, diff.Removed, 1)
+	assert.Empty(t, diff.Added)
+}
+
+func TestDiffReports_Nil(t *testing.T) {
+	diff := DiffReports(nil, nil)
+	assert.Empty(t, diff.Added)
+	assert.Equal(t, 0, diff.ScoreDelta)
+}
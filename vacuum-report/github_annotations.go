@@ -0,0 +1,92 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// GitHubAnnotationLimit is the number of annotations of each level GitHub will show for a single step.
+const GitHubAnnotationLimit = 10
+
+// GetGitHubAnnotationLevel converts a vacuum severity into a GitHub workflow command.
+func GetGitHubAnnotationLevel(severity string) string {
+	switch severity {
+	case model.SeverityError:
+		return "error"
+	case model.SeverityWarn:
+		return "warning"
+	}
+	return "notice"
+}
+
+// BuildGitHubAnnotations renders a RuleResultSet as GitHub Actions workflow commands, so each result shows up as an
+// inline annotation on a pull request diff. Results are rendered most severe first and no more than limit results
+// of each level (error, warning and notice) are rendered, a limit of zero or less renders everything. The annotated
+// map counts the annotations already rendered for each level, so a limit can be shared by many files, and is updated
+// with what's rendered, it can be nil. A notice is rendered for any results that were not annotated.
+func BuildGitHubAnnotations(resultSet *model.RuleResultSet, fileName string, limit int, annotated map[string]int) []byte {
+	results := make([]*model.RuleFunctionResult, 0, len(resultSet.Results))
+	for _, r := range resultSet.Results {
+		if r.Rule != nil {
+			results = append(results, r)
+		}
+	}
+	model.SortRuleFunctionResults(results, model.OrderBySeverity)
+	if annotated == nil {
+		annotated = make(map[string]int)
+	}
+
+	var buf bytes.Buffer
+	skipped := 0
+	for _, r := range results {
+		level := GetGitHubAnnotationLevel(r.Rule.Severity)
+		if limit > 0 && annotated[level] >= limit {
+			skipped++
+			continue
+		}
+		line, col := 1, 0
+		if r.Origin != nil && r.Origin.Line > 0 {
+			line, col = r.Origin.Line, r.Origin.Column
+		} else if r.StartNode != nil && r.StartNode.Line > 0 {
+			line, col = r.StartNode.Line, r.StartNode.Column
+		}
+
+		props := []string{
+			fmt.Sprintf("file=%s", escapeGitHubProperty(filepath.ToSlash(resultLocation(r, fileName)))),
+			fmt.Sprintf("line=%d", line),
+		}
+		if col > 0 {
+			props = append(props, fmt.Sprintf("col=%d", col))
+		}
+		props = append(props, fmt.Sprintf("title=%s", escapeGitHubProperty(r.Rule.Id)))
+
+		buf.WriteString(fmt.Sprintf("::%s %s::%s\n", level, strings.Join(props, ","), escapeGitHubData(r.Message)))
+		annotated[level]++
+	}
+
+	if skipped > 0 {
+		buf.WriteString(fmt.Sprintf("::notice title=vacuum::%d more results in %s were not annotated, the limit is %d of each level\n",
+			skipped, escapeGitHubData(filepath.ToSlash(fileName)), limit))
+	}
+	return buf.Bytes()
+}
+
+// https://github.com/actions/toolkit/blob/main/packages/core/src/command.ts
+func escapeGitHubData(s string) string {
+	s = strings.ReplaceAll(s, "%", "%25")
+	s = strings.ReplaceAll(s, "\r", "%0D")
+	return strings.ReplaceAll(s, "\n", "%0A")
+}
+
+func escapeGitHubProperty(s string) string {
+	s = escapeGitHubData(s)
+	s = strings.ReplaceAll(s, ":", "%3A")
+	return strings.ReplaceAll(s, ",", "%2C")
+}
@@ -0,0 +1,51 @@
+package vacuum_report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuildGitHubAnnotations(t *testing.T) {
+	rs := buildFakeResultSet("no description\nat all", "$.info", "info-description",
+		model.SeverityWarn, model.CategoryInfo, "Info", "spec.yaml", 7)
+	rs.Results[0].StartNode = &yaml.Node{Line: 7, Column: 3}
+
+	out := BuildGitHubAnnotations(rs, "spec.yaml", GitHubAnnotationLimit, nil)
+	assert.Equal(t, "::warning file=spec.yaml,line=7,col=3,title=info-description::no description%0Aat all\n", string(out))
+}
+
+func TestBuildGitHubAnnotations_Limit(t *testing.T) {
+	rs := buildFakeResultSet("hint", "$.a", "hint-rule", model.SeverityHint, model.CategoryInfo, "Info", "spec.yaml", 1)
+	for i := 0; i < 3; i++ {
+		e := buildFakeResultSet("err", "$.b", "error-rule", model.SeverityError, model.CategoryInfo, "Info", "spec.yaml", 2)
+		rs.Results = append(rs.Results, e.Results...)
+	}
+
+	annotated := make(map[string]int)
+	out := BuildGitHubAnnotations(rs, "spec.yaml", 2, annotated)
+	assert.Equal(t, map[string]int{"error": 2, "notice": 1}, annotated)
+	lines := strings.Split(strings.TrimSpace(string(out)), "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "::error "))
+	assert.True(t, strings.HasPrefix(lines[1], "::error "))
+	assert.True(t, strings.HasPrefix(lines[2], "::notice "))
+	assert.Contains(t, lines[3], "1 more results in spec.yaml were not annotated")
+
+	// the error limit is used up, the errors are skipped but the notice is still rendered.
+	out = BuildGitHubAnnotations(rs, "spec.yaml", 2, annotated)
+	lines = strings.Split(strings.TrimSpace(string(out)), "\n")
+	assert.Len(t, lines, 2)
+	assert.True(t, strings.HasPrefix(lines[0], "::notice file=spec.yaml"))
+	assert.Contains(t, lines[1], "3 more results in spec.yaml were not annotated")
+}
+
+func TestBuildGitHubAnnotations_NoLimit(t *testing.T) {
+	rs := buildFakeResultSet("info", "$.a", "info-rule", model.SeverityInfo, model.CategoryInfo, "Info", "a,b:c.yaml", 1)
+	out := BuildGitHubAnnotations(rs, "a,b:c.yaml", 0, nil)
+	assert.Equal(t, 1, strings.Count(string(out), "\n"))
+	assert.Contains(t, string(out), "::notice file=a%2Cb%3Ac.yaml,")
+}
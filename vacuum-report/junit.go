@@ -8,6 +8,11 @@ import (
 	"encoding/xml"
 	"fmt"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/pb33f/libopenapi/utils"
+	"io"
+	"path/filepath"
+	"sort"
 	"strings"
 	"text/template"
 	"time"
@@ -18,14 +23,17 @@ type TestSuites struct {
 	TestSuites []*TestSuite `xml:"testsuite"`
 	Tests      int          `xml:"tests,attr"`
 	Failures   int          `xml:"failures,attr"`
+	Skipped    int          `xml:"skipped,attr"`
 	Time       float64      `xml:"time,attr"`
 }
 
 type TestSuite struct {
 	XMLName   xml.Name    `xml:"testsuite"`
 	Name      string      `xml:"name,attr"`
+	Timestamp string      `xml:"timestamp,attr,omitempty"`
 	Tests     int         `xml:"tests,attr"`
 	Failures  int         `xml:"failures,attr"`
+	Skipped   int         `xml:"skipped,attr"`
 	Time      float64     `xml:"time,attr"`
 	TestCases []*TestCase `xml:"testcase"`
 }
@@ -43,21 +51,38 @@ type Property struct {
 type TestCase struct {
 	Name       string      `xml:"name,attr"`
 	ClassName  string      `xml:"classname,attr"`
+	File       string      `xml:"file,attr,omitempty"`
+	Time       float64     `xml:"time,attr,omitempty"` // the time the rule took to find the result, when it was timed.
 	Failure    *Failure    `xml:"failure,omitempty"`
+	Skipped    *Skipped    `xml:"skipped,omitempty"`
 	Properties *Properties `xml:"properties,omitempty"`
+	SystemOut  string      `xml:"system-out,omitempty"` // the code frame of the result, when the source is known.
 }
 
+// Skipped marks a test case as skipped, results that are not failures (like info and hints) are reported as skipped.
+type Skipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+type junitOutcome int
+
+const (
+	junitPassed junitOutcome = iota
+	junitFailed
+	junitSkipped
+)
+
 type Failure struct {
 	Message  string `xml:"message,attr"`
 	Type     string `xml:"type,attr"`
 	Contents string `xml:",innerxml"`
 }
 
-func BuildJUnitReport(resultSet *model.RuleResultSet, t time.Time, args []string) []byte {
-	since := time.Since(t)
-	var suites []*TestSuite
-	var cats = model.RuleCategoriesOrdered
-	tmpl := `File: {{ .File }}
+// JUnitTimestampFormat is the ISO 8601 layout (without a timezone) the JUnit schema expects for suite timestamps.
+const JUnitTimestampFormat = "2006-01-02T15:04:05"
+
+// DefaultJUnitFailureTemplate renders the contents of a failure, when no template is configured.
+const DefaultJUnitFailureTemplate = `File: {{ .File }}
 Line: {{ .Line }}
 JSON Path: {{ .Path }}
 Rule: {{ .RuleId }}
@@ -65,108 +90,409 @@ Severity: {{ .Severity }}
 
 {{ .Message }}`
 
-	parsedTemplate, err := template.New("failure").Parse(tmpl)
+// JUnitFailureData is available to a failure template, Snippet is only set when the specification is known.
+type JUnitFailureData struct {
+	File     string
+	Line     int
+	Path     string
+	RuleId   string
+	Severity string
+	Message  string
+	Snippet  string
+}
+
+// ParseJUnitFailureTemplate parses a failure template and renders it once with sample data, so templates that
+// refer to unknown fields are rejected up front, rather than silently dropping test cases.
+func ParseJUnitFailureTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("failure").Parse(text)
 	if err != nil {
-		// Handle error, e.g., log it or return an empty report
-		return []byte{}
+		return nil, err
+	}
+	if err = tmpl.Execute(io.Discard, JUnitFailureData{}); err != nil {
+		return nil, err
 	}
+	return tmpl, nil
+}
 
-	gf, gtc := 0, 0 // global failure count, global test cases count
+// Test suites in a JUnit report can be grouped by any of these.
+const (
+	JUnitGroupByCategory = "category"
+	JUnitGroupByFile     = "file"
+	JUnitGroupByRule     = "rule"
+)
 
-	for _, val := range cats {
-		categoryResults := resultSet.GetResultsByRuleCategory(val.Id)
-		f := 0
-		var tc []*TestCase
+// JUnitGroupByOptions are all the ways test suites can be grouped in a JUnit report.
+var JUnitGroupByOptions = []string{JUnitGroupByFile, JUnitGroupByCategory, JUnitGroupByRule}
 
-		for _, r := range categoryResults {
-			line := 1
-			if r.StartNode != nil {
-				line = r.StartNode.Line
-			}
+// IsValidJUnitGroupBy returns true if groupBy is a known way of grouping test suites.
+func IsValidJUnitGroupBy(groupBy string) bool {
+	for _, g := range JUnitGroupByOptions {
+		if g == groupBy {
+			return true
+		}
+	}
+	return false
+}
 
-			file := ""
-			if r.Origin != nil && r.Origin.AbsoluteLocation != "" {
-				file = r.Origin.AbsoluteLocation
-			} else if len(args) > 0 {
-				file = args[0]
-			}
+// The JUnit outcome of a result, used when mapping severities to outcomes.
+const (
+	JUnitOutcomeFailure = "failure"
+	JUnitOutcomeSkipped = "skipped"
+	JUnitOutcomePass    = "pass"
+)
 
-			// Prepare template data
-			templateData := struct {
-				File     string
-				Line     int
-				Path     string
-				RuleId   string
-				Severity string
-				Message  string
-			}{
-				File:     file,
-				Line:     line,
-				Path:     r.Path,
-				RuleId:   r.Rule.Id,
-				Severity: r.Rule.Severity,
-				Message:  r.Message,
-			}
+var junitOutcomes = map[string]junitOutcome{
+	JUnitOutcomeFailure: junitFailed,
+	JUnitOutcomeSkipped: junitSkipped,
+	JUnitOutcomePass:    junitPassed,
+}
 
-			var sb bytes.Buffer
-			err := parsedTemplate.Execute(&sb, templateData)
-			if err != nil {
-				// Handle error, e.g., log it or skip this test case
-				continue
-			}
+// ParseJUnitSeverityMap parses 'severity=outcome' pairs (like 'warn=skipped') into a severity to outcome map.
+func ParseJUnitSeverityMap(pairs []string) (map[string]string, error) {
+	outcomes := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		severity, outcome, ok := strings.Cut(pair, "=")
+		severity, outcome = strings.TrimSpace(severity), strings.TrimSpace(outcome)
+		if !ok || severity == "" {
+			return nil, fmt.Errorf("invalid severity mapping '%s', expected 'severity=outcome'", pair)
+		}
+		switch severity {
+		case model.SeverityError, model.SeverityWarn, model.SeverityInfo, model.SeverityHint:
+		default:
+			return nil, fmt.Errorf("unknown severity '%s' in mapping '%s'", severity, pair)
+		}
+		if _, known := junitOutcomes[outcome]; !known {
+			return nil, fmt.Errorf("unknown outcome '%s' in mapping '%s', expected %s, %s or %s", outcome, pair,
+				JUnitOutcomeFailure, JUnitOutcomeSkipped, JUnitOutcomePass)
+		}
+		outcomes[severity] = outcome
+	}
+	return outcomes, nil
+}
 
-			if r.Rule.Severity == model.SeverityError || r.Rule.Severity == model.SeverityWarn {
-				f++
-				gf++
-			}
+// JUnitReportConfig configures how a JUnit report is rendered, a nil config uses the defaults.
+type JUnitReportConfig struct {
+	FailureThreshold *model.FailureThreshold // decides which results are failures, errors and warnings by default.
+	GroupBy          string                  // file, category or rule, the builder picks a default when empty.
+	FailureTemplate  string                  // Go template for the contents of a failure, see JUnitFailureData.
+	Spec             []byte                  // the specification of a single file report, used for snippets.
+	SeverityOutcomes map[string]string       // severity to outcome (failure, skipped or pass), beats the threshold.
+	CodeFrames       *model.CodeFrameOptions // how code frames are cut from Spec, results that captured one use it.
+	RuleTimings      []*reports.RuleTiming   // how long each rule of a single file report took, to time test cases.
+}
 
-			// Create test case name with rule and location info
-			testCaseName := fmt.Sprintf("Rule: %s - JSON Path: %s", r.Rule.Id, r.Path)
-			if len(testCaseName) > 200 { // Prevent excessively long names
-				testCaseName = testCaseName[:200] + "..."
-			}
+// outcome decides what a result of the given severity is reported as. A mapped severity wins, anything else is
+// a failure if it breaks the threshold, or skipped if it does not.
+func (c *JUnitReportConfig) outcome(severity string) junitOutcome {
+	if c != nil {
+		if o, ok := junitOutcomes[c.SeverityOutcomes[severity]]; ok {
+			return o
+		}
+	}
+	if c.failureThreshold().IsFailure(severity) {
+		return junitFailed
+	}
+	return junitSkipped
+}
 
-			tCase := &TestCase{
-				Name:      testCaseName, // This should now be the descriptive name
-				ClassName: fmt.Sprintf("oas-linter.%s", r.Rule.Id),
-				Failure: &Failure{
-					Message:  r.Message,
-					Type:     strings.ToUpper(r.Rule.Severity),
-					Contents: sb.String(),
-				},
-				Properties: &Properties{
-					Properties: []*Property{
-						{Name: "rule", Value: r.Rule.Id},
-						{Name: "severity", Value: r.Rule.Severity},
-						{Name: "line", Value: fmt.Sprintf("%d", line)},
-						{Name: "file", Value: file},
-						{Name: "json_path", Value: r.Path},
-					},
-				},
-			}
-			tc = append(tc, tCase)
+func (c *JUnitReportConfig) failureThreshold() *model.FailureThreshold {
+	if c == nil || c.FailureThreshold == nil {
+		return model.DefaultJUnitFailureThreshold
+	}
+	return c.FailureThreshold
+}
+
+func (c *JUnitReportConfig) codeFrameOptions() model.CodeFrameOptions {
+	if c == nil || c.CodeFrames == nil {
+		return model.DefaultCodeFrameOptions
+	}
+	return *c.CodeFrames
+}
+
+func (c *JUnitReportConfig) failureTemplate() string {
+	if c == nil || c.FailureTemplate == "" {
+		return DefaultJUnitFailureTemplate
+	}
+	return c.FailureTemplate
+}
+
+func (c *JUnitReportConfig) groupBy(defaultGroup string) string {
+	if c == nil || !IsValidJUnitGroupBy(c.GroupBy) {
+		return defaultGroup
+	}
+	return c.GroupBy
+}
+
+func BuildJUnitReport(resultSet *model.RuleResultSet, t time.Time, args []string) []byte {
+	return BuildJUnitReportWithConfig(resultSet, t, args, nil)
+}
+
+// BuildJUnitReportWithConfig renders a RuleResultSet as JUnit XML, with a test suite for each rule category
+// unless the config groups suites by something else.
+func BuildJUnitReportWithConfig(resultSet *model.RuleResultSet, t time.Time, args []string, config *JUnitReportConfig) []byte {
+	fileName := ""
+	if len(args) > 0 {
+		fileName = args[0]
+	}
+	files := singleFileReport(resultSet, fileName)
+	if config != nil {
+		files[0].Spec = config.Spec
+		if config.RuleTimings != nil {
+			files[0].Statistics = &reports.ReportStatistics{RuleTimings: config.RuleTimings}
 		}
+	}
+	return buildJUnitReport(files, t, config.groupBy(JUnitGroupByCategory), config)
+}
+
+// BuildJUnitReportForFiles renders the results of a multi-file run as JUnit XML, with a test suite for each
+// specification that was linted, unless the config groups suites by something else.
+func BuildJUnitReportForFiles(files []*FileReport, t time.Time, config *JUnitReportConfig) []byte {
+	return buildJUnitReport(files, t, config.groupBy(JUnitGroupByFile), config)
+}
+
+type junitSuiteBuilder struct {
+	start     time.Time
+	since     time.Duration
+	tmpl      *template.Template
+	config    *JUnitReportConfig
+	specLines map[string][]string
+	ruleTimes map[string]map[string]float64 // seconds per result, by file and rule.
+	suites    []*TestSuite
+}
 
-		if len(tc) > 0 {
-			ts := &TestSuite{
-				Name:      fmt.Sprintf("OAS Linting - %s", val.Name), // Improved suite name
-				Tests:     len(categoryResults),
-				Failures:  f,
-				Time:      since.Seconds(),
-				TestCases: tc,
+// add builds a suite from a set of results, empty suites are only kept when keepEmpty is set.
+func (b *junitSuiteBuilder) add(name string, results []fileResult, keepEmpty bool) {
+	f, sk := 0, 0
+	elapsed := 0.0
+	tc := []*TestCase{}
+	for _, fr := range results {
+		tCase, outcome := buildJUnitTestCase(fr.result, fr.fileName, b.specLines[fr.fileName], b.tmpl, b.config)
+		if tCase == nil {
+			continue
+		}
+		tCase.Time = b.ruleTimes[fr.fileName][fr.result.Rule.Id]
+		elapsed += tCase.Time
+		switch outcome {
+		case junitFailed:
+			f++
+		case junitSkipped:
+			sk++
+		}
+		tc = append(tc, tCase)
+	}
+	if len(tc) == 0 && !keepEmpty {
+		return
+	}
+	// a suite takes as long as its test cases, without rule timings there is only the time of the whole run.
+	if len(b.ruleTimes) == 0 {
+		elapsed = b.since.Seconds()
+	}
+	ts := &TestSuite{
+		Name:      fmt.Sprintf("OAS Linting - %s", name),
+		Timestamp: b.start.Format(JUnitTimestampFormat),
+		Tests:     len(tc),
+		Failures:  f,
+		Skipped:   sk,
+		Time:      elapsed,
+		TestCases: tc,
+	}
+	b.suites = append(b.suites, ts)
+}
+
+func buildJUnitReport(files []*FileReport, t time.Time, groupBy string, config *JUnitReportConfig) []byte {
+	parsedTemplate, err := template.New("failure").Parse(config.failureTemplate())
+	if err != nil {
+		// Handle error, e.g., log it or return an empty report
+		return []byte{}
+	}
+	b := &junitSuiteBuilder{start: t, since: time.Since(t), tmpl: parsedTemplate,
+		config: config, specLines: make(map[string][]string), ruleTimes: make(map[string]map[string]float64)}
+	for _, file := range files {
+		if file != nil && file.Spec != nil {
+			b.specLines[file.FileName] = strings.Split(string(file.Spec), "\n")
+		}
+		if file != nil && file.Statistics != nil && len(file.Statistics.RuleTimings) > 0 {
+			b.ruleTimes[file.FileName] = junitRuleTimes(file.Statistics.RuleTimings)
+		}
+	}
+
+	switch groupBy {
+	case JUnitGroupByFile:
+		for _, file := range files {
+			if file == nil || file.ResultSet == nil {
+				continue
+			}
+			var results []fileResult
+			for _, val := range file.ResultSet.GetRuleCategories() {
+				for _, r := range file.ResultSet.GetResultsByRuleCategory(val.Id) {
+					results = append(results, fileResult{result: r, fileName: file.FileName})
+				}
+			}
+			// every specification gets a suite, so a clean file is still reported as passing.
+			b.add(file.FileName, results, true)
+		}
+	case JUnitGroupByRule:
+		byRule := make(map[string][]fileResult)
+		var ruleIds []string
+		for _, fr := range collectFileResults(files) {
+			if _, ok := byRule[fr.result.Rule.Id]; !ok {
+				ruleIds = append(ruleIds, fr.result.Rule.Id)
+			}
+			byRule[fr.result.Rule.Id] = append(byRule[fr.result.Rule.Id], fr)
+		}
+		sort.Strings(ruleIds)
+		for _, id := range ruleIds {
+			b.add(id, byRule[id], false)
+		}
+	default:
+		for _, val := range junitRuleCategories(files) {
+			var results []fileResult
+			for _, file := range files {
+				if file == nil || file.ResultSet == nil {
+					continue
+				}
+				for _, r := range file.ResultSet.GetResultsByRuleCategory(val.Id) {
+					results = append(results, fileResult{result: r, fileName: file.FileName})
+				}
 			}
-			suites = append(suites, ts)
+			b.add(val.Name, results, false)
 		}
-		gtc += len(tc)
 	}
 
-	allSuites := &TestSuites{
-		TestSuites: suites,
+	gf, gs, gtc := 0, 0, 0 // global failure count, global skipped count, global test cases count
+	for _, ts := range b.suites {
+		gf += ts.Failures
+		gs += ts.Skipped
+		gtc += ts.Tests
+	}
+
+	return encodeJUnitSuites(&TestSuites{
+		TestSuites: b.suites,
 		Tests:      gtc,
 		Failures:   gf,
-		Time:       since.Seconds(),
+		Skipped:    gs,
+		Time:       b.since.Seconds(),
+	})
+}
+
+// junitRuleCategories returns the categories of every file, in order, files linted with the same ruleset share them.
+func junitRuleCategories(files []*FileReport) []*model.RuleCategory {
+	var categories []*model.RuleCategory
+	for _, file := range files {
+		if file != nil && file.ResultSet != nil {
+			categories = append(categories, file.ResultSet.GetRuleCategories()...)
+		}
+	}
+	return model.OrderRuleCategories(categories)
+}
+
+// buildJUnitTestCase converts a single result into a test case, the config decides if the result is a failure, is
+// skipped or passes. Only failures carry a failure.
+func buildJUnitTestCase(r *model.RuleFunctionResult, fileName string, specLines []string, tmpl *template.Template,
+	config *JUnitReportConfig) (*TestCase, junitOutcome) {
+	line := 1
+	if r.StartNode != nil {
+		line = r.StartNode.Line
+	}
+
+	file := fileName
+	if r.Origin != nil && r.Origin.AbsoluteLocation != "" {
+		file = r.Origin.AbsoluteLocation
+	}
+
+	// Prepare template data
+	templateData := JUnitFailureData{
+		File:     file,
+		Line:     line,
+		Path:     r.Path,
+		RuleId:   r.Rule.Id,
+		Severity: r.Rule.Severity,
+		Message:  r.Message,
+	}
+	if specLines != nil && r.StartNode != nil {
+		templateData.Snippet = utils.RenderCodeSnippet(r.StartNode, specLines, 3, 3)
+	}
+
+	var sb bytes.Buffer
+	err := tmpl.Execute(&sb, templateData)
+	if err != nil {
+		// Handle error, e.g., log it or skip this test case
+		return nil, junitPassed
+	}
+
+	// contents are written as inner XML, so anything that looks like markup in a message must be escaped.
+	var contents bytes.Buffer
+	_ = xml.EscapeText(&contents, sb.Bytes())
+
+	// Create test case name with rule and location info
+	testCaseName := fmt.Sprintf("Rule: %s - JSON Path: %s", r.Rule.Id, r.Path)
+	if len(testCaseName) > 200 { // Prevent excessively long names
+		testCaseName = testCaseName[:200] + "..."
+	}
+
+	// the relative path keeps reports portable between machines, so CI servers can link back to the source.
+	relFile := ""
+	if file != "" {
+		relFile = filepath.ToSlash(resultLocation(r, fileName))
 	}
 
+	tCase := &TestCase{
+		Name:      testCaseName, // This should now be the descriptive name
+		ClassName: fmt.Sprintf("oas-linter.%s", r.Rule.Id),
+		File:      relFile,
+		Properties: &Properties{
+			Properties: []*Property{
+				{Name: "rule", Value: r.Rule.Id},
+				{Name: "severity", Value: r.Rule.Severity},
+				{Name: "line", Value: fmt.Sprintf("%d", line)},
+				{Name: "file", Value: file},
+				{Name: "json_path", Value: r.Path},
+				{Name: "documentation", Value: r.Rule.GetDocumentationURL()},
+			},
+		},
+	}
+
+	if len(r.Rule.Tags) > 0 {
+		tCase.Properties.Properties = append(tCase.Properties.Properties,
+			&Property{Name: "tags", Value: strings.Join(r.Rule.Tags, ",")})
+	}
+	if frame := model.CodeFrameForResult(r, fileName, specLines, config.codeFrameOptions()); frame != nil {
+		tCase.SystemOut = frame.String()
+	}
+
+	switch config.outcome(r.Rule.Severity) {
+	case junitPassed:
+		return tCase, junitPassed
+	case junitSkipped:
+		tCase.Skipped = &Skipped{Message: r.Message}
+		return tCase, junitSkipped
+	}
+	tCase.Failure = &Failure{
+		Message:  r.Message,
+		Type:     strings.ToUpper(r.Rule.Severity),
+		Contents: contents.String(),
+	}
+	return tCase, junitFailed
+}
+
+// junitRuleTimes converts rule timings into the time of each test case, in seconds. Rules are timed rather than
+// results, so the results of a rule share the time it took.
+func junitRuleTimes(timings []*reports.RuleTiming) map[string]float64 {
+	times := make(map[string]float64, len(timings))
+	for _, rt := range timings {
+		if rt == nil {
+			continue
+		}
+		n := rt.Results
+		if n < 1 {
+			n = 1
+		}
+		times[rt.RuleId] = rt.DurationMs / 1000 / float64(n)
+	}
+	return times
+}
+
+func encodeJUnitSuites(allSuites *TestSuites) []byte {
 	// Add XML declaration
 	var buf bytes.Buffer
 	buf.WriteString(xml.Header)
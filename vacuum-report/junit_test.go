@@ -6,6 +6,7 @@ package vacuum_report
 import (
 	"encoding/xml"
 	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/stretchr/testify/assert"
 	"gopkg.in/yaml.v3"
 	"testing"
@@ -73,6 +74,14 @@ func TestBuildJUnitReport(t *testing.T) {
 				assert.Contains(t, tc.Failure.Contents, "testing, 123")
 				assert.Contains(t, tc.Failure.Contents, "File: test")
 				assert.Contains(t, tc.Failure.Contents, "Line: 1")
+				assert.Nil(t, tc.Skipped)
+				assert.Equal(t, 0, suites.Skipped)
+			} else {
+				assert.Nil(t, tc.Failure, "skipped results must not carry a failure")
+				assert.NotNil(t, tc.Skipped)
+				assert.Equal(t, "testing, 123", tc.Skipped.Message)
+				assert.Equal(t, 1, suite.Skipped)
+				assert.Equal(t, 1, suites.Skipped)
 			}
 
 			// Properties checks
@@ -171,3 +180,237 @@ func buildFakeResultSet(
 
 	return rs
 }
+
+func TestBuildJUnitReportWithConfig_FailureThreshold(t *testing.T) {
+	rs := buildFakeResultSet("testing, 123", "$.somewhere", "one",
+		model.SeverityWarn, model.CategoryOperations, "Operations", "test", 1)
+
+	var suites TestSuites
+	data := BuildJUnitReportWithConfig(rs, time.Now(), []string{"test"}, nil)
+	assert.NoError(t, xml.Unmarshal(data, &suites))
+	assert.Equal(t, 1, suites.Failures)
+
+	// only errors are failures, so the warning no longer counts.
+	data = BuildJUnitReportWithConfig(rs, time.Now(), []string{"test"}, &JUnitReportConfig{
+		FailureThreshold: model.NewFailureThreshold(model.SeverityError, -1),
+	})
+	assert.NoError(t, xml.Unmarshal(data, &suites))
+	assert.Equal(t, 0, suites.Failures)
+}
+
+func TestBuildJUnitReport_TimeAndFileAttributes(t *testing.T) {
+	rs := buildFakeResultSet("testing, 123", "$.somewhere", "one",
+		model.SeverityWarn, model.CategoryOperations, "Operations", "test", 1)
+
+	start := time.Date(2025, 3, 4, 10, 11, 12, 0, time.Local)
+	data := BuildJUnitReport(rs, start, []string{"specs/petstore.yaml"})
+
+	var suites TestSuites
+	assert.NoError(t, xml.Unmarshal(data, &suites))
+	assert.Len(t, suites.TestSuites, 1)
+	suite := suites.TestSuites[0]
+	assert.Equal(t, "2025-03-04T10:11:12", suite.Timestamp)
+	assert.Len(t, suite.TestCases, 1)
+	assert.Equal(t, "specs/petstore.yaml", suite.TestCases[0].File)
+	// a result isn't timed, so the test case has no time rather than a made up one.
+	assert.Zero(t, suite.TestCases[0].Time)
+	assert.Contains(t, string(data), `file="specs/petstore.yaml"`)
+	assert.NotContains(t, string(data), `file="specs/petstore.yaml" time=`)
+}
+
+func TestBuildJUnitReport_TestCaseTimes(t *testing.T) {
+	rs := buildFakeResultSet("testing, 123", "$.somewhere", "one",
+		model.SeverityWarn, model.CategoryOperations, "Operations", "test", 1)
+	rs.Results = append(rs.Results, rs.Results[0])
+
+	data := BuildJUnitReportWithConfig(rs, time.Now(), []string{"specs/petstore.yaml"}, &JUnitReportConfig{
+		RuleTimings: []*reports.RuleTiming{{RuleId: "one", DurationMs: 500, Results: 2}},
+	})
+
+	var suites TestSuites
+	assert.NoError(t, xml.Unmarshal(data, &suites))
+	assert.Len(t, suites.TestSuites[0].TestCases, 2)
+	// the rule took half a second, and found both results.
+	assert.Equal(t, 0.25, suites.TestSuites[0].TestCases[0].Time)
+	assert.Contains(t, string(data), `file="specs/petstore.yaml" time="0.25"`)
+
+	files := []*FileReport{{FileName: "petstore.yaml", ResultSet: rs, Statistics: &reports.ReportStatistics{
+		RuleTimings: []*reports.RuleTiming{{RuleId: "one", DurationMs: 1000, Results: 2}},
+	}}}
+	data = BuildJUnitReportForFiles(files, time.Now().Add(-time.Minute), nil)
+	assert.Contains(t, string(data), `time="0.5"`)
+
+	// a suite takes as long as its test cases, not as long as the whole run.
+	var fileSuites TestSuites
+	assert.NoError(t, xml.Unmarshal(data, &fileSuites))
+	assert.Len(t, fileSuites.TestSuites, 1)
+	assert.Equal(t, 1.0, fileSuites.TestSuites[0].Time)
+	assert.GreaterOrEqual(t, fileSuites.Time, 60.0)
+}
+
+func TestBuildJUnitReportForFiles_GroupBy(t *testing.T) {
+	petstore := buildFakeResultSet("bad", "$.paths", "one",
+		model.SeverityError, model.CategoryOperations, "Operations", "", 1)
+	burgers := buildFakeResultSet("worse", "$.info", "two",
+		model.SeverityWarn, model.CategoryInfo, "Info", "", 2)
+	files := []*FileReport{
+		{FileName: "petstore.yaml", ResultSet: petstore},
+		{FileName: "burgers.yaml", ResultSet: burgers},
+		{FileName: "clean.yaml", ResultSet: model.NewRuleResultSet(nil)},
+	}
+
+	names := func(groupBy string) []string {
+		var suites TestSuites
+		data := BuildJUnitReportForFiles(files, time.Now(), &JUnitReportConfig{GroupBy: groupBy})
+		assert.NoError(t, xml.Unmarshal(data, &suites))
+		assert.Equal(t, 2, suites.Tests)
+		assert.Equal(t, 2, suites.Failures)
+		var n []string
+		for _, s := range suites.TestSuites {
+			n = append(n, s.Name)
+		}
+		return n
+	}
+
+	assert.Equal(t, []string{"OAS Linting - petstore.yaml", "OAS Linting - burgers.yaml",
+		"OAS Linting - clean.yaml"}, names(""))
+	assert.Equal(t, []string{"OAS Linting - petstore.yaml", "OAS Linting - burgers.yaml",
+		"OAS Linting - clean.yaml"}, names(JUnitGroupByFile))
+	assert.Equal(t, []string{"OAS Linting - one", "OAS Linting - two"}, names(JUnitGroupByRule))
+	assert.Len(t, names(JUnitGroupByCategory), 2)
+}
+
+func TestIsValidJUnitGroupBy(t *testing.T) {
+	assert.True(t, IsValidJUnitGroupBy(JUnitGroupByRule))
+	assert.False(t, IsValidJUnitGroupBy("owner"))
+}
+
+func TestBuildJUnitReportWithConfig_FailureTemplate(t *testing.T) {
+	rs := buildFakeResultSet("no <description>", "$.info", "one",
+		model.SeverityError, model.CategoryInfo, "Info", "", 2)
+
+	data := BuildJUnitReportWithConfig(rs, time.Now(), []string{"test.yaml"}, &JUnitReportConfig{
+		FailureTemplate: "{{ .RuleId }}: {{ .Message }} see https://runbooks.example.com/{{ .RuleId }}\n{{ .Snippet }}",
+		Spec:            []byte("openapi: 3.1.0\ninfo:\n  title: test\n"),
+	})
+
+	var suites TestSuites
+	assert.NoError(t, xml.Unmarshal(data, &suites))
+	tc := suites.TestSuites[0].TestCases[0]
+	// contents are raw inner XML, so the message is still escaped here.
+	assert.Contains(t, tc.Failure.Contents, "one: no &lt;description&gt; see https://runbooks.example.com/one")
+	assert.Contains(t, tc.Failure.Contents, "title: test")
+}
+
+func TestParseJUnitFailureTemplate(t *testing.T) {
+	_, err := ParseJUnitFailureTemplate("{{ .RuleId }} {{ .Snippet }}")
+	assert.NoError(t, err)
+	_, err = ParseJUnitFailureTemplate("{{ .Ticket }}")
+	assert.Error(t, err)
+	_, err = ParseJUnitFailureTemplate("{{ .RuleId ")
+	assert.Error(t, err)
+}
+
+func TestBuildJUnitReportWithConfig_SeverityOutcomes(t *testing.T) {
+	outcome := func(severity string, outcomes map[string]string) (*TestCase, *TestSuites) {
+		rs := buildFakeResultSet("testing, 123", "$.somewhere", "one",
+			severity, model.CategoryOperations, "Operations", "test", 1)
+		var suites TestSuites
+		data := BuildJUnitReportWithConfig(rs, time.Now(), []string{"test"}, &JUnitReportConfig{
+			SeverityOutcomes: outcomes,
+		})
+		assert.NoError(t, xml.Unmarshal(data, &suites))
+		return suites.TestSuites[0].TestCases[0], &suites
+	}
+
+	// warnings break pipelines by default.
+	tc, suites := outcome(model.SeverityWarn, nil)
+	assert.NotNil(t, tc.Failure)
+	assert.Equal(t, 1, suites.Failures)
+
+	tc, suites = outcome(model.SeverityWarn, map[string]string{model.SeverityWarn: JUnitOutcomeSkipped})
+	assert.Nil(t, tc.Failure)
+	assert.NotNil(t, tc.Skipped)
+	assert.Equal(t, 0, suites.Failures)
+	assert.Equal(t, 1, suites.Skipped)
+
+	tc, suites = outcome(model.SeverityInfo, map[string]string{model.SeverityInfo: JUnitOutcomePass})
+	assert.Nil(t, tc.Failure)
+	assert.Nil(t, tc.Skipped)
+	assert.Equal(t, 1, suites.Tests)
+	assert.Equal(t, 0, suites.Skipped)
+
+	tc, _ = outcome(model.SeverityHint, map[string]string{model.SeverityHint: JUnitOutcomeFailure})
+	assert.NotNil(t, tc.Failure)
+}
+
+func TestParseJUnitSeverityMap(t *testing.T) {
+	m, err := ParseJUnitSeverityMap([]string{"warn=skipped", " info = pass "})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"warn": JUnitOutcomeSkipped, "info": JUnitOutcomePass}, m)
+
+	_, err = ParseJUnitSeverityMap([]string{"warn"})
+	assert.Error(t, err)
+	_, err = ParseJUnitSeverityMap([]string{"fatal=failure"})
+	assert.Error(t, err)
+	_, err = ParseJUnitSeverityMap([]string{"warn=ignored"})
+	assert.Error(t, err)
+}
+
+func TestBuildJUnitReport_TagProperties(t *testing.T) {
+	rs := buildFakeResultSet("testing, 123", "$.somewhere", "one",
+		model.SeverityWarn, model.CategoryOperations, "Operations", "test", 1)
+	rs.Results[0].Rule.Tags = []string{"security", "style"}
+
+	data := BuildJUnitReport(rs, time.Now(), []string{"test"})
+	assert.Contains(t, string(data), `<property name="tags" value="security,style"></property>`)
+}
+
+func TestBuildJUnitReport_DocumentationProperty(t *testing.T) {
+	rs := buildFakeResultSet("testing, 123", "$.somewhere", "one",
+		model.SeverityError, model.CategoryOperations, "Operations", "test", 1)
+	rs.Results[0].Rule.DocumentationURL = "https://example.com/rules/one"
+
+	data := BuildJUnitReport(rs, time.Now(), []string{"test"})
+	assert.Contains(t, string(data), `<property name="documentation" value="https://example.com/rules/one"></property>`)
+}
+
+func TestBuildJUnitReportForFiles_RuleSetCategory(t *testing.T) {
+	naming := &model.RuleCategory{Id: "junit-naming", Name: "Naming", Order: 1}
+	tags := model.NewRuleSetCategory(&model.RuleCategory{Id: model.CategoryTags, Name: "Navigation"})
+	rs := buildFakeResultSet("bad", "$.paths", "camel-case",
+		model.SeverityError, model.CategoryOperations, "Operations", "", 1)
+	rs.Results[0].Rule.RuleCategory = naming
+	rs.Results = append(rs.Results, &model.RuleFunctionResult{Message: "no tags", Path: "$.tags",
+		Rule: &model.Rule{Id: "openapi-tags", Severity: model.SeverityWarn,
+			RuleCategory: model.RuleCategories[model.CategoryTags]}})
+	rs.CategoryMap = make(map[*model.RuleCategory][]*model.RuleFunctionResult)
+	rs.RuleSetCategories = []*model.RuleCategory{naming, tags}
+
+	var suites TestSuites
+	data := BuildJUnitReportForFiles([]*FileReport{{FileName: "petstore.yaml", ResultSet: rs}}, time.Now(),
+		&JUnitReportConfig{GroupBy: JUnitGroupByCategory})
+	assert.NoError(t, xml.Unmarshal(data, &suites))
+	assert.Len(t, suites.TestSuites, 2)
+	assert.Equal(t, "OAS Linting - Naming", suites.TestSuites[0].Name)
+	assert.Equal(t, "OAS Linting - Navigation", suites.TestSuites[1].Name)
+	assert.Equal(t, "Tags", model.RuleCategories[model.CategoryTags].Name)
+}
+
+func TestBuildJUnitReportForFiles_CodeFrame(t *testing.T) {
+	rs := buildFakeResultSet("missing description", "$.info", "info-description",
+		model.SeverityError, model.CategoryInfo, "Info", "", 2)
+	rs.Results[0].StartNode.Column = 3
+	spec := []byte("openapi: 3.1.0\ninfo:\n  title: pets\n  version: 1.0.0\n")
+
+	var suites TestSuites
+	data := BuildJUnitReportForFiles([]*FileReport{{FileName: "petstore.yaml", ResultSet: rs, Spec: spec}},
+		time.Now(), &JUnitReportConfig{CodeFrames: &model.CodeFrameOptions{ContextLines: 1}})
+	assert.NoError(t, xml.Unmarshal(data, &suites))
+	assert.Equal(t, "  1 | openapi: 3.1.0\n> 2 | info:\n    |   ^\n  3 |   title: pets\n",
+		suites.TestSuites[0].TestCases[0].SystemOut)
+
+	// without the specification, there is nothing to frame.
+	data = BuildJUnitReport(rs, time.Now(), []string{"test"})
+	assert.NotContains(t, string(data), "<system-out>")
+}
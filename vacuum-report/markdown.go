@@ -0,0 +1,201 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/utils"
+)
+
+// MarkdownTopRules is the number of rules listed in the 'top offending rules' table.
+const MarkdownTopRules = 10
+
+// MarkdownResultsPerCategory caps the results rendered for each category, GitHub step summaries are limited to 1MB.
+const MarkdownResultsPerCategory = 50
+
+type severityCounts struct {
+	errors, warnings, info, hints int
+}
+
+func (s *severityCounts) add(severity string) {
+	switch severity {
+	case model.SeverityError:
+		s.errors++
+	case model.SeverityWarn:
+		s.warnings++
+	case model.SeverityInfo:
+		s.info++
+	default:
+		s.hints++
+	}
+}
+
+func (s *severityCounts) row(name string) []string {
+	return []string{name, fmt.Sprint(s.errors), fmt.Sprint(s.warnings), fmt.Sprint(s.info), fmt.Sprint(s.hints)}
+}
+
+// BuildMarkdownReport renders a RuleResultSet as a markdown summary, ready to be piped into $GITHUB_STEP_SUMMARY
+// or posted as a pull request comment. Statistics are optional, if supplied the overall score is rendered.
+func BuildMarkdownReport(resultSet *model.RuleResultSet, stats *reports.ReportStatistics, fileName string) []byte {
+	var sb strings.Builder
+
+	if fileName != "" {
+		sb.WriteString(fmt.Sprintf("## vacuum report: `%s`\n\n", fileName))
+	} else {
+		sb.WriteString("## vacuum report\n\n")
+	}
+
+	if stats != nil {
+		sb.WriteString(fmt.Sprintf("**Quality score: %d/100**\n\n", stats.OverallScore))
+	}
+
+	total := &severityCounts{}
+	categories := make(map[string]*severityCounts)
+	categoryResults := make(map[string][]*model.RuleFunctionResult)
+	ruleCounts := make(map[string]int)
+	ruleSeverity := make(map[string]string)
+
+	for _, r := range resultSet.Results {
+		if r.Rule == nil {
+			continue
+		}
+		total.add(r.Rule.Severity)
+		ruleCounts[r.Rule.Id]++
+		ruleSeverity[r.Rule.Id] = r.Rule.Severity
+
+		catId := model.CategoryAll
+		if r.Rule.RuleCategory != nil {
+			catId = r.Rule.RuleCategory.Id
+		}
+		if categories[catId] == nil {
+			categories[catId] = &severityCounts{}
+		}
+		categories[catId].add(r.Rule.Severity)
+		categoryResults[catId] = append(categoryResults[catId], r)
+	}
+
+	if len(ruleCounts) == 0 {
+		sb.WriteString("No issues found, nice work! :tada:\n\n")
+		writeMarkdownSuppressed(&sb, resultSet)
+		return []byte(sb.String())
+	}
+
+	severityHeaders := []string{"", "Errors", "Warnings", "Info", "Hints"}
+	sb.WriteString(utils.RenderMarkdownTable(severityHeaders, [][]string{total.row("**Total**")}))
+	sb.WriteString("\n")
+
+	// categories, in the same order as every other report.
+	var catRows [][]string
+	var catOrder []*model.RuleCategory
+	listed := make(map[string]bool)
+	for _, cat := range resultSet.GetRuleCategories() {
+		if c, ok := categories[cat.Id]; ok {
+			catRows = append(catRows, c.row(cat.Name))
+			catOrder = append(catOrder, cat)
+			listed[cat.Id] = true
+		}
+	}
+	var unknown []string
+	for id := range categories {
+		if !listed[id] {
+			unknown = append(unknown, id)
+		}
+	}
+	sort.Strings(unknown)
+	for _, id := range unknown {
+		catRows = append(catRows, categories[id].row(id))
+		catOrder = append(catOrder, &model.RuleCategory{Id: id, Name: id})
+	}
+	severityHeaders[0] = "Category"
+	sb.WriteString("### Categories\n\n")
+	sb.WriteString(utils.RenderMarkdownTable(severityHeaders, catRows))
+	sb.WriteString("\n")
+
+	// top offending rules
+	var ruleIds []string
+	for id := range ruleCounts {
+		ruleIds = append(ruleIds, id)
+	}
+	sort.Slice(ruleIds, func(i, j int) bool {
+		if ruleCounts[ruleIds[i]] != ruleCounts[ruleIds[j]] {
+			return ruleCounts[ruleIds[i]] > ruleCounts[ruleIds[j]]
+		}
+		return ruleIds[i] < ruleIds[j]
+	})
+	if len(ruleIds) > MarkdownTopRules {
+		ruleIds = ruleIds[:MarkdownTopRules]
+	}
+	var ruleRows [][]string
+	for _, id := range ruleIds {
+		ruleRows = append(ruleRows, []string{fmt.Sprintf("`%s`", id), ruleSeverity[id], fmt.Sprint(ruleCounts[id])})
+	}
+	sb.WriteString("### Top offending rules\n\n")
+	sb.WriteString(utils.RenderMarkdownTable([]string{"Rule", "Severity", "Results"}, ruleRows))
+	sb.WriteString("\n")
+
+	// results, grouped by category and folded away so the summary stays readable.
+	for _, cat := range catOrder {
+		results := categoryResults[cat.Id]
+		sb.WriteString(fmt.Sprintf("<details>\n<summary>%s (%d)</summary>\n\n", cat.Name, len(results)))
+		var rows [][]string
+		for i, r := range results {
+			if i >= MarkdownResultsPerCategory {
+				break
+			}
+			line := 1
+			if r.StartNode != nil {
+				line = r.StartNode.Line
+			}
+			rows = append(rows, []string{fmt.Sprint(line), r.Rule.Severity, fmt.Sprintf("`%s`", r.Rule.Id),
+				escapeMarkdownCell(r.Message), escapeMarkdownCell(r.Path)})
+		}
+		sb.WriteString(utils.RenderMarkdownTable([]string{"Line", "Severity", "Rule", "Message", "Path"}, rows))
+		if len(results) > MarkdownResultsPerCategory {
+			sb.WriteString(fmt.Sprintf("\n_...and %d more._\n", len(results)-MarkdownResultsPerCategory))
+		}
+		sb.WriteString("\n</details>\n\n")
+	}
+
+	writeMarkdownSuppressed(&sb, resultSet)
+	return []byte(sb.String())
+}
+
+// writeMarkdownSuppressed renders results suppressed inline by the specification, so they are not forgotten about.
+func writeMarkdownSuppressed(sb *strings.Builder, resultSet *model.RuleResultSet) {
+	if len(resultSet.Suppressed) == 0 {
+		return
+	}
+	sb.WriteString(fmt.Sprintf("<details>\n<summary>Suppressed (%d)</summary>\n\n", len(resultSet.Suppressed)))
+	var rows [][]string
+	for i, r := range resultSet.Suppressed {
+		if i >= MarkdownResultsPerCategory {
+			break
+		}
+		line, ruleId := 1, r.RuleId
+		if r.StartNode != nil {
+			line = r.StartNode.Line
+		}
+		if r.Rule != nil {
+			ruleId = r.Rule.Id
+		}
+		rows = append(rows, []string{fmt.Sprint(line), fmt.Sprintf("`%s`", ruleId),
+			escapeMarkdownCell(r.Message), escapeMarkdownCell(r.Path)})
+	}
+	sb.WriteString(utils.RenderMarkdownTable([]string{"Line", "Rule", "Message", "Path"}, rows))
+	if len(resultSet.Suppressed) > MarkdownResultsPerCategory {
+		sb.WriteString(fmt.Sprintf("\n_...and %d more._\n", len(resultSet.Suppressed)-MarkdownResultsPerCategory))
+	}
+	sb.WriteString("\n</details>\n\n")
+}
+
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	s = strings.ReplaceAll(s, "\r", "")
+	return strings.ReplaceAll(s, "\n", " ")
+}
@@ -0,0 +1,47 @@
+package vacuum_report
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildMarkdownReport(t *testing.T) {
+	rs := buildFakeResultSet("no | description", "$.info", "info-description",
+		model.SeverityWarn, model.CategoryInfo, "Info", "spec.yaml", 7)
+	other := buildFakeResultSet("bad", "$.paths", "path-params",
+		model.SeverityError, model.CategoryOperations, "Operations", "spec.yaml", 20)
+	rs.Results = append(rs.Results, other.Results...)
+	rs.Results = append(rs.Results, other.Results...)
+
+	md := string(BuildMarkdownReport(rs, &reports.ReportStatistics{OverallScore: 42}, "spec.yaml"))
+	assert.Contains(t, md, "## vacuum report: `spec.yaml`")
+	assert.Contains(t, md, "**Quality score: 42/100**")
+	assert.Contains(t, md, "| **Total** | 2      | 1        | 0    | 0     |")
+	assert.Contains(t, md, "### Top offending rules")
+	assert.Contains(t, md, "no \\| description")
+	assert.Contains(t, md, "<summary>Operations (2)</summary>")
+
+	// the most offending rule is listed first.
+	top := md[strings.Index(md, "### Top offending rules"):]
+	assert.Less(t, strings.Index(top, "path-params"), strings.Index(top, "info-description"))
+}
+
+func TestBuildMarkdownReport_NoResults(t *testing.T) {
+	md := string(BuildMarkdownReport(&model.RuleResultSet{}, nil, ""))
+	assert.Contains(t, md, "No issues found")
+	assert.NotContains(t, md, "Quality score")
+}
+
+func TestBuildMarkdownReport_Suppressed(t *testing.T) {
+	rs := &model.RuleResultSet{}
+	rs.SetSuppressedResults([]model.RuleFunctionResult{
+		{Message: "no description", Path: "$.info", Rule: &model.Rule{Id: "info-description"}},
+	})
+	md := string(BuildMarkdownReport(rs, nil, "spec.yaml"))
+	assert.Contains(t, md, "<summary>Suppressed (1)</summary>")
+	assert.Contains(t, md, "`info-description`")
+}
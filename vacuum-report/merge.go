@@ -0,0 +1,117 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"fmt"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/daveshanley/vacuum/statistics"
+)
+
+// MergeReports combines vacuum reports (for example, lint runs that have been sharded across CI jobs) into a single
+// report. Identical findings (the same rule, path and message at the same location) are only kept once, and the
+// statistics and score are worked out again from the merged results. The document statistics (paths, schemas etc.)
+// are taken from the first report that has them, as shards lint the same specification.
+func MergeReports(vacuumReports []*VacuumReport) *VacuumReport {
+	merged := &VacuumReport{
+		ResultSet: &model.RuleResultSet{
+			Results:     []*model.RuleFunctionResult{},
+			CategoryMap: make(map[*model.RuleCategory][]*model.RuleFunctionResult),
+		},
+		Rules: make(map[string]*model.Rule),
+	}
+
+	seen := make(map[string]bool)
+	var docStats *reports.ReportStatistics
+	for _, vr := range vacuumReports {
+		if vr == nil {
+			continue
+		}
+		if vr.Generated.After(merged.Generated) {
+			merged.Generated = vr.Generated
+		}
+		if merged.SpecInfo == nil {
+			merged.SpecInfo = vr.SpecInfo
+		}
+		if docStats == nil {
+			docStats = vr.Statistics
+		}
+		for k, v := range vr.Rules {
+			if merged.Rules[k] == nil {
+				merged.Rules[k] = v
+			}
+		}
+		for _, r := range reportResults(vr) {
+			key := mergeKey(r)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			merged.ResultSet.Results = append(merged.ResultSet.Results, r)
+		}
+		if vr.ResultSet != nil {
+			for _, r := range vr.ResultSet.Suppressed {
+				key := mergeKey(r)
+				if seen["suppressed"+key] {
+					continue
+				}
+				seen["suppressed"+key] = true
+				merged.ResultSet.Suppressed = append(merged.ResultSet.Suppressed, r)
+			}
+		}
+	}
+
+	// results read back from a report may only know the id of their rule, severity and category come from the rule.
+	for _, r := range merged.ResultSet.Results {
+		switch {
+		case r.Rule == nil && merged.Rules[r.RuleId] != nil:
+			r.Rule = merged.Rules[r.RuleId]
+		case r.Rule != nil && merged.Rules[r.RuleId] == nil:
+			merged.Rules[r.RuleId] = r.Rule
+		}
+	}
+	if len(merged.Rules) == 0 {
+		merged.Rules = nil
+	}
+
+	merged.Statistics = mergeStatistics(docStats, merged)
+	return merged
+}
+
+// mergeKey identifies a finding, the baseline fingerprint (rule and path), where it was found and the message, so
+// different findings of a rule at the same place are all kept.
+func mergeKey(r *model.RuleFunctionResult) string {
+	return fmt.Sprintf("%s:%d:%d:%d:%d:%s", model.BaselineFingerprint(r),
+		r.Range.Start.Line, r.Range.Start.Char, r.Range.End.Line, r.Range.End.Char, r.Message)
+}
+
+func mergeStatistics(docStats *reports.ReportStatistics, merged *VacuumReport) *reports.ReportStatistics {
+	stats := &reports.ReportStatistics{}
+	if docStats != nil {
+		*stats = *docStats
+	}
+	specFormat := stats.SpecFormat
+	if merged.SpecInfo != nil && merged.SpecInfo.SpecFormat != "" {
+		specFormat = merged.SpecInfo.SpecFormat
+	}
+	kind := model.DocumentKindForFormat(specFormat)
+	if stats.DocumentKind == "" {
+		stats.DocumentKind = string(kind)
+	}
+
+	results := merged.ResultSet
+	if results.CategoryMap == nil {
+		results.CategoryMap = make(map[*model.RuleCategory][]*model.RuleFunctionResult)
+	}
+	results.ResetCounts()
+	stats.OverallScore = statistics.CalculateQualityScore(results)
+	stats.TotalErrors = results.GetErrorCount()
+	stats.TotalWarnings = results.GetWarnCount()
+	stats.TotalInfo = results.GetInfoCount()
+	stats.TotalHints = len(results.GetHintByRuleCategory(model.CategoryAll))
+	stats.CategoryStatistics = statistics.CreateCategoryStatistics(kind, results)
+	return stats
+}
@@ -0,0 +1,56 @@
+package vacuum_report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeReports(t *testing.T) {
+	warn := &model.Rule{Id: "a", Severity: model.SeverityWarn, RuleCategory: model.RuleCategories[model.CategoryInfo]}
+	fail := &model.Rule{Id: "b", Severity: model.SeverityError, RuleCategory: model.RuleCategories[model.CategorySchemas]}
+
+	shared := &model.RuleFunctionResult{RuleId: "a", Path: "$.info", Message: "shared", Rule: warn}
+	dupe := *shared
+	moved := *shared
+	moved.Range.Start.Line = 20
+	broken := &model.RuleFunctionResult{RuleId: "b", Path: "$.components", Message: "broken"}
+
+	older := time.Now().Add(-time.Hour)
+	newer := time.Now()
+	merged := MergeReports([]*VacuumReport{
+		{
+			Generated:  older,
+			Statistics: &reports.ReportStatistics{Paths: 12, OverallScore: 99},
+			ResultSet:  &model.RuleResultSet{Results: []*model.RuleFunctionResult{shared}},
+		},
+		{
+			Generated: newer,
+			ResultSet: &model.RuleResultSet{Results: []*model.RuleFunctionResult{&dupe, &moved, broken}},
+			Rules:     map[string]*model.Rule{"b": fail},
+		},
+		nil,
+	})
+
+	assert.Equal(t, newer, merged.Generated)
+	assert.Len(t, merged.ResultSet.Results, 3)
+	assert.Same(t, fail, broken.Rule)
+	assert.Len(t, merged.Rules, 2)
+
+	assert.Equal(t, 12, merged.Statistics.Paths)
+	assert.Equal(t, 1, merged.Statistics.TotalErrors)
+	assert.Equal(t, 2, merged.Statistics.TotalWarnings)
+	assert.Equal(t, 84, merged.Statistics.OverallScore)
+	assert.Equal(t, string(model.DocumentKindOpenAPI), merged.Statistics.DocumentKind)
+	assert.NotEmpty(t, merged.Statistics.CategoryStatistics)
+}
+
+func TestMergeReports_Empty(t *testing.T) {
+	merged := MergeReports(nil)
+	assert.Empty(t, merged.ResultSet.Results)
+	assert.Nil(t, merged.Rules)
+	assert.Equal(t, 100, merged.Statistics.OverallScore)
+}
@@ -0,0 +1,141 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+)
+
+// openMetricsSeverities are the severities every file has a sample for, even when there are no results.
+var openMetricsSeverities = []string{model.SeverityError, model.SeverityWarn, model.SeverityInfo, model.SeverityHint}
+
+// openMetricsFamily is a metric and its samples, rendered in the order they were added.
+type openMetricsFamily struct {
+	name    string
+	help    string
+	unit    string
+	samples []string
+}
+
+func (f *openMetricsFamily) add(value any, labels ...string) {
+	var sb strings.Builder
+	sb.WriteString(f.name)
+	if len(labels) > 0 {
+		sb.WriteByte('{')
+		for i := 0; i+1 < len(labels); i += 2 {
+			if i > 0 {
+				sb.WriteByte(',')
+			}
+			sb.WriteString(fmt.Sprintf("%s=\"%s\"", labels[i], escapeOpenMetricsLabel(labels[i+1])))
+		}
+		sb.WriteByte('}')
+	}
+	sb.WriteString(fmt.Sprintf(" %v", value))
+	f.samples = append(f.samples, sb.String())
+}
+
+func (f *openMetricsFamily) render(sb *strings.Builder) {
+	sb.WriteString(fmt.Sprintf("# TYPE %s gauge\n", f.name))
+	if f.unit != "" {
+		sb.WriteString(fmt.Sprintf("# UNIT %s %s\n", f.name, f.unit))
+	}
+	sb.WriteString(fmt.Sprintf("# HELP %s %s\n", f.name, f.help))
+	for _, s := range f.samples {
+		sb.WriteString(s)
+		sb.WriteByte('\n')
+	}
+}
+
+// BuildOpenMetricsReportForFiles renders lint metrics for every file in the OpenMetrics text format, for the
+// Prometheus Pushgateway or the textfile collector of node_exporter. Every metric is a gauge labelled by file: the
+// results by severity, and by rule (with its category), the quality score (overall and by category) and the size
+// of the specification. The duration is how long linting every file took. Files that failed to lint are nil and
+// are skipped.
+func BuildOpenMetricsReportForFiles(files []*FileReport, duration time.Duration, version string) []byte {
+	info := &openMetricsFamily{name: "vacuum_build_info", help: "The version of vacuum that linted the specifications."}
+	filesLinted := &openMetricsFamily{name: "vacuum_files_linted", help: "The number of specifications linted."}
+	lintDuration := &openMetricsFamily{name: "vacuum_lint_duration_seconds", unit: "seconds",
+		help: "How long linting every specification took."}
+	results := &openMetricsFamily{name: "vacuum_results", help: "The number of results for a specification, by severity."}
+	ruleResults := &openMetricsFamily{name: "vacuum_rule_results",
+		help: "The number of results for a specification, by rule."}
+	score := &openMetricsFamily{name: "vacuum_score", help: "The quality score of a specification, out of 100."}
+	categoryScore := &openMetricsFamily{name: "vacuum_category_score",
+		help: "The quality score of a specification for a category of rules, out of 100."}
+	size := &openMetricsFamily{name: "vacuum_spec_size_bytes", unit: "bytes",
+		help: "The size of a specification."}
+
+	info.add(1, "version", version)
+	lintDuration.add(duration.Seconds())
+	linted := 0
+	for _, f := range files {
+		if f == nil || f.ResultSet == nil {
+			continue
+		}
+		linted++
+		counts := make(map[string]int)
+		type ruleKey struct{ rule, category, severity string }
+		rules := make(map[ruleKey]int)
+		for _, r := range f.ResultSet.Results {
+			if r.Rule == nil {
+				continue
+			}
+			counts[r.Rule.Severity]++
+			category := ""
+			if r.Rule.RuleCategory != nil {
+				category = r.Rule.RuleCategory.Id
+			}
+			rules[ruleKey{r.Rule.Id, category, r.Rule.Severity}]++
+		}
+		for _, s := range openMetricsSeverities {
+			results.add(counts[s], "file", f.FileName, "severity", s)
+		}
+		keys := make([]ruleKey, 0, len(rules))
+		for k := range rules {
+			keys = append(keys, k)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].rule != keys[j].rule {
+				return keys[i].rule < keys[j].rule
+			}
+			return keys[i].severity < keys[j].severity
+		})
+		for _, k := range keys {
+			ruleResults.add(rules[k], "file", f.FileName, "rule", k.rule, "category", k.category, "severity", k.severity)
+		}
+
+		specSize := len(f.Spec)
+		if f.Statistics != nil {
+			score.add(f.Statistics.OverallScore, "file", f.FileName)
+			for _, c := range f.Statistics.CategoryStatistics {
+				categoryScore.add(c.Score, "file", f.FileName, "category", c.CategoryId)
+			}
+			if f.Statistics.FilesizeBytes > 0 {
+				specSize = f.Statistics.FilesizeBytes
+			}
+		}
+		if specSize > 0 {
+			size.add(specSize, "file", f.FileName)
+		}
+	}
+	filesLinted.add(linted)
+
+	var sb strings.Builder
+	for _, family := range []*openMetricsFamily{info, filesLinted, lintDuration, results, ruleResults, score,
+		categoryScore, size} {
+		family.render(&sb)
+	}
+	sb.WriteString("# EOF\n")
+	return []byte(sb.String())
+}
+
+// escapeOpenMetricsLabel escapes a label value, backslashes, quotes and line breaks are escaped.
+func escapeOpenMetricsLabel(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(value)
+}
@@ -0,0 +1,70 @@
+package vacuum_report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildOpenMetricsReportForFiles(t *testing.T) {
+	pets := buildFakeResultSet("no description", "$.info", "info-description",
+		model.SeverityWarn, model.CategoryInfo, "Info", "pets.yaml", 3)
+	more := buildFakeResultSet("no contact", "$.info", "info-contact",
+		model.SeverityWarn, model.CategoryInfo, "Info", "pets.yaml", 2)
+	pets.Results = append(pets.Results, more.Results[0], more.Results[0])
+
+	files := []*FileReport{
+		{FileName: "pets.yaml", ResultSet: pets, Statistics: &reports.ReportStatistics{
+			OverallScore:       85,
+			FilesizeBytes:      1024,
+			CategoryStatistics: []*reports.CategoryStatistic{{CategoryId: model.CategoryInfo, Score: 70}},
+		}},
+		nil, // failed to lint.
+		{FileName: `specs/"odd".yaml`, ResultSet: &model.RuleResultSet{}, Spec: []byte("openapi: 3.1.0\n")},
+	}
+	out := string(BuildOpenMetricsReportForFiles(files, 1500*time.Millisecond, "1.2.3"))
+	assert.Equal(t, `# TYPE vacuum_build_info gauge
+# HELP vacuum_build_info The version of vacuum that linted the specifications.
+vacuum_build_info{version="1.2.3"} 1
+# TYPE vacuum_files_linted gauge
+# HELP vacuum_files_linted The number of specifications linted.
+vacuum_files_linted 2
+# TYPE vacuum_lint_duration_seconds gauge
+# UNIT vacuum_lint_duration_seconds seconds
+# HELP vacuum_lint_duration_seconds How long linting every specification took.
+vacuum_lint_duration_seconds 1.5
+# TYPE vacuum_results gauge
+# HELP vacuum_results The number of results for a specification, by severity.
+vacuum_results{file="pets.yaml",severity="error"} 0
+vacuum_results{file="pets.yaml",severity="warn"} 3
+vacuum_results{file="pets.yaml",severity="info"} 0
+vacuum_results{file="pets.yaml",severity="hint"} 0
+vacuum_results{file="specs/\"odd\".yaml",severity="error"} 0
+vacuum_results{file="specs/\"odd\".yaml",severity="warn"} 0
+vacuum_results{file="specs/\"odd\".yaml",severity="info"} 0
+vacuum_results{file="specs/\"odd\".yaml",severity="hint"} 0
+# TYPE vacuum_rule_results gauge
+# HELP vacuum_rule_results The number of results for a specification, by rule.
+vacuum_rule_results{file="pets.yaml",rule="info-contact",category="information",severity="warn"} 2
+vacuum_rule_results{file="pets.yaml",rule="info-description",category="information",severity="warn"} 1
+# TYPE vacuum_score gauge
+# HELP vacuum_score The quality score of a specification, out of 100.
+vacuum_score{file="pets.yaml"} 85
+# TYPE vacuum_category_score gauge
+# HELP vacuum_category_score The quality score of a specification for a category of rules, out of 100.
+vacuum_category_score{file="pets.yaml",category="information"} 70
+# TYPE vacuum_spec_size_bytes gauge
+# UNIT vacuum_spec_size_bytes bytes
+# HELP vacuum_spec_size_bytes The size of a specification.
+vacuum_spec_size_bytes{file="pets.yaml"} 1024
+vacuum_spec_size_bytes{file="specs/\"odd\".yaml"} 15
+# EOF
+`, out)
+}
+
+func TestEscapeOpenMetricsLabel(t *testing.T) {
+	assert.Equal(t, `a\\b\"c\nd`, escapeOpenMetricsLabel("a\\b\"c\nd"))
+}
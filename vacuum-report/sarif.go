@@ -0,0 +1,414 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/statistics"
+)
+
+const (
+	SARIFVersion = "2.1.0"
+	SARIFSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// SARIFReport is the root of a SARIF 2.1.0 log file.
+type SARIFReport struct {
+	Schema  string      `json:"$schema"`
+	Version string      `json:"version"`
+	Runs    []*SARIFRun `json:"runs"`
+}
+
+// SARIFRun represents a single invocation of vacuum.
+type SARIFRun struct {
+	Tool       SARIFTool        `json:"tool"`
+	Results    []*SARIFResult   `json:"results"`
+	Taxonomies []*SARIFTaxonomy `json:"taxonomies,omitempty"`
+	Properties map[string]any   `json:"properties,omitempty"`
+}
+
+// SARIFTool describes vacuum and the rules it ran.
+type SARIFTool struct {
+	Driver SARIFDriver `json:"driver"`
+}
+
+// SARIFDriver contains the tool metadata and all the rules reported in the run.
+type SARIFDriver struct {
+	Name           string       `json:"name"`
+	InformationURI string       `json:"informationUri,omitempty"`
+	Version        string       `json:"version,omitempty"`
+	Rules          []*SARIFRule `json:"rules"`
+}
+
+// SARIFRule is the metadata for a single rule.
+type SARIFRule struct {
+	Id                   string               `json:"id"`
+	Name                 string               `json:"name,omitempty"`
+	ShortDescription     *SARIFMessage        `json:"shortDescription,omitempty"`
+	FullDescription      *SARIFMessage        `json:"fullDescription,omitempty"`
+	Help                 *SARIFMessage        `json:"help,omitempty"`
+	HelpURI              string               `json:"helpUri,omitempty"`
+	DefaultConfiguration *SARIFConfiguration  `json:"defaultConfiguration,omitempty"`
+	Relationships        []*SARIFRelationship `json:"relationships,omitempty"`
+	Properties           map[string]any       `json:"properties,omitempty"`
+}
+
+// SARIFRelationship relates a rule to a taxon (a CWE weakness, or an OWASP API Security Top 10 entry).
+type SARIFRelationship struct {
+	Target SARIFDescriptorReference `json:"target"`
+	Kinds  []string                 `json:"kinds,omitempty"`
+}
+
+// SARIFDescriptorReference references a taxon, in one of the taxonomies of the run.
+type SARIFDescriptorReference struct {
+	Id            string                      `json:"id"`
+	ToolComponent SARIFToolComponentReference `json:"toolComponent"`
+}
+
+// SARIFToolComponentReference references a taxonomy by name.
+type SARIFToolComponentReference struct {
+	Name string `json:"name"`
+}
+
+// SARIFTaxonomy is a classification of results, like CWE.
+type SARIFTaxonomy struct {
+	Name           string        `json:"name"`
+	Organization   string        `json:"organization,omitempty"`
+	InformationURI string        `json:"informationUri,omitempty"`
+	Taxa           []*SARIFTaxon `json:"taxa"`
+}
+
+// SARIFTaxon is a single entry of a taxonomy.
+type SARIFTaxon struct {
+	Id               string        `json:"id"`
+	Name             string        `json:"name,omitempty"`
+	ShortDescription *SARIFMessage `json:"shortDescription,omitempty"`
+	HelpURI          string        `json:"helpUri,omitempty"`
+}
+
+// SARIFConfiguration holds the default level of a rule.
+type SARIFConfiguration struct {
+	Level string `json:"level"`
+}
+
+// SARIFMessage is a plain text message.
+type SARIFMessage struct {
+	Text string `json:"text"`
+}
+
+// SARIFResult is a single finding.
+type SARIFResult struct {
+	RuleId     string           `json:"ruleId"`
+	RuleIndex  int              `json:"ruleIndex"`
+	Level      string           `json:"level"`
+	Message    SARIFMessage     `json:"message"`
+	Locations  []*SARIFLocation `json:"locations,omitempty"`
+	Properties map[string]any   `json:"properties,omitempty"`
+}
+
+// SARIFLocation wraps a physical location.
+type SARIFLocation struct {
+	PhysicalLocation SARIFPhysicalLocation `json:"physicalLocation"`
+}
+
+// SARIFPhysicalLocation is the file and region in which a result was found.
+type SARIFPhysicalLocation struct {
+	ArtifactLocation SARIFArtifactLocation `json:"artifactLocation"`
+	Region           *SARIFRegion          `json:"region,omitempty"`
+}
+
+// SARIFArtifactLocation is the URI of the file a result was found in.
+type SARIFArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// SARIFRegion is the line and column range of a result.
+type SARIFRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+	EndLine     int `json:"endLine,omitempty"`
+	EndColumn   int `json:"endColumn,omitempty"`
+}
+
+// GetSARIFLevel converts a vacuum severity into a SARIF level.
+func GetSARIFLevel(severity string) string {
+	switch severity {
+	case model.SeverityError:
+		return "error"
+	case model.SeverityInfo, model.SeverityHint:
+		return "note"
+	}
+	return "warning"
+}
+
+// BuildSARIFReport will convert a RuleResultSet into a SARIF 2.1.0 log, ready to be uploaded to GitHub code scanning
+// or Azure DevOps. The fileName is used as the artifact location for any result that has no origin.
+func BuildSARIFReport(resultSet *model.RuleResultSet, fileName string, version string) []byte {
+	return BuildSARIFReportForFiles(singleFileReport(resultSet, fileName), version)
+}
+
+// BuildSARIFReportForFiles will convert the results of a multi-file run into a single SARIF 2.1.0 log, with a
+// single run containing the results of every file.
+func BuildSARIFReportForFiles(files []*FileReport, version string) []byte {
+
+	fileResults := collectFileResults(files)
+	ruleIndex := make(map[string]int)
+	var rules []*SARIFRule
+	var results []*SARIFResult
+
+	// rules are indexed in a stable order, so SARIF diffs between runs are clean.
+	var ruleIds []string
+	seen := make(map[string]*model.Rule)
+	for _, fr := range fileResults {
+		r := fr.result
+		if _, ok := seen[r.Rule.Id]; !ok {
+			seen[r.Rule.Id] = r.Rule
+			ruleIds = append(ruleIds, r.Rule.Id)
+		}
+	}
+	sort.Strings(ruleIds)
+	for i, id := range ruleIds {
+		rule := seen[id]
+		ruleIndex[id] = i
+		sr := &SARIFRule{
+			Id:      rule.Id,
+			Name:    rule.Name,
+			HelpURI: rule.GetDocumentationURL(),
+			DefaultConfiguration: &SARIFConfiguration{
+				Level: GetSARIFLevel(rule.Severity),
+			},
+		}
+		if rule.Description != "" {
+			sr.ShortDescription = &SARIFMessage{Text: rule.Description}
+			sr.FullDescription = &SARIFMessage{Text: rule.Description}
+		}
+		if rule.HowToFix != "" {
+			sr.Help = &SARIFMessage{Text: rule.HowToFix}
+		}
+		if rule.RuleCategory != nil {
+			sr.Properties = map[string]any{"category": rule.RuleCategory.Name}
+		}
+		addSARIFSecurityMapping(sr, rule)
+		rules = append(rules, sr)
+	}
+
+	for _, fr := range fileResults {
+		r := fr.result
+		res := &SARIFResult{
+			RuleId:    r.Rule.Id,
+			RuleIndex: ruleIndex[r.Rule.Id],
+			Level:     GetSARIFLevel(r.Rule.Severity),
+			Message:   SARIFMessage{Text: r.Message},
+			Locations: []*SARIFLocation{
+				{
+					PhysicalLocation: SARIFPhysicalLocation{
+						ArtifactLocation: SARIFArtifactLocation{URI: filepath.ToSlash(resultLocation(r, fr.fileName))},
+						Region:           buildSARIFRegion(r),
+					},
+				},
+			},
+		}
+		if r.Path != "" {
+			res.Properties = map[string]any{"path": r.Path}
+		}
+		results = append(results, res)
+	}
+
+	run := &SARIFRun{Taxonomies: buildSARIFTaxonomies(ruleIds, seen)}
+	resultPointers := make([]*model.RuleFunctionResult, len(fileResults))
+	for i := range fileResults {
+		resultPointers[i] = fileResults[i].result
+	}
+	if summary := statistics.CreateSecuritySummary(resultPointers); summary != nil {
+		run.Properties = map[string]any{"securitySummary": summary}
+	}
+
+	if rules == nil {
+		rules = []*SARIFRule{}
+	}
+	if results == nil {
+		results = []*SARIFResult{}
+	}
+
+	report := &SARIFReport{
+		Schema:  SARIFSchema,
+		Version: SARIFVersion,
+		Runs:    []*SARIFRun{run},
+	}
+	run.Tool = SARIFTool{
+		Driver: SARIFDriver{
+			Name:           "vacuum",
+			InformationURI: model.WebsiteUrl,
+			Version:        version,
+			Rules:          rules,
+		},
+	}
+	run.Results = results
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return []byte{}
+	}
+	return data
+}
+
+// Names of the taxonomies security rules map to.
+const (
+	SARIFTaxonomyCWE   = "CWE"
+	SARIFTaxonomyOWASP = "OWASP API Security Top 10"
+)
+
+// addSARIFSecurityMapping relates a rule to the CWE weaknesses and OWASP API Security Top 10 entries it maps to, and
+// tags it the way code scanning tools (like GitHub) group security results.
+func addSARIFSecurityMapping(sr *SARIFRule, rule *model.Rule) {
+	if !rule.HasSecurityMapping() {
+		return
+	}
+	if sr.Properties == nil {
+		sr.Properties = make(map[string]any)
+	}
+	tags := []string{"security"}
+	for _, id := range rule.CWE {
+		sr.Relationships = append(sr.Relationships, &SARIFRelationship{
+			Target: SARIFDescriptorReference{Id: id, ToolComponent: SARIFToolComponentReference{Name: SARIFTaxonomyCWE}},
+			Kinds:  []string{"superset"},
+		})
+		tags = append(tags, "external/cwe/"+strings.ToLower(id))
+	}
+	for _, id := range rule.OWASP {
+		sr.Relationships = append(sr.Relationships, &SARIFRelationship{
+			Target: SARIFDescriptorReference{Id: id, ToolComponent: SARIFToolComponentReference{Name: SARIFTaxonomyOWASP}},
+			Kinds:  []string{"superset"},
+		})
+		tags = append(tags, "external/owasp/"+strings.ToLower(id))
+	}
+	sr.Properties["tags"] = tags
+	if len(rule.CWE) > 0 {
+		sr.Properties["cwe"] = rule.CWE
+	}
+	if len(rule.OWASP) > 0 {
+		sr.Properties["owasp"] = rule.OWASP
+	}
+}
+
+// buildSARIFTaxonomies lists every CWE weakness and OWASP API Security Top 10 entry the rules of a run map to.
+func buildSARIFTaxonomies(ruleIds []string, rules map[string]*model.Rule) []*SARIFTaxonomy {
+	cwe := make(map[string]bool)
+	owasp := make(map[string]bool)
+	for _, id := range ruleIds {
+		for _, c := range rules[id].CWE {
+			cwe[c] = true
+		}
+		for _, o := range rules[id].OWASP {
+			owasp[o] = true
+		}
+	}
+	var taxonomies []*SARIFTaxonomy
+	if len(cwe) > 0 {
+		taxonomy := &SARIFTaxonomy{Name: SARIFTaxonomyCWE, Organization: "MITRE", InformationURI: "https://cwe.mitre.org/"}
+		for _, id := range sortedKeys(cwe) {
+			taxon := &SARIFTaxon{Id: id, HelpURI: fmt.Sprintf("https://cwe.mitre.org/data/definitions/%s.html",
+				strings.TrimPrefix(id, "CWE-"))}
+			if name := model.CWENames[id]; name != "" {
+				taxon.ShortDescription = &SARIFMessage{Text: name}
+			}
+			taxonomy.Taxa = append(taxonomy.Taxa, taxon)
+		}
+		taxonomies = append(taxonomies, taxonomy)
+	}
+	if len(owasp) > 0 {
+		taxonomy := &SARIFTaxonomy{Name: SARIFTaxonomyOWASP, Organization: "OWASP",
+			InformationURI: "https://owasp.org/API-Security/"}
+		for _, id := range sortedKeys(owasp) {
+			taxon := &SARIFTaxon{Id: id}
+			if name := model.OWASPAPITop10[id]; name != "" {
+				taxon.ShortDescription = &SARIFMessage{Text: name}
+			}
+			taxonomy.Taxa = append(taxonomy.Taxa, taxon)
+		}
+		taxonomies = append(taxonomies, taxonomy)
+	}
+	return taxonomies
+}
+
+// sortedKeys sorts taxon ids by their number, so CWE-20 comes before CWE-190, and API2:2023 before API10:2023.
+func sortedKeys(m map[string]bool) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ni, nj := taxonNumber(keys[i]), taxonNumber(keys[j])
+		if ni != nj {
+			return ni < nj
+		}
+		return keys[i] < keys[j]
+	})
+	return keys
+}
+
+// taxonNumber returns the first number in a taxon id.
+func taxonNumber(id string) int {
+	n, found := 0, false
+	for _, c := range id {
+		if c >= '0' && c <= '9' {
+			n, found = n*10+int(c-'0'), true
+		} else if found {
+			break
+		}
+	}
+	return n
+}
+
+func buildSARIFRegion(r *model.RuleFunctionResult) *SARIFRegion {
+	region := &SARIFRegion{StartLine: 1}
+	switch {
+	case r.Origin != nil && r.Origin.Line > 0:
+		region.StartLine = r.Origin.Line
+		region.StartColumn = r.Origin.Column
+	case r.StartNode != nil && r.StartNode.Line > 0:
+		region.StartLine = r.StartNode.Line
+		region.StartColumn = r.StartNode.Column
+	}
+	// an origin the start node is not at says nothing about where the result ends.
+	if r.Origin != nil && r.Origin.Line > 0 && (r.StartNode == nil || r.StartNode.Line != r.Origin.Line) {
+		return region
+	}
+	if r.EndNode != nil && (r.EndNode.Line > region.StartLine ||
+		(r.EndNode.Line == region.StartLine && r.EndNode.Column > region.StartColumn)) {
+		region.EndLine = r.EndNode.Line
+		region.EndColumn = r.EndNode.Column
+	}
+	return region
+}
+
+// resultLocation returns the file a result belongs to, relative to the working directory if possible.
+func resultLocation(r *model.RuleFunctionResult, fileName string) string {
+	f := fileName
+	if r.Origin != nil && r.Origin.AbsoluteLocation != "" {
+		f = r.Origin.AbsoluteLocation
+	}
+	if f == "" {
+		// nothing to go on, the specification was most likely read from stdin.
+		return "stdin"
+	}
+	if strings.HasPrefix(f, "http://") || strings.HasPrefix(f, "https://") {
+		return f
+	}
+	if absPath, err := filepath.Abs(f); err == nil {
+		if cwd, err := os.Getwd(); err == nil {
+			if relPath, err := filepath.Rel(cwd, absPath); err == nil {
+				return relPath
+			}
+		}
+	}
+	return f
+}
@@ -0,0 +1,161 @@
+package vacuum_report
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/pb33f/libopenapi/index"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuildSARIFReport(t *testing.T) {
+	rs := buildFakeResultSet("no description", "$.info", "info-description",
+		model.SeverityWarn, model.CategoryInfo, "Info", "spec.yaml", 10)
+	rs.Results[0].Rule.Description = "info must have a description"
+	rs.Results[0].Rule.HowToFix = "add a description"
+
+	data := BuildSARIFReport(rs, "spec.yaml", "1.0.0")
+
+	var report SARIFReport
+	assert.NoError(t, json.Unmarshal(data, &report))
+	assert.Equal(t, SARIFVersion, report.Version)
+	assert.Len(t, report.Runs, 1)
+
+	run := report.Runs[0]
+	assert.Equal(t, "vacuum", run.Tool.Driver.Name)
+	assert.Equal(t, "1.0.0", run.Tool.Driver.Version)
+	assert.Len(t, run.Tool.Driver.Rules, 1)
+	assert.Equal(t, "info-description", run.Tool.Driver.Rules[0].Id)
+	assert.Equal(t, "add a description", run.Tool.Driver.Rules[0].Help.Text)
+
+	assert.Len(t, run.Results, 1)
+	res := run.Results[0]
+	assert.Equal(t, "warning", res.Level)
+	assert.Equal(t, 0, res.RuleIndex)
+	assert.Equal(t, "no description", res.Message.Text)
+	assert.Equal(t, "spec.yaml", res.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, 10, res.Locations[0].PhysicalLocation.Region.StartLine)
+}
+
+func TestBuildSARIFReport_StableRuleIndex(t *testing.T) {
+	rs := buildFakeResultSet("one", "$.a", "zebra-rule", model.SeverityError, model.CategoryInfo, "Info", "spec.yaml", 1)
+	other := buildFakeResultSet("two", "$.b", "alpha-rule", model.SeverityHint, model.CategoryInfo, "Info", "spec.yaml", 2)
+	rs.Results = append(rs.Results, other.Results...)
+
+	var report SARIFReport
+	assert.NoError(t, json.Unmarshal(BuildSARIFReport(rs, "spec.yaml", "1.0.0"), &report))
+	run := report.Runs[0]
+	assert.Equal(t, "alpha-rule", run.Tool.Driver.Rules[0].Id)
+	assert.Equal(t, "zebra-rule", run.Tool.Driver.Rules[1].Id)
+	assert.Equal(t, 1, run.Results[0].RuleIndex)
+	assert.Equal(t, "error", run.Results[0].Level)
+	assert.Equal(t, 0, run.Results[1].RuleIndex)
+	assert.Equal(t, "note", run.Results[1].Level)
+}
+
+func TestBuildSARIFReport_Region(t *testing.T) {
+	r := &model.RuleFunctionResult{
+		StartNode: &yaml.Node{Line: 5, Column: 3},
+		EndNode:   &yaml.Node{Line: 7, Column: 9},
+	}
+	region := buildSARIFRegion(r)
+	assert.Equal(t, 5, region.StartLine)
+	assert.Equal(t, 3, region.StartColumn)
+	assert.Equal(t, 7, region.EndLine)
+	assert.Equal(t, 9, region.EndColumn)
+}
+
+func TestBuildSARIFReport_RegionOrigin(t *testing.T) {
+	r := &model.RuleFunctionResult{
+		StartNode: &yaml.Node{Line: 5, Column: 3},
+		EndNode:   &yaml.Node{Line: 7, Column: 9},
+		Origin:    &index.NodeOrigin{AbsoluteLocation: "/specs/pets.yaml", Line: 5, Column: 3},
+	}
+	region := buildSARIFRegion(r)
+	assert.Equal(t, 5, region.StartLine)
+	assert.Equal(t, 7, region.EndLine)
+	assert.Equal(t, 9, region.EndColumn)
+
+	// an origin somewhere else only has a start.
+	r.Origin.Line = 12
+	region = buildSARIFRegion(r)
+	assert.Equal(t, 12, region.StartLine)
+	assert.Equal(t, 0, region.EndLine)
+}
+
+func TestBuildSARIFReport_Empty(t *testing.T) {
+	var report SARIFReport
+	assert.NoError(t, json.Unmarshal(BuildSARIFReport(&model.RuleResultSet{}, "spec.yaml", ""), &report))
+	assert.NotNil(t, report.Runs[0].Results)
+	assert.Empty(t, report.Runs[0].Results)
+}
+
+func TestBuildSARIFReport_HelpURI(t *testing.T) {
+	rs := buildFakeResultSet("one", "$.a", "info-contact", model.SeverityWarn, model.CategoryInfo, "Info", "spec.yaml", 1)
+	other := buildFakeResultSet("two", "$.b", "house-style", model.SeverityWarn, model.CategoryInfo, "Info", "spec.yaml", 2)
+	other.Results[0].Rule.DocumentationURL = "https://example.com/style#house-style"
+	rs.Results = append(rs.Results, other.Results...)
+
+	var report SARIFReport
+	assert.NoError(t, json.Unmarshal(BuildSARIFReport(rs, "spec.yaml", "1.0.0"), &report))
+	rules := report.Runs[0].Tool.Driver.Rules
+	assert.Equal(t, "https://example.com/style#house-style", rules[0].HelpURI)
+	assert.Equal(t, "https://quobix.com/vacuum/rules/information/info-contact", rules[1].HelpURI)
+}
+
+func TestBuildSARIFReport_SecurityTaxonomies(t *testing.T) {
+	rs := buildFakeResultSet("basic auth", "$.components", "owasp-no-http-basic",
+		model.SeverityError, model.CategoryOWASP, "OWASP", "spec.yaml", 3)
+	rs.Results[0].Rule.CWE = []string{"CWE-522"}
+	rs.Results[0].Rule.OWASP = []string{"API2:2023"}
+	other := buildFakeResultSet("no description", "$.info", "info-description",
+		model.SeverityWarn, model.CategoryInfo, "Info", "spec.yaml", 10)
+	rs.Results = append(rs.Results, other.Results...)
+
+	var report SARIFReport
+	assert.NoError(t, json.Unmarshal(BuildSARIFReport(rs, "spec.yaml", "1.0.0"), &report))
+	run := report.Runs[0]
+
+	assert.Len(t, run.Taxonomies, 2)
+	assert.Equal(t, SARIFTaxonomyCWE, run.Taxonomies[0].Name)
+	assert.Equal(t, "CWE-522", run.Taxonomies[0].Taxa[0].Id)
+	assert.Equal(t, "https://cwe.mitre.org/data/definitions/522.html", run.Taxonomies[0].Taxa[0].HelpURI)
+	assert.Equal(t, SARIFTaxonomyOWASP, run.Taxonomies[1].Name)
+	assert.Equal(t, "Broken Authentication", run.Taxonomies[1].Taxa[0].ShortDescription.Text)
+
+	var basic, description *SARIFRule
+	for _, r := range run.Tool.Driver.Rules {
+		if r.Id == "owasp-no-http-basic" {
+			basic = r
+		} else {
+			description = r
+		}
+	}
+	assert.Len(t, basic.Relationships, 2)
+	assert.Equal(t, "CWE-522", basic.Relationships[0].Target.Id)
+	assert.Equal(t, SARIFTaxonomyCWE, basic.Relationships[0].Target.ToolComponent.Name)
+	assert.Equal(t, []any{"security", "external/cwe/cwe-522", "external/owasp/api2:2023"}, basic.Properties["tags"])
+	assert.Empty(t, description.Relationships)
+	assert.Nil(t, description.Properties["tags"])
+
+	summary := run.Properties["securitySummary"].(map[string]any)
+	assert.Equal(t, float64(1), summary["findings"])
+}
+
+func TestBuildSARIFReport_NoSecurityTaxonomies(t *testing.T) {
+	rs := buildFakeResultSet("no description", "$.info", "info-description",
+		model.SeverityWarn, model.CategoryInfo, "Info", "spec.yaml", 10)
+
+	var report SARIFReport
+	assert.NoError(t, json.Unmarshal(BuildSARIFReport(rs, "spec.yaml", "1.0.0"), &report))
+	assert.Empty(t, report.Runs[0].Taxonomies)
+	assert.Empty(t, report.Runs[0].Properties)
+}
+
+func TestSortedKeys_Taxa(t *testing.T) {
+	assert.Equal(t, []string{"CWE-20", "CWE-190"}, sortedKeys(map[string]bool{"CWE-190": true, "CWE-20": true}))
+	assert.Equal(t, []string{"API2:2023", "API10:2023"},
+		sortedKeys(map[string]bool{"API10:2023": true, "API2:2023": true}))
+}
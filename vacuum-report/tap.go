@@ -0,0 +1,105 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/daveshanley/vacuum/model"
+	"gopkg.in/yaml.v3"
+)
+
+// TAPDiagnostic is the YAML diagnostics block of a TAP test point, where a result was found and what broke.
+type TAPDiagnostic struct {
+	Message  string `yaml:"message"`
+	Severity string `yaml:"severity"`
+	Rule     string `yaml:"rule"`
+	Path     string `yaml:"path,omitempty"`
+	File     string `yaml:"file"`
+	Line     int    `yaml:"line"`
+	Column   int    `yaml:"column,omitempty"`
+}
+
+// BuildTAPReport will convert a RuleResultSet into a TAP (Test Anything Protocol) version 13 stream. The fileName
+// is used for any result that has no origin.
+func BuildTAPReport(resultSet *model.RuleResultSet, fileName string, threshold *model.FailureThreshold) []byte {
+	return BuildTAPReportForFiles(singleFileReport(resultSet, fileName), threshold)
+}
+
+// BuildTAPReportForFiles will convert the results of a multi-file run into a single TAP version 13 stream, with a
+// test point for every result. Results the threshold decides are failures are 'not ok', anything below it is 'not
+// ok' with a TODO directive, so it's reported but doesn't fail the run. A file without results is a single 'ok'
+// test point. A nil threshold is the JUnit default, errors and warnings are failures.
+func BuildTAPReportForFiles(fileReports []*FileReport, threshold *model.FailureThreshold) []byte {
+	if threshold == nil {
+		threshold = model.DefaultJUnitFailureThreshold
+	}
+	var points bytes.Buffer
+	n := 0
+	for _, f := range fileReports {
+		if f == nil {
+			continue
+		}
+		results := collectFileResults([]*FileReport{f})
+		if len(results) == 0 {
+			n++
+			location := resultLocation(&model.RuleFunctionResult{}, f.FileName) // no origin, the file itself.
+			points.WriteString(fmt.Sprintf("ok %d - %s\n", n, escapeTAPDescription(filepath.ToSlash(location))))
+			continue
+		}
+		for _, fr := range results {
+			n++
+			r := fr.result
+			line, col := 1, 0
+			if r.Origin != nil && r.Origin.Line > 0 {
+				line, col = r.Origin.Line, r.Origin.Column
+			} else if r.StartNode != nil && r.StartNode.Line > 0 {
+				line, col = r.StartNode.Line, r.StartNode.Column
+			}
+			directive := ""
+			if !threshold.IsFailure(r.Rule.Severity) {
+				directive = fmt.Sprintf(" # TODO %s", r.Rule.Severity)
+			}
+			points.WriteString(fmt.Sprintf("not ok %d - %s%s\n", n,
+				escapeTAPDescription(fmt.Sprintf("%s: %s", r.Rule.Id, r.Message)), directive))
+
+			var diagnostic bytes.Buffer
+			encoder := yaml.NewEncoder(&diagnostic)
+			encoder.SetIndent(2)
+			err := encoder.Encode(&TAPDiagnostic{
+				Message:  r.Message,
+				Severity: r.Rule.Severity,
+				Rule:     r.Rule.Id,
+				Path:     r.Path,
+				File:     filepath.ToSlash(resultLocation(r, fr.fileName)),
+				Line:     line,
+				Column:   col,
+			})
+			if err != nil || encoder.Close() != nil {
+				continue
+			}
+			points.WriteString("  ---\n")
+			for _, l := range strings.Split(strings.TrimSuffix(diagnostic.String(), "\n"), "\n") {
+				points.WriteString("  " + l + "\n")
+			}
+			points.WriteString("  ...\n")
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("TAP version 13\n")
+	buf.WriteString(fmt.Sprintf("1..%d\n", n))
+	buf.Write(points.Bytes())
+	return buf.Bytes()
+}
+
+// escapeTAPDescription keeps a description on a single line, and escapes '#' so it isn't read as a directive.
+func escapeTAPDescription(s string) string {
+	s = strings.Join(strings.Fields(s), " ")
+	s = strings.ReplaceAll(s, "\\", "\\\\")
+	return strings.ReplaceAll(s, "#", "\\#")
+}
@@ -0,0 +1,62 @@
+package vacuum_report
+
+import (
+	"testing"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildTAPReport(t *testing.T) {
+	rs := buildFakeResultSet("no description", "$.info", "info-description",
+		model.SeverityError, model.CategoryInfo, "Info", "spec.yaml", 7)
+	other := buildFakeResultSet("tag #1 has\nno description", "$.tags[0]", "tag-description",
+		model.SeverityInfo, model.CategoryTags, "Tags", "spec.yaml", 20)
+	rs.Results = append(rs.Results, other.Results...)
+
+	assert.Equal(t, `TAP version 13
+1..2
+not ok 1 - info-description: no description
+  ---
+  message: no description
+  severity: error
+  rule: info-description
+  path: $.info
+  file: spec.yaml
+  line: 7
+  ...
+not ok 2 - tag-description: tag \#1 has no description # TODO info
+  ---
+  message: |-
+    tag #1 has
+    no description
+  severity: info
+  rule: tag-description
+  path: $.tags[0]
+  file: spec.yaml
+  line: 20
+  ...
+`, string(BuildTAPReport(rs, "spec.yaml", nil)))
+}
+
+func TestBuildTAPReportForFiles(t *testing.T) {
+	rs := buildFakeResultSet("no description", "$.info", "info-description",
+		model.SeverityWarn, model.CategoryInfo, "Info", "", 7)
+	files := []*FileReport{
+		{FileName: "clean.yaml", ResultSet: &model.RuleResultSet{}},
+		nil,
+		{FileName: "spec.yaml", ResultSet: rs},
+	}
+
+	// warnings are only failures when the threshold says so.
+	data := string(BuildTAPReportForFiles(files, model.NewFailureThreshold(model.SeverityError, -1)))
+	assert.Contains(t, data, "1..2\nok 1 - clean.yaml\nnot ok 2 - info-description: no description # TODO warn\n")
+	assert.Contains(t, data, "  file: spec.yaml\n")
+
+	data = string(BuildTAPReportForFiles(files, nil))
+	assert.Contains(t, data, "not ok 2 - info-description: no description\n")
+}
+
+func TestBuildTAPReport_Empty(t *testing.T) {
+	assert.Equal(t, "TAP version 13\n1..0\n", string(BuildTAPReportForFiles(nil, nil)))
+}
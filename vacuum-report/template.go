@@ -0,0 +1,185 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/pb33f/libopenapi/utils"
+)
+
+// TemplateContext is what a report template is executed with: every file linted, and the totals across them.
+//
+//	{{ range .Files }}h2. {{ .FileName }} ({{ .Score }}/100)
+//	{{ range .Results }}* {{ .Severity }}: {{ .Message }} ({{ .RuleId }}, line {{ .Line }})
+//	{{ end }}{{ end }}
+type TemplateContext struct {
+	Version   string          // the version of vacuum.
+	Generated time.Time       // when the report was rendered.
+	Files     []*TemplateFile // every file linted, in the order they were given.
+	Results   []*TemplateResult
+	Errors    int
+	Warnings  int
+	Info      int
+	Hints     int
+}
+
+// TemplateFile is a file linted, with its results and statistics. Statistics and ResultSet are the same models the
+// JSON report is made of, for anything the flattened results don't have.
+type TemplateFile struct {
+	FileName   string
+	Score      int // the quality score of the file, out of 100 (zero without statistics).
+	Results    []*TemplateResult
+	Errors     int
+	Warnings   int
+	Info       int
+	Hints      int
+	Statistics *reports.ReportStatistics
+	ResultSet  *model.RuleResultSet
+}
+
+// TemplateResult is a result, flattened so templates don't have to look into the rule. Lines and columns start at
+// 1, the end is just after the last character of the result. Snippet and CodeFrame (the lines around the result,
+// with line numbers and the result marked) are only set when the specification is known.
+type TemplateResult struct {
+	File             string
+	Line             int
+	Column           int
+	EndLine          int
+	EndColumn        int
+	Path             string
+	RuleId           string
+	Severity         string
+	Category         string
+	Message          string
+	Description      string
+	HowToFix         string
+	DocumentationURL string
+	Snippet          string
+	CodeFrame        string
+	Result           *model.RuleFunctionResult
+}
+
+// templateFuncs are available to report templates, on top of the built-in functions of text/template.
+var templateFuncs = template.FuncMap{
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+	"replace": strings.ReplaceAll,
+	"join":    strings.Join,
+	"trim":    strings.TrimSpace,
+	"add":     func(a, b int) int { return a + b },
+	"json": func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		return string(b), err
+	},
+}
+
+// ParseReportTemplate parses a report template and renders it once with sample data, so templates that refer to
+// unknown fields are rejected up front, rather than failing once linting is done.
+func ParseReportTemplate(text string) (*template.Template, error) {
+	tmpl, err := template.New("report").Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	sample := &TemplateResult{Result: &model.RuleFunctionResult{}}
+	ctx := &TemplateContext{
+		Files:   []*TemplateFile{{Results: []*TemplateResult{sample}, ResultSet: &model.RuleResultSet{}}},
+		Results: []*TemplateResult{sample},
+	}
+	if err = tmpl.Execute(io.Discard, ctx); err != nil {
+		return nil, err
+	}
+	return tmpl, nil
+}
+
+// BuildTemplateContext builds the context a report template is executed with, from every file linted. Files that
+// failed to lint are nil and are skipped.
+func BuildTemplateContext(files []*FileReport, generated time.Time, version string) *TemplateContext {
+	ctx := &TemplateContext{Version: version, Generated: generated, Files: []*TemplateFile{}}
+	for _, f := range files {
+		if f == nil || f.ResultSet == nil {
+			continue
+		}
+		tf := &TemplateFile{FileName: f.FileName, Statistics: f.Statistics, ResultSet: f.ResultSet}
+		if f.Statistics != nil {
+			tf.Score = f.Statistics.OverallScore
+		}
+		var specLines []string
+		if len(f.Spec) > 0 {
+			specLines = strings.Split(string(f.Spec), "\n")
+		}
+		for _, r := range f.ResultSet.Results {
+			if r.Rule == nil {
+				continue
+			}
+			tr := buildTemplateResult(r, f.FileName, specLines)
+			tf.Results = append(tf.Results, tr)
+			switch tr.Severity {
+			case model.SeverityError:
+				tf.Errors++
+			case model.SeverityWarn:
+				tf.Warnings++
+			case model.SeverityInfo:
+				tf.Info++
+			case model.SeverityHint:
+				tf.Hints++
+			}
+		}
+		ctx.Files = append(ctx.Files, tf)
+		ctx.Results = append(ctx.Results, tf.Results...)
+		ctx.Errors += tf.Errors
+		ctx.Warnings += tf.Warnings
+		ctx.Info += tf.Info
+		ctx.Hints += tf.Hints
+	}
+	return ctx
+}
+
+func buildTemplateResult(r *model.RuleFunctionResult, fileName string, specLines []string) *TemplateResult {
+	tr := &TemplateResult{
+		File:             resultLocation(r, fileName),
+		Path:             r.Path,
+		RuleId:           r.Rule.Id,
+		Severity:         r.Rule.Severity,
+		Message:          r.Message,
+		Description:      r.Rule.Description,
+		HowToFix:         r.Rule.HowToFix,
+		DocumentationURL: r.Rule.GetDocumentationURL(),
+		Result:           r,
+	}
+	if r.Rule.RuleCategory != nil {
+		tr.Category = r.Rule.RuleCategory.Id
+	}
+	region := buildSARIFRegion(r)
+	tr.Line, tr.Column = region.StartLine, region.StartColumn
+	tr.EndLine, tr.EndColumn = region.EndLine, region.EndColumn
+	if tr.EndLine == 0 {
+		tr.EndLine, tr.EndColumn = tr.Line, tr.Column
+	}
+	if specLines != nil && r.StartNode != nil && r.Origin == nil {
+		tr.Snippet = utils.RenderCodeSnippet(r.StartNode, specLines, 3, 3)
+	}
+	tr.CodeFrame = model.CodeFrameForResult(r, fileName, specLines, model.DefaultCodeFrameOptions).String()
+	return tr
+}
+
+// BuildTemplateReportForFiles renders every file linted with a report template, see TemplateContext.
+func BuildTemplateReportForFiles(text string, files []*FileReport, generated time.Time, version string) ([]byte, error) {
+	tmpl, err := ParseReportTemplate(text)
+	if err != nil {
+		return nil, err
+	}
+	var buf bytes.Buffer
+	if err = tmpl.Execute(&buf, BuildTemplateContext(files, generated, version)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
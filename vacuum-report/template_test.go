@@ -0,0 +1,63 @@
+package vacuum_report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+func TestBuildTemplateReportForFiles(t *testing.T) {
+	pets := buildFakeResultSet("no description", "$.info", "info-description",
+		model.SeverityWarn, model.CategoryInfo, "Info", "pets.yaml", 3)
+	pets.Results[0].EndNode = &yaml.Node{Line: 5, Column: 10}
+	burgers := buildFakeResultSet("bad operationId", "$.paths['/burgers'].get", "operation-operationId",
+		model.SeverityError, model.CategoryOperations, "Operations", "burgers.yaml", 9)
+
+	files := []*FileReport{
+		{FileName: "pets.yaml", ResultSet: pets, Statistics: &reports.ReportStatistics{OverallScore: 90}},
+		nil, // failed to lint.
+		{FileName: "burgers.yaml", ResultSet: burgers},
+	}
+	text := `{{ .Version }}: {{ .Errors }} errors, {{ .Warnings }} warnings
+{{ range .Files }}h2. {{ .FileName }} ({{ .Score }}/100)
+{{ range .Results }}* {{ upper .Severity }} {{ .RuleId }} {{ .Line }}-{{ .EndLine }}:{{ .EndColumn }} {{ .Message | json }}
+{{ end }}{{ end }}`
+	out, err := BuildTemplateReportForFiles(text, files, time.Now(), "1.2.3")
+	assert.NoError(t, err)
+	assert.Equal(t, `1.2.3: 1 errors, 1 warnings
+h2. pets.yaml (90/100)
+* WARN info-description 3-5:10 "no description"
+h2. burgers.yaml (0/100)
+* ERROR operation-operationId 9-9:0 "bad operationId"
+`, string(out))
+}
+
+func TestBuildTemplateContext_Snippet(t *testing.T) {
+	rs := buildFakeResultSet("no description", "$.info", "info-description",
+		model.SeverityWarn, model.CategoryInfo, "Info", "pets.yaml", 2)
+	ctx := BuildTemplateContext([]*FileReport{
+		{FileName: "pets.yaml", ResultSet: rs, Spec: []byte("openapi: 3.1.0\ninfo:\n  title: pets\n")},
+	}, time.Now(), "")
+	assert.Len(t, ctx.Results, 1)
+	assert.Equal(t, model.CategoryInfo, ctx.Results[0].Category)
+	assert.Equal(t, "https://quobix.com/vacuum/rules/information/info-description", ctx.Results[0].DocumentationURL)
+	assert.Contains(t, ctx.Results[0].Snippet, "info:")
+	assert.Equal(t, "  1 | openapi: 3.1.0\n> 2 | info:\n  3 |   title: pets\n", ctx.Results[0].CodeFrame)
+}
+
+func TestParseReportTemplate(t *testing.T) {
+	_, err := ParseReportTemplate("{{ range .Files }}{{ .FileName }}{{ end }}")
+	assert.NoError(t, err)
+
+	// unknown fields are caught before anything is linted.
+	_, err = ParseReportTemplate("{{ range .Files }}{{ .Ticket }}{{ end }}")
+	assert.Error(t, err)
+	_, err = ParseReportTemplate("{{ range .Results }}{{ .Result.Nope }}{{ end }}")
+	assert.Error(t, err)
+	_, err = ParseReportTemplate("{{ .Files")
+	assert.Error(t, err)
+}
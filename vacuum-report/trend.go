@@ -0,0 +1,215 @@
+// Copyright 2025 Princess B33f Heavy Industries / Dave Shanley
+// SPDX-License-Identifier: MIT
+
+package vacuum_report
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/utils"
+)
+
+// TrendEntry is the summary of a single linting run, kept in a trend history so the quality of a specification can
+// be tracked over time.
+type TrendEntry struct {
+	Timestamp  time.Time      `json:"timestamp" yaml:"timestamp"`
+	GitSHA     string         `json:"gitSha,omitempty" yaml:"gitSha,omitempty"`
+	Label      string         `json:"label,omitempty" yaml:"label,omitempty"` // a release or version, optional.
+	Score      int            `json:"score" yaml:"score"`
+	Errors     int            `json:"errors" yaml:"errors"`
+	Warnings   int            `json:"warnings" yaml:"warnings"`
+	Info       int            `json:"info" yaml:"info"`
+	Hints      int            `json:"hints" yaml:"hints"`
+	Categories map[string]int `json:"categories,omitempty" yaml:"categories,omitempty"` // number of issues, by category id.
+}
+
+// NewTrendEntry summarizes a vacuum report as a trend entry. If the report has no statistics, they are worked out
+// from the results.
+func NewTrendEntry(report *VacuumReport, gitSHA string) *TrendEntry {
+	entry := &TrendEntry{Timestamp: time.Now(), GitSHA: gitSHA, Categories: make(map[string]int)}
+	if report == nil {
+		return entry
+	}
+	if !report.Generated.IsZero() {
+		entry.Timestamp = report.Generated
+	}
+	stats := report.Statistics
+	if stats == nil && report.ResultSet != nil {
+		stats = mergeStatistics(nil, report)
+	}
+	if stats == nil {
+		return entry
+	}
+	entry.Score = stats.OverallScore
+	entry.Errors = stats.TotalErrors
+	entry.Warnings = stats.TotalWarnings
+	entry.Info = stats.TotalInfo
+	entry.Hints = stats.TotalHints
+	for _, cat := range stats.CategoryStatistics {
+		entry.Categories[cat.CategoryId] = cat.NumIssues
+	}
+	return entry
+}
+
+// AppendTrendHistory adds an entry to the end of a trend history file, creating it if it does not exist. The history
+// is stored as JSON lines, one entry per run, so it can be appended to (and merged by version control) easily.
+func AppendTrendHistory(path string, entry *TrendEntry) error {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0664)
+	if err != nil {
+		return fmt.Errorf("unable to open trend history '%s': %w", path, err)
+	}
+	defer f.Close()
+	_, err = f.Write(append(data, '\n'))
+	return err
+}
+
+// ReadTrendHistory reads every entry from a trend history file, in the order they were recorded. A history that
+// does not exist yet is empty.
+func ReadTrendHistory(path string) ([]*TrendEntry, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return []*TrendEntry{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read trend history '%s': %w", path, err)
+	}
+	entries := []*TrendEntry{}
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		var entry TrendEntry
+		if err = json.Unmarshal([]byte(text), &entry); err != nil {
+			return nil, fmt.Errorf("trend history '%s' is invalid on line %d: %w", path, line, err)
+		}
+		entries = append(entries, &entry)
+	}
+	return entries, scanner.Err()
+}
+
+var sparks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline renders values as a single line of block characters, scaled between the lowest and highest value.
+func Sparkline(values []int) string {
+	if len(values) == 0 {
+		return ""
+	}
+	low, high := values[0], values[0]
+	for _, v := range values {
+		low = min(low, v)
+		high = max(high, v)
+	}
+	var sb strings.Builder
+	for _, v := range values {
+		spark := len(sparks) / 2
+		if high > low {
+			spark = (v - low) * (len(sparks) - 1) / (high - low)
+		}
+		sb.WriteRune(sparks[spark])
+	}
+	return sb.String()
+}
+
+// TrendScores returns the score of every entry, oldest first.
+func TrendScores(entries []*TrendEntry) []int {
+	scores := make([]int, len(entries))
+	for i, e := range entries {
+		scores[i] = e.Score
+	}
+	return scores
+}
+
+// BuildTrendMarkdown renders a trend history as markdown, with a sparkline of the score and a table of every run
+// (newest first), including the change in score from the run before.
+func BuildTrendMarkdown(entries []*TrendEntry) []byte {
+	var sb strings.Builder
+	sb.WriteString("## vacuum quality trend\n\n")
+	if len(entries) == 0 {
+		sb.WriteString("No runs have been recorded yet.\n")
+		return []byte(sb.String())
+	}
+	first, last := entries[0], entries[len(entries)-1]
+	sb.WriteString(fmt.Sprintf("**Score: %d/100** (%s since %s) `%s`\n\n", last.Score,
+		TrendDelta(last.Score-first.Score), first.Timestamp.Format(time.DateOnly), Sparkline(TrendScores(entries))))
+
+	var rows [][]string
+	for i := len(entries) - 1; i >= 0; i-- {
+		e := entries[i]
+		delta := ""
+		if i > 0 {
+			delta = TrendDelta(e.Score - entries[i-1].Score)
+		}
+		sha := e.GitSHA
+		if len(sha) > 7 {
+			sha = sha[:7]
+		}
+		if sha != "" {
+			sha = fmt.Sprintf("`%s`", sha)
+		}
+		rows = append(rows, []string{e.Timestamp.Format(time.DateTime), sha, escapeMarkdownCell(e.Label),
+			fmt.Sprint(e.Score), delta, fmt.Sprint(e.Errors), fmt.Sprint(e.Warnings), fmt.Sprint(e.Info)})
+	}
+	sb.WriteString(utils.RenderMarkdownTable(
+		[]string{"Date", "Commit", "Label", "Score", "Change", "Errors", "Warnings", "Info"}, rows))
+	return []byte(sb.String())
+}
+
+// TrendDelta renders a change in score, with a sign.
+func TrendDelta(delta int) string {
+	if delta > 0 {
+		return fmt.Sprintf("+%d", delta)
+	}
+	return fmt.Sprint(delta)
+}
+
+// CategoryTrend returns the number of issues for a category over every entry, oldest first.
+func CategoryTrend(entries []*TrendEntry, category string) []int {
+	values := make([]int, len(entries))
+	for i, e := range entries {
+		values[i] = e.Categories[category]
+	}
+	return values
+}
+
+// TrendCategories returns the ids of every category that has had issues in the history, in the usual order.
+func TrendCategories(entries []*TrendEntry) []string {
+	seen := make(map[string]bool)
+	for _, e := range entries {
+		for id, n := range e.Categories {
+			if n > 0 {
+				seen[id] = true
+			}
+		}
+	}
+	var ids, others []string
+	for _, cat := range model.RuleCategoriesOrdered {
+		if seen[cat.Id] {
+			ids = append(ids, cat.Id)
+			delete(seen, cat.Id)
+		}
+	}
+	// categories of a ruleset follow the built-in ones.
+	for id := range seen {
+		others = append(others, id)
+	}
+	sort.Strings(others)
+	return append(ids, others...)
+}
@@ -0,0 +1,87 @@
+package vacuum_report
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/daveshanley/vacuum/model"
+	"github.com/daveshanley/vacuum/model/reports"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTrendEntry(t *testing.T) {
+	generated := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	entry := NewTrendEntry(&VacuumReport{
+		Generated: generated,
+		Statistics: &reports.ReportStatistics{
+			OverallScore:  80,
+			TotalErrors:   1,
+			TotalWarnings: 2,
+			CategoryStatistics: []*reports.CategoryStatistic{
+				{CategoryId: model.CategorySchemas, NumIssues: 3},
+			},
+		},
+	}, "abc123")
+	assert.Equal(t, generated, entry.Timestamp)
+	assert.Equal(t, "abc123", entry.GitSHA)
+	assert.Equal(t, 80, entry.Score)
+	assert.Equal(t, 1, entry.Errors)
+	assert.Equal(t, 2, entry.Warnings)
+	assert.Equal(t, 3, entry.Categories[model.CategorySchemas])
+
+	// no statistics, they are worked out from the results.
+	rule := &model.Rule{Id: "a", Severity: model.SeverityError, RuleCategory: model.RuleCategories[model.CategoryInfo]}
+	entry = NewTrendEntry(&VacuumReport{ResultSet: &model.RuleResultSet{
+		Results: []*model.RuleFunctionResult{{RuleId: "a", Rule: rule}},
+	}}, "")
+	assert.Equal(t, 85, entry.Score)
+	assert.Equal(t, 1, entry.Errors)
+	assert.Equal(t, 1, entry.Categories[model.CategoryInfo])
+}
+
+func TestTrendHistory(t *testing.T) {
+	history := filepath.Join(t.TempDir(), "trend.jsonl")
+
+	entries, err := ReadTrendHistory(history)
+	assert.NoError(t, err)
+	assert.Empty(t, entries)
+
+	assert.NoError(t, AppendTrendHistory(history, &TrendEntry{Score: 50, GitSHA: "one"}))
+	assert.NoError(t, AppendTrendHistory(history, &TrendEntry{Score: 75, GitSHA: "two"}))
+	entries, err = ReadTrendHistory(history)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "two", entries[1].GitSHA)
+	assert.Equal(t, []int{50, 75}, TrendScores(entries))
+
+	assert.NoError(t, os.WriteFile(history, []byte("{\"score\": 1}\nnope\n"), 0664))
+	_, err = ReadTrendHistory(history)
+	assert.ErrorContains(t, err, "invalid on line 2")
+}
+
+func TestSparkline(t *testing.T) {
+	assert.Equal(t, "▁▄█", Sparkline([]int{10, 55, 100}))
+	assert.Equal(t, "▅▅", Sparkline([]int{70, 70}))
+	assert.Empty(t, Sparkline(nil))
+}
+
+func TestBuildTrendMarkdown(t *testing.T) {
+	entries := []*TrendEntry{
+		{Timestamp: time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC), Score: 60, GitSHA: "1234567890",
+			Categories: map[string]int{model.CategorySchemas: 4}},
+		{Timestamp: time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC), Score: 90, Label: "v2",
+			Categories: map[string]int{model.CategorySchemas: 1}},
+	}
+	md := string(BuildTrendMarkdown(entries))
+	assert.Contains(t, md, "**Score: 90/100** (+30 since 2025-01-01)")
+	assert.Contains(t, md, "`1234567`")
+	assert.Less(t, strings.Index(md, "v2"), strings.Index(md, "1234567"))
+	assert.Contains(t, string(BuildTrendMarkdown(nil)), "No runs have been recorded yet")
+
+	assert.Equal(t, []string{model.CategorySchemas}, TrendCategories(entries))
+	assert.Equal(t, []int{4, 1}, CategoryTrend(entries, model.CategorySchemas))
+	assert.Equal(t, "-3", TrendDelta(-3))
+}
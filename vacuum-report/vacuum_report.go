@@ -1,8 +1,6 @@
 package vacuum_report
 
 import (
-	"bytes"
-	"compress/gzip"
 	"github.com/daveshanley/vacuum/model"
 	"github.com/daveshanley/vacuum/model/reports"
 	"github.com/daveshanley/vacuum/motor"
@@ -10,7 +8,6 @@ import (
 	jsoniter "github.com/json-iterator/go"
 	"github.com/pb33f/libopenapi/datamodel"
 	"gopkg.in/yaml.v3"
-	"io"
 	"os"
 	"sync"
 	"time"
@@ -89,30 +86,19 @@ func BuildVacuumReportFromFile(filePath string) (*VacuumReport, []byte, error) {
 }
 
 // CheckFileForVacuumReport will try to extract a vacuum report from a byte array. It checks if the
-// file is compressed or not, then if it can be marshalled into a report.
+// file is compressed or not (gzip, zstd or brotli), then if it can be marshalled into a report.
 func CheckFileForVacuumReport(data []byte) (*VacuumReport, error) {
 	var jsonParse = jsoniter.ConfigCompatibleWithStandardLibrary
-	r := bytes.NewReader(data)
-	gzipRead, rerr := gzip.NewReader(r)
 	var vr VacuumReport
 
-	if rerr != nil {
-		// not compressed? try unmarshal it.
-		if jerr := jsonParse.Unmarshal(data, &vr); jerr != nil {
-			return nil, jerr
-		}
-
-	} else {
-		// ok so the file is gzipped, however, it may still not be a report.
-		// run through all the checks as we would normally.
-		decompressed, derr := io.ReadAll(gzipRead)
-		if derr != nil {
-			return nil, derr
-		}
-		if jerr := jsonParse.Unmarshal(decompressed, &vr); jerr != nil {
-			return nil, jerr
-		}
-
+	// the file may be compressed, however, it may still not be a report.
+	// run through all the checks as we would normally.
+	decompressed, derr := DecompressReport(data)
+	if derr != nil {
+		return nil, derr
+	}
+	if jerr := jsonParse.Unmarshal(decompressed, &vr); jerr != nil {
+		return nil, jerr
 	}
 	if vr.ResultSet == nil {
 		return nil, nil